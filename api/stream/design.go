@@ -29,6 +29,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository/source"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -657,6 +658,24 @@ var _ = Service("stream", func() {
 		})
 	})
 
+	Method("ReimportSinkFiles", func() {
+		Meta("openapi:summary", "Re-import sink files")
+		Description("Imports already imported files of the sink again, for example after the target table has been truncated. " +
+			"Only files opened in the time range are re-imported, their staging files must be retained, see the \"storage.metadataCleanup.importedFileRetention\" configuration of the service. " +
+			"Files opened before the retention has been enabled are skipped, their staging files expire in the Keboola Storage on its own schedule. " +
+			"Optionally, the files can be re-imported to a different table, but only in the same bucket: " +
+			"the staging file is imported using the token of the sink, which is scoped to the bucket of the sink table.")
+		Result(Task)
+		Payload(ReimportSinkFilesRequest)
+		HTTP(func() {
+			POST("/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/files/reimport")
+			Meta("openapi:tag:configuration")
+			Response(StatusAccepted)
+			SourceNotFoundError()
+			SinkNotFoundError()
+		})
+	})
+
 	Method("ListSinkVersions", func() {
 		Meta("openapi:summary", "List sink versions")
 		Description("List all sink versions.")
@@ -703,6 +722,106 @@ var _ = Service("stream", func() {
 		})
 	})
 
+	Method("ListSinkDeadLetters", func() {
+		Meta("openapi:summary", "List sink dead-letter records")
+		Description("List records which could not be mapped by the sink, from the oldest. The dead-letter storage must be enabled by the \"deadLetter\" field of the sink.")
+		Result(SinkDeadLetterRecordsList)
+		Payload(ListSinkDeadLettersRequest)
+		HTTP(func() {
+			GET("/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters")
+			Meta("openapi:tag:configuration")
+			Param("afterId")
+			Param("limit")
+			Response(StatusOK)
+			SourceNotFoundError()
+			SinkNotFoundError()
+		})
+	})
+
+	Method("GetSinkDeadLetter", func() {
+		Meta("openapi:summary", "Get sink dead-letter record")
+		Description("Get the record which could not be mapped by the sink, including the raw body.")
+		Result(SinkDeadLetterRecord)
+		Payload(SinkDeadLetterRequest)
+		HTTP(func() {
+			GET("/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}")
+			Meta("openapi:tag:configuration")
+			Response(StatusOK)
+			SourceNotFoundError()
+			SinkNotFoundError()
+			DeadLetterRecordNotFoundError()
+		})
+	})
+
+	Method("ReplaySinkDeadLetter", func() {
+		Meta("openapi:summary", "Replay sink dead-letter record")
+		Description("Write the record to the sink again, after the mapping has been fixed. " +
+			"The record is mapped by the current mapping first, the replay is rejected, if it still fails. " +
+			"The record is deleted after a successful write, otherwise its error is updated.")
+		Payload(SinkDeadLetterRequest)
+		HTTP(func() {
+			POST("/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}/replay")
+			Meta("openapi:tag:configuration")
+			Response(StatusAccepted)
+			SourceNotFoundError()
+			SinkNotFoundError()
+			DeadLetterRecordNotFoundError()
+			InvalidColumnValueError()
+		})
+	})
+
+	Method("PurgeSinkDeadLetters", func() {
+		Meta("openapi:summary", "Purge sink dead-letter records")
+		Description("Delete all dead-letter records of the sink.")
+		Payload(GetSinkRequest)
+		HTTP(func() {
+			DELETE("/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters")
+			Meta("openapi:tag:configuration")
+			Response(StatusOK)
+			SourceNotFoundError()
+			SinkNotFoundError()
+		})
+	})
+
+	Method("PreviewSinkData", func() {
+		Meta("openapi:summary", "Preview sink data")
+		Description("Reads the last records written to the local storage of the sink, mapped to the table columns. " +
+			"The records are read from the currently open slices on disk writer nodes, so the mapping output can be checked before the import. " +
+			"The order of records is kept only within one source node.\n\n" +
+			"Only an admin token can read the records, the same as dead-letter records, because they may contain personal data. " +
+			"Values are returned as they are written to the table: the local storage is not encrypted, " +
+			"so values encrypted by the client are returned encrypted, and other values are returned in plain text.")
+		Result(SinkDataPreview)
+		Payload(PreviewSinkDataRequest)
+		HTTP(func() {
+			GET("/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/preview")
+			Meta("openapi:tag:configuration")
+			Param("limit")
+			Response(StatusOK)
+			SourceNotFoundError()
+			SinkNotFoundError()
+		})
+	})
+
+	Method("InferSinkMapping", func() {
+		Meta("openapi:summary", "Infer sink mapping")
+		Description("Proposes a table mapping from sample bodies, the result can be used as the \"mapping\" of the CreateSink payload.\n\n" +
+			"If no samples are sent, the last requests received by the source are used, " +
+			"the sampling of requests must be enabled by the \"source.sample.size\" configuration, it is disabled by default. " +
+			"The proposal contains an \"id\" column of the \"uuid\" type, intended as the primary key, a \"datetime\" column, " +
+			"and a \"template\" column with the `Body(\"path\", \"\")` expression for each value found in the samples. " +
+			"Nested objects are flattened up to the \"maxDepth\", deeper objects and arrays are mapped to one JSON column.")
+		Result(InferredSinkMapping)
+		Payload(InferSinkMappingRequest)
+		HTTP(func() {
+			POST("/branches/{branchId}/sources/{sourceId}/infer-mapping")
+			Meta("openapi:tag:configuration")
+			Response(StatusOK)
+			SourceNotFoundError()
+			NoSamplesError()
+		})
+	})
+
 	// Task endpoints --------------------------------------------------------------------------------------------------
 
 	Method("GetTask", func() {
@@ -1046,6 +1165,24 @@ var Source = Type("Source", func() {
 			"created": exampleCreated(),
 		})
 	})
+	Example("kafka_source", func() {
+		Description("Kafka source response shape.")
+		Value(Val{
+			"projectId":   1234,
+			"branchId":    5678,
+			"sourceId":    "my-kafka-source",
+			"type":        "kafka",
+			"name":        "My Kafka Source",
+			"description": "",
+			"kafka": Val{
+				"brokers": []string{"kafka-1.example.com:9092", "kafka-2.example.com:9092"},
+				"topic":   "events",
+				"groupId": "keboola-stream",
+			},
+			"version": exampleVersion(),
+			"created": exampleCreated(),
+		})
+	})
 })
 
 // exampleVersion and exampleCreated build the required version/created blocks
@@ -1086,13 +1223,21 @@ var Sources = Type("Sources", ArrayOf(Source), func() {
 
 var SourceType = Type("SourceType", String, func() {
 	Meta("struct:field:type", "= definition.SourceType", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition")
-	Enum(definition.SourceTypeHTTP.String(), definition.SourceTypeOTLP.String())
+	Enum(definition.SourceTypeHTTP.String(), definition.SourceTypeOTLP.String(), definition.SourceTypeKafka.String())
 	Example(definition.SourceTypeHTTP.String())
 })
 
 var CreateSourceRequest = Type("CreateSourceRequest", func() {
 	BranchKeyRequest()
 	SourceFields(OpCreate)
+	// The example describes an HTTP source, so it must not contain the "kafka" block, see the Source examples.
+	Example(Val{
+		"sourceId":      "github-webhook-source",
+		"type":          definition.SourceTypeHTTP.String(),
+		"name":          "Github Webhook Source",
+		"description":   "The source receives events from Github.",
+		"httpSignature": exampleHTTPSignature(),
+	})
 })
 
 var GetSourceRequest = Type("GetSourceRequest", func() {
@@ -1116,8 +1261,28 @@ var ListSourcesRequest = Type("ListSourcesRequest", func() {
 var UpdateSourceRequest = Type("UpdateSourceRequest", func() {
 	SourceKeyRequest()
 	SourceFields(OpUpdate)
+	// The example describes an HTTP source, so it must not contain the "kafka" block, see the Source examples.
+	Example(Val{
+		"changeDescription":   "Renamed.",
+		"type":                definition.SourceTypeHTTP.String(),
+		"name":                "Github Webhook Source",
+		"description":         "The source receives events from Github.",
+		"httpSignature":       exampleHTTPSignature(),
+		"removeHttpSignature": true,
+	})
 })
 
+func exampleHTTPSignature() Val {
+	return Val{
+		"algorithm":                 definition.SignatureAlgorithmHMACSHA256.String(),
+		"format":                    definition.SignatureFormatHex.String(),
+		"header":                    "X-Hub-Signature-256",
+		"secret":                    "my-webhook-secret",
+		"timestampToleranceSeconds": 300,
+		"replayWindowSeconds":       600,
+	}
+}
+
 // OTLPSignal is the named enum reused by allowedSignals on sinks and the
 // signal query param on the TestSource endpoint. Naming the type means
 // validation errors report a clean field path instead of the doubly-indexed
@@ -1141,9 +1306,38 @@ var TestResult = Type("TestResult", func() {
 	Description("Result of the test endpoint.")
 	SourceKeyResponse()
 	Attribute("tables", ArrayOf(TestResultTable), func() {
-		Description("Table for each configured sink.")
+		Description("Table for each configured sink which accepts the record.")
+	})
+	Attribute("sinks", ArrayOf(TestResultSink), func() {
+		Description("Result of the filter expression for each configured sink.")
+	})
+	Attribute("signature", TestResultSignature, func() {
+		Description("Result of the webhook signature verification, present only if the signature verification is configured.")
+	})
+	Required("tables", "sinks")
+})
+
+var TestResultSink = Type("TestResultSink", func() {
+	Description("Result of the sink filter expression, part of the test result.")
+	Attribute("sinkId", SinkID)
+	Attribute("accepted", Boolean, func() {
+		Description("False if the record would be dropped by the sink filter.")
+		Example(true)
+	})
+	Required("sinkId", "accepted")
+})
+
+var TestResultSignature = Type("TestResultSignature", func() {
+	Description("Result of the webhook signature verification, part of the test result.")
+	Attribute("valid", Boolean, func() {
+		Description("True if the signature is valid. The replay protection is not applied by the test endpoint.")
+		Example(false)
+	})
+	Attribute("message", String, func() {
+		Description("Reason why the signature is invalid.")
+		Example(`Header "X-Hub-Signature-256" is missing.`)
 	})
-	Required("tables")
+	Required("valid")
 })
 
 var TestResultTable = Type("TestResultTable", func() {
@@ -1231,6 +1425,22 @@ var SourceFields = func(op OperationType) {
 		})
 	}
 
+	// Type-specific details - writable
+	Attribute("kafka", KafkaSource, func() {
+		Description(fmt.Sprintf(`Kafka source details for "type" = "%s".`, definition.SourceTypeKafka))
+	})
+	if op == OpCreate || op == OpUpdate {
+		Attribute("httpSignature", HTTPSourceSignature, func() {
+			Description(fmt.Sprintf(`Optional webhook signature verification for "type" = "%s".`, definition.SourceTypeHTTP))
+		})
+	}
+	if op == OpUpdate {
+		Attribute("removeHttpSignature", Boolean, func() {
+			Description("Disable the webhook signature verification.")
+			Example(true)
+		})
+	}
+
 	// Required fields
 	switch op {
 	case OpRead:
@@ -1250,9 +1460,62 @@ var HTTPSource = Type("HTTPSource", func() {
 		Description("URL of the HTTP source. Contains secret used for authentication.")
 		Example("https://stream-in.keboola.com/EXAMPLE-SECRET-PLACEHOLDER-XXXXXXXXXXXXXXXXXXXXX")
 	})
+	Attribute("signature", HTTPSourceSignature, func() {
+		Description("Webhook signature verification, if it is configured. The signature secret is not returned.")
+	})
 	Required("url")
 })
 
+var HTTPSourceSignature = Type("HTTPSourceSignature", func() {
+	Description("Webhook signature verification. A request with a missing or invalid HMAC signature is rejected.")
+	Attribute("algorithm", String, func() {
+		Description("HMAC algorithm.")
+		Enum(
+			definition.SignatureAlgorithmHMACSHA1.String(),
+			definition.SignatureAlgorithmHMACSHA256.String(),
+			definition.SignatureAlgorithmHMACSHA512.String(),
+		)
+		Example(definition.SignatureAlgorithmHMACSHA256.String())
+	})
+	Attribute("format", String, func() {
+		Description(`Format of the signature header and the signed payload. ` +
+			`"hex" - hex encoded signature of the body with an optional "<algorithm>=" prefix, for example GitHub. ` +
+			`"stripe" - "t=<timestamp>,v1=<signature>", the signed payload is "<timestamp>.<body>". ` +
+			`"slack" - "v0=<signature>", the signed payload is "v0:<timestamp>:<body>", the timestamp is read from the "X-Slack-Request-Timestamp" header.`)
+		Enum(
+			definition.SignatureFormatHex.String(),
+			definition.SignatureFormatStripe.String(),
+			definition.SignatureFormatSlack.String(),
+		)
+		Example(definition.SignatureFormatHex.String())
+	})
+	Attribute("header", String, func() {
+		Description("Name of the header with the signature.")
+		MinLength(cast.ToInt(fieldValidationRule(definition.HTTPSourceSignature{}, "Header", "min")))
+		MaxLength(cast.ToInt(fieldValidationRule(definition.HTTPSourceSignature{}, "Header", "max")))
+		Example("X-Hub-Signature-256")
+	})
+	Attribute("secret", String, func() {
+		Description("Secret key of the HMAC. Required when the verification is configured for the first time, it is never returned.")
+		MinLength(cast.ToInt(fieldValidationRule(definition.HTTPSourceSignature{}, "Secret", "min")))
+		MaxLength(cast.ToInt(fieldValidationRule(definition.HTTPSourceSignature{}, "Secret", "max")))
+		Example("my-webhook-secret")
+	})
+	Attribute("timestampToleranceSeconds", Int, func() {
+		Description(`Maximum difference between the signed timestamp and the current time, 0 means no limit. Not supported by the "hex" format.`)
+		Minimum(0)
+		Maximum(86400)
+		Example(300)
+	})
+	Attribute("replayWindowSeconds", Int, func() {
+		Description("A request with an already received signature is rejected during the window, 0 disables the replay protection.")
+		Minimum(0)
+		Maximum(86400)
+		Example(600)
+	})
+	Required("algorithm", "format", "header")
+})
+
 // OTLP Source----------------------------------------------------------------------------------------------------------
 
 var OTLPSource = Type("OTLPSource", func() {
@@ -1277,6 +1540,36 @@ var OTLPSource = Type("OTLPSource", func() {
 	Required("url", "baseUrl", "secret")
 })
 
+// Kafka Source---------------------------------------------------------------------------------------------------------
+
+var KafkaSource = Type("KafkaSource", func() {
+	Description(fmt.Sprintf(`Kafka source details for "type" = "%s". Records are consumed from a topic of a Kafka-protocol compatible broker.`, definition.SourceTypeKafka))
+	Attribute("brokers", ArrayOf(String), func() {
+		// The length of the list is validated by the definition.KafkaSource.
+		// A MinLength/MaxLength validation here would change the OpenAPI hash of the shared string list,
+		// and the aggregated sources result would be generated twice, as "AggregatedSourcesResult2".
+		Description(fmt.Sprintf(
+			"Addresses of the seed brokers, in the host:port format, %s-%s items.",
+			fieldValidationRule(definition.KafkaSource{}, "Brokers", "min"),
+			fieldValidationRule(definition.KafkaSource{}, "Brokers", "max"),
+		))
+		Example([]string{"kafka-1.example.com:9092", "kafka-2.example.com:9092"})
+	})
+	Attribute("topic", String, func() {
+		Description("Topic to consume.")
+		MinLength(cast.ToInt(fieldValidationRule(definition.KafkaSource{}, "Topic", "min")))
+		MaxLength(cast.ToInt(fieldValidationRule(definition.KafkaSource{}, "Topic", "max")))
+		Example("events")
+	})
+	Attribute("groupId", String, func() {
+		Description("Consumer group, the offsets of processed records are committed to.")
+		MinLength(cast.ToInt(fieldValidationRule(definition.KafkaSource{}, "GroupID", "min")))
+		MaxLength(cast.ToInt(fieldValidationRule(definition.KafkaSource{}, "GroupID", "max")))
+		Example("keboola-stream")
+	})
+	Required("brokers", "topic", "groupId")
+})
+
 // Sink ----------------------------------------------------------------------------------------------------------------
 
 var SinkResponse = func() {
@@ -1300,7 +1593,7 @@ var Sinks = Type("Sinks", ArrayOf(Sink), func() {
 
 var SinkType = Type("SinkType", String, func() {
 	Meta("struct:field:type", "= definition.SinkType", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition")
-	Enum(definition.SinkTypeTable.String())
+	Enum(definition.SinkTypeTable.String(), definition.SinkTypeWebhook.String(), definition.SinkTypeFile.String())
 	Example(definition.SinkTypeTable.String())
 })
 
@@ -1317,6 +1610,33 @@ var CreateSinkRequest = Type("CreateSinkRequest", func() {
 	SinkFields(OpCreate)
 })
 
+var InferSinkMappingRequest = Type("InferSinkMappingRequest", func() {
+	SourceKeyRequest()
+	Attribute("samples", ArrayOf(Any), func() {
+		Description("Sample JSON objects. If empty, the last requests received by the source are used, if the sampling is enabled.")
+		MaxLength(100)
+		Example([]any{map[string]any{"event": "login", "user": map[string]any{"id": 123, "name": "John"}}})
+	})
+	Attribute("maxDepth", Int, func() {
+		Description("Nested objects are flattened up to the depth, 1 means only top-level keys.")
+		Minimum(1)
+		Maximum(10)
+		Default(2)
+		Example(2)
+	})
+})
+
+var InferredSinkMapping = Type("InferredSinkMapping", func() {
+	Description("Proposed table mapping.")
+	SourceKeyResponse()
+	Attribute("samplesCount", Int, func() {
+		Description("Number of samples used for the proposal.")
+		Example(1)
+	})
+	Attribute("mapping", TableMapping)
+	Required("samplesCount", "mapping")
+})
+
 var GetSinkRequest = Type("GetSinkRequest", func() {
 	SinkKeyRequest()
 })
@@ -1329,6 +1649,24 @@ var SinkStatisticsRequest = Type("SinkStatisticsRequest", func() {
 	})
 })
 
+var ReimportSinkFilesRequest = Type("ReimportSinkFilesRequest", func() {
+	SinkKeyRequest()
+	Attribute("since", String, func() {
+		Description("Files opened at or after the time are re-imported.")
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
+	Attribute("until", String, func() {
+		Description("Files opened before the time are re-imported. To re-import one file, use its \"openedAt\" as \"since\" and a millisecond later as \"until\".")
+		Format(FormatDateTime)
+		Example("2022-04-28T15:20:04.000Z")
+	})
+	Attribute("tableId", TableID, func() {
+		Description("Optional table to re-import the files to. It must be in the same bucket as the table of the file, because the sink token is scoped to the bucket. By default, each file is re-imported to its original table.")
+	})
+	Required("since", "until")
+})
+
 var ListSinksRequest = Type("ListSinksRequest", func() {
 	SourceKeyRequest()
 	PaginatedRequest()
@@ -1368,9 +1706,124 @@ var SinkSettingsPatch = Type("SinkSettingsPatch", func() {
 	Attribute("settings", SettingsPatch)
 })
 
+var SinkDeadLetterRecordID = Type("SinkDeadLetterRecordID", String, func() {
+	Meta("struct:field:type", "= deadletter.RecordID", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter")
+	Description("Unique ID of the dead-letter record.")
+	MinLength(cast.ToInt(fieldValidationRule(deadletter.RecordKey{}, "RecordID", "min")))
+	MaxLength(cast.ToInt(fieldValidationRule(deadletter.RecordKey{}, "RecordID", "max")))
+	Example("01HZ3FQ4Y6V1N9J8T7K5M2C0XR")
+})
+
+var PreviewSinkDataRequest = Type("PreviewSinkDataRequest", func() {
+	SinkKeyRequest()
+	Attribute("limit", Int, func() {
+		Description("Maximum number of returned records.")
+		Default(10)
+		Minimum(1)
+		Maximum(100)
+		Example(10)
+	})
+})
+
+var SinkDataPreview = Type("SinkDataPreview", func() {
+	Description("The last records written to the local storage of the sink.")
+	SinkKeyResponse()
+	Attribute("rows", ArrayOf(SinkDataPreviewRow), func() {
+		Description("Mapped rows, from the oldest.")
+	})
+	Required("rows")
+})
+
+var SinkDataPreviewRow = Type("SinkDataPreviewRow", func() {
+	Description("Mapped table row, part of the sink data preview.")
+	Attribute("columns", ArrayOf(SinkDataPreviewColumn), func() {
+		Description("Mapped columns.")
+	})
+	Required("columns")
+})
+
+var SinkDataPreviewColumn = Type("SinkDataPreviewColumn", func() {
+	Description("Mapped table column value, part of the sink data preview.")
+	Attribute("name", String, func() {
+		Description("Column name.")
+		Example("id")
+	})
+	Attribute("value", String, func() {
+		Description("Column value.")
+		Example("12345")
+	})
+	Required("name", "value")
+})
+
+var ListSinkDeadLettersRequest = Type("ListSinkDeadLettersRequest", func() {
+	SinkKeyRequest()
+	PaginatedRequest()
+})
+
+var SinkDeadLetterRequest = Type("SinkDeadLetterRequest", func() {
+	SinkKeyRequest()
+	Attribute("recordId", SinkDeadLetterRecordID)
+	Required("recordId")
+})
+
+var SinkDeadLetterRecord = Type("SinkDeadLetterRecord", func() {
+	Description("A record which could not be mapped by the sink.")
+	SinkKeyResponse()
+	Attribute("recordId", SinkDeadLetterRecordID)
+	Attribute("receivedAt", String, func() {
+		Description("Date and time of the record receipt, it is kept on the replay.")
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
+	Attribute("failedAt", String, func() {
+		Description("Date and time of the last failure.")
+		Format(FormatDateTime)
+		Example("2022-04-28T14:20:04.000Z")
+	})
+	Attribute("error", String, func() {
+		Description("Error of the last failure.")
+		Example(`cannot convert column "name" to CSV value: path "name" not found in the body`)
+	})
+	Attribute("clientIp", String, func() {
+		Description("IP address of the client.")
+		Example("1.2.3.4")
+	})
+	Attribute("headers", MapOf(String, String), func() {
+		Description("Headers of the record.")
+		Example(map[string]string{"Content-Type": "application/json"})
+	})
+	Attribute("body", String, func() {
+		Description("Raw body of the record, it is not part of the list response.")
+		Example(`{"foo":"bar"}`)
+	})
+	Attribute("bodyTruncated", Boolean, func() {
+		Description("True if the body has been truncated, such record cannot be replayed.")
+		Example(false)
+	})
+	Attribute("replayRequested", Boolean, func() {
+		Description("True if the replay of the record is in progress.")
+		Example(false)
+	})
+	Required("recordId", "receivedAt", "failedAt", "error", "headers", "bodyTruncated", "replayRequested")
+})
+
+var SinkDeadLetterRecordsList = Type("SinkDeadLetterRecordsList", func() {
+	Description("List of dead-letter records of the sink, without bodies.")
+	SinkKeyResponse()
+	Attribute("page", PaginatedResponse)
+	Attribute("records", ArrayOf(SinkDeadLetterRecord))
+	Required("page", "records")
+})
+
 var SinkStatisticsTotalResult = Type("SinkStatisticsTotalResult", func() {
 	Attribute("total", Level)
 	Attribute("levels", Levels)
+	Attribute("dropped", Level, func() {
+		Description("Records dropped by the sink filter.")
+	})
+	Attribute("deduplicated", Level, func() {
+		Description("Duplicate records skipped by the sink deduplication window.")
+	})
 	Required("levels", "total")
 })
 
@@ -1405,9 +1858,27 @@ var Level = Type("Level", func() {
 
 var SinkStatisticsFilesResult = Type("SinkStatisticsFilesResult", func() {
 	Attribute("files", SinkFiles)
+	Attribute("partitions", SinkPartitions)
 	Required("files")
 })
 
+var SinkPartitions = Type("SinkPartitions", ArrayOf(SinkPartition), func() {
+	Description("Statistics of recent sink files grouped by the table partition. Only for a table sink with partitioning.")
+})
+
+var SinkPartition = Type("SinkPartition", func() {
+	Attribute("partition", String, func() {
+		Description("Partition ID, it is used as the table name suffix.")
+		Example("20220428")
+	})
+	Attribute("filesCount", Int, func() {
+		Description("Number of recent files of the partition.")
+		Example(3)
+	})
+	Attribute("statistics", SinkFileStatistics)
+	Required("partition", "filesCount")
+})
+
 var SinkFiles = Type("SinkFiles", ArrayOf(SinkFile), func() {
 	Description("List of recent sink files.")
 })
@@ -1443,6 +1914,10 @@ var SinkFile = Type("SinkFile", func() {
 		Format(FormatDateTime)
 		Example("2022-04-28T14:20:04.000Z")
 	})
+	Attribute("partition", String, func() {
+		Description("Partition ID of the file. Only for a table sink with partitioning.")
+		Example("20220428")
+	})
 	Required("state", "openedAt")
 	Attribute("statistics", SinkFileStatistics)
 })
@@ -1455,7 +1930,7 @@ var SinkFileStatistics = Type("SinkFileStatistics", func() {
 
 var FileState = Type("FileState", String, func() {
 	Meta("struct:field:type", "= model.FileState", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model")
-	Enum(model.FileWriting.String(), model.FileClosing.String(), model.FileImporting.String(), model.FileImported.String())
+	Enum(model.FileWriting.String(), model.FileClosing.String(), model.FileImporting.String(), model.FileImported.String(), model.FileReimporting.String())
 	Example(model.FileWriting.String())
 })
 
@@ -1493,6 +1968,20 @@ var SinkFields = func(op OperationType) {
 		Example([]string{"logs"})
 	})
 
+	Attribute("filter", String, func() {
+		Description(`Optional Jsonnet expression evaluated for each record, it must return a boolean. ` +
+			`Records for which the expression returns false are dropped by the sink. ` +
+			`The same functions as in the template column are available, for example Body(), Header() and Ip().`)
+		MaxLength(cast.ToInt(fieldValidationRule(definition.Sink{}, "Filter", "max")))
+		Example(`Body("level", "") == "error"`)
+	})
+
+	Attribute("deadLetter", Boolean, func() {
+		Description(`Store records which cannot be mapped by the sink, for example if a path is not found in the body. ` +
+			`Stored records can be inspected and replayed after the mapping has been fixed.`)
+		Example(true)
+	})
+
 	// Table sub-definition
 	switch op {
 	case OpRead:
@@ -1505,6 +1994,30 @@ var SinkFields = func(op OperationType) {
 		panic(errors.Errorf(`unexpected operation type "%v"`, op))
 	}
 
+	// Webhook sub-definition
+	switch op {
+	case OpRead:
+		Attribute("webhook", WebhookSink)
+	case OpCreate:
+		Attribute("webhook", WebhookSinkCreateRequest)
+	case OpUpdate:
+		Attribute("webhook", WebhookSinkUpdateRequest)
+	default:
+		panic(errors.Errorf(`unexpected operation type "%v"`, op))
+	}
+
+	// File sub-definition
+	switch op {
+	case OpRead:
+		Attribute("file", FileSink)
+	case OpCreate:
+		Attribute("file", FileSinkCreateRequest)
+	case OpUpdate:
+		Attribute("file", FileSinkUpdateRequest)
+	default:
+		panic(errors.Errorf(`unexpected operation type "%v"`, op))
+	}
+
 	// Required fields
 	switch op {
 	case OpRead:
@@ -1530,6 +2043,10 @@ var TableSinkCreateRequest = Type("TableSinkCreate", func() {
 
 var TableSinkUpdateRequest = Type("TableSinkUpdate", func() {
 	TableSinkFields()
+	Attribute("removeMirror", Boolean, func() {
+		Description("Set to true to remove the mirror table. Already accepted records are still imported to the mirror table.")
+		Example(true)
+	})
 })
 
 var TableSinkFields = func() {
@@ -1537,6 +2054,8 @@ var TableSinkFields = func() {
 	Attribute("type", TableType)
 	Attribute("tableId", TableID)
 	Attribute("mapping", TableMapping)
+	Attribute("partitioning", TablePartitioning)
+	Attribute("mirror", TableMirror)
 }
 
 var TableType = Type("TableType", String, func() {
@@ -1545,6 +2064,38 @@ var TableType = Type("TableType", String, func() {
 	Example(definition.TableTypeKeboola.String())
 })
 
+var TablePartitioning = Type("TablePartitioning", func() {
+	Description("Optional time-based partitioning. Records are written to partition tables, the partition ID is appended to the table name, for example \"in.c-bucket.table_20220428\". " +
+		"A partition table is created with the same mapping, when the first file of the partition is opened. " +
+		"Each record is routed by its column value. Records of the next partition are rejected with a retryable error until the file of the partition is opened at the partition start. " +
+		"Records of an already closed partition, for example late events, cannot be written and are stored to the dead-letter storage, if enabled.")
+	Attribute("granularity", TablePartitionGranularity)
+	Attribute("column", String, func() {
+		Description("Name of a \"datetime\" or \"template\" column of the mapping. The \"datetime\" column contains the time the record was received. " +
+			"The \"template\" column must return a time in the RFC 3339 format, for example an event time from the body. The partition is determined in UTC.")
+		Example("datetime-col")
+	})
+	Required("granularity", "column")
+})
+
+var TableMirror = Type("TableMirror", func() {
+	Description("Optional secondary destination table. Each record accepted by the sink is written also to the mirror table, for example to a raw archive table in another bucket. " +
+		"Files of the mirror are rotated and imported independently of the sink files. The sink filter is evaluated once, values of columns present in both mappings are rendered once. " +
+		"A failed write to the mirror fails the record, unless the record is stored to the dead-letter storage of the sink. The mirror result is reported in the \"mirror\" field of the sink result, in the verbose mode of the source.")
+	Attribute("type", TableType)
+	Attribute("tableId", TableID)
+	Attribute("mapping", TableMapping, func() {
+		Description("Mapping of the mirror table. The mapping of the sink is used, if it is not set.")
+	})
+	Required("type", "tableId")
+})
+
+var TablePartitionGranularity = Type("TablePartitionGranularity", String, func() {
+	Meta("struct:field:type", "= definition.PartitionGranularity", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition")
+	Enum(definition.PartitionGranularityDaily.String(), definition.PartitionGranularityMonthly.String())
+	Example(definition.PartitionGranularityDaily.String())
+})
+
 var TableID = Type("TableID", String, func() {
 	Example("in.c-bucket.table")
 })
@@ -1569,6 +2120,7 @@ var TableColumns = Type("TableColumns", ArrayOf(TableColumn), func() {
 		column.Body{Name: "body-col"},
 		column.Path{Name: "path-col", Path: `foo.bar[0]`, DefaultValue: new(""), RawString: true},
 		column.Template{Name: "template-col", Template: column.TemplateConfig{Language: "jsonnet", Content: `body.foo + "-" + body.bar`}},
+		column.Path{Name: "count-col", Path: "count", Cast: &column.Cast{DataType: column.DataTypeInteger, OnError: column.CastErrorNull}},
 	})
 })
 
@@ -1599,6 +2151,9 @@ var TableColumn = Type("TableColumn", func() {
 	Attribute("template", TableColumnTemplate, func() {
 		Description(`Template mapping details. Only for "type" = "template".`)
 	})
+	Attribute("cast", TableColumnCast, func() {
+		Description(`Optional target data type of the value. Only for "type" = "body", "path" or "template".`)
+	})
 	Required("type", "name")
 })
 
@@ -1616,6 +2171,97 @@ var TableColumnTemplate = Type("TableColumnTemplate", func() {
 	Required("language", "content")
 })
 
+var TableColumnCast = Type("TableColumnCast", func() {
+	Description("Target data type of the column value, the value is validated and normalized to the type. " +
+		"A null or an empty value is always kept as null. " +
+		"If the column is a part of a Keboola table, the table is created with the matching column type.")
+	Attribute("dataType", String, func() {
+		Meta("struct:field:type", "column.DataType", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column")
+		Description("Target data type. The \"timestamp\" accepts an ISO 8601 string or a Unix timestamp in seconds.")
+		Enum(column.AllDataTypes().AnySlice()...)
+		Example(column.DataTypeInteger.String())
+	})
+	Attribute("onError", String, func() {
+		Meta("struct:field:type", "column.CastErrorPolicy", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column")
+		Description("Policy if the value cannot be cast: \"fail\" rejects the record, \"null\" uses null, \"default\" uses the \"defaultValue\".")
+		Enum(column.AllCastErrorPolicies().AnySlice()...)
+		Default(column.CastErrorFail.String())
+		Example(column.CastErrorNull.String())
+	})
+	Attribute("defaultValue", String, func() {
+		Description(`Value used by the "default" policy, it must be valid for the "dataType".`)
+		Example("0")
+	})
+	Required("dataType")
+})
+
+// Webhook Sink --------------------------------------------------------------------------------------------------------
+
+var WebhookSink = Type("WebhookSink", func() {
+	WebhookSinkFields()
+	Required("url", "mapping")
+})
+
+var WebhookSinkCreateRequest = Type("WebhookSinkCreate", func() {
+	WebhookSinkFields()
+	Required("url", "mapping")
+})
+
+var WebhookSinkUpdateRequest = Type("WebhookSinkUpdate", func() {
+	WebhookSinkFields()
+})
+
+var WebhookSinkFields = func() {
+	Description(fmt.Sprintf(`Webhook sink configuration for "type" = "%s".`, definition.SinkTypeWebhook))
+	Attribute("url", String, func() {
+		Description("URL of the endpoint. Records are sent in batches by POST requests, the body is a JSON array of objects with the mapping columns.")
+		MaxLength(cast.ToInt(fieldValidationRule(definition.WebhookSink{}, "URL", "max")))
+		Example("https://example.com/webhook")
+	})
+	Attribute("headers", WebhookHeaders)
+	Attribute("mapping", TableMapping)
+}
+
+var WebhookHeaders = Type("WebhookHeaders", ArrayOf(TableColumn), func() {
+	maxLength := cast.ToInt(fieldValidationRule(definition.WebhookSink{}, "Headers", "max"))
+	Description(fmt.Sprintf("List of request headers, the column name is used as the header name, the value is rendered from the record. A webhook may have a maximum of %d headers.", maxLength))
+	MaxLength(maxLength)
+	Example(column.Columns{
+		column.Template{Name: "X-Tenant", Template: column.TemplateConfig{Language: "jsonnet", Content: `Body("tenant")`}, RawString: true},
+	})
+})
+
+// File Sink -----------------------------------------------------------------------------------------------------------
+
+var FileSink = Type("FileSink", func() {
+	FileSinkFields()
+	Required("provider", "mapping")
+})
+
+var FileSinkCreateRequest = Type("FileSinkCreate", func() {
+	FileSinkFields()
+	Required("provider", "mapping")
+})
+
+var FileSinkUpdateRequest = Type("FileSinkUpdate", func() {
+	FileSinkFields()
+})
+
+var FileSinkFields = func() {
+	Description(fmt.Sprintf(`File sink configuration for "type" = "%s". `, definition.SinkTypeFile) +
+		"Records are stored to files in a bucket of the staging provider, independent of Keboola Storage. " +
+		`The file format is configured by the "storage.level.local.encoding.encoder.type" setting of the sink, for example "csv", "ndjson" or "parquet".`)
+	Attribute("provider", StagingProvider)
+	Attribute("mapping", TableMapping)
+}
+
+var StagingProvider = Type("StagingProvider", String, func() {
+	Meta("struct:field:type", "= definition.StagingProvider", "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition")
+	Description("Staging provider of the files, it must be enabled in the configuration of the service.")
+	Enum(definition.StagingProviderS3.String(), definition.StagingProviderLocal.String())
+	Example(definition.StagingProviderS3.String())
+})
+
 // Settings ------------------------------------------------------------------------------------------------------------
 
 var SettingsResult = Type("SettingsResult", func() {
@@ -1792,6 +2438,25 @@ var AggregatedSource = Type("AggregatedSource", func() {
 			"sinks":   []any{},
 		})
 	})
+	Example("kafka_source", func() {
+		Description("Kafka source with aggregated sink statistics.")
+		Value(Val{
+			"projectId":   1234,
+			"branchId":    5678,
+			"sourceId":    "my-kafka-source",
+			"type":        "kafka",
+			"name":        "My Kafka Source",
+			"description": "",
+			"kafka": Val{
+				"brokers": []string{"kafka-1.example.com:9092", "kafka-2.example.com:9092"},
+				"topic":   "events",
+				"groupId": "keboola-stream",
+			},
+			"version": exampleVersion(),
+			"created": exampleCreated(),
+			"sinks":   []any{},
+		})
+	})
 })
 
 var AggregatedSinks = Type("AggregatedSinks", ArrayOf(AggregatedSink))
@@ -1868,6 +2533,10 @@ func SourceAlreadyExistsError() {
 	GenericError(StatusConflict, "sourceAlreadyExists", "Source already exists in the branch.", `Source already exists in the branch.`)
 }
 
+func DeadLetterRecordNotFoundError() {
+	GenericError(StatusNotFound, "recordNotFound", "Dead-letter record not found error.", `Record "01HZ3FQ4Y6V1N9J8T7K5M2C0XR" not found in the sink.`)
+}
+
 func SinkAlreadyExistsError() {
 	GenericError(StatusConflict, "sinkAlreadyExists", "Sink already exists in the source.", `Sink already exists in the source.`)
 }
@@ -1888,6 +2557,10 @@ func ForbiddenProtectedSettingError() {
 	GenericError(StatusNotFound, "forbidden", "Modification of protected settings is forbidden.", `Cannot modify protected keys: "storage.level.local.encoding.compression.gzip.blockSize".`)
 }
 
+func NoSamplesError() {
+	GenericError(StatusUnprocessableEntity, "unprocessableContent", "No samples to infer the mapping.", `No samples found, send samples or a request to the source.`)
+}
+
 func InvalidColumnValueError() {
 	GenericError(StatusUnprocessableEntity, "invalidColumnValue", "Invalid data for sink.", `Invalid value for column "name": path "name" not found in the body`)
 }
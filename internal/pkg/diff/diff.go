@@ -181,17 +181,15 @@ func (d *Differ) diffState(state model.ObjectState) (*Result, error) {
 func (d *Differ) resultFn(result *Result, state model.ObjectState, diffFields []*reflecthelper.StructField, remoteValues, localValues reflect.Value, opts func(r *Reporter) cmp.Options) *Result {
 	state.RemoteState()
 	for _, field := range diffFields {
-		reporter := d.diffValues(
-			state,
-			remoteValues.FieldByName(field.StructField.Name).Interface(),
-			localValues.FieldByName(field.StructField.Name).Interface(),
-			opts,
-		)
+		remoteValue := remoteValues.FieldByName(field.StructField.Name).Interface()
+		localValue := localValues.FieldByName(field.StructField.Name).Interface()
+		reporter := d.diffValues(state, remoteValue, localValue, opts)
 		diffStr := reporter.String()
 		if len(diffStr) > 0 {
 			result.ChangedFields.
 				Add(strhelper.FirstLower(field.JSONName())).
 				SetDiff(diffStr).
+				SetValues(remoteValue, localValue).
 				AddPath(reporter.Paths()...)
 		}
 	}
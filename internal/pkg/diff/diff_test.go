@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
 	"github.com/keboola/keboola-as-code/internal/pkg/fixtures"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
@@ -802,6 +803,113 @@ func TestResults_Format(t *testing.T) {
 	assert.Equal(t, expected, output)
 }
 
+func TestResults_ToJSON(t *testing.T) {
+	t.Parallel()
+	projectState := newProjectState(t)
+	branchKey := model.BranchKey{ID: 123}
+	require.NoError(t, projectState.Set(&model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: branchKey, Paths: model.Paths{AbsPath: model.NewAbsPath("", "main")}},
+		Remote:         &model.Branch{BranchKey: branchKey, Name: "name", Description: "description"},
+		Local:          &model.Branch{BranchKey: branchKey, Name: "changed", Description: "description"},
+	}))
+	otherKey := model.BranchKey{ID: 456}
+	require.NoError(t, projectState.Set(&model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: otherKey, Paths: model.Paths{AbsPath: model.NewAbsPath("", "other")}},
+		Remote:         &model.Branch{BranchKey: otherKey, Name: "other"},
+	}))
+
+	results, err := NewDiffer(projectState).Diff()
+	require.NoError(t, err)
+
+	// Without details
+	expected := `
+{
+  "equal": false,
+  "results": [
+    {
+      "kind": "branch",
+      "key": {
+        "branchId": 123
+      },
+      "path": "main",
+      "state": "changed",
+      "changedFields": [
+        {
+          "name": "name",
+          "before": null,
+          "after": null
+        }
+      ]
+    },
+    {
+      "kind": "branch",
+      "key": {
+        "branchId": 456
+      },
+      "path": "other",
+      "state": "onlyInRemote",
+      "changedFields": []
+    }
+  ]
+}
+`
+	assert.Equal(t, strings.TrimLeft(expected, "\n"), json.MustEncodeString(results.ToJSON(false), true))
+
+	// With details
+	field := results.ToJSON(true).Results[0].ChangedFields[0]
+	assert.Equal(t, "  - name\n  + changed", field.Diff)
+	assert.Equal(t, "name", field.Before)
+	assert.Equal(t, "changed", field.After)
+}
+
+func TestResults_ToJSON_MaskSecrets(t *testing.T) {
+	t.Parallel()
+	projectState := newProjectState(t)
+	branchKey := model.BranchKey{ID: 123}
+	require.NoError(t, projectState.Set(&model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: branchKey},
+		Remote:         &model.Branch{BranchKey: branchKey, Name: "name"},
+		Local:          &model.Branch{BranchKey: branchKey, Name: "name"},
+	}))
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: "foo.bar", ID: "456"}
+	require.NoError(t, projectState.Set(&model.ConfigState{
+		ConfigManifest: &model.ConfigManifest{ConfigKey: configKey},
+		Remote: &model.Config{ConfigKey: configKey, Name: "name", Content: orderedmap.FromPairs([]orderedmap.Pair{
+			{Key: "#password", Value: "KBC::ProjectSecure::abc"},
+			{Key: "user", Value: "foo"},
+		})},
+		Local: &model.Config{ConfigKey: configKey, Name: "name", Content: orderedmap.FromPairs([]orderedmap.Pair{
+			{Key: "#password", Value: "my-secret"},
+			{Key: "user", Value: "bar"},
+			{Key: "db", Value: orderedmap.FromPairs([]orderedmap.Pair{{Key: "#token", Value: "my-token"}})},
+		})},
+	}))
+
+	results, err := NewDiffer(projectState).Diff()
+	require.NoError(t, err)
+
+	field := results.ToJSON(true).Results[0].ChangedFields[0]
+	assert.Equal(t, "configuration", field.Name)
+	assert.Equal(t, `{"#password":"*****","user":"foo"}`, json.MustEncodeString(field.Before, false))
+	assert.Equal(t, `{"#password":"*****","user":"bar","db":{"#token":"*****"}}`, json.MustEncodeString(field.After, false))
+	assert.Equal(t, strings.Join([]string{
+		`  #password:`,
+		`    - *****`,
+		`    + *****`,
+		`+ db:`,
+		`+   {`,
+		`+     "#token": "*****"`,
+		`+   }`,
+		`  user:`,
+		`    - foo`,
+		`    + bar`,
+	}, "\n"), field.Diff)
+
+	// The state is not modified
+	config := projectState.MustGet(configKey).LocalState().(*model.Config)
+	assert.Equal(t, "my-secret", config.Content.GetOrNil("#password"))
+}
+
 func newProjectState(t *testing.T) *state.State {
 	t.Helper()
 	d := dependencies.NewMocked(t, t.Context())
@@ -0,0 +1,202 @@
+package diff
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	OutputFormatText = "text"
+	OutputFormatJSON = "json"
+	// SecretMask replaces values of secret keys, prefixed with "#", in the JSON output.
+	SecretMask = "*****"
+)
+
+// secretJSONValueRegexp matches a string value of a secret key in a JSON formatted diff line.
+var secretJSONValueRegexp = regexp.MustCompile(`("#[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// ResultsJSON is a machine-readable form of the diff results.
+// The schema is part of the CLI interface, changes must be backward compatible.
+type ResultsJSON struct {
+	Equal   bool         `json:"equal"`
+	Results []ResultJSON `json:"results"`
+}
+
+// ResultJSON is a machine-readable form of one not equal diff result.
+type ResultJSON struct {
+	Kind          string             `json:"kind"`
+	Key           KeyJSON            `json:"key"`
+	Path          string             `json:"path"`
+	State         string             `json:"state"`
+	ChangedFields []ChangedFieldJSON `json:"changedFields"`
+}
+
+// KeyJSON identifies a branch, config or config row.
+type KeyJSON struct {
+	BranchID    int    `json:"branchId,omitempty"`
+	ComponentID string `json:"componentId,omitempty"`
+	ConfigID    string `json:"configId,omitempty"`
+	RowID       string `json:"rowId,omitempty"`
+}
+
+// ChangedFieldJSON describes one changed field.
+// Diff, Before and After are set only with details, otherwise they are empty/null.
+// Values of secret keys, prefixed with "#", are masked, the values may not be encrypted yet.
+// Before is the remote value and After is the local value, the pull plan swaps them.
+type ChangedFieldJSON struct {
+	Name   string   `json:"name"`
+	Paths  []string `json:"paths,omitempty"`
+	Diff   string   `json:"diff,omitempty"`
+	Before any      `json:"before"`
+	After  any      `json:"after"`
+}
+
+func IsValidOutputFormat(format string) bool {
+	switch format {
+	case OutputFormatText, OutputFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// ToJSON converts not equal results to the machine-readable form.
+func (r *Results) ToJSON(details bool) ResultsJSON {
+	out := ResultsJSON{Equal: r.Equal, Results: []ResultJSON{}}
+	for _, result := range r.Results {
+		if result.State != ResultEqual {
+			out.Results = append(out.Results, result.ToJSON(details))
+		}
+	}
+	return out
+}
+
+func (r *Result) ToJSON(details bool) ResultJSON {
+	out := ResultJSON{
+		Kind:          r.Kind().Name,
+		Key:           newKeyJSON(r.Key()),
+		Path:          r.Path(),
+		State:         r.State.String(),
+		ChangedFields: []ChangedFieldJSON{},
+	}
+
+	for _, field := range r.ChangedFields.All() {
+		fieldOut := ChangedFieldJSON{Name: field.Name(), Paths: field.PathList()}
+		if details {
+			fieldOut.Diff = maskSecretsInDiff(field.Diff())
+			fieldOut.Before = maskSecrets(field.RemoteValue())
+			fieldOut.After = maskSecrets(field.LocalValue())
+		}
+		out.ChangedFields = append(out.ChangedFields, fieldOut)
+	}
+
+	return out
+}
+
+func (s ResultState) String() string {
+	switch s {
+	case ResultNotSet:
+		return "notSet"
+	case ResultNotEqual:
+		return "changed"
+	case ResultEqual:
+		return "equal"
+	case ResultOnlyInRemote:
+		return "onlyInRemote"
+	case ResultOnlyInLocal:
+		return "onlyInLocal"
+	default:
+		panic(errors.Errorf("unexpected result state %d", int(s)))
+	}
+}
+
+func newKeyJSON(key model.Key) KeyJSON {
+	switch k := key.(type) {
+	case model.BranchKey:
+		return KeyJSON{BranchID: int(k.ID)}
+	case model.ConfigKey:
+		return KeyJSON{BranchID: int(k.BranchID), ComponentID: k.ComponentID.String(), ConfigID: k.ID.String()}
+	case model.ConfigRowKey:
+		return KeyJSON{BranchID: int(k.BranchID), ComponentID: k.ComponentID.String(), ConfigID: k.ConfigID.String(), RowID: k.ID.String()}
+	default:
+		return KeyJSON{}
+	}
+}
+
+// maskSecrets returns a copy of the value with masked values of secret keys.
+func maskSecrets(value any) any {
+	switch v := value.(type) {
+	case *orderedmap.OrderedMap:
+		if v == nil {
+			return v
+		}
+		out := orderedmap.New()
+		for _, key := range v.Keys() {
+			item, _ := v.Get(key)
+			out.Set(key, maskSecretValue(key, item))
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			out[key] = maskSecretValue(key, item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = maskSecrets(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+func maskSecretValue(key string, value any) any {
+	if strings.HasPrefix(key, "#") {
+		return SecretMask
+	}
+	return maskSecrets(value)
+}
+
+// maskSecretsInDiff masks values of secret keys in the diff lines, see Reporter.Report for the format.
+// Lines under a path with a secret key are masked, secret values in a JSON formatted value are masked.
+func maskSecretsInDiff(diff string) string {
+	if diff == "" {
+		return diff
+	}
+
+	lines := strings.Split(diff, "\n")
+	inSecretPath := false
+	for i, line := range lines {
+		// Path line, for example "  parameters.#password:"
+		if len(line) > 2 && line[1] == ' ' && line[2] != ' ' && strings.HasSuffix(line, ":") {
+			inSecretPath = false
+			for _, step := range strings.Split(strings.TrimSuffix(line[2:], ":"), ".") {
+				if strings.HasPrefix(step, "#") {
+					inSecretPath = true
+				}
+			}
+			continue
+		}
+
+		// Value line, for example "    - value"
+		if inSecretPath && len(line) > 4 {
+			prefix := line[:4]
+			if rest := line[4:]; strings.HasPrefix(rest, OnlyInRemoteMark+" ") || strings.HasPrefix(rest, OnlyInLocalMark+" ") {
+				prefix += rest[:2]
+			}
+			lines[i] = prefix + SecretMask
+			continue
+		}
+
+		lines[i] = secretJSONValueRegexp.ReplaceAllString(line, `$1"`+SecretMask+`"`)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,40 @@
+package codemapper
+
+import (
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/state"
+)
+
+type codeMapper struct {
+	state    *state.State
+	logger   log.Logger
+	registry Registry
+}
+
+func NewMapper(s *state.State) *codeMapper {
+	return NewMapperWithRegistry(s, DefaultRegistry())
+}
+
+// NewMapperWithRegistry creates the mapper, which extracts code defined by the registry to separate files.
+func NewMapperWithRegistry(s *state.State, registry Registry) *codeMapper {
+	if err := registry.Validate(); err != nil {
+		panic(err)
+	}
+	return &codeMapper{state: s, logger: s.Logger(), registry: registry}
+}
+
+// codeFiles returns code files registered for the object, if the object is a configuration containing code.
+func (m *codeMapper) codeFiles(object any) (*model.Config, []CodeFile) {
+	config, ok := object.(*model.Config)
+	if !ok {
+		return nil, nil
+	}
+
+	files := m.registry.Get(config.ComponentID)
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	return config, files
+}
@@ -0,0 +1,17 @@
+package codemapper_test
+
+import (
+	"testing"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/mapper/codemapper"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/state"
+)
+
+func createStateWithMapper(t *testing.T) (*state.State, dependencies.Mocked) {
+	t.Helper()
+	d := dependencies.NewMocked(t, t.Context())
+	mockedState := d.MockedState()
+	mockedState.Mapper().AddMapper(codemapper.NewMapper(mockedState))
+	return mockedState, d
+}
@@ -2,9 +2,7 @@ package codemapper
 
 import (
 	"context"
-	"os"
-
-	"github.com/keboola/go-utils/pkg/orderedmap"
+	"strings"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
@@ -13,12 +11,11 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
-// MapAfterLocalLoad - loads Python code from code.py file and inserts it into the configuration.
-func (m *pythonMapper) MapAfterLocalLoad(ctx context.Context, recipe *model.LocalLoadRecipe) error {
-	// Check if this is a Custom Python configuration
-	if ok, err := m.isPythonConfig(recipe.Object); err != nil {
-		return err
-	} else if !ok {
+// MapAfterLocalLoad - loads code from separate files and inserts it into the configuration, see Registry.
+func (m *codeMapper) MapAfterLocalLoad(ctx context.Context, recipe *model.LocalLoadRecipe) error {
+	// Check if the configuration contains code
+	config, files := m.codeFiles(recipe.Object)
+	if len(files) == 0 {
 		return nil
 	}
 
@@ -27,12 +24,15 @@ func (m *pythonMapper) MapAfterLocalLoad(ctx context.Context, recipe *model.Loca
 		State:           m.state,
 		LocalLoadRecipe: recipe,
 		logger:          m.logger,
-		config:          recipe.Object.(*model.Config),
+		config:          config,
 		errors:          errors.NewMultiError(),
 	}
 
 	// Load code
-	return l.loadCode(ctx)
+	for _, file := range files {
+		l.loadCode(ctx, file)
+	}
+	return l.errors.ErrorOrNil()
 }
 
 type localLoader struct {
@@ -43,54 +43,52 @@ type localLoader struct {
 	errors errors.MultiError
 }
 
-func (l *localLoader) loadCode(ctx context.Context) error {
-	// Path to code.py file
-	codePath := filesystem.Join(l.Path(), "code.py")
+func (l *localLoader) loadCode(ctx context.Context, codeFile CodeFile) {
+	// Path to the code file
+	codePath := filesystem.Join(l.Path(), codeFile.FileName)
+
+	// If the file doesn't exist, it's not an error
+	if !l.ObjectsRoot().IsFile(ctx, codePath) {
+		l.logger.Debugf(ctx, `%s file "%s" does not exist`, codeFile.Description, codePath)
+		return
+	}
 
 	// Load the file
 	file, err := l.Files.
 		Load(codePath).
 		AddMetadata(filesystem.ObjectKeyMetadata, l.config.Key()).
-		SetDescription("Python code").
+		SetDescription(codeFile.Description).
 		AddTag(model.FileTypeOther).
 		AddTag(model.FileKindNativeCode).
 		ReadFile(ctx)
 	if err != nil {
-		// If the file doesn't exist, it's not an error
-		if os.IsNotExist(err) {
-			l.logger.Debugf(ctx, `Python code file "%s" does not exist`, codePath)
-			return nil
-		}
 		l.errors.Append(err)
-		return l.errors.ErrorOrNil()
+		return
 	}
 
-	// Get file content
-	content := file.Content
-
 	// Insert code into configuration
-	l.setCodeInConfig(content)
+	if err := l.config.Content.SetNested(codeFile.JSONPath, codeValue(file.Content, codeFile.Format)); err != nil {
+		l.errors.Append(errors.PrefixErrorf(err, `cannot insert %s from "%s"`, codeFile.Description, codePath))
+		return
+	}
 
-	l.logger.Debugf(ctx, `Loaded Python code from "%s"`, codePath)
-	return l.errors.ErrorOrNil()
+	l.logger.Debugf(ctx, `Loaded %s from "%s"`, codeFile.Description, codePath)
 }
 
-func (l *localLoader) setCodeInConfig(code string) {
-	// Get or create parameters in the configuration
-	parameters, found := l.config.Content.Get("parameters")
-	if !found || parameters == nil {
-		parameters = orderedmap.New()
-		l.config.Content.Set("parameters", parameters)
-	}
-
-	// Check if parameters is an OrderedMap
-	paramsMap, ok := parameters.(*orderedmap.OrderedMap)
-	if !ok {
-		// If not an OrderedMap, create a new one
-		paramsMap = orderedmap.New()
-		l.config.Content.Set("parameters", paramsMap)
+// codeValue converts the file content to the value in the configuration content.
+func codeValue(content string, format CodeFormat) any {
+	switch format {
+	case FormatStringArray:
+		return []any{content}
+	case FormatLines:
+		lines := make([]any, 0)
+		for _, line := range strings.Split(strings.TrimRight(content, "\r\n"), "\n") {
+			if line = strings.TrimRight(line, "\r"); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	default:
+		return content
 	}
-
-	// Insert code into parameters
-	paramsMap.Set("code", code)
 }
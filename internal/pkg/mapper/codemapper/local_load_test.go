@@ -0,0 +1,80 @@
+package codemapper_test
+
+import (
+	"testing"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/mapper/codemapper"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+func TestCodeMapper_MapAfterLocalLoad(t *testing.T) {
+	t.Parallel()
+	state, d := createStateWithMapper(t)
+	logger := d.DebugLogger()
+	fs := state.ObjectsRoot()
+
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: codemapper.DataApp, ID: "456"}
+	manifest := &model.ConfigManifest{
+		ConfigKey: configKey,
+		Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "app/data-app")},
+	}
+	config := &model.Config{ConfigKey: configKey, Content: orderedmap.New()}
+	require.NoError(t, fs.WriteFile(t.Context(), filesystem.NewRawFile("branch/app/data-app/streamlit_app.py", "import streamlit\n")))
+
+	recipe := model.NewLocalLoadRecipe(state.FileLoader(), manifest, config)
+	require.NoError(t, state.Mapper().MapAfterLocalLoad(t.Context(), recipe))
+	assert.Empty(t, logger.WarnAndErrorMessages())
+
+	// Parent objects are created
+	code, found, err := config.Content.GetNested("parameters.script")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []any{"import streamlit\n"}, code)
+	assert.Len(t, recipe.Files.Loaded(), 1)
+}
+
+func TestCodeMapper_MapAfterLocalLoad_Lines(t *testing.T) {
+	t.Parallel()
+	state, _ := createStateWithMapper(t)
+	fs := state.ObjectsRoot()
+
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: codemapper.DBTTransformation, ID: "456"}
+	manifest := &model.ConfigManifest{
+		ConfigKey: configKey,
+		Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "transformation/dbt")},
+	}
+	content := orderedmap.FromPairs([]orderedmap.Pair{{Key: "parameters", Value: orderedmap.FromPairs([]orderedmap.Pair{
+		{Key: "dbt", Value: orderedmap.FromPairs([]orderedmap.Pair{{Key: "threads", Value: 4}})},
+	})}})
+	config := &model.Config{ConfigKey: configKey, Content: content}
+	require.NoError(t, fs.WriteFile(t.Context(), filesystem.NewRawFile("branch/transformation/dbt/execute_steps.sh", "dbt run\r\n\ndbt test\n")))
+
+	recipe := model.NewLocalLoadRecipe(state.FileLoader(), manifest, config)
+	require.NoError(t, state.Mapper().MapAfterLocalLoad(t.Context(), recipe))
+
+	// Empty lines are skipped
+	assert.Equal(t, `{"parameters":{"dbt":{"threads":4,"executeSteps":["dbt run","dbt test"]}}}`, json.MustEncodeString(config.Content, false))
+}
+
+func TestCodeMapper_MapAfterLocalLoad_MissingFile(t *testing.T) {
+	t.Parallel()
+	state, _ := createStateWithMapper(t)
+
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: codemapper.CustomPythonApp, ID: "456"}
+	manifest := &model.ConfigManifest{
+		ConfigKey: configKey,
+		Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "app/custom-python")},
+	}
+	content := orderedmap.FromPairs([]orderedmap.Pair{{Key: "parameters", Value: orderedmap.New()}})
+	config := &model.Config{ConfigKey: configKey, Content: content}
+
+	recipe := model.NewLocalLoadRecipe(state.FileLoader(), manifest, config)
+	require.NoError(t, state.Mapper().MapAfterLocalLoad(t.Context(), recipe))
+	assert.Equal(t, `{"parameters":{}}`, json.MustEncodeString(config.Content, false))
+}
@@ -2,8 +2,8 @@ package codemapper
 
 import (
 	"context"
-
-	"github.com/keboola/go-utils/pkg/orderedmap"
+	"reflect"
+	"strings"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
 	"github.com/keboola/keboola-as-code/internal/pkg/model"
@@ -11,12 +11,11 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
-// MapBeforeLocalSave - extracts Python code from configuration and saves it to code.py file.
-func (m *pythonMapper) MapBeforeLocalSave(ctx context.Context, recipe *model.LocalSaveRecipe) error {
-	// Check if this is a Custom Python configuration
-	if ok, err := m.isPythonConfig(recipe.Object); err != nil {
-		return err
-	} else if !ok {
+// MapBeforeLocalSave - extracts code from configuration and saves it to separate files, see Registry.
+func (m *codeMapper) MapBeforeLocalSave(ctx context.Context, recipe *model.LocalSaveRecipe) error {
+	// Check if the configuration contains code
+	config, files := m.codeFiles(recipe.Object)
+	if len(files) == 0 {
 		return nil
 	}
 
@@ -24,12 +23,15 @@ func (m *pythonMapper) MapBeforeLocalSave(ctx context.Context, recipe *model.Loc
 	w := &localWriter{
 		State:           m.state,
 		LocalSaveRecipe: recipe,
-		config:          recipe.Object.(*model.Config),
+		config:          config,
 		errors:          errors.NewMultiError(),
 	}
 
 	// Save
-	return w.save(ctx)
+	for _, file := range files {
+		w.save(ctx, file)
+	}
+	return w.errors.ErrorOrNil()
 }
 
 type localWriter struct {
@@ -39,55 +41,93 @@ type localWriter struct {
 	errors errors.MultiError
 }
 
-func (w *localWriter) save(ctx context.Context) error {
-	// Get Python code from configuration
-	// We assume that the code is stored in the "code" parameter in the configuration
-	content, err := w.getCodeFromConfig()
+func (w *localWriter) save(ctx context.Context, file CodeFile) {
+	// Get code from configuration
+	content, found, err := w.getCodeFromConfig(file)
 	if err != nil {
 		w.errors.Append(err)
-		return w.errors.ErrorOrNil()
+		return
+	} else if !found {
+		return
 	}
 
-	// Always save the file, even if the code is empty
-	// Path to code.py file
-	codePath := filesystem.Join(w.Path(), "code.py")
+	// Save the file, even if the code is empty
+	codePath := filesystem.Join(w.Path(), file.FileName)
 
 	// Create file
 	w.Files.
 		Add(filesystem.NewRawFile(codePath, content)).
-		SetDescription("Python code").
+		SetDescription(file.Description).
 		AddTag(model.FileTypeOther).
 		AddTag(model.FileKindNativeCode)
 
-	w.Logger().Debugf(ctx, "Saved Python code to %s", codePath)
+	// Remove the code from config.json, the code file is the only source
+	if !file.KeepInConfig {
+		w.removeCodeFromConfigFile(file)
+	}
 
-	return w.errors.ErrorOrNil()
+	w.Logger().Debugf(ctx, `Saved %s to "%s"`, file.Description, codePath)
 }
 
-func (w *localWriter) getCodeFromConfig() (string, error) {
-	// Get parameters from configuration
-	parameters, ok := w.config.Content.Get("parameters")
+func (w *localWriter) removeCodeFromConfigFile(file CodeFile) {
+	configFile, ok := w.Files.GetOneByTag(model.FileKindObjectConfig).(*filesystem.JSONFile)
 	if !ok {
-		return "", nil // Not an error, just no code
+		return
 	}
 
-	// Convert to OrderedMap
-	paramsMap, ok := parameters.(*orderedmap.OrderedMap)
-	if !ok {
-		return "", errors.New("parameters is not an OrderedMap")
+	// The content is cloned, the configuration object is not modified
+	content := configFile.Content.Clone()
+	parentPath, key := "", file.JSONPath
+	if i := strings.LastIndex(file.JSONPath, "."); i != -1 {
+		parentPath, key = file.JSONPath[:i], file.JSONPath[i+1:]
+	}
+	parent := content
+	if parentPath != "" {
+		var found bool
+		if parent, found, _ = content.GetNestedMap(parentPath); !found || parent == nil {
+			return
+		}
 	}
+	parent.Delete(key)
+	configFile.Content = content
+}
 
-	// Get code
-	codeVal, ok := paramsMap.Get("code")
-	if !ok {
-		return "", nil // Not an error, just no code
+func (w *localWriter) getCodeFromConfig(file CodeFile) (string, bool, error) {
+	// Get value from configuration
+	value, found, err := w.config.Content.GetNested(file.JSONPath)
+	if !found {
+		return "", false, nil // Not an error, just no code
+	} else if err != nil {
+		return "", false, errors.PrefixErrorf(err, `cannot extract %s`, file.Description)
 	}
 
-	// Convert to string
-	code, ok := codeVal.(string)
-	if !ok {
-		return "", errors.New("code is not a string")
+	// Only a string and an array of strings are extracted, other values stay in the configuration
+	var content string
+	switch v := value.(type) {
+	case string:
+		content = v
+	case []any:
+		lines := make([]string, 0, len(v))
+		for _, item := range v {
+			line, ok := item.(string)
+			if !ok {
+				return "", false, nil
+			}
+			lines = append(lines, line)
+		}
+		content = strings.Join(lines, "\n")
+	default:
+		return "", false, nil
+	}
+
+	// The value stays in the configuration, if it cannot be loaded back from the file in the same shape,
+	// for example, an array with more items in the FormatStringArray, or a blank line in the FormatLines.
+	if file.Format == FormatLines && content != "" {
+		content += "\n"
+	}
+	if !reflect.DeepEqual(codeValue(content, file.Format), value) {
+		return "", false, nil
 	}
 
-	return code, nil
+	return content, true, nil
 }
@@ -0,0 +1,142 @@
+package codemapper_test
+
+import (
+	"testing"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/mapper/codemapper"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+func TestCodeMapper_MapBeforeLocalSave(t *testing.T) {
+	t.Parallel()
+	state, d := createStateWithMapper(t)
+	logger := d.DebugLogger()
+
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: codemapper.CustomPythonApp, ID: "456"}
+	manifest := &model.ConfigManifest{
+		ConfigKey: configKey,
+		Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "app/custom-python")},
+	}
+	config := &model.Config{
+		ConfigKey: configKey,
+		Content: orderedmap.FromPairs([]orderedmap.Pair{
+			{Key: "parameters", Value: orderedmap.FromPairs([]orderedmap.Pair{
+				{Key: "code", Value: "print(200)\n"},
+				{Key: "venv", Value: "base"},
+			})},
+		}),
+	}
+
+	recipe := model.NewLocalSaveRecipe(manifest, config, model.NewChangedFields())
+	configFile := recipe.Files.
+		Add(filesystem.NewJSONFile("branch/app/custom-python/config.json", config.Content)).
+		AddTag(model.FileKindObjectConfig)
+	require.NoError(t, state.Mapper().MapBeforeLocalSave(t.Context(), recipe))
+	assert.Empty(t, logger.WarnAndErrorMessages())
+
+	assert.Equal(t, []filesystem.File{
+		configFile,
+		filesystem.NewRawFile("branch/app/custom-python/code.py", "print(200)\n").
+			SetDescription("Python code").
+			AddTag(model.FileTypeOther).
+			AddTag(model.FileKindNativeCode),
+	}, recipe.Files.All())
+
+	// The Python code is kept in config.json, see CodeFile.KeepInConfig
+	assert.JSONEq(t, `{"parameters":{"code":"print(200)\n","venv":"base"}}`, json.MustEncodeString(configFile.(*filesystem.JSONFile).Content, false))
+}
+
+func TestCodeMapper_MapBeforeLocalSave_StringArray(t *testing.T) {
+	t.Parallel()
+	state, _ := createStateWithMapper(t)
+
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: codemapper.DataApp, ID: "456"}
+	manifest := &model.ConfigManifest{
+		ConfigKey: configKey,
+		Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "app/data-app")},
+	}
+	config := &model.Config{
+		ConfigKey: configKey,
+		Content: orderedmap.FromPairs([]orderedmap.Pair{
+			{Key: "parameters", Value: orderedmap.FromPairs([]orderedmap.Pair{
+				{Key: "script", Value: []any{"import streamlit\nstreamlit.write(123)\n"}},
+			})},
+		}),
+	}
+
+	recipe := model.NewLocalSaveRecipe(manifest, config, model.NewChangedFields())
+	require.NoError(t, state.Mapper().MapBeforeLocalSave(t.Context(), recipe))
+
+	files := recipe.Files.All()
+	require.Len(t, files, 1)
+	assert.Equal(t, "branch/app/data-app/streamlit_app.py", files[0].Path())
+	assert.Equal(t, "import streamlit\nstreamlit.write(123)\n", files[0].(*filesystem.RawFile).Content)
+}
+
+func TestCodeMapper_MapBeforeLocalSave_Lines(t *testing.T) {
+	t.Parallel()
+	state, _ := createStateWithMapper(t)
+
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: codemapper.DBTTransformation, ID: "456"}
+	manifest := &model.ConfigManifest{
+		ConfigKey: configKey,
+		Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "transformation/dbt")},
+	}
+	config := &model.Config{
+		ConfigKey: configKey,
+		Content: orderedmap.FromPairs([]orderedmap.Pair{
+			{Key: "parameters", Value: orderedmap.FromPairs([]orderedmap.Pair{
+				{Key: "dbt", Value: orderedmap.FromPairs([]orderedmap.Pair{
+					{Key: "executeSteps", Value: []any{"dbt run", "dbt test"}},
+					{Key: "threads", Value: 4},
+				})},
+			})},
+		}),
+	}
+
+	recipe := model.NewLocalSaveRecipe(manifest, config, model.NewChangedFields())
+	configFile := recipe.Files.
+		Add(filesystem.NewJSONFile("branch/transformation/dbt/config.json", config.Content)).
+		AddTag(model.FileKindObjectConfig)
+	require.NoError(t, state.Mapper().MapBeforeLocalSave(t.Context(), recipe))
+
+	files := recipe.Files.All()
+	require.Len(t, files, 2)
+	assert.Equal(t, "branch/transformation/dbt/execute_steps.sh", files[1].Path())
+	assert.Equal(t, "dbt run\ndbt test\n", files[1].(*filesystem.RawFile).Content)
+	assert.JSONEq(t, `{"parameters":{"dbt":{"threads":4}}}`, json.MustEncodeString(configFile.(*filesystem.JSONFile).Content, false))
+}
+
+func TestCodeMapper_MapBeforeLocalSave_NoCode(t *testing.T) {
+	t.Parallel()
+	state, _ := createStateWithMapper(t)
+
+	configKey := model.ConfigKey{BranchID: 123, ComponentID: codemapper.DataApp, ID: "456"}
+	manifest := &model.ConfigManifest{
+		ConfigKey: configKey,
+		Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "app/data-app")},
+	}
+	config := &model.Config{
+		ConfigKey: configKey,
+		Content: orderedmap.FromPairs([]orderedmap.Pair{
+			{Key: "parameters", Value: orderedmap.FromPairs([]orderedmap.Pair{
+				// Only a string and an array of strings are extracted
+				{Key: "script", Value: []any{"import streamlit", 123}},
+			})},
+		}),
+	}
+
+	recipe := model.NewLocalSaveRecipe(manifest, config, model.NewChangedFields())
+	configFile := recipe.Files.
+		Add(filesystem.NewJSONFile("branch/app/data-app/config.json", config.Content)).
+		AddTag(model.FileKindObjectConfig)
+	require.NoError(t, state.Mapper().MapBeforeLocalSave(t.Context(), recipe))
+	assert.Equal(t, []filesystem.File{configFile}, recipe.Files.All())
+	assert.JSONEq(t, `{"parameters":{"script":["import streamlit",123]}}`, json.MustEncodeString(configFile.(*filesystem.JSONFile).Content, false))
+}
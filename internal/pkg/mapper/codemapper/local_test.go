@@ -0,0 +1,129 @@
+package codemapper_test
+
+import (
+	"testing"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/mapper/codemapper"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+// TestCodeMapper_LocalSaveAndLoad simulates pull and diff, the configuration loaded from the local files
+// must be same as the saved configuration, otherwise the diff reports a change right after the pull.
+func TestCodeMapper_LocalSaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		componentID keboola.ComponentID
+		content     string
+		codeFile    string
+		code        string
+	}{
+		{
+			name:        "python string",
+			componentID: codemapper.CustomPythonApp,
+			content:     `{"parameters":{"code":"print(200)\n","venv":"base"}}`,
+			codeFile:    "code.py",
+			code:        "print(200)\n",
+		},
+		{
+			name:        "string array with one item",
+			componentID: codemapper.DataApp,
+			content:     `{"parameters":{"script":["import streamlit\n\nstreamlit.write(123)"]}}`,
+			codeFile:    "streamlit_app.py",
+			code:        "import streamlit\n\nstreamlit.write(123)",
+		},
+		{
+			name:        "string array with more items",
+			componentID: codemapper.DataApp,
+			content:     `{"parameters":{"script":["import streamlit","streamlit.write(123)"]}}`,
+		},
+		{
+			name:        "string instead of string array",
+			componentID: codemapper.DataApp,
+			content:     `{"parameters":{"script":"import streamlit"}}`,
+		},
+		{
+			name:        "lines",
+			componentID: codemapper.DBTTransformation,
+			content:     `{"parameters":{"dbt":{"executeSteps":["dbt run","dbt test"],"threads":4}}}`,
+			codeFile:    "execute_steps.sh",
+			code:        "dbt run\ndbt test\n",
+		},
+		{
+			name:        "empty lines",
+			componentID: codemapper.DBTTransformation,
+			content:     `{"parameters":{"dbt":{"executeSteps":[],"threads":4}}}`,
+			codeFile:    "execute_steps.sh",
+			code:        "",
+		},
+		{
+			name:        "lines with blank item",
+			componentID: codemapper.DBTTransformation,
+			content:     `{"parameters":{"dbt":{"executeSteps":["dbt run","","dbt test"],"threads":4}}}`,
+		},
+		{
+			name:        "lines with multi-line item",
+			componentID: codemapper.DBTTransformation,
+			content:     `{"parameters":{"dbt":{"executeSteps":["dbt run\ndbt test"],"threads":4}}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			state, d := createStateWithMapper(t)
+			logger := d.DebugLogger()
+			fs := state.ObjectsRoot()
+
+			configKey := model.ConfigKey{BranchID: 123, ComponentID: tc.componentID, ID: "456"}
+			manifest := &model.ConfigManifest{
+				ConfigKey: configKey,
+				Paths:     model.Paths{AbsPath: model.NewAbsPath("branch", "config")},
+			}
+			content := orderedmap.New()
+			json.MustDecodeString(tc.content, content)
+			config := &model.Config{ConfigKey: configKey, Content: content}
+
+			// Save
+			saveRecipe := model.NewLocalSaveRecipe(manifest, config, model.NewChangedFields())
+			configFile := saveRecipe.Files.
+				Add(filesystem.NewJSONFile("branch/config/config.json", config.Content)).
+				AddTag(model.FileKindObjectConfig)
+			require.NoError(t, state.Mapper().MapBeforeLocalSave(t.Context(), saveRecipe))
+			for _, file := range saveRecipe.Files.All() {
+				if raw, ok := file.(*filesystem.RawFile); ok {
+					require.NoError(t, fs.WriteFile(t.Context(), raw))
+				}
+			}
+
+			// Check the code file
+			codePath := "branch/config/" + tc.codeFile
+			if tc.codeFile == "" {
+				assert.Len(t, saveRecipe.Files.All(), 1)
+			} else {
+				file, err := fs.ReadFile(t.Context(), filesystem.NewFileDef(codePath))
+				require.NoError(t, err)
+				assert.Equal(t, tc.code, file.Content)
+			}
+
+			// Load, the config.json content is the base
+			loadedContent := orderedmap.New()
+			json.MustDecodeString(json.MustEncodeString(configFile.(*filesystem.JSONFile).Content, false), loadedContent)
+			loadedConfig := &model.Config{ConfigKey: configKey, Content: loadedContent}
+			loadRecipe := model.NewLocalLoadRecipe(state.FileLoader(), manifest, loadedConfig)
+			require.NoError(t, state.Mapper().MapAfterLocalLoad(t.Context(), loadRecipe))
+			assert.Empty(t, logger.WarnAndErrorMessages())
+
+			// No diff
+			assert.JSONEq(t, tc.content, json.MustEncodeString(loadedConfig.Content, false))
+		})
+	}
+}
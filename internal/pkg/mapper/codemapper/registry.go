@@ -0,0 +1,102 @@
+package codemapper
+
+import (
+	"strings"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/naming"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	CustomPythonApp   = "kds-team.app-custom-python"
+	DataApp           = "keboola.data-apps"
+	DBTTransformation = "keboola.dbt-transformation"
+)
+
+const (
+	// FormatString - the code is stored as a string.
+	FormatString CodeFormat = iota
+	// FormatStringArray - the code is stored as an array with one string, eg. `"script": ["import streamlit"]`.
+	// The file is loaded back as one item, so an array with more items is not extracted.
+	FormatStringArray
+	// FormatLines - each line of the file is one item of an array, eg. commands.
+	// Blank lines are skipped on load, so an array with an empty or multi-line item is not extracted.
+	FormatLines
+)
+
+// CodeFormat is the form of the code value in the configuration content.
+type CodeFormat int
+
+// CodeFile maps a string value in the configuration content to a source file in the configuration directory.
+type CodeFile struct {
+	// JSONPath to the value in the configuration content, eg. "parameters.code".
+	JSONPath string
+	// FileName of the source file, the extension determines the language, eg. "code.py".
+	FileName string
+	// Description of the file, used in logs.
+	Description string
+	// Format of the value, the file is loaded back in this format.
+	// The value is extracted only if it is loaded back unchanged, otherwise it stays in the configuration.
+	Format CodeFormat
+	// KeepInConfig keeps the extracted value also in the config.json, the file takes precedence on load.
+	// It preserves the layout of directories pulled before the code was removed from the config.json.
+	KeepInConfig bool
+}
+
+// Registry maps component IDs to code files extracted from their configurations.
+type Registry map[keboola.ComponentID][]CodeFile
+
+// DefaultRegistry contains all components with code embedded in the configuration content.
+// Transformations with code blocks and shared codes are not included, they are handled by the transformation and sharedcode mappers.
+func DefaultRegistry() Registry {
+	return Registry{
+		CustomPythonApp: {
+			{JSONPath: "parameters.code", FileName: "code.py", Description: "Python code", KeepInConfig: true},
+		},
+		DataApp: {
+			{JSONPath: "parameters.script", FileName: "streamlit_app.py", Description: "Streamlit app code", Format: FormatStringArray},
+		},
+		DBTTransformation: {
+			{JSONPath: "parameters.dbt.executeSteps", FileName: "execute_steps.sh", Description: "dbt execute steps", Format: FormatLines},
+		},
+	}
+}
+
+// Get code files registered for the component.
+func (r Registry) Get(componentID keboola.ComponentID) []CodeFile {
+	return r[componentID]
+}
+
+// Validate checks that JSON paths and file names are defined and file names don't collide.
+func (r Registry) Validate() error {
+	errs := errors.NewMultiError()
+	for componentID, files := range r {
+		fileNames := make(map[string]bool)
+		jsonPaths := make(map[string]bool)
+		for _, file := range files {
+			switch {
+			case file.JSONPath == "":
+				errs.Append(errors.Errorf(`component "%s": JSON path is not set`, componentID))
+			case jsonPaths[file.JSONPath]:
+				errs.Append(errors.Errorf(`component "%s": duplicate JSON path "%s"`, componentID, file.JSONPath))
+			}
+			switch {
+			case file.FileName == "" || strings.ContainsAny(file.FileName, `/\`):
+				errs.Append(errors.Errorf(`component "%s": invalid file name "%s"`, componentID, file.FileName))
+			case isReservedFileName(file.FileName):
+				errs.Append(errors.Errorf(`component "%s": file name "%s" is reserved`, componentID, file.FileName))
+			case fileNames[file.FileName]:
+				errs.Append(errors.Errorf(`component "%s": duplicate file name "%s"`, componentID, file.FileName))
+			}
+			jsonPaths[file.JSONPath] = true
+			fileNames[file.FileName] = true
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func isReservedFileName(fileName string) bool {
+	return fileName == naming.ConfigFile || fileName == naming.MetaFile || fileName == naming.DescriptionFile
+}
@@ -0,0 +1,34 @@
+package codemapper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/mapper/codemapper"
+)
+
+func TestDefaultRegistry_Validate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, codemapper.DefaultRegistry().Validate())
+}
+
+func TestRegistry_Validate_Invalid(t *testing.T) {
+	t.Parallel()
+	registry := codemapper.Registry{
+		"foo.bar": {
+			{JSONPath: "parameters.code", FileName: "code.py"},
+			{JSONPath: "parameters.code", FileName: "code.py"},
+			{JSONPath: "", FileName: "config.json"},
+			{JSONPath: "parameters.script", FileName: "dir/script.R"},
+		},
+	}
+	err := registry.Validate()
+	require.Error(t, err)
+	assert.Equal(t, `- component "foo.bar": duplicate JSON path "parameters.code"
+- component "foo.bar": duplicate file name "code.py"
+- component "foo.bar": JSON path is not set
+- component "foo.bar": file name "config.json" is reserved
+- component "foo.bar": invalid file name "dir/script.R"`, err.Error())
+}
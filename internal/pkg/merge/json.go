@@ -0,0 +1,76 @@
+package merge
+
+import (
+	"bytes"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+)
+
+// JSON merges the local and remote versions of a JSON object key by key, nested objects are merged recursively.
+// A conflict is resolved in favor of the remote version, paths of the conflicting keys are returned.
+// Keys are ordered as in the remote version, keys added only locally are appended.
+func JSON(base, local, remote *orderedmap.OrderedMap) (*orderedmap.OrderedMap, []string) {
+	var conflicts []string
+	out := mergeObject("", base, local, remote, &conflicts)
+	return out, conflicts
+}
+
+func mergeObject(path string, base, local, remote *orderedmap.OrderedMap, conflicts *[]string) *orderedmap.OrderedMap {
+	keys := remote.Keys()
+	for _, key := range local.Keys() {
+		if _, found := remote.Get(key); !found {
+			keys = append(keys, key)
+		}
+	}
+
+	out := orderedmap.New()
+	for _, key := range keys {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		baseValue, inBase := base.Get(key)
+		localValue, inLocal := local.Get(key)
+		remoteValue, inRemote := remote.Get(key)
+
+		// Both sides contain an object, merge it recursively
+		localMap, localIsMap := localValue.(*orderedmap.OrderedMap)
+		remoteMap, remoteIsMap := remoteValue.(*orderedmap.OrderedMap)
+		if localIsMap && remoteIsMap {
+			baseMap, ok := baseValue.(*orderedmap.OrderedMap)
+			if !ok {
+				baseMap = orderedmap.New()
+			}
+			out.Set(key, mergeObject(keyPath, baseMap, localMap, remoteMap, conflicts))
+			continue
+		}
+
+		var value any
+		var found bool
+		switch {
+		case equalValues(localValue, inLocal, baseValue, inBase):
+			value, found = remoteValue, inRemote
+		case equalValues(remoteValue, inRemote, baseValue, inBase), equalValues(localValue, inLocal, remoteValue, inRemote):
+			value, found = localValue, inLocal
+		default:
+			*conflicts = append(*conflicts, keyPath)
+			value, found = remoteValue, inRemote
+		}
+
+		if found {
+			out.Set(key, value)
+		}
+	}
+
+	return out
+}
+
+func equalValues(a any, aFound bool, b any, bFound bool) bool {
+	if !aFound || !bFound {
+		return aFound == bFound
+	}
+	return bytes.Equal(json.MustEncode(a, false), json.MustEncode(b, false))
+}
@@ -0,0 +1,33 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	decode := func(data string) *orderedmap.OrderedMap {
+		m := orderedmap.New()
+		json.MustDecodeString(data, m)
+		return m
+	}
+
+	base := decode(`{"parameters":{"a":1,"b":2,"nested":{"x":"x"}},"storage":{}}`)
+	local := decode(`{"parameters":{"a":10,"b":2,"nested":{"x":"local"},"local":true},"storage":{}}`)
+	remote := decode(`{"parameters":{"a":1,"nested":{"x":"remote"}},"storage":{"input":[]},"runtime":{}}`)
+
+	merged, conflicts := JSON(base, local, remote)
+	assert.Equal(t, `{"parameters":{"a":10,"nested":{"x":"remote"},"local":true},"storage":{"input":[]},"runtime":{}}`, json.MustEncodeString(merged, false))
+	assert.Equal(t, []string{"parameters.nested.x"}, conflicts)
+
+	// No conflict
+	merged, conflicts = JSON(base, local, base)
+	assert.Equal(t, json.MustEncodeString(local, false), json.MustEncodeString(merged, false))
+	assert.Empty(t, conflicts)
+}
@@ -0,0 +1,121 @@
+// Package merge provides three-way merge of the local and remote versions of a file, against their common base.
+package merge
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/kylelemons/godebug/diff"
+)
+
+const (
+	MarkerLocal     = "<<<<<<< local"
+	MarkerSeparator = "======="
+	MarkerRemote    = ">>>>>>> remote"
+)
+
+// Text merges the local and remote versions of a text file line by line.
+// Non-overlapping changes are merged, overlapping changes are written with conflict markers.
+// The second return value is true if there is at least one conflict.
+func Text(base, local, remote string) (string, bool) {
+	baseLines, localLines, remoteLines := splitLines(base), splitLines(local), splitLines(remote)
+	localMatches, remoteMatches := matchLines(baseLines, localLines), matchLines(baseLines, remoteLines)
+
+	var out strings.Builder
+	conflict := false
+	baseIdx, localIdx, remoteIdx := 0, 0, 0
+	for {
+		// Find the next base line present in both versions
+		stable := baseIdx
+		for stable < len(baseLines) && (localMatches[stable] < 0 || remoteMatches[stable] < 0) {
+			stable++
+		}
+
+		localEnd, remoteEnd := len(localLines), len(remoteLines)
+		if stable < len(baseLines) {
+			localEnd, remoteEnd = localMatches[stable], remoteMatches[stable]
+		}
+
+		// Merge the unstable chunk before the stable line
+		baseChunk, localChunk, remoteChunk := baseLines[baseIdx:stable], localLines[localIdx:localEnd], remoteLines[remoteIdx:remoteEnd]
+		switch {
+		case slices.Equal(localChunk, baseChunk):
+			writeLines(&out, remoteChunk, false)
+		case slices.Equal(remoteChunk, baseChunk), slices.Equal(localChunk, remoteChunk):
+			writeLines(&out, localChunk, false)
+		default:
+			conflict = true
+			out.WriteString(MarkerLocal + "\n")
+			writeLines(&out, localChunk, true)
+			out.WriteString(MarkerSeparator + "\n")
+			writeLines(&out, remoteChunk, true)
+			out.WriteString(MarkerRemote + "\n")
+		}
+
+		if stable >= len(baseLines) {
+			break
+		}
+
+		out.WriteString(baseLines[stable])
+		baseIdx, localIdx, remoteIdx = stable+1, localEnd+1, remoteEnd+1
+	}
+
+	return out.String(), conflict
+}
+
+// HasConflictMarkers returns true if the content contains an unresolved conflict written by Text.
+func HasConflictMarkers(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimRight(line, "\r") == MarkerLocal {
+			return true
+		}
+	}
+	return false
+}
+
+// splitLines splits the content to lines, each line keeps its line ending.
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchLines returns, for each line of the base, index of the same line in the other version, or -1 if the line has been changed.
+func matchLines(base, other []string) []int {
+	matches := make([]int, len(base))
+
+	// DiffChunks returns no chunk for equal inputs
+	if slices.Equal(base, other) {
+		for i := range matches {
+			matches[i] = i
+		}
+		return matches
+	}
+
+	baseIdx, otherIdx := 0, 0
+	for _, chunk := range diff.DiffChunks(base, other) {
+		for range chunk.Deleted {
+			matches[baseIdx] = -1
+			baseIdx++
+		}
+		otherIdx += len(chunk.Added)
+		for range chunk.Equal {
+			matches[baseIdx] = otherIdx
+			baseIdx++
+			otherIdx++
+		}
+	}
+	return matches
+}
+
+// writeLines writes the lines, the last line without line ending is terminated only if terminate is true.
+func writeLines(out *strings.Builder, lines []string, terminate bool) {
+	for _, line := range lines {
+		out.WriteString(line)
+		if terminate && !strings.HasSuffix(line, "\n") {
+			out.WriteString("\n")
+		}
+	}
+}
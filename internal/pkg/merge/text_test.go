@@ -0,0 +1,71 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		base     string
+		local    string
+		remote   string
+		expected string
+		conflict bool
+	}{
+		{
+			name:     "no change",
+			base:     "a\nb\nc\n",
+			local:    "a\nb\nc\n",
+			remote:   "a\nb\nc\n",
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "only local change",
+			base:     "a\nb\nc\n",
+			local:    "a\nB\nc\n",
+			remote:   "a\nb\nc\n",
+			expected: "a\nB\nc\n",
+		},
+		{
+			name:     "only remote change",
+			base:     "a\nb\nc\n",
+			local:    "a\nb\nc\n",
+			remote:   "a\nb\nc\nd",
+			expected: "a\nb\nc\nd",
+		},
+		{
+			name:     "non-overlapping changes",
+			base:     "a\nb\nc\nd\ne\n",
+			local:    "A\nb\nc\nd\ne\n",
+			remote:   "a\nb\nc\nd\nE\nf\n",
+			expected: "A\nb\nc\nd\nE\nf\n",
+		},
+		{
+			name:     "same change",
+			base:     "a\nb\nc\n",
+			local:    "a\nX\nc\n",
+			remote:   "a\nX\nc\n",
+			expected: "a\nX\nc\n",
+		},
+		{
+			name:     "conflict",
+			base:     "a\nb\nc",
+			local:    "a\nb\nlocal",
+			remote:   "a\nb\nremote",
+			expected: "a\nb\n<<<<<<< local\nlocal\n=======\nremote\n>>>>>>> remote\n",
+			conflict: true,
+		},
+	}
+
+	for _, tc := range cases {
+		actual, conflict := Text(tc.base, tc.local, tc.remote)
+		assert.Equal(t, tc.expected, actual, tc.name)
+		assert.Equal(t, tc.conflict, conflict, tc.name)
+		assert.Equal(t, tc.conflict, HasConflictMarkers(actual), tc.name)
+	}
+}
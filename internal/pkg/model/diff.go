@@ -10,9 +10,11 @@ type ChangedFields map[string]*ChangedField
 
 // ChangedField one changed field, contains diff string and changed paths in any.
 type ChangedField struct {
-	name  string
-	paths map[string]bool
-	diff  string
+	name        string
+	paths       map[string]bool
+	diff        string
+	remoteValue any
+	localValue  any
 }
 
 func NewChangedFields(fields ...string) ChangedFields {
@@ -98,6 +100,21 @@ func (v *ChangedField) Diff() string {
 	return v.diff
 }
 
+// SetValues stores the compared remote and local values of the field.
+func (v *ChangedField) SetValues(remoteValue, localValue any) *ChangedField {
+	v.remoteValue = remoteValue
+	v.localValue = localValue
+	return v
+}
+
+func (v *ChangedField) RemoteValue() any {
+	return v.remoteValue
+}
+
+func (v *ChangedField) LocalValue() any {
+	return v.localValue
+}
+
 func (v *ChangedField) AddPath(paths ...string) *ChangedField {
 	for _, path := range paths {
 		if !v.HasPath(path) {
@@ -116,6 +133,11 @@ func (v *ChangedField) RemovePath(path string) {
 }
 
 func (v *ChangedField) Paths() string {
+	return strings.Join(v.PathList(), `, `)
+}
+
+// PathList returns sorted changed paths.
+func (v *ChangedField) PathList() []string {
 	var out []string
 	for path, changed := range v.paths {
 		if changed {
@@ -123,5 +145,5 @@ func (v *ChangedField) Paths() string {
 		}
 	}
 	sort.Strings(out)
-	return strings.Join(out, `, `)
+	return out
 }
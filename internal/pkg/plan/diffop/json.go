@@ -0,0 +1,61 @@
+package diffop
+
+import (
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+)
+
+// PlanJSON is a machine-readable form of the plan, see Plan.Log for the human-readable form.
+type PlanJSON struct {
+	Operation string       `json:"operation"`
+	Empty     bool         `json:"empty"`
+	Actions   []ActionJSON `json:"actions"`
+}
+
+// ActionJSON is one planned action, the action is "create", "update" or "delete".
+// Skipped is true for a remote deletion which is not allowed by the "--force" flag.
+type ActionJSON struct {
+	diff.ResultJSON
+	Action  string `json:"action"`
+	Skipped bool   `json:"skipped"`
+}
+
+// WriteJSON writes the plan in the machine-readable form.
+func (p *Plan) WriteJSON(w io.Writer, details bool) error {
+	// Sort a copy, the order of the plan actions is not modified
+	actions := slices.Clone(p.actions)
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Path() < actions[j].Path()
+	})
+
+	out := PlanJSON{Operation: p.Name(), Empty: p.Empty(), Actions: []ActionJSON{}}
+	for _, action := range actions {
+		result := action.ToJSON(details)
+
+		// Before/after are from the remote/local point of view, swap them if the local state is modified
+		if action.action == ActionSaveLocal || action.action == ActionDeleteLocal {
+			for i := range result.ChangedFields {
+				field := &result.ChangedFields[i]
+				field.Before, field.After = field.After, field.Before
+			}
+		}
+
+		out.Actions = append(out.Actions, ActionJSON{
+			ResultJSON: result,
+			Action:     action.opString(),
+			Skipped:    !p.allowedRemoteDelete && action.action == ActionDeleteRemote,
+		})
+	}
+
+	bytes, err := json.Encode(out, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(bytes)
+	return err
+}
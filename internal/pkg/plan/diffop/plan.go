@@ -90,6 +90,17 @@ func (p *Plan) Validate() error {
 	return nil
 }
 
+// Results returns diff results of the actions of the type.
+func (p *Plan) Results(actionType ActionType) []*diff.Result {
+	var out []*diff.Result
+	for _, action := range p.actions {
+		if action.action == actionType {
+			out = append(out, action.Result)
+		}
+	}
+	return out
+}
+
 func (p *Plan) Add(result *diff.Result, actionType ActionType) {
 	p.actions = append(p.actions, &action{Result: result, action: actionType})
 }
@@ -0,0 +1,231 @@
+package diffop
+
+import (
+	"path"
+	"strings"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Selection restricts a push/pull plan to the selected objects.
+//
+// An object is selected if it matches all non-empty filters, values within one filter are alternatives.
+// Objects the selected objects depend on are added, if they are missing on the target side of the operation,
+// for example the parent branch/config, a shared code or a config used in an orchestration.
+type Selection struct {
+	// Paths of object directories, relative to the project directory, nested objects are selected too.
+	Paths []string
+	// Configs are matched by the component ID and the config ID, the branch ID is ignored. Rows of the configs are selected too.
+	Configs []model.ConfigKey
+	// ComponentIDs select configs and config rows of the components.
+	ComponentIDs []keboola.ComponentID
+}
+
+// NewSelection parses the selection, configs are in the "{componentId}:{configId}" format.
+// Empty values are ignored, nil is returned if nothing is selected.
+func NewSelection(paths, configs, componentIDs []string) (*Selection, error) {
+	s := &Selection{}
+	for _, p := range paths {
+		if p = strings.TrimSpace(p); p != "" {
+			s.Paths = append(s.Paths, p)
+		}
+	}
+	for _, c := range configs {
+		if c = strings.TrimSpace(c); c == "" {
+			continue
+		}
+		componentID, configID, found := strings.Cut(c, ":")
+		if !found || componentID == "" || configID == "" {
+			return nil, errors.Errorf(`invalid config "%s", expected format "{componentId}:{configId}"`, c)
+		}
+		s.Configs = append(s.Configs, model.ConfigKey{ComponentID: keboola.ComponentID(componentID), ID: keboola.ConfigID(configID)})
+	}
+	for _, c := range componentIDs {
+		if c = strings.TrimSpace(c); c != "" {
+			s.ComponentIDs = append(s.ComponentIDs, keboola.ComponentID(c))
+		}
+	}
+
+	if s.IsEmpty() {
+		return nil, nil
+	}
+	return s, nil
+}
+
+func (s *Selection) IsEmpty() bool {
+	return s == nil || (len(s.Paths) == 0 && len(s.Configs) == 0 && len(s.ComponentIDs) == 0)
+}
+
+// Filter returns results of the selected objects and their dependencies.
+// Dependencies are added only if their result state is the missingOnTarget state,
+// diff.ResultOnlyInLocal for the push operation and diff.ResultOnlyInRemote for the pull operation.
+func (s *Selection) Filter(results *diff.Results, missingOnTarget diff.ResultState) *diff.Results {
+	if s.IsEmpty() {
+		return results
+	}
+
+	resultsMap := make(map[string]*diff.Result, len(results.Results))
+	for _, result := range results.Results {
+		resultsMap[result.Key().String()] = result
+	}
+
+	// Select objects
+	selected := make(map[string]bool)
+	var queue []*diff.Result
+	for _, result := range results.Results {
+		if s.match(result) {
+			selected[result.Key().String()] = true
+			queue = append(queue, result)
+		}
+	}
+
+	// Add dependencies
+	for len(queue) > 0 {
+		result := queue[0]
+		queue = queue[1:]
+		for _, key := range dependencies(result.ObjectState, results.Objects) {
+			dependency, found := resultsMap[key.String()]
+			if !found || selected[key.String()] || dependency.State != missingOnTarget {
+				continue
+			}
+			selected[key.String()] = true
+			queue = append(queue, dependency)
+		}
+	}
+
+	out := *results
+	out.Results = nil
+	for _, result := range results.Results {
+		if selected[result.Key().String()] {
+			out.Results = append(out.Results, result)
+		}
+	}
+	return &out
+}
+
+func (s *Selection) match(result *diff.Result) bool {
+	return s.matchPath(result) && s.matchConfig(result) && s.matchComponent(result)
+}
+
+func (s *Selection) matchPath(result *diff.Result) bool {
+	if len(s.Paths) == 0 {
+		return true
+	}
+
+	objectPath := result.Path()
+	for _, p := range s.Paths {
+		p = strings.TrimSuffix(path.Clean(strings.ReplaceAll(p, `\`, `/`)), "/")
+		if p == "." || objectPath == p || strings.HasPrefix(objectPath, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Selection) matchConfig(result *diff.Result) bool {
+	if len(s.Configs) == 0 {
+		return true
+	}
+
+	configKey, ok := configKeyOf(result.Key())
+	if !ok {
+		return false
+	}
+	for _, k := range s.Configs {
+		if k.ComponentID == configKey.ComponentID && k.ID == configKey.ID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Selection) matchComponent(result *diff.Result) bool {
+	if len(s.ComponentIDs) == 0 {
+		return true
+	}
+
+	configKey, ok := configKeyOf(result.Key())
+	if !ok {
+		return false
+	}
+	for _, componentID := range s.ComponentIDs {
+		if componentID == configKey.ComponentID {
+			return true
+		}
+	}
+	return false
+}
+
+func configKeyOf(key model.Key) (model.ConfigKey, bool) {
+	switch k := key.(type) {
+	case model.ConfigKey:
+		return k, true
+	case model.ConfigRowKey:
+		return k.ConfigKey(), true
+	default:
+		return model.ConfigKey{}, false
+	}
+}
+
+// dependencies returns keys of objects required by the object, in the local or the remote state.
+func dependencies(objectState model.ObjectState, objects model.ObjectStates) (out []model.Key) {
+	if parentKey, err := objectState.Key().ParentKey(); err == nil && parentKey != nil {
+		out = append(out, parentKey)
+	}
+
+	type side struct {
+		object  model.Object
+		objects model.Objects
+	}
+	var sides []side
+	if objectState.HasLocalState() {
+		sides = append(sides, side{object: objectState.LocalState(), objects: objects.LocalObjects()})
+	}
+	if objectState.HasRemoteState() {
+		sides = append(sides, side{object: objectState.RemoteState(), objects: objects.RemoteObjects()})
+	}
+	for _, side := range sides {
+
+		// Relations, for example variables or scheduler
+		if v, ok := side.object.(model.ObjectWithRelations); ok {
+			for _, relation := range v.GetRelations() {
+				if parentKey, err := relation.ParentKey(v.Key()); err == nil && parentKey != nil {
+					out = append(out, parentKey)
+				}
+				if otherSide, _, err := relation.NewOtherSideRelation(v, side.objects); err == nil && otherSide != nil {
+					out = append(out, otherSide)
+				}
+			}
+		}
+
+		config, ok := side.object.(*model.Config)
+		if !ok {
+			continue
+		}
+
+		// Shared code used by the transformation
+		if config.Transformation != nil && config.Transformation.LinkToSharedCode != nil {
+			link := config.Transformation.LinkToSharedCode
+			out = append(out, link.Config)
+			for _, rowKey := range link.Rows {
+				out = append(out, rowKey)
+			}
+		}
+
+		// Configs used in the orchestration
+		if config.Orchestration != nil {
+			for _, phase := range config.Orchestration.Phases {
+				for _, task := range phase.Tasks {
+					if task.ConfigID != "" {
+						out = append(out, model.ConfigKey{BranchID: config.BranchID, ComponentID: task.ComponentID, ID: task.ConfigID})
+					}
+				}
+			}
+		}
+	}
+	return out
+}
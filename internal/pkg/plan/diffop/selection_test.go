@@ -0,0 +1,108 @@
+package diffop_test
+
+import (
+	"testing"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/diffop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+)
+
+func TestSelection_Filter(t *testing.T) {
+	t.Parallel()
+
+	projectState := dependencies.NewMocked(t, t.Context()).MockedState()
+	branchKey := model.BranchKey{ID: 123}
+	branch := &model.Branch{BranchKey: branchKey, Name: "Main", IsDefault: true}
+	require.NoError(t, projectState.Set(&model.BranchState{
+		BranchManifest: &model.BranchManifest{BranchKey: branchKey, Paths: model.Paths{AbsPath: model.NewAbsPath("", "main")}},
+		Remote:         branch,
+		Local:          &model.Branch{BranchKey: branchKey, Name: "Main", IsDefault: true},
+	}))
+
+	newConfig := func(key model.ConfigKey, parentPath string) (*model.ConfigManifest, *model.Config) {
+		manifest := &model.ConfigManifest{ConfigKey: key, Paths: model.Paths{AbsPath: model.NewAbsPath(parentPath, key.ID.String())}}
+		return manifest, &model.Config{ConfigKey: key, Name: key.ID.String(), Content: orderedmap.New()}
+	}
+
+	// New config, used in the orchestration
+	usedKey := model.ConfigKey{BranchID: 123, ComponentID: "ex-generic-v2", ID: "used"}
+	usedManifest, used := newConfig(usedKey, "main/extractor/ex-generic-v2")
+	require.NoError(t, projectState.Set(&model.ConfigState{ConfigManifest: usedManifest, Local: used}))
+
+	// New orchestration
+	orchestratorKey := model.ConfigKey{BranchID: 123, ComponentID: keboola.OrchestratorComponentID, ID: "flow"}
+	orchestratorManifest, orchestrator := newConfig(orchestratorKey, "main/other/orchestrator")
+	orchestrator.Orchestration = &model.Orchestration{Phases: []*model.Phase{{
+		Name:    "Phase",
+		Content: orderedmap.New(),
+		Tasks:   []*model.Task{{Name: "Task", Enabled: true, ComponentID: usedKey.ComponentID, ConfigID: usedKey.ID}},
+	}}}
+	require.NoError(t, projectState.Set(&model.ConfigState{ConfigManifest: orchestratorManifest, Local: orchestrator}))
+
+	// Changed config
+	changedKey := model.ConfigKey{BranchID: 123, ComponentID: "ex-generic-v2", ID: "changed"}
+	changedManifest, changedRemote := newConfig(changedKey, "main/extractor/ex-generic-v2")
+	_, changedLocal := newConfig(changedKey, "main/extractor/ex-generic-v2")
+	changedLocal.Description = "changed"
+	require.NoError(t, projectState.Set(&model.ConfigState{ConfigManifest: changedManifest, Remote: changedRemote, Local: changedLocal}))
+
+	results, err := diff.NewDiffer(projectState).Diff()
+	require.NoError(t, err)
+
+	paths := func(results *diff.Results) (out []string) {
+		for _, result := range results.Results {
+			out = append(out, result.Path())
+		}
+		return out
+	}
+
+	// No selection
+	var selection *diffop.Selection
+	assert.Same(t, results, selection.Filter(results, diff.ResultOnlyInLocal))
+
+	// Path, the config used in the orchestration is added
+	selection = &diffop.Selection{Paths: []string{"main/other/"}}
+	assert.Equal(t, []string{
+		"main/extractor/ex-generic-v2/used",
+		"main/other/orchestrator/flow",
+	}, paths(selection.Filter(results, diff.ResultOnlyInLocal)))
+
+	// Dependency is added only if it is missing on the target side
+	assert.Equal(t, []string{"main/other/orchestrator/flow"}, paths(selection.Filter(results, diff.ResultOnlyInRemote)))
+
+	// Config
+	selection = &diffop.Selection{Configs: []model.ConfigKey{{ComponentID: "ex-generic-v2", ID: "changed"}}}
+	assert.Equal(t, []string{"main/extractor/ex-generic-v2/changed"}, paths(selection.Filter(results, diff.ResultOnlyInLocal)))
+
+	// Component and path
+	selection = &diffop.Selection{ComponentIDs: []keboola.ComponentID{"ex-generic-v2"}, Paths: []string{"main/extractor/ex-generic-v2/used"}}
+	assert.Equal(t, []string{"main/extractor/ex-generic-v2/used"}, paths(selection.Filter(results, diff.ResultOnlyInLocal)))
+}
+
+func TestNewSelection(t *testing.T) {
+	t.Parallel()
+
+	selection, err := diffop.NewSelection(nil, []string{""}, []string{" "})
+	require.NoError(t, err)
+	assert.Nil(t, selection)
+
+	selection, err = diffop.NewSelection([]string{"main/extractor"}, []string{"ex-generic-v2:123", ""}, []string{"keboola.snowflake-transformation"})
+	require.NoError(t, err)
+	assert.Equal(t, &diffop.Selection{
+		Paths:        []string{"main/extractor"},
+		Configs:      []model.ConfigKey{{ComponentID: "ex-generic-v2", ID: "123"}},
+		ComponentIDs: []keboola.ComponentID{"keboola.snowflake-transformation"},
+	}, selection)
+
+	_, err = diffop.NewSelection(nil, []string{"123"}, nil)
+	if assert.Error(t, err) {
+		assert.Equal(t, `invalid config "123", expected format "{componentId}:{configId}"`, err.Error())
+	}
+}
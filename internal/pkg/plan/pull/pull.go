@@ -6,7 +6,9 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
-func NewPlan(diffResults *diff.Results) (*diffop.Plan, error) {
+// NewPlan creates the pull plan, the selection is optional, nil means all objects.
+func NewPlan(diffResults *diff.Results, selection *diffop.Selection) (*diffop.Plan, error) {
+	diffResults = selection.Filter(diffResults, diff.ResultOnlyInRemote)
 	plan := diffop.NewPlan(`pull`)
 	for _, result := range diffResults.Results {
 		switch result.State {
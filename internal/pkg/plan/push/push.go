@@ -7,7 +7,9 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
-func NewPlan(diffResults *diff.Results) (*diffop.Plan, error) {
+// NewPlan creates the push plan, the selection is optional, nil means all objects.
+func NewPlan(diffResults *diff.Results, selection *diffop.Selection) (*diffop.Plan, error) {
+	diffResults = selection.Filter(diffResults, diff.ResultOnlyInLocal)
 	plan := diffop.NewPlan(`push`)
 	for _, result := range diffResults.Results {
 		switch result.State {
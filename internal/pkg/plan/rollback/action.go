@@ -0,0 +1,57 @@
+package rollback
+
+import (
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/pushsnapshot"
+)
+
+const (
+	ActionCreate ActionType = iota
+	ActionRestore
+	ActionUpdate
+	ActionDelete
+)
+
+type ActionType int
+
+// action restores one object from the push snapshot.
+type action struct {
+	*pushsnapshot.Object
+	action ActionType
+	// changedFields are fields which differ between the snapshot and the current remote state.
+	changedFields []string
+	// changedAfterPush is true if the remote object has been changed after the push.
+	changedAfterPush bool
+}
+
+func (a *action) String() string {
+	msg := a.markString() + " " + a.Key().Kind().Abbr + " " + a.Path
+	if a.action == ActionRestore {
+		msg += " | restored from trash"
+	}
+	if len(a.changedFields) > 0 {
+		msg += " | changed: " + strings.Join(a.changedFields, ", ")
+	}
+	return msg
+}
+
+func (a *action) Key() model.Key {
+	if a.IsRow() {
+		return model.ConfigRowKey{BranchID: a.ConfigKey.BranchID, ComponentID: a.ConfigKey.ComponentID, ConfigID: a.ConfigKey.ID, ID: a.RowID}
+	}
+	return model.ConfigKey{BranchID: a.ConfigKey.BranchID, ComponentID: a.ConfigKey.ComponentID, ID: a.ConfigKey.ID}
+}
+
+func (a *action) markString() string {
+	switch a.action {
+	case ActionCreate, ActionRestore:
+		return diff.AddMark
+	case ActionUpdate:
+		return diff.ChangeMark
+	default:
+		return diff.DeleteMark
+	}
+}
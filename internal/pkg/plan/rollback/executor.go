@@ -0,0 +1,110 @@
+package rollback
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/keboola/keboola-sdk-go/v2/pkg/request"
+)
+
+// restoredFields are fields of a config or a config row restored by the update.
+var restoredFields = []string{"name", "description", "isDisabled", "configuration", "changeDescription"}
+
+type executor struct {
+	*Plan
+	keboolaProjectAPI *keboola.AuthorizedAPI
+	storageAPIToken   string
+	changeDescription string
+}
+
+func newExecutor(plan *Plan, keboolaProjectAPI *keboola.AuthorizedAPI, storageAPIToken string, changeDescription string) *executor {
+	return &executor{Plan: plan, keboolaProjectAPI: keboolaProjectAPI, storageAPIToken: storageAPIToken, changeDescription: changeDescription}
+}
+
+func (e *executor) invoke(ctx context.Context) error {
+	// Configs are saved before rows, rows are deleted before configs
+	phases := [][]*action{
+		e.filter(false, ActionCreate, ActionRestore, ActionUpdate),
+		e.filter(true, ActionCreate, ActionRestore, ActionUpdate),
+		e.filter(true, ActionDelete),
+		e.filter(false, ActionDelete),
+	}
+
+	for _, actions := range phases {
+		wg := request.NewWaitGroup(ctx)
+		for _, action := range actions {
+			wg.Send(e.request(action))
+		}
+		if err := wg.Wait(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *executor) filter(rows bool, types ...ActionType) (out []*action) {
+	for _, action := range e.actions {
+		if action.IsRow() != rows {
+			continue
+		}
+		for _, t := range types {
+			if action.action == t {
+				out = append(out, action)
+			}
+		}
+	}
+	return out
+}
+
+func (e *executor) request(action *action) request.Sendable {
+	api := e.keboolaProjectAPI
+	if action.IsRow() {
+		switch action.action {
+		case ActionDelete:
+			return api.DeleteConfigRowRequest(action.RowKey())
+		default:
+			row := *action.Row
+			row.ConfigRowKey = action.RowKey()
+			row.ChangeDescription = e.changeDescription
+			if action.action == ActionCreate {
+				// Deleted rows are not kept in the trash, the row is created again with the same ID
+				return api.CreateConfigRowRequest(&row)
+			}
+			// Rows of a restored config are restored with the config
+			return api.UpdateConfigRowRequest(&row, restoredFields)
+		}
+	}
+
+	switch action.action {
+	case ActionDelete:
+		return api.DeleteConfigRequest(action.ConfigKey)
+	default:
+		config := *action.Config
+		config.ConfigKey = action.ConfigKey
+		config.ChangeDescription = e.changeDescription
+		update := api.UpdateConfigRequest(&keboola.ConfigWithRows{Config: &config}, restoredFields)
+		if action.action == ActionRestore {
+			// The config deleted by the push is in the trash, it is restored and then updated to the snapshot version
+			return request.NewAPIRequest(request.NoResult{}, e.restoreConfigRequest(action.ConfigKey)).
+				WithOnSuccess(func(ctx context.Context, _ request.NoResult) error {
+					_, err := update.Send(ctx)
+					return err
+				})
+		}
+		return update
+	}
+}
+
+// restoreConfigRequest restores the config from the trash, the request is not provided by the SDK.
+// https://keboola.docs.apiary.io/#reference/components-and-configurations/restore-deleted-configuration/restore
+func (e *executor) restoreConfigRequest(key keboola.ConfigKey) request.HTTPRequest {
+	return request.NewHTTPRequest(e.keboolaProjectAPI.Client()).
+		WithBaseURL("v2/storage").
+		WithError(&keboola.StorageError{}).
+		AndHeader("X-StorageApi-Token", e.storageAPIToken).
+		WithPost("branch/{branchId}/components/{componentId}/configs/{configId}/restore").
+		AndPathParam("branchId", key.BranchID.String()).
+		AndPathParam("componentId", key.ComponentID.String()).
+		AndPathParam("configId", key.ID.String())
+}
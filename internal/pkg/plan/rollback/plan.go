@@ -0,0 +1,59 @@
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/project/pushsnapshot"
+)
+
+type Plan struct {
+	snapshot *pushsnapshot.Snapshot
+	actions  []*action
+}
+
+func (p *Plan) Empty() bool {
+	return len(p.actions) == 0
+}
+
+func (p *Plan) Name() string {
+	return "rollback"
+}
+
+// ChangedAfterPush returns paths of the objects changed in the remote after the push.
+// The changes are overwritten by the rollback.
+func (p *Plan) ChangedAfterPush() (out []string) {
+	for _, action := range p.actions {
+		if action.changedAfterPush {
+			out = append(out, action.Path)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func (p *Plan) Invoke(ctx context.Context, keboolaProjectAPI *keboola.AuthorizedAPI, storageAPIToken string, changeDescription string) error {
+	return newExecutor(p, keboolaProjectAPI, storageAPIToken, changeDescription).invoke(ctx)
+}
+
+func (p *Plan) Log(w io.Writer) {
+	fmt.Fprintf(w, `Plan for "%s" operation of the push "%s":`, p.Name(), p.snapshot.PushID)
+	fmt.Fprintln(w)
+	actions := p.actions
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Path < actions[j].Path
+	})
+
+	if len(actions) == 0 {
+		fmt.Fprintln(w, "  no difference")
+		return
+	}
+
+	for _, action := range actions {
+		fmt.Fprintln(w, "  "+action.String())
+	}
+}
@@ -0,0 +1,78 @@
+package rollback
+
+import (
+	"reflect"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/pushsnapshot"
+)
+
+// NewPlan creates a plan to restore the remote state from the push snapshot.
+// The current argument contains the current remote state of the snapshot objects, see pushsnapshot.LoadRemote.
+// Objects changed by the push are updated from the snapshot, objects created by the push are deleted.
+// Configs deleted by the push are restored from the trash, with their rows.
+func NewPlan(snapshot *pushsnapshot.Snapshot, current []*pushsnapshot.Object) *Plan {
+	plan := &Plan{snapshot: snapshot}
+	restoredConfigs := make(map[keboola.ConfigKey]bool)
+	for i, object := range snapshot.Objects {
+		a := &action{Object: object}
+		remote := current[i]
+
+		switch {
+		case object.Existed() && remote != nil:
+			a.action = ActionUpdate
+			a.changedFields = changedFields(object, remote)
+			if len(a.changedFields) == 0 {
+				// The object is the same as before the push
+				continue
+			}
+		case object.Existed() && !object.IsRow():
+			a.action = ActionRestore
+			restoredConfigs[object.ConfigKey] = true
+		case object.Existed():
+			a.action = ActionCreate
+		case remote != nil:
+			a.action = ActionDelete
+		default:
+			// The created object has already been deleted
+			continue
+		}
+
+		a.changedAfterPush = remote != nil && object.PushedVersion != 0 && remote.Version() != object.PushedVersion
+		plan.actions = append(plan.actions, a)
+	}
+
+	// Rows of a restored config are restored with the config, they are only updated
+	for _, a := range plan.actions {
+		if a.IsRow() && a.action == ActionCreate && restoredConfigs[a.ConfigKey] {
+			a.action = ActionRestore
+		}
+	}
+
+	return plan
+}
+
+// changedFields compares the restored fields of the snapshot object and the remote object.
+func changedFields(snapshot, remote *pushsnapshot.Object) (out []string) {
+	a, b := restoredValues(snapshot), restoredValues(remote)
+	// Fields are sorted
+	for _, field := range []string{"configuration", "description", "isDisabled", "name"} {
+		if !reflect.DeepEqual(a[field], b[field]) {
+			out = append(out, field)
+		}
+	}
+	return out
+}
+
+// restoredValues returns the restored fields of the object, decoded from JSON, so the order of keys doesn't matter.
+func restoredValues(o *pushsnapshot.Object) map[string]any {
+	var v any = o.Config
+	if o.IsRow() {
+		v = o.Row
+	}
+	values := make(map[string]any)
+	json.MustDecodeString(json.MustEncodeString(v, false), &values)
+	return values
+}
@@ -0,0 +1,89 @@
+package rollback
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/project/pushsnapshot"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+)
+
+func TestRollbackPlan(t *testing.T) {
+	t.Parallel()
+
+	d := dependencies.NewMocked(t, t.Context())
+	transport := d.MockedHTTPTransport()
+	notFound := httpmock.NewJsonResponderOrPanic(404, map[string]any{"error": "not found", "code": "notFound"})
+
+	// Snapshot before the push
+	key := func(id keboola.ConfigID) keboola.ConfigKey {
+		return keboola.ConfigKey{BranchID: 123, ComponentID: "ex-generic-v2", ID: id}
+	}
+	content := orderedmap.FromPairs([]orderedmap.Pair{{Key: "foo", Value: "bar"}, {Key: "baz", Value: 123}})
+	snapshot := &pushsnapshot.Snapshot{
+		PushID: "my-push",
+		Objects: []*pushsnapshot.Object{
+			{Path: "main/extractor/ex-generic-v2/changed", ConfigKey: key("changed"), Config: &keboola.Config{ConfigKey: key("changed"), Name: "Old name", Content: content, Version: 1}, PushedVersion: 2},
+			{Path: "main/extractor/ex-generic-v2/changed/rows/deleted", ConfigKey: key("changed"), RowID: "deleted", Row: &keboola.ConfigRow{Name: "Row", Content: orderedmap.New(), Version: 1}},
+			{Path: "main/extractor/ex-generic-v2/created", ConfigKey: key("created"), PushedVersion: 1},
+			{Path: "main/extractor/ex-generic-v2/deleted", ConfigKey: key("deleted"), Config: &keboola.Config{ConfigKey: key("deleted"), Name: "Deleted", Content: orderedmap.New(), Version: 1}},
+			{Path: "main/extractor/ex-generic-v2/deleted/rows/row", ConfigKey: key("deleted"), RowID: "row", Row: &keboola.ConfigRow{Name: "Row", Content: orderedmap.New(), Version: 1}},
+			{Path: "main/extractor/ex-generic-v2/unchanged", ConfigKey: key("unchanged"), Config: &keboola.Config{ConfigKey: key("unchanged"), Name: "Unchanged", Content: content, Version: 1}},
+			{Path: "main/extractor/ex-generic-v2/already-deleted", ConfigKey: key("already-deleted")},
+		},
+	}
+
+	// Remote state, the "changed" config has been changed again after the push, the order of the keys doesn't matter
+	transport.RegisterResponder("GET", `=~/storage/branch/123/components/ex-generic-v2/configs/changed$`, httpmock.NewJsonResponderOrPanic(200, map[string]any{
+		"id": "changed", "name": "New name", "description": "New description", "configuration": map[string]any{"baz": 123, "foo": "bar"}, "version": 3,
+	}))
+	transport.RegisterResponder("GET", `=~/storage/branch/123/components/ex-generic-v2/configs/changed/rows/deleted$`, notFound)
+	transport.RegisterResponder("GET", `=~/storage/branch/123/components/ex-generic-v2/configs/created$`, httpmock.NewJsonResponderOrPanic(200, map[string]any{
+		"id": "created", "name": "Created", "configuration": map[string]any{}, "version": 1,
+	}))
+	transport.RegisterResponder("GET", `=~/storage/branch/123/components/ex-generic-v2/configs/deleted$`, notFound)
+	transport.RegisterResponder("GET", `=~/storage/branch/123/components/ex-generic-v2/configs/deleted/rows/row$`, notFound)
+	transport.RegisterResponder("GET", `=~/storage/branch/123/components/ex-generic-v2/configs/unchanged$`, httpmock.NewJsonResponderOrPanic(200, map[string]any{
+		"id": "unchanged", "name": "Unchanged", "configuration": map[string]any{"baz": 123, "foo": "bar"}, "version": 1,
+	}))
+	transport.RegisterResponder("GET", `=~/storage/branch/123/components/ex-generic-v2/configs/already-deleted$`, notFound)
+
+	current, err := pushsnapshot.LoadRemote(t.Context(), d.KeboolaProjectAPI(), snapshot.Objects)
+	require.NoError(t, err)
+
+	plan := NewPlan(snapshot, current)
+	var out bytes.Buffer
+	plan.Log(&out)
+	assert.Equal(t, `Plan for "rollback" operation of the push "my-push":
+  * C main/extractor/ex-generic-v2/changed | changed: description, name
+  + R main/extractor/ex-generic-v2/changed/rows/deleted
+  × C main/extractor/ex-generic-v2/created
+  + C main/extractor/ex-generic-v2/deleted | restored from trash
+  + R main/extractor/ex-generic-v2/deleted/rows/row | restored from trash
+`, out.String())
+	assert.Equal(t, []string{"main/extractor/ex-generic-v2/changed"}, plan.ChangedAfterPush())
+
+	// Invoke
+	transport.RegisterResponder("PUT", `=~/storage/branch/123/components/ex-generic-v2/configs/changed$`, httpmock.NewJsonResponderOrPanic(200, map[string]any{"id": "changed"}))
+	transport.RegisterResponder("POST", `=~/storage/branch/123/components/ex-generic-v2/configs/changed/rows$`, httpmock.NewJsonResponderOrPanic(201, map[string]any{"id": "deleted"}))
+	transport.RegisterResponder("DELETE", `=~/storage/branch/123/components/ex-generic-v2/configs/created$`, httpmock.NewStringResponder(204, ``))
+	transport.RegisterResponder("POST", `=~/storage/branch/123/components/ex-generic-v2/configs/deleted/restore$`, httpmock.NewJsonResponderOrPanic(200, map[string]any{"id": "deleted"}))
+	transport.RegisterResponder("PUT", `=~/storage/branch/123/components/ex-generic-v2/configs/deleted$`, httpmock.NewJsonResponderOrPanic(200, map[string]any{"id": "deleted"}))
+	transport.RegisterResponder("PUT", `=~/storage/branch/123/components/ex-generic-v2/configs/deleted/rows/row$`, httpmock.NewJsonResponderOrPanic(200, map[string]any{"id": "row"}))
+	require.NoError(t, plan.Invoke(t.Context(), d.KeboolaProjectAPI(), d.StorageAPIToken().Token, "Rollback"))
+
+	info := transport.GetCallCountInfo()
+	assert.Equal(t, 1, info[`PUT =~/storage/branch/123/components/ex-generic-v2/configs/changed$`])
+	assert.Equal(t, 1, info[`POST =~/storage/branch/123/components/ex-generic-v2/configs/changed/rows$`])
+	assert.Equal(t, 1, info[`DELETE =~/storage/branch/123/components/ex-generic-v2/configs/created$`])
+	assert.Equal(t, 1, info[`POST =~/storage/branch/123/components/ex-generic-v2/configs/deleted/restore$`])
+	assert.Equal(t, 1, info[`PUT =~/storage/branch/123/components/ex-generic-v2/configs/deleted$`])
+	assert.Equal(t, 1, info[`PUT =~/storage/branch/123/components/ex-generic-v2/configs/deleted/rows/row$`])
+	assert.Equal(t, 0, info[`POST =~/storage/branch/123/components/ex-generic-v2/configs$`])
+}
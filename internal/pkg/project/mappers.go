@@ -36,7 +36,7 @@ func MappersFor(s *state.State, d dependencies) (mapper.Mappers, error) {
 		// AES codes
 		transformation.NewMapper(s),
 		sharedcode.NewCodesMapper(s),
-		// Custom Python component
+		// Code embedded in configurations
 		codemapper.NewMapper(s),
 		// Shared code links
 		sharedcode.NewLinksMapper(s),
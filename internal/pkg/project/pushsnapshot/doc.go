@@ -0,0 +1,3 @@
+// Package pushsnapshot manages .keboola/.push-snapshots.json file with remote versions of the objects changed by the push.
+// A snapshot is identified by the push ID, the push can be reverted by the rollback command.
+package pushsnapshot
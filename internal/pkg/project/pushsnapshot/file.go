@@ -0,0 +1,158 @@
+package pushsnapshot
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/keboola/keboola-sdk-go/v2/pkg/request"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// FileName is hidden, the file is an internal state, it is not intended to be edited.
+	FileName = ".push-snapshots.json"
+	// MaxSnapshots is the number of kept snapshots, the oldest snapshot is removed first.
+	MaxSnapshots = 10
+)
+
+func Path() string {
+	return filesystem.Join(filesystem.MetadataDir, FileName)
+}
+
+type File struct {
+	Snapshots []*Snapshot `json:"snapshots"`
+}
+
+// Snapshot contains remote versions of the configs and rows before the push.
+type Snapshot struct {
+	PushID            string    `json:"pushId"`
+	Created           time.Time `json:"created"`
+	ChangeDescription string    `json:"changeDescription,omitempty"`
+	Objects           []*Object `json:"objects"`
+}
+
+// Object is a config or a config row in the Storage API form.
+// Config and Row are nil, if the object has been created by the push.
+type Object struct {
+	Path      string             `json:"path"`
+	ConfigKey keboola.ConfigKey  `json:"configKey"`
+	RowID     keboola.RowID      `json:"rowId,omitempty"`
+	Config    *keboola.Config    `json:"config,omitempty"`
+	Row       *keboola.ConfigRow `json:"row,omitempty"`
+	// PushedVersion is the remote version after the push, it is used to detect remote changes after the push.
+	// The value is 0, if the object has been deleted by the push or the push failed.
+	PushedVersion int `json:"pushedVersion,omitempty"`
+}
+
+func New() *File {
+	return &File{Snapshots: make([]*Snapshot, 0)}
+}
+
+func Load(ctx context.Context, fs filesystem.Fs) (*File, error) {
+	content := New()
+
+	path := Path()
+	if fs.IsFile(ctx, path) {
+		if _, err := fs.FileLoader().ReadJSONFileTo(ctx, filesystem.NewFileDef(path).SetDescription("push snapshots"), content); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+func (f *File) Save(ctx context.Context, fs filesystem.Fs) error {
+	// Write JSON file
+	content, err := json.EncodeString(f, true)
+	if err != nil {
+		return errors.PrefixError(err, "cannot encode push snapshots")
+	}
+	rawFile := filesystem.NewRawFile(Path(), content)
+	if err := fs.WriteFile(ctx, rawFile); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Add snapshot, the oldest snapshots over MaxSnapshots are removed.
+func (f *File) Add(snapshot *Snapshot) {
+	f.Snapshots = append(f.Snapshots, snapshot)
+	if over := len(f.Snapshots) - MaxSnapshots; over > 0 {
+		f.Snapshots = f.Snapshots[over:]
+	}
+}
+
+func (f *File) Get(pushID string) (*Snapshot, bool) {
+	for _, snapshot := range f.Snapshots {
+		if snapshot.PushID == pushID {
+			return snapshot, true
+		}
+	}
+	return nil, false
+}
+
+func (o *Object) IsRow() bool {
+	return o.RowID != ""
+}
+
+// Existed returns true if the object existed before the push.
+func (o *Object) Existed() bool {
+	return o.Config != nil || o.Row != nil
+}
+
+func (o *Object) RowKey() keboola.ConfigRowKey {
+	return keboola.ConfigRowKey{BranchID: o.ConfigKey.BranchID, ComponentID: o.ConfigKey.ComponentID, ConfigID: o.ConfigKey.ID, ID: o.RowID}
+}
+
+// Version returns the remote version of the object in the snapshot, 0 if the object has been created by the push.
+func (o *Object) Version() int {
+	switch {
+	case o.Config != nil:
+		return o.Config.Version
+	case o.Row != nil:
+		return o.Row.Version
+	default:
+		return 0
+	}
+}
+
+// LoadRemote loads the current remote versions of the objects, in the Storage API form.
+// The result has the same order as the objects, an item is nil if the object doesn't exist.
+// Configs in the trash don't exist.
+func LoadRemote(ctx context.Context, api *keboola.AuthorizedAPI, objects []*Object) ([]*Object, error) {
+	out := make([]*Object, len(objects))
+	wg := request.NewWaitGroup(ctx)
+	for i, object := range objects {
+		if object.IsRow() {
+			wg.Send(api.GetConfigRowRequest(object.RowKey()).
+				WithOnSuccess(func(_ context.Context, row *keboola.ConfigRow) error {
+					out[i] = &Object{Path: object.Path, ConfigKey: object.ConfigKey, RowID: object.RowID, Row: row}
+					return nil
+				}).
+				WithOnError(ignoreNotFoundError))
+		} else {
+			wg.Send(api.GetConfigRequest(object.ConfigKey).
+				WithOnSuccess(func(_ context.Context, config *keboola.Config) error {
+					out[i] = &Object{Path: object.Path, ConfigKey: object.ConfigKey, Config: config}
+					return nil
+				}).
+				WithOnError(ignoreNotFoundError))
+		}
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func ignoreNotFoundError(_ context.Context, err error) error {
+	var apiErr *keboola.StorageError
+	if errors.As(err, &apiErr) && apiErr.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+	return err
+}
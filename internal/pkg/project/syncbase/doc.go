@@ -0,0 +1,3 @@
+// Package syncbase manages .keboola/.sync-base.json file with contents of the object files after the last sync.
+// The contents are used as the base of the three-way merge in the pull operation.
+package syncbase
@@ -0,0 +1,163 @@
+package syncbase
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/merge"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/naming"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// FileName is hidden, the file is an internal state, it is not intended to be edited.
+	FileName       = ".sync-base.json"
+	ConflictSuffix = ".conflict"
+	// blocksDir contains transformation code blocks, see naming.Generator.BlocksDir.
+	blocksDir = "blocks"
+)
+
+func Path() string {
+	return filesystem.Join(filesystem.MetadataDir, FileName)
+}
+
+// Files maps path relative to the object directory to the file content.
+type Files map[string]string
+
+type File struct {
+	// Objects maps object key to its files.
+	Objects map[string]Files `json:"objects"`
+	// Conflicts contains paths of the files with conflicts, relative to the project directory.
+	Conflicts []string `json:"conflicts"`
+}
+
+func New() *File {
+	return &File{
+		Objects:   make(map[string]Files),
+		Conflicts: make([]string, 0),
+	}
+}
+
+func Load(ctx context.Context, fs filesystem.Fs) (*File, error) {
+	content := New()
+
+	path := Path()
+	if fs.IsFile(ctx, path) {
+		if _, err := fs.FileLoader().ReadJSONFileTo(ctx, filesystem.NewFileDef(path).SetDescription("sync base"), content); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+func (f *File) Save(ctx context.Context, fs filesystem.Fs) error {
+	// Write JSON file
+	content, err := json.EncodeString(f, true)
+	if err != nil {
+		return errors.PrefixError(err, "cannot encode sync base")
+	}
+	rawFile := filesystem.NewRawFile(Path(), content)
+	if err := fs.WriteFile(ctx, rawFile); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Get returns base files of the object, if any.
+func (f *File) Get(key model.Key) (Files, bool) {
+	files, found := f.Objects[key.String()]
+	return files, found
+}
+
+func (f *File) Set(key model.Key, files Files) {
+	f.Objects[key.String()] = files
+}
+
+func (f *File) Delete(key model.Key) {
+	delete(f.Objects, key.String())
+}
+
+// AddConflict records a file with a conflict, the path is relative to the project directory.
+func (f *File) AddConflict(path string) {
+	if !slices.Contains(f.Conflicts, path) {
+		f.Conflicts = append(f.Conflicts, path)
+	}
+}
+
+// UnresolvedConflicts returns recorded conflicts which are still present in the project directory.
+// A conflict is resolved by removing the ".conflict" file or the conflict markers.
+func (f *File) UnresolvedConflicts(ctx context.Context, fs filesystem.Fs) []string {
+	var out []string
+	for _, path := range f.Conflicts {
+		if !fs.IsFile(ctx, path) {
+			continue
+		}
+		if !strings.HasSuffix(path, ConflictSuffix) {
+			file, err := fs.ReadFile(ctx, filesystem.NewFileDef(path))
+			if err != nil || !merge.HasConflictMarkers(file.Content) {
+				continue
+			}
+		}
+		out = append(out, path)
+	}
+	return out
+}
+
+// IsMergeable returns true for object files merged by the three-way merge: config, description and code files.
+func IsMergeable(path string) bool {
+	name := filesystem.Base(path)
+	switch {
+	case strings.HasSuffix(name, ConflictSuffix):
+		return false
+	case name == naming.ConfigFile, name == naming.DescriptionFile:
+		return true
+	default:
+		return strings.HasPrefix(name, naming.CodeFileName+".")
+	}
+}
+
+// ReadFiles reads mergeable files of the object.
+// Files are read from the object directory and from the code blocks directory, nested objects are skipped.
+func ReadFiles(ctx context.Context, fs filesystem.Fs, objectDir string) (Files, error) {
+	files := make(Files)
+	if !fs.IsDir(ctx, objectDir) {
+		return files, nil
+	}
+
+	err := fs.Walk(ctx, objectDir, func(path string, info filesystem.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filesystem.Rel(objectDir, path)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			// Skip nested objects, for example config rows
+			if path != objectDir && relPath != blocksDir && !filesystem.IsFrom(relPath, blocksDir) {
+				return filesystem.SkipDir
+			}
+			return nil
+		}
+
+		if IsMergeable(relPath) {
+			file, err := fs.ReadFile(ctx, filesystem.NewFileDef(path))
+			if err != nil {
+				return err
+			}
+			files[relPath] = file.Content
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
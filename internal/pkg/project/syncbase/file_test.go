@@ -0,0 +1,81 @@
+package syncbase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem/aferofs"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+)
+
+func TestReadFiles(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	fs := aferofs.NewMemoryFs()
+
+	configDir := "main/transformation/keboola.snowflake-transformation/my-transformation"
+	for path, content := range map[string]string{
+		"config.json":                          "{}",
+		"meta.json":                            "{}",
+		"description.md":                       "desc",
+		"blocks/001-block/meta.json":           "{}",
+		"blocks/001-block/001-code/code.sql":   "SELECT 1;",
+		"blocks/001-block/001-code/meta.json":  "{}",
+		"rows/my-row/config.json":              "{}",
+		"config.json" + ConflictSuffix:         "{}",
+		"blocks/001-block/001-code/readme.txt": "",
+	} {
+		require.NoError(t, fs.WriteFile(ctx, filesystem.NewRawFile(filesystem.Join(configDir, path), content)))
+	}
+
+	files, err := ReadFiles(ctx, fs, configDir)
+	require.NoError(t, err)
+	assert.Equal(t, Files{
+		"config.json":                        "{}",
+		"description.md":                     "desc",
+		"blocks/001-block/001-code/code.sql": "SELECT 1;",
+	}, files)
+
+	// Missing directory
+	files, err = ReadFiles(ctx, fs, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestFile_SaveLoad(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	fs := aferofs.NewMemoryFs()
+
+	// Missing file
+	file, err := Load(ctx, fs)
+	require.NoError(t, err)
+	assert.Empty(t, file.Objects)
+
+	key := model.ConfigKey{BranchID: 123, ComponentID: "foo.bar", ID: "456"}
+	file.Set(key, Files{"config.json": "{}"})
+	file.AddConflict("main/code.sql")
+	file.AddConflict("main/config.json" + ConflictSuffix)
+	require.NoError(t, file.Save(ctx, fs))
+
+	file, err = Load(ctx, fs)
+	require.NoError(t, err)
+	files, found := file.Get(key)
+	assert.True(t, found)
+	assert.Equal(t, Files{"config.json": "{}"}, files)
+
+	// Conflict files don't exist
+	assert.Empty(t, file.UnresolvedConflicts(ctx, fs))
+
+	// Conflict markers and conflict file exist
+	require.NoError(t, fs.WriteFile(ctx, filesystem.NewRawFile("main/code.sql", "<<<<<<< local\nA\n=======\nB\n>>>>>>> remote\n")))
+	require.NoError(t, fs.WriteFile(ctx, filesystem.NewRawFile("main/config.json"+ConflictSuffix, "{}")))
+	assert.Equal(t, []string{"main/code.sql", "main/config.json.conflict"}, file.UnresolvedConflicts(ctx, fs))
+
+	// Conflict markers resolved
+	require.NoError(t, fs.WriteFile(ctx, filesystem.NewRawFile("main/code.sql", "A\n")))
+	assert.Equal(t, []string{"main/config.json.conflict"}, file.UnresolvedConflicts(ctx, fs))
+}
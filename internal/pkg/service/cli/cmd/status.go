@@ -3,18 +3,23 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/pkg/lib/operation/status"
 )
 
 type Flags struct {
 	StorageAPIHost configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
+	OutputFormat   configmap.Value[string] `configKey:"output-format" configUsage:"output format (text/json)"`
 }
 
 func DefaultFlags() Flags {
-	return Flags{}
+	return Flags{
+		OutputFormat: configmap.NewValue(diff.OutputFormatText),
+	}
 }
 
 func StatusCommand(p dependencies.Provider) *cobra.Command {
@@ -28,12 +33,16 @@ func StatusCommand(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			if !diff.IsValidOutputFormat(f.OutputFormat.Value) {
+				return errors.Errorf(`invalid output format "%s"`, f.OutputFormat.Value)
+			}
+
 			d, err := p.LocalCommandScope(cmd.Context(), f.StorageAPIHost, dependencies.WithDefaultStorageAPIHost())
 			if err != nil {
 				return err
 			}
 
-			return status.Run(cmd.Context(), d)
+			return status.Run(cmd.Context(), status.Options{OutputFormat: f.OutputFormat.Value}, d)
 		},
 	}
 
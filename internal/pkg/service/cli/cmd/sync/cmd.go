@@ -7,6 +7,7 @@ import (
 	syncInit "github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/sync/init"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/sync/pull"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/sync/push"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/cmd/sync/rollback"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 )
@@ -22,6 +23,7 @@ func Commands(p dependencies.Provider) *cobra.Command {
 		pull.Command(p),
 		push.Command(p),
 		diff.Command(p),
+		rollback.Command(p),
 	)
 	return cmd
 }
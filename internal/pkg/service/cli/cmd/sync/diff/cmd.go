@@ -3,10 +3,12 @@ package diff
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	projectManifest "github.com/keboola/keboola-as-code/internal/pkg/project/manifest"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/diff/printdiff"
 	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
 )
@@ -15,10 +17,13 @@ type Flags struct {
 	StorageAPIHost  configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
 	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
 	Details         configmap.Value[bool]   `configKey:"details" configUsage:"print changed fields"`
+	OutputFormat    configmap.Value[string] `configKey:"output-format" configUsage:"output format (text/json)"`
 }
 
 func DefaultFlags() Flags {
-	return Flags{}
+	return Flags{
+		OutputFormat: configmap.NewValue(diff.OutputFormatText),
+	}
 }
 
 func Command(p dependencies.Provider) *cobra.Command {
@@ -32,6 +37,10 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			if !diff.IsValidOutputFormat(f.OutputFormat.Value) {
+				return errors.Errorf(`invalid output format "%s"`, f.OutputFormat.Value)
+			}
+
 			// Command must be used in project directory
 			_, _, err := p.BaseScope().FsInfo().ProjectDir(cmd.Context())
 			if err != nil {
@@ -66,6 +75,7 @@ func Command(p dependencies.Provider) *cobra.Command {
 			options := printdiff.Options{
 				PrintDetails:      f.Details.Value,
 				LogUntrackedPaths: true,
+				OutputFormat:      f.OutputFormat.Value,
 			}
 
 			// Send cmd successful/failed event
@@ -78,7 +88,7 @@ func Command(p dependencies.Provider) *cobra.Command {
 			}
 
 			// Print info about --details flag
-			if !options.PrintDetails && results.HasNotEqualResult {
+			if !options.PrintDetails && results.HasNotEqualResult && options.OutputFormat != diff.OutputFormatJSON {
 				logger := d.Logger()
 				logger.Info(cmd.Context(), "")
 				logger.Info(cmd.Context(), `Use --details flag to list the changed fields.`)
@@ -1,8 +1,13 @@
 package pull
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/diffop"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
@@ -17,16 +22,23 @@ type Flags struct {
 	StorageAPIToken        configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
 	Force                  configmap.Value[bool]   `configKey:"force" configUsage:"ignore invalid local state"`
 	DryRun                 configmap.Value[bool]   `configKey:"dry-run" configUsage:"print what needs to be done"`
+	OutputFormat           configmap.Value[string] `configKey:"output-format" configUsage:"output format (text/json), json requires --dry-run"`
+	Details                configmap.Value[bool]   `configKey:"details" configUsage:"include changed values in the json output"`
+	Path                   configmap.Value[string] `configKey:"path" configUsage:"comma separated list of paths to pull"`
+	Config                 configmap.Value[string] `configKey:"config" configUsage:"comma separated list of {componentId}:{configId} to pull"`
+	Component              configmap.Value[string] `configKey:"component" configUsage:"comma separated list of component IDs to pull"`
 	CleanupRenameConflicts configmap.Value[bool]   `configKey:"cleanup-rename-conflicts" configUsage:"enable cleanup mode for rename conflicts (removes conflicting destinations)"`
 }
 
 func DefaultFlags() Flags {
-	return Flags{}
+	return Flags{
+		OutputFormat: configmap.NewValue(diff.OutputFormatText),
+	}
 }
 
 func Command(p dependencies.Provider) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "pull",
+		Use:   "pull [path ...]",
 		Short: helpmsg.Read(`sync/pull/short`),
 		Long:  helpmsg.Read(`sync/pull/long`),
 		RunE: func(cmd *cobra.Command, args []string) (cmdErr error) {
@@ -41,6 +53,10 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			if err := validateOutputFormat(f.OutputFormat.Value, f.DryRun.Value, f.Details.Value); err != nil {
+				return err
+			}
+
 			// Authentication
 			d, err := p.RemoteCommandScope(cmd.Context(), f.StorageAPIHost, f.StorageAPIToken)
 			if err != nil {
@@ -69,11 +85,26 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			// Selection, paths from the "--path" flag and the arguments, relative to the working directory
+			var paths []string
+			for _, path := range append(strings.Split(f.Path.Value, ","), args...) {
+				if path = strings.TrimSpace(path); path != "" {
+					paths = append(paths, filesystem.Join(prj.Fs().WorkingDir(), path))
+				}
+			}
+			selection, err := diffop.NewSelection(paths, strings.Split(f.Config.Value, ","), strings.Split(f.Component.Value, ","))
+			if err != nil {
+				return err
+			}
+
 			// Options
 			options := pull.Options{
 				DryRun:                 f.DryRun.Value,
 				LogUntrackedPaths:      true,
 				CleanupRenameConflicts: f.CleanupRenameConflicts.Value,
+				OutputFormat:           f.OutputFormat.Value,
+				Details:                f.Details.Value,
+				Selection:              selection,
 			}
 
 			// Send cmd successful/failed event
@@ -89,3 +120,16 @@ func Command(p dependencies.Provider) *cobra.Command {
 
 	return cmd
 }
+
+func validateOutputFormat(format string, dryRun bool, details bool) error {
+	if !diff.IsValidOutputFormat(format) {
+		return errors.Errorf(`invalid output format "%s"`, format)
+	}
+	if format == diff.OutputFormatJSON && !dryRun {
+		return errors.Errorf(`output format "%s" can be used only with the "--dry-run" flag`, format)
+	}
+	if details && format != diff.OutputFormatJSON {
+		return errors.New(`the "--details" flag can be used only with the "--output-format json" flag`)
+	}
+	return nil
+}
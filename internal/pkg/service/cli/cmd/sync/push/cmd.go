@@ -1,12 +1,18 @@
 package push
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/diffop"
 	projectManifest "github.com/keboola/keboola-as-code/internal/pkg/project/manifest"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	saveManifest "github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/manifest/save"
 	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/push"
 	loadState "github.com/keboola/keboola-as-code/pkg/lib/operation/state/load"
@@ -17,11 +23,18 @@ type Flags struct {
 	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
 	Force           configmap.Value[bool]   `configKey:"force" configUsage:"enable deleting of remote objects"`
 	DryRun          configmap.Value[bool]   `configKey:"dry-run" configUsage:"print what needs to be done"`
+	OutputFormat    configmap.Value[string] `configKey:"output-format" configUsage:"output format (text/json), json requires --dry-run"`
+	Details         configmap.Value[bool]   `configKey:"details" configUsage:"include changed values in the json output"`
+	Path            configmap.Value[string] `configKey:"path" configUsage:"comma separated list of paths to push"`
+	Config          configmap.Value[string] `configKey:"config" configUsage:"comma separated list of {componentId}:{configId} to push"`
+	Component       configmap.Value[string] `configKey:"component" configUsage:"comma separated list of component IDs to push"`
 	Encrypt         configmap.Value[bool]   `configKey:"encrypt" configUsage:"encrypt unencrypted values before push"`
 }
 
 func DefaultFlags() Flags {
-	return Flags{}
+	return Flags{
+		OutputFormat: configmap.NewValue(diff.OutputFormatText),
+	}
 }
 
 func Command(p dependencies.Provider) *cobra.Command {
@@ -41,6 +54,10 @@ func Command(p dependencies.Provider) *cobra.Command {
 				return err
 			}
 
+			if err := validateOutputFormat(f.OutputFormat.Value, f.DryRun.Value, f.Details.Value); err != nil {
+				return err
+			}
+
 			// Get dependencies
 			d, err := p.RemoteCommandScope(cmd.Context(), f.StorageAPIHost, f.StorageAPIToken)
 			if err != nil {
@@ -94,6 +111,18 @@ func Command(p dependencies.Provider) *cobra.Command {
 				changeDescription = args[0]
 			}
 
+			// Selection, paths are relative to the working directory
+			var paths []string
+			for path := range strings.SplitSeq(f.Path.Value, ",") {
+				if path = strings.TrimSpace(path); path != "" {
+					paths = append(paths, filesystem.Join(prj.Fs().WorkingDir(), path))
+				}
+			}
+			selection, err := diffop.NewSelection(paths, strings.Split(f.Config.Value, ","), strings.Split(f.Component.Value, ","))
+			if err != nil {
+				return err
+			}
+
 			// Options
 			options := push.Options{
 				Encrypt:           f.Encrypt.Value,
@@ -101,6 +130,10 @@ func Command(p dependencies.Provider) *cobra.Command {
 				AllowRemoteDelete: f.Force.Value,
 				LogUntrackedPaths: true,
 				ChangeDescription: changeDescription,
+				OutputFormat:      f.OutputFormat.Value,
+				Details:           f.Details.Value,
+				Selection:         selection,
+				CreateSnapshot:    true,
 			}
 
 			// Send cmd successful/failed event
@@ -116,3 +149,16 @@ func Command(p dependencies.Provider) *cobra.Command {
 
 	return cmd
 }
+
+func validateOutputFormat(format string, dryRun bool, details bool) error {
+	if !diff.IsValidOutputFormat(format) {
+		return errors.Errorf(`invalid output format "%s"`, format)
+	}
+	if format == diff.OutputFormatJSON && !dryRun {
+		return errors.Errorf(`output format "%s" can be used only with the "--dry-run" flag`, format)
+	}
+	if details && format != diff.OutputFormatJSON {
+		return errors.New(`the "--details" flag can be used only with the "--output-format json" flag`)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package rollback
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/cli/helpmsg"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configmap"
+	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/sync/rollback"
+)
+
+type Flags struct {
+	StorageAPIHost  configmap.Value[string] `configKey:"storage-api-host" configShorthand:"H" configUsage:"storage API host, eg. \"connection.keboola.com\""`
+	StorageAPIToken configmap.Value[string] `configKey:"storage-api-token" configShorthand:"t" configUsage:"storage API token from your project"`
+	DryRun          configmap.Value[bool]   `configKey:"dry-run" configUsage:"print what needs to be done"`
+}
+
+func DefaultFlags() Flags {
+	return Flags{}
+}
+
+func Command(p dependencies.Provider) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   `rollback [push-id]`,
+		Short: helpmsg.Read(`sync/rollback/short`),
+		Long:  helpmsg.Read(`sync/rollback/long`),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) (cmdErr error) {
+			// Command must be used in project directory
+			fs, _, err := p.BaseScope().FsInfo().ProjectDir(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			f := Flags{}
+			if err = p.BaseScope().ConfigBinder().Bind(cmd.Context(), cmd.Flags(), args, &f); err != nil {
+				return err
+			}
+
+			// List snapshots, if the push ID is not set
+			if len(args) == 0 {
+				return rollback.List(cmd.Context(), fs, p.BaseScope().Stdout())
+			}
+
+			// Get dependencies
+			d, err := p.RemoteCommandScope(cmd.Context(), f.StorageAPIHost, f.StorageAPIToken)
+			if err != nil {
+				return err
+			}
+
+			// Get local project
+			prj, _, err := d.LocalProject(cmd.Context(), false)
+			if err != nil {
+				return err
+			}
+
+			// Send cmd successful/failed event
+			defer d.EventSender().SendCmdEvent(cmd.Context(), d.Clock().Now(), &cmdErr, "sync-rollback")
+
+			// Rollback
+			return rollback.Run(cmd.Context(), prj.Fs(), rollback.Options{PushID: args[0], DryRun: f.DryRun.Value}, d)
+		},
+	}
+
+	// Flags
+	configmap.MustGenerateFlags(cmd.Flags(), DefaultFlags())
+
+	return cmd
+}
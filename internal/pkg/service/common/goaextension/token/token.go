@@ -92,7 +92,10 @@ func AddTokenHeaderToPayloads(tokenScheme *expr.SchemeExpr, field, header string
 							// Payload is a user type.
 							// Convert it to an objects that extend the user type,
 							// so the APIKey can be added there.
+							// Examples of the user type are not inherited by the Extend, so they are copied.
+							examples := method.Payload.ExtractUserExamples()
 							dsl.Payload(func() { dsl.Extend(t) })
+							method.Payload.UserExamples = examples
 						}
 						if method.Payload.Type == expr.Empty {
 							// Payload is the empty type -> convert it to an empty object.
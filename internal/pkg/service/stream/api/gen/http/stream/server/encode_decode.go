@@ -19,6 +19,7 @@ import (
 
 	stream "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
 	goahttp "goa.design/goa/v3/http"
 	goa "goa.design/goa/v3/pkg"
 )
@@ -3118,6 +3119,128 @@ func EncodeUndeleteSinkError(encoder func(context.Context, http.ResponseWriter)
 	}
 }
 
+// EncodeReimportSinkFilesResponse returns an encoder for responses returned by
+// the stream ReimportSinkFiles endpoint.
+func EncodeReimportSinkFilesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*stream.Task)
+		enc := encoder(ctx, w)
+		body := NewReimportSinkFilesResponseBody(res)
+		w.WriteHeader(http.StatusAccepted)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeReimportSinkFilesRequest returns a decoder for requests sent to the
+// stream ReimportSinkFiles endpoint.
+func DecodeReimportSinkFilesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*stream.ReimportSinkFilesPayload, error) {
+	return func(r *http.Request) (*stream.ReimportSinkFilesPayload, error) {
+		var payload *stream.ReimportSinkFilesPayload
+		var (
+			body ReimportSinkFilesRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return payload, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return payload, gerr
+			}
+			return payload, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateReimportSinkFilesRequestBody(&body, []string{"body"})
+		if err != nil {
+			return payload, err
+		}
+
+		var (
+			branchID        string
+			sourceID        string
+			sinkID          string
+			storageAPIToken string
+
+			params = mux.Vars(r)
+		)
+		branchID = params["branchId"]
+		sourceID = params["sourceId"]
+		if utf8.RuneCountInString(sourceID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 1, true))
+		}
+		if utf8.RuneCountInString(sourceID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 48, false))
+		}
+		sinkID = params["sinkId"]
+		if utf8.RuneCountInString(sinkID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 1, true))
+		}
+		if utf8.RuneCountInString(sinkID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 48, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return payload, err
+		}
+		payload = NewReimportSinkFilesPayload(&body, branchID, sourceID, sinkID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeReimportSinkFilesError returns an encoder for errors returned by the
+// ReimportSinkFiles stream endpoint.
+func EncodeReimportSinkFilesError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.sourceNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewReimportSinkFilesStreamAPISourceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.sinkNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewReimportSinkFilesStreamAPISinkNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
 // EncodeListSinkVersionsResponse returns an encoder for responses returned by
 // the stream ListSinkVersions endpoint.
 func EncodeListSinkVersionsResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
@@ -3479,24 +3602,749 @@ func EncodeRollbackSinkVersionError(encoder func(context.Context, http.ResponseW
 			if false { // formatter != nil {
 				body = formatter(ctx, res)
 			} else {
-				body = NewRollbackSinkVersionStreamAPISinkNotFoundResponseBody(res)
+				body = NewRollbackSinkVersionStreamAPISinkNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.versionNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewRollbackSinkVersionStreamAPIVersionNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeListSinkDeadLettersResponse returns an encoder for responses returned
+// by the stream ListSinkDeadLetters endpoint.
+func EncodeListSinkDeadLettersResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*stream.SinkDeadLetterRecordsList)
+		enc := encoder(ctx, w)
+		body := NewListSinkDeadLettersResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeListSinkDeadLettersRequest returns a decoder for requests sent to the
+// stream ListSinkDeadLetters endpoint.
+func DecodeListSinkDeadLettersRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*stream.ListSinkDeadLettersPayload, error) {
+	return func(r *http.Request) (*stream.ListSinkDeadLettersPayload, error) {
+		var payload *stream.ListSinkDeadLettersPayload
+		var (
+			branchID        string
+			sourceID        string
+			sinkID          string
+			afterID         string
+			limit           int
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branchID = params["branchId"]
+		sourceID = params["sourceId"]
+		if utf8.RuneCountInString(sourceID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 1, true))
+		}
+		if utf8.RuneCountInString(sourceID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 48, false))
+		}
+		sinkID = params["sinkId"]
+		if utf8.RuneCountInString(sinkID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 1, true))
+		}
+		if utf8.RuneCountInString(sinkID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 48, false))
+		}
+		qp := r.URL.Query()
+		afterIDRaw := qp.Get("afterId")
+		if afterIDRaw != "" {
+			afterID = afterIDRaw
+		}
+		{
+			limitRaw := qp.Get("limit")
+			if limitRaw == "" {
+				limit = 100
+			} else {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				limit = int(v)
+			}
+		}
+		if limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+		}
+		if limit > 100 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 100, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return payload, err
+		}
+		payload = NewListSinkDeadLettersPayload(branchID, sourceID, sinkID, afterID, limit, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeListSinkDeadLettersError returns an encoder for errors returned by the
+// ListSinkDeadLetters stream endpoint.
+func EncodeListSinkDeadLettersError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.sourceNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewListSinkDeadLettersStreamAPISourceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.sinkNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewListSinkDeadLettersStreamAPISinkNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeGetSinkDeadLetterResponse returns an encoder for responses returned by
+// the stream GetSinkDeadLetter endpoint.
+func EncodeGetSinkDeadLetterResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*stream.SinkDeadLetterRecord)
+		enc := encoder(ctx, w)
+		body := NewGetSinkDeadLetterResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeGetSinkDeadLetterRequest returns a decoder for requests sent to the
+// stream GetSinkDeadLetter endpoint.
+func DecodeGetSinkDeadLetterRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*stream.GetSinkDeadLetterPayload, error) {
+	return func(r *http.Request) (*stream.GetSinkDeadLetterPayload, error) {
+		var payload *stream.GetSinkDeadLetterPayload
+		var (
+			branchID        string
+			sourceID        string
+			sinkID          string
+			recordID        string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branchID = params["branchId"]
+		sourceID = params["sourceId"]
+		if utf8.RuneCountInString(sourceID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 1, true))
+		}
+		if utf8.RuneCountInString(sourceID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 48, false))
+		}
+		sinkID = params["sinkId"]
+		if utf8.RuneCountInString(sinkID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 1, true))
+		}
+		if utf8.RuneCountInString(sinkID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 48, false))
+		}
+		recordID = params["recordId"]
+		if utf8.RuneCountInString(recordID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("recordId", recordID, utf8.RuneCountInString(recordID), 1, true))
+		}
+		if utf8.RuneCountInString(recordID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("recordId", recordID, utf8.RuneCountInString(recordID), 48, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return payload, err
+		}
+		payload = NewGetSinkDeadLetterPayload(branchID, sourceID, sinkID, recordID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeGetSinkDeadLetterError returns an encoder for errors returned by the
+// GetSinkDeadLetter stream endpoint.
+func EncodeGetSinkDeadLetterError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.sourceNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetSinkDeadLetterStreamAPISourceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.sinkNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetSinkDeadLetterStreamAPISinkNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.recordNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewGetSinkDeadLetterStreamAPIRecordNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeReplaySinkDeadLetterResponse returns an encoder for responses returned
+// by the stream ReplaySinkDeadLetter endpoint.
+func EncodeReplaySinkDeadLetterResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusAccepted)
+		return nil
+	}
+}
+
+// DecodeReplaySinkDeadLetterRequest returns a decoder for requests sent to the
+// stream ReplaySinkDeadLetter endpoint.
+func DecodeReplaySinkDeadLetterRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*stream.ReplaySinkDeadLetterPayload, error) {
+	return func(r *http.Request) (*stream.ReplaySinkDeadLetterPayload, error) {
+		var payload *stream.ReplaySinkDeadLetterPayload
+		var (
+			branchID        string
+			sourceID        string
+			sinkID          string
+			recordID        string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branchID = params["branchId"]
+		sourceID = params["sourceId"]
+		if utf8.RuneCountInString(sourceID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 1, true))
+		}
+		if utf8.RuneCountInString(sourceID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 48, false))
+		}
+		sinkID = params["sinkId"]
+		if utf8.RuneCountInString(sinkID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 1, true))
+		}
+		if utf8.RuneCountInString(sinkID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 48, false))
+		}
+		recordID = params["recordId"]
+		if utf8.RuneCountInString(recordID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("recordId", recordID, utf8.RuneCountInString(recordID), 1, true))
+		}
+		if utf8.RuneCountInString(recordID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("recordId", recordID, utf8.RuneCountInString(recordID), 48, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return payload, err
+		}
+		payload = NewReplaySinkDeadLetterPayload(branchID, sourceID, sinkID, recordID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeReplaySinkDeadLetterError returns an encoder for errors returned by
+// the ReplaySinkDeadLetter stream endpoint.
+func EncodeReplaySinkDeadLetterError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.sourceNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewReplaySinkDeadLetterStreamAPISourceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.sinkNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewReplaySinkDeadLetterStreamAPISinkNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.recordNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewReplaySinkDeadLetterStreamAPIRecordNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.invalidColumnValue":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusUnprocessableEntity
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewReplaySinkDeadLetterStreamAPIInvalidColumnValueResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodePurgeSinkDeadLettersResponse returns an encoder for responses returned
+// by the stream PurgeSinkDeadLetters endpoint.
+func EncodePurgeSinkDeadLettersResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+
+// DecodePurgeSinkDeadLettersRequest returns a decoder for requests sent to the
+// stream PurgeSinkDeadLetters endpoint.
+func DecodePurgeSinkDeadLettersRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*stream.PurgeSinkDeadLettersPayload, error) {
+	return func(r *http.Request) (*stream.PurgeSinkDeadLettersPayload, error) {
+		var payload *stream.PurgeSinkDeadLettersPayload
+		var (
+			branchID        string
+			sourceID        string
+			sinkID          string
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branchID = params["branchId"]
+		sourceID = params["sourceId"]
+		if utf8.RuneCountInString(sourceID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 1, true))
+		}
+		if utf8.RuneCountInString(sourceID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 48, false))
+		}
+		sinkID = params["sinkId"]
+		if utf8.RuneCountInString(sinkID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 1, true))
+		}
+		if utf8.RuneCountInString(sinkID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 48, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return payload, err
+		}
+		payload = NewPurgeSinkDeadLettersPayload(branchID, sourceID, sinkID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodePurgeSinkDeadLettersError returns an encoder for errors returned by
+// the PurgeSinkDeadLetters stream endpoint.
+func EncodePurgeSinkDeadLettersError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.sourceNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewPurgeSinkDeadLettersStreamAPISourceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.sinkNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewPurgeSinkDeadLettersStreamAPISinkNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodePreviewSinkDataResponse returns an encoder for responses returned by
+// the stream PreviewSinkData endpoint.
+func EncodePreviewSinkDataResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*stream.SinkDataPreview)
+		enc := encoder(ctx, w)
+		body := NewPreviewSinkDataResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodePreviewSinkDataRequest returns a decoder for requests sent to the
+// stream PreviewSinkData endpoint.
+func DecodePreviewSinkDataRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*stream.PreviewSinkDataPayload, error) {
+	return func(r *http.Request) (*stream.PreviewSinkDataPayload, error) {
+		var payload *stream.PreviewSinkDataPayload
+		var (
+			branchID        string
+			sourceID        string
+			sinkID          string
+			limit           int
+			storageAPIToken string
+			err             error
+
+			params = mux.Vars(r)
+		)
+		branchID = params["branchId"]
+		sourceID = params["sourceId"]
+		if utf8.RuneCountInString(sourceID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 1, true))
+		}
+		if utf8.RuneCountInString(sourceID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 48, false))
+		}
+		sinkID = params["sinkId"]
+		if utf8.RuneCountInString(sinkID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 1, true))
+		}
+		if utf8.RuneCountInString(sinkID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sinkId", sinkID, utf8.RuneCountInString(sinkID), 48, false))
+		}
+		{
+			limitRaw := r.URL.Query().Get("limit")
+			if limitRaw == "" {
+				limit = 10
+			} else {
+				v, err2 := strconv.ParseInt(limitRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("limit", limitRaw, "integer"))
+				}
+				limit = int(v)
+			}
+		}
+		if limit < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 1, true))
+		}
+		if limit > 100 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("limit", limit, 100, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return payload, err
+		}
+		payload = NewPreviewSinkDataPayload(branchID, sourceID, sinkID, limit, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodePreviewSinkDataError returns an encoder for errors returned by the
+// PreviewSinkData stream endpoint.
+func EncodePreviewSinkDataError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.sourceNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewPreviewSinkDataStreamAPISourceNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		case "stream.api.sinkNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewPreviewSinkDataStreamAPISinkNotFoundResponseBody(res)
+			}
+			w.Header().Set("goa-error", res.GoaErrorName())
+			w.WriteHeader(http.StatusNotFound)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+
+// EncodeInferSinkMappingResponse returns an encoder for responses returned by
+// the stream InferSinkMapping endpoint.
+func EncodeInferSinkMappingResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, any) error {
+	return func(ctx context.Context, w http.ResponseWriter, v any) error {
+		res, _ := v.(*stream.InferredSinkMapping)
+		enc := encoder(ctx, w)
+		body := NewInferSinkMappingResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+
+// DecodeInferSinkMappingRequest returns a decoder for requests sent to the
+// stream InferSinkMapping endpoint.
+func DecodeInferSinkMappingRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (*stream.InferSinkMappingPayload, error) {
+	return func(r *http.Request) (*stream.InferSinkMappingPayload, error) {
+		var payload *stream.InferSinkMappingPayload
+		var (
+			body InferSinkMappingRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return payload, goa.MissingPayloadError()
+			}
+			var gerr *goa.ServiceError
+			if errors.As(err, &gerr) {
+				return payload, gerr
+			}
+			return payload, goa.DecodePayloadError(err.Error())
+		}
+		err = ValidateInferSinkMappingRequestBody(&body, []string{"body"})
+		if err != nil {
+			return payload, err
+		}
+
+		var (
+			branchID        string
+			sourceID        string
+			storageAPIToken string
+
+			params = mux.Vars(r)
+		)
+		branchID = params["branchId"]
+		sourceID = params["sourceId"]
+		if utf8.RuneCountInString(sourceID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 1, true))
+		}
+		if utf8.RuneCountInString(sourceID) > 48 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("sourceId", sourceID, utf8.RuneCountInString(sourceID), 48, false))
+		}
+		storageAPIToken = r.Header.Get("X-StorageApi-Token")
+		if storageAPIToken == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-StorageApi-Token", "header"))
+		}
+		if err != nil {
+			return payload, err
+		}
+		payload = NewInferSinkMappingPayload(&body, branchID, sourceID, storageAPIToken)
+		if strings.Contains(payload.StorageAPIToken, " ") {
+			// Remove authorization scheme prefix (e.g. "Bearer")
+			cred := strings.SplitN(payload.StorageAPIToken, " ", 2)[1]
+			payload.StorageAPIToken = cred
+		}
+
+		return payload, nil
+	}
+}
+
+// EncodeInferSinkMappingError returns an encoder for errors returned by the
+// InferSinkMapping stream endpoint.
+func EncodeInferSinkMappingError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder, formatter func(ctx context.Context, err error) goahttp.Statuser) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder, formatter)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		var en goa.GoaErrorNamer
+		if !errors.As(v, &en) {
+			return encodeError(ctx, w, v)
+		}
+		switch en.GoaErrorName() {
+		case "stream.api.sourceNotFound":
+			var res *stream.GenericError
+			errors.As(v, &res)
+			res.StatusCode = http.StatusNotFound
+			enc := encoder(ctx, w)
+			var body any
+			if false { // formatter != nil {
+				body = formatter(ctx, res)
+			} else {
+				body = NewInferSinkMappingStreamAPISourceNotFoundResponseBody(res)
 			}
 			w.Header().Set("goa-error", res.GoaErrorName())
 			w.WriteHeader(http.StatusNotFound)
 			return enc.Encode(body)
-		case "stream.api.versionNotFound":
+		case "stream.api.unprocessableContent":
 			var res *stream.GenericError
 			errors.As(v, &res)
-			res.StatusCode = http.StatusNotFound
+			res.StatusCode = http.StatusUnprocessableEntity
 			enc := encoder(ctx, w)
 			var body any
 			if false { // formatter != nil {
 				body = formatter(ctx, res)
 			} else {
-				body = NewRollbackSinkVersionStreamAPIVersionNotFoundResponseBody(res)
+				body = NewInferSinkMappingStreamAPIUnprocessableContentResponseBody(res)
 			}
 			w.Header().Set("goa-error", res.GoaErrorName())
-			w.WriteHeader(http.StatusNotFound)
+			w.WriteHeader(http.StatusUnprocessableEntity)
 			return enc.Encode(body)
 		default:
 			return encodeError(ctx, w, v)
@@ -3645,6 +4493,43 @@ func DecodeAggregationSourcesRequest(mux goahttp.Muxer, decoder func(*http.Reque
 	}
 }
 
+// unmarshalKafkaSourceRequestBodyToStreamKafkaSource builds a value of type
+// *stream.KafkaSource from a value of type *KafkaSourceRequestBody.
+func unmarshalKafkaSourceRequestBodyToStreamKafkaSource(v *KafkaSourceRequestBody) *stream.KafkaSource {
+	if v == nil {
+		return nil
+	}
+	res := &stream.KafkaSource{
+		Topic:   *v.Topic,
+		GroupID: *v.GroupID,
+	}
+	res.Brokers = make([]string, len(v.Brokers))
+	for i, val := range v.Brokers {
+		res.Brokers[i] = val
+	}
+
+	return res
+}
+
+// unmarshalHTTPSourceSignatureRequestBodyToStreamHTTPSourceSignature builds a
+// value of type *stream.HTTPSourceSignature from a value of type
+// *HTTPSourceSignatureRequestBody.
+func unmarshalHTTPSourceSignatureRequestBodyToStreamHTTPSourceSignature(v *HTTPSourceSignatureRequestBody) *stream.HTTPSourceSignature {
+	if v == nil {
+		return nil
+	}
+	res := &stream.HTTPSourceSignature{
+		Algorithm:                 *v.Algorithm,
+		Format:                    *v.Format,
+		Header:                    *v.Header,
+		Secret:                    v.Secret,
+		TimestampToleranceSeconds: v.TimestampToleranceSeconds,
+		ReplayWindowSeconds:       v.ReplayWindowSeconds,
+	}
+
+	return res
+}
+
 // marshalStreamTaskOutputsToTaskOutputsResponseBody builds a value of type
 // *TaskOutputsResponseBody from a value of type *stream.TaskOutputs.
 func marshalStreamTaskOutputsToTaskOutputsResponseBody(v *stream.TaskOutputs) *TaskOutputsResponseBody {
@@ -3705,6 +4590,9 @@ func marshalStreamSourceToSourceResponseBody(v *stream.Source) *SourceResponseBo
 	if v.Otlp != nil {
 		res.Otlp = marshalStreamOTLPSourceToOTLPSourceResponseBody(v.Otlp)
 	}
+	if v.Kafka != nil {
+		res.Kafka = marshalStreamKafkaSourceToKafkaSourceResponseBody(v.Kafka)
+	}
 	if v.Version != nil {
 		res.Version = marshalStreamVersionToVersionResponseBody(v.Version)
 	}
@@ -3730,6 +4618,28 @@ func marshalStreamHTTPSourceToHTTPSourceResponseBody(v *stream.HTTPSource) *HTTP
 	res := &HTTPSourceResponseBody{
 		URL: v.URL,
 	}
+	if v.Signature != nil {
+		res.Signature = marshalStreamHTTPSourceSignatureToHTTPSourceSignatureResponseBody(v.Signature)
+	}
+
+	return res
+}
+
+// marshalStreamHTTPSourceSignatureToHTTPSourceSignatureResponseBody builds a
+// value of type *HTTPSourceSignatureResponseBody from a value of type
+// *stream.HTTPSourceSignature.
+func marshalStreamHTTPSourceSignatureToHTTPSourceSignatureResponseBody(v *stream.HTTPSourceSignature) *HTTPSourceSignatureResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &HTTPSourceSignatureResponseBody{
+		Algorithm:                 v.Algorithm,
+		Format:                    v.Format,
+		Header:                    v.Header,
+		Secret:                    v.Secret,
+		TimestampToleranceSeconds: v.TimestampToleranceSeconds,
+		ReplayWindowSeconds:       v.ReplayWindowSeconds,
+	}
 
 	return res
 }
@@ -3749,6 +4659,28 @@ func marshalStreamOTLPSourceToOTLPSourceResponseBody(v *stream.OTLPSource) *OTLP
 	return res
 }
 
+// marshalStreamKafkaSourceToKafkaSourceResponseBody builds a value of type
+// *KafkaSourceResponseBody from a value of type *stream.KafkaSource.
+func marshalStreamKafkaSourceToKafkaSourceResponseBody(v *stream.KafkaSource) *KafkaSourceResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &KafkaSourceResponseBody{
+		Topic:   v.Topic,
+		GroupID: v.GroupID,
+	}
+	if v.Brokers != nil {
+		res.Brokers = make([]string, len(v.Brokers))
+		for i, val := range v.Brokers {
+			res.Brokers[i] = val
+		}
+	} else {
+		res.Brokers = []string{}
+	}
+
+	return res
+}
+
 // marshalStreamVersionToVersionResponseBody builds a value of type
 // *VersionResponseBody from a value of type *stream.Version.
 func marshalStreamVersionToVersionResponseBody(v *stream.Version) *VersionResponseBody {
@@ -3915,6 +4847,32 @@ func marshalStreamTestResultColumnToTestResultColumnResponseBody(v *stream.TestR
 	return res
 }
 
+// marshalStreamTestResultSinkToTestResultSinkResponseBody builds a value of
+// type *TestResultSinkResponseBody from a value of type *stream.TestResultSink.
+func marshalStreamTestResultSinkToTestResultSinkResponseBody(v *stream.TestResultSink) *TestResultSinkResponseBody {
+	res := &TestResultSinkResponseBody{
+		SinkID:   string(v.SinkID),
+		Accepted: v.Accepted,
+	}
+
+	return res
+}
+
+// marshalStreamTestResultSignatureToTestResultSignatureResponseBody builds a
+// value of type *TestResultSignatureResponseBody from a value of type
+// *stream.TestResultSignature.
+func marshalStreamTestResultSignatureToTestResultSignatureResponseBody(v *stream.TestResultSignature) *TestResultSignatureResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &TestResultSignatureResponseBody{
+		Valid:   v.Valid,
+		Message: v.Message,
+	}
+
+	return res
+}
+
 // unmarshalTableSinkCreateRequestBodyToStreamTableSinkCreate builds a value of
 // type *stream.TableSinkCreate from a value of type
 // *TableSinkCreateRequestBody.
@@ -3927,6 +4885,12 @@ func unmarshalTableSinkCreateRequestBodyToStreamTableSinkCreate(v *TableSinkCrea
 		TableID: stream.TableID(*v.TableID),
 	}
 	res.Mapping = unmarshalTableMappingRequestBodyToStreamTableMapping(v.Mapping)
+	if v.Partitioning != nil {
+		res.Partitioning = unmarshalTablePartitioningRequestBodyToStreamTablePartitioning(v.Partitioning)
+	}
+	if v.Mirror != nil {
+		res.Mirror = unmarshalTableMirrorRequestBodyToStreamTableMirror(v.Mirror)
+	}
 
 	return res
 }
@@ -3960,6 +4924,9 @@ func unmarshalTableColumnRequestBodyToStreamTableColumn(v *TableColumnRequestBod
 	if v.Template != nil {
 		res.Template = unmarshalTableColumnTemplateRequestBodyToStreamTableColumnTemplate(v.Template)
 	}
+	if v.Cast != nil {
+		res.Cast = unmarshalTableColumnCastRequestBodyToStreamTableColumnCast(v.Cast)
+	}
 
 	return res
 }
@@ -3979,6 +4946,98 @@ func unmarshalTableColumnTemplateRequestBodyToStreamTableColumnTemplate(v *Table
 	return res
 }
 
+// unmarshalTableColumnCastRequestBodyToStreamTableColumnCast builds a value of
+// type *stream.TableColumnCast from a value of type
+// *TableColumnCastRequestBody.
+func unmarshalTableColumnCastRequestBodyToStreamTableColumnCast(v *TableColumnCastRequestBody) *stream.TableColumnCast {
+	if v == nil {
+		return nil
+	}
+	res := &stream.TableColumnCast{
+		DataType:     *v.DataType,
+		DefaultValue: v.DefaultValue,
+	}
+	if v.OnError != nil {
+		res.OnError = *v.OnError
+	}
+	if v.OnError == nil {
+		res.OnError = "fail"
+	}
+
+	return res
+}
+
+// unmarshalTablePartitioningRequestBodyToStreamTablePartitioning builds a
+// value of type *stream.TablePartitioning from a value of type
+// *TablePartitioningRequestBody.
+func unmarshalTablePartitioningRequestBodyToStreamTablePartitioning(v *TablePartitioningRequestBody) *stream.TablePartitioning {
+	if v == nil {
+		return nil
+	}
+	res := &stream.TablePartitioning{
+		Granularity: stream.TablePartitionGranularity(*v.Granularity),
+		Column:      *v.Column,
+	}
+
+	return res
+}
+
+// unmarshalTableMirrorRequestBodyToStreamTableMirror builds a value of type
+// *stream.TableMirror from a value of type *TableMirrorRequestBody.
+func unmarshalTableMirrorRequestBodyToStreamTableMirror(v *TableMirrorRequestBody) *stream.TableMirror {
+	if v == nil {
+		return nil
+	}
+	res := &stream.TableMirror{
+		Type:    stream.TableType(*v.Type),
+		TableID: stream.TableID(*v.TableID),
+	}
+	if v.Mapping != nil {
+		res.Mapping = unmarshalTableMappingRequestBodyToStreamTableMapping(v.Mapping)
+	}
+
+	return res
+}
+
+// unmarshalWebhookSinkCreateRequestBodyToStreamWebhookSinkCreate builds a
+// value of type *stream.WebhookSinkCreate from a value of type
+// *WebhookSinkCreateRequestBody.
+func unmarshalWebhookSinkCreateRequestBodyToStreamWebhookSinkCreate(v *WebhookSinkCreateRequestBody) *stream.WebhookSinkCreate {
+	if v == nil {
+		return nil
+	}
+	res := &stream.WebhookSinkCreate{
+		URL: *v.URL,
+	}
+	if v.Headers != nil {
+		res.Headers = make([]*stream.TableColumn, len(v.Headers))
+		for i, val := range v.Headers {
+			if val == nil {
+				res.Headers[i] = nil
+				continue
+			}
+			res.Headers[i] = unmarshalTableColumnRequestBodyToStreamTableColumn(val)
+		}
+	}
+	res.Mapping = unmarshalTableMappingRequestBodyToStreamTableMapping(v.Mapping)
+
+	return res
+}
+
+// unmarshalFileSinkCreateRequestBodyToStreamFileSinkCreate builds a value of
+// type *stream.FileSinkCreate from a value of type *FileSinkCreateRequestBody.
+func unmarshalFileSinkCreateRequestBodyToStreamFileSinkCreate(v *FileSinkCreateRequestBody) *stream.FileSinkCreate {
+	if v == nil {
+		return nil
+	}
+	res := &stream.FileSinkCreate{
+		Provider: stream.StagingProvider(*v.Provider),
+	}
+	res.Mapping = unmarshalTableMappingRequestBodyToStreamTableMapping(v.Mapping)
+
+	return res
+}
+
 // marshalStreamTableSinkToTableSinkResponseBody builds a value of type
 // *TableSinkResponseBody from a value of type *stream.TableSink.
 func marshalStreamTableSinkToTableSinkResponseBody(v *stream.TableSink) *TableSinkResponseBody {
@@ -3992,6 +5051,12 @@ func marshalStreamTableSinkToTableSinkResponseBody(v *stream.TableSink) *TableSi
 	if v.Mapping != nil {
 		res.Mapping = marshalStreamTableMappingToTableMappingResponseBody(v.Mapping)
 	}
+	if v.Partitioning != nil {
+		res.Partitioning = marshalStreamTablePartitioningToTablePartitioningResponseBody(v.Partitioning)
+	}
+	if v.Mirror != nil {
+		res.Mirror = marshalStreamTableMirrorToTableMirrorResponseBody(v.Mirror)
+	}
 
 	return res
 }
@@ -4029,6 +5094,9 @@ func marshalStreamTableColumnToTableColumnResponseBody(v *stream.TableColumn) *T
 	if v.Template != nil {
 		res.Template = marshalStreamTableColumnTemplateToTableColumnTemplateResponseBody(v.Template)
 	}
+	if v.Cast != nil {
+		res.Cast = marshalStreamTableColumnCastToTableColumnCastResponseBody(v.Cast)
+	}
 
 	return res
 }
@@ -4048,6 +5116,102 @@ func marshalStreamTableColumnTemplateToTableColumnTemplateResponseBody(v *stream
 	return res
 }
 
+// marshalStreamTableColumnCastToTableColumnCastResponseBody builds a value of
+// type *TableColumnCastResponseBody from a value of type
+// *stream.TableColumnCast.
+func marshalStreamTableColumnCastToTableColumnCastResponseBody(v *stream.TableColumnCast) *TableColumnCastResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &TableColumnCastResponseBody{
+		DataType:     v.DataType,
+		OnError:      v.OnError,
+		DefaultValue: v.DefaultValue,
+	}
+	{
+		var zero column.CastErrorPolicy
+		if res.OnError == zero {
+			res.OnError = "fail"
+		}
+	}
+
+	return res
+}
+
+// marshalStreamTablePartitioningToTablePartitioningResponseBody builds a value
+// of type *TablePartitioningResponseBody from a value of type
+// *stream.TablePartitioning.
+func marshalStreamTablePartitioningToTablePartitioningResponseBody(v *stream.TablePartitioning) *TablePartitioningResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &TablePartitioningResponseBody{
+		Granularity: string(v.Granularity),
+		Column:      v.Column,
+	}
+
+	return res
+}
+
+// marshalStreamTableMirrorToTableMirrorResponseBody builds a value of type
+// *TableMirrorResponseBody from a value of type *stream.TableMirror.
+func marshalStreamTableMirrorToTableMirrorResponseBody(v *stream.TableMirror) *TableMirrorResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &TableMirrorResponseBody{
+		Type:    string(v.Type),
+		TableID: string(v.TableID),
+	}
+	if v.Mapping != nil {
+		res.Mapping = marshalStreamTableMappingToTableMappingResponseBody(v.Mapping)
+	}
+
+	return res
+}
+
+// marshalStreamWebhookSinkToWebhookSinkResponseBody builds a value of type
+// *WebhookSinkResponseBody from a value of type *stream.WebhookSink.
+func marshalStreamWebhookSinkToWebhookSinkResponseBody(v *stream.WebhookSink) *WebhookSinkResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &WebhookSinkResponseBody{
+		URL: v.URL,
+	}
+	if v.Headers != nil {
+		res.Headers = make([]*TableColumnResponseBody, len(v.Headers))
+		for i, val := range v.Headers {
+			if val == nil {
+				res.Headers[i] = nil
+				continue
+			}
+			res.Headers[i] = marshalStreamTableColumnToTableColumnResponseBody(val)
+		}
+	}
+	if v.Mapping != nil {
+		res.Mapping = marshalStreamTableMappingToTableMappingResponseBody(v.Mapping)
+	}
+
+	return res
+}
+
+// marshalStreamFileSinkToFileSinkResponseBody builds a value of type
+// *FileSinkResponseBody from a value of type *stream.FileSink.
+func marshalStreamFileSinkToFileSinkResponseBody(v *stream.FileSink) *FileSinkResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &FileSinkResponseBody{
+		Provider: string(v.Provider),
+	}
+	if v.Mapping != nil {
+		res.Mapping = marshalStreamTableMappingToTableMappingResponseBody(v.Mapping)
+	}
+
+	return res
+}
+
 // marshalStreamSinkToSinkResponseBody builds a value of type *SinkResponseBody
 // from a value of type *stream.Sink.
 func marshalStreamSinkToSinkResponseBody(v *stream.Sink) *SinkResponseBody {
@@ -4059,6 +5223,8 @@ func marshalStreamSinkToSinkResponseBody(v *stream.Sink) *SinkResponseBody {
 		Type:        string(v.Type),
 		Name:        v.Name,
 		Description: v.Description,
+		Filter:      v.Filter,
+		DeadLetter:  v.DeadLetter,
 	}
 	if v.AllowedSignals != nil {
 		res.AllowedSignals = make([]string, len(v.AllowedSignals))
@@ -4069,6 +5235,12 @@ func marshalStreamSinkToSinkResponseBody(v *stream.Sink) *SinkResponseBody {
 	if v.Table != nil {
 		res.Table = marshalStreamTableSinkToTableSinkResponseBody(v.Table)
 	}
+	if v.Webhook != nil {
+		res.Webhook = marshalStreamWebhookSinkToWebhookSinkResponseBody(v.Webhook)
+	}
+	if v.File != nil {
+		res.File = marshalStreamFileSinkToFileSinkResponseBody(v.File)
+	}
 	if v.Version != nil {
 		res.Version = marshalStreamVersionToVersionResponseBody(v.Version)
 	}
@@ -4092,7 +5264,9 @@ func unmarshalTableSinkUpdateRequestBodyToStreamTableSinkUpdate(v *TableSinkUpda
 	if v == nil {
 		return nil
 	}
-	res := &stream.TableSinkUpdate{}
+	res := &stream.TableSinkUpdate{
+		RemoveMirror: v.RemoveMirror,
+	}
 	if v.Type != nil {
 		type_ := stream.TableType(*v.Type)
 		res.Type = &type_
@@ -4104,6 +5278,57 @@ func unmarshalTableSinkUpdateRequestBodyToStreamTableSinkUpdate(v *TableSinkUpda
 	if v.Mapping != nil {
 		res.Mapping = unmarshalTableMappingRequestBodyToStreamTableMapping(v.Mapping)
 	}
+	if v.Partitioning != nil {
+		res.Partitioning = unmarshalTablePartitioningRequestBodyToStreamTablePartitioning(v.Partitioning)
+	}
+	if v.Mirror != nil {
+		res.Mirror = unmarshalTableMirrorRequestBodyToStreamTableMirror(v.Mirror)
+	}
+
+	return res
+}
+
+// unmarshalWebhookSinkUpdateRequestBodyToStreamWebhookSinkUpdate builds a
+// value of type *stream.WebhookSinkUpdate from a value of type
+// *WebhookSinkUpdateRequestBody.
+func unmarshalWebhookSinkUpdateRequestBodyToStreamWebhookSinkUpdate(v *WebhookSinkUpdateRequestBody) *stream.WebhookSinkUpdate {
+	if v == nil {
+		return nil
+	}
+	res := &stream.WebhookSinkUpdate{
+		URL: v.URL,
+	}
+	if v.Headers != nil {
+		res.Headers = make([]*stream.TableColumn, len(v.Headers))
+		for i, val := range v.Headers {
+			if val == nil {
+				res.Headers[i] = nil
+				continue
+			}
+			res.Headers[i] = unmarshalTableColumnRequestBodyToStreamTableColumn(val)
+		}
+	}
+	if v.Mapping != nil {
+		res.Mapping = unmarshalTableMappingRequestBodyToStreamTableMapping(v.Mapping)
+	}
+
+	return res
+}
+
+// unmarshalFileSinkUpdateRequestBodyToStreamFileSinkUpdate builds a value of
+// type *stream.FileSinkUpdate from a value of type *FileSinkUpdateRequestBody.
+func unmarshalFileSinkUpdateRequestBodyToStreamFileSinkUpdate(v *FileSinkUpdateRequestBody) *stream.FileSinkUpdate {
+	if v == nil {
+		return nil
+	}
+	res := &stream.FileSinkUpdate{}
+	if v.Provider != nil {
+		provider := stream.StagingProvider(*v.Provider)
+		res.Provider = &provider
+	}
+	if v.Mapping != nil {
+		res.Mapping = unmarshalTableMappingRequestBodyToStreamTableMapping(v.Mapping)
+	}
 
 	return res
 }
@@ -4151,6 +5376,7 @@ func marshalStreamSinkFileToSinkFileResponseBody(v *stream.SinkFile) *SinkFileRe
 		RetryAttempt: v.RetryAttempt,
 		RetryReason:  v.RetryReason,
 		RetryAfter:   v.RetryAfter,
+		Partition:    v.Partition,
 	}
 	if v.Statistics != nil {
 		res.Statistics = marshalStreamSinkFileStatisticsToSinkFileStatisticsResponseBody(v.Statistics)
@@ -4177,6 +5403,86 @@ func marshalStreamSinkFileStatisticsToSinkFileStatisticsResponseBody(v *stream.S
 	return res
 }
 
+// marshalStreamSinkPartitionToSinkPartitionResponseBody builds a value of type
+// *SinkPartitionResponseBody from a value of type *stream.SinkPartition.
+func marshalStreamSinkPartitionToSinkPartitionResponseBody(v *stream.SinkPartition) *SinkPartitionResponseBody {
+	if v == nil {
+		return nil
+	}
+	res := &SinkPartitionResponseBody{
+		Partition:  v.Partition,
+		FilesCount: v.FilesCount,
+	}
+	if v.Statistics != nil {
+		res.Statistics = marshalStreamSinkFileStatisticsToSinkFileStatisticsResponseBody(v.Statistics)
+	}
+
+	return res
+}
+
+// marshalStreamSinkDeadLetterRecordToSinkDeadLetterRecordResponseBody builds a
+// value of type *SinkDeadLetterRecordResponseBody from a value of type
+// *stream.SinkDeadLetterRecord.
+func marshalStreamSinkDeadLetterRecordToSinkDeadLetterRecordResponseBody(v *stream.SinkDeadLetterRecord) *SinkDeadLetterRecordResponseBody {
+	res := &SinkDeadLetterRecordResponseBody{
+		ProjectID:       int(v.ProjectID),
+		BranchID:        int(v.BranchID),
+		SourceID:        string(v.SourceID),
+		SinkID:          string(v.SinkID),
+		RecordID:        string(v.RecordID),
+		ReceivedAt:      v.ReceivedAt,
+		FailedAt:        v.FailedAt,
+		Error:           v.Error,
+		ClientIP:        v.ClientIP,
+		Body:            v.Body,
+		BodyTruncated:   v.BodyTruncated,
+		ReplayRequested: v.ReplayRequested,
+	}
+	if v.Headers != nil {
+		res.Headers = make(map[string]string, len(v.Headers))
+		for key, val := range v.Headers {
+			tk := key
+			tv := val
+			res.Headers[tk] = tv
+		}
+	}
+
+	return res
+}
+
+// marshalStreamSinkDataPreviewRowToSinkDataPreviewRowResponseBody builds a
+// value of type *SinkDataPreviewRowResponseBody from a value of type
+// *stream.SinkDataPreviewRow.
+func marshalStreamSinkDataPreviewRowToSinkDataPreviewRowResponseBody(v *stream.SinkDataPreviewRow) *SinkDataPreviewRowResponseBody {
+	res := &SinkDataPreviewRowResponseBody{}
+	if v.Columns != nil {
+		res.Columns = make([]*SinkDataPreviewColumnResponseBody, len(v.Columns))
+		for i, val := range v.Columns {
+			if val == nil {
+				res.Columns[i] = nil
+				continue
+			}
+			res.Columns[i] = marshalStreamSinkDataPreviewColumnToSinkDataPreviewColumnResponseBody(val)
+		}
+	} else {
+		res.Columns = []*SinkDataPreviewColumnResponseBody{}
+	}
+
+	return res
+}
+
+// marshalStreamSinkDataPreviewColumnToSinkDataPreviewColumnResponseBody builds
+// a value of type *SinkDataPreviewColumnResponseBody from a value of type
+// *stream.SinkDataPreviewColumn.
+func marshalStreamSinkDataPreviewColumnToSinkDataPreviewColumnResponseBody(v *stream.SinkDataPreviewColumn) *SinkDataPreviewColumnResponseBody {
+	res := &SinkDataPreviewColumnResponseBody{
+		Name:  v.Name,
+		Value: v.Value,
+	}
+
+	return res
+}
+
 // marshalStreamAggregatedSourceToAggregatedSourceResponseBody builds a value
 // of type *AggregatedSourceResponseBody from a value of type
 // *stream.AggregatedSource.
@@ -4195,6 +5501,9 @@ func marshalStreamAggregatedSourceToAggregatedSourceResponseBody(v *stream.Aggre
 	if v.Otlp != nil {
 		res.Otlp = marshalStreamOTLPSourceToOTLPSourceResponseBody(v.Otlp)
 	}
+	if v.Kafka != nil {
+		res.Kafka = marshalStreamKafkaSourceToKafkaSourceResponseBody(v.Kafka)
+	}
 	if v.Version != nil {
 		res.Version = marshalStreamVersionToVersionResponseBody(v.Version)
 	}
@@ -4234,6 +5543,8 @@ func marshalStreamAggregatedSinkToAggregatedSinkResponseBody(v *stream.Aggregate
 		Type:        string(v.Type),
 		Name:        v.Name,
 		Description: v.Description,
+		Filter:      v.Filter,
+		DeadLetter:  v.DeadLetter,
 	}
 	if v.AllowedSignals != nil {
 		res.AllowedSignals = make([]string, len(v.AllowedSignals))
@@ -4244,6 +5555,12 @@ func marshalStreamAggregatedSinkToAggregatedSinkResponseBody(v *stream.Aggregate
 	if v.Table != nil {
 		res.Table = marshalStreamTableSinkToTableSinkResponseBody(v.Table)
 	}
+	if v.Webhook != nil {
+		res.Webhook = marshalStreamWebhookSinkToWebhookSinkResponseBody(v.Webhook)
+	}
+	if v.File != nil {
+		res.File = marshalStreamFileSinkToFileSinkResponseBody(v.File)
+	}
 	if v.Version != nil {
 		res.Version = marshalStreamVersionToVersionResponseBody(v.Version)
 	}
@@ -184,6 +184,11 @@ func UndeleteSinkStreamPath(branchID string, sourceID string, sinkID string) str
 	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/undelete", branchID, sourceID, sinkID)
 }
 
+// ReimportSinkFilesStreamPath returns the URL path to the stream service ReimportSinkFiles HTTP endpoint.
+func ReimportSinkFilesStreamPath(branchID string, sourceID string, sinkID string) string {
+	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/files/reimport", branchID, sourceID, sinkID)
+}
+
 // ListSinkVersionsStreamPath returns the URL path to the stream service ListSinkVersions HTTP endpoint.
 func ListSinkVersionsStreamPath(branchID string, sourceID string, sinkID string) string {
 	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/versions", branchID, sourceID, sinkID)
@@ -199,6 +204,36 @@ func RollbackSinkVersionStreamPath(branchID string, sourceID string, sinkID stri
 	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/versions/%v/rollback", branchID, sourceID, sinkID, versionNumber)
 }
 
+// ListSinkDeadLettersStreamPath returns the URL path to the stream service ListSinkDeadLetters HTTP endpoint.
+func ListSinkDeadLettersStreamPath(branchID string, sourceID string, sinkID string) string {
+	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/dead-letters", branchID, sourceID, sinkID)
+}
+
+// GetSinkDeadLetterStreamPath returns the URL path to the stream service GetSinkDeadLetter HTTP endpoint.
+func GetSinkDeadLetterStreamPath(branchID string, sourceID string, sinkID string, recordID string) string {
+	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/dead-letters/%v", branchID, sourceID, sinkID, recordID)
+}
+
+// ReplaySinkDeadLetterStreamPath returns the URL path to the stream service ReplaySinkDeadLetter HTTP endpoint.
+func ReplaySinkDeadLetterStreamPath(branchID string, sourceID string, sinkID string, recordID string) string {
+	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/dead-letters/%v/replay", branchID, sourceID, sinkID, recordID)
+}
+
+// PurgeSinkDeadLettersStreamPath returns the URL path to the stream service PurgeSinkDeadLetters HTTP endpoint.
+func PurgeSinkDeadLettersStreamPath(branchID string, sourceID string, sinkID string) string {
+	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/dead-letters", branchID, sourceID, sinkID)
+}
+
+// PreviewSinkDataStreamPath returns the URL path to the stream service PreviewSinkData HTTP endpoint.
+func PreviewSinkDataStreamPath(branchID string, sourceID string, sinkID string) string {
+	return fmt.Sprintf("/v1/branches/%v/sources/%v/sinks/%v/preview", branchID, sourceID, sinkID)
+}
+
+// InferSinkMappingStreamPath returns the URL path to the stream service InferSinkMapping HTTP endpoint.
+func InferSinkMappingStreamPath(branchID string, sourceID string) string {
+	return fmt.Sprintf("/v1/branches/%v/sources/%v/infer-mapping", branchID, sourceID)
+}
+
 // GetTaskStreamPath returns the URL path to the stream service GetTask HTTP endpoint.
 func GetTaskStreamPath(taskID string) string {
 	return fmt.Sprintf("/v1/tasks/%v", taskID)
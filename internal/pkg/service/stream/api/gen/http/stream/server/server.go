@@ -56,9 +56,16 @@ type Server struct {
 	DisableSink           http.Handler
 	EnableSink            http.Handler
 	UndeleteSink          http.Handler
+	ReimportSinkFiles     http.Handler
 	ListSinkVersions      http.Handler
 	SinkVersionDetail     http.Handler
 	RollbackSinkVersion   http.Handler
+	ListSinkDeadLetters   http.Handler
+	GetSinkDeadLetter     http.Handler
+	ReplaySinkDeadLetter  http.Handler
+	PurgeSinkDeadLetters  http.Handler
+	PreviewSinkData       http.Handler
+	InferSinkMapping      http.Handler
 	GetTask               http.Handler
 	AggregationSources    http.Handler
 	CORS                  http.Handler
@@ -155,9 +162,16 @@ func New(
 			{"DisableSink", "PUT", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/disable"},
 			{"EnableSink", "PUT", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/enable"},
 			{"UndeleteSink", "PUT", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/undelete"},
+			{"ReimportSinkFiles", "POST", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/files/reimport"},
 			{"ListSinkVersions", "GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions"},
 			{"SinkVersionDetail", "GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions/{versionNumber}"},
 			{"RollbackSinkVersion", "PUT", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions/{versionNumber}/rollback"},
+			{"ListSinkDeadLetters", "GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters"},
+			{"GetSinkDeadLetter", "GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}"},
+			{"ReplaySinkDeadLetter", "POST", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}/replay"},
+			{"PurgeSinkDeadLetters", "DELETE", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters"},
+			{"PreviewSinkData", "GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/preview"},
+			{"InferSinkMapping", "POST", "/v1/branches/{branchId}/sources/{sourceId}/infer-mapping"},
 			{"GetTask", "GET", "/v1/tasks/{*taskId}"},
 			{"AggregationSources", "GET", "/v1/branches/{branchId}/aggregation/sources"},
 			{"CORS", "OPTIONS", "/"},
@@ -186,9 +200,15 @@ func New(
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/disable"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/enable"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/undelete"},
+			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/files/reimport"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions/{versionNumber}"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions/{versionNumber}/rollback"},
+			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters"},
+			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}"},
+			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}/replay"},
+			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/preview"},
+			{"CORS", "OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/infer-mapping"},
 			{"CORS", "OPTIONS", "/v1/tasks/{*taskId}"},
 			{"CORS", "OPTIONS", "/v1/branches/{branchId}/aggregation/sources"},
 			{"CORS", "OPTIONS", "/v1/documentation/openapi.json"},
@@ -236,9 +256,16 @@ func New(
 		DisableSink:           NewDisableSinkHandler(e.DisableSink, mux, decoder, encoder, errhandler, formatter),
 		EnableSink:            NewEnableSinkHandler(e.EnableSink, mux, decoder, encoder, errhandler, formatter),
 		UndeleteSink:          NewUndeleteSinkHandler(e.UndeleteSink, mux, decoder, encoder, errhandler, formatter),
+		ReimportSinkFiles:     NewReimportSinkFilesHandler(e.ReimportSinkFiles, mux, decoder, encoder, errhandler, formatter),
 		ListSinkVersions:      NewListSinkVersionsHandler(e.ListSinkVersions, mux, decoder, encoder, errhandler, formatter),
 		SinkVersionDetail:     NewSinkVersionDetailHandler(e.SinkVersionDetail, mux, decoder, encoder, errhandler, formatter),
 		RollbackSinkVersion:   NewRollbackSinkVersionHandler(e.RollbackSinkVersion, mux, decoder, encoder, errhandler, formatter),
+		ListSinkDeadLetters:   NewListSinkDeadLettersHandler(e.ListSinkDeadLetters, mux, decoder, encoder, errhandler, formatter),
+		GetSinkDeadLetter:     NewGetSinkDeadLetterHandler(e.GetSinkDeadLetter, mux, decoder, encoder, errhandler, formatter),
+		ReplaySinkDeadLetter:  NewReplaySinkDeadLetterHandler(e.ReplaySinkDeadLetter, mux, decoder, encoder, errhandler, formatter),
+		PurgeSinkDeadLetters:  NewPurgeSinkDeadLettersHandler(e.PurgeSinkDeadLetters, mux, decoder, encoder, errhandler, formatter),
+		PreviewSinkData:       NewPreviewSinkDataHandler(e.PreviewSinkData, mux, decoder, encoder, errhandler, formatter),
+		InferSinkMapping:      NewInferSinkMappingHandler(e.InferSinkMapping, mux, decoder, encoder, errhandler, formatter),
 		GetTask:               NewGetTaskHandler(e.GetTask, mux, decoder, encoder, errhandler, formatter),
 		AggregationSources:    NewAggregationSourcesHandler(e.AggregationSources, mux, decoder, encoder, errhandler, formatter),
 		CORS:                  NewCORSHandler(),
@@ -289,9 +316,16 @@ func (s *Server) Use(m func(http.Handler) http.Handler) {
 	s.DisableSink = m(s.DisableSink)
 	s.EnableSink = m(s.EnableSink)
 	s.UndeleteSink = m(s.UndeleteSink)
+	s.ReimportSinkFiles = m(s.ReimportSinkFiles)
 	s.ListSinkVersions = m(s.ListSinkVersions)
 	s.SinkVersionDetail = m(s.SinkVersionDetail)
 	s.RollbackSinkVersion = m(s.RollbackSinkVersion)
+	s.ListSinkDeadLetters = m(s.ListSinkDeadLetters)
+	s.GetSinkDeadLetter = m(s.GetSinkDeadLetter)
+	s.ReplaySinkDeadLetter = m(s.ReplaySinkDeadLetter)
+	s.PurgeSinkDeadLetters = m(s.PurgeSinkDeadLetters)
+	s.PreviewSinkData = m(s.PreviewSinkData)
+	s.InferSinkMapping = m(s.InferSinkMapping)
 	s.GetTask = m(s.GetTask)
 	s.AggregationSources = m(s.AggregationSources)
 	s.CORS = m(s.CORS)
@@ -336,9 +370,16 @@ func Mount(mux goahttp.Muxer, h *Server) {
 	MountDisableSinkHandler(mux, h.DisableSink)
 	MountEnableSinkHandler(mux, h.EnableSink)
 	MountUndeleteSinkHandler(mux, h.UndeleteSink)
+	MountReimportSinkFilesHandler(mux, h.ReimportSinkFiles)
 	MountListSinkVersionsHandler(mux, h.ListSinkVersions)
 	MountSinkVersionDetailHandler(mux, h.SinkVersionDetail)
 	MountRollbackSinkVersionHandler(mux, h.RollbackSinkVersion)
+	MountListSinkDeadLettersHandler(mux, h.ListSinkDeadLetters)
+	MountGetSinkDeadLetterHandler(mux, h.GetSinkDeadLetter)
+	MountReplaySinkDeadLetterHandler(mux, h.ReplaySinkDeadLetter)
+	MountPurgeSinkDeadLettersHandler(mux, h.PurgeSinkDeadLetters)
+	MountPreviewSinkDataHandler(mux, h.PreviewSinkData)
+	MountInferSinkMappingHandler(mux, h.InferSinkMapping)
 	MountGetTaskHandler(mux, h.GetTask)
 	MountAggregationSourcesHandler(mux, h.AggregationSources)
 	MountCORSHandler(mux, h.CORS)
@@ -2120,6 +2161,59 @@ func NewUndeleteSinkHandler(
 	})
 }
 
+// MountReimportSinkFilesHandler configures the mux to serve the "stream"
+// service "ReimportSinkFiles" endpoint.
+func MountReimportSinkFilesHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/files/reimport", f)
+}
+
+// NewReimportSinkFilesHandler creates a HTTP handler which loads the HTTP
+// request and calls the "stream" service "ReimportSinkFiles" endpoint.
+func NewReimportSinkFilesHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeReimportSinkFilesRequest(mux, decoder)
+		encodeResponse = EncodeReimportSinkFilesResponse(encoder)
+		encodeError    = EncodeReimportSinkFilesError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ReimportSinkFiles")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
 // MountListSinkVersionsHandler configures the mux to serve the "stream"
 // service "ListSinkVersions" endpoint.
 func MountListSinkVersionsHandler(mux goahttp.Muxer, h http.Handler) {
@@ -2279,6 +2373,324 @@ func NewRollbackSinkVersionHandler(
 	})
 }
 
+// MountListSinkDeadLettersHandler configures the mux to serve the "stream"
+// service "ListSinkDeadLetters" endpoint.
+func MountListSinkDeadLettersHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters", f)
+}
+
+// NewListSinkDeadLettersHandler creates a HTTP handler which loads the HTTP
+// request and calls the "stream" service "ListSinkDeadLetters" endpoint.
+func NewListSinkDeadLettersHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeListSinkDeadLettersRequest(mux, decoder)
+		encodeResponse = EncodeListSinkDeadLettersResponse(encoder)
+		encodeError    = EncodeListSinkDeadLettersError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ListSinkDeadLetters")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountGetSinkDeadLetterHandler configures the mux to serve the "stream"
+// service "GetSinkDeadLetter" endpoint.
+func MountGetSinkDeadLetterHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}", f)
+}
+
+// NewGetSinkDeadLetterHandler creates a HTTP handler which loads the HTTP
+// request and calls the "stream" service "GetSinkDeadLetter" endpoint.
+func NewGetSinkDeadLetterHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeGetSinkDeadLetterRequest(mux, decoder)
+		encodeResponse = EncodeGetSinkDeadLetterResponse(encoder)
+		encodeError    = EncodeGetSinkDeadLetterError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "GetSinkDeadLetter")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountReplaySinkDeadLetterHandler configures the mux to serve the "stream"
+// service "ReplaySinkDeadLetter" endpoint.
+func MountReplaySinkDeadLetterHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}/replay", f)
+}
+
+// NewReplaySinkDeadLetterHandler creates a HTTP handler which loads the HTTP
+// request and calls the "stream" service "ReplaySinkDeadLetter" endpoint.
+func NewReplaySinkDeadLetterHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeReplaySinkDeadLetterRequest(mux, decoder)
+		encodeResponse = EncodeReplaySinkDeadLetterResponse(encoder)
+		encodeError    = EncodeReplaySinkDeadLetterError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "ReplaySinkDeadLetter")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountPurgeSinkDeadLettersHandler configures the mux to serve the "stream"
+// service "PurgeSinkDeadLetters" endpoint.
+func MountPurgeSinkDeadLettersHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("DELETE", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters", f)
+}
+
+// NewPurgeSinkDeadLettersHandler creates a HTTP handler which loads the HTTP
+// request and calls the "stream" service "PurgeSinkDeadLetters" endpoint.
+func NewPurgeSinkDeadLettersHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePurgeSinkDeadLettersRequest(mux, decoder)
+		encodeResponse = EncodePurgeSinkDeadLettersResponse(encoder)
+		encodeError    = EncodePurgeSinkDeadLettersError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PurgeSinkDeadLetters")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountPreviewSinkDataHandler configures the mux to serve the "stream" service
+// "PreviewSinkData" endpoint.
+func MountPreviewSinkDataHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/preview", f)
+}
+
+// NewPreviewSinkDataHandler creates a HTTP handler which loads the HTTP
+// request and calls the "stream" service "PreviewSinkData" endpoint.
+func NewPreviewSinkDataHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodePreviewSinkDataRequest(mux, decoder)
+		encodeResponse = EncodePreviewSinkDataResponse(encoder)
+		encodeError    = EncodePreviewSinkDataError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "PreviewSinkData")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
+// MountInferSinkMappingHandler configures the mux to serve the "stream"
+// service "InferSinkMapping" endpoint.
+func MountInferSinkMappingHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := HandleStreamOrigin(h).(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", "/v1/branches/{branchId}/sources/{sourceId}/infer-mapping", f)
+}
+
+// NewInferSinkMappingHandler creates a HTTP handler which loads the HTTP
+// request and calls the "stream" service "InferSinkMapping" endpoint.
+func NewInferSinkMappingHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	decoder func(*http.Request) goahttp.Decoder,
+	encoder func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	errhandler func(context.Context, http.ResponseWriter, error),
+	formatter func(ctx context.Context, err error) goahttp.Statuser,
+) http.Handler {
+	var (
+		decodeRequest  = DecodeInferSinkMappingRequest(mux, decoder)
+		encodeResponse = EncodeInferSinkMappingResponse(encoder)
+		encodeError    = EncodeInferSinkMappingError(encoder, formatter)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "InferSinkMapping")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "stream")
+		payload, err := decodeRequest(r)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		res, err := endpoint(ctx, payload)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil && errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			if errhandler != nil {
+				errhandler(ctx, w, err)
+			}
+		}
+	})
+}
+
 // MountGetTaskHandler configures the mux to serve the "stream" service
 // "GetTask" endpoint.
 func MountGetTaskHandler(mux goahttp.Muxer, h http.Handler) {
@@ -2467,9 +2879,15 @@ func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/disable", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/enable", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/undelete", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/files/reimport", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions/{versionNumber}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/versions/{versionNumber}/rollback", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/dead-letters/{recordId}/replay", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/sinks/{sinkId}/preview", h.ServeHTTP)
+	mux.Handle("OPTIONS", "/v1/branches/{branchId}/sources/{sourceId}/infer-mapping", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/tasks/{*taskId}", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/branches/{branchId}/aggregation/sources", h.ServeHTTP)
 	mux.Handle("OPTIONS", "/v1/documentation/openapi.json", h.ServeHTTP)
@@ -30,6 +30,10 @@ type CreateSourceRequestBody struct {
 	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
 	// Description of the source.
 	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Kafka source details for "type" = "kafka".
+	Kafka *KafkaSourceRequestBody `form:"kafka,omitempty" json:"kafka,omitempty" xml:"kafka,omitempty"`
+	// Optional webhook signature verification for "type" = "http".
+	HTTPSignature *HTTPSourceSignatureRequestBody `form:"httpSignature,omitempty" json:"httpSignature,omitempty" xml:"httpSignature,omitempty"`
 }
 
 // UpdateSourceRequestBody is the type of the "stream" service "UpdateSource"
@@ -42,6 +46,12 @@ type UpdateSourceRequestBody struct {
 	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
 	// Description of the source.
 	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
+	// Kafka source details for "type" = "kafka".
+	Kafka *KafkaSourceRequestBody `form:"kafka,omitempty" json:"kafka,omitempty" xml:"kafka,omitempty"`
+	// Optional webhook signature verification for "type" = "http".
+	HTTPSignature *HTTPSourceSignatureRequestBody `form:"httpSignature,omitempty" json:"httpSignature,omitempty" xml:"httpSignature,omitempty"`
+	// Disable the webhook signature verification.
+	RemoveHTTPSignature *bool `form:"removeHttpSignature,omitempty" json:"removeHttpSignature,omitempty" xml:"removeHttpSignature,omitempty"`
 }
 
 // UpdateSourceSettingsRequestBody is the type of the "stream" service
@@ -65,8 +75,19 @@ type CreateSinkRequestBody struct {
 	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
-	AllowedSignals []string                    `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
-	Table          *TableSinkCreateRequestBody `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
+	AllowedSignals []string `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string `form:"filter,omitempty" json:"filter,omitempty" xml:"filter,omitempty"`
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool                         `form:"deadLetter,omitempty" json:"deadLetter,omitempty" xml:"deadLetter,omitempty"`
+	Table      *TableSinkCreateRequestBody   `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
+	Webhook    *WebhookSinkCreateRequestBody `form:"webhook,omitempty" json:"webhook,omitempty" xml:"webhook,omitempty"`
+	File       *FileSinkCreateRequestBody    `form:"file,omitempty" json:"file,omitempty" xml:"file,omitempty"`
 }
 
 // UpdateSinkSettingsRequestBody is the type of the "stream" service
@@ -89,8 +110,43 @@ type UpdateSinkRequestBody struct {
 	Description *string `form:"description,omitempty" json:"description,omitempty" xml:"description,omitempty"`
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
-	AllowedSignals []string                    `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
-	Table          *TableSinkUpdateRequestBody `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
+	AllowedSignals []string `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string `form:"filter,omitempty" json:"filter,omitempty" xml:"filter,omitempty"`
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool                         `form:"deadLetter,omitempty" json:"deadLetter,omitempty" xml:"deadLetter,omitempty"`
+	Table      *TableSinkUpdateRequestBody   `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
+	Webhook    *WebhookSinkUpdateRequestBody `form:"webhook,omitempty" json:"webhook,omitempty" xml:"webhook,omitempty"`
+	File       *FileSinkUpdateRequestBody    `form:"file,omitempty" json:"file,omitempty" xml:"file,omitempty"`
+}
+
+// ReimportSinkFilesRequestBody is the type of the "stream" service
+// "ReimportSinkFiles" endpoint HTTP request body.
+type ReimportSinkFilesRequestBody struct {
+	// Files opened at or after the time are re-imported.
+	Since *string `form:"since,omitempty" json:"since,omitempty" xml:"since,omitempty"`
+	// Files opened before the time are re-imported. To re-import one file, use its
+	// "openedAt" as "since" and a millisecond later as "until".
+	Until *string `form:"until,omitempty" json:"until,omitempty" xml:"until,omitempty"`
+	// Optional table to re-import the files to. It must be in the same bucket as
+	// the table of the file, because the sink token is scoped to the bucket. By
+	// default, each file is re-imported to its original table.
+	TableID *string `form:"tableId,omitempty" json:"tableId,omitempty" xml:"tableId,omitempty"`
+}
+
+// InferSinkMappingRequestBody is the type of the "stream" service
+// "InferSinkMapping" endpoint HTTP request body.
+type InferSinkMappingRequestBody struct {
+	// Sample JSON objects. If empty, the last requests received by the source are
+	// used, if the sampling is enabled.
+	Samples []any `form:"samples,omitempty" json:"samples,omitempty" xml:"samples,omitempty"`
+	// Nested objects are flattened up to the depth, 1 means only top-level keys.
+	MaxDepth *int `form:"maxDepth,omitempty" json:"maxDepth,omitempty" xml:"maxDepth,omitempty"`
 }
 
 // APIVersionIndexResponseBody is the type of the "stream" service
@@ -180,7 +236,9 @@ type GetSourceResponseBody struct {
 	// HTTP source details for "type" = "http".
 	HTTP *HTTPSourceResponseBody `form:"http,omitempty" json:"http,omitempty" xml:"http,omitempty"`
 	// OTLP source details for "type" = "otlp".
-	Otlp     *OTLPSourceResponseBody     `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	Otlp *OTLPSourceResponseBody `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	// Kafka source details for "type" = "kafka".
+	Kafka    *KafkaSourceResponseBody    `form:"kafka,omitempty" json:"kafka,omitempty" xml:"kafka,omitempty"`
 	Version  *VersionResponseBody        `form:"version" json:"version" xml:"version"`
 	Created  *CreatedEntityResponseBody  `form:"created" json:"created" xml:"created"`
 	Deleted  *DeletedEntityResponseBody  `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
@@ -245,8 +303,13 @@ type TestSourceResponseBody struct {
 	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
 	BranchID  int    `form:"branchId" json:"branchId" xml:"branchId"`
 	SourceID  string `form:"sourceId" json:"sourceId" xml:"sourceId"`
-	// Table for each configured sink.
+	// Table for each configured sink which accepts the record.
 	Tables []*TestResultTableResponseBody `form:"tables" json:"tables" xml:"tables"`
+	// Result of the filter expression for each configured sink.
+	Sinks []*TestResultSinkResponseBody `form:"sinks" json:"sinks" xml:"sinks"`
+	// Result of the webhook signature verification, present only if the signature
+	// verification is configured.
+	Signature *TestResultSignatureResponseBody `form:"signature,omitempty" json:"signature,omitempty" xml:"signature,omitempty"`
 }
 
 // DisableSourceResponseBody is the type of the "stream" service
@@ -309,7 +372,9 @@ type RotateSourceSecretResponseBody struct {
 	// HTTP source details for "type" = "http".
 	HTTP *HTTPSourceResponseBody `form:"http,omitempty" json:"http,omitempty" xml:"http,omitempty"`
 	// OTLP source details for "type" = "otlp".
-	Otlp     *OTLPSourceResponseBody     `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	Otlp *OTLPSourceResponseBody `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	// Kafka source details for "type" = "kafka".
+	Kafka    *KafkaSourceResponseBody    `form:"kafka,omitempty" json:"kafka,omitempty" xml:"kafka,omitempty"`
 	Version  *VersionResponseBody        `form:"version" json:"version" xml:"version"`
 	Created  *CreatedEntityResponseBody  `form:"created" json:"created" xml:"created"`
 	Deleted  *DeletedEntityResponseBody  `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
@@ -421,12 +486,23 @@ type GetSinkResponseBody struct {
 	Description string `form:"description" json:"description" xml:"description"`
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
-	AllowedSignals []string                    `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
-	Table          *TableSinkResponseBody      `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
-	Version        *VersionResponseBody        `form:"version" json:"version" xml:"version"`
-	Created        *CreatedEntityResponseBody  `form:"created" json:"created" xml:"created"`
-	Deleted        *DeletedEntityResponseBody  `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
-	Disabled       *DisabledEntityResponseBody `form:"disabled,omitempty" json:"disabled,omitempty" xml:"disabled,omitempty"`
+	AllowedSignals []string `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string `form:"filter,omitempty" json:"filter,omitempty" xml:"filter,omitempty"`
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool                       `form:"deadLetter,omitempty" json:"deadLetter,omitempty" xml:"deadLetter,omitempty"`
+	Table      *TableSinkResponseBody      `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
+	Webhook    *WebhookSinkResponseBody    `form:"webhook,omitempty" json:"webhook,omitempty" xml:"webhook,omitempty"`
+	File       *FileSinkResponseBody       `form:"file,omitempty" json:"file,omitempty" xml:"file,omitempty"`
+	Version    *VersionResponseBody        `form:"version" json:"version" xml:"version"`
+	Created    *CreatedEntityResponseBody  `form:"created" json:"created" xml:"created"`
+	Deleted    *DeletedEntityResponseBody  `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
+	Disabled   *DisabledEntityResponseBody `form:"disabled,omitempty" json:"disabled,omitempty" xml:"disabled,omitempty"`
 }
 
 // GetSinkSettingsResponseBody is the type of the "stream" service
@@ -529,12 +605,17 @@ type DeleteSinkResponseBody struct {
 type SinkStatisticsTotalResponseBody struct {
 	Total  *LevelResponseBody  `form:"total" json:"total" xml:"total"`
 	Levels *LevelsResponseBody `form:"levels" json:"levels" xml:"levels"`
+	// Records dropped by the sink filter.
+	Dropped *LevelResponseBody `form:"dropped,omitempty" json:"dropped,omitempty" xml:"dropped,omitempty"`
+	// Duplicate records skipped by the sink deduplication window.
+	Deduplicated *LevelResponseBody `form:"deduplicated,omitempty" json:"deduplicated,omitempty" xml:"deduplicated,omitempty"`
 }
 
 // SinkStatisticsFilesResponseBody is the type of the "stream" service
 // "SinkStatisticsFiles" endpoint HTTP response body.
 type SinkStatisticsFilesResponseBody struct {
-	Files []*SinkFileResponseBody `form:"files" json:"files" xml:"files"`
+	Files      []*SinkFileResponseBody      `form:"files" json:"files" xml:"files"`
+	Partitions []*SinkPartitionResponseBody `form:"partitions,omitempty" json:"partitions,omitempty" xml:"partitions,omitempty"`
 }
 
 // DisableSinkResponseBody is the type of the "stream" service "DisableSink"
@@ -606,6 +687,29 @@ type UndeleteSinkResponseBody struct {
 	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
+// ReimportSinkFilesResponseBody is the type of the "stream" service
+// "ReimportSinkFiles" endpoint HTTP response body.
+type ReimportSinkFilesResponseBody struct {
+	TaskID string `form:"taskId" json:"taskId" xml:"taskId"`
+	// Task type.
+	Type string `form:"type" json:"type" xml:"type"`
+	// URL of the task.
+	URL string `form:"url" json:"url" xml:"url"`
+	// Task status, one of: processing, success, error
+	Status string `form:"status" json:"status" xml:"status"`
+	// Shortcut for status != "processing".
+	IsFinished bool `form:"isFinished" json:"isFinished" xml:"isFinished"`
+	// Date and time of the task creation.
+	CreatedAt string `form:"createdAt" json:"createdAt" xml:"createdAt"`
+	// Date and time of the task end.
+	FinishedAt *string `form:"finishedAt,omitempty" json:"finishedAt,omitempty" xml:"finishedAt,omitempty"`
+	// Duration of the task in milliseconds.
+	Duration *int64                   `form:"duration,omitempty" json:"duration,omitempty" xml:"duration,omitempty"`
+	Result   *string                  `form:"result,omitempty" json:"result,omitempty" xml:"result,omitempty"`
+	Error    *string                  `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
+}
+
 // ListSinkVersionsResponseBody is the type of the "stream" service
 // "ListSinkVersions" endpoint HTTP response body.
 type ListSinkVersionsResponseBody struct {
@@ -651,6 +755,65 @@ type RollbackSinkVersionResponseBody struct {
 	Outputs  *TaskOutputsResponseBody `form:"outputs,omitempty" json:"outputs,omitempty" xml:"outputs,omitempty"`
 }
 
+// ListSinkDeadLettersResponseBody is the type of the "stream" service
+// "ListSinkDeadLetters" endpoint HTTP response body.
+type ListSinkDeadLettersResponseBody struct {
+	ProjectID int                                 `form:"projectId" json:"projectId" xml:"projectId"`
+	BranchID  int                                 `form:"branchId" json:"branchId" xml:"branchId"`
+	SourceID  string                              `form:"sourceId" json:"sourceId" xml:"sourceId"`
+	SinkID    string                              `form:"sinkId" json:"sinkId" xml:"sinkId"`
+	Page      *PaginatedResponseResponseBody      `form:"page" json:"page" xml:"page"`
+	Records   []*SinkDeadLetterRecordResponseBody `form:"records" json:"records" xml:"records"`
+}
+
+// GetSinkDeadLetterResponseBody is the type of the "stream" service
+// "GetSinkDeadLetter" endpoint HTTP response body.
+type GetSinkDeadLetterResponseBody struct {
+	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
+	BranchID  int    `form:"branchId" json:"branchId" xml:"branchId"`
+	SourceID  string `form:"sourceId" json:"sourceId" xml:"sourceId"`
+	SinkID    string `form:"sinkId" json:"sinkId" xml:"sinkId"`
+	RecordID  string `form:"recordId" json:"recordId" xml:"recordId"`
+	// Date and time of the record receipt, it is kept on the replay.
+	ReceivedAt string `form:"receivedAt" json:"receivedAt" xml:"receivedAt"`
+	// Date and time of the last failure.
+	FailedAt string `form:"failedAt" json:"failedAt" xml:"failedAt"`
+	// Error of the last failure.
+	Error string `form:"error" json:"error" xml:"error"`
+	// IP address of the client.
+	ClientIP *string `form:"clientIp,omitempty" json:"clientIp,omitempty" xml:"clientIp,omitempty"`
+	// Headers of the record.
+	Headers map[string]string `form:"headers" json:"headers" xml:"headers"`
+	// Raw body of the record, it is not part of the list response.
+	Body *string `form:"body,omitempty" json:"body,omitempty" xml:"body,omitempty"`
+	// True if the body has been truncated, such record cannot be replayed.
+	BodyTruncated bool `form:"bodyTruncated" json:"bodyTruncated" xml:"bodyTruncated"`
+	// True if the replay of the record is in progress.
+	ReplayRequested bool `form:"replayRequested" json:"replayRequested" xml:"replayRequested"`
+}
+
+// PreviewSinkDataResponseBody is the type of the "stream" service
+// "PreviewSinkData" endpoint HTTP response body.
+type PreviewSinkDataResponseBody struct {
+	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
+	BranchID  int    `form:"branchId" json:"branchId" xml:"branchId"`
+	SourceID  string `form:"sourceId" json:"sourceId" xml:"sourceId"`
+	SinkID    string `form:"sinkId" json:"sinkId" xml:"sinkId"`
+	// Mapped rows, from the oldest.
+	Rows []*SinkDataPreviewRowResponseBody `form:"rows" json:"rows" xml:"rows"`
+}
+
+// InferSinkMappingResponseBody is the type of the "stream" service
+// "InferSinkMapping" endpoint HTTP response body.
+type InferSinkMappingResponseBody struct {
+	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
+	BranchID  int    `form:"branchId" json:"branchId" xml:"branchId"`
+	SourceID  string `form:"sourceId" json:"sourceId" xml:"sourceId"`
+	// Number of samples used for the proposal.
+	SamplesCount int                       `form:"samplesCount" json:"samplesCount" xml:"samplesCount"`
+	Mapping      *TableMappingResponseBody `form:"mapping" json:"mapping" xml:"mapping"`
+}
+
 // GetTaskResponseBody is the type of the "stream" service "GetTask" endpoint
 // HTTP response body.
 type GetTaskResponseBody struct {
@@ -1259,6 +1422,30 @@ type UndeleteSinkStreamAPISinkNotFoundResponseBody struct {
 	Message string `form:"message" json:"message" xml:"message"`
 }
 
+// ReimportSinkFilesStreamAPISourceNotFoundResponseBody is the type of the
+// "stream" service "ReimportSinkFiles" endpoint HTTP response body for the
+// "stream.api.sourceNotFound" error.
+type ReimportSinkFilesStreamAPISourceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// ReimportSinkFilesStreamAPISinkNotFoundResponseBody is the type of the
+// "stream" service "ReimportSinkFiles" endpoint HTTP response body for the
+// "stream.api.sinkNotFound" error.
+type ReimportSinkFilesStreamAPISinkNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
 // ListSinkVersionsStreamAPISourceNotFoundResponseBody is the type of the
 // "stream" service "ListSinkVersions" endpoint HTTP response body for the
 // "stream.api.sourceNotFound" error.
@@ -1355,6 +1542,186 @@ type RollbackSinkVersionStreamAPIVersionNotFoundResponseBody struct {
 	Message string `form:"message" json:"message" xml:"message"`
 }
 
+// ListSinkDeadLettersStreamAPISourceNotFoundResponseBody is the type of the
+// "stream" service "ListSinkDeadLetters" endpoint HTTP response body for the
+// "stream.api.sourceNotFound" error.
+type ListSinkDeadLettersStreamAPISourceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// ListSinkDeadLettersStreamAPISinkNotFoundResponseBody is the type of the
+// "stream" service "ListSinkDeadLetters" endpoint HTTP response body for the
+// "stream.api.sinkNotFound" error.
+type ListSinkDeadLettersStreamAPISinkNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// GetSinkDeadLetterStreamAPISourceNotFoundResponseBody is the type of the
+// "stream" service "GetSinkDeadLetter" endpoint HTTP response body for the
+// "stream.api.sourceNotFound" error.
+type GetSinkDeadLetterStreamAPISourceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// GetSinkDeadLetterStreamAPISinkNotFoundResponseBody is the type of the
+// "stream" service "GetSinkDeadLetter" endpoint HTTP response body for the
+// "stream.api.sinkNotFound" error.
+type GetSinkDeadLetterStreamAPISinkNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// GetSinkDeadLetterStreamAPIRecordNotFoundResponseBody is the type of the
+// "stream" service "GetSinkDeadLetter" endpoint HTTP response body for the
+// "stream.api.recordNotFound" error.
+type GetSinkDeadLetterStreamAPIRecordNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// ReplaySinkDeadLetterStreamAPISourceNotFoundResponseBody is the type of the
+// "stream" service "ReplaySinkDeadLetter" endpoint HTTP response body for the
+// "stream.api.sourceNotFound" error.
+type ReplaySinkDeadLetterStreamAPISourceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// ReplaySinkDeadLetterStreamAPISinkNotFoundResponseBody is the type of the
+// "stream" service "ReplaySinkDeadLetter" endpoint HTTP response body for the
+// "stream.api.sinkNotFound" error.
+type ReplaySinkDeadLetterStreamAPISinkNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// ReplaySinkDeadLetterStreamAPIRecordNotFoundResponseBody is the type of the
+// "stream" service "ReplaySinkDeadLetter" endpoint HTTP response body for the
+// "stream.api.recordNotFound" error.
+type ReplaySinkDeadLetterStreamAPIRecordNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// ReplaySinkDeadLetterStreamAPIInvalidColumnValueResponseBody is the type of
+// the "stream" service "ReplaySinkDeadLetter" endpoint HTTP response body for
+// the "stream.api.invalidColumnValue" error.
+type ReplaySinkDeadLetterStreamAPIInvalidColumnValueResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// PurgeSinkDeadLettersStreamAPISourceNotFoundResponseBody is the type of the
+// "stream" service "PurgeSinkDeadLetters" endpoint HTTP response body for the
+// "stream.api.sourceNotFound" error.
+type PurgeSinkDeadLettersStreamAPISourceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// PurgeSinkDeadLettersStreamAPISinkNotFoundResponseBody is the type of the
+// "stream" service "PurgeSinkDeadLetters" endpoint HTTP response body for the
+// "stream.api.sinkNotFound" error.
+type PurgeSinkDeadLettersStreamAPISinkNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// PreviewSinkDataStreamAPISourceNotFoundResponseBody is the type of the
+// "stream" service "PreviewSinkData" endpoint HTTP response body for the
+// "stream.api.sourceNotFound" error.
+type PreviewSinkDataStreamAPISourceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// PreviewSinkDataStreamAPISinkNotFoundResponseBody is the type of the "stream"
+// service "PreviewSinkData" endpoint HTTP response body for the
+// "stream.api.sinkNotFound" error.
+type PreviewSinkDataStreamAPISinkNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// InferSinkMappingStreamAPISourceNotFoundResponseBody is the type of the
+// "stream" service "InferSinkMapping" endpoint HTTP response body for the
+// "stream.api.sourceNotFound" error.
+type InferSinkMappingStreamAPISourceNotFoundResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// InferSinkMappingStreamAPIUnprocessableContentResponseBody is the type of the
+// "stream" service "InferSinkMapping" endpoint HTTP response body for the
+// "stream.api.unprocessableContent" error.
+type InferSinkMappingStreamAPIUnprocessableContentResponseBody struct {
+	// HTTP status code.
+	StatusCode int `form:"statusCode" json:"statusCode" xml:"statusCode"`
+	// Name of error.
+	Name string `form:"error" json:"error" xml:"error"`
+	// Error message.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
 // GetTaskStreamAPITaskNotFoundResponseBody is the type of the "stream" service
 // "GetTask" endpoint HTTP response body for the "stream.api.taskNotFound"
 // error.
@@ -1407,7 +1774,9 @@ type SourceResponseBody struct {
 	// HTTP source details for "type" = "http".
 	HTTP *HTTPSourceResponseBody `form:"http,omitempty" json:"http,omitempty" xml:"http,omitempty"`
 	// OTLP source details for "type" = "otlp".
-	Otlp     *OTLPSourceResponseBody     `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	Otlp *OTLPSourceResponseBody `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	// Kafka source details for "type" = "kafka".
+	Kafka    *KafkaSourceResponseBody    `form:"kafka,omitempty" json:"kafka,omitempty" xml:"kafka,omitempty"`
 	Version  *VersionResponseBody        `form:"version" json:"version" xml:"version"`
 	Created  *CreatedEntityResponseBody  `form:"created" json:"created" xml:"created"`
 	Deleted  *DeletedEntityResponseBody  `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
@@ -1418,6 +1787,34 @@ type SourceResponseBody struct {
 type HTTPSourceResponseBody struct {
 	// URL of the HTTP source. Contains secret used for authentication.
 	URL string `form:"url" json:"url" xml:"url"`
+	// Webhook signature verification, if it is configured. The signature secret is
+	// not returned.
+	Signature *HTTPSourceSignatureResponseBody `form:"signature,omitempty" json:"signature,omitempty" xml:"signature,omitempty"`
+}
+
+// HTTPSourceSignatureResponseBody is used to define fields on response body
+// types.
+type HTTPSourceSignatureResponseBody struct {
+	// HMAC algorithm.
+	Algorithm string `form:"algorithm" json:"algorithm" xml:"algorithm"`
+	// Format of the signature header and the signed payload. "hex" - hex encoded
+	// signature of the body with an optional "<algorithm>=" prefix, for example
+	// GitHub. "stripe" - "t=<timestamp>,v1=<signature>", the signed payload is
+	// "<timestamp>.<body>". "slack" - "v0=<signature>", the signed payload is
+	// "v0:<timestamp>:<body>", the timestamp is read from the
+	// "X-Slack-Request-Timestamp" header.
+	Format string `form:"format" json:"format" xml:"format"`
+	// Name of the header with the signature.
+	Header string `form:"header" json:"header" xml:"header"`
+	// Secret key of the HMAC. Required when the verification is configured for the
+	// first time, it is never returned.
+	Secret *string `form:"secret,omitempty" json:"secret,omitempty" xml:"secret,omitempty"`
+	// Maximum difference between the signed timestamp and the current time, 0
+	// means no limit. Not supported by the "hex" format.
+	TimestampToleranceSeconds *int `form:"timestampToleranceSeconds,omitempty" json:"timestampToleranceSeconds,omitempty" xml:"timestampToleranceSeconds,omitempty"`
+	// A request with an already received signature is rejected during the window,
+	// 0 disables the replay protection.
+	ReplayWindowSeconds *int `form:"replayWindowSeconds,omitempty" json:"replayWindowSeconds,omitempty" xml:"replayWindowSeconds,omitempty"`
 }
 
 // OTLPSourceResponseBody is used to define fields on response body types.
@@ -1438,6 +1835,16 @@ type OTLPSourceResponseBody struct {
 	Secret string `form:"secret" json:"secret" xml:"secret"`
 }
 
+// KafkaSourceResponseBody is used to define fields on response body types.
+type KafkaSourceResponseBody struct {
+	// Addresses of the seed brokers, in the host:port format, 1-20 items.
+	Brokers []string `form:"brokers" json:"brokers" xml:"brokers"`
+	// Topic to consume.
+	Topic string `form:"topic" json:"topic" xml:"topic"`
+	// Consumer group, the offsets of processed records are committed to.
+	GroupID string `form:"groupId" json:"groupId" xml:"groupId"`
+}
+
 // VersionResponseBody is used to define fields on response body types.
 type VersionResponseBody struct {
 	// Version number counted from 1.
@@ -1534,11 +1941,30 @@ type TestResultColumnResponseBody struct {
 	Value string `form:"value" json:"value" xml:"value"`
 }
 
+// TestResultSinkResponseBody is used to define fields on response body types.
+type TestResultSinkResponseBody struct {
+	SinkID string `form:"sinkId" json:"sinkId" xml:"sinkId"`
+	// False if the record would be dropped by the sink filter.
+	Accepted bool `form:"accepted" json:"accepted" xml:"accepted"`
+}
+
+// TestResultSignatureResponseBody is used to define fields on response body
+// types.
+type TestResultSignatureResponseBody struct {
+	// True if the signature is valid. The replay protection is not applied by the
+	// test endpoint.
+	Valid bool `form:"valid" json:"valid" xml:"valid"`
+	// Reason why the signature is invalid.
+	Message *string `form:"message,omitempty" json:"message,omitempty" xml:"message,omitempty"`
+}
+
 // TableSinkResponseBody is used to define fields on response body types.
 type TableSinkResponseBody struct {
-	Type    string                    `form:"type" json:"type" xml:"type"`
-	TableID string                    `form:"tableId" json:"tableId" xml:"tableId"`
-	Mapping *TableMappingResponseBody `form:"mapping" json:"mapping" xml:"mapping"`
+	Type         string                         `form:"type" json:"type" xml:"type"`
+	TableID      string                         `form:"tableId" json:"tableId" xml:"tableId"`
+	Mapping      *TableMappingResponseBody      `form:"mapping" json:"mapping" xml:"mapping"`
+	Partitioning *TablePartitioningResponseBody `form:"partitioning,omitempty" json:"partitioning,omitempty" xml:"partitioning,omitempty"`
+	Mirror       *TableMirrorResponseBody       `form:"mirror,omitempty" json:"mirror,omitempty" xml:"mirror,omitempty"`
 }
 
 // TableMappingResponseBody is used to define fields on response body types.
@@ -1562,6 +1988,9 @@ type TableColumnResponseBody struct {
 	RawString *bool `form:"rawString,omitempty" json:"rawString,omitempty" xml:"rawString,omitempty"`
 	// Template mapping details. Only for "type" = "template".
 	Template *TableColumnTemplateResponseBody `form:"template,omitempty" json:"template,omitempty" xml:"template,omitempty"`
+	// Optional target data type of the value. Only for "type" = "body", "path" or
+	// "template".
+	Cast *TableColumnCastResponseBody `form:"cast,omitempty" json:"cast,omitempty" xml:"cast,omitempty"`
 }
 
 // TableColumnTemplateResponseBody is used to define fields on response body
@@ -1571,6 +2000,53 @@ type TableColumnTemplateResponseBody struct {
 	Content  string `form:"content" json:"content" xml:"content"`
 }
 
+// TableColumnCastResponseBody is used to define fields on response body types.
+type TableColumnCastResponseBody struct {
+	// Target data type. The "timestamp" accepts an ISO 8601 string or a Unix
+	// timestamp in seconds.
+	DataType column.DataType `form:"dataType" json:"dataType" xml:"dataType"`
+	// Policy if the value cannot be cast: "fail" rejects the record, "null" uses
+	// null, "default" uses the "defaultValue".
+	OnError column.CastErrorPolicy `form:"onError" json:"onError" xml:"onError"`
+	// Value used by the "default" policy, it must be valid for the "dataType".
+	DefaultValue *string `form:"defaultValue,omitempty" json:"defaultValue,omitempty" xml:"defaultValue,omitempty"`
+}
+
+// TablePartitioningResponseBody is used to define fields on response body
+// types.
+type TablePartitioningResponseBody struct {
+	Granularity string `form:"granularity" json:"granularity" xml:"granularity"`
+	// Name of a "datetime" or "template" column of the mapping. The "datetime"
+	// column contains the time the record was received. The "template" column must
+	// return a time in the RFC 3339 format, for example an event time from the
+	// body. The partition is determined in UTC.
+	Column string `form:"column" json:"column" xml:"column"`
+}
+
+// TableMirrorResponseBody is used to define fields on response body types.
+type TableMirrorResponseBody struct {
+	Type    string `form:"type" json:"type" xml:"type"`
+	TableID string `form:"tableId" json:"tableId" xml:"tableId"`
+	// Mapping of the mirror table. The mapping of the sink is used, if it is not
+	// set.
+	Mapping *TableMappingResponseBody `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+}
+
+// WebhookSinkResponseBody is used to define fields on response body types.
+type WebhookSinkResponseBody struct {
+	// URL of the endpoint. Records are sent in batches by POST requests, the body
+	// is a JSON array of objects with the mapping columns.
+	URL     string                     `form:"url" json:"url" xml:"url"`
+	Headers []*TableColumnResponseBody `form:"headers,omitempty" json:"headers,omitempty" xml:"headers,omitempty"`
+	Mapping *TableMappingResponseBody  `form:"mapping" json:"mapping" xml:"mapping"`
+}
+
+// FileSinkResponseBody is used to define fields on response body types.
+type FileSinkResponseBody struct {
+	Provider string                    `form:"provider" json:"provider" xml:"provider"`
+	Mapping  *TableMappingResponseBody `form:"mapping" json:"mapping" xml:"mapping"`
+}
+
 // SinkResponseBody is used to define fields on response body types.
 type SinkResponseBody struct {
 	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
@@ -1584,12 +2060,23 @@ type SinkResponseBody struct {
 	Description string `form:"description" json:"description" xml:"description"`
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
-	AllowedSignals []string                    `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
-	Table          *TableSinkResponseBody      `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
-	Version        *VersionResponseBody        `form:"version" json:"version" xml:"version"`
-	Created        *CreatedEntityResponseBody  `form:"created" json:"created" xml:"created"`
-	Deleted        *DeletedEntityResponseBody  `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
-	Disabled       *DisabledEntityResponseBody `form:"disabled,omitempty" json:"disabled,omitempty" xml:"disabled,omitempty"`
+	AllowedSignals []string `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string `form:"filter,omitempty" json:"filter,omitempty" xml:"filter,omitempty"`
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool                       `form:"deadLetter,omitempty" json:"deadLetter,omitempty" xml:"deadLetter,omitempty"`
+	Table      *TableSinkResponseBody      `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
+	Webhook    *WebhookSinkResponseBody    `form:"webhook,omitempty" json:"webhook,omitempty" xml:"webhook,omitempty"`
+	File       *FileSinkResponseBody       `form:"file,omitempty" json:"file,omitempty" xml:"file,omitempty"`
+	Version    *VersionResponseBody        `form:"version" json:"version" xml:"version"`
+	Created    *CreatedEntityResponseBody  `form:"created" json:"created" xml:"created"`
+	Deleted    *DeletedEntityResponseBody  `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
+	Disabled   *DisabledEntityResponseBody `form:"disabled,omitempty" json:"disabled,omitempty" xml:"disabled,omitempty"`
 }
 
 // LevelResponseBody is used to define fields on response body types.
@@ -1624,7 +2111,9 @@ type SinkFileResponseBody struct {
 	// Reason of the last failed attempt.
 	RetryReason *string `form:"retryReason,omitempty" json:"retryReason,omitempty" xml:"retryReason,omitempty"`
 	// Next attempt time.
-	RetryAfter *string                         `form:"retryAfter,omitempty" json:"retryAfter,omitempty" xml:"retryAfter,omitempty"`
+	RetryAfter *string `form:"retryAfter,omitempty" json:"retryAfter,omitempty" xml:"retryAfter,omitempty"`
+	// Partition ID of the file. Only for a table sink with partitioning.
+	Partition  *string                         `form:"partition,omitempty" json:"partition,omitempty" xml:"partition,omitempty"`
 	Statistics *SinkFileStatisticsResponseBody `form:"statistics,omitempty" json:"statistics,omitempty" xml:"statistics,omitempty"`
 }
 
@@ -1635,6 +2124,57 @@ type SinkFileStatisticsResponseBody struct {
 	Levels *LevelsResponseBody `form:"levels" json:"levels" xml:"levels"`
 }
 
+// SinkPartitionResponseBody is used to define fields on response body types.
+type SinkPartitionResponseBody struct {
+	// Partition ID, it is used as the table name suffix.
+	Partition string `form:"partition" json:"partition" xml:"partition"`
+	// Number of recent files of the partition.
+	FilesCount int                             `form:"filesCount" json:"filesCount" xml:"filesCount"`
+	Statistics *SinkFileStatisticsResponseBody `form:"statistics,omitempty" json:"statistics,omitempty" xml:"statistics,omitempty"`
+}
+
+// SinkDeadLetterRecordResponseBody is used to define fields on response body
+// types.
+type SinkDeadLetterRecordResponseBody struct {
+	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
+	BranchID  int    `form:"branchId" json:"branchId" xml:"branchId"`
+	SourceID  string `form:"sourceId" json:"sourceId" xml:"sourceId"`
+	SinkID    string `form:"sinkId" json:"sinkId" xml:"sinkId"`
+	RecordID  string `form:"recordId" json:"recordId" xml:"recordId"`
+	// Date and time of the record receipt, it is kept on the replay.
+	ReceivedAt string `form:"receivedAt" json:"receivedAt" xml:"receivedAt"`
+	// Date and time of the last failure.
+	FailedAt string `form:"failedAt" json:"failedAt" xml:"failedAt"`
+	// Error of the last failure.
+	Error string `form:"error" json:"error" xml:"error"`
+	// IP address of the client.
+	ClientIP *string `form:"clientIp,omitempty" json:"clientIp,omitempty" xml:"clientIp,omitempty"`
+	// Headers of the record.
+	Headers map[string]string `form:"headers" json:"headers" xml:"headers"`
+	// Raw body of the record, it is not part of the list response.
+	Body *string `form:"body,omitempty" json:"body,omitempty" xml:"body,omitempty"`
+	// True if the body has been truncated, such record cannot be replayed.
+	BodyTruncated bool `form:"bodyTruncated" json:"bodyTruncated" xml:"bodyTruncated"`
+	// True if the replay of the record is in progress.
+	ReplayRequested bool `form:"replayRequested" json:"replayRequested" xml:"replayRequested"`
+}
+
+// SinkDataPreviewRowResponseBody is used to define fields on response body
+// types.
+type SinkDataPreviewRowResponseBody struct {
+	// Mapped columns.
+	Columns []*SinkDataPreviewColumnResponseBody `form:"columns" json:"columns" xml:"columns"`
+}
+
+// SinkDataPreviewColumnResponseBody is used to define fields on response body
+// types.
+type SinkDataPreviewColumnResponseBody struct {
+	// Column name.
+	Name string `form:"name" json:"name" xml:"name"`
+	// Column value.
+	Value string `form:"value" json:"value" xml:"value"`
+}
+
 // AggregatedSourceResponseBody is used to define fields on response body types.
 type AggregatedSourceResponseBody struct {
 	ProjectID int    `form:"projectId" json:"projectId" xml:"projectId"`
@@ -1648,7 +2188,9 @@ type AggregatedSourceResponseBody struct {
 	// HTTP source details for "type" = "http".
 	HTTP *HTTPSourceResponseBody `form:"http,omitempty" json:"http,omitempty" xml:"http,omitempty"`
 	// OTLP source details for "type" = "otlp".
-	Otlp     *OTLPSourceResponseBody       `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	Otlp *OTLPSourceResponseBody `form:"otlp,omitempty" json:"otlp,omitempty" xml:"otlp,omitempty"`
+	// Kafka source details for "type" = "kafka".
+	Kafka    *KafkaSourceResponseBody      `form:"kafka,omitempty" json:"kafka,omitempty" xml:"kafka,omitempty"`
 	Version  *VersionResponseBody          `form:"version" json:"version" xml:"version"`
 	Created  *CreatedEntityResponseBody    `form:"created" json:"created" xml:"created"`
 	Deleted  *DeletedEntityResponseBody    `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
@@ -1669,13 +2211,24 @@ type AggregatedSinkResponseBody struct {
 	Description string `form:"description" json:"description" xml:"description"`
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
-	AllowedSignals []string                          `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
-	Table          *TableSinkResponseBody            `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
-	Version        *VersionResponseBody              `form:"version" json:"version" xml:"version"`
-	Created        *CreatedEntityResponseBody        `form:"created" json:"created" xml:"created"`
-	Deleted        *DeletedEntityResponseBody        `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
-	Disabled       *DisabledEntityResponseBody       `form:"disabled,omitempty" json:"disabled,omitempty" xml:"disabled,omitempty"`
-	Statistics     *AggregatedStatisticsResponseBody `form:"statistics,omitempty" json:"statistics,omitempty" xml:"statistics,omitempty"`
+	AllowedSignals []string `form:"allowedSignals,omitempty" json:"allowedSignals,omitempty" xml:"allowedSignals,omitempty"`
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string `form:"filter,omitempty" json:"filter,omitempty" xml:"filter,omitempty"`
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool                             `form:"deadLetter,omitempty" json:"deadLetter,omitempty" xml:"deadLetter,omitempty"`
+	Table      *TableSinkResponseBody            `form:"table,omitempty" json:"table,omitempty" xml:"table,omitempty"`
+	Webhook    *WebhookSinkResponseBody          `form:"webhook,omitempty" json:"webhook,omitempty" xml:"webhook,omitempty"`
+	File       *FileSinkResponseBody             `form:"file,omitempty" json:"file,omitempty" xml:"file,omitempty"`
+	Version    *VersionResponseBody              `form:"version" json:"version" xml:"version"`
+	Created    *CreatedEntityResponseBody        `form:"created" json:"created" xml:"created"`
+	Deleted    *DeletedEntityResponseBody        `form:"deleted,omitempty" json:"deleted,omitempty" xml:"deleted,omitempty"`
+	Disabled   *DisabledEntityResponseBody       `form:"disabled,omitempty" json:"disabled,omitempty" xml:"disabled,omitempty"`
+	Statistics *AggregatedStatisticsResponseBody `form:"statistics,omitempty" json:"statistics,omitempty" xml:"statistics,omitempty"`
 }
 
 // AggregatedStatisticsResponseBody is used to define fields on response body
@@ -1686,6 +2239,41 @@ type AggregatedStatisticsResponseBody struct {
 	Files  []*SinkFileResponseBody `form:"files" json:"files" xml:"files"`
 }
 
+// KafkaSourceRequestBody is used to define fields on request body types.
+type KafkaSourceRequestBody struct {
+	// Addresses of the seed brokers, in the host:port format, 1-20 items.
+	Brokers []string `form:"brokers,omitempty" json:"brokers,omitempty" xml:"brokers,omitempty"`
+	// Topic to consume.
+	Topic *string `form:"topic,omitempty" json:"topic,omitempty" xml:"topic,omitempty"`
+	// Consumer group, the offsets of processed records are committed to.
+	GroupID *string `form:"groupId,omitempty" json:"groupId,omitempty" xml:"groupId,omitempty"`
+}
+
+// HTTPSourceSignatureRequestBody is used to define fields on request body
+// types.
+type HTTPSourceSignatureRequestBody struct {
+	// HMAC algorithm.
+	Algorithm *string `form:"algorithm,omitempty" json:"algorithm,omitempty" xml:"algorithm,omitempty"`
+	// Format of the signature header and the signed payload. "hex" - hex encoded
+	// signature of the body with an optional "<algorithm>=" prefix, for example
+	// GitHub. "stripe" - "t=<timestamp>,v1=<signature>", the signed payload is
+	// "<timestamp>.<body>". "slack" - "v0=<signature>", the signed payload is
+	// "v0:<timestamp>:<body>", the timestamp is read from the
+	// "X-Slack-Request-Timestamp" header.
+	Format *string `form:"format,omitempty" json:"format,omitempty" xml:"format,omitempty"`
+	// Name of the header with the signature.
+	Header *string `form:"header,omitempty" json:"header,omitempty" xml:"header,omitempty"`
+	// Secret key of the HMAC. Required when the verification is configured for the
+	// first time, it is never returned.
+	Secret *string `form:"secret,omitempty" json:"secret,omitempty" xml:"secret,omitempty"`
+	// Maximum difference between the signed timestamp and the current time, 0
+	// means no limit. Not supported by the "hex" format.
+	TimestampToleranceSeconds *int `form:"timestampToleranceSeconds,omitempty" json:"timestampToleranceSeconds,omitempty" xml:"timestampToleranceSeconds,omitempty"`
+	// A request with an already received signature is rejected during the window,
+	// 0 disables the replay protection.
+	ReplayWindowSeconds *int `form:"replayWindowSeconds,omitempty" json:"replayWindowSeconds,omitempty" xml:"replayWindowSeconds,omitempty"`
+}
+
 // SettingPatchRequestBody is used to define fields on request body types.
 type SettingPatchRequestBody struct {
 	// Key path.
@@ -1696,9 +2284,11 @@ type SettingPatchRequestBody struct {
 
 // TableSinkCreateRequestBody is used to define fields on request body types.
 type TableSinkCreateRequestBody struct {
-	Type    *string                  `form:"type,omitempty" json:"type,omitempty" xml:"type,omitempty"`
-	TableID *string                  `form:"tableId,omitempty" json:"tableId,omitempty" xml:"tableId,omitempty"`
-	Mapping *TableMappingRequestBody `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+	Type         *string                       `form:"type,omitempty" json:"type,omitempty" xml:"type,omitempty"`
+	TableID      *string                       `form:"tableId,omitempty" json:"tableId,omitempty" xml:"tableId,omitempty"`
+	Mapping      *TableMappingRequestBody      `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+	Partitioning *TablePartitioningRequestBody `form:"partitioning,omitempty" json:"partitioning,omitempty" xml:"partitioning,omitempty"`
+	Mirror       *TableMirrorRequestBody       `form:"mirror,omitempty" json:"mirror,omitempty" xml:"mirror,omitempty"`
 }
 
 // TableMappingRequestBody is used to define fields on request body types.
@@ -1722,6 +2312,9 @@ type TableColumnRequestBody struct {
 	RawString *bool `form:"rawString,omitempty" json:"rawString,omitempty" xml:"rawString,omitempty"`
 	// Template mapping details. Only for "type" = "template".
 	Template *TableColumnTemplateRequestBody `form:"template,omitempty" json:"template,omitempty" xml:"template,omitempty"`
+	// Optional target data type of the value. Only for "type" = "body", "path" or
+	// "template".
+	Cast *TableColumnCastRequestBody `form:"cast,omitempty" json:"cast,omitempty" xml:"cast,omitempty"`
 }
 
 // TableColumnTemplateRequestBody is used to define fields on request body
@@ -1731,11 +2324,77 @@ type TableColumnTemplateRequestBody struct {
 	Content  *string `form:"content,omitempty" json:"content,omitempty" xml:"content,omitempty"`
 }
 
+// TableColumnCastRequestBody is used to define fields on request body types.
+type TableColumnCastRequestBody struct {
+	// Target data type. The "timestamp" accepts an ISO 8601 string or a Unix
+	// timestamp in seconds.
+	DataType *column.DataType `form:"dataType,omitempty" json:"dataType,omitempty" xml:"dataType,omitempty"`
+	// Policy if the value cannot be cast: "fail" rejects the record, "null" uses
+	// null, "default" uses the "defaultValue".
+	OnError *column.CastErrorPolicy `form:"onError,omitempty" json:"onError,omitempty" xml:"onError,omitempty"`
+	// Value used by the "default" policy, it must be valid for the "dataType".
+	DefaultValue *string `form:"defaultValue,omitempty" json:"defaultValue,omitempty" xml:"defaultValue,omitempty"`
+}
+
+// TablePartitioningRequestBody is used to define fields on request body types.
+type TablePartitioningRequestBody struct {
+	Granularity *string `form:"granularity,omitempty" json:"granularity,omitempty" xml:"granularity,omitempty"`
+	// Name of a "datetime" or "template" column of the mapping. The "datetime"
+	// column contains the time the record was received. The "template" column must
+	// return a time in the RFC 3339 format, for example an event time from the
+	// body. The partition is determined in UTC.
+	Column *string `form:"column,omitempty" json:"column,omitempty" xml:"column,omitempty"`
+}
+
+// TableMirrorRequestBody is used to define fields on request body types.
+type TableMirrorRequestBody struct {
+	Type    *string `form:"type,omitempty" json:"type,omitempty" xml:"type,omitempty"`
+	TableID *string `form:"tableId,omitempty" json:"tableId,omitempty" xml:"tableId,omitempty"`
+	// Mapping of the mirror table. The mapping of the sink is used, if it is not
+	// set.
+	Mapping *TableMappingRequestBody `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+}
+
+// WebhookSinkCreateRequestBody is used to define fields on request body types.
+type WebhookSinkCreateRequestBody struct {
+	// URL of the endpoint. Records are sent in batches by POST requests, the body
+	// is a JSON array of objects with the mapping columns.
+	URL     *string                   `form:"url,omitempty" json:"url,omitempty" xml:"url,omitempty"`
+	Headers []*TableColumnRequestBody `form:"headers,omitempty" json:"headers,omitempty" xml:"headers,omitempty"`
+	Mapping *TableMappingRequestBody  `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+}
+
+// FileSinkCreateRequestBody is used to define fields on request body types.
+type FileSinkCreateRequestBody struct {
+	Provider *string                  `form:"provider,omitempty" json:"provider,omitempty" xml:"provider,omitempty"`
+	Mapping  *TableMappingRequestBody `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+}
+
 // TableSinkUpdateRequestBody is used to define fields on request body types.
 type TableSinkUpdateRequestBody struct {
-	Type    *string                  `form:"type,omitempty" json:"type,omitempty" xml:"type,omitempty"`
-	TableID *string                  `form:"tableId,omitempty" json:"tableId,omitempty" xml:"tableId,omitempty"`
-	Mapping *TableMappingRequestBody `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+	Type         *string                       `form:"type,omitempty" json:"type,omitempty" xml:"type,omitempty"`
+	TableID      *string                       `form:"tableId,omitempty" json:"tableId,omitempty" xml:"tableId,omitempty"`
+	Mapping      *TableMappingRequestBody      `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+	Partitioning *TablePartitioningRequestBody `form:"partitioning,omitempty" json:"partitioning,omitempty" xml:"partitioning,omitempty"`
+	Mirror       *TableMirrorRequestBody       `form:"mirror,omitempty" json:"mirror,omitempty" xml:"mirror,omitempty"`
+	// Set to true to remove the mirror table. Already accepted records are still
+	// imported to the mirror table.
+	RemoveMirror *bool `form:"removeMirror,omitempty" json:"removeMirror,omitempty" xml:"removeMirror,omitempty"`
+}
+
+// WebhookSinkUpdateRequestBody is used to define fields on request body types.
+type WebhookSinkUpdateRequestBody struct {
+	// URL of the endpoint. Records are sent in batches by POST requests, the body
+	// is a JSON array of objects with the mapping columns.
+	URL     *string                   `form:"url,omitempty" json:"url,omitempty" xml:"url,omitempty"`
+	Headers []*TableColumnRequestBody `form:"headers,omitempty" json:"headers,omitempty" xml:"headers,omitempty"`
+	Mapping *TableMappingRequestBody  `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
+}
+
+// FileSinkUpdateRequestBody is used to define fields on request body types.
+type FileSinkUpdateRequestBody struct {
+	Provider *string                  `form:"provider,omitempty" json:"provider,omitempty" xml:"provider,omitempty"`
+	Mapping  *TableMappingRequestBody `form:"mapping,omitempty" json:"mapping,omitempty" xml:"mapping,omitempty"`
 }
 
 // NewAPIVersionIndexResponseBody builds the HTTP response body from the result
@@ -1857,6 +2516,9 @@ func NewGetSourceResponseBody(res *stream.Source) *GetSourceResponseBody {
 	if res.Otlp != nil {
 		body.Otlp = marshalStreamOTLPSourceToOTLPSourceResponseBody(res.Otlp)
 	}
+	if res.Kafka != nil {
+		body.Kafka = marshalStreamKafkaSourceToKafkaSourceResponseBody(res.Kafka)
+	}
 	if res.Version != nil {
 		body.Version = marshalStreamVersionToVersionResponseBody(res.Version)
 	}
@@ -1951,13 +2613,28 @@ func NewTestSourceResponseBody(res *stream.TestResult) *TestSourceResponseBody {
 	} else {
 		body.Tables = []*TestResultTableResponseBody{}
 	}
-	return body
-}
-
-// NewDisableSourceResponseBody builds the HTTP response body from the result
-// of the "DisableSource" endpoint of the "stream" service.
-func NewDisableSourceResponseBody(res *stream.Task) *DisableSourceResponseBody {
-	body := &DisableSourceResponseBody{
+	if res.Sinks != nil {
+		body.Sinks = make([]*TestResultSinkResponseBody, len(res.Sinks))
+		for i, val := range res.Sinks {
+			if val == nil {
+				body.Sinks[i] = nil
+				continue
+			}
+			body.Sinks[i] = marshalStreamTestResultSinkToTestResultSinkResponseBody(val)
+		}
+	} else {
+		body.Sinks = []*TestResultSinkResponseBody{}
+	}
+	if res.Signature != nil {
+		body.Signature = marshalStreamTestResultSignatureToTestResultSignatureResponseBody(res.Signature)
+	}
+	return body
+}
+
+// NewDisableSourceResponseBody builds the HTTP response body from the result
+// of the "DisableSource" endpoint of the "stream" service.
+func NewDisableSourceResponseBody(res *stream.Task) *DisableSourceResponseBody {
+	body := &DisableSourceResponseBody{
 		TaskID:     string(res.TaskID),
 		Type:       res.Type,
 		URL:        res.URL,
@@ -2013,6 +2690,9 @@ func NewRotateSourceSecretResponseBody(res *stream.Source) *RotateSourceSecretRe
 	if res.Otlp != nil {
 		body.Otlp = marshalStreamOTLPSourceToOTLPSourceResponseBody(res.Otlp)
 	}
+	if res.Kafka != nil {
+		body.Kafka = marshalStreamKafkaSourceToKafkaSourceResponseBody(res.Kafka)
+	}
 	if res.Version != nil {
 		body.Version = marshalStreamVersionToVersionResponseBody(res.Version)
 	}
@@ -2139,6 +2819,8 @@ func NewGetSinkResponseBody(res *stream.Sink) *GetSinkResponseBody {
 		Type:        string(res.Type),
 		Name:        res.Name,
 		Description: res.Description,
+		Filter:      res.Filter,
+		DeadLetter:  res.DeadLetter,
 	}
 	if res.AllowedSignals != nil {
 		body.AllowedSignals = make([]string, len(res.AllowedSignals))
@@ -2149,6 +2831,12 @@ func NewGetSinkResponseBody(res *stream.Sink) *GetSinkResponseBody {
 	if res.Table != nil {
 		body.Table = marshalStreamTableSinkToTableSinkResponseBody(res.Table)
 	}
+	if res.Webhook != nil {
+		body.Webhook = marshalStreamWebhookSinkToWebhookSinkResponseBody(res.Webhook)
+	}
+	if res.File != nil {
+		body.File = marshalStreamFileSinkToFileSinkResponseBody(res.File)
+	}
 	if res.Version != nil {
 		body.Version = marshalStreamVersionToVersionResponseBody(res.Version)
 	}
@@ -2306,6 +2994,12 @@ func NewSinkStatisticsTotalResponseBody(res *stream.SinkStatisticsTotalResult) *
 	if res.Levels != nil {
 		body.Levels = marshalStreamLevelsToLevelsResponseBody(res.Levels)
 	}
+	if res.Dropped != nil {
+		body.Dropped = marshalStreamLevelToLevelResponseBody(res.Dropped)
+	}
+	if res.Deduplicated != nil {
+		body.Deduplicated = marshalStreamLevelToLevelResponseBody(res.Deduplicated)
+	}
 	return body
 }
 
@@ -2325,6 +3019,16 @@ func NewSinkStatisticsFilesResponseBody(res *stream.SinkStatisticsFilesResult) *
 	} else {
 		body.Files = []*SinkFileResponseBody{}
 	}
+	if res.Partitions != nil {
+		body.Partitions = make([]*SinkPartitionResponseBody, len(res.Partitions))
+		for i, val := range res.Partitions {
+			if val == nil {
+				body.Partitions[i] = nil
+				continue
+			}
+			body.Partitions[i] = marshalStreamSinkPartitionToSinkPartitionResponseBody(val)
+		}
+	}
 	return body
 }
 
@@ -2391,6 +3095,27 @@ func NewUndeleteSinkResponseBody(res *stream.Task) *UndeleteSinkResponseBody {
 	return body
 }
 
+// NewReimportSinkFilesResponseBody builds the HTTP response body from the
+// result of the "ReimportSinkFiles" endpoint of the "stream" service.
+func NewReimportSinkFilesResponseBody(res *stream.Task) *ReimportSinkFilesResponseBody {
+	body := &ReimportSinkFilesResponseBody{
+		TaskID:     string(res.TaskID),
+		Type:       res.Type,
+		URL:        res.URL,
+		Status:     res.Status,
+		IsFinished: res.IsFinished,
+		CreatedAt:  res.CreatedAt,
+		FinishedAt: res.FinishedAt,
+		Duration:   res.Duration,
+		Result:     res.Result,
+		Error:      res.Error,
+	}
+	if res.Outputs != nil {
+		body.Outputs = marshalStreamTaskOutputsToTaskOutputsResponseBody(res.Outputs)
+	}
+	return body
+}
+
 // NewListSinkVersionsResponseBody builds the HTTP response body from the
 // result of the "ListSinkVersions" endpoint of the "stream" service.
 func NewListSinkVersionsResponseBody(res *stream.EntityVersions) *ListSinkVersionsResponseBody {
@@ -2449,6 +3174,100 @@ func NewRollbackSinkVersionResponseBody(res *stream.Task) *RollbackSinkVersionRe
 	return body
 }
 
+// NewListSinkDeadLettersResponseBody builds the HTTP response body from the
+// result of the "ListSinkDeadLetters" endpoint of the "stream" service.
+func NewListSinkDeadLettersResponseBody(res *stream.SinkDeadLetterRecordsList) *ListSinkDeadLettersResponseBody {
+	body := &ListSinkDeadLettersResponseBody{
+		ProjectID: int(res.ProjectID),
+		BranchID:  int(res.BranchID),
+		SourceID:  string(res.SourceID),
+		SinkID:    string(res.SinkID),
+	}
+	if res.Page != nil {
+		body.Page = marshalStreamPaginatedResponseToPaginatedResponseResponseBody(res.Page)
+	}
+	if res.Records != nil {
+		body.Records = make([]*SinkDeadLetterRecordResponseBody, len(res.Records))
+		for i, val := range res.Records {
+			if val == nil {
+				body.Records[i] = nil
+				continue
+			}
+			body.Records[i] = marshalStreamSinkDeadLetterRecordToSinkDeadLetterRecordResponseBody(val)
+		}
+	} else {
+		body.Records = []*SinkDeadLetterRecordResponseBody{}
+	}
+	return body
+}
+
+// NewGetSinkDeadLetterResponseBody builds the HTTP response body from the
+// result of the "GetSinkDeadLetter" endpoint of the "stream" service.
+func NewGetSinkDeadLetterResponseBody(res *stream.SinkDeadLetterRecord) *GetSinkDeadLetterResponseBody {
+	body := &GetSinkDeadLetterResponseBody{
+		ProjectID:       int(res.ProjectID),
+		BranchID:        int(res.BranchID),
+		SourceID:        string(res.SourceID),
+		SinkID:          string(res.SinkID),
+		RecordID:        string(res.RecordID),
+		ReceivedAt:      res.ReceivedAt,
+		FailedAt:        res.FailedAt,
+		Error:           res.Error,
+		ClientIP:        res.ClientIP,
+		Body:            res.Body,
+		BodyTruncated:   res.BodyTruncated,
+		ReplayRequested: res.ReplayRequested,
+	}
+	if res.Headers != nil {
+		body.Headers = make(map[string]string, len(res.Headers))
+		for key, val := range res.Headers {
+			tk := key
+			tv := val
+			body.Headers[tk] = tv
+		}
+	}
+	return body
+}
+
+// NewPreviewSinkDataResponseBody builds the HTTP response body from the result
+// of the "PreviewSinkData" endpoint of the "stream" service.
+func NewPreviewSinkDataResponseBody(res *stream.SinkDataPreview) *PreviewSinkDataResponseBody {
+	body := &PreviewSinkDataResponseBody{
+		ProjectID: int(res.ProjectID),
+		BranchID:  int(res.BranchID),
+		SourceID:  string(res.SourceID),
+		SinkID:    string(res.SinkID),
+	}
+	if res.Rows != nil {
+		body.Rows = make([]*SinkDataPreviewRowResponseBody, len(res.Rows))
+		for i, val := range res.Rows {
+			if val == nil {
+				body.Rows[i] = nil
+				continue
+			}
+			body.Rows[i] = marshalStreamSinkDataPreviewRowToSinkDataPreviewRowResponseBody(val)
+		}
+	} else {
+		body.Rows = []*SinkDataPreviewRowResponseBody{}
+	}
+	return body
+}
+
+// NewInferSinkMappingResponseBody builds the HTTP response body from the
+// result of the "InferSinkMapping" endpoint of the "stream" service.
+func NewInferSinkMappingResponseBody(res *stream.InferredSinkMapping) *InferSinkMappingResponseBody {
+	body := &InferSinkMappingResponseBody{
+		ProjectID:    int(res.ProjectID),
+		BranchID:     int(res.BranchID),
+		SourceID:     string(res.SourceID),
+		SamplesCount: res.SamplesCount,
+	}
+	if res.Mapping != nil {
+		body.Mapping = marshalStreamTableMappingToTableMappingResponseBody(res.Mapping)
+	}
+	return body
+}
+
 // NewGetTaskResponseBody builds the HTTP response body from the result of the
 // "GetTask" endpoint of the "stream" service.
 func NewGetTaskResponseBody(res *stream.Task) *GetTaskResponseBody {
@@ -3050,6 +3869,30 @@ func NewUndeleteSinkStreamAPISinkNotFoundResponseBody(res *stream.GenericError)
 	return body
 }
 
+// NewReimportSinkFilesStreamAPISourceNotFoundResponseBody builds the HTTP
+// response body from the result of the "ReimportSinkFiles" endpoint of the
+// "stream" service.
+func NewReimportSinkFilesStreamAPISourceNotFoundResponseBody(res *stream.GenericError) *ReimportSinkFilesStreamAPISourceNotFoundResponseBody {
+	body := &ReimportSinkFilesStreamAPISourceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewReimportSinkFilesStreamAPISinkNotFoundResponseBody builds the HTTP
+// response body from the result of the "ReimportSinkFiles" endpoint of the
+// "stream" service.
+func NewReimportSinkFilesStreamAPISinkNotFoundResponseBody(res *stream.GenericError) *ReimportSinkFilesStreamAPISinkNotFoundResponseBody {
+	body := &ReimportSinkFilesStreamAPISinkNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewListSinkVersionsStreamAPISourceNotFoundResponseBody builds the HTTP
 // response body from the result of the "ListSinkVersions" endpoint of the
 // "stream" service.
@@ -3146,6 +3989,186 @@ func NewRollbackSinkVersionStreamAPIVersionNotFoundResponseBody(res *stream.Gene
 	return body
 }
 
+// NewListSinkDeadLettersStreamAPISourceNotFoundResponseBody builds the HTTP
+// response body from the result of the "ListSinkDeadLetters" endpoint of the
+// "stream" service.
+func NewListSinkDeadLettersStreamAPISourceNotFoundResponseBody(res *stream.GenericError) *ListSinkDeadLettersStreamAPISourceNotFoundResponseBody {
+	body := &ListSinkDeadLettersStreamAPISourceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewListSinkDeadLettersStreamAPISinkNotFoundResponseBody builds the HTTP
+// response body from the result of the "ListSinkDeadLetters" endpoint of the
+// "stream" service.
+func NewListSinkDeadLettersStreamAPISinkNotFoundResponseBody(res *stream.GenericError) *ListSinkDeadLettersStreamAPISinkNotFoundResponseBody {
+	body := &ListSinkDeadLettersStreamAPISinkNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewGetSinkDeadLetterStreamAPISourceNotFoundResponseBody builds the HTTP
+// response body from the result of the "GetSinkDeadLetter" endpoint of the
+// "stream" service.
+func NewGetSinkDeadLetterStreamAPISourceNotFoundResponseBody(res *stream.GenericError) *GetSinkDeadLetterStreamAPISourceNotFoundResponseBody {
+	body := &GetSinkDeadLetterStreamAPISourceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewGetSinkDeadLetterStreamAPISinkNotFoundResponseBody builds the HTTP
+// response body from the result of the "GetSinkDeadLetter" endpoint of the
+// "stream" service.
+func NewGetSinkDeadLetterStreamAPISinkNotFoundResponseBody(res *stream.GenericError) *GetSinkDeadLetterStreamAPISinkNotFoundResponseBody {
+	body := &GetSinkDeadLetterStreamAPISinkNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewGetSinkDeadLetterStreamAPIRecordNotFoundResponseBody builds the HTTP
+// response body from the result of the "GetSinkDeadLetter" endpoint of the
+// "stream" service.
+func NewGetSinkDeadLetterStreamAPIRecordNotFoundResponseBody(res *stream.GenericError) *GetSinkDeadLetterStreamAPIRecordNotFoundResponseBody {
+	body := &GetSinkDeadLetterStreamAPIRecordNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewReplaySinkDeadLetterStreamAPISourceNotFoundResponseBody builds the HTTP
+// response body from the result of the "ReplaySinkDeadLetter" endpoint of the
+// "stream" service.
+func NewReplaySinkDeadLetterStreamAPISourceNotFoundResponseBody(res *stream.GenericError) *ReplaySinkDeadLetterStreamAPISourceNotFoundResponseBody {
+	body := &ReplaySinkDeadLetterStreamAPISourceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewReplaySinkDeadLetterStreamAPISinkNotFoundResponseBody builds the HTTP
+// response body from the result of the "ReplaySinkDeadLetter" endpoint of the
+// "stream" service.
+func NewReplaySinkDeadLetterStreamAPISinkNotFoundResponseBody(res *stream.GenericError) *ReplaySinkDeadLetterStreamAPISinkNotFoundResponseBody {
+	body := &ReplaySinkDeadLetterStreamAPISinkNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewReplaySinkDeadLetterStreamAPIRecordNotFoundResponseBody builds the HTTP
+// response body from the result of the "ReplaySinkDeadLetter" endpoint of the
+// "stream" service.
+func NewReplaySinkDeadLetterStreamAPIRecordNotFoundResponseBody(res *stream.GenericError) *ReplaySinkDeadLetterStreamAPIRecordNotFoundResponseBody {
+	body := &ReplaySinkDeadLetterStreamAPIRecordNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewReplaySinkDeadLetterStreamAPIInvalidColumnValueResponseBody builds the
+// HTTP response body from the result of the "ReplaySinkDeadLetter" endpoint of
+// the "stream" service.
+func NewReplaySinkDeadLetterStreamAPIInvalidColumnValueResponseBody(res *stream.GenericError) *ReplaySinkDeadLetterStreamAPIInvalidColumnValueResponseBody {
+	body := &ReplaySinkDeadLetterStreamAPIInvalidColumnValueResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewPurgeSinkDeadLettersStreamAPISourceNotFoundResponseBody builds the HTTP
+// response body from the result of the "PurgeSinkDeadLetters" endpoint of the
+// "stream" service.
+func NewPurgeSinkDeadLettersStreamAPISourceNotFoundResponseBody(res *stream.GenericError) *PurgeSinkDeadLettersStreamAPISourceNotFoundResponseBody {
+	body := &PurgeSinkDeadLettersStreamAPISourceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewPurgeSinkDeadLettersStreamAPISinkNotFoundResponseBody builds the HTTP
+// response body from the result of the "PurgeSinkDeadLetters" endpoint of the
+// "stream" service.
+func NewPurgeSinkDeadLettersStreamAPISinkNotFoundResponseBody(res *stream.GenericError) *PurgeSinkDeadLettersStreamAPISinkNotFoundResponseBody {
+	body := &PurgeSinkDeadLettersStreamAPISinkNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewPreviewSinkDataStreamAPISourceNotFoundResponseBody builds the HTTP
+// response body from the result of the "PreviewSinkData" endpoint of the
+// "stream" service.
+func NewPreviewSinkDataStreamAPISourceNotFoundResponseBody(res *stream.GenericError) *PreviewSinkDataStreamAPISourceNotFoundResponseBody {
+	body := &PreviewSinkDataStreamAPISourceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewPreviewSinkDataStreamAPISinkNotFoundResponseBody builds the HTTP response
+// body from the result of the "PreviewSinkData" endpoint of the "stream"
+// service.
+func NewPreviewSinkDataStreamAPISinkNotFoundResponseBody(res *stream.GenericError) *PreviewSinkDataStreamAPISinkNotFoundResponseBody {
+	body := &PreviewSinkDataStreamAPISinkNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewInferSinkMappingStreamAPISourceNotFoundResponseBody builds the HTTP
+// response body from the result of the "InferSinkMapping" endpoint of the
+// "stream" service.
+func NewInferSinkMappingStreamAPISourceNotFoundResponseBody(res *stream.GenericError) *InferSinkMappingStreamAPISourceNotFoundResponseBody {
+	body := &InferSinkMappingStreamAPISourceNotFoundResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
+// NewInferSinkMappingStreamAPIUnprocessableContentResponseBody builds the HTTP
+// response body from the result of the "InferSinkMapping" endpoint of the
+// "stream" service.
+func NewInferSinkMappingStreamAPIUnprocessableContentResponseBody(res *stream.GenericError) *InferSinkMappingStreamAPIUnprocessableContentResponseBody {
+	body := &InferSinkMappingStreamAPIUnprocessableContentResponseBody{
+		StatusCode: res.StatusCode,
+		Name:       res.Name,
+		Message:    res.Message,
+	}
+	return body
+}
+
 // NewGetTaskStreamAPITaskNotFoundResponseBody builds the HTTP response body
 // from the result of the "GetTask" endpoint of the "stream" service.
 func NewGetTaskStreamAPITaskNotFoundResponseBody(res *stream.GenericError) *GetTaskStreamAPITaskNotFoundResponseBody {
@@ -3168,6 +4191,12 @@ func NewCreateSourcePayload(body *CreateSourceRequestBody, branchID string, stor
 		sourceID := stream.SourceID(*body.SourceID)
 		v.SourceID = &sourceID
 	}
+	if body.Kafka != nil {
+		v.Kafka = unmarshalKafkaSourceRequestBodyToStreamKafkaSource(body.Kafka)
+	}
+	if body.HTTPSignature != nil {
+		v.HTTPSignature = unmarshalHTTPSourceSignatureRequestBodyToStreamHTTPSourceSignature(body.HTTPSignature)
+	}
 	v.BranchID = stream.BranchIDOrDefault(branchID)
 	v.StorageAPIToken = storageAPIToken
 
@@ -3177,14 +4206,21 @@ func NewCreateSourcePayload(body *CreateSourceRequestBody, branchID string, stor
 // NewUpdateSourcePayload builds a stream service UpdateSource endpoint payload.
 func NewUpdateSourcePayload(body *UpdateSourceRequestBody, branchID string, sourceID string, storageAPIToken string) *stream.UpdateSourcePayload {
 	v := &stream.UpdateSourcePayload{
-		ChangeDescription: body.ChangeDescription,
-		Name:              body.Name,
-		Description:       body.Description,
+		ChangeDescription:   body.ChangeDescription,
+		Name:                body.Name,
+		Description:         body.Description,
+		RemoveHTTPSignature: body.RemoveHTTPSignature,
 	}
 	if body.Type != nil {
 		type_ := stream.SourceType(*body.Type)
 		v.Type = &type_
 	}
+	if body.Kafka != nil {
+		v.Kafka = unmarshalKafkaSourceRequestBodyToStreamKafkaSource(body.Kafka)
+	}
+	if body.HTTPSignature != nil {
+		v.HTTPSignature = unmarshalHTTPSourceSignatureRequestBodyToStreamHTTPSourceSignature(body.HTTPSignature)
+	}
 	v.BranchID = stream.BranchIDOrDefault(branchID)
 	v.SourceID = stream.SourceID(sourceID)
 	v.StorageAPIToken = storageAPIToken
@@ -3381,6 +4417,8 @@ func NewCreateSinkPayload(body *CreateSinkRequestBody, branchID string, sourceID
 		Type:        stream.SinkType(*body.Type),
 		Name:        *body.Name,
 		Description: body.Description,
+		Filter:      body.Filter,
+		DeadLetter:  body.DeadLetter,
 	}
 	if body.SinkID != nil {
 		sinkID := stream.SinkID(*body.SinkID)
@@ -3395,6 +4433,12 @@ func NewCreateSinkPayload(body *CreateSinkRequestBody, branchID string, sourceID
 	if body.Table != nil {
 		v.Table = unmarshalTableSinkCreateRequestBodyToStreamTableSinkCreate(body.Table)
 	}
+	if body.Webhook != nil {
+		v.Webhook = unmarshalWebhookSinkCreateRequestBodyToStreamWebhookSinkCreate(body.Webhook)
+	}
+	if body.File != nil {
+		v.File = unmarshalFileSinkCreateRequestBodyToStreamFileSinkCreate(body.File)
+	}
 	v.BranchID = stream.BranchIDOrDefault(branchID)
 	v.SourceID = stream.SourceID(sourceID)
 	v.StorageAPIToken = storageAPIToken
@@ -3480,6 +4524,8 @@ func NewUpdateSinkPayload(body *UpdateSinkRequestBody, branchID string, sourceID
 		ChangeDescription: body.ChangeDescription,
 		Name:              body.Name,
 		Description:       body.Description,
+		Filter:            body.Filter,
+		DeadLetter:        body.DeadLetter,
 	}
 	if body.Type != nil {
 		type_ := stream.SinkType(*body.Type)
@@ -3494,6 +4540,12 @@ func NewUpdateSinkPayload(body *UpdateSinkRequestBody, branchID string, sourceID
 	if body.Table != nil {
 		v.Table = unmarshalTableSinkUpdateRequestBodyToStreamTableSinkUpdate(body.Table)
 	}
+	if body.Webhook != nil {
+		v.Webhook = unmarshalWebhookSinkUpdateRequestBodyToStreamWebhookSinkUpdate(body.Webhook)
+	}
+	if body.File != nil {
+		v.File = unmarshalFileSinkUpdateRequestBodyToStreamFileSinkUpdate(body.File)
+	}
 	v.BranchID = stream.BranchIDOrDefault(branchID)
 	v.SourceID = stream.SourceID(sourceID)
 	v.SinkID = stream.SinkID(sinkID)
@@ -3583,6 +4635,25 @@ func NewUndeleteSinkPayload(branchID string, sourceID string, sinkID string, sto
 	return v
 }
 
+// NewReimportSinkFilesPayload builds a stream service ReimportSinkFiles
+// endpoint payload.
+func NewReimportSinkFilesPayload(body *ReimportSinkFilesRequestBody, branchID string, sourceID string, sinkID string, storageAPIToken string) *stream.ReimportSinkFilesPayload {
+	v := &stream.ReimportSinkFilesPayload{
+		Since: *body.Since,
+		Until: *body.Until,
+	}
+	if body.TableID != nil {
+		tableID := stream.TableID(*body.TableID)
+		v.TableID = &tableID
+	}
+	v.BranchID = stream.BranchIDOrDefault(branchID)
+	v.SourceID = stream.SourceID(sourceID)
+	v.SinkID = stream.SinkID(sinkID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
 // NewListSinkVersionsPayload builds a stream service ListSinkVersions endpoint
 // payload.
 func NewListSinkVersionsPayload(branchID string, sourceID string, sinkID string, afterID string, limit int, storageAPIToken string) *stream.ListSinkVersionsPayload {
@@ -3623,6 +4694,94 @@ func NewRollbackSinkVersionPayload(branchID string, sourceID string, sinkID stri
 	return v
 }
 
+// NewListSinkDeadLettersPayload builds a stream service ListSinkDeadLetters
+// endpoint payload.
+func NewListSinkDeadLettersPayload(branchID string, sourceID string, sinkID string, afterID string, limit int, storageAPIToken string) *stream.ListSinkDeadLettersPayload {
+	v := &stream.ListSinkDeadLettersPayload{}
+	v.BranchID = stream.BranchIDOrDefault(branchID)
+	v.SourceID = stream.SourceID(sourceID)
+	v.SinkID = stream.SinkID(sinkID)
+	v.AfterID = afterID
+	v.Limit = limit
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewGetSinkDeadLetterPayload builds a stream service GetSinkDeadLetter
+// endpoint payload.
+func NewGetSinkDeadLetterPayload(branchID string, sourceID string, sinkID string, recordID string, storageAPIToken string) *stream.GetSinkDeadLetterPayload {
+	v := &stream.GetSinkDeadLetterPayload{}
+	v.BranchID = stream.BranchIDOrDefault(branchID)
+	v.SourceID = stream.SourceID(sourceID)
+	v.SinkID = stream.SinkID(sinkID)
+	v.RecordID = stream.SinkDeadLetterRecordID(recordID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewReplaySinkDeadLetterPayload builds a stream service ReplaySinkDeadLetter
+// endpoint payload.
+func NewReplaySinkDeadLetterPayload(branchID string, sourceID string, sinkID string, recordID string, storageAPIToken string) *stream.ReplaySinkDeadLetterPayload {
+	v := &stream.ReplaySinkDeadLetterPayload{}
+	v.BranchID = stream.BranchIDOrDefault(branchID)
+	v.SourceID = stream.SourceID(sourceID)
+	v.SinkID = stream.SinkID(sinkID)
+	v.RecordID = stream.SinkDeadLetterRecordID(recordID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewPurgeSinkDeadLettersPayload builds a stream service PurgeSinkDeadLetters
+// endpoint payload.
+func NewPurgeSinkDeadLettersPayload(branchID string, sourceID string, sinkID string, storageAPIToken string) *stream.PurgeSinkDeadLettersPayload {
+	v := &stream.PurgeSinkDeadLettersPayload{}
+	v.BranchID = stream.BranchIDOrDefault(branchID)
+	v.SourceID = stream.SourceID(sourceID)
+	v.SinkID = stream.SinkID(sinkID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewPreviewSinkDataPayload builds a stream service PreviewSinkData endpoint
+// payload.
+func NewPreviewSinkDataPayload(branchID string, sourceID string, sinkID string, limit int, storageAPIToken string) *stream.PreviewSinkDataPayload {
+	v := &stream.PreviewSinkDataPayload{}
+	v.BranchID = stream.BranchIDOrDefault(branchID)
+	v.SourceID = stream.SourceID(sourceID)
+	v.SinkID = stream.SinkID(sinkID)
+	v.Limit = limit
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
+// NewInferSinkMappingPayload builds a stream service InferSinkMapping endpoint
+// payload.
+func NewInferSinkMappingPayload(body *InferSinkMappingRequestBody, branchID string, sourceID string, storageAPIToken string) *stream.InferSinkMappingPayload {
+	v := &stream.InferSinkMappingPayload{}
+	if body.MaxDepth != nil {
+		v.MaxDepth = *body.MaxDepth
+	}
+	if body.Samples != nil {
+		v.Samples = make([]any, len(body.Samples))
+		for i, val := range body.Samples {
+			v.Samples[i] = val
+		}
+	}
+	if body.MaxDepth == nil {
+		v.MaxDepth = 2
+	}
+	v.BranchID = stream.BranchIDOrDefault(branchID)
+	v.SourceID = stream.SourceID(sourceID)
+	v.StorageAPIToken = storageAPIToken
+
+	return v
+}
+
 // NewGetTaskPayload builds a stream service GetTask endpoint payload.
 func NewGetTaskPayload(taskID string, storageAPIToken string) *stream.GetTaskPayload {
 	v := &stream.GetTaskPayload{}
@@ -3664,8 +4823,8 @@ func ValidateCreateSourceRequestBody(body *CreateSourceRequestBody, errContext [
 		}
 	}
 	if body.Type != nil {
-		if !(*body.Type == "http" || *body.Type == "otlp") {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"http", "otlp"}))
+		if !(*body.Type == "http" || *body.Type == "otlp" || *body.Type == "kafka") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"http", "otlp", "kafka"}))
 		}
 	}
 	if body.Name != nil {
@@ -3683,6 +4842,16 @@ func ValidateCreateSourceRequestBody(body *CreateSourceRequestBody, errContext [
 			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "description"), "."), *body.Description, utf8.RuneCountInString(*body.Description), 4096, false))
 		}
 	}
+	if body.Kafka != nil {
+		if err2 := ValidateKafkaSourceRequestBody(body.Kafka, append(errContext, "kafka")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.HTTPSignature != nil {
+		if err2 := ValidateHTTPSourceSignatureRequestBody(body.HTTPSignature, append(errContext, "hTTPSignature")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
 
@@ -3690,8 +4859,8 @@ func ValidateCreateSourceRequestBody(body *CreateSourceRequestBody, errContext [
 // UpdateSourceRequestBody
 func ValidateUpdateSourceRequestBody(body *UpdateSourceRequestBody, errContext []string) (err error) {
 	if body.Type != nil {
-		if !(*body.Type == "http" || *body.Type == "otlp") {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"http", "otlp"}))
+		if !(*body.Type == "http" || *body.Type == "otlp" || *body.Type == "kafka") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"http", "otlp", "kafka"}))
 		}
 	}
 	if body.Name != nil {
@@ -3709,6 +4878,16 @@ func ValidateUpdateSourceRequestBody(body *UpdateSourceRequestBody, errContext [
 			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "description"), "."), *body.Description, utf8.RuneCountInString(*body.Description), 4096, false))
 		}
 	}
+	if body.Kafka != nil {
+		if err2 := ValidateKafkaSourceRequestBody(body.Kafka, append(errContext, "kafka")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.HTTPSignature != nil {
+		if err2 := ValidateHTTPSourceSignatureRequestBody(body.HTTPSignature, append(errContext, "hTTPSignature")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
 
@@ -3746,8 +4925,8 @@ func ValidateCreateSinkRequestBody(body *CreateSinkRequestBody, errContext []str
 		}
 	}
 	if body.Type != nil {
-		if !(*body.Type == "table") {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"table"}))
+		if !(*body.Type == "table" || *body.Type == "webhook" || *body.Type == "file") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"table", "webhook", "file"}))
 		}
 	}
 	if body.Name != nil {
@@ -3771,11 +4950,26 @@ func ValidateCreateSinkRequestBody(body *CreateSinkRequestBody, errContext []str
 			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "allowedSignals[*]"), "."), e, []any{"logs", "metrics", "traces"}))
 		}
 	}
+	if body.Filter != nil {
+		if utf8.RuneCountInString(*body.Filter) > 4096 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "filter"), "."), *body.Filter, utf8.RuneCountInString(*body.Filter), 4096, false))
+		}
+	}
 	if body.Table != nil {
 		if err2 := ValidateTableSinkCreateRequestBody(body.Table, append(errContext, "table")); err2 != nil {
 			err = goa.MergeErrors(err, err2)
 		}
 	}
+	if body.Webhook != nil {
+		if err2 := ValidateWebhookSinkCreateRequestBody(body.Webhook, append(errContext, "webhook")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.File != nil {
+		if err2 := ValidateFileSinkCreateRequestBody(body.File, append(errContext, "file")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
 
@@ -3797,8 +4991,8 @@ func ValidateUpdateSinkSettingsRequestBody(body *UpdateSinkSettingsRequestBody,
 // UpdateSinkRequestBody
 func ValidateUpdateSinkRequestBody(body *UpdateSinkRequestBody, errContext []string) (err error) {
 	if body.Type != nil {
-		if !(*body.Type == "table") {
-			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"table"}))
+		if !(*body.Type == "table" || *body.Type == "webhook" || *body.Type == "file") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"table", "webhook", "file"}))
 		}
 	}
 	if body.Name != nil {
@@ -3822,11 +5016,163 @@ func ValidateUpdateSinkRequestBody(body *UpdateSinkRequestBody, errContext []str
 			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "allowedSignals[*]"), "."), e, []any{"logs", "metrics", "traces"}))
 		}
 	}
+	if body.Filter != nil {
+		if utf8.RuneCountInString(*body.Filter) > 4096 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "filter"), "."), *body.Filter, utf8.RuneCountInString(*body.Filter), 4096, false))
+		}
+	}
 	if body.Table != nil {
 		if err2 := ValidateTableSinkUpdateRequestBody(body.Table, append(errContext, "table")); err2 != nil {
 			err = goa.MergeErrors(err, err2)
 		}
 	}
+	if body.Webhook != nil {
+		if err2 := ValidateWebhookSinkUpdateRequestBody(body.Webhook, append(errContext, "webhook")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.File != nil {
+		if err2 := ValidateFileSinkUpdateRequestBody(body.File, append(errContext, "file")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateReimportSinkFilesRequestBody runs the validations defined on
+// ReimportSinkFilesRequestBody
+func ValidateReimportSinkFilesRequestBody(body *ReimportSinkFilesRequestBody, errContext []string) (err error) {
+	if body.Since == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("since", strings.Join(errContext, ".")))
+	}
+	if body.Until == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("until", strings.Join(errContext, ".")))
+	}
+	if body.Since != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.since", *body.Since, goa.FormatDateTime))
+	}
+	if body.Until != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.until", *body.Until, goa.FormatDateTime))
+	}
+	return
+}
+
+// ValidateInferSinkMappingRequestBody runs the validations defined on
+// InferSinkMappingRequestBody
+func ValidateInferSinkMappingRequestBody(body *InferSinkMappingRequestBody, errContext []string) (err error) {
+	if len(body.Samples) > 100 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "samples"), "."), body.Samples, len(body.Samples), 100, false))
+	}
+	if body.MaxDepth != nil {
+		if *body.MaxDepth < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(strings.Join(append(errContext, "maxDepth"), "."), *body.MaxDepth, 1, true))
+		}
+	}
+	if body.MaxDepth != nil {
+		if *body.MaxDepth > 10 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(strings.Join(append(errContext, "maxDepth"), "."), *body.MaxDepth, 10, false))
+		}
+	}
+	return
+}
+
+// ValidateKafkaSourceRequestBody runs the validations defined on
+// KafkaSourceRequestBody
+func ValidateKafkaSourceRequestBody(body *KafkaSourceRequestBody, errContext []string) (err error) {
+	if body.Brokers == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("brokers", strings.Join(errContext, ".")))
+	}
+	if body.Topic == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("topic", strings.Join(errContext, ".")))
+	}
+	if body.GroupID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("groupId", strings.Join(errContext, ".")))
+	}
+	if body.Topic != nil {
+		if utf8.RuneCountInString(*body.Topic) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "topic"), "."), *body.Topic, utf8.RuneCountInString(*body.Topic), 1, true))
+		}
+	}
+	if body.Topic != nil {
+		if utf8.RuneCountInString(*body.Topic) > 249 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "topic"), "."), *body.Topic, utf8.RuneCountInString(*body.Topic), 249, false))
+		}
+	}
+	if body.GroupID != nil {
+		if utf8.RuneCountInString(*body.GroupID) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "groupId"), "."), *body.GroupID, utf8.RuneCountInString(*body.GroupID), 1, true))
+		}
+	}
+	if body.GroupID != nil {
+		if utf8.RuneCountInString(*body.GroupID) > 255 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "groupId"), "."), *body.GroupID, utf8.RuneCountInString(*body.GroupID), 255, false))
+		}
+	}
+	return
+}
+
+// ValidateHTTPSourceSignatureRequestBody runs the validations defined on
+// HTTPSourceSignatureRequestBody
+func ValidateHTTPSourceSignatureRequestBody(body *HTTPSourceSignatureRequestBody, errContext []string) (err error) {
+	if body.Algorithm == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("algorithm", strings.Join(errContext, ".")))
+	}
+	if body.Format == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("format", strings.Join(errContext, ".")))
+	}
+	if body.Header == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("header", strings.Join(errContext, ".")))
+	}
+	if body.Algorithm != nil {
+		if !(*body.Algorithm == "hmac-sha1" || *body.Algorithm == "hmac-sha256" || *body.Algorithm == "hmac-sha512") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "algorithm"), "."), *body.Algorithm, []any{"hmac-sha1", "hmac-sha256", "hmac-sha512"}))
+		}
+	}
+	if body.Format != nil {
+		if !(*body.Format == "hex" || *body.Format == "stripe" || *body.Format == "slack") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "format"), "."), *body.Format, []any{"hex", "stripe", "slack"}))
+		}
+	}
+	if body.Header != nil {
+		if utf8.RuneCountInString(*body.Header) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "header"), "."), *body.Header, utf8.RuneCountInString(*body.Header), 1, true))
+		}
+	}
+	if body.Header != nil {
+		if utf8.RuneCountInString(*body.Header) > 100 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "header"), "."), *body.Header, utf8.RuneCountInString(*body.Header), 100, false))
+		}
+	}
+	if body.Secret != nil {
+		if utf8.RuneCountInString(*body.Secret) < 1 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "secret"), "."), *body.Secret, utf8.RuneCountInString(*body.Secret), 1, true))
+		}
+	}
+	if body.Secret != nil {
+		if utf8.RuneCountInString(*body.Secret) > 256 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "secret"), "."), *body.Secret, utf8.RuneCountInString(*body.Secret), 256, false))
+		}
+	}
+	if body.TimestampToleranceSeconds != nil {
+		if *body.TimestampToleranceSeconds < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(strings.Join(append(errContext, "timestampToleranceSeconds"), "."), *body.TimestampToleranceSeconds, 0, true))
+		}
+	}
+	if body.TimestampToleranceSeconds != nil {
+		if *body.TimestampToleranceSeconds > 86400 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(strings.Join(append(errContext, "timestampToleranceSeconds"), "."), *body.TimestampToleranceSeconds, 86400, false))
+		}
+	}
+	if body.ReplayWindowSeconds != nil {
+		if *body.ReplayWindowSeconds < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(strings.Join(append(errContext, "replayWindowSeconds"), "."), *body.ReplayWindowSeconds, 0, true))
+		}
+	}
+	if body.ReplayWindowSeconds != nil {
+		if *body.ReplayWindowSeconds > 86400 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(strings.Join(append(errContext, "replayWindowSeconds"), "."), *body.ReplayWindowSeconds, 86400, false))
+		}
+	}
 	return
 }
 
@@ -3866,6 +5212,16 @@ func ValidateTableSinkCreateRequestBody(body *TableSinkCreateRequestBody, errCon
 			err = goa.MergeErrors(err, err2)
 		}
 	}
+	if body.Partitioning != nil {
+		if err2 := ValidateTablePartitioningRequestBody(body.Partitioning, append(errContext, "partitioning")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.Mirror != nil {
+		if err2 := ValidateTableMirrorRequestBody(body.Mirror, append(errContext, "mirror")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
 
@@ -3911,6 +5267,11 @@ func ValidateTableColumnRequestBody(body *TableColumnRequestBody, errContext []s
 			err = goa.MergeErrors(err, err2)
 		}
 	}
+	if body.Cast != nil {
+		if err2 := ValidateTableColumnCastRequestBody(body.Cast, append(errContext, "cast")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
 
@@ -3941,6 +5302,119 @@ func ValidateTableColumnTemplateRequestBody(body *TableColumnTemplateRequestBody
 	return
 }
 
+// ValidateTableColumnCastRequestBody runs the validations defined on
+// TableColumnCastRequestBody
+func ValidateTableColumnCastRequestBody(body *TableColumnCastRequestBody, errContext []string) (err error) {
+	if body.DataType == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("dataType", strings.Join(errContext, ".")))
+	}
+	if body.DataType != nil {
+		if !(*body.DataType == "integer" || *body.DataType == "number" || *body.DataType == "boolean" || *body.DataType == "timestamp" || *body.DataType == "json") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "dataType"), "."), *body.DataType, []any{"integer", "number", "boolean", "timestamp", "json"}))
+		}
+	}
+	if body.OnError != nil {
+		if !(*body.OnError == "fail" || *body.OnError == "null" || *body.OnError == "default") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "onError"), "."), *body.OnError, []any{"fail", "null", "default"}))
+		}
+	}
+	return
+}
+
+// ValidateTablePartitioningRequestBody runs the validations defined on
+// TablePartitioningRequestBody
+func ValidateTablePartitioningRequestBody(body *TablePartitioningRequestBody, errContext []string) (err error) {
+	if body.Granularity == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("granularity", strings.Join(errContext, ".")))
+	}
+	if body.Column == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("column", strings.Join(errContext, ".")))
+	}
+	if body.Granularity != nil {
+		if !(*body.Granularity == "daily" || *body.Granularity == "monthly") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "granularity"), "."), *body.Granularity, []any{"daily", "monthly"}))
+		}
+	}
+	return
+}
+
+// ValidateTableMirrorRequestBody runs the validations defined on
+// TableMirrorRequestBody
+func ValidateTableMirrorRequestBody(body *TableMirrorRequestBody, errContext []string) (err error) {
+	if body.Type == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("type", strings.Join(errContext, ".")))
+	}
+	if body.TableID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("tableId", strings.Join(errContext, ".")))
+	}
+	if body.Type != nil {
+		if !(*body.Type == "keboola") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "type"), "."), *body.Type, []any{"keboola"}))
+		}
+	}
+	if body.Mapping != nil {
+		if err2 := ValidateTableMappingRequestBody(body.Mapping, append(errContext, "mapping")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateWebhookSinkCreateRequestBody runs the validations defined on
+// WebhookSinkCreateRequestBody
+func ValidateWebhookSinkCreateRequestBody(body *WebhookSinkCreateRequestBody, errContext []string) (err error) {
+	if body.URL == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("url", strings.Join(errContext, ".")))
+	}
+	if body.Mapping == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("mapping", strings.Join(errContext, ".")))
+	}
+	if body.URL != nil {
+		if utf8.RuneCountInString(*body.URL) > 2048 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "url"), "."), *body.URL, utf8.RuneCountInString(*body.URL), 2048, false))
+		}
+	}
+	if len(body.Headers) > 20 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "headers"), "."), body.Headers, len(body.Headers), 20, false))
+	}
+	for i, e := range body.Headers {
+		errContext := append(errContext, fmt.Sprintf(`headers[%d]`, i))
+		if e != nil {
+			if err2 := ValidateTableColumnRequestBody(e, errContext); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	if body.Mapping != nil {
+		if err2 := ValidateTableMappingRequestBody(body.Mapping, append(errContext, "mapping")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateFileSinkCreateRequestBody runs the validations defined on
+// FileSinkCreateRequestBody
+func ValidateFileSinkCreateRequestBody(body *FileSinkCreateRequestBody, errContext []string) (err error) {
+	if body.Provider == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("provider", strings.Join(errContext, ".")))
+	}
+	if body.Mapping == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("mapping", strings.Join(errContext, ".")))
+	}
+	if body.Provider != nil {
+		if !(*body.Provider == "s3" || *body.Provider == "local") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "provider"), "."), *body.Provider, []any{"s3", "local"}))
+		}
+	}
+	if body.Mapping != nil {
+		if err2 := ValidateTableMappingRequestBody(body.Mapping, append(errContext, "mapping")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
 // ValidateTableSinkUpdateRequestBody runs the validations defined on
 // TableSinkUpdateRequestBody
 func ValidateTableSinkUpdateRequestBody(body *TableSinkUpdateRequestBody, errContext []string) (err error) {
@@ -3954,5 +5428,58 @@ func ValidateTableSinkUpdateRequestBody(body *TableSinkUpdateRequestBody, errCon
 			err = goa.MergeErrors(err, err2)
 		}
 	}
+	if body.Partitioning != nil {
+		if err2 := ValidateTablePartitioningRequestBody(body.Partitioning, append(errContext, "partitioning")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if body.Mirror != nil {
+		if err2 := ValidateTableMirrorRequestBody(body.Mirror, append(errContext, "mirror")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateWebhookSinkUpdateRequestBody runs the validations defined on
+// WebhookSinkUpdateRequestBody
+func ValidateWebhookSinkUpdateRequestBody(body *WebhookSinkUpdateRequestBody, errContext []string) (err error) {
+	if body.URL != nil {
+		if utf8.RuneCountInString(*body.URL) > 2048 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "url"), "."), *body.URL, utf8.RuneCountInString(*body.URL), 2048, false))
+		}
+	}
+	if len(body.Headers) > 20 {
+		err = goa.MergeErrors(err, goa.InvalidLengthError(strings.Join(append(errContext, "headers"), "."), body.Headers, len(body.Headers), 20, false))
+	}
+	for i, e := range body.Headers {
+		errContext := append(errContext, fmt.Sprintf(`headers[%d]`, i))
+		if e != nil {
+			if err2 := ValidateTableColumnRequestBody(e, errContext); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	if body.Mapping != nil {
+		if err2 := ValidateTableMappingRequestBody(body.Mapping, append(errContext, "mapping")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// ValidateFileSinkUpdateRequestBody runs the validations defined on
+// FileSinkUpdateRequestBody
+func ValidateFileSinkUpdateRequestBody(body *FileSinkUpdateRequestBody, errContext []string) (err error) {
+	if body.Provider != nil {
+		if !(*body.Provider == "s3" || *body.Provider == "local") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(strings.Join(append(errContext, "provider"), "."), *body.Provider, []any{"s3", "local"}))
+		}
+	}
+	if body.Mapping != nil {
+		if err2 := ValidateTableMappingRequestBody(body.Mapping, append(errContext, "mapping")); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
@@ -51,15 +51,22 @@ type Client struct {
 	DisableSinkEndpoint           goa.Endpoint
 	EnableSinkEndpoint            goa.Endpoint
 	UndeleteSinkEndpoint          goa.Endpoint
+	ReimportSinkFilesEndpoint     goa.Endpoint
 	ListSinkVersionsEndpoint      goa.Endpoint
 	SinkVersionDetailEndpoint     goa.Endpoint
 	RollbackSinkVersionEndpoint   goa.Endpoint
+	ListSinkDeadLettersEndpoint   goa.Endpoint
+	GetSinkDeadLetterEndpoint     goa.Endpoint
+	ReplaySinkDeadLetterEndpoint  goa.Endpoint
+	PurgeSinkDeadLettersEndpoint  goa.Endpoint
+	PreviewSinkDataEndpoint       goa.Endpoint
+	InferSinkMappingEndpoint      goa.Endpoint
 	GetTaskEndpoint               goa.Endpoint
 	AggregationSourcesEndpoint    goa.Endpoint
 }
 
 // NewClient initializes a "stream" service client given the endpoints.
-func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, createSource, updateSource, listSources, listDeletedSources, getSource, deleteSource, getSourceSettings, updateSourceSettings, testSource, sourceStatisticsClear, disableSource, enableSource, rotateSourceSecret, undeleteSource, listSourceVersions, sourceVersionDetail, rollbackSourceVersion, createSink, getSink, getSinkSettings, updateSinkSettings, listSinks, listDeletedSinks, updateSink, deleteSink, sinkStatisticsTotal, sinkStatisticsFiles, sinkStatisticsClear, disableSink, enableSink, undeleteSink, listSinkVersions, sinkVersionDetail, rollbackSinkVersion, getTask, aggregationSources goa.Endpoint) *Client {
+func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, createSource, updateSource, listSources, listDeletedSources, getSource, deleteSource, getSourceSettings, updateSourceSettings, testSource, sourceStatisticsClear, disableSource, enableSource, rotateSourceSecret, undeleteSource, listSourceVersions, sourceVersionDetail, rollbackSourceVersion, createSink, getSink, getSinkSettings, updateSinkSettings, listSinks, listDeletedSinks, updateSink, deleteSink, sinkStatisticsTotal, sinkStatisticsFiles, sinkStatisticsClear, disableSink, enableSink, undeleteSink, reimportSinkFiles, listSinkVersions, sinkVersionDetail, rollbackSinkVersion, listSinkDeadLetters, getSinkDeadLetter, replaySinkDeadLetter, purgeSinkDeadLetters, previewSinkData, inferSinkMapping, getTask, aggregationSources goa.Endpoint) *Client {
 	return &Client{
 		APIRootIndexEndpoint:          aPIRootIndex,
 		APIVersionIndexEndpoint:       aPIVersionIndex,
@@ -95,9 +102,16 @@ func NewClient(aPIRootIndex, aPIVersionIndex, healthCheck, createSource, updateS
 		DisableSinkEndpoint:           disableSink,
 		EnableSinkEndpoint:            enableSink,
 		UndeleteSinkEndpoint:          undeleteSink,
+		ReimportSinkFilesEndpoint:     reimportSinkFiles,
 		ListSinkVersionsEndpoint:      listSinkVersions,
 		SinkVersionDetailEndpoint:     sinkVersionDetail,
 		RollbackSinkVersionEndpoint:   rollbackSinkVersion,
+		ListSinkDeadLettersEndpoint:   listSinkDeadLetters,
+		GetSinkDeadLetterEndpoint:     getSinkDeadLetter,
+		ReplaySinkDeadLetterEndpoint:  replaySinkDeadLetter,
+		PurgeSinkDeadLettersEndpoint:  purgeSinkDeadLetters,
+		PreviewSinkDataEndpoint:       previewSinkData,
+		InferSinkMappingEndpoint:      inferSinkMapping,
 		GetTaskEndpoint:               getTask,
 		AggregationSourcesEndpoint:    aggregationSources,
 	}
@@ -550,6 +564,21 @@ func (c *Client) UndeleteSink(ctx context.Context, p *UndeleteSinkPayload) (res
 	return ires.(*Task), nil
 }
 
+// ReimportSinkFiles calls the "ReimportSinkFiles" endpoint of the "stream"
+// service.
+// ReimportSinkFiles may return the following errors:
+//   - "stream.api.sourceNotFound" (type *GenericError): Source not found error.
+//   - "stream.api.sinkNotFound" (type *GenericError): Sink not found error.
+//   - error: internal error
+func (c *Client) ReimportSinkFiles(ctx context.Context, p *ReimportSinkFilesPayload) (res *Task, err error) {
+	var ires any
+	ires, err = c.ReimportSinkFilesEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*Task), nil
+}
+
 // ListSinkVersions calls the "ListSinkVersions" endpoint of the "stream"
 // service.
 // ListSinkVersions may return the following errors:
@@ -597,6 +626,90 @@ func (c *Client) RollbackSinkVersion(ctx context.Context, p *RollbackSinkVersion
 	return ires.(*Task), nil
 }
 
+// ListSinkDeadLetters calls the "ListSinkDeadLetters" endpoint of the "stream"
+// service.
+// ListSinkDeadLetters may return the following errors:
+//   - "stream.api.sourceNotFound" (type *GenericError): Source not found error.
+//   - "stream.api.sinkNotFound" (type *GenericError): Sink not found error.
+//   - error: internal error
+func (c *Client) ListSinkDeadLetters(ctx context.Context, p *ListSinkDeadLettersPayload) (res *SinkDeadLetterRecordsList, err error) {
+	var ires any
+	ires, err = c.ListSinkDeadLettersEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*SinkDeadLetterRecordsList), nil
+}
+
+// GetSinkDeadLetter calls the "GetSinkDeadLetter" endpoint of the "stream"
+// service.
+// GetSinkDeadLetter may return the following errors:
+//   - "stream.api.sourceNotFound" (type *GenericError): Source not found error.
+//   - "stream.api.sinkNotFound" (type *GenericError): Sink not found error.
+//   - "stream.api.recordNotFound" (type *GenericError): Dead-letter record not found error.
+//   - error: internal error
+func (c *Client) GetSinkDeadLetter(ctx context.Context, p *GetSinkDeadLetterPayload) (res *SinkDeadLetterRecord, err error) {
+	var ires any
+	ires, err = c.GetSinkDeadLetterEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*SinkDeadLetterRecord), nil
+}
+
+// ReplaySinkDeadLetter calls the "ReplaySinkDeadLetter" endpoint of the
+// "stream" service.
+// ReplaySinkDeadLetter may return the following errors:
+//   - "stream.api.sourceNotFound" (type *GenericError): Source not found error.
+//   - "stream.api.sinkNotFound" (type *GenericError): Sink not found error.
+//   - "stream.api.recordNotFound" (type *GenericError): Dead-letter record not found error.
+//   - "stream.api.invalidColumnValue" (type *GenericError): Invalid data for sink.
+//   - error: internal error
+func (c *Client) ReplaySinkDeadLetter(ctx context.Context, p *ReplaySinkDeadLetterPayload) (err error) {
+	_, err = c.ReplaySinkDeadLetterEndpoint(ctx, p)
+	return
+}
+
+// PurgeSinkDeadLetters calls the "PurgeSinkDeadLetters" endpoint of the
+// "stream" service.
+// PurgeSinkDeadLetters may return the following errors:
+//   - "stream.api.sourceNotFound" (type *GenericError): Source not found error.
+//   - "stream.api.sinkNotFound" (type *GenericError): Sink not found error.
+//   - error: internal error
+func (c *Client) PurgeSinkDeadLetters(ctx context.Context, p *PurgeSinkDeadLettersPayload) (err error) {
+	_, err = c.PurgeSinkDeadLettersEndpoint(ctx, p)
+	return
+}
+
+// PreviewSinkData calls the "PreviewSinkData" endpoint of the "stream" service.
+// PreviewSinkData may return the following errors:
+//   - "stream.api.sourceNotFound" (type *GenericError): Source not found error.
+//   - "stream.api.sinkNotFound" (type *GenericError): Sink not found error.
+//   - error: internal error
+func (c *Client) PreviewSinkData(ctx context.Context, p *PreviewSinkDataPayload) (res *SinkDataPreview, err error) {
+	var ires any
+	ires, err = c.PreviewSinkDataEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*SinkDataPreview), nil
+}
+
+// InferSinkMapping calls the "InferSinkMapping" endpoint of the "stream"
+// service.
+// InferSinkMapping may return the following errors:
+//   - "stream.api.sourceNotFound" (type *GenericError): Source not found error.
+//   - "stream.api.unprocessableContent" (type *GenericError): No samples to infer the mapping.
+//   - error: internal error
+func (c *Client) InferSinkMapping(ctx context.Context, p *InferSinkMappingPayload) (res *InferredSinkMapping, err error) {
+	var ires any
+	ires, err = c.InferSinkMappingEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*InferredSinkMapping), nil
+}
+
 // GetTask calls the "GetTask" endpoint of the "stream" service.
 // GetTask may return the following errors:
 //   - "stream.api.taskNotFound" (type *GenericError): Task not found error.
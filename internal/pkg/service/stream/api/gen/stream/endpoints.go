@@ -53,9 +53,16 @@ type Endpoints struct {
 	DisableSink           goa.Endpoint
 	EnableSink            goa.Endpoint
 	UndeleteSink          goa.Endpoint
+	ReimportSinkFiles     goa.Endpoint
 	ListSinkVersions      goa.Endpoint
 	SinkVersionDetail     goa.Endpoint
 	RollbackSinkVersion   goa.Endpoint
+	ListSinkDeadLetters   goa.Endpoint
+	GetSinkDeadLetter     goa.Endpoint
+	ReplaySinkDeadLetter  goa.Endpoint
+	PurgeSinkDeadLetters  goa.Endpoint
+	PreviewSinkData       goa.Endpoint
+	InferSinkMapping      goa.Endpoint
 	GetTask               goa.Endpoint
 	AggregationSources    goa.Endpoint
 }
@@ -108,9 +115,16 @@ func NewEndpoints(s Service) *Endpoints {
 		DisableSink:           NewDisableSinkEndpoint(s, a.APIKeyAuth),
 		EnableSink:            NewEnableSinkEndpoint(s, a.APIKeyAuth),
 		UndeleteSink:          NewUndeleteSinkEndpoint(s, a.APIKeyAuth),
+		ReimportSinkFiles:     NewReimportSinkFilesEndpoint(s, a.APIKeyAuth),
 		ListSinkVersions:      NewListSinkVersionsEndpoint(s, a.APIKeyAuth),
 		SinkVersionDetail:     NewSinkVersionDetailEndpoint(s, a.APIKeyAuth),
 		RollbackSinkVersion:   NewRollbackSinkVersionEndpoint(s, a.APIKeyAuth),
+		ListSinkDeadLetters:   NewListSinkDeadLettersEndpoint(s, a.APIKeyAuth),
+		GetSinkDeadLetter:     NewGetSinkDeadLetterEndpoint(s, a.APIKeyAuth),
+		ReplaySinkDeadLetter:  NewReplaySinkDeadLetterEndpoint(s, a.APIKeyAuth),
+		PurgeSinkDeadLetters:  NewPurgeSinkDeadLettersEndpoint(s, a.APIKeyAuth),
+		PreviewSinkData:       NewPreviewSinkDataEndpoint(s, a.APIKeyAuth),
+		InferSinkMapping:      NewInferSinkMappingEndpoint(s, a.APIKeyAuth),
 		GetTask:               NewGetTaskEndpoint(s, a.APIKeyAuth),
 		AggregationSources:    NewAggregationSourcesEndpoint(s, a.APIKeyAuth),
 	}
@@ -152,9 +166,16 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.DisableSink = m(e.DisableSink)
 	e.EnableSink = m(e.EnableSink)
 	e.UndeleteSink = m(e.UndeleteSink)
+	e.ReimportSinkFiles = m(e.ReimportSinkFiles)
 	e.ListSinkVersions = m(e.ListSinkVersions)
 	e.SinkVersionDetail = m(e.SinkVersionDetail)
 	e.RollbackSinkVersion = m(e.RollbackSinkVersion)
+	e.ListSinkDeadLetters = m(e.ListSinkDeadLetters)
+	e.GetSinkDeadLetter = m(e.GetSinkDeadLetter)
+	e.ReplaySinkDeadLetter = m(e.ReplaySinkDeadLetter)
+	e.PurgeSinkDeadLetters = m(e.PurgeSinkDeadLetters)
+	e.PreviewSinkData = m(e.PreviewSinkData)
+	e.InferSinkMapping = m(e.InferSinkMapping)
 	e.GetTask = m(e.GetTask)
 	e.AggregationSources = m(e.AggregationSources)
 }
@@ -806,6 +827,26 @@ func NewUndeleteSinkEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) go
 	}
 }
 
+// NewReimportSinkFilesEndpoint returns an endpoint function that calls the
+// method "ReimportSinkFiles" of service "stream".
+func NewReimportSinkFilesEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ReimportSinkFilesPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.SinkRequestScopeCtxKey).(dependencies.SinkRequestScope)
+		return s.ReimportSinkFiles(ctx, deps, p)
+	}
+}
+
 // NewListSinkVersionsEndpoint returns an endpoint function that calls the
 // method "ListSinkVersions" of service "stream".
 func NewListSinkVersionsEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
@@ -866,6 +907,126 @@ func NewRollbackSinkVersionEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyF
 	}
 }
 
+// NewListSinkDeadLettersEndpoint returns an endpoint function that calls the
+// method "ListSinkDeadLetters" of service "stream".
+func NewListSinkDeadLettersEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ListSinkDeadLettersPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.SinkRequestScopeCtxKey).(dependencies.SinkRequestScope)
+		return s.ListSinkDeadLetters(ctx, deps, p)
+	}
+}
+
+// NewGetSinkDeadLetterEndpoint returns an endpoint function that calls the
+// method "GetSinkDeadLetter" of service "stream".
+func NewGetSinkDeadLetterEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*GetSinkDeadLetterPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.SinkRequestScopeCtxKey).(dependencies.SinkRequestScope)
+		return s.GetSinkDeadLetter(ctx, deps, p)
+	}
+}
+
+// NewReplaySinkDeadLetterEndpoint returns an endpoint function that calls the
+// method "ReplaySinkDeadLetter" of service "stream".
+func NewReplaySinkDeadLetterEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*ReplaySinkDeadLetterPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.SinkRequestScopeCtxKey).(dependencies.SinkRequestScope)
+		return nil, s.ReplaySinkDeadLetter(ctx, deps, p)
+	}
+}
+
+// NewPurgeSinkDeadLettersEndpoint returns an endpoint function that calls the
+// method "PurgeSinkDeadLetters" of service "stream".
+func NewPurgeSinkDeadLettersEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PurgeSinkDeadLettersPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.SinkRequestScopeCtxKey).(dependencies.SinkRequestScope)
+		return nil, s.PurgeSinkDeadLetters(ctx, deps, p)
+	}
+}
+
+// NewPreviewSinkDataEndpoint returns an endpoint function that calls the
+// method "PreviewSinkData" of service "stream".
+func NewPreviewSinkDataEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*PreviewSinkDataPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.SinkRequestScopeCtxKey).(dependencies.SinkRequestScope)
+		return s.PreviewSinkData(ctx, deps, p)
+	}
+}
+
+// NewInferSinkMappingEndpoint returns an endpoint function that calls the
+// method "InferSinkMapping" of service "stream".
+func NewInferSinkMappingEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
+	return func(ctx context.Context, req any) (any, error) {
+		p := req.(*InferSinkMappingPayload)
+		var err error
+		sc := security.APIKeyScheme{
+			Name:           "storage-api-token",
+			Scopes:         []string{},
+			RequiredScopes: []string{},
+		}
+		ctx, err = authAPIKeyFn(ctx, p.StorageAPIToken, &sc)
+		if err != nil {
+			return nil, err
+		}
+		deps := ctx.Value(dependencies.SourceRequestScopeCtxKey).(dependencies.SourceRequestScope)
+		return s.InferSinkMapping(ctx, deps, p)
+	}
+}
+
 // NewGetTaskEndpoint returns an endpoint function that calls the method
 // "GetTask" of service "stream".
 func NewGetTaskEndpoint(s Service, authAPIKeyFn security.AuthAPIKeyFunc) goa.Endpoint {
@@ -17,6 +17,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	dependencies "github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
 	"goa.design/goa/v3/security"
@@ -130,12 +131,56 @@ type Service interface {
 	EnableSink(context.Context, dependencies.SinkRequestScope, *EnableSinkPayload) (res *Task, err error)
 	// Undelete the sink.
 	UndeleteSink(context.Context, dependencies.SinkRequestScope, *UndeleteSinkPayload) (res *Task, err error)
+	// Imports already imported files of the sink again, for example after the
+	// target table has been truncated. Only files opened in the time range are
+	// re-imported, their staging files must be retained, see the
+	// "storage.metadataCleanup.importedFileRetention" configuration of the
+	// service. Files opened before the retention has been enabled are skipped,
+	// their staging files expire in the Keboola Storage on its own schedule.
+	// Optionally, the files can be re-imported to a different table, but only in
+	// the same bucket: the staging file is imported using the token of the sink,
+	// which is scoped to the bucket of the sink table.
+	ReimportSinkFiles(context.Context, dependencies.SinkRequestScope, *ReimportSinkFilesPayload) (res *Task, err error)
 	// List all sink versions.
 	ListSinkVersions(context.Context, dependencies.SinkRequestScope, *ListSinkVersionsPayload) (res *EntityVersions, err error)
 	// Sink version detail.
 	SinkVersionDetail(context.Context, dependencies.SinkRequestScope, *SinkVersionDetailPayload) (res *Version, err error)
 	// Rollback sink version.
 	RollbackSinkVersion(context.Context, dependencies.SinkRequestScope, *RollbackSinkVersionPayload) (res *Task, err error)
+	// List records which could not be mapped by the sink, from the oldest. The
+	// dead-letter storage must be enabled by the "deadLetter" field of the sink.
+	ListSinkDeadLetters(context.Context, dependencies.SinkRequestScope, *ListSinkDeadLettersPayload) (res *SinkDeadLetterRecordsList, err error)
+	// Get the record which could not be mapped by the sink, including the raw body.
+	GetSinkDeadLetter(context.Context, dependencies.SinkRequestScope, *GetSinkDeadLetterPayload) (res *SinkDeadLetterRecord, err error)
+	// Write the record to the sink again, after the mapping has been fixed. The
+	// record is mapped by the current mapping first, the replay is rejected, if it
+	// still fails. The record is deleted after a successful write, otherwise its
+	// error is updated.
+	ReplaySinkDeadLetter(context.Context, dependencies.SinkRequestScope, *ReplaySinkDeadLetterPayload) (err error)
+	// Delete all dead-letter records of the sink.
+	PurgeSinkDeadLetters(context.Context, dependencies.SinkRequestScope, *PurgeSinkDeadLettersPayload) (err error)
+	// Reads the last records written to the local storage of the sink, mapped to
+	// the table columns. The records are read from the currently open slices on
+	// disk writer nodes, so the mapping output can be checked before the import.
+	// The order of records is kept only within one source node.
+
+	// Only an admin token can read the records, the same as dead-letter records,
+	// because they may contain personal data. Values are returned as they are
+	// written to the table: the local storage is not encrypted, so values
+	// encrypted by the client are returned encrypted, and other values are
+	// returned in plain text.
+	PreviewSinkData(context.Context, dependencies.SinkRequestScope, *PreviewSinkDataPayload) (res *SinkDataPreview, err error)
+	// Proposes a table mapping from sample bodies, the result can be used as the
+	// "mapping" of the CreateSink payload.
+
+	// If no samples are sent, the last requests received by the source are used,
+	// the sampling of requests must be enabled by the "source.sample.size"
+	// configuration, it is disabled by default. The proposal contains an "id"
+	// column of the "uuid" type, intended as the primary key, a "datetime" column,
+	// and a "template" column with the `Body("path", "")` expression for each
+	// value found in the samples. Nested objects are flattened up to the
+	// "maxDepth", deeper objects and arrays are mapped to one JSON column.
+	InferSinkMapping(context.Context, dependencies.SourceRequestScope, *InferSinkMappingPayload) (res *InferredSinkMapping, err error)
 	// Get details of a task.
 	GetTask(context.Context, dependencies.ProjectRequestScope, *GetTaskPayload) (res *Task, err error)
 	// Details about sources for the UI.
@@ -162,7 +207,7 @@ const ServiceName = "stream"
 // MethodNames lists the service method names as defined in the design. These
 // are the same values that are set in the endpoint request contexts under the
 // MethodKey key.
-var MethodNames = [39]string{"ApiRootIndex", "ApiVersionIndex", "HealthCheck", "CreateSource", "UpdateSource", "ListSources", "ListDeletedSources", "GetSource", "DeleteSource", "GetSourceSettings", "UpdateSourceSettings", "TestSource", "SourceStatisticsClear", "DisableSource", "EnableSource", "RotateSourceSecret", "UndeleteSource", "ListSourceVersions", "SourceVersionDetail", "RollbackSourceVersion", "CreateSink", "GetSink", "GetSinkSettings", "UpdateSinkSettings", "ListSinks", "ListDeletedSinks", "UpdateSink", "DeleteSink", "SinkStatisticsTotal", "SinkStatisticsFiles", "SinkStatisticsClear", "DisableSink", "EnableSink", "UndeleteSink", "ListSinkVersions", "SinkVersionDetail", "RollbackSinkVersion", "GetTask", "AggregationSources"}
+var MethodNames = [46]string{"ApiRootIndex", "ApiVersionIndex", "HealthCheck", "CreateSource", "UpdateSource", "ListSources", "ListDeletedSources", "GetSource", "DeleteSource", "GetSourceSettings", "UpdateSourceSettings", "TestSource", "SourceStatisticsClear", "DisableSource", "EnableSource", "RotateSourceSecret", "UndeleteSource", "ListSourceVersions", "SourceVersionDetail", "RollbackSourceVersion", "CreateSink", "GetSink", "GetSinkSettings", "UpdateSinkSettings", "ListSinks", "ListDeletedSinks", "UpdateSink", "DeleteSink", "SinkStatisticsTotal", "SinkStatisticsFiles", "SinkStatisticsClear", "DisableSink", "EnableSink", "UndeleteSink", "ReimportSinkFiles", "ListSinkVersions", "SinkVersionDetail", "RollbackSinkVersion", "ListSinkDeadLetters", "GetSinkDeadLetter", "ReplaySinkDeadLetter", "PurgeSinkDeadLetters", "PreviewSinkData", "InferSinkMapping", "GetTask", "AggregationSources"}
 
 // A mapping from imported data to a destination table.
 type AggregatedSink struct {
@@ -178,12 +223,23 @@ type AggregatedSink struct {
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
 	AllowedSignals []OTLPSignal
-	Table          *TableSink
-	Version        *Version
-	Created        *CreatedEntity
-	Deleted        *DeletedEntity
-	Disabled       *DisabledEntity
-	Statistics     *AggregatedStatistics
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool
+	Table      *TableSink
+	Webhook    *WebhookSink
+	File       *FileSink
+	Version    *Version
+	Created    *CreatedEntity
+	Deleted    *DeletedEntity
+	Disabled   *DisabledEntity
+	Statistics *AggregatedStatistics
 }
 
 type AggregatedSinks []*AggregatedSink
@@ -202,7 +258,9 @@ type AggregatedSource struct {
 	// HTTP source details for "type" = "http".
 	HTTP *HTTPSource
 	// OTLP source details for "type" = "otlp".
-	Otlp     *OTLPSource
+	Otlp *OTLPSource
+	// Kafka source details for "type" = "kafka".
+	Kafka    *KafkaSource
 	Version  *Version
 	Created  *CreatedEntity
 	Deleted  *DeletedEntity
@@ -275,7 +333,18 @@ type CreateSinkPayload struct {
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
 	AllowedSignals []OTLPSignal
-	Table          *TableSinkCreate
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool
+	Table      *TableSinkCreate
+	Webhook    *WebhookSinkCreate
+	File       *FileSinkCreate
 }
 
 // CreateSourcePayload is the payload type of the stream service CreateSource
@@ -291,6 +360,10 @@ type CreateSourcePayload struct {
 	Name string
 	// Description of the source.
 	Description *string
+	// Kafka source details for "type" = "kafka".
+	Kafka *KafkaSource
+	// Optional webhook signature verification for "type" = "http".
+	HTTPSignature *HTTPSourceSignature
 }
 
 // Information about the entity creation.
@@ -380,6 +453,33 @@ type EntityVersions struct {
 	Page     *PaginatedResponse
 }
 
+// File sink configuration for "type" = "file". Records are stored to files in
+// a bucket of the staging provider, independent of Keboola Storage. The file
+// format is configured by the "storage.level.local.encoding.encoder.type"
+// setting of the sink, for example "csv", "ndjson" or "parquet".
+type FileSink struct {
+	Provider StagingProvider
+	Mapping  *TableMapping
+}
+
+// File sink configuration for "type" = "file". Records are stored to files in
+// a bucket of the staging provider, independent of Keboola Storage. The file
+// format is configured by the "storage.level.local.encoding.encoder.type"
+// setting of the sink, for example "csv", "ndjson" or "parquet".
+type FileSinkCreate struct {
+	Provider StagingProvider
+	Mapping  *TableMapping
+}
+
+// File sink configuration for "type" = "file". Records are stored to files in
+// a bucket of the staging provider, independent of Keboola Storage. The file
+// format is configured by the "storage.level.local.encoding.encoder.type"
+// setting of the sink, for example "csv", "ndjson" or "parquet".
+type FileSinkUpdate struct {
+	Provider *StagingProvider
+	Mapping  *TableMapping
+}
+
 type FileState = model.FileState
 
 // Generic error.
@@ -392,6 +492,16 @@ type GenericError struct {
 	Message string
 }
 
+// GetSinkDeadLetterPayload is the payload type of the stream service
+// GetSinkDeadLetter method.
+type GetSinkDeadLetterPayload struct {
+	StorageAPIToken string
+	BranchID        BranchIDOrDefault
+	SourceID        SourceID
+	SinkID          SinkID
+	RecordID        SinkDeadLetterRecordID
+}
+
 // GetSinkPayload is the payload type of the stream service GetSink method.
 type GetSinkPayload struct {
 	StorageAPIToken string
@@ -434,6 +544,69 @@ type GetTaskPayload struct {
 type HTTPSource struct {
 	// URL of the HTTP source. Contains secret used for authentication.
 	URL string
+	// Webhook signature verification, if it is configured. The signature secret is
+	// not returned.
+	Signature *HTTPSourceSignature
+}
+
+// Webhook signature verification. A request with a missing or invalid HMAC
+// signature is rejected.
+type HTTPSourceSignature struct {
+	// HMAC algorithm.
+	Algorithm string
+	// Format of the signature header and the signed payload. "hex" - hex encoded
+	// signature of the body with an optional "<algorithm>=" prefix, for example
+	// GitHub. "stripe" - "t=<timestamp>,v1=<signature>", the signed payload is
+	// "<timestamp>.<body>". "slack" - "v0=<signature>", the signed payload is
+	// "v0:<timestamp>:<body>", the timestamp is read from the
+	// "X-Slack-Request-Timestamp" header.
+	Format string
+	// Name of the header with the signature.
+	Header string
+	// Secret key of the HMAC. Required when the verification is configured for the
+	// first time, it is never returned.
+	Secret *string
+	// Maximum difference between the signed timestamp and the current time, 0
+	// means no limit. Not supported by the "hex" format.
+	TimestampToleranceSeconds *int
+	// A request with an already received signature is rejected during the window,
+	// 0 disables the replay protection.
+	ReplayWindowSeconds *int
+}
+
+// InferSinkMappingPayload is the payload type of the stream service
+// InferSinkMapping method.
+type InferSinkMappingPayload struct {
+	StorageAPIToken string
+	BranchID        BranchIDOrDefault
+	SourceID        SourceID
+	// Sample JSON objects. If empty, the last requests received by the source are
+	// used, if the sampling is enabled.
+	Samples []any
+	// Nested objects are flattened up to the depth, 1 means only top-level keys.
+	MaxDepth int
+}
+
+// InferredSinkMapping is the result type of the stream service
+// InferSinkMapping method.
+type InferredSinkMapping struct {
+	ProjectID ProjectID
+	BranchID  BranchID
+	SourceID  SourceID
+	// Number of samples used for the proposal.
+	SamplesCount int
+	Mapping      *TableMapping
+}
+
+// Kafka source details for "type" = "kafka". Records are consumed from a topic
+// of a Kafka-protocol compatible broker.
+type KafkaSource struct {
+	// Addresses of the seed brokers, in the host:port format, 1-20 items.
+	Brokers []string
+	// Topic to consume.
+	Topic string
+	// Consumer group, the offsets of processed records are committed to.
+	GroupID string
 }
 
 type Level struct {
@@ -477,6 +650,19 @@ type ListDeletedSourcesPayload struct {
 	Limit int
 }
 
+// ListSinkDeadLettersPayload is the payload type of the stream service
+// ListSinkDeadLetters method.
+type ListSinkDeadLettersPayload struct {
+	StorageAPIToken string
+	BranchID        BranchIDOrDefault
+	SourceID        SourceID
+	SinkID          SinkID
+	// Request records after the ID.
+	AfterID string
+	// Maximum number of returned records.
+	Limit int
+}
+
 // ListSinkVersionsPayload is the payload type of the stream service
 // ListSinkVersions method.
 type ListSinkVersionsPayload struct {
@@ -556,9 +742,57 @@ type PaginatedResponse struct {
 	LastID string
 }
 
+// PreviewSinkDataPayload is the payload type of the stream service
+// PreviewSinkData method.
+type PreviewSinkDataPayload struct {
+	StorageAPIToken string
+	BranchID        BranchIDOrDefault
+	SourceID        SourceID
+	SinkID          SinkID
+	// Maximum number of returned records.
+	Limit int
+}
+
 // ID of the project.
 type ProjectID = keboola.ProjectID
 
+// PurgeSinkDeadLettersPayload is the payload type of the stream service
+// PurgeSinkDeadLetters method.
+type PurgeSinkDeadLettersPayload struct {
+	StorageAPIToken string
+	BranchID        BranchIDOrDefault
+	SourceID        SourceID
+	SinkID          SinkID
+}
+
+// ReimportSinkFilesPayload is the payload type of the stream service
+// ReimportSinkFiles method.
+type ReimportSinkFilesPayload struct {
+	StorageAPIToken string
+	BranchID        BranchIDOrDefault
+	SourceID        SourceID
+	SinkID          SinkID
+	// Files opened at or after the time are re-imported.
+	Since string
+	// Files opened before the time are re-imported. To re-import one file, use its
+	// "openedAt" as "since" and a millisecond later as "until".
+	Until string
+	// Optional table to re-import the files to. It must be in the same bucket as
+	// the table of the file, because the sink token is scoped to the bucket. By
+	// default, each file is re-imported to its original table.
+	TableID *TableID
+}
+
+// ReplaySinkDeadLetterPayload is the payload type of the stream service
+// ReplaySinkDeadLetter method.
+type ReplaySinkDeadLetterPayload struct {
+	StorageAPIToken string
+	BranchID        BranchIDOrDefault
+	SourceID        SourceID
+	SinkID          SinkID
+	RecordID        SinkDeadLetterRecordID
+}
+
 // RollbackSinkVersionPayload is the payload type of the stream service
 // RollbackSinkVersion method.
 type RollbackSinkVersionPayload struct {
@@ -647,11 +881,87 @@ type Sink struct {
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
 	AllowedSignals []OTLPSignal
-	Table          *TableSink
-	Version        *Version
-	Created        *CreatedEntity
-	Deleted        *DeletedEntity
-	Disabled       *DisabledEntity
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool
+	Table      *TableSink
+	Webhook    *WebhookSink
+	File       *FileSink
+	Version    *Version
+	Created    *CreatedEntity
+	Deleted    *DeletedEntity
+	Disabled   *DisabledEntity
+}
+
+// SinkDataPreview is the result type of the stream service PreviewSinkData
+// method.
+type SinkDataPreview struct {
+	ProjectID ProjectID
+	BranchID  BranchID
+	SourceID  SourceID
+	SinkID    SinkID
+	// Mapped rows, from the oldest.
+	Rows []*SinkDataPreviewRow
+}
+
+// Mapped table column value, part of the sink data preview.
+type SinkDataPreviewColumn struct {
+	// Column name.
+	Name string
+	// Column value.
+	Value string
+}
+
+// Mapped table row, part of the sink data preview.
+type SinkDataPreviewRow struct {
+	// Mapped columns.
+	Columns []*SinkDataPreviewColumn
+}
+
+// SinkDeadLetterRecord is the result type of the stream service
+// GetSinkDeadLetter method.
+type SinkDeadLetterRecord struct {
+	ProjectID ProjectID
+	BranchID  BranchID
+	SourceID  SourceID
+	SinkID    SinkID
+	RecordID  SinkDeadLetterRecordID
+	// Date and time of the record receipt, it is kept on the replay.
+	ReceivedAt string
+	// Date and time of the last failure.
+	FailedAt string
+	// Error of the last failure.
+	Error string
+	// IP address of the client.
+	ClientIP *string
+	// Headers of the record.
+	Headers map[string]string
+	// Raw body of the record, it is not part of the list response.
+	Body *string
+	// True if the body has been truncated, such record cannot be replayed.
+	BodyTruncated bool
+	// True if the replay of the record is in progress.
+	ReplayRequested bool
+}
+
+// Unique ID of the dead-letter record.
+type SinkDeadLetterRecordID = deadletter.RecordID
+
+// SinkDeadLetterRecordsList is the result type of the stream service
+// ListSinkDeadLetters method.
+type SinkDeadLetterRecordsList struct {
+	ProjectID ProjectID
+	BranchID  BranchID
+	SourceID  SourceID
+	SinkID    SinkID
+	Page      *PaginatedResponse
+	Records   []*SinkDeadLetterRecord
 }
 
 type SinkFile struct {
@@ -666,6 +976,8 @@ type SinkFile struct {
 	RetryReason *string
 	// Next attempt time.
 	RetryAfter *string
+	// Partition ID of the file. Only for a table sink with partitioning.
+	Partition  *string
 	Statistics *SinkFileStatistics
 }
 
@@ -680,6 +992,18 @@ type SinkFiles []*SinkFile
 // Unique ID of the sink.
 type SinkID = key.SinkID
 
+type SinkPartition struct {
+	// Partition ID, it is used as the table name suffix.
+	Partition string
+	// Number of recent files of the partition.
+	FilesCount int
+	Statistics *SinkFileStatistics
+}
+
+// Statistics of recent sink files grouped by the table partition. Only for a
+// table sink with partitioning.
+type SinkPartitions []*SinkPartition
+
 // SinkStatisticsClearPayload is the payload type of the stream service
 // SinkStatisticsClear method.
 type SinkStatisticsClearPayload struct {
@@ -704,7 +1028,8 @@ type SinkStatisticsFilesPayload struct {
 // SinkStatisticsFilesResult is the result type of the stream service
 // SinkStatisticsFiles method.
 type SinkStatisticsFilesResult struct {
-	Files SinkFiles
+	Files      SinkFiles
+	Partitions SinkPartitions
 }
 
 // SinkStatisticsTotalPayload is the payload type of the stream service
@@ -721,6 +1046,10 @@ type SinkStatisticsTotalPayload struct {
 type SinkStatisticsTotalResult struct {
 	Total  *Level
 	Levels *Levels
+	// Records dropped by the sink filter.
+	Dropped *Level
+	// Duplicate records skipped by the sink deduplication window.
+	Deduplicated *Level
 }
 
 type SinkType = definition.SinkType
@@ -761,7 +1090,9 @@ type Source struct {
 	// HTTP source details for "type" = "http".
 	HTTP *HTTPSource
 	// OTLP source details for "type" = "otlp".
-	Otlp     *OTLPSource
+	Otlp *OTLPSource
+	// Kafka source details for "type" = "kafka".
+	Kafka    *KafkaSource
 	Version  *Version
 	Created  *CreatedEntity
 	Deleted  *DeletedEntity
@@ -802,6 +1133,10 @@ type SourcesList struct {
 	Sources   Sources
 }
 
+// Staging provider of the files, it must be enabled in the configuration of
+// the service.
+type StagingProvider = definition.StagingProvider
+
 // An output mapping defined by a template.
 type TableColumn struct {
 	// Column mapping type. This represents a static mapping (e.g. `body` or
@@ -818,6 +1153,24 @@ type TableColumn struct {
 	RawString *bool
 	// Template mapping details. Only for "type" = "template".
 	Template *TableColumnTemplate
+	// Optional target data type of the value. Only for "type" = "body", "path" or
+	// "template".
+	Cast *TableColumnCast
+}
+
+// Target data type of the column value, the value is validated and normalized
+// to the type. A null or an empty value is always kept as null. If the column
+// is a part of a Keboola table, the table is created with the matching column
+// type.
+type TableColumnCast struct {
+	// Target data type. The "timestamp" accepts an ISO 8601 string or a Unix
+	// timestamp in seconds.
+	DataType column.DataType
+	// Policy if the value cannot be cast: "fail" rejects the record, "null" uses
+	// null, "default" uses the "defaultValue".
+	OnError column.CastErrorPolicy
+	// Value used by the "default" policy, it must be valid for the "dataType".
+	DefaultValue *string
 }
 
 // Template column definition, for "type" = "template".
@@ -836,25 +1189,69 @@ type TableMapping struct {
 	Columns TableColumns
 }
 
-// Table sink configuration for "type" = "table".
-type TableSink struct {
+// Optional secondary destination table. Each record accepted by the sink is
+// written also to the mirror table, for example to a raw archive table in
+// another bucket. Files of the mirror are rotated and imported independently
+// of the sink files. The sink filter is evaluated once, values of columns
+// present in both mappings are rendered once. A failed write to the mirror
+// fails the record, unless the record is stored to the dead-letter storage of
+// the sink. The mirror result is reported in the "mirror" field of the sink
+// result, in the verbose mode of the source.
+type TableMirror struct {
 	Type    TableType
 	TableID TableID
+	// Mapping of the mirror table. The mapping of the sink is used, if it is not
+	// set.
 	Mapping *TableMapping
 }
 
+type TablePartitionGranularity = definition.PartitionGranularity
+
+// Optional time-based partitioning. Records are written to partition tables,
+// the partition ID is appended to the table name, for example
+// "in.c-bucket.table_20220428". A partition table is created with the same
+// mapping, when the first file of the partition is opened. Each record is
+// routed by its column value. Records of the next partition are rejected with
+// a retryable error until the file of the partition is opened at the partition
+// start. Records of an already closed partition, for example late events,
+// cannot be written and are stored to the dead-letter storage, if enabled.
+type TablePartitioning struct {
+	Granularity TablePartitionGranularity
+	// Name of a "datetime" or "template" column of the mapping. The "datetime"
+	// column contains the time the record was received. The "template" column must
+	// return a time in the RFC 3339 format, for example an event time from the
+	// body. The partition is determined in UTC.
+	Column string
+}
+
+// Table sink configuration for "type" = "table".
+type TableSink struct {
+	Type         TableType
+	TableID      TableID
+	Mapping      *TableMapping
+	Partitioning *TablePartitioning
+	Mirror       *TableMirror
+}
+
 // Table sink configuration for "type" = "table".
 type TableSinkCreate struct {
-	Type    TableType
-	TableID TableID
-	Mapping *TableMapping
+	Type         TableType
+	TableID      TableID
+	Mapping      *TableMapping
+	Partitioning *TablePartitioning
+	Mirror       *TableMirror
 }
 
 // Table sink configuration for "type" = "table".
 type TableSinkUpdate struct {
-	Type    *TableType
-	TableID *TableID
-	Mapping *TableMapping
+	Type         *TableType
+	TableID      *TableID
+	Mapping      *TableMapping
+	Partitioning *TablePartitioning
+	Mirror       *TableMirror
+	// Set to true to remove the mirror table. Already accepted records are still
+	// imported to the mirror table.
+	RemoveMirror *bool
 }
 
 type TableType = definition.TableType
@@ -903,8 +1300,13 @@ type TestResult struct {
 	ProjectID ProjectID
 	BranchID  BranchID
 	SourceID  SourceID
-	// Table for each configured sink.
+	// Table for each configured sink which accepts the record.
 	Tables []*TestResultTable
+	// Result of the filter expression for each configured sink.
+	Sinks []*TestResultSink
+	// Result of the webhook signature verification, present only if the signature
+	// verification is configured.
+	Signature *TestResultSignature
 }
 
 // Generated table column value, part of the test result.
@@ -921,6 +1323,22 @@ type TestResultRow struct {
 	Columns []*TestResultColumn
 }
 
+// Result of the webhook signature verification, part of the test result.
+type TestResultSignature struct {
+	// True if the signature is valid. The replay protection is not applied by the
+	// test endpoint.
+	Valid bool
+	// Reason why the signature is invalid.
+	Message *string
+}
+
+// Result of the sink filter expression, part of the test result.
+type TestResultSink struct {
+	SinkID SinkID
+	// False if the record would be dropped by the sink filter.
+	Accepted bool
+}
+
 // Generated table rows, part of the test result.
 type TestResultTable struct {
 	SinkID  SinkID
@@ -975,7 +1393,18 @@ type UpdateSinkPayload struct {
 	// Restricts the sink to specific OTLP signal types. Empty (default) accepts
 	// all signals. Only relevant for OTLP sources; HTTP sources ignore this field.
 	AllowedSignals []OTLPSignal
-	Table          *TableSinkUpdate
+	// Optional Jsonnet expression evaluated for each record, it must return a
+	// boolean. Records for which the expression returns false are dropped by the
+	// sink. The same functions as in the template column are available, for
+	// example Body(), Header() and Ip().
+	Filter *string
+	// Store records which cannot be mapped by the sink, for example if a path is
+	// not found in the body. Stored records can be inspected and replayed after
+	// the mapping has been fixed.
+	DeadLetter *bool
+	Table      *TableSinkUpdate
+	Webhook    *WebhookSinkUpdate
+	File       *FileSinkUpdate
 }
 
 // UpdateSinkSettingsPayload is the payload type of the stream service
@@ -1003,6 +1432,12 @@ type UpdateSourcePayload struct {
 	Name *string
 	// Description of the source.
 	Description *string
+	// Kafka source details for "type" = "kafka".
+	Kafka *KafkaSource
+	// Optional webhook signature verification for "type" = "http".
+	HTTPSignature *HTTPSourceSignature
+	// Disable the webhook signature verification.
+	RemoveHTTPSignature *bool
 }
 
 // UpdateSourceSettingsPayload is the payload type of the stream service
@@ -1030,6 +1465,38 @@ type Version struct {
 	By *By
 }
 
+// List of request headers, the column name is used as the header name, the
+// value is rendered from the record. A webhook may have a maximum of 20
+// headers.
+type WebhookHeaders []*TableColumn
+
+// Webhook sink configuration for "type" = "webhook".
+type WebhookSink struct {
+	// URL of the endpoint. Records are sent in batches by POST requests, the body
+	// is a JSON array of objects with the mapping columns.
+	URL     string
+	Headers WebhookHeaders
+	Mapping *TableMapping
+}
+
+// Webhook sink configuration for "type" = "webhook".
+type WebhookSinkCreate struct {
+	// URL of the endpoint. Records are sent in batches by POST requests, the body
+	// is a JSON array of objects with the mapping columns.
+	URL     string
+	Headers WebhookHeaders
+	Mapping *TableMapping
+}
+
+// Webhook sink configuration for "type" = "webhook".
+type WebhookSinkUpdate struct {
+	// URL of the endpoint. Records are sent in batches by POST requests, the body
+	// is a JSON array of objects with the mapping columns.
+	URL     *string
+	Headers WebhookHeaders
+	Mapping *TableMapping
+}
+
 // Error returns an error description.
 func (e *GenericError) Error() string {
 	return "Generic error."
@@ -55,7 +55,7 @@ func (m *Mapper) NewAggregationSource(entity definition.Source) (*api.Aggregated
 		if err != nil {
 			return nil, err
 		}
-		out.HTTP = &api.HTTPSource{URL: u}
+		out.HTTP = &api.HTTPSource{URL: u, Signature: newHTTPSourceSignatureResponse(entity.HTTP.Signature)}
 	case definition.SourceTypeOTLP:
 		publicURL := m.httpSourcePublicURL.String()
 		u, err := entity.FormatOTLPSourceURL(publicURL)
@@ -67,6 +67,8 @@ func (m *Mapper) NewAggregationSource(entity definition.Source) (*api.Aggregated
 			return nil, err
 		}
 		out.Otlp = &api.OTLPSource{URL: u, BaseURL: baseURL, Secret: entity.OTLP.Secret}
+	case definition.SourceTypeKafka:
+		out.Kafka = newKafkaSourceResponse(entity.Kafka)
 	default:
 		return nil, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, out.Type.String()))
 	}
@@ -89,6 +91,14 @@ func (m *Mapper) NewAggregationSinkResponse(entity repository.SinkWithStatistics
 		Disabled:       m.NewDisabledResponse(entity.Switchable),
 	}
 
+	if entity.Filter != "" {
+		out.Filter = new(entity.Filter)
+	}
+
+	if entity.DeadLetter {
+		out.DeadLetter = new(true)
+	}
+
 	if entity.Statistics.Total != nil {
 		totals := m.NewSinkStatisticsTotalResponse(*entity.Statistics.Total)
 		files := api.SinkFiles{}
@@ -118,6 +128,12 @@ func (m *Mapper) NewAggregationSinkResponse(entity repository.SinkWithStatistics
 			return nil, err
 		}
 		out.Table = &tableResponse
+	case definition.SinkTypeWebhook:
+		webhookResponse := m.newWebhookSinkResponse(entity.Webhook)
+		out.Webhook = &webhookResponse
+	case definition.SinkTypeFile:
+		fileResponse := m.newFileSinkResponse(entity.File)
+		out.File = &fileResponse
 	default:
 		return nil, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, out.Type.String()))
 	}
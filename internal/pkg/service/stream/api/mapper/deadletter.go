@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"context"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+)
+
+// NewSinkDeadLetterRecordResponse maps the dead-letter record, the body is included only in the detail.
+func (m *Mapper) NewSinkDeadLetterRecordResponse(record deadletter.Record, replayRequested bool, withBody bool) *api.SinkDeadLetterRecord {
+	out := &api.SinkDeadLetterRecord{
+		ProjectID:       record.ProjectID,
+		BranchID:        record.BranchID,
+		SourceID:        record.SourceID,
+		SinkID:          record.SinkID,
+		RecordID:        record.RecordID,
+		ReceivedAt:      record.ReceivedAt.String(),
+		FailedAt:        record.FailedAt.String(),
+		Error:           record.Error,
+		Headers:         record.Headers,
+		BodyTruncated:   record.BodyTruncated,
+		ReplayRequested: replayRequested,
+	}
+
+	if out.Headers == nil {
+		out.Headers = make(map[string]string)
+	}
+
+	if record.ClientIP != "" {
+		out.ClientIP = new(record.ClientIP)
+	}
+
+	if withBody {
+		out.Body = new(string(record.Body))
+	}
+
+	return out
+}
+
+func (m *Mapper) NewSinkDeadLetterRecordsResponse(
+	ctx context.Context,
+	k key.SinkKey,
+	afterId string,
+	limit int,
+	list func(...iterator.Option) iterator.DefinitionT[deadletter.Record],
+	replayRequested map[deadletter.RecordKey]bool,
+) (*api.SinkDeadLetterRecordsList, error) {
+	mapper := func(record deadletter.Record) (*api.SinkDeadLetterRecord, error) {
+		return m.NewSinkDeadLetterRecordResponse(record, replayRequested[record.RecordKey], false), nil
+	}
+
+	records, page, err := loadPage(ctx, afterId, limit, etcd.SortAscend, list, mapper)
+	if err != nil {
+		return nil, err
+	}
+
+	if records == nil {
+		records = []*api.SinkDeadLetterRecord{}
+	}
+
+	return &api.SinkDeadLetterRecordsList{
+		ProjectID: k.ProjectID,
+		BranchID:  k.BranchID,
+		SourceID:  k.SourceID,
+		SinkID:    k.SinkID,
+		Page:      page,
+		Records:   records,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	jsonnetWrapper "github.com/keboola/keboola-as-code/internal/pkg/encoding/jsonnet"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/filter"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/jsonnet"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
 )
@@ -15,6 +16,7 @@ type Mapper struct {
 	apiPublicURL        *url.URL
 	httpSourcePublicURL *url.URL
 	jsonnetPool         *jsonnetWrapper.VMPool[recordctx.Context]
+	filters             *filter.Evaluator
 }
 
 type dependencies interface {
@@ -28,5 +30,6 @@ func New(d dependencies, cfg config.Config) *Mapper {
 		apiPublicURL:        d.APIPublicURL(),
 		httpSourcePublicURL: d.HTTPSourcePublicURL(),
 		jsonnetPool:         jsonnet.NewPool(),
+		filters:             filter.NewEvaluator(),
 	}
 }
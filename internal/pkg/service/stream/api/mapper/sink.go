@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
@@ -27,6 +30,14 @@ func (m *Mapper) NewSinkStatisticsTotalResponse(result statistics.Aggregated) *s
 	return res
 }
 
+func (m *Mapper) NewSinkStatisticsDroppedResponse(value statistics.Value) *stream.Level {
+	return mapValueToLevel(value)
+}
+
+func (m *Mapper) NewSinkStatisticsDeduplicatedResponse(value statistics.Value) *stream.Level {
+	return mapValueToLevel(value)
+}
+
 func (m *Mapper) NewSinkFile(file model.File) *stream.SinkFile {
 	sinkFile := &stream.SinkFile{
 		State:       file.State,
@@ -36,6 +47,10 @@ func (m *Mapper) NewSinkFile(file model.File) *stream.SinkFile {
 		ImportedAt:  timeToStringPointer(file.ImportedAt),
 	}
 
+	if partition := file.TargetStorage.Partition; partition != nil {
+		sinkFile.Partition = new(partition.ID)
+	}
+
 	if file.RetryAttempt > 0 {
 		sinkFile.RetryAttempt = new(file.RetryAttempt)
 		sinkFile.RetryReason = new(file.RetryReason)
@@ -45,6 +60,46 @@ func (m *Mapper) NewSinkFile(file model.File) *stream.SinkFile {
 	return sinkFile
 }
 
+// NewSinkPartitions groups files statistics by the table partition, files without a partition are skipped.
+func (m *Mapper) NewSinkPartitions(files []model.File, stats map[model.FileID]*statistics.Aggregated) stream.SinkPartitions {
+	partitions := make(map[string]*stream.SinkPartition)
+	aggregated := make(map[string]*statistics.Aggregated)
+	for _, file := range files {
+		partition := file.TargetStorage.Partition
+		if partition == nil {
+			continue
+		}
+
+		if _, found := partitions[partition.ID]; !found {
+			partitions[partition.ID] = &stream.SinkPartition{Partition: partition.ID}
+			aggregated[partition.ID] = &statistics.Aggregated{}
+		}
+
+		partitions[partition.ID].FilesCount++
+		if fileStats, ok := stats[file.FileID]; ok {
+			aggregated[partition.ID].Add(model.LevelLocal, fileStats.Local)
+			aggregated[partition.ID].Add(model.LevelStaging, fileStats.Staging)
+			aggregated[partition.ID].Add(model.LevelTarget, fileStats.Target)
+		}
+	}
+
+	if len(partitions) == 0 {
+		return nil
+	}
+
+	out := make(stream.SinkPartitions, 0, len(partitions))
+	for id, partition := range partitions {
+		partition.Statistics = m.NewSinkFileStatistics(aggregated[id])
+		out = append(out, partition)
+	}
+
+	slices.SortStableFunc(out, func(a, b *stream.SinkPartition) int {
+		return strings.Compare(a.Partition, b.Partition)
+	})
+
+	return out
+}
+
 func (m *Mapper) NewSinkFileStatistics(result *statistics.Aggregated) *stream.SinkFileStatistics {
 	return &stream.SinkFileStatistics{
 		Total: mapValueToLevel(result.Total),
@@ -0,0 +1,124 @@
+package mapper_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+)
+
+func TestNewSinkEntity_Filter(t *testing.T) {
+	t.Parallel()
+	m := newTestMapper()
+	sourceKey := newSourceKey()
+
+	t.Run("valid_filter", func(t *testing.T) {
+		t.Parallel()
+		payload := &api.CreateSinkPayload{
+			Name:   "errors-sink",
+			Type:   definition.SinkTypeTable,
+			Filter: new(`Body("level") == "error"`),
+			Table:  newMinimalTablePayload(),
+		}
+		entity, err := m.NewSinkEntity(sourceKey, payload)
+		require.NoError(t, err)
+		assert.Equal(t, `Body("level") == "error"`, entity.Filter)
+	})
+
+	t.Run("invalid_filter", func(t *testing.T) {
+		t.Parallel()
+		payload := &api.CreateSinkPayload{
+			Name:   "errors-sink",
+			Type:   definition.SinkTypeTable,
+			Filter: new(`Body("level") ==`),
+			Table:  newMinimalTablePayload(),
+		}
+		_, err := m.NewSinkEntity(sourceKey, payload)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), `"filter" is invalid`)
+		}
+	})
+}
+
+func TestUpdateSinkEntity_Filter(t *testing.T) {
+	t.Parallel()
+	m := newTestMapper()
+
+	base := definition.Sink{
+		SinkKey: key.SinkKey{SourceKey: newSourceKey(), SinkID: "my-sink"},
+		Type:    definition.SinkTypeTable,
+		Name:    "My Sink",
+		Filter:  `Body("level") == "error"`,
+		Table:   newTableSink(),
+	}
+
+	t.Run("nil_means_no_change", func(t *testing.T) {
+		t.Parallel()
+		updated, err := m.UpdateSinkEntity(base, &api.UpdateSinkPayload{})
+		require.NoError(t, err)
+		assert.Equal(t, `Body("level") == "error"`, updated.Filter)
+	})
+
+	t.Run("empty_string_clears_filter", func(t *testing.T) {
+		t.Parallel()
+		updated, err := m.UpdateSinkEntity(base, &api.UpdateSinkPayload{Filter: new("")})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Filter)
+	})
+
+	t.Run("response", func(t *testing.T) {
+		t.Parallel()
+		resp, err := m.NewSinkResponse(base)
+		require.NoError(t, err)
+		assert.Equal(t, new(`Body("level") == "error"`), resp.Filter)
+	})
+}
+
+func TestNewTestResultResponse_Filter(t *testing.T) {
+	t.Parallel()
+	m := newTestMapper()
+	sourceKey := newSourceKey()
+
+	newSink := func(sinkID key.SinkID, filter string) definition.Sink {
+		return definition.Sink{
+			SinkKey: key.SinkKey{SourceKey: sourceKey, SinkID: sinkID},
+			Type:    definition.SinkTypeTable,
+			Name:    "My Sink",
+			Filter:  filter,
+			Table:   newTableSink(),
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"level":"info"}`))
+	req.Header.Set("Content-Type", "application/json")
+	recordCtx := recordctx.FromHTTP(time.Now(), req)
+
+	sinks := []definition.Sink{
+		newSink("errors", `Body("level") == "error"`),
+		newSink("all", ""),
+	}
+	result, err := m.NewTestResultResponse(sourceKey, sinks, recordCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []*api.TestResultSink{
+		{SinkID: "errors", Accepted: false},
+		{SinkID: "all", Accepted: true},
+	}, result.Sinks)
+	if assert.Len(t, result.Tables, 1) {
+		assert.Equal(t, key.SinkID("all"), result.Tables[0].SinkID)
+	}
+
+	// Invalid filter
+	_, err = m.NewTestResultResponse(sourceKey, []definition.Sink{newSink("invalid", `Body("level")`)}, recordCtx)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `filter expression must return a boolean`)
+	}
+}
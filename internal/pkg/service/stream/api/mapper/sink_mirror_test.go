@@ -0,0 +1,80 @@
+package mapper_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+)
+
+func TestNewSinkEntity_Mirror(t *testing.T) {
+	t.Parallel()
+	m := newTestMapper()
+	sourceKey := newSourceKey()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		tableSink := newMinimalTablePayload()
+		tableSink.Mirror = &api.TableMirror{
+			Type:    definition.TableTypeKeboola,
+			TableID: "in.c-archive.my-table",
+			Mapping: &api.TableMapping{Columns: api.TableColumns{{Type: column.Body{}.ColumnType(), Name: "body"}}},
+		}
+		entity, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: "My Sink", Type: definition.SinkTypeTable, Table: tableSink})
+		require.NoError(t, err)
+		assert.Equal(t, &definition.TableMirror{
+			Type:    definition.TableTypeKeboola,
+			Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.c-archive.my-table")},
+			Mapping: &table.Mapping{Columns: column.Columns{column.Body{Name: "body"}}},
+		}, entity.Table.Mirror)
+
+		resp, err := m.NewSinkResponse(entity)
+		require.NoError(t, err)
+		assert.Equal(t, tableSink.Mirror, resp.Table.Mirror)
+
+		// Remove the mirror
+		entity, err = m.UpdateSinkEntity(entity, &api.UpdateSinkPayload{Table: &api.TableSinkUpdate{RemoveMirror: new(true)}})
+		require.NoError(t, err)
+		assert.Nil(t, entity.Table.Mirror)
+	})
+
+	t.Run("same_table", func(t *testing.T) {
+		t.Parallel()
+		tableSink := newMinimalTablePayload()
+		tableSink.Mirror = &api.TableMirror{Type: definition.TableTypeKeboola, TableID: tableSink.TableID}
+		_, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: "My Sink", Type: definition.SinkTypeTable, Table: tableSink})
+		if assert.Error(t, err) {
+			assert.Equal(t, `mirror table "in.c-bucket.my-table" must differ from the sink table`, err.Error())
+		}
+	})
+
+	t.Run("long_sink_id", func(t *testing.T) {
+		t.Parallel()
+		tableSink := newMinimalTablePayload()
+		tableSink.Mirror = &api.TableMirror{Type: definition.TableTypeKeboola, TableID: "in.c-archive.my-table"}
+		_, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: strings.Repeat("a", 42), Type: definition.SinkTypeTable, Table: tableSink})
+		if assert.Error(t, err) {
+			assert.Equal(t, `"sinkId" must be a maximum of 41 characters in length, if the mirror is configured`, err.Error())
+		}
+	})
+
+	t.Run("mirror_and_remove_mirror", func(t *testing.T) {
+		t.Parallel()
+		entity, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: "My Sink", Type: definition.SinkTypeTable, Table: newMinimalTablePayload()})
+		require.NoError(t, err)
+		_, err = m.UpdateSinkEntity(entity, &api.UpdateSinkPayload{Table: &api.TableSinkUpdate{
+			Mirror:       &api.TableMirror{Type: definition.TableTypeKeboola, TableID: "in.c-archive.my-table"},
+			RemoveMirror: new(true),
+		}})
+		if assert.Error(t, err) {
+			assert.Equal(t, `"mirror" and "removeMirror" cannot be set together`, err.Error())
+		}
+	})
+}
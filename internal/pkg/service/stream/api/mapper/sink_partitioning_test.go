@@ -0,0 +1,114 @@
+package mapper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+)
+
+func TestNewSinkEntity_Partitioning(t *testing.T) {
+	t.Parallel()
+	m := newTestMapper()
+	sourceKey := newSourceKey()
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		table := newMinimalTablePayload()
+		table.Partitioning = &api.TablePartitioning{Granularity: definition.PartitionGranularityDaily, Column: "datetime"}
+		entity, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: "My Sink", Type: definition.SinkTypeTable, Table: table})
+		require.NoError(t, err)
+		assert.Equal(t, &definition.TablePartitioning{Granularity: definition.PartitionGranularityDaily, Column: "datetime"}, entity.Table.Partitioning)
+
+		resp, err := m.NewSinkResponse(entity)
+		require.NoError(t, err)
+		assert.Equal(t, &api.TablePartitioning{Granularity: definition.PartitionGranularityDaily, Column: "datetime"}, resp.Table.Partitioning)
+	})
+
+	t.Run("template_column", func(t *testing.T) {
+		t.Parallel()
+		table := newMinimalTablePayload()
+		table.Mapping.Columns = append(table.Mapping.Columns, &api.TableColumn{
+			Type:     column.Template{}.ColumnType(),
+			Name:     "event_time",
+			Template: &api.TableColumnTemplate{Language: column.TemplateLanguageJsonnet, Content: "Body('time')"},
+		})
+		table.Partitioning = &api.TablePartitioning{Granularity: definition.PartitionGranularityDaily, Column: "event_time"}
+		entity, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: "My Sink", Type: definition.SinkTypeTable, Table: table})
+		require.NoError(t, err)
+		assert.Equal(t, "event_time", entity.Table.Partitioning.Column)
+	})
+
+	t.Run("column_not_found", func(t *testing.T) {
+		t.Parallel()
+		table := newMinimalTablePayload()
+		table.Partitioning = &api.TablePartitioning{Granularity: definition.PartitionGranularityDaily, Column: "missing"}
+		_, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: "My Sink", Type: definition.SinkTypeTable, Table: table})
+		if assert.Error(t, err) {
+			assert.Equal(t, `partitioning column "missing" not found in the mapping`, err.Error())
+		}
+	})
+
+	t.Run("invalid_column_type", func(t *testing.T) {
+		t.Parallel()
+		table := newMinimalTablePayload()
+		table.Mapping.Columns = append(table.Mapping.Columns, &api.TableColumn{Type: column.Body{}.ColumnType(), Name: "body"})
+		table.Partitioning = &api.TablePartitioning{Granularity: definition.PartitionGranularityMonthly, Column: "body"}
+		_, err := m.NewSinkEntity(sourceKey, &api.CreateSinkPayload{Name: "My Sink", Type: definition.SinkTypeTable, Table: table})
+		if assert.Error(t, err) {
+			assert.Equal(t, `partitioning column "body" must be of the type "datetime" or "template", found "body"`, err.Error())
+		}
+	})
+}
+
+func TestNewSinkPartitions(t *testing.T) {
+	t.Parallel()
+	m := newTestMapper()
+	sinkKey := key.SinkKey{SourceKey: newSourceKey(), SinkID: "my-sink"}
+
+	newFile := func(openedAt string, partition string) model.File {
+		file := model.File{FileKey: model.FileKey{SinkKey: sinkKey, FileID: model.FileID{OpenedAt: utctime.MustParse(openedAt)}}}
+		if partition != "" {
+			file.TargetStorage.Partition = &targetModel.Partition{
+				ID:     partition,
+				Column: "datetime",
+				Start:  utctime.MustParse("2000-01-01T00:00:00.000Z"),
+				End:    utctime.MustParse("2000-01-03T00:00:00.000Z"),
+			}
+		}
+		return file
+	}
+
+	file1 := newFile("2000-01-01T01:00:00.000Z", "20000101")
+	file2 := newFile("2000-01-01T02:00:00.000Z", "20000101")
+	file3 := newFile("2000-01-02T01:00:00.000Z", "20000102")
+
+	// Sink without partitioning
+	assert.Nil(t, m.NewSinkPartitions([]model.File{newFile("2000-01-01T01:00:00.000Z", "")}, nil))
+
+	stats := map[model.FileID]*statistics.Aggregated{
+		file1.FileID: {Target: statistics.Value{RecordsCount: 1}, Total: statistics.Value{RecordsCount: 1}},
+		file2.FileID: {Local: statistics.Value{RecordsCount: 2}, Total: statistics.Value{RecordsCount: 2}},
+		file3.FileID: {Local: statistics.Value{RecordsCount: 3}, Total: statistics.Value{RecordsCount: 3}},
+	}
+
+	partitions := m.NewSinkPartitions([]model.File{file3, file2, file1}, stats)
+	require.Len(t, partitions, 2)
+	assert.Equal(t, "20000101", partitions[0].Partition)
+	assert.Equal(t, 2, partitions[0].FilesCount)
+	assert.Equal(t, uint64(3), partitions[0].Statistics.Total.RecordsCount)
+	assert.Equal(t, uint64(2), partitions[0].Statistics.Levels.Local.RecordsCount)
+	assert.Equal(t, uint64(1), partitions[0].Statistics.Levels.Target.RecordsCount)
+	assert.Equal(t, "20000102", partitions[1].Partition)
+	assert.Equal(t, 1, partitions[1].FilesCount)
+	assert.Equal(t, uint64(3), partitions[1].Statistics.Total.RecordsCount)
+}
@@ -40,6 +40,19 @@ func (m *Mapper) NewSinkEntity(parent key.SourceKey, payload *api.CreateSinkPayl
 		entity.AllowedSignals = signalsToStrings(payload.AllowedSignals)
 	}
 
+	// Filter is optional
+	if payload.Filter != nil {
+		if err := m.validateFilter(*payload.Filter); err != nil {
+			return definition.Sink{}, err
+		}
+		entity.Filter = *payload.Filter
+	}
+
+	// Dead-letter storage is disabled by default
+	if payload.DeadLetter != nil {
+		entity.DeadLetter = *payload.DeadLetter
+	}
+
 	// Sink type
 	entity.Type = payload.Type
 	switch entity.Type {
@@ -49,6 +62,21 @@ func (m *Mapper) NewSinkEntity(parent key.SourceKey, payload *api.CreateSinkPayl
 		} else {
 			return definition.Sink{}, err
 		}
+		if err := validateTableMirror(entity); err != nil {
+			return definition.Sink{}, err
+		}
+	case definition.SinkTypeWebhook:
+		if webhookEntity, err := m.newWebhookSinkEntity(payload); err == nil {
+			entity.Webhook = &webhookEntity
+		} else {
+			return definition.Sink{}, err
+		}
+	case definition.SinkTypeFile:
+		if fileEntity, err := m.newFileSinkEntity(payload); err == nil {
+			entity.File = &fileEntity
+		} else {
+			return definition.Sink{}, err
+		}
 	default:
 		return definition.Sink{}, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, payload.Type.String()))
 	}
@@ -72,6 +100,19 @@ func (m *Mapper) UpdateSinkEntity(entity definition.Sink, payload *api.UpdateSin
 		entity.AllowedSignals = signalsToStrings(payload.AllowedSignals)
 	}
 
+	// Filter, empty string removes the filter
+	if payload.Filter != nil {
+		if err := m.validateFilter(*payload.Filter); err != nil {
+			return definition.Sink{}, err
+		}
+		entity.Filter = *payload.Filter
+	}
+
+	// Dead-letter storage
+	if payload.DeadLetter != nil {
+		entity.DeadLetter = *payload.DeadLetter
+	}
+
 	// Type
 	if payload.Type != nil {
 		entity.Type = *payload.Type
@@ -88,6 +129,27 @@ func (m *Mapper) UpdateSinkEntity(entity definition.Sink, payload *api.UpdateSin
 				return definition.Sink{}, err
 			}
 		}
+		if err := validateTableMirror(entity); err != nil {
+			return definition.Sink{}, err
+		}
+	case definition.SinkTypeWebhook:
+		if entity.Webhook == nil {
+			entity.Webhook = &definition.WebhookSink{}
+		}
+		if payload.Webhook != nil {
+			if err := m.updateWebhookSinkEntity(entity.Webhook, payload); err != nil {
+				return definition.Sink{}, err
+			}
+		}
+	case definition.SinkTypeFile:
+		if entity.File == nil {
+			entity.File = &definition.FileSink{}
+		}
+		if payload.File != nil {
+			if err := m.updateFileSinkEntity(entity.File, payload); err != nil {
+				return definition.Sink{}, err
+			}
+		}
 	default:
 		return definition.Sink{}, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, payload.Type.String()))
 	}
@@ -95,6 +157,16 @@ func (m *Mapper) UpdateSinkEntity(entity definition.Sink, payload *api.UpdateSin
 	return entity, nil
 }
 
+func (m *Mapper) validateFilter(expression string) error {
+	if expression == "" {
+		return nil
+	}
+	if err := m.filters.Validate(expression); err != nil {
+		return svcerrors.NewBadRequestError(errors.Errorf(`"filter" is invalid: %w`, err))
+	}
+	return nil
+}
+
 func (m *Mapper) newTableSinkEntity(payload *api.CreateSinkPayload) (entity definition.TableSink, err error) {
 	// User has to specify table definition
 	if payload.Table == nil {
@@ -126,9 +198,102 @@ func (m *Mapper) newTableSinkEntity(payload *api.CreateSinkPayload) (entity defi
 		return definition.TableSink{}, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, payload.Type.String()))
 	}
 
+	// Partitioning
+	if payload.Table.Partitioning != nil {
+		entity.Partitioning = newTablePartitioningEntity(payload.Table.Partitioning)
+		if err := validateTablePartitioning(entity); err != nil {
+			return definition.TableSink{}, err
+		}
+	}
+
+	// Mirror
+	if payload.Table.Mirror != nil {
+		entity.Mirror, err = m.newTableMirrorEntity(payload.Table.Mirror)
+		if err != nil {
+			return definition.TableSink{}, err
+		}
+	}
+
 	return entity, err
 }
 
+func (m *Mapper) newTableMirrorEntity(payload *api.TableMirror) (*definition.TableMirror, error) {
+	entity := &definition.TableMirror{Type: payload.Type}
+
+	// Table type specific fields
+	switch entity.Type {
+	case definition.TableTypeKeboola:
+		tableID, err := keboola.ParseTableID(string(payload.TableID))
+		if err != nil {
+			return nil, svcerrors.NewBadRequestError(errors.Errorf(`invalid "mirror.tableId" value "%s": %w`, payload.TableID, err))
+		}
+		entity.Keboola = &definition.KeboolaTable{TableID: tableID}
+	default:
+		return nil, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "mirror.type" "%s"`, payload.Type.String()))
+	}
+
+	// Mapping is optional, the sink mapping is used by default
+	if payload.Mapping != nil {
+		mapping, err := m.newTableSinkMappingEntity(payload.Mapping)
+		if err != nil {
+			return nil, err
+		}
+		entity.Mapping = &mapping
+	}
+
+	return entity, nil
+}
+
+// validateTableMirror checks that the mirror table differs from the sink table,
+// and that the SinkID is short enough to derive the SinkID of the mirror, see key.SinkKey.Mirror.
+func validateTableMirror(entity definition.Sink) error {
+	if entity.Table == nil || entity.Table.Mirror == nil {
+		return nil
+	}
+
+	if len(entity.SinkID) > key.MirrorSinkIDMaxLength {
+		return svcerrors.NewBadRequestError(errors.Errorf(`"sinkId" must be a maximum of %d characters in length, if the mirror is configured`, key.MirrorSinkIDMaxLength))
+	}
+
+	mirror := entity.Table.Mirror
+	if entity.Table.Keboola != nil && mirror.Keboola != nil && entity.Table.Keboola.TableID == mirror.Keboola.TableID {
+		return svcerrors.NewBadRequestError(errors.Errorf(`mirror table "%s" must differ from the sink table`, mirror.Keboola.TableID))
+	}
+
+	return nil
+}
+
+func newTablePartitioningEntity(payload *api.TablePartitioning) *definition.TablePartitioning {
+	return &definition.TablePartitioning{
+		Granularity: payload.Granularity,
+		Column:      payload.Column,
+	}
+}
+
+// validateTablePartitioning checks that the partitioning column is a datetime or a template column of the mapping.
+// A value of the template column must be a time, it is checked when the record is routed to a partition.
+func validateTablePartitioning(entity definition.TableSink) error {
+	if entity.Partitioning == nil {
+		return nil
+	}
+
+	for _, col := range entity.Mapping.Columns {
+		if col.ColumnName() == entity.Partitioning.Column {
+			switch col.(type) {
+			case column.Datetime, column.Template:
+				return nil
+			default:
+				return svcerrors.NewBadRequestError(errors.Errorf(
+					`partitioning column "%s" must be of the type "%s" or "%s", found "%s"`,
+					col.ColumnName(), column.ColumnDatetimeType, column.ColumnTemplateType, col.ColumnType(),
+				))
+			}
+		}
+	}
+
+	return svcerrors.NewBadRequestError(errors.Errorf(`partitioning column "%s" not found in the mapping`, entity.Partitioning.Column))
+}
+
 // signalsToStrings converts the Goa-typed []api.OTLPSignal payload field into
 // the plain []string used by the persisted entity. The reverse conversion is
 // stringsToSignals immediately below.
@@ -158,20 +323,29 @@ func (m *Mapper) newTableSinkMappingEntity(payload *api.TableMapping) (entity ta
 		return table.Mapping{}, errors.Errorf(`"table.mapping" must be configured for the "%s" sink type`, definition.SinkTypeTable)
 	}
 
+	// Columns
+	entity.Columns, err = m.newColumnsEntity(payload.Columns)
+	if err != nil {
+		return table.Mapping{}, err
+	}
+
+	return entity, nil
+}
+
+func (m *Mapper) newColumnsEntity(payload []*api.TableColumn) (columns column.Columns, err error) {
 	vm := m.jsonnetPool.Get()
 	defer m.jsonnetPool.Put(vm)
 
-	// Columns
-	for _, columnPayload := range payload.Columns {
+	for _, columnPayload := range payload {
 		columnEntity, err := column.MakeColumn(columnPayload.Type, columnPayload.Name, false)
 		if err != nil {
-			return table.Mapping{}, err
+			return nil, err
 		}
 
 		// Path column
 		if pathColumn, ok := columnEntity.(column.Path); ok {
 			if columnPayload.Path == nil {
-				return table.Mapping{}, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" is missing path`, columnPayload.Name))
+				return nil, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" is missing path`, columnPayload.Name))
 			}
 
 			pathColumn.Path = *columnPayload.Path
@@ -183,11 +357,11 @@ func (m *Mapper) newTableSinkMappingEntity(payload *api.TableMapping) (entity ta
 		// Template column
 		if tmplColumn, ok := columnEntity.(column.Template); ok {
 			if columnPayload.Template == nil {
-				return table.Mapping{}, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" is missing template`, columnPayload.Name))
+				return nil, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" is missing template`, columnPayload.Name))
 			}
 
 			if err := vm.Validate(columnPayload.Template.Content); err != nil {
-				return table.Mapping{}, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" template is invalid: %w`, columnPayload.Name, err))
+				return nil, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" template is invalid: %w`, columnPayload.Name, err))
 			}
 
 			tmplColumn.Template.Language = columnPayload.Template.Language
@@ -196,10 +370,48 @@ func (m *Mapper) newTableSinkMappingEntity(payload *api.TableMapping) (entity ta
 			columnEntity = tmplColumn
 		}
 
-		entity.Columns = append(entity.Columns, columnEntity)
+		// Target data type
+		if columnPayload.Cast != nil {
+			columnEntity, err = m.newColumnCastEntity(columnEntity, columnPayload.Cast)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		columns = append(columns, columnEntity)
 	}
 
-	return entity, nil
+	return columns, nil
+}
+
+func (m *Mapper) newColumnCastEntity(columnEntity column.Column, payload *api.TableColumnCast) (column.Column, error) {
+	cast := &column.Cast{
+		DataType:     payload.DataType,
+		OnError:      payload.OnError,
+		DefaultValue: payload.DefaultValue,
+	}
+
+	if cast.OnError == column.CastErrorDefault && cast.DefaultValue == nil {
+		return nil, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" is missing cast default value`, columnEntity.ColumnName()))
+	}
+
+	if err := cast.CheckDefaultValue(); err != nil {
+		return nil, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" cast is invalid: %w`, columnEntity.ColumnName(), err))
+	}
+
+	switch c := columnEntity.(type) {
+	case column.Body:
+		c.Cast = cast
+		return c, nil
+	case column.Path:
+		c.Cast = cast
+		return c, nil
+	case column.Template:
+		c.Cast = cast
+		return c, nil
+	default:
+		return nil, svcerrors.NewBadRequestError(errors.Errorf(`column "%s" of the type "%s" does not support cast`, columnEntity.ColumnName(), columnEntity.ColumnType()))
+	}
 }
 
 func (m *Mapper) updateTableSinkEntity(entity *definition.TableSink, payload *api.UpdateSinkPayload) (err error) {
@@ -234,5 +446,109 @@ func (m *Mapper) updateTableSinkEntity(entity *definition.TableSink, payload *ap
 		return svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, payload.Type.String()))
 	}
 
+	// Partitioning
+	if payload.Table.Partitioning != nil {
+		entity.Partitioning = newTablePartitioningEntity(payload.Table.Partitioning)
+	}
+	if err := validateTablePartitioning(*entity); err != nil {
+		return err
+	}
+
+	// Mirror
+	removeMirror := payload.Table.RemoveMirror != nil && *payload.Table.RemoveMirror
+	switch {
+	case removeMirror && payload.Table.Mirror != nil:
+		return svcerrors.NewBadRequestError(errors.New(`"mirror" and "removeMirror" cannot be set together`))
+	case removeMirror:
+		entity.Mirror = nil
+	case payload.Table.Mirror != nil:
+		entity.Mirror, err = m.newTableMirrorEntity(payload.Table.Mirror)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
+
+func (m *Mapper) newWebhookSinkEntity(payload *api.CreateSinkPayload) (entity definition.WebhookSink, err error) {
+	// User has to specify webhook definition
+	if payload.Webhook == nil {
+		return definition.WebhookSink{}, svcerrors.NewBadRequestError(errors.Errorf(`"webhook" must be configured for the "%s" sink type`, definition.SinkTypeWebhook))
+	}
+
+	entity.URL = payload.Webhook.URL
+
+	entity.Headers, err = m.newColumnsEntity(payload.Webhook.Headers)
+	if err != nil {
+		return definition.WebhookSink{}, err
+	}
+
+	if payload.Webhook.Mapping == nil {
+		return definition.WebhookSink{}, svcerrors.NewBadRequestError(errors.Errorf(`"webhook.mapping" must be configured for the "%s" sink type`, definition.SinkTypeWebhook))
+	}
+
+	entity.Mapping.Columns, err = m.newColumnsEntity(payload.Webhook.Mapping.Columns)
+	if err != nil {
+		return definition.WebhookSink{}, err
+	}
+
+	return entity, nil
+}
+
+func (m *Mapper) updateWebhookSinkEntity(entity *definition.WebhookSink, payload *api.UpdateSinkPayload) (err error) {
+	if payload.Webhook.URL != nil {
+		entity.URL = *payload.Webhook.URL
+	}
+
+	if payload.Webhook.Headers != nil {
+		entity.Headers, err = m.newColumnsEntity(payload.Webhook.Headers)
+		if err != nil {
+			return err
+		}
+	}
+
+	if payload.Webhook.Mapping != nil {
+		entity.Mapping.Columns, err = m.newColumnsEntity(payload.Webhook.Mapping.Columns)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Mapper) newFileSinkEntity(payload *api.CreateSinkPayload) (entity definition.FileSink, err error) {
+	// User has to specify file definition
+	if payload.File == nil {
+		return definition.FileSink{}, svcerrors.NewBadRequestError(errors.Errorf(`"file" must be configured for the "%s" sink type`, definition.SinkTypeFile))
+	}
+
+	entity.Provider = payload.File.Provider
+
+	if payload.File.Mapping == nil {
+		return definition.FileSink{}, svcerrors.NewBadRequestError(errors.Errorf(`"file.mapping" must be configured for the "%s" sink type`, definition.SinkTypeFile))
+	}
+
+	entity.Mapping.Columns, err = m.newColumnsEntity(payload.File.Mapping.Columns)
+	if err != nil {
+		return definition.FileSink{}, err
+	}
+
+	return entity, nil
+}
+
+func (m *Mapper) updateFileSinkEntity(entity *definition.FileSink, payload *api.UpdateSinkPayload) (err error) {
+	if payload.File.Provider != nil {
+		entity.Provider = *payload.File.Provider
+	}
+
+	if payload.File.Mapping != nil {
+		entity.Mapping.Columns, err = m.newColumnsEntity(payload.File.Mapping.Columns)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
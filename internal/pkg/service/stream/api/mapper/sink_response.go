@@ -30,6 +30,14 @@ func (m *Mapper) NewSinkResponse(entity definition.Sink) (*api.Sink, error) {
 		Disabled:       m.NewDisabledResponse(entity.Switchable),
 	}
 
+	if entity.Filter != "" {
+		out.Filter = new(entity.Filter)
+	}
+
+	if entity.DeadLetter {
+		out.DeadLetter = new(true)
+	}
+
 	// Type
 	out.Type = entity.Type
 	switch out.Type {
@@ -39,6 +47,12 @@ func (m *Mapper) NewSinkResponse(entity definition.Sink) (*api.Sink, error) {
 			return nil, err
 		}
 		out.Table = &tableResponse
+	case definition.SinkTypeWebhook:
+		webhookResponse := m.newWebhookSinkResponse(entity.Webhook)
+		out.Webhook = &webhookResponse
+	case definition.SinkTypeFile:
+		fileResponse := m.newFileSinkResponse(entity.File)
+		out.File = &fileResponse
 	default:
 		return nil, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, out.Type.String()))
 	}
@@ -82,6 +96,29 @@ func (m *Mapper) newTableSinkResponse(entity *definition.TableSink) (out api.Tab
 		return api.TableSink{}, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "table.type" "%s"`, out.Type.String()))
 	}
 
+	// Partitioning
+	if entity.Partitioning != nil {
+		out.Partitioning = &api.TablePartitioning{
+			Granularity: entity.Partitioning.Granularity,
+			Column:      entity.Partitioning.Column,
+		}
+	}
+
+	// Mirror
+	if mirror := entity.Mirror; mirror != nil {
+		out.Mirror = &api.TableMirror{Type: mirror.Type}
+		switch mirror.Type {
+		case definition.TableTypeKeboola:
+			out.Mirror.TableID = api.TableID(mirror.Keboola.TableID.String())
+		default:
+			return api.TableSink{}, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "table.mirror.type" "%s"`, mirror.Type.String()))
+		}
+		if mirror.Mapping != nil {
+			mapping := m.newTableMappingResponse(*mirror.Mapping)
+			out.Mirror.Mapping = &mapping
+		}
+	}
+
 	return out, nil
 }
 
@@ -107,9 +144,36 @@ func (m *Mapper) NewSinkVersions(
 	}, nil
 }
 
+func (m *Mapper) newWebhookSinkResponse(entity *definition.WebhookSink) api.WebhookSink {
+	mapping := m.newTableMappingResponse(entity.Mapping)
+	out := api.WebhookSink{
+		URL:     entity.URL,
+		Mapping: &mapping,
+	}
+
+	if len(entity.Headers) > 0 {
+		out.Headers = api.WebhookHeaders(m.newColumnsResponse(entity.Headers))
+	}
+
+	return out
+}
+
+func (m *Mapper) newFileSinkResponse(entity *definition.FileSink) api.FileSink {
+	mapping := m.newTableMappingResponse(entity.Mapping)
+	return api.FileSink{
+		Provider: entity.Provider,
+		Mapping:  &mapping,
+	}
+}
+
 func (m *Mapper) newTableMappingResponse(entity table.Mapping) (out api.TableMapping) {
-	out.Columns = make(api.TableColumns, 0, len(entity.Columns))
-	for _, input := range entity.Columns {
+	out.Columns = m.newColumnsResponse(entity.Columns)
+	return out
+}
+
+func (m *Mapper) newColumnsResponse(entity column.Columns) (out api.TableColumns) {
+	out = make(api.TableColumns, 0, len(entity))
+	for _, input := range entity {
 		output := &api.TableColumn{
 			Type: input.ColumnType(),
 			Name: input.ColumnName(),
@@ -129,8 +193,46 @@ func (m *Mapper) newTableMappingResponse(entity table.Mapping) (out api.TableMap
 			}
 		}
 
-		out.Columns = append(out.Columns, output)
+		if v, ok := input.(column.Typed); ok && v.ColumnCast() != nil {
+			cast := v.ColumnCast()
+			output.Cast = &api.TableColumnCast{
+				DataType:     cast.DataType,
+				OnError:      cast.OnError,
+				DefaultValue: cast.DefaultValue,
+			}
+			if output.Cast.OnError == "" {
+				output.Cast.OnError = column.CastErrorFail
+			}
+		}
+
+		out = append(out, output)
 	}
 
 	return out
 }
+
+// NewSinkDataPreviewRow maps record values to the column names, by the index.
+func (m *Mapper) NewSinkDataPreviewRow(names []string, values []string) *api.SinkDataPreviewRow {
+	out := &api.SinkDataPreviewRow{Columns: make([]*api.SinkDataPreviewColumn, 0, len(values))}
+	for i, value := range values {
+		var name string
+		if i < len(names) {
+			name = names[i]
+		}
+		out.Columns = append(out.Columns, &api.SinkDataPreviewColumn{Name: name, Value: value})
+	}
+	return out
+}
+
+func (m *Mapper) NewSinkDataPreviewResponse(k key.SinkKey, rows []*api.SinkDataPreviewRow) *api.SinkDataPreview {
+	if rows == nil {
+		rows = []*api.SinkDataPreviewRow{}
+	}
+	return &api.SinkDataPreview{
+		ProjectID: k.ProjectID,
+		BranchID:  k.BranchID,
+		SourceID:  k.SourceID,
+		SinkID:    k.SinkID,
+		Rows:      rows,
+	}
+}
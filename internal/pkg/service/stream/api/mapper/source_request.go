@@ -1,7 +1,10 @@
 package mapper
 
 import (
+	"time"
+
 	"github.com/keboola/keboola-as-code/internal/pkg/idgenerator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
 	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
@@ -32,6 +35,11 @@ func (m *Mapper) NewSourceEntity(parent key.BranchKey, payload *api.CreateSource
 		entity.Description = *payload.Description
 	}
 
+	// Webhook signature is supported only by the HTTP source
+	if payload.HTTPSignature != nil && payload.Type != definition.SourceTypeHTTP {
+		return definition.Source{}, svcerrors.NewBadRequestError(errors.Errorf(`"httpSignature" cannot be set for the "type" "%s"`, payload.Type.String()))
+	}
+
 	// Type
 	entity.Type = payload.Type
 	switch entity.Type {
@@ -39,10 +47,22 @@ func (m *Mapper) NewSourceEntity(parent key.BranchKey, payload *api.CreateSource
 		entity.HTTP = &definition.HTTPSource{
 			Secret: idgenerator.StreamHTTPSourceSecret(),
 		}
+		if payload.HTTPSignature != nil {
+			signature, err := newHTTPSourceSignature(nil, payload.HTTPSignature)
+			if err != nil {
+				return definition.Source{}, err
+			}
+			entity.HTTP.Signature = signature
+		}
 	case definition.SourceTypeOTLP:
 		entity.OTLP = &definition.OTLPSource{
 			Secret: idgenerator.StreamHTTPSourceSecret(),
 		}
+	case definition.SourceTypeKafka:
+		if payload.Kafka == nil {
+			return definition.Source{}, svcerrors.NewBadRequestError(errors.Errorf(`"kafka" must be set for the "type" "%s"`, payload.Type.String()))
+		}
+		entity.Kafka = newKafkaSource(payload.Kafka)
 	default:
 		return definition.Source{}, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, payload.Type.String()))
 	}
@@ -66,6 +86,11 @@ func (m *Mapper) UpdateSourceEntity(entity definition.Source, payload *api.Updat
 		entity.Type = *payload.Type
 	}
 
+	// Webhook signature is supported only by the HTTP source
+	if (payload.HTTPSignature != nil || payload.RemoveHTTPSignature != nil) && entity.Type != definition.SourceTypeHTTP {
+		return definition.Source{}, svcerrors.NewBadRequestError(errors.Errorf(`"httpSignature" cannot be set for the "type" "%s"`, entity.Type.String()))
+	}
+
 	// Type-specific updates. Only the block matching the active type is kept
 	// so that switching type drops the previous type's stale secret/config and
 	// the persisted entity always carries exactly one type-specific block.
@@ -77,7 +102,17 @@ func (m *Mapper) UpdateSourceEntity(entity definition.Source, payload *api.Updat
 		if entity.HTTP.Secret == "" {
 			entity.HTTP.Secret = idgenerator.StreamHTTPSourceSecret()
 		}
+		if payload.RemoveHTTPSignature != nil && *payload.RemoveHTTPSignature {
+			entity.HTTP.Signature = nil
+		} else if payload.HTTPSignature != nil {
+			signature, err := newHTTPSourceSignature(entity.HTTP.Signature, payload.HTTPSignature)
+			if err != nil {
+				return definition.Source{}, err
+			}
+			entity.HTTP.Signature = signature
+		}
 		entity.OTLP = nil
+		entity.Kafka = nil
 	case definition.SourceTypeOTLP:
 		if entity.OTLP == nil {
 			entity.OTLP = &definition.OTLPSource{}
@@ -86,9 +121,54 @@ func (m *Mapper) UpdateSourceEntity(entity definition.Source, payload *api.Updat
 			entity.OTLP.Secret = idgenerator.StreamHTTPSourceSecret()
 		}
 		entity.HTTP = nil
+		entity.Kafka = nil
+	case definition.SourceTypeKafka:
+		if payload.Kafka != nil {
+			entity.Kafka = newKafkaSource(payload.Kafka)
+		}
+		if entity.Kafka == nil {
+			return definition.Source{}, svcerrors.NewBadRequestError(errors.Errorf(`"kafka" must be set for the "type" "%s"`, entity.Type.String()))
+		}
+		entity.HTTP = nil
+		entity.OTLP = nil
 	default:
 		return definition.Source{}, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, payload.Type.String()))
 	}
 
 	return entity, nil
 }
+
+func newKafkaSource(payload *api.KafkaSource) *definition.KafkaSource {
+	return &definition.KafkaSource{
+		Brokers: payload.Brokers,
+		Topic:   payload.Topic,
+		GroupID: payload.GroupID,
+	}
+}
+
+// newHTTPSourceSignature maps the payload, the secret is kept from the old value, if it is not set.
+func newHTTPSourceSignature(old *definition.HTTPSourceSignature, payload *api.HTTPSourceSignature) (*definition.HTTPSourceSignature, error) {
+	out := &definition.HTTPSourceSignature{
+		Algorithm: definition.SignatureAlgorithm(payload.Algorithm),
+		Format:    definition.SignatureFormat(payload.Format),
+		Header:    payload.Header,
+	}
+
+	switch {
+	case payload.Secret != nil:
+		out.Secret = *payload.Secret
+	case old != nil:
+		out.Secret = old.Secret
+	default:
+		return nil, svcerrors.NewBadRequestError(errors.New(`"httpSignature.secret" must be set`))
+	}
+
+	if payload.TimestampToleranceSeconds != nil {
+		out.TimestampTolerance = duration.From(time.Duration(*payload.TimestampToleranceSeconds) * time.Second)
+	}
+	if payload.ReplayWindowSeconds != nil {
+		out.ReplayWindow = duration.From(time.Duration(*payload.ReplayWindowSeconds) * time.Second)
+	}
+
+	return out, nil
+}
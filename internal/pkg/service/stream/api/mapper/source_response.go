@@ -38,7 +38,7 @@ func (m *Mapper) NewSourceResponse(entity definition.Source) (*api.Source, error
 		if err != nil {
 			return nil, err
 		}
-		out.HTTP = &api.HTTPSource{URL: u}
+		out.HTTP = &api.HTTPSource{URL: u, Signature: newHTTPSourceSignatureResponse(entity.HTTP.Signature)}
 	case definition.SourceTypeOTLP:
 		publicURL := m.httpSourcePublicURL.String()
 		u, err := entity.FormatOTLPSourceURL(publicURL)
@@ -50,6 +50,8 @@ func (m *Mapper) NewSourceResponse(entity definition.Source) (*api.Source, error
 			return nil, err
 		}
 		out.Otlp = &api.OTLPSource{URL: u, BaseURL: baseURL, Secret: entity.OTLP.Secret}
+	case definition.SourceTypeKafka:
+		out.Kafka = newKafkaSourceResponse(entity.Kafka)
 	default:
 		return nil, svcerrors.NewBadRequestError(errors.Errorf(`unexpected "type" "%s"`, out.Type.String()))
 	}
@@ -108,7 +110,20 @@ func (m *Mapper) NewTestResultResponse(sourceKey key.SourceKey, sinks []definiti
 
 	renderer := column.NewRenderer()
 
+	result.Sinks = make([]*api.TestResultSink, 0, len(sinks))
 	for _, sink := range sinks {
+		// Report whether the record would be dropped by the sink filter
+		accepted, err := m.filters.Accepts(sink.Filter, recordCtx)
+		if err != nil {
+			return nil, svcerrors.NewUnprocessableContentError(err).WithUserMessage(fmt.Sprintf(`Invalid filter of sink "%s": %s`, sink.SinkID, err.Error()))
+		}
+		result.Sinks = append(result.Sinks, &api.TestResultSink{SinkID: sink.SinkID, Accepted: accepted})
+
+		// Only table sinks which accept the record are tested
+		if !accepted || sink.Type != definition.SinkTypeTable {
+			continue
+		}
+
 		row := &api.TestResultRow{}
 		for _, c := range sink.Table.Mapping.Columns {
 			csvValue, err := renderer.CSVValue(c, recordCtx)
@@ -136,3 +151,44 @@ func (m *Mapper) NewTestResultResponse(sourceKey key.SourceKey, sinks []definiti
 
 	return result, nil
 }
+
+func (m *Mapper) NewTestResultSignatureResponse(err error) *api.TestResultSignature {
+	if err == nil {
+		return &api.TestResultSignature{Valid: true}
+	}
+
+	return &api.TestResultSignature{Valid: false, Message: new(errors.Format(err, errors.FormatAsSentences()))}
+}
+
+func newHTTPSourceSignatureResponse(entity *definition.HTTPSourceSignature) *api.HTTPSourceSignature {
+	if entity == nil {
+		return nil
+	}
+
+	// The secret is not returned
+	return &api.HTTPSourceSignature{
+		Algorithm:                 entity.Algorithm.String(),
+		Format:                    entity.Format.String(),
+		Header:                    entity.Header,
+		TimestampToleranceSeconds: new(int(entity.TimestampTolerance.Duration().Seconds())),
+		ReplayWindowSeconds:       new(int(entity.ReplayWindow.Duration().Seconds())),
+	}
+}
+
+func newKafkaSourceResponse(entity *definition.KafkaSource) *api.KafkaSource {
+	return &api.KafkaSource{
+		Brokers: entity.Brokers,
+		Topic:   entity.Topic,
+		GroupID: entity.GroupID,
+	}
+}
+
+func (m *Mapper) NewInferredSinkMappingResponse(sourceKey key.SourceKey, samplesCount int, columns column.Columns) *api.InferredSinkMapping {
+	return &api.InferredSinkMapping{
+		ProjectID:    sourceKey.ProjectID,
+		BranchID:     sourceKey.BranchID,
+		SourceID:     sourceKey.SourceID,
+		SamplesCount: samplesCount,
+		Mapping:      &api.TableMapping{Columns: m.newColumnsResponse(columns)},
+	}
+}
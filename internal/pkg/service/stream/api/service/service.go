@@ -14,6 +14,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
 	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
@@ -30,18 +31,29 @@ type service struct {
 	definition *definitionRepo.Repository
 	mapper     *mapper.Mapper
 	adminError error
+	// adminReadError is returned, if a non-admin token reads raw records, they may contain personal data
+	adminReadError error
+	// nodeID and writerNetwork are used to connect to disk writer nodes, for example to preview sink data.
+	nodeID        string
+	writerNetwork network.Config
+	// sampling is true, if source nodes capture request samples, see the sample package
+	sampling bool
 }
 
 func New(d dependencies.APIScope, cfg config.Config) api.Service {
 	return &service{
-		logger:     d.Logger(),
-		clock:      d.Clock(),
-		publicURL:  d.APIPublicURL(),
-		tasks:      d.TaskNode(),
-		locks:      d.DistributedLockProvider(),
-		definition: d.DefinitionRepository(),
-		mapper:     mapper.New(d, cfg),
-		adminError: errors.New("only admin token can do write operations on streams"),
+		logger:         d.Logger(),
+		clock:          d.Clock(),
+		publicURL:      d.APIPublicURL(),
+		tasks:          d.TaskNode(),
+		locks:          d.DistributedLockProvider(),
+		definition:     d.DefinitionRepository(),
+		mapper:         mapper.New(d, cfg),
+		adminError:     errors.New("only admin token can do write operations on streams"),
+		adminReadError: errors.New("only admin token can read records of streams"),
+		nodeID:         cfg.NodeID,
+		writerNetwork:  cfg.Storage.Level.Local.Writer.Network,
+		sampling:       cfg.Source.Sample.Size > 0,
 	}
 }
 
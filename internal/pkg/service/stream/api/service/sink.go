@@ -20,6 +20,8 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/coordinator/clusterlock"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
@@ -295,7 +297,20 @@ func (s *service) SinkStatisticsTotal(ctx context.Context, d dependencies.SinkRe
 		return nil, err
 	}
 
-	return s.mapper.NewSinkStatisticsTotalResponse(stats), nil
+	dropped, err := d.StatisticsRepository().SinkDroppedStats(d.SinkKey()).Do(ctx).ResultOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	deduplicated, err := d.StatisticsRepository().SinkDeduplicatedStats(d.SinkKey()).Do(ctx).ResultOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	res := s.mapper.NewSinkStatisticsTotalResponse(stats)
+	res.Dropped = s.mapper.NewSinkStatisticsDroppedResponse(dropped)
+	res.Deduplicated = s.mapper.NewSinkStatisticsDeduplicatedResponse(deduplicated)
+	return res, nil
 }
 
 func (s *service) SinkStatisticsFiles(ctx context.Context, d dependencies.SinkRequestScope, payload *stream.SinkStatisticsFilesPayload) (*stream.SinkStatisticsFilesResult, error) {
@@ -304,6 +319,8 @@ func (s *service) SinkStatisticsFiles(ctx context.Context, d dependencies.SinkRe
 	}
 
 	filesMap := make(map[model.FileID]*stream.SinkFile)
+	var files []model.File
+	var statisticsMap map[model.FileID]*statistics.Aggregated
 
 	lastReset, err := d.StatisticsRepository().LastReset(d.SinkKey()).Do(ctx).ResultOrErr()
 	if err != nil {
@@ -328,6 +345,7 @@ func (s *service) SinkStatisticsFiles(ctx context.Context, d dependencies.SinkRe
 		Do(ctx).
 		ForEachValue(func(value model.File, _ *iterator.Header) error {
 			filesMap[value.FileID] = s.mapper.NewSinkFile(value)
+			files = append(files, value)
 			return nil
 		})
 	if err != nil {
@@ -341,7 +359,7 @@ func (s *service) SinkStatisticsFiles(ctx context.Context, d dependencies.SinkRe
 			return strings.Compare(a.String(), b.String())
 		})
 
-		statisticsMap, err := d.StatisticsRepository().FilesStats(d.SinkKey(), keys[0], keys[len(keys)-1]).Do(ctx).ResultOrErr()
+		statisticsMap, err = d.StatisticsRepository().FilesStats(d.SinkKey(), keys[0], keys[len(keys)-1]).Do(ctx).ResultOrErr()
 		if err != nil {
 			return nil, err
 		}
@@ -354,12 +372,15 @@ func (s *service) SinkStatisticsFiles(ctx context.Context, d dependencies.SinkRe
 	}
 
 	// Sort files response by OpenedAt timestamp
-	files := maps.Values(filesMap)
+	sinkFiles := maps.Values(filesMap)
 
 	// Sort files by specific conditions:
-	slices.SortStableFunc(files, compareSinkFiles)
+	slices.SortStableFunc(sinkFiles, compareSinkFiles)
 
-	return &stream.SinkStatisticsFilesResult{Files: files}, nil
+	// Group statistics by the table partition, if any
+	partitions := s.mapper.NewSinkPartitions(files, statisticsMap)
+
+	return &stream.SinkStatisticsFilesResult{Files: sinkFiles, Partitions: partitions}, nil
 }
 
 func (s *service) SinkStatisticsClear(ctx context.Context, d dependencies.SinkRequestScope, payload *api.SinkStatisticsClearPayload) (err error) {
@@ -483,6 +504,96 @@ func (s *service) UndeleteSink(ctx context.Context, scope dependencies.SinkReque
 	return s.mapper.NewTaskResponse(t)
 }
 
+func (s *service) ReimportSinkFiles(ctx context.Context, d dependencies.SinkRequestScope, payload *api.ReimportSinkFilesPayload) (res *api.Task, err error) {
+	// If user is not admin deny access for write
+	token := d.StorageAPIToken()
+	if token.Admin == nil || token.Admin.Role != adminRole {
+		return nil, svcerrors.NewForbiddenError(s.adminError)
+	}
+
+	since, err := time.Parse(time.RFC3339, payload.Since)
+	if err != nil {
+		return nil, svcerrors.NewBadRequestError(errors.PrefixError(err, `invalid "since"`))
+	}
+	until, err := time.Parse(time.RFC3339, payload.Until)
+	if err != nil {
+		return nil, svcerrors.NewBadRequestError(errors.PrefixError(err, `invalid "until"`))
+	}
+	if !since.Before(until) {
+		return nil, svcerrors.NewBadRequestError(errors.New(`"until" must be after "since"`))
+	}
+
+	var tableID string
+	if payload.TableID != nil {
+		tableID = string(*payload.TableID)
+	}
+
+	if err := s.sinkMustExist(ctx, d.SinkKey()); err != nil {
+		return nil, err
+	}
+
+	// Re-import files in a task
+	t, err := s.startTask(ctx, taskConfig{
+		Type:      "reimport.sink.files",
+		Timeout:   5 * time.Minute,
+		ProjectID: d.ProjectID(),
+		ObjectKey: d.SinkKey(),
+		Operation: func(ctx context.Context, logger log.Logger) task.Result {
+			// Empty files are not imported at all, the staged file has been deleted.
+			// A staging file, which has not been retained, may be already expired, so the file is skipped.
+			var fileKeys []model.FileKey
+			var expired int
+			err := d.StorageRepository().File().ListInState(d.SinkKey(), model.FileImported).
+				WithFilter(func(v model.File) bool {
+					openedAt := v.OpenedAt().Time()
+					return !v.StagingStorage.IsEmpty && !openedAt.Before(since) && openedAt.Before(until)
+				}).
+				Do(ctx).
+				ForEachValue(func(value model.File, _ *iterator.Header) error {
+					if value.StagingStorage.Retained {
+						fileKeys = append(fileKeys, value.FileKey)
+					} else {
+						expired++
+					}
+					return nil
+				})
+			if err != nil {
+				return task.ErrResult(err)
+			}
+
+			if len(fileKeys) == 0 && expired > 0 {
+				return task.ErrResult(svcerrors.NewBadRequestError(errors.Errorf(
+					`staging files of %d imported files have not been retained, see the "storage.metadataCleanup.importedFileRetention" configuration`, expired,
+				)))
+			}
+
+			if len(fileKeys) == 0 {
+				return task.ErrResult(svcerrors.NewResourceNotFoundError("imported file", fmt.Sprintf("%s - %s", payload.Since, payload.Until), "sink"))
+			}
+
+			// The file import operator takes over the files in the reimporting state
+			for _, fileKey := range fileKeys {
+				if err := s.switchFileToReimporting(ctx, d, logger, fileKey, tableID); err != nil {
+					return task.ErrResult(err)
+				}
+			}
+
+			msg := fmt.Sprintf("Re-import of %d files has been started.", len(fileKeys))
+			if expired > 0 {
+				msg += fmt.Sprintf(" Skipped %d files, their staging files have not been retained.", expired)
+			}
+			result := task.OkResult(msg)
+			result = s.mapper.WithTaskOutputs(result, d.SinkKey())
+			return result
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.NewTaskResponse(t)
+}
+
 func (s *service) ListSinkVersions(ctx context.Context, scope dependencies.SinkRequestScope, payload *api.ListSinkVersionsPayload) (res *api.EntityVersions, err error) {
 	if err := s.sinkMustExist(ctx, scope.SinkKey()); err != nil {
 		return nil, err
@@ -545,6 +656,17 @@ func (s *service) RollbackSinkVersion(ctx context.Context, scope dependencies.Si
 	return s.mapper.NewTaskResponse(t)
 }
 
+// switchFileToReimporting uses the same lock as the file import operator.
+func (s *service) switchFileToReimporting(ctx context.Context, d dependencies.SinkRequestScope, logger log.Logger, fileKey model.FileKey, tableID string) error {
+	lock, unlock, err := clusterlock.LockFile(ctx, s.locks, logger, fileKey)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return d.StorageRepository().File().SwitchToReimporting(fileKey, d.Clock().Now(), tableID).RequireLock(lock).Do(ctx).Err()
+}
+
 func (s *service) sinkMustNotExist(ctx context.Context, k key.SinkKey) error {
 	return s.definition.Sink().MustNotExist(k).Do(ctx).Err()
 }
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func (s *service) ListSinkDeadLetters(ctx context.Context, d dependencies.SinkRequestScope, payload *api.ListSinkDeadLettersPayload) (*api.SinkDeadLetterRecordsList, error) {
+	// If user is not admin deny access to stored records
+	token := d.StorageAPIToken()
+	if token.Admin == nil || token.Admin.Role != adminRole {
+		return nil, svcerrors.NewForbiddenError(s.adminReadError)
+	}
+
+	if err := s.sinkMustExist(ctx, d.SinkKey()); err != nil {
+		return nil, err
+	}
+
+	// Load pending replay requests
+	replayRequested := make(map[deadletter.RecordKey]bool)
+	err := d.DeadLetterRepository().ListReplayRequests(d.SinkKey()).
+		ForEach(func(request deadletter.ReplayRequest, _ *iterator.Header) error {
+			replayRequested[request.RecordKey] = true
+			return nil
+		}).
+		Do(ctx).
+		Err()
+	if err != nil {
+		return nil, err
+	}
+
+	list := func(opts ...iterator.Option) iterator.DefinitionT[deadletter.Record] {
+		return d.DeadLetterRepository().List(d.SinkKey(), opts...)
+	}
+
+	return s.mapper.NewSinkDeadLetterRecordsResponse(ctx, d.SinkKey(), payload.AfterID, payload.Limit, list, replayRequested)
+}
+
+func (s *service) GetSinkDeadLetter(ctx context.Context, d dependencies.SinkRequestScope, payload *api.GetSinkDeadLetterPayload) (*api.SinkDeadLetterRecord, error) {
+	// If user is not admin deny access to stored records
+	token := d.StorageAPIToken()
+	if token.Admin == nil || token.Admin.Role != adminRole {
+		return nil, svcerrors.NewForbiddenError(s.adminReadError)
+	}
+
+	if err := s.sinkMustExist(ctx, d.SinkKey()); err != nil {
+		return nil, err
+	}
+
+	k := deadletter.RecordKey{SinkKey: d.SinkKey(), RecordID: payload.RecordID}
+
+	record, err := d.DeadLetterRepository().Get(k).Do(ctx).ResultOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := d.DeadLetterRepository().ReplayRequest(k).Do(ctx).ResultOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.NewSinkDeadLetterRecordResponse(record, request != nil, true), nil
+}
+
+func (s *service) ReplaySinkDeadLetter(ctx context.Context, d dependencies.SinkRequestScope, payload *api.ReplaySinkDeadLetterPayload) error {
+	// If user is not admin deny access for write
+	token := d.StorageAPIToken()
+	if token.Admin == nil || token.Admin.Role != adminRole {
+		return svcerrors.NewForbiddenError(s.adminError)
+	}
+
+	sink, err := s.definition.Sink().Get(d.SinkKey()).Do(ctx).ResultOrErr()
+	if err != nil {
+		return err
+	}
+
+	k := deadletter.RecordKey{SinkKey: d.SinkKey(), RecordID: payload.RecordID}
+	record, err := d.DeadLetterRepository().Get(k).Do(ctx).ResultOrErr()
+	if err != nil {
+		return err
+	}
+
+	// The original record cannot be reconstructed from a truncated body
+	if record.BodyTruncated {
+		return svcerrors.
+			NewUnprocessableContentError(errors.Errorf(`record "%s" cannot be replayed, the body is truncated`, k.RecordID)).
+			WithUserMessage(fmt.Sprintf(`Record "%s" cannot be replayed, the stored body is truncated.`, k.RecordID))
+	}
+
+	// Check that the record can be mapped by the current sink configuration, so the user gets a quick feedback
+	if err := checkDeadLetterMapping(ctx, sink, record); err != nil {
+		return err
+	}
+
+	return d.DeadLetterRepository().RequestReplay(k, s.clock.Now()).Do(ctx).Err()
+}
+
+func (s *service) PurgeSinkDeadLetters(ctx context.Context, d dependencies.SinkRequestScope, _ *api.PurgeSinkDeadLettersPayload) error {
+	// If user is not admin deny access for write
+	token := d.StorageAPIToken()
+	if token.Admin == nil || token.Admin.Role != adminRole {
+		return svcerrors.NewForbiddenError(s.adminError)
+	}
+
+	if err := s.sinkMustExist(ctx, d.SinkKey()); err != nil {
+		return err
+	}
+
+	return d.DeadLetterRepository().Purge(d.SinkKey()).Do(ctx).Err()
+}
+
+func checkDeadLetterMapping(ctx context.Context, sink definition.Sink, record deadletter.Record) error {
+	var columns column.Columns
+	switch sink.Type {
+	case definition.SinkTypeTable:
+		columns = sink.Table.Mapping.Columns
+	case definition.SinkTypeWebhook:
+		columns = append(columns, sink.Webhook.Headers...)
+		columns = append(columns, sink.Webhook.Mapping.Columns...)
+	case definition.SinkTypeFile:
+		columns = sink.File.Mapping.Columns
+	default:
+		return nil
+	}
+
+	renderer := column.NewRenderer()
+	recordCtx := record.RecordContext(ctx)
+	for _, c := range columns {
+		if _, err := renderer.CSVValue(c, recordCtx); err != nil {
+			return svcerrors.NewUnprocessableContentError(err).WithUserMessage(fmt.Sprintf(`Invalid value for column "%s": %s`, c.ColumnName(), err.Error()))
+		}
+	}
+
+	return nil
+}
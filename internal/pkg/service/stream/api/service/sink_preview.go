@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/rpc"
+	volume "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func (s *service) PreviewSinkData(ctx context.Context, d dependencies.SinkRequestScope, payload *api.PreviewSinkDataPayload) (*api.SinkDataPreview, error) {
+	// If user is not admin deny access to stored records
+	token := d.StorageAPIToken()
+	if token.Admin == nil || token.Admin.Role != adminRole {
+		return nil, svcerrors.NewForbiddenError(s.adminReadError)
+	}
+
+	sink, err := s.definition.Sink().Get(d.SinkKey()).Do(ctx).ResultOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.Plugins().IsSinkWithLocalStorage(sink.Type) {
+		return nil, svcerrors.NewBadRequestError(errors.Errorf(`sink type "%s" does not use the local storage`, sink.Type))
+	}
+
+	// Open slices are on disk writer nodes
+	slices, err := d.StorageRepository().Slice().ListInState(d.SinkKey(), model.SliceWriting).Do(ctx).All()
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := d.StorageRepository().Volume().ListWriterVolumes().Do(ctx).All()
+	if err != nil {
+		return nil, err
+	}
+	volumesMap := make(map[volume.ID]volume.Metadata, len(volumes))
+	for _, vol := range volumes {
+		volumesMap[vol.ID] = vol
+	}
+
+	// Group slices by the disk writer node, one connection per node is used
+	var nodeIDs []string
+	nodeSlices := make(map[string][]rpc.SlicePreview)
+	nodeSliceIndexes := make(map[string][]int)
+	for i, slice := range slices {
+		vol, found := volumesMap[slice.VolumeID]
+		if !found {
+			return nil, svcerrors.NewResourceNotFoundError("writer volume", slice.VolumeID.String(), "slice")
+		}
+
+		if _, ok := nodeSlices[vol.NodeID]; !ok {
+			nodeIDs = append(nodeIDs, vol.NodeID)
+		}
+		nodeSlices[vol.NodeID] = append(nodeSlices[vol.NodeID], rpc.SlicePreview{
+			Volume:       vol,
+			SliceKey:     slice.SliceKey,
+			LocalStorage: slice.LocalStorage,
+			EncoderType:  slice.Encoding.Encoder.Type,
+			Compression:  slice.Encoding.Compression,
+		})
+		nodeSliceIndexes[vol.NodeID] = append(nodeSliceIndexes[vol.NodeID], i)
+	}
+
+	// Read the last records of each slice, nodes are read in parallel
+	slicesRecords := make([][][]string, len(slices))
+	grp, grpCtx := errgroup.WithContext(ctx)
+	for _, nodeID := range nodeIDs {
+		grp.Go(func() error {
+			records, err := rpc.PreviewSlices(grpCtx, s.logger, s.writerNetwork, s.nodeID, nodeSlices[nodeID], payload.Limit)
+			if err != nil {
+				return errors.PrefixErrorf(err, `cannot preview slices on the node "%s"`, nodeID)
+			}
+			for i, sliceIndex := range nodeSliceIndexes[nodeID] {
+				slicesRecords[sliceIndex] = records[i]
+			}
+			return nil
+		})
+	}
+	if err := grp.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Keep the last records overall
+	var rows []*api.SinkDataPreviewRow
+	for i, slice := range slices {
+		for _, record := range slicesRecords[i] {
+			rows = append(rows, s.mapper.NewSinkDataPreviewRow(slice.Mapping.Columns.Names(), record))
+		}
+	}
+
+	if len(rows) > payload.Limit {
+		rows = rows[len(rows)-payload.Limit:]
+	}
+
+	return s.mapper.NewSinkDataPreviewResponse(d.SinkKey(), rows), nil
+}
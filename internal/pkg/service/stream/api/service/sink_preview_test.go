@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
+	volume "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/writernode"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/netutils"
+)
+
+func TestPreviewSinkData(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+
+	// Create two volumes of one disk writer node
+	volumesPath := t.TempDir()
+	volumePaths := make(map[volume.ID]string)
+	for _, volumeID := range []volume.ID{"my-volume-1", "my-volume-2"} {
+		volumePath := filepath.Join(volumesPath, "hdd", volumeID.String())
+		require.NoError(t, os.MkdirAll(volumePath, 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(volumePath, volume.IDFile), []byte(volumeID), 0o600))
+		volumePaths[volumeID] = volumePath
+	}
+
+	// Start disk writer node
+	writerScp, writerMock := dependencies.NewMockedStorageWriterScopeWithConfig(
+		t,
+		ctx,
+		func(cfg *config.Config) {
+			cfg.NodeID = "disk-writer"
+			cfg.Storage.VolumesPath = volumesPath
+			cfg.Storage.Level.Local.Writer.Network.Listen = fmt.Sprintf("0.0.0.0:%d", netutils.FreePortForTest(t))
+		},
+		commonDeps.WithEtcdConfig(etcdCfg),
+	)
+	require.NoError(t, writernode.Start(ctx, writerScp, writerMock.TestConfig()))
+
+	// Create resources in an API node, the sink uses both volumes
+	apiScp, apiMock := dependencies.NewMockedAPIScopeWithConfig(
+		t,
+		ctx,
+		func(cfg *config.Config) {
+			cfg.NodeID = "api"
+			cfg.Storage.Level.Local.Volume.Assignment.Count = 2
+		},
+		commonDeps.WithEtcdConfig(etcdCfg),
+	)
+	branchKey := key.BranchKey{ProjectID: 12345, BranchID: 456}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(key.SourceKey{BranchKey: branchKey, SourceID: "my-source"})
+	source.HTTP.Secret = strings.Repeat("1", 48)
+	sink := dummy.NewSinkWithLocalStorage(key.SinkKey{SourceKey: source.SourceKey, SinkID: "my-sink"})
+	require.NoError(t, apiScp.DefinitionRepository().Branch().Create(&branch, apiScp.Clock().Now(), test.ByUser()).Do(ctx).Err())
+	require.NoError(t, apiScp.DefinitionRepository().Source().Create(&source, apiScp.Clock().Now(), test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, apiScp.DefinitionRepository().Sink().Create(&sink, apiScp.Clock().Now(), test.ByUser(), "create").Do(ctx).Err())
+
+	// Write compressed CSV records to each slice, as a source node
+	slices, err := apiScp.StorageRepository().Slice().ListIn(sink.SinkKey).Do(ctx).All()
+	require.NoError(t, err)
+	require.Len(t, slices, 2)
+	assert.NotEqual(t, slices[0].VolumeID, slices[1].VolumeID)
+	for i, content := range []string{"\"foo\",\"1\"\n\"bar\",\"2\"\n", "\"baz\",\"3\"\n"} {
+		slice := slices[i]
+		var buf bytes.Buffer
+		w, err := compressionWriter.New(&buf, slice.Encoding.Compression)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		volumePath := volumePaths[slice.VolumeID]
+		require.NoError(t, os.MkdirAll(slice.LocalStorage.DirName(volumePath), 0o700))
+		require.NoError(t, os.WriteFile(slice.LocalStorage.FileName(volumePath, "source"), buf.Bytes(), 0o600))
+	}
+
+	svc := New(apiScp, apiMock.TestConfig())
+	sinkScope := func(token keboola.Token) dependencies.SinkRequestScope {
+		branchScp, _ := dependencies.NewMockedBranchRequestScope(
+			t,
+			ctx,
+			key.BranchIDOrDefault(branchKey.BranchID.String()),
+			commonDeps.WithEtcdConfig(etcdCfg),
+			commonDeps.WithMockedStorageAPIToken(token),
+		)
+		sourceScp := dependencies.NewSourceRequestScope(branchScp.(dependencies.BranchRequestScope), source.SourceID)
+		return dependencies.NewSinkRequestScope(sourceScp, sink.SinkID)
+	}
+	token := keboola.Token{
+		ID:       "token-12345-id",
+		Token:    "my-secret",
+		IsMaster: true,
+		Owner:    keboola.TokenOwner{ID: 12345, Name: "Project 12345"},
+	}
+
+	// Only an admin token can read records
+	_, err = svc.PreviewSinkData(ctx, sinkScope(token), &api.PreviewSinkDataPayload{Limit: 2})
+	var forbiddenErr svcerrors.ForbiddenError
+	require.True(t, errors.As(err, &forbiddenErr))
+	assert.Equal(t, http.StatusForbidden, forbiddenErr.StatusCode())
+	assert.Equal(t, "only admin token can read records of streams", err.Error())
+
+	// The last records of both slices are read from the node
+	token.Admin = &keboola.TokenAdmin{Role: adminRole}
+	result, err := svc.PreviewSinkData(ctx, sinkScope(token), &api.PreviewSinkDataPayload{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, sink.SinkID, result.SinkID)
+	var values [][]string
+	for _, row := range result.Rows {
+		var rowValues []string
+		for _, col := range row.Columns {
+			rowValues = append(rowValues, col.Value)
+		}
+		values = append(values, rowValues)
+	}
+	assert.Equal(t, [][]string{{"bar", "2"}, {"baz", "3"}}, values)
+
+	// Shutdown the writer node
+	writerMock.Process().Shutdown(ctx, errors.New("bye bye"))
+	writerMock.Process().WaitForShutdown()
+}
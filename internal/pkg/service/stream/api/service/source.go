@@ -19,6 +19,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola/bridge"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/httpsource/signature"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
@@ -474,7 +475,21 @@ func (s *service) TestSource(ctx context.Context, d dependencies.SourceRequestSc
 		recordCtx = recordctx.FromHTTP(d.Clock().Now(), req)
 	}
 
-	return s.mapper.NewTestResultResponse(d.SourceKey(), sinks, recordCtx)
+	res, err = s.mapper.NewTestResultResponse(d.SourceKey(), sinks, recordCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Report the webhook signature verification result, the test request is not rejected
+	if source.Type == definition.SourceTypeHTTP && source.HTTP.Signature != nil {
+		body, err := recordCtx.BodyBytes()
+		if err != nil {
+			return nil, err
+		}
+		res.Signature = s.mapper.NewTestResultSignatureResponse(signature.Verify(*source.HTTP.Signature, d.Clock().Now(), req.Header.Get, body))
+	}
+
+	return res, nil
 }
 
 // filterSinksBySignal keeps only sinks whose AcceptsSignal returns true.
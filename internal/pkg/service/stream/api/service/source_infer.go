@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	api "github.com/keboola/keboola-as-code/internal/pkg/service/stream/api/gen/stream"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/infer"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func (s *service) InferSinkMapping(ctx context.Context, d dependencies.SourceRequestScope, payload *api.InferSinkMappingPayload) (*api.InferredSinkMapping, error) {
+	if err := s.sourceMustExist(ctx, d.SourceKey()); err != nil {
+		return nil, err
+	}
+
+	var bodies []*orderedmap.OrderedMap
+	if len(payload.Samples) > 0 {
+		// Samples from the request
+		for i, sample := range payload.Samples {
+			body, err := sampleToMap(sample)
+			if err != nil {
+				return nil, svcerrors.NewBadRequestError(errors.Errorf(`"samples[%d]" must be a JSON object`, i))
+			}
+			bodies = append(bodies, body)
+		}
+	} else if !s.sampling {
+		return nil, svcerrors.
+			NewUnprocessableContentError(errors.New("sampling of source requests is disabled")).
+			WithUserMessage(`Sampling of source requests is disabled by the "source.sample.size" configuration, send samples in the request.`)
+	} else {
+		// Last requests of the source, a sample which cannot be parsed is skipped
+		samples, err := d.SampleRepository().List(d.SourceKey()).Do(ctx).All()
+		if err != nil {
+			return nil, err
+		}
+		for _, sample := range samples {
+			if body, err := sample.BodyMap(ctx); err == nil {
+				bodies = append(bodies, body)
+			}
+		}
+	}
+
+	if len(bodies) == 0 {
+		return nil, svcerrors.
+			NewUnprocessableContentError(errors.New("no samples found")).
+			WithUserMessage("No samples found, send samples or a request to the source.")
+	}
+
+	columns := infer.Columns(bodies, infer.Config{MaxDepth: payload.MaxDepth})
+	return s.mapper.NewInferredSinkMappingResponse(d.SourceKey(), len(bodies), columns), nil
+}
+
+// sampleToMap converts the decoded JSON object to the ordered map, keys are sorted, the original order is not known.
+func sampleToMap(sample any) (*orderedmap.OrderedMap, error) {
+	if _, ok := sample.(map[string]any); !ok {
+		return nil, errors.New("sample is not an object")
+	}
+
+	out := orderedmap.New()
+	if err := json.ConvertByJSON(sample, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
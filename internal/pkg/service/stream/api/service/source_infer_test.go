@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_sampleToMap(t *testing.T) {
+	t.Parallel()
+
+	out, err := sampleToMap(map[string]any{"b": 1, "a": map[string]any{"c": true}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, out.Keys())
+
+	_, err = sampleToMap([]any{1, 2})
+	require.Error(t, err)
+
+	_, err = sampleToMap("foo")
+	require.Error(t, err)
+}
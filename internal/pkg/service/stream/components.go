@@ -9,7 +9,9 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/api"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter/replay"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/httpsource"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/kafkasource"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/coordinator"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/readernode"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/writernode"
@@ -105,7 +107,15 @@ func StartComponents(ctx context.Context, serviceScp dependencies.ServiceScope,
 		if err != nil {
 			return err
 		}
-		if err := httpsource.Start(ctx, d, cfg.Source.HTTP); err != nil {
+		if err := httpsource.Start(ctx, d, cfg.Source.HTTP, cfg.Source.RateLimit); err != nil {
+			return err
+		}
+		// Kafka sources are consumed by the same source nodes, partitions are distributed between them
+		if err := kafkasource.Start(ctx, d, cfg.Source.Kafka); err != nil {
+			return err
+		}
+		// Replay of dead-letter records is performed by source nodes, records are written through the sink router
+		if err := replay.Start(ctx, d); err != nil {
 			return err
 		}
 	}
@@ -127,6 +127,52 @@ source:
         writeBufferSize: 4KB
         # Max size of the HTTP request body. Validation rules: required,minBytes=100B,maxBytes=4MB
         maxRequestBodySize: 1MB
+        # Maximum number of records of a batch request dispatched in parallel, parallel writes wait for the sync together. Validation rules: required,min=1,max=10000
+        batchParallelism: 1000
+    kafka:
+        # Client ID sent to Kafka brokers. Validation rules: required
+        clientId: keboola-stream
+        # Timeout of a connection to a Kafka broker. Validation rules: required,minDuration=1s,maxDuration=60s
+        dialTimeout: 10s
+        # How often topic partitions are listed and assigned to source nodes. Validation rules: required,minDuration=1s,maxDuration=30m
+        reconcileInterval: 30s
+        # Maximum number of records processed in one poll. Validation rules: required,min=1,max=100000
+        maxPollRecords: 1000
+        # Initial delay before a retry of a record which has not been written to a sink. Validation rules: required,minDuration=1ms,maxDuration=1m
+        retryInitialBackoff: 100ms
+        # Maximum delay before a retry of a record which has not been written to a sink. Validation rules: required,minDuration=1ms,maxDuration=30m,gtefield=RetryInitialBackoff
+        retryMaxBackoff: 30s
+        # Maximum number of retries of a record which has not been written to a sink. Then the record is stored to the dead-letter storage of the sink, if enabled, and skipped. Validation rules: min=0,max=1000
+        maxRetries: 20
+        # Maximum number of records of one poll dispatched in parallel, parallel writes wait for the sync together. Validation rules: required,min=1,max=100000
+        dispatchParallelism: 1000
+        # Delay before the offset commit of records accepted by a sink without waiting for the sync. It is longer than the maximum sync interval, so an accepted record is lost only if its sync fails. Validation rules: required,minDuration=31s,maxDuration=5m
+        acceptedCommitDelay: 35s
+    sample:
+        # Maximum number of request samples stored per source, the oldest samples are deleted. Zero disables the sampling. Validation rules: min=0,max=100
+        size: 0
+        # Minimal interval between two samples of a source captured by a node. Validation rules: required,minDuration=100ms,maxDuration=1h
+        interval: 10s
+        # Maximum size of a stored sample body, a longer body is truncated and the sample is ignored. Validation rules: required,minBytes=100B,maxBytes=1MB
+        maxBodySize: 64KB
+    rateLimit:
+        # Max records per second accepted by a source, 0 means unlimited. The limit is split evenly among source nodes. Validation rules: max=10000000
+        recordsPerSecond: 0
+        # Max records accepted by a source in a burst, 0 means the same as recordsPerSecond. The burst is split evenly among source nodes, a larger request is rejected. Validation rules: max=100000000
+        recordsBurst: 0
+        # Max bytes per second accepted by a source, 0 means unlimited. The limit is split evenly among source nodes. Validation rules: maxBytes=10GB
+        bytesPerSecond: 0B
+        # Max bytes accepted by a source in a burst, 0 means the same as bytesPerSecond. The burst is split evenly among source nodes, a larger request is rejected. Validation rules: maxBytes=100GB
+        bytesBurst: 0B
+        project:
+            # Max records per second accepted by all sources of a project, 0 means unlimited. The limit is split evenly among source nodes. Validation rules: max=10000000
+            recordsPerSecond: 0
+            # Max records accepted by all sources of a project in a burst, 0 means the same as recordsPerSecond. The burst is split evenly among source nodes, a larger request is rejected. Validation rules: max=100000000
+            recordsBurst: 0
+            # Max bytes per second accepted by all sources of a project, 0 means unlimited. The limit is split evenly among source nodes. Validation rules: maxBytes=10GB
+            bytesPerSecond: 0B
+            # Max bytes accepted by all sources of a project in a burst, 0 means the same as bytesPerSecond. The burst is split evenly among source nodes, a larger request is rejected. Validation rules: maxBytes=100GB
+            bytesBurst: 0B
 sink:
     table:
         keboola:
@@ -134,6 +180,41 @@ sink:
             eventSendTimeout: 30s
             # Number of import jobs running in keboola for single sink
             jobLimit: 2
+    webhook:
+        # Maximum number of records sent in one request. Validation rules: required,min=1,max=10000
+        batchSize: 100
+        # Maximum time a record waits for other records of the batch. Validation rules: required,minDuration=1ms,maxDuration=1m
+        flushInterval: 100ms
+        # Timeout of one request to the webhook endpoint. Validation rules: required,minDuration=100ms,maxDuration=5m
+        requestTimeout: 30s
+        # Maximum number of retries of a failed request, then records of the batch are stored to the dead-letter storage of the sink, if enabled. Validation rules: min=0,max=100
+        maxRetries: 3
+        # Initial delay before a retry of a failed request. Validation rules: required,minDuration=1ms,maxDuration=1m
+        retryInitialBackoff: 100ms
+        # Maximum delay before a retry of a failed request. Validation rules: required,minDuration=1ms,maxDuration=5m,gtefield=RetryInitialBackoff
+        retryMaxBackoff: 5s
+        # Maximum number of batches collected or sent at once by one sink, a record over the limit is rejected. Validation rules: required,min=1,max=10000
+        maxPendingBatches: 100
+        # Allow requests to private, loopback and link-local addresses. It should be enabled only for a local development.
+        allowPrivateTargets: false
+    deadLetter:
+        # Maximum number of dead-letter records stored per sink, the oldest records are deleted. Validation rules: required,min=1,max=10000
+        maxRecords: 100
+        # Maximum size of a stored record body, a longer body is truncated and the record cannot be replayed. Validation rules: required,minBytes=100B,maxBytes=1MB
+        maxBodySize: 64KB
+        # Maximum number of records waiting to be saved to the database, records over the limit are not stored. Validation rules: required,min=1,max=100000
+        queueSize: 1000
+    deduplication:
+        # Records with the same key received within the window are written only once, 0 disables deduplication. Validation rules: maxDuration=24h
+        window: 0s
+        # Deduplication key: "header" or "primaryKey" of the table mapping. Validation rules: required,oneof=header primaryKey
+        key: header
+        # Name of the header used as the deduplication key, if the key is "header". Validation rules: required
+        header: Idempotency-Key
+        # Max time a duplicate waits for the result of the original record, which is still being written. Validation rules: required,minDuration=1s,maxDuration=5m
+        inFlightTimeout: 30s
+        # Maximum number of keys in the window of a sink per source node, the oldest keys are evicted. Validation rules: required,min=1,max=10000000
+        maxKeys: 100000
 storage:
     # Mounted volumes path, each volume is in "{type}/{label}" subdir. Validation rules: required
     volumesPath: ""
@@ -162,6 +243,8 @@ storage:
         activeFileExpiration: 168h0m0s
         # Expiration interval of a file that has already been imported. Validation rules: required,minDuration=15m,maxDuration=720h
         archivedFileExpiration: 1h0m0s
+        # How long is an imported file and its staging file kept for a re-import, 0 = disabled. Validation rules: maxDuration=720h
+        importedFileRetention: 0s
         # Cleanup interval of a file. Validation rules: required,minDuration=30s,maxDuration=24h
         interval: 10m0s
     jobCleanup:
@@ -194,7 +277,7 @@ storage:
                     ttlSeconds: 10
             encoding:
                 encoder:
-                    # Encoder type. Validation rules: required,oneof=csv
+                    # Encoder type. Validation rules: required,oneof=csv parquet ndjson
                     type: csv
                     # Concurrency of the format writer for the specified file type. 0 = auto = num of CPU cores. Validation rules: min=0,max=256
                     concurrency: 0
@@ -207,7 +290,7 @@ storage:
                 # If the defined number of chunks cannot be sent, the pipeline is marked as not ready. Validation rules: required,min=1,max=100
                 failedChunksThreshold: 3
                 compression:
-                    # Compression type. Validation rules: required,oneof=none gzip
+                    # Compression type. Validation rules: required,oneof=none gzip zstd
                     type: gzip
                     gzip:
                         # GZIP compression level: 1-9. Validation rules: min=1,max=9
@@ -259,7 +342,7 @@ storage:
                     kcpInputBuffer: 8MB
                     # Buffer size for transferring responses between writer and source node (kcp). Validation rules: required,minBytes=16kB,maxBytes=100MB
                     kcpResponseBuffer: 512KB
-                    # Pipeline balancer type which balances the writing on particular nodes based on selected strategy. Validation rules: required,oneof=rand roundRobin
+                    # Pipeline balancer type which balances the writing on particular nodes based on selected strategy. Validation rules: required,oneof=rand roundRobin leastLoaded
                     pipelineBalancer: roundRobin
                 allocation:
                     # Allocate disk space for each slice. Useless for SSD.
@@ -290,6 +373,33 @@ storage:
                     size: 5MB
                     # Duration from the last slice upload to trigger the next upload. Validation rules: required,minDuration=1s,maxDuration=30m
                     interval: 30s
+            # Compression type of slices in the staging storage, empty means the same as in the local storage. Slices are transcoded on upload, if the types differ. Validation rules: omitempty,oneof=none gzip zstd
+            compression: ""
+            provider:
+                # How long it is possible to write to a staging file of the provider. Validation rules: required,minDuration=1m,maxDuration=168h
+                expiration: 24h0m0s
+                s3:
+                    # Enable the "s3" staging provider for file sinks.
+                    enabled: false
+                    # Endpoint of an S3-compatible API, for example MinIO. Empty means AWS S3.
+                    endpoint: ""
+                    # Region of the bucket. Validation rules: required_if=Enabled true
+                    region: ""
+                    # Name of the bucket. Validation rules: required_if=Enabled true
+                    bucket: ""
+                    # Prefix of all objects in the bucket.
+                    prefix: ""
+                    # Access key ID. Empty means the default AWS credentials chain.
+                    accessKeyId: ""
+                    # Secret access key.
+                    secretAccessKey: '*****'
+                    # Use path-style addressing, it is required by MinIO.
+                    forcePathStyle: false
+                local:
+                    # Enable the "local" staging provider for file sinks.
+                    enabled: false
+                    # Absolute path to the directory with staging files. Validation rules: required_if=Enabled true
+                    path: ""
         target:
             operator:
                 # Import triggers check interval. Validation rules: required,minDuration=100ms,maxDuration=30s
@@ -318,6 +428,12 @@ storage:
                     slicesCount: 100
                     # Min remaining expiration to trigger file import. Validation rules: required,minDuration=5m,maxDuration=45m
                     expiration: 30m0s
+            provider:
+                # Target provider of files of file sinks: empty to keep files in the staging bucket or "localfs". It requires an enabled staging provider. Validation rules: omitempty,oneof=localfs
+                type: ""
+                localfs:
+                    # Absolute path to the directory with imported files.
+                    path: ""
 encryption:
     # Encryption provider. Validation rules: required,oneof=none aes gcp aws azure
     provider: none
@@ -375,6 +491,76 @@ func TestTableSinkConfigPatch_ToKVs(t *testing.T) {
 
 	assert.JSONEq(t, strings.TrimSpace(`
 [
+  {
+    "key": "sink.deduplication.header",
+    "type": "string",
+    "description": "Name of the header used as the deduplication key, if the key is \"header\".",
+    "value": "Idempotency-Key",
+    "defaultValue": "Idempotency-Key",
+    "overwritten": false,
+    "protected": false,
+    "validation": "required"
+  },
+  {
+    "key": "sink.deduplication.key",
+    "type": "string",
+    "description": "Deduplication key: \"header\" or \"primaryKey\" of the table mapping.",
+    "value": "header",
+    "defaultValue": "header",
+    "overwritten": false,
+    "protected": false,
+    "validation": "required,oneof=header primaryKey"
+  },
+  {
+    "key": "sink.deduplication.window",
+    "type": "string",
+    "description": "Records with the same key received within the window are written only once, 0 disables deduplication.",
+    "value": "0s",
+    "defaultValue": "0s",
+    "overwritten": false,
+    "protected": false,
+    "validation": "maxDuration=24h"
+  },
+  {
+    "key": "source.rateLimit.bytesBurst",
+    "type": "string",
+    "description": "Max bytes accepted by a source in a burst, 0 means the same as bytesPerSecond. The burst is split evenly among source nodes, a larger request is rejected.",
+    "value": "0B",
+    "defaultValue": "0B",
+    "overwritten": false,
+    "protected": true,
+    "validation": "maxBytes=100GB"
+  },
+  {
+    "key": "source.rateLimit.bytesPerSecond",
+    "type": "string",
+    "description": "Max bytes per second accepted by a source, 0 means unlimited. The limit is split evenly among source nodes.",
+    "value": "0B",
+    "defaultValue": "0B",
+    "overwritten": false,
+    "protected": true,
+    "validation": "maxBytes=10GB"
+  },
+  {
+    "key": "source.rateLimit.recordsBurst",
+    "type": "uint64",
+    "description": "Max records accepted by a source in a burst, 0 means the same as recordsPerSecond. The burst is split evenly among source nodes, a larger request is rejected.",
+    "value": 0,
+    "defaultValue": 0,
+    "overwritten": false,
+    "protected": true,
+    "validation": "max=100000000"
+  },
+  {
+    "key": "source.rateLimit.recordsPerSecond",
+    "type": "uint64",
+    "description": "Max records per second accepted by a source, 0 means unlimited. The limit is split evenly among source nodes.",
+    "value": 0,
+    "defaultValue": 0,
+    "overwritten": false,
+    "protected": true,
+    "validation": "max=10000000"
+  },
   {
     "key": "storage.level.local.encoding.compression.gzip.blockSize",
     "type": "string",
@@ -422,7 +608,7 @@ func TestTableSinkConfigPatch_ToKVs(t *testing.T) {
     "defaultValue": "gzip",
     "overwritten": false,
     "protected": true,
-    "validation": "required,oneof=none gzip"
+    "validation": "required,oneof=none gzip zstd"
   },
   {
     "key": "storage.level.local.encoding.encoder.concurrency",
@@ -442,7 +628,7 @@ func TestTableSinkConfigPatch_ToKVs(t *testing.T) {
     "defaultValue": "csv",
     "overwritten": false,
     "protected": true,
-    "validation": "required,oneof=csv"
+    "validation": "required,oneof=csv parquet ndjson"
   },
   {
     "key": "storage.level.local.encoding.failedChunksThreshold",
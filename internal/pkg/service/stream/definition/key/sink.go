@@ -1,11 +1,20 @@
 package key
 
 import (
+	"strings"
+
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
+const (
+	mirrorSinkIDSuffix = ".mirror"
+	// MirrorSinkIDMaxLength is the maximum length of the SinkID of a sink with a mirror,
+	// so the SinkID of the mirror doesn't exceed the maximum length.
+	MirrorSinkIDMaxLength = 48 - len(mirrorSinkIDSuffix)
+)
+
 type SinkID string
 
 type SinkKey struct {
@@ -29,3 +38,21 @@ func (v SinkKey) Telemetry() []attribute.KeyValue {
 	t = append(t, attribute.String("sink.id", v.SinkID.String()))
 	return t
 }
+
+// Mirror returns key of the mirror of the sink, see the definition.TableMirror.
+// The suffix cannot collide with a user defined SinkID, the dot is not allowed in it.
+func (v SinkKey) Mirror() SinkKey {
+	v.SinkID += mirrorSinkIDSuffix
+	return v
+}
+
+// IsMirror returns true, if the key is a key of a sink mirror, see the Mirror method.
+func (v SinkKey) IsMirror() bool {
+	return strings.HasSuffix(string(v.SinkID), mirrorSinkIDSuffix)
+}
+
+// Owner returns key of the sink which owns the mirror, or the key itself, if it is not a mirror key.
+func (v SinkKey) Owner() SinkKey {
+	v.SinkID = SinkID(strings.TrimSuffix(string(v.SinkID), mirrorSinkIDSuffix))
+	return v
+}
@@ -23,9 +23,18 @@ type Sink struct {
 	// Valid values: "logs", "metrics", "traces".
 	AllowedSignals []string `json:"allowedSignals,omitempty" validate:"dive,oneof=logs metrics traces"`
 
+	// Filter is an optional Jsonnet expression evaluated for each record, it must return a boolean.
+	// Records for which the expression returns false are dropped, see the mapping/filter package.
+	Filter string `json:"filter,omitempty" validate:"max=4096"`
+
+	// DeadLetter enables storing of records which cannot be mapped by the sink, see the sink/deadletter package.
+	DeadLetter bool `json:"deadLetter,omitempty"`
+
 	// Sink type specific fields
 
-	Table *TableSink `json:"table,omitempty" validate:"required_if=Type table"`
+	Table   *TableSink   `json:"table,omitempty" validate:"required_if=Type table"`
+	Webhook *WebhookSink `json:"webhook,omitempty" validate:"required_if=Type webhook"`
+	File    *FileSink    `json:"file,omitempty" validate:"required_if=Type file"`
 }
 
 func (t SinkType) String() string {
@@ -0,0 +1,30 @@
+package definition
+
+import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+)
+
+const (
+	SinkTypeFile = SinkType("file")
+)
+
+const (
+	StagingProviderS3    = StagingProvider("s3")
+	StagingProviderLocal = StagingProvider("local")
+)
+
+type StagingProvider string
+
+// FileSink stores mapped records to files in a bucket of a staging provider, independent of Keboola Storage.
+//
+// The provider must be enabled in the configuration of the service, see the storage/level/staging/provider package.
+// Files are kept in the bucket, or merged to the local filesystem, if the localfs target provider is configured.
+// The file format is configured by the encoder in the sink config patch, for example CSV, NDJSON or Parquet.
+type FileSink struct {
+	Provider StagingProvider `json:"provider" validate:"required,oneof=s3 local"`
+	Mapping  table.Mapping   `json:"mapping"`
+}
+
+func (p StagingProvider) String() string {
+	return string(p)
+}
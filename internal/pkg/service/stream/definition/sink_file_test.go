@@ -0,0 +1,79 @@
+package definition_test
+
+import (
+	"testing"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/testvalidation"
+)
+
+func TestFileSink_Validation(t *testing.T) {
+	t.Parallel()
+
+	sinkKey := key.SinkKey{
+		SourceKey: key.SourceKey{
+			BranchKey: key.BranchKey{
+				ProjectID: 123,
+				BranchID:  456,
+			},
+			SourceID: "my-source",
+		},
+		SinkID: "my-sink",
+	}
+
+	newSink := func(file *definition.FileSink) definition.Sink {
+		return definition.Sink{
+			SinkKey:       sinkKey,
+			Created:       test.Created(),
+			Versioned:     test.Versioned(),
+			SoftDeletable: test.SoftDeletable(),
+			Type:          definition.SinkTypeFile,
+			Name:          "My Sink",
+			Description:   "My Description",
+			File:          file,
+		}
+	}
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Body{Name: "body"},
+		},
+	}
+
+	// Test cases
+	cases := testvalidation.TestCases[definition.Sink]{
+		{
+			Name:          "nil file section",
+			ExpectedError: `"file" is a required field`,
+			Value:         newSink(nil),
+		},
+		{
+			Name: "empty file section",
+			ExpectedError: `
+- "file.provider" is a required field
+- "file.mapping.columns" is a required field
+`,
+			Value: newSink(&definition.FileSink{}),
+		},
+		{
+			Name:          "invalid provider",
+			ExpectedError: `"file.provider" must be one of [s3 local]`,
+			Value:         newSink(&definition.FileSink{Provider: "foo", Mapping: mapping}),
+		},
+		{
+			Name:  "s3",
+			Value: newSink(&definition.FileSink{Provider: definition.StagingProviderS3, Mapping: mapping}),
+		},
+		{
+			Name:  "local",
+			Value: newSink(&definition.FileSink{Provider: definition.StagingProviderLocal, Mapping: mapping}),
+		},
+	}
+
+	// Run test cases
+	cases.Run(t)
+}
@@ -1,6 +1,8 @@
 package definition
 
 import (
+	"time"
+
 	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
@@ -11,20 +13,105 @@ const (
 	TableTypeKeboola = TableType("keboola")
 )
 
+const (
+	PartitionGranularityDaily   = PartitionGranularity("daily")
+	PartitionGranularityMonthly = PartitionGranularity("monthly")
+)
+
 type TableType string
 
+type PartitionGranularity string
+
 // TableSink configures destination table.
 type TableSink struct {
 	Type    TableType     `json:"type" validate:"required,oneof=keboola"`
 	Keboola *KeboolaTable `json:"keboola" validate:"required_if=Type keboola"`
 
 	Mapping table.Mapping `json:"mapping"`
+
+	// Partitioning optionally splits records to time-based partition tables, see TablePartitioning.
+	Partitioning *TablePartitioning `json:"partitioning,omitempty"`
+
+	// Mirror optionally writes each record to a secondary destination table, see TableMirror.
+	Mirror *TableMirror `json:"mirror,omitempty"`
 }
 
 type KeboolaTable struct {
 	TableID keboola.TableID `json:"tableId" validate:"required"`
 }
 
+// TablePartitioning splits records to partition tables by the time of the Column.
+// The Column must be a datetime column of the mapping, or a template column returning a time.
+// Each file contains records of one partition and is rotated at the end of the partition,
+// each record is routed to a file by its Column value, see the storage/level/target/model.Partition.
+type TablePartitioning struct {
+	Granularity PartitionGranularity `json:"granularity" validate:"required,oneof=daily monthly"`
+	Column      string               `json:"column" validate:"required"`
+}
+
+// TableMirror is a secondary destination table of the TableSink.
+// Each record accepted by the sink is written also to the mirror table, within the same sink router pipeline.
+// Files of the mirror are rotated and imported independently of the sink files, see the Sink.MirrorSink method.
+type TableMirror struct {
+	Type    TableType     `json:"type" validate:"required,oneof=keboola"`
+	Keboola *KeboolaTable `json:"keboola" validate:"required_if=Type keboola"`
+	// Mapping of the mirror table, the mapping of the sink is used, if it is not set.
+	Mapping *table.Mapping `json:"mapping,omitempty"`
+}
+
+// TablePartition is a time range of the TablePartitioning.
+type TablePartition struct {
+	// ID is used as the table name suffix, for example "20240102" for the daily or "202401" for the monthly granularity.
+	ID    string
+	Start time.Time
+	End   time.Time
+}
+
 func (t TableType) String() string {
 	return string(t)
 }
+
+func (g PartitionGranularity) String() string {
+	return string(g)
+}
+
+// Partition returns the partition containing the time t, in UTC.
+func (p TablePartitioning) Partition(t time.Time) TablePartition {
+	t = t.UTC()
+	switch p.Granularity {
+	case PartitionGranularityMonthly:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return TablePartition{ID: start.Format("200601"), Start: start, End: start.AddDate(0, 1, 0)}
+	default:
+		start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return TablePartition{ID: start.Format("20060102"), Start: start, End: start.AddDate(0, 0, 1)}
+	}
+}
+
+// TableID returns ID of the partition table, the partition ID is appended to the table name.
+func (p TablePartition) TableID(base keboola.TableID) keboola.TableID {
+	base.TableName += "_" + p.ID
+	return base
+}
+
+// MirrorSink returns the mirror of the table sink as a derived Sink entity, or nil, if the sink has no mirror.
+// The derived entity is not stored in the database, it only has a different key, see key.SinkKey.Mirror,
+// a different target table and mapping, so files of the mirror are handled in the same way as files of the sink.
+func (s *Sink) MirrorSink() *Sink {
+	if s.Table == nil || s.Table.Mirror == nil {
+		return nil
+	}
+
+	mirror := *s
+	mirror.SinkKey = s.SinkKey.Mirror()
+	mirror.Table = &TableSink{
+		Type:    s.Table.Mirror.Type,
+		Keboola: s.Table.Mirror.Keboola,
+		Mapping: s.Table.Mapping,
+	}
+	if s.Table.Mirror.Mapping != nil {
+		mirror.Table.Mapping = *s.Table.Mirror.Mapping
+	}
+
+	return &mirror
+}
@@ -3,8 +3,10 @@ package definition_test
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
@@ -172,8 +174,154 @@ func TestTableSink_Validation(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:          "invalid partitioning",
+			ExpectedError: `"table.partitioning.granularity" must be one of [daily monthly]`,
+			Value: definition.Sink{
+				SinkKey:       sinkKey,
+				Created:       test.Created(),
+				Versioned:     test.Versioned(),
+				SoftDeletable: test.SoftDeletable(),
+				Type:          definition.SinkTypeTable,
+				Name:          "My Source",
+				Description:   "My Description",
+				Table: &definition.TableSink{
+					Type:    definition.TableTypeKeboola,
+					Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.bucket.table")},
+					Mapping: table.Mapping{
+						Columns: column.Columns{
+							column.Datetime{Name: "datetime"},
+						},
+					},
+					Partitioning: &definition.TablePartitioning{Granularity: "hourly", Column: "datetime"},
+				},
+			},
+		},
+		{
+			Name: "with partitioning",
+			Value: definition.Sink{
+				SinkKey:       sinkKey,
+				Created:       test.Created(),
+				Versioned:     test.Versioned(),
+				SoftDeletable: test.SoftDeletable(),
+				Type:          definition.SinkTypeTable,
+				Name:          "My Source",
+				Description:   "My Description",
+				Table: &definition.TableSink{
+					Type:    definition.TableTypeKeboola,
+					Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.bucket.table")},
+					Mapping: table.Mapping{
+						Columns: column.Columns{
+							column.Datetime{Name: "datetime"},
+						},
+					},
+					Partitioning: &definition.TablePartitioning{Granularity: definition.PartitionGranularityDaily, Column: "datetime"},
+				},
+			},
+		},
+		{
+			Name:          "invalid mirror",
+			ExpectedError: `"table.mirror.keboola" is a required field`,
+			Value: definition.Sink{
+				SinkKey:       sinkKey,
+				Created:       test.Created(),
+				Versioned:     test.Versioned(),
+				SoftDeletable: test.SoftDeletable(),
+				Type:          definition.SinkTypeTable,
+				Name:          "My Source",
+				Description:   "My Description",
+				Table: &definition.TableSink{
+					Type:    definition.TableTypeKeboola,
+					Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.bucket.table")},
+					Mapping: table.Mapping{
+						Columns: column.Columns{
+							column.Body{Name: "body"},
+						},
+					},
+					Mirror: &definition.TableMirror{Type: definition.TableTypeKeboola},
+				},
+			},
+		},
+		{
+			Name: "with mirror",
+			Value: definition.Sink{
+				SinkKey:       sinkKey,
+				Created:       test.Created(),
+				Versioned:     test.Versioned(),
+				SoftDeletable: test.SoftDeletable(),
+				Type:          definition.SinkTypeTable,
+				Name:          "My Source",
+				Description:   "My Description",
+				Table: &definition.TableSink{
+					Type:    definition.TableTypeKeboola,
+					Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.bucket.table")},
+					Mapping: table.Mapping{
+						Columns: column.Columns{
+							column.Body{Name: "body"},
+						},
+					},
+					Mirror: &definition.TableMirror{
+						Type:    definition.TableTypeKeboola,
+						Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.archive.table")},
+					},
+				},
+			},
+		},
 	}
 
 	// Run test cases
 	cases.Run(t)
 }
+
+func TestTablePartitioning_Partition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 12, 31, 23, 59, 59, 0, time.FixedZone("CET", 3600))
+	tableID := keboola.MustParseTableID("in.bucket.table")
+
+	daily := definition.TablePartitioning{Granularity: definition.PartitionGranularityDaily}.Partition(now)
+	assert.Equal(t, "20241231", daily.ID)
+	assert.Equal(t, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), daily.Start)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), daily.End)
+	assert.Equal(t, "in.bucket.table_20241231", daily.TableID(tableID).String())
+
+	monthly := definition.TablePartitioning{Granularity: definition.PartitionGranularityMonthly}.Partition(now)
+	assert.Equal(t, "202412", monthly.ID)
+	assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), monthly.Start)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), monthly.End)
+	assert.Equal(t, "in.bucket.table_202412", monthly.TableID(tableID).String())
+}
+
+func TestSink_MirrorSink(t *testing.T) {
+	t.Parallel()
+
+	sinkKey := key.SinkKey{
+		SourceKey: key.SourceKey{BranchKey: key.BranchKey{ProjectID: 123, BranchID: 456}, SourceID: "my-source"},
+		SinkID:    "my-sink",
+	}
+	mapping := table.Mapping{Columns: column.Columns{column.Datetime{Name: "datetime"}, column.Body{Name: "body"}}}
+	sink := test.NewKeboolaTableSink(sinkKey)
+	sink.Table.Mapping = mapping
+	sink.Table.Partitioning = &definition.TablePartitioning{Granularity: definition.PartitionGranularityDaily, Column: "datetime"}
+
+	// No mirror
+	assert.Nil(t, sink.MirrorSink())
+
+	// Mirror with the sink mapping
+	mirrorTable := &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.archive.table")}
+	sink.Table.Mirror = &definition.TableMirror{Type: definition.TableTypeKeboola, Keboola: mirrorTable}
+	mirror := sink.MirrorSink()
+	assert.Equal(t, key.SinkID("my-sink.mirror"), mirror.SinkID)
+	assert.True(t, mirror.SinkKey.IsMirror())
+	assert.False(t, sink.SinkKey.IsMirror())
+	assert.Equal(t, sink.SinkKey, mirror.SinkKey.Owner())
+	assert.Equal(t, sink.SinkKey, sink.SinkKey.Owner())
+	assert.Equal(t, sink.Versioned, mirror.Versioned)
+	assert.Equal(t, &definition.TableSink{Type: definition.TableTypeKeboola, Keboola: mirrorTable, Mapping: mapping}, mirror.Table)
+
+	// Mirror with an own mapping
+	mirrorMapping := table.Mapping{Columns: column.Columns{column.Body{Name: "raw"}}}
+	sink.Table.Mirror.Mapping = &mirrorMapping
+	assert.Equal(t, mirrorMapping, sink.MirrorSink().Table.Mapping)
+	assert.Equal(t, mapping, sink.Table.Mapping)
+}
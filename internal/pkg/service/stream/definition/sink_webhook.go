@@ -0,0 +1,21 @@
+package definition
+
+import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+)
+
+const (
+	SinkTypeWebhook = SinkType("webhook")
+)
+
+// WebhookSink forwards mapped records to an HTTP endpoint.
+//
+// Records are sent in batches, the request body is a JSON array, each item is a JSON object with the Mapping columns.
+// Headers are rendered for each record, from the Headers columns, the column name is the header name.
+// Records with different rendered headers are sent in separate requests.
+type WebhookSink struct {
+	URL     string         `json:"url" validate:"required,http_url,max=2048"`
+	Headers column.Columns `json:"headers,omitempty" validate:"max=20,dive"`
+	Mapping table.Mapping  `json:"mapping"`
+}
@@ -0,0 +1,100 @@
+package definition_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/testvalidation"
+)
+
+func TestWebhookSink_Validation(t *testing.T) {
+	t.Parallel()
+
+	sinkKey := key.SinkKey{
+		SourceKey: key.SourceKey{
+			BranchKey: key.BranchKey{
+				ProjectID: 123,
+				BranchID:  456,
+			},
+			SourceID: "my-source",
+		},
+		SinkID: "my-sink",
+	}
+
+	newSink := func(webhook *definition.WebhookSink) definition.Sink {
+		return definition.Sink{
+			SinkKey:       sinkKey,
+			Created:       test.Created(),
+			Versioned:     test.Versioned(),
+			SoftDeletable: test.SoftDeletable(),
+			Type:          definition.SinkTypeWebhook,
+			Name:          "My Sink",
+			Description:   "My Description",
+			Webhook:       webhook,
+		}
+	}
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Body{Name: "body"},
+		},
+	}
+
+	// Test cases
+	cases := testvalidation.TestCases[definition.Sink]{
+		{
+			Name:          "nil webhook section",
+			ExpectedError: `"webhook" is a required field`,
+			Value:         newSink(nil),
+		},
+		{
+			Name: "empty webhook section",
+			ExpectedError: `
+- "webhook.url" is a required field
+- "webhook.mapping.columns" is a required field
+`,
+			Value: newSink(&definition.WebhookSink{}),
+		},
+		{
+			Name:          "invalid url",
+			ExpectedError: `"webhook.url" is invalid`,
+			Value:         newSink(&definition.WebhookSink{URL: "ftp://example.com", Mapping: mapping}),
+		},
+		{
+			Name:          "long url",
+			ExpectedError: `"webhook.url" must be a maximum of 2,048 characters in length`,
+			Value:         newSink(&definition.WebhookSink{URL: "https://example.com/" + strings.Repeat("a", 2048), Mapping: mapping}),
+		},
+		{
+			Name:          "invalid header column",
+			ExpectedError: `"webhook.headers[0].name" is a required field`,
+			Value: newSink(&definition.WebhookSink{
+				URL:     "https://example.com/webhook",
+				Headers: column.Columns{column.Datetime{}},
+				Mapping: mapping,
+			}),
+		},
+		{
+			Name:  "minimal",
+			Value: newSink(&definition.WebhookSink{URL: "https://example.com/webhook", Mapping: mapping}),
+		},
+		{
+			Name: "with headers",
+			Value: newSink(&definition.WebhookSink{
+				URL: "https://example.com/webhook",
+				Headers: column.Columns{
+					column.Template{Name: "X-Tenant", Template: column.TemplateConfig{Language: column.TemplateLanguageJsonnet, Content: `Body("tenant")`}},
+				},
+				Mapping: mapping,
+			}),
+		},
+	}
+
+	// Run test cases
+	cases.Run(t)
+}
@@ -22,8 +22,9 @@ type Source struct {
 
 	// Source type specific fields
 
-	HTTP *HTTPSource `json:"http,omitempty" validate:"required_if=Type http"`
-	OTLP *OTLPSource `json:"otlp,omitempty" validate:"required_if=Type otlp"`
+	HTTP  *HTTPSource  `json:"http,omitempty" validate:"required_if=Type http"`
+	OTLP  *OTLPSource  `json:"otlp,omitempty" validate:"required_if=Type otlp"`
+	Kafka *KafkaSource `json:"kafka,omitempty" validate:"required_if=Type kafka"`
 }
 
 func (s *Source) FormatHTTPSourceURL(httpSourcePublicURL string) (string, error) {
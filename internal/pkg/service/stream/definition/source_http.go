@@ -1,13 +1,60 @@
 package definition
 
+import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+)
+
 const (
 	SourceTypeHTTP = SourceType("http")
 )
 
+const (
+	SignatureAlgorithmHMACSHA1   = SignatureAlgorithm("hmac-sha1")
+	SignatureAlgorithmHMACSHA256 = SignatureAlgorithm("hmac-sha256")
+	SignatureAlgorithmHMACSHA512 = SignatureAlgorithm("hmac-sha512")
+)
+
+const (
+	// SignatureFormatHex - the header contains hex encoded HMAC of the body, with an optional "<algorithm>=" prefix, for example GitHub.
+	SignatureFormatHex = SignatureFormat("hex")
+	// SignatureFormatStripe - the header contains "t=<timestamp>,v1=<signature>", the signed payload is "<timestamp>.<body>".
+	SignatureFormatStripe = SignatureFormat("stripe")
+	// SignatureFormatSlack - the header contains "v0=<signature>", the signed payload is "v0:<timestamp>:<body>",
+	// the timestamp is read from the X-Slack-Request-Timestamp header.
+	SignatureFormatSlack = SignatureFormat("slack")
+)
+
+type SignatureAlgorithm string
+
+type SignatureFormat string
+
 type HTTPSource struct {
-	Secret string `json:"secret" validate:"required,len=48"`
+	Secret    string               `json:"secret" validate:"required,len=48"`
+	Signature *HTTPSourceSignature `json:"signature,omitempty"`
+}
+
+// HTTPSourceSignature configures an optional verification of the webhook signature.
+// The signature is verified in addition to the secret in the URL.
+type HTTPSourceSignature struct {
+	Algorithm SignatureAlgorithm `json:"algorithm" validate:"required,oneof=hmac-sha1 hmac-sha256 hmac-sha512"`
+	Format    SignatureFormat    `json:"format" validate:"required,oneof=hex stripe slack"`
+	Header    string             `json:"header" validate:"required,min=1,max=100"`
+	Secret    string             `json:"secret" validate:"required,min=1,max=256"`
+	// TimestampTolerance is the maximum difference between the signed timestamp and the current time, 0 means no limit.
+	// The hex format doesn't contain a timestamp, so the tolerance cannot be set.
+	TimestampTolerance duration.Duration `json:"timestampTolerance,omitempty" validate:"excluded_if=Format hex,maxDuration=24h"`
+	// ReplayWindow defines how long a received signature is remembered and a request with the same signature is rejected, 0 disables the check.
+	ReplayWindow duration.Duration `json:"replayWindow,omitempty" validate:"maxDuration=24h"`
 }
 
 func (v SourceType) String() string {
 	return string(v)
 }
+
+func (v SignatureAlgorithm) String() string {
+	return string(v)
+}
+
+func (v SignatureFormat) String() string {
+	return string(v)
+}
@@ -3,7 +3,9 @@ package definition_test
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
@@ -132,3 +134,84 @@ func TestHTTPSource_Validation(t *testing.T) {
 	// Run test cases
 	cases.Run(t)
 }
+
+func TestHTTPSourceSignature_Validation(t *testing.T) {
+	t.Parallel()
+
+	sourceKey := key.SourceKey{
+		BranchKey: key.BranchKey{ProjectID: 123, BranchID: 456},
+		SourceID:  "my-source",
+	}
+
+	newSource := func(signature *definition.HTTPSourceSignature) definition.Source {
+		return definition.Source{
+			SourceKey:     sourceKey,
+			Created:       test.Created(),
+			Versioned:     test.Versioned(),
+			SoftDeletable: test.SoftDeletable(),
+			Type:          definition.SourceTypeHTTP,
+			Name:          "My Source",
+			Description:   "My Description",
+			HTTP: &definition.HTTPSource{
+				Secret:    strings.Repeat("1", 48),
+				Signature: signature,
+			},
+		}
+	}
+
+	cases := testvalidation.TestCases[definition.Source]{
+		{
+			Name:  "no signature",
+			Value: newSource(nil),
+		},
+		{
+			Name: "empty signature",
+			ExpectedError: `
+- "http.signature.algorithm" is a required field
+- "http.signature.format" is a required field
+- "http.signature.header" is a required field
+- "http.signature.secret" is a required field
+`,
+			Value: newSource(&definition.HTTPSourceSignature{}),
+		},
+		{
+			Name: "invalid values",
+			ExpectedError: `
+- "http.signature.algorithm" must be one of [hmac-sha1 hmac-sha256 hmac-sha512]
+- "http.signature.format" must be one of [hex stripe slack]
+- "http.signature.replayWindow" must be 24h0m0s or less
+`,
+			Value: newSource(&definition.HTTPSourceSignature{
+				Algorithm:    "md5",
+				Format:       "foo",
+				Header:       "X-Signature",
+				Secret:       "my-secret",
+				ReplayWindow: duration.From(48 * time.Hour),
+			}),
+		},
+		{
+			Name:          "timestamp tolerance with hex format",
+			ExpectedError: `"http.signature.timestampTolerance" should not be set`,
+			Value: newSource(&definition.HTTPSourceSignature{
+				Algorithm:          definition.SignatureAlgorithmHMACSHA256,
+				Format:             definition.SignatureFormatHex,
+				Header:             "X-Hub-Signature-256",
+				Secret:             "my-secret",
+				TimestampTolerance: duration.From(5 * time.Minute),
+			}),
+		},
+		{
+			Name: "ok",
+			Value: newSource(&definition.HTTPSourceSignature{
+				Algorithm:          definition.SignatureAlgorithmHMACSHA256,
+				Format:             definition.SignatureFormatStripe,
+				Header:             "Stripe-Signature",
+				Secret:             "my-secret",
+				TimestampTolerance: duration.From(5 * time.Minute),
+				ReplayWindow:       duration.From(10 * time.Minute),
+			}),
+		},
+	}
+
+	cases.Run(t)
+}
@@ -0,0 +1,13 @@
+package definition
+
+const (
+	SourceTypeKafka = SourceType("kafka")
+)
+
+// KafkaSource consumes records from a topic of a Kafka-protocol compatible broker.
+// Consumed offsets are committed to the consumer group GroupID.
+type KafkaSource struct {
+	Brokers []string `json:"brokers" validate:"required,min=1,max=20,dive,hostname_port"`
+	Topic   string   `json:"topic" validate:"required,min=1,max=249"`
+	GroupID string   `json:"groupId" validate:"required,min=1,max=255"`
+}
@@ -0,0 +1,68 @@
+package definition_test
+
+import (
+	"testing"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/testvalidation"
+)
+
+func TestKafkaSource_Validation(t *testing.T) {
+	t.Parallel()
+
+	sourceKey := key.SourceKey{
+		BranchKey: key.BranchKey{ProjectID: 123, BranchID: 456},
+		SourceID:  "my-source",
+	}
+
+	newSource := func(kafka *definition.KafkaSource) definition.Source {
+		return definition.Source{
+			SourceKey:     sourceKey,
+			Created:       test.Created(),
+			Versioned:     test.Versioned(),
+			SoftDeletable: test.SoftDeletable(),
+			Type:          definition.SourceTypeKafka,
+			Name:          "My Source",
+			Description:   "My Description",
+			Kafka:         kafka,
+		}
+	}
+
+	cases := testvalidation.TestCases[definition.Source]{
+		{
+			Name:          "nil Kafka section",
+			ExpectedError: `"kafka" is a required field`,
+			Value:         newSource(nil),
+		},
+		{
+			Name: "empty Kafka section",
+			ExpectedError: `
+- "kafka.brokers" is a required field
+- "kafka.topic" is a required field
+- "kafka.groupId" is a required field
+`,
+			Value: newSource(&definition.KafkaSource{}),
+		},
+		{
+			Name:          "invalid broker",
+			ExpectedError: `"kafka.brokers[0]" is invalid`,
+			Value: newSource(&definition.KafkaSource{
+				Brokers: []string{"foo"},
+				Topic:   "my-topic",
+				GroupID: "my-group",
+			}),
+		},
+		{
+			Name: "ok",
+			Value: newSource(&definition.KafkaSource{
+				Brokers: []string{"kafka-1:9092", "kafka-2:9092"},
+				Topic:   "my-topic",
+				GroupID: "my-group",
+			}),
+		},
+	}
+
+	cases.Run(t)
+}
@@ -51,14 +51,18 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	deadLetterRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter/repository"
 	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
 	keboolaSinkBridge "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola/bridge"
 	keboolaBridgeRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola/bridge/model/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/webhooksink"
+	sampleRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskreader"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/connection"
 	storageRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/router"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding"
+	stagingProvider "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/provider"
 	storageRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics/cache"
 	statsRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics/repository"
@@ -87,8 +91,12 @@ type ServiceScope interface {
 	StorageRepository() *storageRepo.Repository
 	StatisticsRepository() *statsRepo.Repository
 	AggregationRepository() *aggregationRepo.Repository
+	DeadLetterRepository() *deadLetterRepo.Repository
+	SampleRepository() *sampleRepo.Repository
 	KeboolaSinkBridge() *keboolaSinkBridge.Bridge
 	KeboolaBridgeRepository() *keboolaBridgeRepo.Repository
+	// StagingProviders returns all enabled staging providers of file sinks.
+	StagingProviders() *stagingProvider.Providers
 	WatchTelemetryInterval() time.Duration
 }
 
@@ -137,6 +145,7 @@ type SourceScope interface {
 	ConnectionManager() *connection.Manager
 	SinkRouter() *sinkRouter.Router
 	StorageRouter() *storageRouter.Router
+	WebhookSinkProvider() *webhooksink.Provider
 }
 
 type CoordinatorScope interface {
@@ -63,6 +63,9 @@ func testConfig(tb testing.TB, d dependencies.Mocked) config.Config {
 	// Disable task cleanup in unrelated tests (it disturbs, for example, etcd ops logs)
 	cfg.API.Task.CleanupEnabled = false
 
+	// Disable request samples in unrelated tests (they disturb, for example, etcd KVs assertions)
+	cfg.Source.Sample.Size = 0
+
 	// Use native encryption for tests
 	secretKey := make([]byte, 32)
 	_, err := rand.Read(secretKey)
@@ -21,8 +21,12 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	deadLetterRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter/repository"
 	keboolaSinkBridge "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola/bridge"
 	keboolaBridgeRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola/bridge/model/repository"
+	sampleRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample/repository"
+	stagingProvider "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/provider"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/localfs"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	storageRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository"
 	statsRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics/repository"
@@ -49,7 +53,11 @@ type serviceScope struct {
 	storageRepository           *storageRepo.Repository
 	storageStatisticsRepository *statsRepo.Repository
 	aggregationRepository       *aggregationRepo.Repository
+	deadLetterRepository        *deadLetterRepo.Repository
+	sampleRepository            *sampleRepo.Repository
 	keboolaBridge               *keboolaSinkBridge.Bridge
+	stagingProviders            *stagingProvider.Providers
+	localFSImporter             *localfs.Importer
 	keboolaBridgeRepository     *keboolaBridgeRepo.Repository
 	watchTelemetryInterval      time.Duration
 }
@@ -81,7 +89,7 @@ func NewServiceScope(
 		return nil, err
 	}
 
-	return newServiceScope(p.BaseScope, p.PublicScope, p.EtcdClientScope, p.EncryptionScope, p.DistributedLockScope, cfg, model.DefaultBackoff())
+	return newServiceScope(ctx, p.BaseScope, p.PublicScope, p.EtcdClientScope, p.EncryptionScope, p.DistributedLockScope, cfg, model.DefaultBackoff())
 }
 
 func newParentScopes(
@@ -180,7 +188,7 @@ func NewMockedServiceScopeWithConfig(tb testing.TB, ctx context.Context, modifyC
 	require.NoError(tb, err)
 
 	backoff := model.NoRandomizationBackoff()
-	serviceScp, err := newServiceScope(mock, mock, mock, encryptionScope, distLockScope, cfg, backoff)
+	serviceScp, err := newServiceScope(ctx, mock, mock, mock, encryptionScope, distLockScope, cfg, backoff)
 	require.NoError(tb, err)
 
 	mock.DebugLogger().Truncate()
@@ -194,6 +202,7 @@ func NewMockedServiceScopeWithConfig(tb testing.TB, ctx context.Context, modifyC
 }
 
 func newServiceScope(
+	ctx context.Context,
 	baseScp dependencies.BaseScope,
 	publicScp dependencies.PublicScope,
 	etcdClientScp dependencies.EtcdClientScope,
@@ -230,7 +239,7 @@ func newServiceScope(
 	}
 
 	d.plugins.RegisterSinkWithLocalStorage(func(sinkType definition.SinkType) bool {
-		return sinkType == definition.SinkTypeTable
+		return sinkType == definition.SinkTypeTable || sinkType == definition.SinkTypeFile
 	})
 
 	apiCtxProvider := func(ctx context.Context) *keboola.AuthorizedAPI {
@@ -243,7 +252,22 @@ func newServiceScope(
 		return nil, err
 	}
 
-	d.keboolaBridge, err = keboolaSinkBridge.New(d, apiCtxProvider, cfg.Sink.Table.Keboola)
+	// Staging files are retained for a re-import as long as imported files, see the metacleanup package
+	keboolaBridgeCfg := cfg.Sink.Table.Keboola
+	keboolaBridgeCfg.StagingFileRetention = cfg.Storage.MetadataCleanup.ImportedFileRetention
+	d.keboolaBridge, err = keboolaSinkBridge.New(d, apiCtxProvider, keboolaBridgeCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Staging providers of file sinks
+	d.stagingProviders, err = stagingProvider.New(ctx, d, cfg.Storage.Level.Staging.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Target provider of files of file sinks, the hook must be registered after the staging providers
+	d.localFSImporter, err = localfs.New(d, cfg.Storage.Level.Target.Provider)
 	if err != nil {
 		return nil, err
 	}
@@ -252,6 +276,9 @@ func newServiceScope(
 
 	d.aggregationRepository = aggregationRepo.New(d)
 
+	d.deadLetterRepository = deadLetterRepo.New(d)
+	d.sampleRepository = sampleRepo.New(d)
+
 	return d, nil
 }
 
@@ -271,6 +298,10 @@ func (v *serviceScope) KeboolaSinkBridge() *keboolaSinkBridge.Bridge {
 	return v.keboolaBridge
 }
 
+func (v *serviceScope) StagingProviders() *stagingProvider.Providers {
+	return v.stagingProviders
+}
+
 func (v *serviceScope) KeboolaBridgeRepository() *keboolaBridgeRepo.Repository {
 	return v.keboolaBridgeRepository
 }
@@ -287,6 +318,14 @@ func (v *serviceScope) AggregationRepository() *aggregationRepo.Repository {
 	return v.aggregationRepository
 }
 
+func (v *serviceScope) DeadLetterRepository() *deadLetterRepo.Repository {
+	return v.deadLetterRepository
+}
+
+func (v *serviceScope) SampleRepository() *sampleRepo.Repository {
+	return v.sampleRepository
+}
+
 func (v *serviceScope) WatchTelemetryInterval() time.Duration {
 	return v.watchTelemetryInterval
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
 	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/webhooksink"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/connection"
 	storageRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/router"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding"
@@ -26,6 +27,7 @@ type sourceScope struct {
 	connectionManager *connection.Manager
 	sinkRouter        *sinkRouter.Router
 	storageRouter     *storageRouter.Router
+	webhookSink       *webhooksink.Provider
 }
 
 func (v *sourceScope) EncodingManager() *encoding.Manager {
@@ -44,6 +46,10 @@ func (v *sourceScope) StorageRouter() *storageRouter.Router {
 	return v.storageRouter
 }
 
+func (v *sourceScope) WebhookSinkProvider() *webhooksink.Provider {
+	return v.webhookSink
+}
+
 func NewSourceScope(serviceScp ServiceScope, distScp dependencies.DistributionScope, sourceType string, cfg config.Config) (v SourceScope, err error) {
 	return newSourceScope(serviceScp, distScp, sourceType, cfg)
 }
@@ -81,7 +87,7 @@ func newSourceScope(svcScp ServiceScope, distScp dependencies.DistributionScope,
 
 	statsCollector.Start(d, d.encodingManager.Events(), cfg.Storage.Statistics.Collector, cfg.NodeID)
 
-	d.sinkRouter, err = sinkRouter.New(d, sourceType)
+	d.sinkRouter, err = sinkRouter.New(d, sourceType, cfg.NodeID, cfg.Storage.Statistics.Collector, cfg.Sink.DeadLetter, cfg.Sink.Deduplication, cfg.Source.Sample)
 	if err != nil {
 		return nil, err
 	}
@@ -98,5 +104,7 @@ func newSourceScope(svcScp ServiceScope, distScp dependencies.DistributionScope,
 		return nil, pipeline.NoOpenerFoundError{SinkType: sinkType}
 	})
 
+	d.webhookSink = webhooksink.New(d, cfg.Sink.Webhook)
+
 	return d, nil
 }
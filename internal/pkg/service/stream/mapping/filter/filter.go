@@ -0,0 +1,54 @@
+// Package filter provides evaluation of record-level filter expressions, see definition.Sink.Filter.
+//
+// The expression is a Jsonnet code, all functions from the mapping/jsonnet package are available,
+// for example Body(), Header() and Ip(). The expression must return a boolean.
+package filter
+
+import (
+	"strings"
+
+	jsonnetWrapper "github.com/keboola/keboola-as-code/internal/pkg/encoding/jsonnet"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/jsonnet"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type Evaluator struct {
+	jsonnetPool *jsonnetWrapper.VMPool[recordctx.Context]
+}
+
+func NewEvaluator() *Evaluator {
+	return &Evaluator{jsonnetPool: jsonnet.NewPool()}
+}
+
+// Accepts returns true, if the record matches the filter expression.
+// An empty expression accepts all records.
+func (e *Evaluator) Accepts(expression string, c recordctx.Context) (bool, error) {
+	if expression == "" {
+		return true, nil
+	}
+
+	vm := e.jsonnetPool.Get()
+	defer e.jsonnetPool.Put(vm)
+
+	out, err := jsonnet.Evaluate(vm, c, expression)
+	if err != nil {
+		return false, err
+	}
+
+	switch out = strings.TrimSpace(out); out {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, errors.Errorf(`filter expression must return a boolean, found "%s"`, out)
+	}
+}
+
+// Validate checks the syntax of the filter expression.
+func (e *Evaluator) Validate(expression string) error {
+	vm := e.jsonnetPool.Get()
+	defer e.jsonnetPool.Put(vm)
+	return vm.Validate(expression)
+}
@@ -0,0 +1,63 @@
+package filter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/filter"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+)
+
+func TestEvaluator_Accepts(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		expression string
+		expected   bool
+		err        string
+	}{
+		{name: "empty", expression: "", expected: true},
+		{name: "body true", expression: `Body("level") == "error"`, expected: true},
+		{name: "body false", expression: `Body("level") == "debug"`, expected: false},
+		{name: "header", expression: `Header("X-Tenant", "") == "a"`, expected: true},
+		{name: "ip", expression: `Ip() == "1.2.3.4"`, expected: true},
+		{name: "default value", expression: `Body("missing", 0) > 0`, expected: false},
+		{name: "not boolean", expression: `Body("level")`, err: `filter expression must return a boolean, found ""error""`},
+		{name: "missing key", expression: `Body("missing") == 1`, err: `path "missing" not found in the body`},
+	}
+
+	e := filter.NewEvaluator()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"level":"error"}`))
+			req.RemoteAddr = "1.2.3.4:1234"
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant", "a")
+
+			accepted, err := e.Accepts(tc.expression, recordctx.FromHTTP(time.Now(), req))
+			if tc.err == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, accepted)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestEvaluator_Validate(t *testing.T) {
+	t.Parallel()
+
+	e := filter.NewEvaluator()
+	require.NoError(t, e.Validate(`Body("a") == 1`))
+	assert.Error(t, e.Validate(`Body("a") ==`))
+}
@@ -0,0 +1,35 @@
+package recordctx
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+)
+
+type batchIndexCtxKey struct{}
+
+// FromBatchItem builds a Context from a single item of a batch request, see httpsource package.
+//
+// All items of the batch share the arrival timestamp, the client IP and the request headers.
+// The body of the item is always parsed as JSON, the Content-Type header describes the whole batch.
+// The index of the item in the batch can be read by the BatchIndex function.
+func FromBatchItem(ctx context.Context, timestamp time.Time, clientIP net.IP, headers *orderedmap.OrderedMap, index int, body []byte) Context {
+	return &bytesContext{
+		ctx:         context.WithValue(ctx, batchIndexCtxKey{}, index),
+		timestamp:   timestamp,
+		clientIP:    clientIP,
+		headers:     headers,
+		contentType: "application/json",
+		body:        body,
+		kind:        "record",
+	}
+}
+
+// BatchIndex returns the index of the item in the batch request, if the record is an item of a batch, see FromBatchItem.
+// Items of a batch share the request headers, so the index distinguishes them, for example in a deduplication key.
+func BatchIndex(c Context) (int, bool) {
+	index, ok := c.Ctx().Value(batchIndexCtxKey{}).(int)
+	return index, ok
+}
@@ -0,0 +1,52 @@
+package recordctx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fastjson"
+)
+
+func TestBatchItemContext(t *testing.T) {
+	t.Parallel()
+
+	// Content-Type of the batch is ignored, the item is always JSON
+	headers := orderedmap.New()
+	headers.Set("Content-Type", "application/x-ndjson")
+	c := FromBatchItem(context.Background(), time.Now(), net.ParseIP("1.2.3.4"), headers, 3, []byte(`{"a":"b"}`))
+
+	bodyMap, err := c.BodyMap()
+	require.NoError(t, err)
+	v, _ := bodyMap.Get("a")
+	assert.Equal(t, "b", v)
+	assert.Equal(t, "1.2.3.4", c.ClientIP().String())
+	assert.Equal(t, "Content-Type: application/x-ndjson\n", c.HeadersString())
+
+	// The index is kept, if the context is wrapped
+	index, ok := BatchIndex(WithSharedValues(c))
+	assert.True(t, ok)
+	assert.Equal(t, 3, index)
+	_, ok = BatchIndex(FromHTTP(time.Now(), httptest.NewRequest(http.MethodPost, "/", nil)))
+	assert.False(t, ok)
+}
+
+func TestBatchItemContext_InvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	c := FromBatchItem(context.Background(), time.Now(), nil, orderedmap.New(), 0, []byte(`foo`))
+
+	_, err := c.BodyMap()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot parse record body")
+
+	_, err = c.JSONValue(&fastjson.ParserPool{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot parse record json")
+}
@@ -0,0 +1,127 @@
+package recordctx
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/valyala/fastjson"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// bytesContext is a record which is not backed by an HTTP request, but by a byte slice,
+// for example a Kafka message or an item of a batch request.
+type bytesContext struct {
+	ctx         context.Context
+	timestamp   time.Time
+	clientIP    net.IP
+	headers     *orderedmap.OrderedMap
+	contentType string
+	body        []byte
+	// kind of the record, it is used in error messages, for example "message"
+	kind string
+
+	lock          sync.Mutex
+	headersString *string
+	bodyMap       *orderedmap.OrderedMap
+	bodyMapErr    error
+	jsonValue     *fastjson.Value
+	jsonValueErr  error
+}
+
+func (c *bytesContext) Ctx() context.Context {
+	return c.ctx
+}
+
+func (c *bytesContext) Timestamp() time.Time {
+	return c.timestamp
+}
+
+// ClientIP returns nil, if it is not known, for example if the message is pulled from a broker.
+func (c *bytesContext) ClientIP() net.IP {
+	return c.clientIP
+}
+
+func (c *bytesContext) Signal() string {
+	return ""
+}
+
+func (c *bytesContext) HeadersString() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.headersString == nil {
+		keys := c.headers.Keys()
+		lines := make([]string, 0, len(keys))
+		for _, k := range keys {
+			v, _ := c.headers.Get(k)
+			if str, ok := v.(string); ok {
+				lines = append(lines, http.CanonicalHeaderKey(k)+": "+str+"\n")
+			}
+		}
+		sort.Strings(lines)
+		s := strings.Join(lines, "")
+		c.headersString = &s
+	}
+	return *c.headersString
+}
+
+func (c *bytesContext) HeadersMap() *orderedmap.OrderedMap {
+	return c.headers
+}
+
+func (c *bytesContext) ReleaseBuffers() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.bodyMap = nil
+	c.jsonValue = nil
+}
+
+func (c *bytesContext) BodyBytes() ([]byte, error) {
+	return c.body, nil
+}
+
+func (c *bytesContext) BodyLength() int {
+	return len(c.body)
+}
+
+func (c *bytesContext) BodyMap() (*orderedmap.OrderedMap, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.bodyMap == nil && c.bodyMapErr == nil {
+		if bodyMap, err := parseBody(c.contentType, c.body); err != nil {
+			c.bodyMapErr = errors.PrefixErrorf(err, "cannot parse %s body", c.kind)
+		} else {
+			c.bodyMap = bodyMap
+		}
+	}
+
+	return c.bodyMap, c.bodyMapErr
+}
+
+func (c *bytesContext) JSONValue(parserPool *fastjson.ParserPool) (*fastjson.Value, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.jsonValue != nil || c.jsonValueErr != nil {
+		return c.jsonValue, c.jsonValueErr
+	}
+
+	parser := parserPool.Get()
+	defer parserPool.Put(parser)
+
+	if jsonValue, err := parser.ParseBytes(c.body); err != nil {
+		c.jsonValueErr = errors.PrefixErrorf(err, "cannot parse %s json", c.kind)
+	} else {
+		c.jsonValue = jsonValue
+	}
+
+	return c.jsonValue, c.jsonValueErr
+}
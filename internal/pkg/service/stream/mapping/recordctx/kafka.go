@@ -0,0 +1,36 @@
+package recordctx
+
+import (
+	"context"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+)
+
+// FromKafka builds a Context from a single message consumed from a Kafka topic.
+//
+// timestamp is the message timestamp, so a re-consumed message gets the same datetime column value.
+//
+// headers contains the message headers, the source adds also the message metadata, see kafkasource package.
+// The body is parsed according to the Content-Type header, JSON is expected if the header is not set.
+func FromKafka(ctx context.Context, timestamp time.Time, headers *orderedmap.OrderedMap, body []byte) Context {
+	if headers == nil {
+		headers = orderedmap.New()
+	}
+
+	contentType := "application/json"
+	if v, ok := headers.Get("Content-Type"); ok {
+		if str, ok := v.(string); ok && str != "" {
+			contentType = str
+		}
+	}
+
+	return &bytesContext{
+		ctx:         ctx,
+		timestamp:   timestamp,
+		headers:     headers,
+		contentType: contentType,
+		body:        body,
+		kind:        "message",
+	}
+}
@@ -0,0 +1,54 @@
+package recordctx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fastjson"
+)
+
+func TestKafkaContext_BodyMap_JSONByDefault(t *testing.T) {
+	t.Parallel()
+
+	c := FromKafka(context.Background(), time.Now(), nil, []byte(`{"a":"b","c":1}`))
+
+	bodyMap, err := c.BodyMap()
+	require.NoError(t, err)
+	v, _ := bodyMap.Get("a")
+	assert.Equal(t, "b", v)
+	assert.Equal(t, 15, c.BodyLength())
+	assert.Empty(t, c.HeadersString())
+	assert.Nil(t, c.ClientIP())
+}
+
+func TestKafkaContext_BodyMap_ContentTypeHeader(t *testing.T) {
+	t.Parallel()
+
+	headers := orderedmap.New()
+	headers.Set("Content-Type", "application/x-www-form-urlencoded")
+	c := FromKafka(context.Background(), time.Now(), headers, []byte(`a=b`))
+
+	bodyMap, err := c.BodyMap()
+	require.NoError(t, err)
+	v, _ := bodyMap.Get("a")
+	assert.Equal(t, "b", v)
+	assert.Equal(t, "Content-Type: application/x-www-form-urlencoded\n", c.HeadersString())
+}
+
+func TestKafkaContext_InvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	c := FromKafka(context.Background(), time.Now(), nil, []byte(`foo`))
+
+	_, err := c.BodyMap()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid JSON")
+
+	_, err = c.JSONValue(&fastjson.ParserPool{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot parse message json")
+}
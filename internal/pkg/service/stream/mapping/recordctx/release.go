@@ -0,0 +1,26 @@
+package recordctx
+
+import (
+	"sync/atomic"
+)
+
+type deferredReleaseContext struct {
+	Context
+	requested atomic.Bool
+}
+
+// WithDeferredRelease wraps the Context, so buffers released by a sink are kept until the returned release function is called.
+// It is used if the raw record may be needed after writes to all sinks, for example by the dead-letter storage.
+// The release function releases buffers only if it has been requested by a sink.
+func WithDeferredRelease(c Context) (Context, func()) {
+	wrapped := &deferredReleaseContext{Context: c}
+	return wrapped, func() {
+		if wrapped.requested.Load() {
+			c.ReleaseBuffers()
+		}
+	}
+}
+
+func (c *deferredReleaseContext) ReleaseBuffers() {
+	c.requested.Store(true)
+}
@@ -0,0 +1,43 @@
+package recordctx_test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+)
+
+type releaseCounter struct {
+	recordctx.Context
+	calls atomic.Int64
+}
+
+func (c *releaseCounter) ReleaseBuffers() {
+	c.calls.Add(1)
+}
+
+func TestWithDeferredRelease(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	// The release is not requested by a sink, buffers are not released
+	original := &releaseCounter{Context: recordctx.FromHTTP(now, &http.Request{})}
+	_, release := recordctx.WithDeferredRelease(original)
+	release()
+	assert.Equal(t, int64(0), original.calls.Load())
+
+	// The release is requested by sinks, buffers are released only once, by the release function
+	original = &releaseCounter{Context: recordctx.FromHTTP(now, &http.Request{})}
+	c, release := recordctx.WithDeferredRelease(original)
+	assert.Equal(t, now, c.Timestamp())
+	c.ReleaseBuffers()
+	c.ReleaseBuffers()
+	assert.Equal(t, int64(0), original.calls.Load())
+	release()
+	assert.Equal(t, int64(1), original.calls.Load())
+}
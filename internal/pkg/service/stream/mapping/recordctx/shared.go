@@ -0,0 +1,49 @@
+package recordctx
+
+import (
+	"sync"
+)
+
+// SharedValues is implemented by a Context created by the WithSharedValues function.
+type SharedValues interface {
+	// SharedValue returns the value stored under the key, the value is rendered by the fn on the first call.
+	SharedValue(key string, fn func() (any, error)) (any, error)
+}
+
+type sharedValuesContext struct {
+	Context
+	lock   sync.Mutex
+	values map[string]*sharedValue
+}
+
+type sharedValue struct {
+	once  sync.Once
+	value any
+	err   error
+}
+
+// WithSharedValues wraps the Context, so values rendered from the record can be shared
+// by multiple mappings of the same record, for example by a table sink and its mirror.
+// It is safe to render the values concurrently, each value is rendered only once.
+func WithSharedValues(c Context) Context {
+	if _, ok := c.(SharedValues); ok {
+		return c
+	}
+	return &sharedValuesContext{Context: c, values: make(map[string]*sharedValue)}
+}
+
+func (c *sharedValuesContext) SharedValue(key string, fn func() (any, error)) (any, error) {
+	c.lock.Lock()
+	v, found := c.values[key]
+	if !found {
+		v = &sharedValue{}
+		c.values[key] = v
+	}
+	c.lock.Unlock()
+
+	v.once.Do(func() {
+		v.value, v.err = fn()
+	})
+
+	return v.value, v.err
+}
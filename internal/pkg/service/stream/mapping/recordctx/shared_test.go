@@ -0,0 +1,56 @@
+package recordctx_test
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestWithSharedValues(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c := recordctx.WithSharedValues(recordctx.FromHTTP(now, &http.Request{}))
+	assert.Equal(t, now, c.Timestamp())
+
+	// Wrapping is not repeated
+	assert.Same(t, c, recordctx.WithSharedValues(c))
+
+	shared, ok := c.(recordctx.SharedValues)
+	require.True(t, ok)
+
+	// Value is rendered only once, also on concurrent access
+	var calls atomic.Int64
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			value, err := shared.SharedValue("foo", func() (any, error) {
+				calls.Add(1)
+				return "bar", nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "bar", value)
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1), calls.Load())
+
+	// Error is shared too
+	for range 2 {
+		_, err := shared.SharedValue("error", func() (any, error) {
+			calls.Add(1)
+			return nil, errors.New("some error")
+		})
+		require.Error(t, err)
+		assert.Equal(t, "some error", err.Error())
+	}
+	assert.Equal(t, int64(2), calls.Load())
+}
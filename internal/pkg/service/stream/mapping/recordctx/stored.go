@@ -0,0 +1,30 @@
+package recordctx
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+)
+
+// FromStored builds a Context from a record stored outside the request, for example in the dead-letter storage of a sink.
+//
+// The body is parsed according to the Content-Type header, in the same way as the body of the original request.
+func FromStored(ctx context.Context, timestamp time.Time, clientIP net.IP, headers *orderedmap.OrderedMap, body []byte) Context {
+	if headers == nil {
+		headers = orderedmap.New()
+	}
+
+	contentType, _ := headers.GetOrNil("Content-Type").(string)
+
+	return &bytesContext{
+		ctx:         ctx,
+		timestamp:   timestamp,
+		clientIP:    clientIP,
+		headers:     headers,
+		contentType: contentType,
+		body:        body,
+		kind:        "record",
+	}
+}
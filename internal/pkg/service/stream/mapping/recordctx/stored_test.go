@@ -0,0 +1,38 @@
+package recordctx
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredContext(t *testing.T) {
+	t.Parallel()
+
+	// Body is parsed according to the Content-Type header
+	headers := orderedmap.New()
+	headers.Set("Content-Type", "application/x-www-form-urlencoded")
+	c := FromStored(context.Background(), time.Now(), net.ParseIP("1.2.3.4"), headers, []byte(`a=b`))
+
+	bodyMap, err := c.BodyMap()
+	require.NoError(t, err)
+	v, _ := bodyMap.Get("a")
+	assert.Equal(t, "b", v)
+	assert.Equal(t, "1.2.3.4", c.ClientIP().String())
+	assert.Equal(t, "Content-Type: application/x-www-form-urlencoded\n", c.HeadersString())
+}
+
+func TestStoredContext_NoHeaders(t *testing.T) {
+	t.Parallel()
+
+	c := FromStored(context.Background(), time.Now(), nil, nil, []byte(`{"a":"b"}`))
+
+	_, err := c.BodyMap()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot parse record body")
+}
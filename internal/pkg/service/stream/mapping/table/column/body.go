@@ -7,6 +7,7 @@ const (
 type Body struct {
 	Name       string `json:"name" validate:"required"`
 	PrimaryKey bool   `json:"primaryKey,omitempty"`
+	Cast       *Cast  `json:"cast,omitempty"`
 }
 
 func (v Body) ColumnType() Type {
@@ -20,3 +21,7 @@ func (v Body) ColumnName() string {
 func (v Body) IsPrimaryKey() bool {
 	return v.PrimaryKey
 }
+
+func (v Body) ColumnCast() *Cast {
+	return v.Cast
+}
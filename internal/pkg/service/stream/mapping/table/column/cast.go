@@ -0,0 +1,252 @@
+package column
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/relvacode/iso8601"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/strhelper"
+)
+
+const (
+	DataTypeInteger   DataType = "integer"
+	DataTypeNumber    DataType = "number"
+	DataTypeBoolean   DataType = "boolean"
+	DataTypeTimestamp DataType = "timestamp"
+	DataTypeJSON      DataType = "json"
+)
+
+const (
+	// CastErrorFail rejects the record, it is the default policy.
+	CastErrorFail CastErrorPolicy = "fail"
+	// CastErrorNull replaces the invalid value with null.
+	CastErrorNull CastErrorPolicy = "null"
+	// CastErrorDefault replaces the invalid value with the Cast.DefaultValue.
+	CastErrorDefault CastErrorPolicy = "default"
+)
+
+// castErrorValueMaxLength limits length of the invalid value in an error message.
+const castErrorValueMaxLength = 50
+
+// DataType is a target data type of a column value, see Cast.
+type DataType string
+
+type DataTypes []DataType
+
+// CastErrorPolicy defines how a value which cannot be cast to the DataType is handled.
+type CastErrorPolicy string
+
+type CastErrorPolicies []CastErrorPolicy
+
+// Cast is an optional target data type of the Body, Path and Template columns.
+// Without the Cast, a value is rendered as a string.
+// With the Cast, the Renderer validates and normalizes the value to the DataType:
+//   - integer: a whole number, for example 123, "123" or 123.0, is normalized to 123.
+//   - number: a finite number, for example 1.5 or "1.5e3", is normalized to 1500.
+//   - boolean: for example true, "1" or "FALSE", is normalized to true/false.
+//   - timestamp: an ISO 8601 string or a Unix timestamp in seconds, is normalized to the TimeFormat in UTC.
+//   - json: a valid JSON, is normalized to a single line.
+//
+// A null or an empty value is always rendered as null, it is not a cast error.
+type Cast struct {
+	DataType     DataType        `json:"dataType" validate:"required,oneof=integer number boolean timestamp json"`
+	OnError      CastErrorPolicy `json:"onError,omitempty" validate:"omitempty,oneof=fail null default"`
+	DefaultValue *string         `json:"defaultValue,omitempty" validate:"required_if=OnError default"`
+}
+
+// Typed is implemented by columns with an optional target data type.
+type Typed interface {
+	Column
+	ColumnCast() *Cast
+}
+
+// castValue is a result of the Cast.
+type castValue struct {
+	dataType DataType
+	value    string
+	null     bool
+}
+
+func AllDataTypes() DataTypes {
+	return DataTypes{
+		DataTypeInteger,
+		DataTypeNumber,
+		DataTypeBoolean,
+		DataTypeTimestamp,
+		DataTypeJSON,
+	}
+}
+
+func AllCastErrorPolicies() CastErrorPolicies {
+	return CastErrorPolicies{
+		CastErrorFail,
+		CastErrorNull,
+		CastErrorDefault,
+	}
+}
+
+func (v DataType) String() string {
+	return string(v)
+}
+
+func (v DataTypes) AnySlice() (out []any) {
+	for _, t := range v {
+		out = append(out, t.String())
+	}
+	return out
+}
+
+func (v CastErrorPolicy) String() string {
+	return string(v)
+}
+
+func (v CastErrorPolicies) AnySlice() (out []any) {
+	for _, p := range v {
+		out = append(out, p.String())
+	}
+	return out
+}
+
+// CheckDefaultValue returns an error, if the DefaultValue is set, but it cannot be cast to the DataType.
+func (c *Cast) CheckDefaultValue() error {
+	if c.DefaultValue == nil {
+		return nil
+	}
+	if _, err := c.cast([]byte(*c.DefaultValue)); err != nil {
+		return errors.Errorf(`invalid default value: %w`, err)
+	}
+	return nil
+}
+
+// apply casts the rendered value to the DataType, the OnError policy is used, if the value is not valid.
+func (c *Cast) apply(raw []byte) (castValue, error) {
+	out, err := c.cast(raw)
+	if err != nil {
+		out, err = c.onError(err)
+	}
+	out.dataType = c.DataType
+	return out, err
+}
+
+func (c *Cast) onError(err error) (castValue, error) {
+	switch c.OnError {
+	case CastErrorNull:
+		return castValue{null: true}, nil
+	case CastErrorDefault:
+		if c.DefaultValue == nil {
+			return castValue{}, errors.Errorf(`default value for the data type "%s" is not set`, c.DataType)
+		}
+		out, defErr := c.cast([]byte(*c.DefaultValue))
+		if defErr != nil {
+			return castValue{}, errors.Errorf(`invalid default value: %w`, defErr)
+		}
+		return out, nil
+	default:
+		return castValue{}, err
+	}
+}
+
+func (c *Cast) cast(raw []byte) (castValue, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return castValue{null: true}, nil
+	}
+
+	if c.DataType == DataTypeJSON {
+		if !stdjson.Valid(raw) {
+			return castValue{}, c.invalidValueError(string(raw))
+		}
+		var buf bytes.Buffer
+		if err := stdjson.Compact(&buf, raw); err != nil {
+			return castValue{}, err
+		}
+		return castValue{value: buf.String()}, nil
+	}
+
+	// A JSON string is unquoted, scalar values are often sent as strings
+	str := string(raw)
+	if raw[0] == '"' {
+		var decoded string
+		if json.DecodeString(str, &decoded) == nil {
+			str = strings.TrimSpace(decoded)
+		}
+		if str == "" {
+			return castValue{null: true}, nil
+		}
+	}
+
+	var value string
+	var ok bool
+	switch c.DataType {
+	case DataTypeInteger:
+		value, ok = castInteger(str)
+	case DataTypeNumber:
+		value, ok = castNumber(str)
+	case DataTypeBoolean:
+		value, ok = castBoolean(str)
+	case DataTypeTimestamp:
+		value, ok = castTimestamp(str)
+	default:
+		return castValue{}, errors.Errorf(`unexpected data type "%s"`, c.DataType)
+	}
+
+	if !ok {
+		return castValue{}, c.invalidValueError(str)
+	}
+
+	return castValue{value: value}, nil
+}
+
+func (c *Cast) invalidValueError(value string) error {
+	return errors.Errorf(`value "%s" is not a valid %s`, strhelper.Truncate(value, castErrorValueMaxLength, "…"), c.DataType)
+}
+
+func castInteger(str string) (string, bool) {
+	if v, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return strconv.FormatInt(v, 10), true
+	}
+
+	// A float with no fractional part, for example 123.0 or 1e3
+	if f, err := strconv.ParseFloat(str, 64); err == nil && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return strconv.FormatInt(int64(f), 10), true
+	}
+
+	return "", false
+}
+
+func castNumber(str string) (string, bool) {
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", false
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64), true
+}
+
+func castBoolean(str string) (string, bool) {
+	b, err := strconv.ParseBool(str)
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatBool(b), true
+}
+
+func castTimestamp(str string) (string, bool) {
+	if t, err := iso8601.ParseString(str); err == nil {
+		return t.UTC().Format(TimeFormat), true
+	}
+
+	// Unix timestamp in seconds, with an optional fractional part
+	if f, err := strconv.ParseFloat(str, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC().Format(TimeFormat), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,162 @@
+package column_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+)
+
+func TestRenderer_Cast(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		dataType column.DataType
+		value    string
+		expected string
+		err      string
+	}{
+		{dataType: column.DataTypeInteger, value: `123`, expected: `123`},
+		{dataType: column.DataTypeInteger, value: `"-123"`, expected: `-123`},
+		{dataType: column.DataTypeInteger, value: `1e3`, expected: `1000`},
+		{dataType: column.DataTypeInteger, value: `12.5`, err: `value "12.5" is not a valid integer`},
+		{dataType: column.DataTypeInteger, value: `"abc"`, err: `value "abc" is not a valid integer`},
+		{dataType: column.DataTypeNumber, value: `12.50`, expected: `12.5`},
+		{dataType: column.DataTypeNumber, value: `"1.5e3"`, expected: `1500`},
+		{dataType: column.DataTypeNumber, value: `"NaN"`, err: `value "NaN" is not a valid number`},
+		{dataType: column.DataTypeBoolean, value: `true`, expected: `true`},
+		{dataType: column.DataTypeBoolean, value: `"0"`, expected: `false`},
+		{dataType: column.DataTypeBoolean, value: `"yes"`, err: `value "yes" is not a valid boolean`},
+		{dataType: column.DataTypeTimestamp, value: `"2024-01-02T03:04:05+01:00"`, expected: `2024-01-02T02:04:05.000Z`},
+		{dataType: column.DataTypeTimestamp, value: `1704164645.5`, expected: `2024-01-02T03:04:05.500Z`},
+		{dataType: column.DataTypeTimestamp, value: `"yesterday"`, err: `value "yesterday" is not a valid timestamp`},
+		{dataType: column.DataTypeJSON, value: `{"a": [1, 2]}`, expected: `{"a":[1,2]}`},
+		{dataType: column.DataTypeJSON, value: `"foo"`, expected: `"foo"`},
+		{dataType: column.DataTypeInteger, value: `null`, expected: ``},
+		{dataType: column.DataTypeInteger, value: `""`, expected: ``},
+	}
+
+	renderer := column.NewRenderer()
+	for _, tc := range cases {
+		c := column.Path{Path: "key", Cast: &column.Cast{DataType: tc.dataType}}
+		body := `{"key":` + tc.value + `}`
+		val, err := renderer.CSVValue(c, jsonRecord(body))
+		if tc.err == "" {
+			require.NoError(t, err, tc.value)
+			assert.Equal(t, tc.expected, val, tc.value)
+		} else if assert.Error(t, err, tc.value) {
+			assert.Equal(t, tc.err, err.Error(), tc.value)
+			assert.ErrorAs(t, err, &column.RenderError{})
+		}
+	}
+}
+
+func TestRenderer_Cast_OnError(t *testing.T) {
+	t.Parallel()
+
+	renderer := column.NewRenderer()
+	record := func() recordctx.Context { return jsonRecord(`{"key":"abc"}`) }
+
+	// Fail
+	_, err := renderer.CSVValue(column.Path{Path: "key", Cast: &column.Cast{DataType: column.DataTypeInteger, OnError: column.CastErrorFail}}, record())
+	require.Error(t, err)
+	assert.Equal(t, `value "abc" is not a valid integer`, err.Error())
+
+	// Null
+	val, err := renderer.CSVValue(column.Path{Path: "key", Cast: &column.Cast{DataType: column.DataTypeInteger, OnError: column.CastErrorNull}}, record())
+	require.NoError(t, err)
+	assert.Equal(t, ``, val)
+
+	// Default
+	val, err = renderer.CSVValue(column.Path{Path: "key", Cast: &column.Cast{DataType: column.DataTypeInteger, OnError: column.CastErrorDefault, DefaultValue: new("-1")}}, record())
+	require.NoError(t, err)
+	assert.Equal(t, `-1`, val)
+
+	// Invalid default
+	_, err = renderer.CSVValue(column.Path{Path: "key", Cast: &column.Cast{DataType: column.DataTypeInteger, OnError: column.CastErrorDefault, DefaultValue: new("x")}}, record())
+	require.Error(t, err)
+	assert.Equal(t, `invalid default value: value "x" is not a valid integer`, err.Error())
+}
+
+func TestRenderer_Cast_BodyAndTemplate(t *testing.T) {
+	t.Parallel()
+
+	renderer := column.NewRenderer()
+
+	val, err := renderer.CSVValue(column.Body{Cast: &column.Cast{DataType: column.DataTypeJSON}}, jsonRecord("{\n  \"key\": 1\n}"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"key":1}`, val)
+
+	c := column.Template{
+		Template: column.TemplateConfig{Language: column.TemplateLanguageJsonnet, Content: `Body("key") * 2`},
+		Cast:     &column.Cast{DataType: column.DataTypeNumber},
+	}
+	val, err = renderer.CSVValue(c, jsonRecord(`{"key":1.25}`))
+	require.NoError(t, err)
+	assert.Equal(t, `2.5`, val)
+}
+
+func TestRenderer_Cast_WriteJSONValue(t *testing.T) {
+	t.Parallel()
+
+	renderer := column.NewRenderer()
+	body := `{"int":"12","bool":"true","time":"2024-01-02T03:04:05Z","obj":{"a":1},"null":null}`
+
+	cases := []struct {
+		column   column.Column
+		expected string
+	}{
+		{column: column.Path{Path: "int", Cast: &column.Cast{DataType: column.DataTypeInteger}}, expected: `12`},
+		{column: column.Path{Path: "bool", Cast: &column.Cast{DataType: column.DataTypeBoolean}}, expected: `true`},
+		{column: column.Path{Path: "time", Cast: &column.Cast{DataType: column.DataTypeTimestamp}}, expected: `"2024-01-02T03:04:05.000Z"`},
+		{column: column.Path{Path: "obj", Cast: &column.Cast{DataType: column.DataTypeJSON}}, expected: `{"a":1}`},
+		{column: column.Path{Path: "null", Cast: &column.Cast{DataType: column.DataTypeInteger}}, expected: `null`},
+		{column: column.Path{Path: "int"}, expected: `"12"`},
+	}
+
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		require.NoError(t, renderer.WriteJSONValue(&buf, tc.column, jsonRecord(body)))
+		assert.Equal(t, tc.expected, buf.String())
+	}
+}
+
+func TestRenderer_TypedValue(t *testing.T) {
+	t.Parallel()
+
+	renderer := column.NewRenderer()
+	body := `{"int":"12","null":null}`
+
+	// Typed column
+	val, dataType, null, err := renderer.TypedValue(column.Path{Path: "int", Cast: &column.Cast{DataType: column.DataTypeInteger}}, jsonRecord(body))
+	require.NoError(t, err)
+	assert.Equal(t, `12`, val)
+	assert.Equal(t, column.DataTypeInteger, dataType)
+	assert.False(t, null)
+
+	// Typed column, null value
+	_, dataType, null, err = renderer.TypedValue(column.Path{Path: "null", Cast: &column.Cast{DataType: column.DataTypeInteger}}, jsonRecord(body))
+	require.NoError(t, err)
+	assert.Equal(t, column.DataTypeInteger, dataType)
+	assert.True(t, null)
+
+	// Column without the Cast
+	val, dataType, null, err = renderer.TypedValue(column.Path{Path: "int"}, jsonRecord(body))
+	require.NoError(t, err)
+	assert.Equal(t, `"12"`, val)
+	assert.Empty(t, dataType)
+	assert.False(t, null)
+}
+
+func jsonRecord(body string) recordctx.Context {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	return recordctx.FromHTTP(time.Now(), &http.Request{Header: header, Body: io.NopCloser(strings.NewReader(body))})
+}
@@ -26,9 +26,14 @@ func TestMappedColumns_Serde(t *testing.T) {
 				Content:  `Body('my.key')+':'+Body('my.value')'`,
 			},
 		},
+		column.Path{
+			Name: "count",
+			Path: "my.count",
+			Cast: &column.Cast{DataType: column.DataTypeInteger, OnError: column.CastErrorDefault, DefaultValue: new("0")},
+		},
 	}
 
-	expectedJSON := `[{"type":"uuid","name":"id","primaryKey":true},{"type":"datetime","name":"datetime"},{"type":"ip","name":"ip"},{"type":"body","name":"body"},{"type":"headers","name":"headers"},{"type":"template","name":"template","template":{"language":"jsonnet","content":"Body('my.key')+':'+Body('my.value')'"}}]`
+	expectedJSON := `[{"type":"uuid","name":"id","primaryKey":true},{"type":"datetime","name":"datetime"},{"type":"ip","name":"ip"},{"type":"body","name":"body"},{"type":"headers","name":"headers"},{"type":"template","name":"template","template":{"language":"jsonnet","content":"Body('my.key')+':'+Body('my.value')'"}},{"type":"path","name":"count","path":"my.count","cast":{"dataType":"integer","onError":"default","defaultValue":"0"}}]`
 
 	bytes, err := json.Marshal(&typed)
 	require.NoError(t, err)
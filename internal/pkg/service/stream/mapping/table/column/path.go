@@ -10,6 +10,7 @@ type Path struct {
 	Path         string  `json:"path" validate:"required"`
 	RawString    bool    `json:"rawString,omitempty"`
 	DefaultValue *string `json:"defaultValue,omitempty"`
+	Cast         *Cast   `json:"cast,omitempty"`
 }
 
 func (v Path) ColumnType() Type {
@@ -35,3 +36,7 @@ func (v Path) ReturnsRawString() bool {
 func (v Path) GetDefaultValue() *string {
 	return v.DefaultValue
 }
+
+func (v Path) ColumnCast() *Cast {
+	return v.Cast
+}
@@ -1,9 +1,12 @@
 package column
 
 import (
+	"bytes"
+	stdjson "encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/keboola/go-utils/pkg/orderedmap"
@@ -29,7 +32,120 @@ func NewRenderer() *Renderer {
 	}
 }
 
+// RenderError is returned by the Renderer, if a column value cannot be rendered from the record,
+// for example, if the path is not found in the body or the template fails.
+// Such records can be stored to the dead-letter storage of the sink, see the deadletter package.
+type RenderError struct {
+	err error
+}
+
+func (e RenderError) Error() string {
+	return e.err.Error()
+}
+
+func (e RenderError) Unwrap() error {
+	return e.err
+}
+
+// CSVValue renders the column value, a value of a Typed column is cast to the DataType, null is rendered as an empty string.
 func (r *Renderer) CSVValue(c Column, ctx recordctx.Context) (any, error) {
+	value, _, err := r.render(c, ctx)
+	return value, err
+}
+
+// TypedValue renders the column value as the CSVValue, and returns the target data type of the value.
+// The data type is empty, if the column has no Cast. The null is true, if the cast value is null.
+func (r *Renderer) TypedValue(c Column, ctx recordctx.Context) (value any, dataType DataType, null bool, err error) {
+	value, casted, err := r.render(c, ctx)
+	if err != nil || casted == nil {
+		return value, "", false, err
+	}
+	return value, casted.dataType, casted.null, nil
+}
+
+// TimeValue renders the column value as a time, it is used to route the record to a time-based partition.
+// The Datetime column returns the record timestamp, a value of other columns must be a time in the RFC 3339 format.
+func (r *Renderer) TimeValue(c Column, ctx recordctx.Context) (time.Time, error) {
+	if _, ok := c.(Datetime); ok {
+		return ctx.Timestamp().UTC(), nil
+	}
+
+	value, _, err := r.render(c, ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Value of a Template column without the RawString option is a JSON string
+	str := strings.Trim(fmt.Sprint(value), `"`)
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return time.Time{}, RenderError{err: errors.Errorf(`value "%s" of the column "%s" is not a time in the RFC 3339 format`, str, c.ColumnName())}
+	}
+
+	return t.UTC(), nil
+}
+
+// NewRenderError wraps an error of a record, which cannot be written regardless of a retry, see RenderError.
+func NewRenderError(err error) error {
+	return RenderError{err: err}
+}
+
+// render returns the column value, and the cast value, if the column has a target data type, see Cast.
+func (r *Renderer) render(c Column, ctx recordctx.Context) (any, *castValue, error) {
+	value, err := r.csvValue(c, ctx)
+	if err != nil {
+		return value, nil, RenderError{err: err}
+	}
+
+	typed, ok := c.(Typed)
+	if !ok || typed.ColumnCast() == nil {
+		return value, nil, nil
+	}
+
+	raw, err := valueBytes(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	casted, err := typed.ColumnCast().apply(raw)
+	if err != nil {
+		return nil, nil, RenderError{err: err}
+	}
+
+	return casted.value, &casted, nil
+}
+
+func (r *Renderer) csvValue(c Column, ctx recordctx.Context) (any, error) {
+	// The value is shared by multiple mappings of the record, if they contain the same column, see recordctx.WithSharedValues
+	if shared, ok := ctx.(recordctx.SharedValues); ok {
+		if key, ok := sharedValueKey(c); ok {
+			return shared.SharedValue(key, func() (any, error) {
+				return r.renderValue(c, ctx)
+			})
+		}
+	}
+	return r.renderValue(c, ctx)
+}
+
+// sharedValueKey returns a key of the column value, the column name is not part of the key,
+// so the value can be shared by columns with the same definition but a different name.
+// Only values of the Path and Template columns are shared, other values are cheap to render, or they must be unique, like the UUID.
+func sharedValueKey(c Column) (string, bool) {
+	switch c := c.(type) {
+	case Path:
+		defaultValue := ""
+		if c.DefaultValue != nil {
+			defaultValue = "=" + *c.DefaultValue
+		}
+		return fmt.Sprintf("path:%t:%q%s", c.RawString, c.Path, defaultValue), true
+	case Template:
+		return fmt.Sprintf("template:%t:%s:%s", c.RawString, c.Template.Language, c.Template.Content), true
+	default:
+		return "", false
+	}
+}
+
+func (r *Renderer) renderValue(c Column, ctx recordctx.Context) (any, error) {
 	switch c := c.(type) {
 	case Body:
 		return ctx.BodyBytes()
@@ -82,6 +198,70 @@ func (r *Renderer) CSVValue(c Column, ctx recordctx.Context) (any, error) {
 	return "", errors.Errorf("unknown column type %T", c)
 }
 
+// WriteJSONValue writes the column value to the buffer as a JSON value.
+// Values of the Body, Headers, Path and Template columns are kept as structured JSON, if they contain a valid JSON.
+// A value of a Typed column is written as the JSON type matching the DataType, or as null.
+// Other values are encoded as a JSON string.
+func (r *Renderer) WriteJSONValue(buf *bytes.Buffer, c Column, ctx recordctx.Context) error {
+	value, casted, err := r.render(c, ctx)
+	if err != nil {
+		return err
+	}
+
+	raw, err := valueBytes(value)
+	if err != nil {
+		return err
+	}
+
+	// Cast values are normalized, only the timestamp is encoded as a JSON string
+	if casted != nil {
+		switch {
+		case casted.null:
+			buf.WriteString("null")
+			return nil
+		case casted.dataType != DataTypeTimestamp:
+			buf.Write(raw)
+			return nil
+		}
+	}
+
+	// Values of these columns may be a structured JSON
+	structured := false
+	switch c := c.(type) {
+	case Body, Headers:
+		structured = true
+	case Path:
+		structured = !c.ReturnsRawString()
+	case Template:
+		structured = !c.ReturnsRawString()
+	}
+
+	// Keep a valid JSON as it is, but on a single line
+	if structured && stdjson.Valid(raw) {
+		return stdjson.Compact(buf, raw)
+	}
+
+	// Encode other values as a JSON string, the encoder appends a new line, it is removed
+	enc := stdjson.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(string(raw)); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1)
+	return nil
+}
+
+func valueBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, errors.Errorf(`unexpected value type "%T"`, value)
+	}
+}
+
 func (r *Renderer) mapPathCSVValue(c Path, ctx recordctx.Context) (string, error) {
 	bodyMap, err := ctx.BodyMap()
 	if err != nil {
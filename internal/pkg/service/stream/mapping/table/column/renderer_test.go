@@ -10,6 +10,7 @@ import (
 	"github.com/gofrs/uuid/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/valyala/fastjson"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
@@ -784,3 +785,86 @@ func TestRenderer_Template_InvalidLanguage(t *testing.T) {
 	_, err := renderer.CSVValue(c, recordctx.FromHTTP(time.Now(), &http.Request{}))
 	assert.ErrorContains(t, err, `unsupported language "invalid", only "jsonnet" is supported`)
 }
+
+type jsonValueCounter struct {
+	recordctx.Context
+	count int
+}
+
+func (c *jsonValueCounter) JSONValue(parserPool *fastjson.ParserPool) (*fastjson.Value, error) {
+	c.count++
+	return c.Context.JSONValue(parserPool)
+}
+
+func TestRenderer_SharedValues(t *testing.T) {
+	t.Parallel()
+
+	renderer := column.NewRenderer()
+	path1 := column.Path{Name: "a", Path: "key1.key2"}
+	path2 := column.Path{Name: "b", Path: "key1.key2"}
+	path3 := column.Path{Name: "c", Path: "key3", RawString: true}
+	id := column.UUID{Name: "id"}
+
+	newRecord := func() *jsonValueCounter {
+		body := `{"key1":{"key2":"val2"},"key3":"val3"}`
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		return &jsonValueCounter{Context: recordctx.FromHTTP(time.Now(), &http.Request{Header: header, Body: io.NopCloser(strings.NewReader(body))})}
+	}
+
+	// Without shared values, each column is rendered
+	record := newRecord()
+	for _, c := range []column.Column{path1, path2, path3} {
+		_, err := renderer.CSVValue(c, record)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 3, record.count)
+
+	// With shared values, columns with the same definition are rendered once
+	record = newRecord()
+	shared := recordctx.WithSharedValues(record)
+	var values []any
+	for _, c := range []column.Column{path1, path2, path3, path1} {
+		val, err := renderer.CSVValue(c, shared)
+		require.NoError(t, err)
+		values = append(values, val)
+	}
+	assert.Equal(t, 2, record.count)
+	assert.Equal(t, []any{`"val2"`, `"val2"`, "val3", `"val2"`}, values)
+
+	// UUID is not shared
+	id1, err := renderer.CSVValue(id, shared)
+	require.NoError(t, err)
+	id2, err := renderer.CSVValue(id, shared)
+	require.NoError(t, err)
+	assert.NotEqual(t, id1, id2)
+}
+
+func TestRenderer_TimeValue(t *testing.T) {
+	t.Parallel()
+
+	renderer := column.NewRenderer()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	newRecord := func(body string) recordctx.Context {
+		return recordctx.FromHTTP(now, &http.Request{Header: header, Body: io.NopCloser(strings.NewReader(body))})
+	}
+	template := column.Template{Name: "time", Template: column.TemplateConfig{
+		Language: column.TemplateLanguageJsonnet,
+		Content:  "Body('time')",
+	}}
+
+	// Datetime column returns the record timestamp
+	val, err := renderer.TimeValue(column.Datetime{Name: "datetime"}, newRecord(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, now, val)
+
+	// Template column returns the parsed value
+	val, err = renderer.TimeValue(template, newRecord(`{"time":"2023-12-31T23:59:59+01:00"}`))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 31, 22, 59, 59, 0, time.UTC), val)
+
+	// Invalid value is a render error
+	_, err = renderer.TimeValue(template, newRecord(`{"time":"foo"}`))
+	require.ErrorAs(t, err, &column.RenderError{})
+	assert.Equal(t, `value "foo" of the column "time" is not a time in the RFC 3339 format`, err.Error())
+}
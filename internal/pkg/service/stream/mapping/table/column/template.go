@@ -10,6 +10,7 @@ type Template struct {
 	PrimaryKey bool           `json:"primaryKey,omitempty"`
 	RawString  bool           `json:"rawString,omitempty"`
 	Template   TemplateConfig `json:"template"`
+	Cast       *Cast          `json:"cast,omitempty"`
 }
 
 type TemplateConfig struct {
@@ -32,3 +33,7 @@ func (v Template) IsPrimaryKey() bool {
 func (v Template) ReturnsRawString() bool {
 	return v.RawString
 }
+
+func (v Template) ColumnCast() *Cast {
+	return v.Cast
+}
@@ -0,0 +1,156 @@
+// Package infer proposes table columns from sample bodies of a source.
+//
+// The proposal always starts with an "id" column of the UUID type, the primary key, and a "datetime" column.
+// Then a Template column with the Body("path", "") expression is added for each value found in the samples,
+// a value missing in a record is mapped to an empty string.
+// Nested objects are flattened up to the Config.MaxDepth, deeper objects and arrays are mapped to one JSON column.
+//
+// Paths are collected from all samples, in the order of the first occurrence.
+// If a key cannot be used in a Body path, the whole parent object is mapped to one column,
+// such top-level keys are covered by a "body" column with the whole body.
+package infer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/umisama/go-regexpcache"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+)
+
+const (
+	IDColumnName       = "id"
+	DatetimeColumnName = "datetime"
+	BodyColumnName     = "body"
+	// MaxColumns matches the limit of the table.Mapping.
+	MaxColumns = 100
+)
+
+type Config struct {
+	// MaxDepth of flattened nested objects, 1 means only top-level keys.
+	MaxDepth int
+}
+
+type collector struct {
+	config     Config
+	paths      [][]string
+	seen       map[string]bool
+	bodyColumn bool
+}
+
+// Columns proposes table columns from the sample bodies.
+func Columns(samples []*orderedmap.OrderedMap, cfg Config) column.Columns {
+	if cfg.MaxDepth < 1 {
+		cfg.MaxDepth = 1
+	}
+
+	c := &collector{config: cfg, seen: make(map[string]bool)}
+	for _, s := range samples {
+		c.collectObject(nil, s)
+	}
+
+	columns := column.Columns{
+		column.UUID{Name: IDColumnName, PrimaryKey: true},
+		column.Datetime{Name: DatetimeColumnName},
+	}
+
+	names := map[string]bool{IDColumnName: true, DatetimeColumnName: true}
+	if c.bodyColumn {
+		columns = append(columns, column.Body{Name: BodyColumnName})
+		names[BodyColumnName] = true
+	}
+
+	for _, path := range c.leafPaths() {
+		if len(columns) >= MaxColumns {
+			break
+		}
+		name := uniqueName(columnName(path), names)
+		names[name] = true
+		columns = append(columns, column.Template{
+			Name:      name,
+			RawString: true,
+			Template: column.TemplateConfig{
+				Language: column.TemplateLanguageJsonnet,
+				Content:  fmt.Sprintf(`Body(%q, "")`, strings.Join(path, ".")),
+			},
+		})
+	}
+
+	return columns
+}
+
+func (c *collector) collectObject(parent []string, obj *orderedmap.OrderedMap) {
+	for _, key := range obj.Keys() {
+		if !isSafeKey(key) {
+			// A top-level key is covered by the "body" column, the whole parent object is the value otherwise.
+			if len(parent) == 0 {
+				c.bodyColumn = true
+			}
+			continue
+		}
+
+		value, _ := obj.Get(key)
+		path := append(append([]string(nil), parent...), key)
+		if nested, ok := value.(*orderedmap.OrderedMap); ok && len(path) < c.config.MaxDepth && nested.Len() > 0 && allKeysSafe(nested) {
+			c.collectObject(path, nested)
+			continue
+		}
+
+		c.add(path)
+	}
+}
+
+func (c *collector) add(path []string) {
+	k := strings.Join(path, ".")
+	if !c.seen[k] {
+		c.seen[k] = true
+		c.paths = append(c.paths, path)
+	}
+}
+
+// leafPaths returns paths without a parent path, a value is a scalar in one sample and an object in another.
+func (c *collector) leafPaths() (out [][]string) {
+	for _, path := range c.paths {
+		covered := false
+		for i := 1; i < len(path); i++ {
+			if c.seen[strings.Join(path[:i], ".")] {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			out = append(out, path)
+		}
+	}
+	return out
+}
+
+func isSafeKey(key string) bool {
+	return regexpcache.MustCompile(`^[a-zA-Z0-9_\-]+$`).MatchString(key)
+}
+
+func allKeysSafe(obj *orderedmap.OrderedMap) bool {
+	for _, key := range obj.Keys() {
+		if !isSafeKey(key) {
+			return false
+		}
+	}
+	return true
+}
+
+func columnName(path []string) string {
+	return regexpcache.MustCompile(`[^a-zA-Z0-9_]+`).ReplaceAllString(strings.Join(path, "_"), "_")
+}
+
+func uniqueName(name string, used map[string]bool) string {
+	if !used[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		if candidate := fmt.Sprintf("%s_%d", name, i); !used[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package infer_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/infer"
+)
+
+func TestColumns(t *testing.T) {
+	t.Parallel()
+
+	samples := []*orderedmap.OrderedMap{
+		parse(t, `{"event":"login","user":{"id":123,"name":"John","address":{"city":"Prague"}},"tags":["a","b"]}`),
+		parse(t, `{"event":"logout","user":{"id":456},"duration":1.5,"weird key":true}`),
+		parse(t, `{"id":"abc","meta":{"x.y":1}}`),
+	}
+
+	assert.Equal(t, column.Columns{
+		column.UUID{Name: "id", PrimaryKey: true},
+		column.Datetime{Name: "datetime"},
+		column.Body{Name: "body"},
+		template("event", `Body("event", "")`),
+		template("user_id", `Body("user.id", "")`),
+		template("user_name", `Body("user.name", "")`),
+		template("user_address", `Body("user.address", "")`),
+		template("tags", `Body("tags", "")`),
+		template("duration", `Body("duration", "")`),
+		template("id_2", `Body("id", "")`),
+		template("meta", `Body("meta", "")`),
+	}, infer.Columns(samples, infer.Config{MaxDepth: 2}))
+}
+
+func TestColumns_MaxDepth(t *testing.T) {
+	t.Parallel()
+
+	samples := []*orderedmap.OrderedMap{
+		parse(t, `{"user":{"id":123,"address":{"city":"Prague"}}}`),
+	}
+
+	assert.Equal(t, column.Columns{
+		column.UUID{Name: "id", PrimaryKey: true},
+		column.Datetime{Name: "datetime"},
+		template("user", `Body("user", "")`),
+	}, infer.Columns(samples, infer.Config{MaxDepth: 1}))
+
+	assert.Equal(t, column.Columns{
+		column.UUID{Name: "id", PrimaryKey: true},
+		column.Datetime{Name: "datetime"},
+		template("user_id", `Body("user.id", "")`),
+		template("user_address_city", `Body("user.address.city", "")`),
+	}, infer.Columns(samples, infer.Config{MaxDepth: 3}))
+}
+
+func TestColumns_ScalarAndObject(t *testing.T) {
+	t.Parallel()
+
+	// The value is an object in one sample and a scalar in another, so it is mapped to one column
+	samples := []*orderedmap.OrderedMap{
+		parse(t, `{"value":{"a":1}}`),
+		parse(t, `{"value":"foo"}`),
+	}
+
+	assert.Equal(t, column.Columns{
+		column.UUID{Name: "id", PrimaryKey: true},
+		column.Datetime{Name: "datetime"},
+		template("value", `Body("value", "")`),
+	}, infer.Columns(samples, infer.Config{MaxDepth: 2}))
+}
+
+func parse(t *testing.T, body string) *orderedmap.OrderedMap {
+	t.Helper()
+	out := orderedmap.New()
+	require.NoError(t, json.Unmarshal([]byte(body), out))
+	return out
+}
+
+func template(name, content string) column.Template {
+	return column.Template{
+		Name:      name,
+		RawString: true,
+		Template:  column.TemplateConfig{Language: column.TemplateLanguageJsonnet, Content: content},
+	}
+}
+
+func TestColumns_Render(t *testing.T) {
+	t.Parallel()
+
+	samples := []*orderedmap.OrderedMap{
+		parse(t, `{"event":"login","user":{"id":123}}`),
+		parse(t, `{"event":"logout"}`),
+	}
+	columns := infer.Columns(samples, infer.Config{MaxDepth: 2})
+
+	// Missing values don't fail the mapping
+	headers := orderedmap.New()
+	headers.Set("Content-Type", "application/json")
+	recordCtx := recordctx.FromStored(t.Context(), time.Now(), nil, headers, []byte(`{"event":"logout"}`))
+	renderer := column.NewRenderer()
+	var values []any
+	for _, c := range columns[2:] {
+		value, err := renderer.CSVValue(c, recordCtx)
+		require.NoError(t, err)
+		values = append(values, value)
+	}
+	assert.Equal(t, []any{"logout", ""}, values)
+}
@@ -16,6 +16,8 @@ type File struct {
 	model.FileKey
 	IsEmpty  bool
 	Provider targetModel.Provider
+	// Reimport is set, if the imported file is being imported again.
+	Reimport *model.FileReimport
 }
 
 // ErrWaitForImportOperationDeadlineExceeded is a sentinel error used when waiting for import operation times out.
@@ -1,19 +1,29 @@
 package sink
 
 import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deduplication"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/webhooksink"
 )
 
 type Config struct {
-	Table tablesink.Config `configKey:"table"`
+	Table         tablesink.Config     `configKey:"table"`
+	Webhook       webhooksink.Config   `configKey:"webhook"`
+	DeadLetter    deadletter.Config    `configKey:"deadLetter"`
+	Deduplication deduplication.Config `configKey:"deduplication"`
 }
 
 type ConfigPatch struct {
-	Table *tablesink.ConfigPatch `json:"table,omitempty"`
+	Table         *tablesink.ConfigPatch     `json:"table,omitempty"`
+	Deduplication *deduplication.ConfigPatch `json:"deduplication,omitempty"`
 }
 
 func NewConfig() Config {
 	return Config{
-		Table: tablesink.NewConfig(),
+		Table:         tablesink.NewConfig(),
+		Webhook:       webhooksink.NewConfig(),
+		DeadLetter:    deadletter.NewConfig(),
+		Deduplication: deduplication.NewConfig(),
 	}
 }
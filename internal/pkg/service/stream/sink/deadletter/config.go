@@ -0,0 +1,19 @@
+package deadletter
+
+import (
+	"github.com/c2h5oh/datasize"
+)
+
+type Config struct {
+	MaxRecords  int               `configKey:"maxRecords" configUsage:"Maximum number of dead-letter records stored per sink, the oldest records are deleted." validate:"required,min=1,max=10000"`
+	MaxBodySize datasize.ByteSize `configKey:"maxBodySize" configUsage:"Maximum size of a stored record body, a longer body is truncated and the record cannot be replayed." validate:"required,minBytes=100B,maxBytes=1MB"`
+	QueueSize   int               `configKey:"queueSize" configUsage:"Maximum number of records waiting to be saved to the database, records over the limit are not stored." validate:"required,min=1,max=100000"`
+}
+
+func NewConfig() Config {
+	return Config{
+		MaxRecords:  100,
+		MaxBodySize: 64 * datasize.KB,
+		QueueSize:   1000,
+	}
+}
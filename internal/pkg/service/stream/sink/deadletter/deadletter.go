@@ -0,0 +1,165 @@
+// Package deadletter stores records which could not be mapped by a sink,
+// for example, if a path is not found in the body or a template fails, see column.RenderError.
+// Records which could not be delivered asynchronously are stored too,
+// for example, by the webhook sink after all retries, or by the Kafka source after the max retries.
+//
+// The storage is enabled per sink by the definition.Sink.DeadLetter field.
+// Records are saved by the sink router, together with the error and the time of the failure.
+// The count of stored records per sink is limited, the oldest records are deleted, see Config.
+//
+// Values of headers with credentials are redacted, so a replayed record doesn't contain them.
+// A stored record can be inspected, purged, or replayed after the mapping of the sink has been fixed.
+// The replay is requested via the API and performed by a source node, see the replay package.
+package deadletter
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// RedactedValue replaces the value of a header with a credential, see the IsCredentialHeader function.
+const RedactedValue = "[redacted]"
+
+// credentialHeaderParts are lower-case parts of names of headers with credentials,
+// for example "Authorization", "X-StorageApi-Token", "X-Hub-Signature-256" or "Cookie".
+var credentialHeaderParts = []string{"auth", "token", "signature", "secret", "password", "api-key", "apikey", "cookie"}
+
+// RecordID is a time-sortable ULID, so records of a sink are listed from the oldest.
+type RecordID string
+
+type RecordKey struct {
+	key.SinkKey
+	RecordID RecordID `json:"recordId" validate:"required,min=1,max=48"`
+}
+
+// Record is a record which could not be mapped or delivered by the sink.
+type Record struct {
+	RecordKey
+	ReceivedAt utctime.UTCTime   `json:"receivedAt" validate:"required"`
+	FailedAt   utctime.UTCTime   `json:"failedAt" validate:"required"`
+	Error      string            `json:"error" validate:"required"`
+	ClientIP   string            `json:"clientIp,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       []byte            `json:"body,omitempty"`
+	// BodyTruncated is true, if the body exceeded the Config.MaxBodySize, such record cannot be replayed.
+	BodyTruncated bool `json:"bodyTruncated,omitempty"`
+}
+
+// ReplayRequest marks a record which should be written to the sink again, see the replay package.
+type ReplayRequest struct {
+	RecordKey
+	RequestedAt utctime.UTCTime `json:"requestedAt" validate:"required"`
+}
+
+// Snapshot is a copy of the raw record, it is taken after a failed write,
+// buffers of the record are kept until then, see recordctx.WithDeferredRelease.
+// Values of headers with credentials are redacted, see the IsCredentialHeader function.
+type Snapshot struct {
+	ReceivedAt    time.Time
+	ClientIP      net.IP
+	Headers       map[string]string
+	Body          []byte
+	BodyTruncated bool
+}
+
+// IsCredentialHeader returns true, if the header may contain a credential, for example a token or a request signature.
+// The value of such header is not stored, so the credential cannot be read by the API.
+func IsCredentialHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range credentialHeaderParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v RecordID) String() string {
+	if v == "" {
+		panic(errors.New("RecordID cannot be empty"))
+	}
+	return string(v)
+}
+
+func (v RecordKey) String() string {
+	return v.SinkKey.String() + "/" + v.RecordID.String()
+}
+
+func (v RecordKey) Telemetry() []attribute.KeyValue {
+	t := v.SinkKey.Telemetry()
+	t = append(t, attribute.String("deadLetter.recordId", v.RecordID.String()))
+	return t
+}
+
+// NewSnapshot copies the raw record, the body is truncated to the maxBodySize.
+func NewSnapshot(c recordctx.Context, maxBodySize datasize.ByteSize) Snapshot {
+	s := Snapshot{
+		ReceivedAt: c.Timestamp(),
+		ClientIP:   c.ClientIP(),
+		Headers:    make(map[string]string),
+	}
+
+	if headers := c.HeadersMap(); headers != nil {
+		for _, k := range headers.Keys() {
+			if v, ok := headers.GetOrNil(k).(string); ok {
+				if IsCredentialHeader(k) {
+					v = RedactedValue
+				}
+				s.Headers[k] = v
+			}
+		}
+	}
+
+	// The body buffer may be reused, so it is copied
+	if body, err := c.BodyBytes(); err == nil {
+		if uint64(len(body)) > maxBodySize.Bytes() {
+			body = body[:maxBodySize.Bytes()]
+			s.BodyTruncated = true
+		}
+		s.Body = bytes.Clone(body)
+	}
+
+	return s
+}
+
+// NewRecord creates the dead-letter record from the snapshot and the mapping error.
+func (s Snapshot) NewRecord(k RecordKey, failedAt time.Time, err error) Record {
+	r := Record{
+		RecordKey:     k,
+		ReceivedAt:    utctime.From(s.ReceivedAt),
+		FailedAt:      utctime.From(failedAt),
+		Error:         err.Error(),
+		Headers:       s.Headers,
+		Body:          s.Body,
+		BodyTruncated: s.BodyTruncated,
+	}
+	if s.ClientIP != nil {
+		r.ClientIP = s.ClientIP.String()
+	}
+	return r
+}
+
+// RecordContext rebuilds the record context for the replay, the original timestamp is kept.
+func (r Record) RecordContext(ctx context.Context) recordctx.Context {
+	headers := orderedmap.New()
+	for k, v := range r.Headers {
+		headers.Set(k, v)
+	}
+	headers.SortKeys(func(keys []string) {
+		sort.Strings(keys)
+	})
+	return recordctx.FromStored(ctx, r.ReceivedAt.Time(), net.ParseIP(r.ClientIP), headers, r.Body)
+}
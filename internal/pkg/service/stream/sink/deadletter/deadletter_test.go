@@ -0,0 +1,47 @@
+package deadletter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"foo":"bar"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "abc")
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("X-StorageApi-Token", "my-token")
+	req.Header.Set("X-Hub-Signature-256", "sha256=abc")
+	req.Header.Set("Cookie", "session=abc")
+
+	s := deadletter.NewSnapshot(recordctx.FromHTTP(now, req), datasize.KB)
+	assert.Equal(t, now, s.ReceivedAt)
+	assert.Equal(t, `{"foo":"bar"}`, string(s.Body))
+	assert.False(t, s.BodyTruncated)
+	assert.Equal(t, map[string]string{
+		"Authorization":       deadletter.RedactedValue,
+		"Content-Type":        "application/json",
+		"Cookie":              deadletter.RedactedValue,
+		"X-Hub-Signature-256": deadletter.RedactedValue,
+		"X-Storageapi-Token":  deadletter.RedactedValue,
+		"X-Tenant":            "abc",
+	}, s.Headers)
+
+	// The body is truncated
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", 200)))
+	s = deadletter.NewSnapshot(recordctx.FromHTTP(now, req), 100*datasize.B)
+	assert.Len(t, s.Body, 100)
+	assert.True(t, s.BodyTruncated)
+}
@@ -0,0 +1,194 @@
+// Package replay writes dead-letter records, which have been marked for the replay via the API, to the sink again.
+//
+// Replay requests are distributed between source nodes using the distribution.Assigner,
+// the distribution key is the record key, so each request is processed by a single node.
+// If the record is written successfully, it is deleted, otherwise the error of the record is updated.
+// In both cases, the replay request is removed.
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	deadLetterRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter/repository"
+	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	distributionGroup = "sink.deadletter.replay"
+)
+
+type dependencies interface {
+	Clock() clockwork.Clock
+	Logger() log.Logger
+	Process() *servicectx.Process
+	DistributionNode() *distribution.Node
+	DeadLetterRepository() *deadLetterRepo.Repository
+	SinkRouter() *sinkRouter.Router
+	Telemetry() telemetry.Telemetry
+	WatchTelemetryInterval() time.Duration
+}
+
+type node struct {
+	clock        clockwork.Clock
+	logger       log.Logger
+	repository   *deadLetterRepo.Repository
+	router       *sinkRouter.Router
+	distribution *distribution.GroupNode
+
+	// requests field contains in-memory snapshot of all replay requests
+	requests *etcdop.MirrorMap[deadletter.ReplayRequest, deadletter.RecordKey, deadletter.ReplayRequest]
+
+	// trigger channel requests processing of replay requests
+	trigger chan struct{}
+}
+
+func Start(ctx context.Context, d dependencies) error {
+	var err error
+	n := &node{
+		clock:      d.Clock(),
+		logger:     d.Logger().WithComponent("deadletter.replay"),
+		repository: d.DeadLetterRepository(),
+		router:     d.SinkRouter(),
+		trigger:    make(chan struct{}, 1),
+	}
+
+	// Join the distribution group
+	n.distribution, err = d.DistributionNode().Group(distributionGroup)
+	if err != nil {
+		return err
+	}
+
+	// Graceful shutdown, an in-flight replay is finished
+	wg := &sync.WaitGroup{}
+	bgCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	d.Process().OnShutdown(func(ctx context.Context) {
+		n.logger.Info(ctx, "shutting down dead-letter replay")
+		cancel(errors.New("shutting down: dead-letter replay"))
+		wg.Wait()
+		n.logger.Info(ctx, "dead-letter replay shutdown done")
+	})
+
+	// Mirror replay requests
+	{
+		n.requests = etcdop.SetupMirrorMap[deadletter.ReplayRequest, deadletter.RecordKey, deadletter.ReplayRequest](
+			n.repository.GetAllAndWatchReplayRequests(bgCtx),
+			func(_ string, request deadletter.ReplayRequest) deadletter.RecordKey {
+				return request.RecordKey
+			},
+			func(_ string, request deadletter.ReplayRequest, rawValue *op.KeyValue, oldValue *deadletter.ReplayRequest) deadletter.ReplayRequest {
+				return request
+			},
+		).
+			WithOnUpdate(func(_ etcdop.MirrorUpdate) {
+				n.triggerReplay()
+			}).
+			BuildMirror()
+		if err = <-n.requests.StartMirroring(bgCtx, wg, n.logger, d.Telemetry(), d.WatchTelemetryInterval()); err != nil {
+			return err
+		}
+	}
+
+	// Requests of a node, which left the group, are processed by another node
+	{
+		wg.Add(1)
+		listener := n.distribution.OnChangeListener()
+
+		go func() {
+			defer wg.Done()
+			defer listener.Stop()
+
+			for {
+				select {
+				case <-bgCtx.Done():
+					return
+				case <-listener.C:
+					n.triggerReplay()
+				}
+			}
+		}()
+	}
+
+	// Process requests sequentially
+	{
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-bgCtx.Done():
+					return
+				case <-n.trigger:
+					n.replayAll(bgCtx)
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (n *node) triggerReplay() {
+	select {
+	case n.trigger <- struct{}{}:
+	default:
+		// processing is already requested
+	}
+}
+
+// replayAll processes all replay requests owned by the node.
+func (n *node) replayAll(ctx context.Context) {
+	assigner := n.distribution.CloneAssigner()
+	for k := range n.requests.CloneMap() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if ok, err := assigner.IsOwner(k.String()); err != nil {
+			n.logger.Errorf(ctx, `cannot check the dead-letter record owner: %s`, err)
+		} else if ok {
+			n.replay(ctx, k)
+		}
+	}
+}
+
+func (n *node) replay(ctx context.Context, k deadletter.RecordKey) {
+	record, err := n.repository.Get(k).Do(ctx).ResultOrErr()
+	if err != nil {
+		// The record has been already replayed or purged, remove the orphaned request
+		if errors.As(err, &svcerrors.ResourceNotFoundError{}) {
+			err = n.repository.Delete(k).Do(ctx).Err()
+		}
+		if err != nil {
+			n.logger.Errorf(ctx, `cannot load dead-letter record "%s": %s`, k, err)
+		}
+		return
+	}
+
+	if replayErr := n.router.ReplayToSink(k.SinkKey, record.RecordContext(ctx)); replayErr != nil {
+		n.logger.Warnf(ctx, `replay of dead-letter record "%s" failed: %s`, k, replayErr)
+		if err := n.repository.ReplayFailed(k, n.clock.Now(), replayErr).Do(ctx).Err(); err != nil {
+			n.logger.Errorf(ctx, `cannot update dead-letter record "%s": %s`, k, err)
+		}
+		return
+	}
+
+	if err := n.repository.Delete(k).Do(ctx).Err(); err != nil {
+		n.logger.Errorf(ctx, `cannot delete replayed dead-letter record "%s": %s`, k, err)
+		return
+	}
+
+	n.logger.Infof(ctx, `dead-letter record "%s" replayed`, k)
+}
@@ -0,0 +1,187 @@
+// Package repository provides database operations with the dead-letter records of sinks, see the deadletter package.
+package repository
+
+import (
+	"context"
+	"time"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	serviceError "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository/sink"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter/repository/schema"
+)
+
+type Repository struct {
+	client  *etcd.Client
+	records schema.Record
+	replays schema.ReplayRequest
+	sinks   *sink.Repository
+	plugins *plugin.Plugins
+}
+
+type dependencies interface {
+	EtcdClient() *etcd.Client
+	EtcdSerde() *serde.Serde
+	DefinitionRepository() *definitionRepo.Repository
+	Plugins() *plugin.Plugins
+}
+
+func New(d dependencies) *Repository {
+	r := &Repository{
+		client:  d.EtcdClient(),
+		records: schema.ForRecord(d.EtcdSerde()),
+		replays: schema.ForReplayRequest(d.EtcdSerde()),
+		sinks:   d.DefinitionRepository().Sink(),
+		plugins: d.Plugins(),
+	}
+
+	r.purgeOnSinkDelete()
+	return r
+}
+
+// Add saves the record, the oldest records of the sink are deleted to keep at most maxRecords records.
+func (r *Repository) Add(record deadletter.Record, maxRecords int) *op.AtomicOp[deadletter.Record] {
+	var existing []string
+	var added deadletter.Record
+	return op.Atomic(r.client, &added).
+		// Sink must exist
+		Read(func(ctx context.Context) op.Op {
+			return r.sinks.ExistsOrErr(record.SinkKey)
+		}).
+		// Load keys of stored records, from the oldest
+		Read(func(ctx context.Context) op.Op {
+			return r.recordKeys(record.SinkKey).WithResultTo(&existing)
+		}).
+		Write(func(ctx context.Context) op.Op {
+			txn := op.Txn(r.client)
+
+			// Delete the oldest records over the limit
+			if overLimit := len(existing) + 1 - maxRecords; overLimit > 0 {
+				for _, k := range existing[:overLimit] {
+					txn.Then(op.NewNoResultOp(
+						r.client,
+						func(_ context.Context) (etcd.Op, error) {
+							return etcd.OpDelete(k), nil
+						},
+						func(_ context.Context, _ *op.RawResponse) error {
+							return nil
+						},
+					))
+				}
+			}
+
+			// Save the record
+			added = record
+			return txn.Then(r.records.ByKey(record.RecordKey).Put(r.client, added))
+		})
+}
+
+// Get returns the record by key.
+func (r *Repository) Get(k deadletter.RecordKey) op.WithResult[deadletter.Record] {
+	return r.records.ByKey(k).GetOrErr(r.client).
+		WithEmptyResultAsError(func() error {
+			return serviceError.NewResourceNotFoundError("record", k.RecordID.String(), "sink")
+		})
+}
+
+// List records by the parent key (SinkKey, SourceKey, BranchKey, ProjectID), from the oldest.
+func (r *Repository) List(parentKey any, opts ...iterator.Option) iterator.DefinitionT[deadletter.Record] {
+	return r.records.In(parentKey).GetAll(r.client, opts...)
+}
+
+// Delete removes the record and the replay request, if any.
+func (r *Repository) Delete(k deadletter.RecordKey) *op.TxnOp[op.NoResult] {
+	return op.Txn(r.client).
+		Then(r.records.ByKey(k).Delete(r.client)).
+		Then(r.replays.ByKey(k).Delete(r.client))
+}
+
+// Purge removes all records of the sink, and the replay requests.
+func (r *Repository) Purge(k key.SinkKey) *op.TxnOp[op.NoResult] {
+	return op.Txn(r.client).
+		Then(r.records.InSink(k).DeleteAll(r.client)).
+		Then(r.replays.InSink(k).DeleteAll(r.client))
+}
+
+// RequestReplay marks the record, it will be written to the sink again by a source node, see the replay package.
+func (r *Repository) RequestReplay(k deadletter.RecordKey, now time.Time) *op.AtomicOp[deadletter.ReplayRequest] {
+	var request deadletter.ReplayRequest
+	return op.Atomic(r.client, &request).
+		// Record must exist
+		Read(func(ctx context.Context) op.Op {
+			return r.Get(k)
+		}).
+		Write(func(ctx context.Context) op.Op {
+			request = deadletter.ReplayRequest{RecordKey: k, RequestedAt: utctime.From(now)}
+			return r.replays.ByKey(k).Put(r.client, request)
+		})
+}
+
+// ReplayFailed updates the error of the record and removes the replay request.
+func (r *Repository) ReplayFailed(k deadletter.RecordKey, now time.Time, replayErr error) *op.AtomicOp[deadletter.Record] {
+	var record deadletter.Record
+	return op.Atomic(r.client, &record).
+		Read(func(ctx context.Context) op.Op {
+			return r.Get(k).WithResultTo(&record)
+		}).
+		Write(func(ctx context.Context) op.Op {
+			record.FailedAt = utctime.From(now)
+			record.Error = replayErr.Error()
+			return op.Txn(r.client).
+				Then(r.records.ByKey(k).Put(r.client, record)).
+				Then(r.replays.ByKey(k).Delete(r.client))
+		})
+}
+
+// ReplayRequest returns the replay request of the record, or nil, if the replay has not been requested.
+func (r *Repository) ReplayRequest(k deadletter.RecordKey) op.WithResult[*deadletter.ReplayRequest] {
+	return r.replays.ByKey(k).GetOrNil(r.client)
+}
+
+// ListReplayRequests by the parent key (SinkKey, SourceKey, BranchKey, ProjectID).
+func (r *Repository) ListReplayRequests(parentKey any, opts ...iterator.Option) iterator.DefinitionT[deadletter.ReplayRequest] {
+	return r.replays.In(parentKey).GetAll(r.client, opts...)
+}
+
+// GetAllAndWatchReplayRequests streams replay requests of all sinks.
+func (r *Repository) GetAllAndWatchReplayRequests(ctx context.Context, opts ...etcd.OpOption) *etcdop.RestartableWatchStreamT[deadletter.ReplayRequest] {
+	return r.replays.GetAllAndWatch(ctx, r.client, opts...)
+}
+
+func (r *Repository) purgeOnSinkDelete() {
+	r.plugins.Collection().OnSinkDelete(func(ctx context.Context, now time.Time, by definition.By, original, deleted *definition.Sink) error {
+		op.AtomicOpCtxFrom(ctx).Write(func(ctx context.Context) op.Op {
+			return r.Purge(deleted.SinkKey)
+		})
+		return nil
+	})
+}
+
+// recordKeys loads only keys of the sink records, bodies are not needed, from the oldest.
+func (r *Repository) recordKeys(k key.SinkKey) op.WithResult[[]string] {
+	pfx := r.records.InSink(k).Prefix()
+	return op.NewForType(
+		r.client,
+		func(_ context.Context) (etcd.Op, error) {
+			return etcd.OpGet(pfx, etcd.WithPrefix(), etcd.WithKeysOnly(), etcd.WithSort(etcd.SortByKey, etcd.SortAscend)), nil
+		},
+		func(_ context.Context, raw *op.RawResponse) ([]string, error) {
+			keys := make([]string, 0, len(raw.Get().Kvs))
+			for _, kv := range raw.Get().Kvs {
+				keys = append(keys, string(kv.Key))
+			}
+			return keys, nil
+		},
+	)
+}
@@ -0,0 +1,137 @@
+package repository_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	now := utctime.MustParse("2000-01-01T01:00:00.000Z").Time()
+	by := test.ByUser()
+
+	d, _ := dependencies.NewMockedServiceScope(t, ctx)
+	repo := d.DeadLetterRepository()
+
+	// Fixtures
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}
+	newRecord := func(id deadletter.RecordID) deadletter.Record {
+		return deadletter.Record{
+			RecordKey:  deadletter.RecordKey{SinkKey: sinkKey, RecordID: id},
+			ReceivedAt: utctime.From(now),
+			FailedAt:   utctime.From(now),
+			Error:      "some error",
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       []byte(`{"foo":"bar"}`),
+		}
+	}
+
+	// Add - parent not found
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		if err := repo.Add(newRecord("001"), 2).Do(ctx).Err(); assert.Error(t, err) {
+			assert.Equal(t, `branch "456" not found in the project`, err.Error())
+			serviceErrors.AssertErrorStatusCode(t, http.StatusNotFound, err)
+		}
+	}
+
+	// Create sink
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		branch := test.NewBranch(branchKey)
+		require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, by).Do(ctx).Err())
+		source := test.NewSource(sourceKey)
+		require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, by, "Create source").Do(ctx).Err())
+		sink := test.NewWebhookSink(sinkKey, "https://example.com")
+		require.NoError(t, d.DefinitionRepository().Sink().Create(&sink, now, by, "Create sink").Do(ctx).Err())
+	}
+
+	// Add - the oldest records over the limit are deleted
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		require.NoError(t, repo.Add(newRecord("001"), 2).Do(ctx).Err())
+		require.NoError(t, repo.Add(newRecord("002"), 2).Do(ctx).Err())
+		require.NoError(t, repo.Add(newRecord("003"), 2).Do(ctx).Err())
+		records, err := repo.List(sinkKey).Do(ctx).All()
+		require.NoError(t, err)
+		if assert.Len(t, records, 2) {
+			assert.Equal(t, deadletter.RecordID("002"), records[0].RecordID)
+			assert.Equal(t, deadletter.RecordID("003"), records[1].RecordID)
+		}
+	}
+
+	// Get - not found
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		k := deadletter.RecordKey{SinkKey: sinkKey, RecordID: "001"}
+		if err := repo.Get(k).Do(ctx).Err(); assert.Error(t, err) {
+			assert.Equal(t, `record "001" not found in the sink`, err.Error())
+			serviceErrors.AssertErrorStatusCode(t, http.StatusNotFound, err)
+		}
+		if err := repo.RequestReplay(k, now).Do(ctx).Err(); assert.Error(t, err) {
+			assert.Equal(t, `record "001" not found in the sink`, err.Error())
+		}
+	}
+
+	// RequestReplay, ReplayFailed
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		k := deadletter.RecordKey{SinkKey: sinkKey, RecordID: "002"}
+		require.NoError(t, repo.RequestReplay(k, now).Do(ctx).Err())
+		request, err := repo.ReplayRequest(k).Do(ctx).ResultOrErr()
+		require.NoError(t, err)
+		assert.NotNil(t, request)
+
+		failedAt := now.Add(time.Hour)
+		record, err := repo.ReplayFailed(k, failedAt, errors.New("another error")).Do(ctx).ResultOrErr()
+		require.NoError(t, err)
+		assert.Equal(t, "another error", record.Error)
+		assert.Equal(t, utctime.From(failedAt), record.FailedAt)
+		request, err = repo.ReplayRequest(k).Do(ctx).ResultOrErr()
+		require.NoError(t, err)
+		assert.Nil(t, request)
+	}
+
+	// Delete
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		k := deadletter.RecordKey{SinkKey: sinkKey, RecordID: "002"}
+		require.NoError(t, repo.RequestReplay(k, now).Do(ctx).Err())
+		require.NoError(t, repo.Delete(k).Do(ctx).Err())
+		requests, err := repo.ListReplayRequests(sinkKey).Do(ctx).All()
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+		records, err := repo.List(sinkKey).Do(ctx).All()
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+	}
+
+	// Purge on sink delete
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		require.NoError(t, repo.RequestReplay(deadletter.RecordKey{SinkKey: sinkKey, RecordID: "003"}, now).Do(ctx).Err())
+		require.NoError(t, d.DefinitionRepository().Sink().SoftDelete(sinkKey, now, by).Do(ctx).Err())
+		records, err := repo.List(sinkKey).Do(ctx).All()
+		require.NoError(t, err)
+		assert.Empty(t, records)
+		requests, err := repo.ListReplayRequests(sinkKey).Do(ctx).All()
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+	}
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type (
+	// Record is an etcd prefix that stores records which could not be mapped by a sink.
+	Record struct {
+		etcdop.PrefixT[deadletter.Record]
+	}
+	// ReplayRequest is an etcd prefix that stores records waiting for the replay.
+	ReplayRequest struct {
+		etcdop.PrefixT[deadletter.ReplayRequest]
+	}
+)
+
+func ForRecord(s *serde.Serde) Record {
+	return Record{PrefixT: etcdop.NewTypedPrefix[deadletter.Record]("deadletter/record", s)}
+}
+
+func ForReplayRequest(s *serde.Serde) ReplayRequest {
+	return ReplayRequest{PrefixT: etcdop.NewTypedPrefix[deadletter.ReplayRequest]("deadletter/replay", s)}
+}
+
+func (r Record) In(objectKey any) etcdop.PrefixT[deadletter.Record] {
+	return r.Add(parentKey(objectKey))
+}
+
+func (r Record) InSink(k key.SinkKey) etcdop.PrefixT[deadletter.Record] {
+	return r.Add(k.String())
+}
+
+func (r Record) ByKey(k deadletter.RecordKey) etcdop.KeyT[deadletter.Record] {
+	return r.Key(k.String())
+}
+
+func (r ReplayRequest) In(objectKey any) etcdop.PrefixT[deadletter.ReplayRequest] {
+	return r.Add(parentKey(objectKey))
+}
+
+func (r ReplayRequest) InSink(k key.SinkKey) etcdop.PrefixT[deadletter.ReplayRequest] {
+	return r.Add(k.String())
+}
+
+func (r ReplayRequest) ByKey(k deadletter.RecordKey) etcdop.KeyT[deadletter.ReplayRequest] {
+	return r.Key(k.String())
+}
+
+func parentKey(objectKey any) string {
+	switch k := objectKey.(type) {
+	case keboola.ProjectID:
+		return k.String()
+	case key.BranchKey:
+		return k.String()
+	case key.SourceKey:
+		return k.String()
+	case key.SinkKey:
+		return k.String()
+	default:
+		panic(errors.Errorf(`unexpected dead-letter record parent key type "%T"`, objectKey))
+	}
+}
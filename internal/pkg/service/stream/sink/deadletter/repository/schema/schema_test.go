@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+)
+
+func TestDeadLetterSchema(t *testing.T) {
+	t.Parallel()
+	s := serde.NewJSON(serde.NoValidation)
+	records := ForRecord(s)
+	replays := ForReplayRequest(s)
+
+	sinkKey := key.SinkKey{
+		SourceKey: key.SourceKey{
+			BranchKey: key.BranchKey{ProjectID: 123, BranchID: 456},
+			SourceID:  "my-source",
+		},
+		SinkID: "my-sink",
+	}
+
+	recordKey := deadletter.RecordKey{
+		SinkKey:  sinkKey,
+		RecordID: "01HZ3FQ4Y6V1N9J8T7K5M2C0XR",
+	}
+
+	cases := []struct{ actual, expected string }{
+		{
+			records.Prefix(),
+			"deadletter/record/",
+		},
+		{
+			records.In(recordKey.ProjectID).Prefix(),
+			"deadletter/record/123/",
+		},
+		{
+			records.In(recordKey.BranchKey).Prefix(),
+			"deadletter/record/123/456/",
+		},
+		{
+			records.In(recordKey.SourceKey).Prefix(),
+			"deadletter/record/123/456/my-source/",
+		},
+		{
+			records.In(recordKey.SinkKey).Prefix(),
+			"deadletter/record/123/456/my-source/my-sink/",
+		},
+		{
+			records.InSink(recordKey.SinkKey).Prefix(),
+			"deadletter/record/123/456/my-source/my-sink/",
+		},
+		{
+			records.ByKey(recordKey).Key(),
+			"deadletter/record/123/456/my-source/my-sink/01HZ3FQ4Y6V1N9J8T7K5M2C0XR",
+		},
+		{
+			replays.Prefix(),
+			"deadletter/replay/",
+		},
+		{
+			replays.In(recordKey.SourceKey).Prefix(),
+			"deadletter/replay/123/456/my-source/",
+		},
+		{
+			replays.InSink(recordKey.SinkKey).Prefix(),
+			"deadletter/replay/123/456/my-source/my-sink/",
+		},
+		{
+			replays.ByKey(recordKey).Key(),
+			"deadletter/replay/123/456/my-source/my-sink/01HZ3FQ4Y6V1N9J8T7K5M2C0XR",
+		},
+	}
+
+	for i, c := range cases {
+		assert.Equal(t, c.expected, c.actual, `case "%d"`, i+1)
+	}
+}
+
+func TestDeadLetterSchema_In(t *testing.T) {
+	t.Parallel()
+	s := ForRecord(serde.NewJSON(serde.NoValidation))
+	assert.Panics(t, func() {
+		s.In("unexpected type")
+	})
+}
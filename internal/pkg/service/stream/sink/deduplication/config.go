@@ -0,0 +1,50 @@
+package deduplication
+
+import (
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+)
+
+const (
+	// KeyHeader deduplicates records by the value of the Config.Header, for example "Idempotency-Key".
+	KeyHeader = KeyType("header")
+	// KeyPrimaryKey deduplicates records by the rendered primary key columns of the table sink mapping.
+	KeyPrimaryKey = KeyType("primaryKey")
+)
+
+type KeyType string
+
+// Config configures the deduplication window of a sink.
+// The window is disabled by default, it can be enabled per sink by the Sink.Config patch.
+type Config struct {
+	Window          duration.Duration `json:"window" configKey:"window" configUsage:"Records with the same key received within the window are written only once, 0 disables deduplication." validate:"maxDuration=24h" modAllowed:"true"`
+	Key             KeyType           `json:"key" configKey:"key" configUsage:"Deduplication key: \"header\" or \"primaryKey\" of the table mapping." validate:"required,oneof=header primaryKey" modAllowed:"true"`
+	Header          string            `json:"header" configKey:"header" configUsage:"Name of the header used as the deduplication key, if the key is \"header\"." validate:"required" modAllowed:"true"`
+	InFlightTimeout duration.Duration `json:"inFlightTimeout" configKey:"inFlightTimeout" configUsage:"Max time a duplicate waits for the result of the original record, which is still being written." validate:"required,minDuration=1s,maxDuration=5m"`
+	MaxKeys         int               `json:"maxKeys" configKey:"maxKeys" configUsage:"Maximum number of keys in the window of a sink per source node, the oldest keys are evicted." validate:"required,min=1,max=10000000"`
+}
+
+// ConfigPatch is same as the Config, but with optional/nullable fields.
+// It may be part of a Sink definition to allow modification of the default configuration.
+type ConfigPatch struct {
+	Window *duration.Duration `json:"window,omitempty"`
+	Key    *KeyType           `json:"key,omitempty"`
+	Header *string            `json:"header,omitempty"`
+}
+
+// NewConfig returns the default configuration, the deduplication is disabled.
+func NewConfig() Config {
+	return Config{
+		Window:          duration.From(0),
+		Key:             KeyHeader,
+		Header:          "Idempotency-Key",
+		InFlightTimeout: duration.From(30 * time.Second),
+		MaxKeys:         100000,
+	}
+}
+
+// Enabled returns true, if the deduplication window is set.
+func (c Config) Enabled() bool {
+	return c.Window.Duration() > 0
+}
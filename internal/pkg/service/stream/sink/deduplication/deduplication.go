@@ -0,0 +1,38 @@
+// Package deduplication provides an optional per-sink deduplication window.
+//
+// Clients may retry a request, if they don't receive a response, so the same record can be written to a file multiple times.
+// Primary key of a table is applied at the import to the table, but it doesn't help with duplicates inside one file.
+//
+// The window is enabled per sink by the Sink.Config patch, see Config.
+// Records are deduplicated by the value of a header, for example "Idempotency-Key", or by the rendered primary key columns.
+// The body is not part of the header key, so a retry with a modified body is a duplicate too,
+// only items of a batch request are distinguished by their index.
+//
+// Records are encoded by the source node, a disk writer node receives only encoded chunks of slices,
+// so keys are checked by the sink router of the source node, before the record is written to the sink pipeline.
+// Keys are stored in a bounded in-memory Window per sink, there is no database operation per record.
+// The window is per source node, a retry received by another source node is not detected.
+// The window is lost on a restart of the source node.
+// A key is added, when the record has been written, so the key of a failed write is not added and a retry is written again.
+// A duplicate gets the status of the original record. If the original record is still being written,
+// the duplicate waits for its result, at most for the Config.InFlightTimeout, then a retryable error is returned.
+//
+// A key expires after the Config.Window. The memory usage is bounded by the Config.MaxKeys per sink,
+// the oldest keys are evicted first, so under a high load the effective window may be shorter.
+package deduplication
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Key is a fixed size hash of the deduplication key, so the memory usage of the Window is bounded.
+type Key [sha256.Size]byte
+
+func NewKey(value string) Key {
+	return sha256.Sum256([]byte(value))
+}
+
+func (k Key) String() string {
+	return hex.EncodeToString(k[:])
+}
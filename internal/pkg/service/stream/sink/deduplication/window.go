@@ -0,0 +1,88 @@
+package deduplication
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Window is a bounded in-memory window of keys of records written to one sink.
+// A key expires after the Config.Window, the oldest keys are evicted, if there are more than Config.MaxKeys keys.
+type Window struct {
+	lock    sync.Mutex
+	maxKeys int
+	items   map[Key]*list.Element
+	// order of keys from the oldest to the newest, it is used for the expiration and eviction
+	order *list.List
+}
+
+type windowItem struct {
+	key       Key
+	expiresAt time.Time
+	processed bool
+}
+
+func NewWindow(maxKeys int) *Window {
+	return &Window{
+		maxKeys: maxKeys,
+		items:   make(map[Key]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns true, if the key has been written within the window.
+// The processed flag is true, if the record has been processed, otherwise it has been only accepted, see pipeline.RecordStatus.
+func (w *Window) Get(now time.Time, k Key) (processed bool, found bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.expire(now)
+
+	if el := w.items[k]; el != nil {
+		item := el.Value.(*windowItem)
+		if now.Before(item.expiresAt) {
+			return item.processed, true
+		}
+	}
+
+	return false, false
+}
+
+// Add adds the key of a written record to the window, the key expires after the ttl.
+func (w *Window) Add(now time.Time, k Key, ttl time.Duration, processed bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.expire(now)
+
+	if el := w.items[k]; el != nil {
+		w.order.Remove(el)
+	}
+
+	w.items[k] = w.order.PushBack(&windowItem{key: k, expiresAt: now.Add(ttl), processed: processed})
+
+	// Evict the oldest keys
+	for w.order.Len() > w.maxKeys {
+		w.remove(w.order.Front())
+	}
+}
+
+// Len returns count of keys in the window, including expired keys, which have not been removed yet.
+func (w *Window) Len() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.order.Len()
+}
+
+// expire removes expired keys from the start of the window.
+// The ttl may be modified by a sink update, so a key may be removed a little later, the Get method checks the expiration of the key.
+func (w *Window) expire(now time.Time) {
+	for el := w.order.Front(); el != nil && !now.Before(el.Value.(*windowItem).expiresAt); el = w.order.Front() {
+		w.remove(el)
+	}
+}
+
+func (w *Window) remove(el *list.Element) {
+	w.order.Remove(el)
+	delete(w.items, el.Value.(*windowItem).key)
+}
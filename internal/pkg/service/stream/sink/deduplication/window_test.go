@@ -0,0 +1,54 @@
+package deduplication_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deduplication"
+)
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	now := utctime.MustParse("2000-01-01T01:00:00.000Z").Time()
+	key1 := deduplication.NewKey("header:key1")
+	key2 := deduplication.NewKey("header:key2")
+	key3 := deduplication.NewKey("header:key3")
+	w := deduplication.NewWindow(2)
+
+	// Unknown key
+	_, found := w.Get(now, key1)
+	assert.False(t, found)
+
+	// Added keys
+	w.Add(now, key1, time.Minute, false)
+	w.Add(now.Add(10*time.Second), key2, time.Minute, true)
+	processed, found := w.Get(now, key1)
+	assert.True(t, found)
+	assert.False(t, processed)
+	processed, found = w.Get(now, key2)
+	assert.True(t, found)
+	assert.True(t, processed)
+
+	// The key expires after the ttl
+	_, found = w.Get(now.Add(time.Minute), key1)
+	assert.False(t, found)
+	assert.Equal(t, 1, w.Len())
+	_, found = w.Get(now.Add(time.Minute), key2)
+	assert.True(t, found)
+
+	// The oldest key is evicted, if the window is full
+	now = now.Add(time.Minute)
+	w.Add(now, key1, time.Minute, false)
+	w.Add(now, key3, time.Minute, false)
+	assert.Equal(t, 2, w.Len())
+	_, found = w.Get(now, key2)
+	assert.False(t, found)
+	_, found = w.Get(now, key1)
+	assert.True(t, found)
+	_, found = w.Get(now, key3)
+	assert.True(t, found)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deduplication"
 )
 
 type collection struct {
@@ -22,6 +24,15 @@ type sinkData struct {
 	sinkType       definition.SinkType
 	enabled        bool
 	allowedSignals []string
+	filter         string
+	deadLetter     bool
+	// deduplication is the effective configuration, with modifications from the Sink.Config, see deduplicationConfig
+	deduplication deduplication.Config
+	// primaryKey contains primary key columns of the table mapping, they may be used as the deduplication key
+	primaryKey column.Columns
+	// mirror is a secondary destination of the sink, see definition.TableMirror,
+	// records accepted by the sink filter are written to both pipelines.
+	mirror *sinkData
 }
 
 func newCollection() *collection {
@@ -59,6 +70,15 @@ func (c *collection) sink(sinkKey key.SinkKey) (*sinkData, bool) {
 		return nil, false
 	}
 
+	// Mirror is stored as a part of the owner sink
+	if sinkKey.IsMirror() {
+		owner := source.sinks[sinkKey.Owner()]
+		if owner == nil || owner.mirror == nil {
+			return nil, false
+		}
+		return owner.mirror, true
+	}
+
 	sink := source.sinks[sinkKey]
 	if sink == nil {
 		return nil, false
@@ -67,7 +87,7 @@ func (c *collection) sink(sinkKey key.SinkKey) (*sinkData, bool) {
 	return sink, true
 }
 
-func (c *collection) addSink(sink definition.Sink) {
+func (c *collection) addSink(sink definition.Sink, dedupConfig deduplication.Config) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
@@ -77,12 +97,33 @@ func (c *collection) addSink(sink definition.Sink) {
 		c.sources[sink.SourceKey] = source
 	}
 
-	source.sinks[sink.SinkKey] = &sinkData{
+	data := &sinkData{
 		sinkKey:        sink.SinkKey,
 		sinkType:       sink.Type,
 		enabled:        sink.IsEnabled(),
 		allowedSignals: sink.AllowedSignals,
+		filter:         sink.Filter,
+		deadLetter:     sink.DeadLetter,
+		deduplication:  dedupConfig,
+	}
+
+	if sink.Table != nil {
+		for _, col := range sink.Table.Mapping.Columns {
+			if col.IsPrimaryKey() {
+				data.primaryKey = append(data.primaryKey, col)
+			}
+		}
 	}
+
+	if mirror := sink.MirrorSink(); mirror != nil {
+		data.mirror = &sinkData{
+			sinkKey:  mirror.SinkKey,
+			sinkType: mirror.Type,
+			enabled:  mirror.IsEnabled(),
+		}
+	}
+
+	source.sinks[sink.SinkKey] = data
 }
 
 func (c *collection) deleteSink(sinkKey key.SinkKey) {
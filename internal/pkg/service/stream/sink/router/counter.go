@@ -0,0 +1,162 @@
+package router
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	statsRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// recordsCounter counts records which are not written to a sink, for example records dropped by the sink filter.
+// The counts are periodically saved to the database.
+type recordsCounter struct {
+	logger    log.Logger
+	config    statistics.SyncConfig
+	nodeID    string
+	name      string
+	lastValue func(k key.SinkKey, nodeID string) op.WithResult[statistics.Value]
+	put       func(ctx context.Context, nodeID string, stats []statistics.PerSink) error
+
+	syncLock *sync.Mutex
+	lock     *sync.Mutex
+	sinks    map[key.SinkKey]*counterSnapshot
+}
+
+type counterSnapshot struct {
+	// initialValue is loaded from the database before the first sync, the node may continue after a restart
+	initialValue *statistics.Value
+	// value contains records counted since the start of the node
+	value   statistics.Value
+	changed bool
+}
+
+// newDroppedRecords counts records dropped by sink filters.
+func newDroppedRecords(logger log.Logger, repository *statsRepo.Repository, config statistics.SyncConfig, nodeID string) *recordsCounter {
+	return newRecordsCounter(logger, config, nodeID, "dropped records", repository.LastDroppedNodeValue, repository.PutDropped)
+}
+
+// newDeduplicatedRecords counts duplicate records skipped by sink deduplication windows, see the deduplication package.
+func newDeduplicatedRecords(logger log.Logger, repository *statsRepo.Repository, config statistics.SyncConfig, nodeID string) *recordsCounter {
+	return newRecordsCounter(logger, config, nodeID, "deduplicated records", repository.LastDeduplicatedNodeValue, repository.PutDeduplicated)
+}
+
+func newRecordsCounter(
+	logger log.Logger,
+	config statistics.SyncConfig,
+	nodeID string,
+	name string,
+	lastValue func(k key.SinkKey, nodeID string) op.WithResult[statistics.Value],
+	put func(ctx context.Context, nodeID string, stats []statistics.PerSink) error,
+) *recordsCounter {
+	return &recordsCounter{
+		logger:    logger,
+		config:    config,
+		nodeID:    nodeID,
+		name:      name,
+		lastValue: lastValue,
+		put:       put,
+		syncLock:  &sync.Mutex{},
+		lock:      &sync.Mutex{},
+		sinks:     make(map[key.SinkKey]*counterSnapshot),
+	}
+}
+
+func (d *recordsCounter) add(sinkKey key.SinkKey, timestamp time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	s := d.sinks[sinkKey]
+	if s == nil {
+		s = &counterSnapshot{}
+		d.sinks[sinkKey] = s
+	}
+
+	at := utctime.From(timestamp)
+	if s.value.FirstRecordAt.IsZero() || s.value.FirstRecordAt.After(at) {
+		s.value.FirstRecordAt = at
+	}
+	if at.After(s.value.LastRecordAt) {
+		s.value.LastRecordAt = at
+	}
+	s.value.RecordsCount++
+	s.changed = true
+}
+
+// sync saves changed counts to the database.
+func (d *recordsCounter) sync(ctx context.Context) error {
+	d.syncLock.Lock()
+	defer d.syncLock.Unlock()
+
+	ctx, cancel := context.WithTimeoutCause(ctx, d.config.SyncTimeout.Duration(), errors.Errorf("%s sync timeout", d.name))
+	defer cancel()
+
+	// Collect changed sinks
+	d.lock.Lock()
+	changed := make(map[key.SinkKey]*counterSnapshot)
+	for sinkKey, s := range d.sinks {
+		if s.changed {
+			changed[sinkKey] = s
+		}
+	}
+	d.lock.Unlock()
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// Load initial values, outside the lock
+	for sinkKey, s := range changed {
+		if s.initialValue == nil {
+			initialValue, err := d.lastValue(sinkKey, d.nodeID).Do(ctx).ResultOrErr()
+			if err != nil {
+				err = errors.Errorf("cannot load %s statistics: %w", d.name, err)
+				d.logger.Error(ctx, err.Error())
+				return err
+			}
+			s.initialValue = &initialValue
+		}
+	}
+
+	// Compose values
+	d.lock.Lock()
+	forSync := make([]statistics.PerSink, 0, len(changed))
+	for sinkKey, s := range changed {
+		value := s.initialValue.With(s.value)
+		forSync = append(forSync, statistics.PerSink{
+			SinkKey:       sinkKey,
+			FirstRecordAt: value.FirstRecordAt,
+			LastRecordAt:  value.LastRecordAt,
+			RecordsCount:  value.RecordsCount,
+		})
+		s.changed = false
+	}
+	d.lock.Unlock()
+
+	// Sort slice for easier debugging
+	sort.SliceStable(forSync, func(i, j int) bool {
+		return forSync[i].SinkKey.String() < forSync[j].SinkKey.String()
+	})
+
+	if err := d.put(ctx, d.nodeID, forSync); err != nil {
+		// Mark the values as changed again, so they are saved on the next sync
+		d.lock.Lock()
+		for _, s := range changed {
+			s.changed = true
+		}
+		d.lock.Unlock()
+
+		err = errors.Errorf("cannot save %s statistics to the database: %w", d.name, err)
+		d.logger.Error(ctx, err.Error())
+		return err
+	}
+
+	return nil
+}
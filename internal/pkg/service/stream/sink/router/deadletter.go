@@ -0,0 +1,114 @@
+package router
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	deadLetterRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/ulid"
+)
+
+// deadLetters saves records which cannot be mapped by a sink with the enabled dead-letter storage, see the deadletter package.
+// Records are saved in the background, so a failed request is not slowed down by the database.
+// If the queue is full, the record is not stored and a warning is logged.
+type deadLetters struct {
+	config      deadletter.Config
+	clock       clockwork.Clock
+	logger      log.Logger
+	repository  *deadLetterRepo.Repository
+	idGenerator ulid.Generator
+
+	lock   sync.RWMutex
+	closed bool
+	queue  chan deadletter.Record
+	done   chan struct{}
+}
+
+func newDeadLetters(config deadletter.Config, clock clockwork.Clock, logger log.Logger, repository *deadLetterRepo.Repository, idGenerator ulid.Generator) *deadLetters {
+	dl := &deadLetters{
+		config:      config,
+		clock:       clock,
+		logger:      logger,
+		repository:  repository,
+		idGenerator: idGenerator,
+		queue:       make(chan deadletter.Record, config.QueueSize),
+		done:        make(chan struct{}),
+	}
+
+	go func() {
+		defer close(dl.done)
+		ctx := context.Background()
+		for record := range dl.queue {
+			if err := dl.repository.Add(record, dl.config.MaxRecords).Do(ctx).Err(); err != nil {
+				dl.logger.Errorf(ctx, `cannot save dead-letter record "%s": %s`, record.RecordKey, err)
+			}
+		}
+	}()
+
+	return dl
+}
+
+// enabled returns true, if a sink of the source may store the raw record.
+func (dl *deadLetters) enabled(source *sourceData) bool {
+	for _, sink := range source.sinks {
+		if sink.enabled && sink.deadLetter {
+			return true
+		}
+	}
+	return false
+}
+
+// add enqueues the failed record, if the error is a mapping error.
+// The raw record is copied only now, buffers of the record must not be released yet, see recordctx.WithDeferredRelease.
+func (dl *deadLetters) add(ctx context.Context, sinkKey key.SinkKey, c recordctx.Context, err error) {
+	if !errors.As(err, &column.RenderError{}) {
+		return
+	}
+
+	snapshot := deadletter.NewSnapshot(c, dl.config.MaxBodySize)
+	dl.enqueue(ctx, sinkKey, &snapshot, err)
+}
+
+// addMirrorError enqueues the record, which has been written to the sink, but not to the sink mirror.
+// The record is stored under the key of the owner sink, so it is listed with other failed records of the sink.
+// False is returned, if the record cannot be queued.
+func (dl *deadLetters) addMirrorError(ctx context.Context, sinkKey key.SinkKey, c recordctx.Context, err error) bool {
+	snapshot := deadletter.NewSnapshot(c, dl.config.MaxBodySize)
+	return dl.enqueue(ctx, sinkKey, &snapshot, errors.PrefixError(err, "cannot write record to the sink mirror"))
+}
+
+// enqueue enqueues the failed record, regardless of the error type.
+// False is returned, if the record cannot be queued.
+func (dl *deadLetters) enqueue(ctx context.Context, sinkKey key.SinkKey, snapshot *deadletter.Snapshot, err error) bool {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+	if dl.closed {
+		return false
+	}
+
+	k := deadletter.RecordKey{SinkKey: sinkKey, RecordID: deadletter.RecordID(dl.idGenerator.NewULID())}
+	select {
+	case dl.queue <- snapshot.NewRecord(k, dl.clock.Now(), err):
+		return true
+	default:
+		dl.logger.Warnf(ctx, `dead-letter queue is full, record of the sink "%s" is not stored`, sinkKey)
+		return false
+	}
+}
+
+// close waits until all queued records are saved.
+func (dl *deadLetters) close() {
+	dl.lock.Lock()
+	dl.closed = true
+	close(dl.queue)
+	dl.lock.Unlock()
+	<-dl.done
+}
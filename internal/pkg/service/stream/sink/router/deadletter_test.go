@@ -0,0 +1,95 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestRouter_DeadLetter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	d, mock := dependencies.NewMockedSourceScope(t, ctx)
+	sinkController := mock.TestDummySinkController()
+
+	// Create branch, source and sinks
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	enabledSink := dummy.NewSink(key.SinkKey{SourceKey: sourceKey, SinkID: "enabled"})
+	enabledSink.DeadLetter = true
+	disabledSink := dummy.NewSink(key.SinkKey{SourceKey: sourceKey, SinkID: "disabled"})
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&enabledSink, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&disabledSink, now, test.ByUser(), "create").Do(ctx).Err())
+
+	// Wait for the sink router
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 1, d.SinkRouter().SourcesCount())
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Both sinks fail to map the record
+	sinkController.PipelineWriteError = renderError(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"foo":"bar"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Tenant", "abc")
+	req.Header.Set("Authorization", "Bearer my-secret")
+	result := d.SinkRouter().DispatchToSource(sourceKey, recordctx.FromHTTP(now, req))
+	result.Finalize()
+	assert.Equal(t, 2, result.FailedSinks)
+
+	// Only the sink with the enabled dead-letter storage has the record, it is saved in the background
+	var records []deadletter.Record
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		var err error
+		records, err = d.DeadLetterRepository().List(enabledSink.SinkKey).Do(ctx).All()
+		assert.NoError(c, err)
+		assert.Len(c, records, 1)
+	}, 10*time.Second, 10*time.Millisecond)
+	record := records[0]
+	assert.Equal(t, enabledSink.SinkKey, record.SinkKey)
+	assert.JSONEq(t, `{"foo":"bar"}`, string(record.Body))
+	assert.False(t, record.BodyTruncated)
+	assert.Equal(t, "abc", record.Headers["X-Tenant"])
+	assert.Equal(t, deadletter.RedactedValue, record.Headers["Authorization"])
+	assert.Contains(t, record.Error, `path "missing" not found in the body`)
+	records, err := d.DeadLetterRepository().List(disabledSink.SinkKey).Do(ctx).All()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// Shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+}
+
+func renderError(t *testing.T) error {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	_, err := column.NewRenderer().CSVValue(column.Path{Name: "value", Path: "missing"}, recordctx.FromHTTP(time.Now(), req))
+	require.Error(t, err)
+	require.ErrorAs(t, err, &column.RenderError{})
+	return err
+}
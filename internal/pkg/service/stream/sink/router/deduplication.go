@@ -0,0 +1,178 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deduplication"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+)
+
+// deduplicator skips records already written to a sink within the deduplication window, see the deduplication package.
+// Keys of written records are stored in a bounded in-memory window per sink.
+// Duplicates received while the original record is being written wait for the in-flight write.
+type deduplicator struct {
+	clock    clockwork.Clock
+	renderer *column.Renderer
+
+	lock     sync.Mutex
+	windows  map[key.SinkKey]*deduplication.Window
+	inFlight map[inFlightKey]*inFlightWrite
+}
+
+type inFlightKey struct {
+	sinkKey key.SinkKey
+	key     deduplication.Key
+}
+
+// inFlightWrite is a write of the original record, the done channel is closed, when the write is finished.
+type inFlightWrite struct {
+	done   chan struct{}
+	status pipeline.RecordStatus
+}
+
+// dedupReservation is a reserved key of the record, the result of the write must be reported by the finish method.
+type dedupReservation struct {
+	d     *deduplicator
+	sink  *sinkData
+	key   inFlightKey
+	write *inFlightWrite
+}
+
+func newDeduplicator(clock clockwork.Clock) *deduplicator {
+	return &deduplicator{
+		clock:    clock,
+		renderer: column.NewRenderer(),
+		windows:  make(map[key.SinkKey]*deduplication.Window),
+		inFlight: make(map[inFlightKey]*inFlightWrite),
+	}
+}
+
+// reserve reserves the key of the record, before the record is written.
+// If the record is a duplicate, the status of the original record is returned and the reservation is nil.
+// If the original record is still being written, the method waits for its result, at most for the Config.InFlightTimeout.
+// The record is not deduplicated, if the key cannot be composed, for example, if the header is missing, then both results are nil.
+func (d *deduplicator) reserve(sink *sinkData, c recordctx.Context) (*dedupReservation, *pipeline.RecordStatus, error) {
+	k, found := d.key(sink, c)
+	if !found {
+		return nil, nil, nil
+	}
+
+	ctx, cancel := context.WithTimeoutCause(c.Ctx(), sink.deduplication.InFlightTimeout.Duration(), DuplicateInFlightError{})
+	defer cancel()
+
+	ifk := inFlightKey{sinkKey: sink.sinkKey, key: k}
+	for {
+		d.lock.Lock()
+
+		// The original record has been written within the window
+		if processed, found := d.window(sink).Get(d.clock.Now(), k); found {
+			d.lock.Unlock()
+			status := pipeline.RecordAccepted
+			if processed {
+				status = pipeline.RecordProcessed
+			}
+			return nil, &status, nil
+		}
+
+		// Wait for the original record, which is still being written
+		if original := d.inFlight[ifk]; original != nil {
+			d.lock.Unlock()
+			select {
+			case <-original.done:
+				if original.status != pipeline.RecordError {
+					return nil, &original.status, nil
+				}
+				// The original write has failed, try to reserve the key again
+				continue
+			case <-ctx.Done():
+				return nil, nil, context.Cause(ctx)
+			}
+		}
+
+		write := &inFlightWrite{done: make(chan struct{}), status: pipeline.RecordError}
+		d.inFlight[ifk] = write
+		d.lock.Unlock()
+
+		return &dedupReservation{d: d, sink: sink, key: ifk, write: write}, nil, nil
+	}
+}
+
+// deleteSink drops the window of a deleted sink.
+func (d *deduplicator) deleteSink(sinkKey key.SinkKey) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.windows, sinkKey)
+}
+
+// window returns the window of the sink, the lock must be held.
+func (d *deduplicator) window(sink *sinkData) *deduplication.Window {
+	w := d.windows[sink.sinkKey]
+	if w == nil {
+		w = deduplication.NewWindow(sink.deduplication.MaxKeys)
+		d.windows[sink.sinkKey] = w
+	}
+	return w
+}
+
+// finish reports the write result of the record and notifies local duplicates waiting for the write.
+// The key of a written record is kept for the deduplication window, the key of a failed record is not kept, so a retry is not skipped.
+func (r *dedupReservation) finish(status pipeline.RecordStatus) {
+	r.d.lock.Lock()
+	defer r.d.lock.Unlock()
+
+	if status != pipeline.RecordError {
+		r.d.window(r.sink).Add(r.d.clock.Now(), r.key.key, r.sink.deduplication.Window.Duration(), status == pipeline.RecordProcessed)
+	}
+
+	r.write.status = status
+	close(r.write.done)
+	delete(r.d.inFlight, r.key)
+}
+
+func (d *deduplicator) key(sink *sinkData, c recordctx.Context) (deduplication.Key, bool) {
+	var b strings.Builder
+	b.WriteString(string(sink.deduplication.Key))
+	b.WriteString(":")
+
+	switch sink.deduplication.Key {
+	case deduplication.KeyHeader:
+		value, found := c.HeadersMap().Get(http.CanonicalHeaderKey(sink.deduplication.Header))
+		if !found || value == "" {
+			return deduplication.Key{}, false
+		}
+
+		// Only the header value is the key, so a retry with a modified body is a duplicate too.
+		// Items of a batch request share the header, so the item index is part of the key.
+		_, _ = fmt.Fprintf(&b, "%v", value)
+		if index, ok := recordctx.BatchIndex(c); ok {
+			_, _ = fmt.Fprintf(&b, "\x00%d", index)
+		}
+	case deduplication.KeyPrimaryKey:
+		if len(sink.primaryKey) == 0 {
+			return deduplication.Key{}, false
+		}
+
+		// Rendered values are shared with the sink mapping, see recordctx.WithSharedValues.
+		// If a value cannot be rendered, the record is not deduplicated, the error is reported by the sink pipeline.
+		for _, col := range sink.primaryKey {
+			value, err := d.renderer.CSVValue(col, c)
+			if err != nil {
+				return deduplication.Key{}, false
+			}
+			_, _ = fmt.Fprintf(&b, "%s\x00", value)
+		}
+	default:
+		return deduplication.Key{}, false
+	}
+
+	return deduplication.NewKey(b.String()), true
+}
@@ -0,0 +1,137 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configpatch"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+)
+
+func TestRouter_SinkDeduplication(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	d, mock := dependencies.NewMockedSourceScope(t, ctx)
+	sinkController := mock.TestDummySinkController()
+	sinkController.PipelineWriteRecordStatus = pipeline.RecordProcessed
+
+	// Create branch, source and sink with the deduplication window
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	sink := dummy.NewSink(key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"})
+	sink.Config = configpatch.PatchKVs{{KeyPath: "sink.deduplication.window", Value: "10m"}}
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&sink, now, test.ByUser(), "create").Do(ctx).Err())
+
+	// Wait for the sink router
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 1, d.SinkRouter().SourcesCount())
+	}, 10*time.Second, 10*time.Millisecond)
+
+	dispatch := func(idempotencyKey, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if idempotencyKey != "" {
+			req.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		result := d.SinkRouter().DispatchToSource(sourceKey, recordctx.FromHTTP(now, req))
+		result.Finalize()
+		return result.StatusCode
+	}
+
+	// The first record is written, the retry is skipped
+	assert.Equal(t, http.StatusOK, dispatch("key1", `{"foo":"bar"}`))
+	assert.Equal(t, http.StatusOK, dispatch("key1", `{"foo":"bar"}`))
+	assert.Equal(t, 1, sinkController.WriteCount(sink.SinkKey))
+
+	// Only the header value is the key, a retry with a different body is a duplicate too
+	assert.Equal(t, http.StatusOK, dispatch("key2", `{"foo":"bar"}`))
+	assert.Equal(t, http.StatusOK, dispatch("key1", `{"foo":"baz"}`))
+	assert.Equal(t, 2, sinkController.WriteCount(sink.SinkKey))
+
+	// Records without the header are not deduplicated
+	assert.Equal(t, http.StatusOK, dispatch("", `{"foo":"bar"}`))
+	assert.Equal(t, http.StatusOK, dispatch("", `{"foo":"bar"}`))
+	assert.Equal(t, 4, sinkController.WriteCount(sink.SinkKey))
+
+	// The key of a failed write is released, so the retry is written, the failed write is counted too
+	sinkController.PipelineWriteHook = func(key.SinkKey) error {
+		return errors.New("some error")
+	}
+	assert.Equal(t, http.StatusInternalServerError, dispatch("key3", `{"foo":"bar"}`))
+	sinkController.PipelineWriteHook = nil
+	assert.Equal(t, http.StatusOK, dispatch("key3", `{"foo":"bar"}`))
+	assert.Equal(t, http.StatusOK, dispatch("key3", `{"foo":"bar"}`))
+	assert.Equal(t, 6, sinkController.WriteCount(sink.SinkKey))
+
+	// The duplicate gets the status of the original record
+	sinkController.PipelineWriteRecordStatus = pipeline.RecordAccepted
+	assert.Equal(t, http.StatusAccepted, dispatch("key4", `{"foo":"bar"}`))
+	sinkController.PipelineWriteRecordStatus = pipeline.RecordProcessed
+	assert.Equal(t, http.StatusAccepted, dispatch("key4", `{"foo":"bar"}`))
+	assert.Equal(t, 7, sinkController.WriteCount(sink.SinkKey))
+
+	// The duplicate waits for the original record, which is still being written by the same node
+	writeStarted := make(chan struct{})
+	unblockWrite := make(chan struct{})
+	sinkController.PipelineWriteHook = func(key.SinkKey) error {
+		close(writeStarted)
+		<-unblockWrite
+		return nil
+	}
+	originalStatus := make(chan int)
+	go func() {
+		originalStatus <- dispatch("key5", `{"foo":"bar"}`)
+	}()
+	<-writeStarted
+	duplicateStatus := make(chan int)
+	go func() {
+		duplicateStatus <- dispatch("key5", `{"foo":"bar"}`)
+	}()
+	select {
+	case <-duplicateStatus:
+		assert.Fail(t, "the duplicate should wait for the original record")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(unblockWrite)
+	assert.Equal(t, http.StatusOK, <-originalStatus)
+	assert.Equal(t, http.StatusOK, <-duplicateStatus)
+	sinkController.PipelineWriteHook = nil
+	assert.Equal(t, 8, sinkController.WriteCount(sink.SinkKey))
+
+	// Counts of duplicate records are saved on shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+	etcdhelper.AssertKVsString(t, mock.TestEtcdClient(), `
+<<<<<
+storage/stats/deduplicated/123/456/my-source/my-sink/test-node
+-----
+{
+  "firstRecordAt": "%s",
+  "lastRecordAt": "%s",
+  "recordsCount": 5
+}
+>>>>>
+`, etcdhelper.WithIgnoredKeyPattern(`^definition/|^storage/volume/|^runtime/`))
+}
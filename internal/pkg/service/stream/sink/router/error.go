@@ -17,3 +17,18 @@ func (ShutdownError) ErrorName() string {
 func (ShutdownError) StatusCode() int {
 	return http.StatusServiceUnavailable
 }
+
+// DuplicateInFlightError is returned, if the original record with the same deduplication key is still being written, see the deduplication package.
+type DuplicateInFlightError struct{}
+
+func (e DuplicateInFlightError) Error() string {
+	return "the original record with the same deduplication key is still being written, retry later"
+}
+
+func (DuplicateInFlightError) ErrorName() string {
+	return "duplicateInFlight"
+}
+
+func (DuplicateInFlightError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
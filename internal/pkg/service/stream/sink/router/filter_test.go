@@ -0,0 +1,108 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+)
+
+func TestRouter_SinkFilter(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	d, mock := dependencies.NewMockedSourceScope(t, ctx)
+	sinkController := mock.TestDummySinkController()
+	sinkController.PipelineWriteRecordStatus = pipeline.RecordProcessed
+
+	// Create branch, source and sinks
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	errorsSink := dummy.NewSink(key.SinkKey{SourceKey: sourceKey, SinkID: "errors"})
+	errorsSink.Filter = `Body("level", "") == "error"`
+	allSink := dummy.NewSink(key.SinkKey{SourceKey: sourceKey, SinkID: "all"})
+	invalidSink := dummy.NewSink(key.SinkKey{SourceKey: sourceKey, SinkID: "invalid"})
+	invalidSink.Filter = `Body("level", "")`
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&errorsSink, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&allSink, now, test.ByUser(), "create").Do(ctx).Err())
+
+	dispatch := func(body string) *routerResult {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		result := d.SinkRouter().DispatchToSource(sourceKey, recordctx.FromHTTP(now, req))
+		result.Finalize()
+		return &routerResult{
+			StatusCode:   result.StatusCode,
+			Message:      result.Message,
+			AllSinks:     result.AllSinks,
+			FailedSinks:  result.FailedSinks,
+			DroppedSinks: result.DroppedSinks,
+		}
+	}
+
+	// Wait until the sink router loads both sinks, the error record is never dropped, so the dropped stats are not affected
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 2, dispatch(`{"level":"error"}`).AllSinks)
+	}, 10*time.Second, 10*time.Millisecond)
+	sinkController.ResetWriteCounts()
+
+	// The record is accepted by both sinks
+	assert.Equal(t, &routerResult{StatusCode: http.StatusOK, Message: "Successfully written to 2/2 sinks.", AllSinks: 2}, dispatch(`{"level":"error"}`))
+
+	// The record is dropped by the filter of the "errors" sink
+	assert.Equal(t, &routerResult{StatusCode: http.StatusOK, Message: "Successfully written to 1/1 sinks.", AllSinks: 1, DroppedSinks: 1}, dispatch(`{"level":"info"}`))
+	assert.Equal(t, &routerResult{StatusCode: http.StatusOK, Message: "Successfully written to 1/1 sinks.", AllSinks: 1, DroppedSinks: 1}, dispatch(`{}`))
+	assert.Equal(t, 1, sinkController.WriteCount(errorsSink.SinkKey))
+	assert.Equal(t, 3, sinkController.WriteCount(allSink.SinkKey))
+
+	// The filter which doesn't return a boolean fails the write
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&invalidSink, now, test.ByUser(), "create").Do(ctx).Err())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 3, dispatch(`{"level":"error"}`).AllSinks)
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, &routerResult{StatusCode: http.StatusUnprocessableEntity, Message: "Written to 1/2 sinks.", AllSinks: 2, FailedSinks: 1, DroppedSinks: 1}, dispatch(`{"level":"info"}`))
+
+	// Dropped records are saved on shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+	etcdhelper.AssertKVsString(t, mock.TestEtcdClient(), `
+<<<<<
+storage/stats/dropped/123/456/my-source/errors/test-node
+-----
+{
+  "firstRecordAt": "%s",
+  "lastRecordAt": "%s",
+  "recordsCount": 3
+}
+>>>>>
+`, etcdhelper.WithIgnoredKeyPattern(`^definition/|^storage/volume/|^runtime/`))
+}
+
+type routerResult struct {
+	StatusCode   int
+	Message      string
+	AllSinks     int
+	FailedSinks  int
+	DroppedSinks int
+}
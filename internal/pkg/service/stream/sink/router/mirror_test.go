@@ -0,0 +1,151 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestRouter_SinkMirror(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	d, mock := dependencies.NewMockedSourceScope(t, ctx)
+	sinkController := mock.TestDummySinkController()
+	sinkController.PipelineWriteRecordStatus = pipeline.RecordProcessed
+
+	// Create branch, source and sink with a mirror
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	sink := dummy.NewSink(key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"})
+	sink.Filter = `Body("level", "") == "error"`
+	sink.Table = &definition.TableSink{
+		Type:    definition.TableTypeKeboola,
+		Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.bucket.table")},
+		Mapping: table.Mapping{Columns: column.Columns{column.Body{Name: "body"}}},
+		Mirror: &definition.TableMirror{
+			Type:    definition.TableTypeKeboola,
+			Keboola: &definition.KeboolaTable{TableID: keboola.MustParseTableID("in.archive.table")},
+		},
+	}
+	mirrorKey := sink.SinkKey.Mirror()
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&sink, now, test.ByUser(), "create").Do(ctx).Err())
+
+	// Wait for the sink router
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 1, d.SinkRouter().SourcesCount())
+	}, 10*time.Second, 10*time.Millisecond)
+
+	var lastResult *router.SourceResult
+	dispatch := func(body string) *routerResult {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		result := d.SinkRouter().DispatchToSource(sourceKey, recordctx.FromHTTP(now, req))
+		result.Finalize()
+		lastResult = result
+		return &routerResult{
+			StatusCode:   result.StatusCode,
+			Message:      result.Message,
+			AllSinks:     result.AllSinks,
+			FailedSinks:  result.FailedSinks,
+			DroppedSinks: result.DroppedSinks,
+		}
+	}
+
+	// The record is written to the sink and to the mirror, the mirror is not counted as a sink
+	assert.Equal(t, &routerResult{StatusCode: http.StatusOK, Message: "Successfully written to 1/1 sinks.", AllSinks: 1}, dispatch(`{"level":"error"}`))
+	assert.Equal(t, 1, sinkController.WriteCount(sink.SinkKey))
+	assert.Equal(t, 1, sinkController.WriteCount(mirrorKey))
+
+	// The mirror result is reported separately
+	mirrorResult := lastResult.Sinks[0].Mirror
+	require.NotNil(t, mirrorResult)
+	assert.Equal(t, &router.SinkResult{SinkID: mirrorKey.SinkID, StatusCode: http.StatusOK, Message: "processed"}, exportedSinkResult(mirrorResult))
+
+	// The sink filter applies also to the mirror
+	assert.Equal(t, &routerResult{StatusCode: http.StatusOK, Message: "Record dropped by filters of all sinks.", DroppedSinks: 1}, dispatch(`{"level":"info"}`))
+	assert.Equal(t, 1, sinkController.WriteCount(sink.SinkKey))
+	assert.Equal(t, 1, sinkController.WriteCount(mirrorKey))
+
+	// Write to the mirror fails, the dead-letter storage is disabled, so the record fails and the source retries it
+	sinkController.PipelineWriteHook = func(sinkKey key.SinkKey) error {
+		if sinkKey == mirrorKey {
+			return errors.New("some error")
+		}
+		return nil
+	}
+	assert.Equal(t, &routerResult{StatusCode: http.StatusInternalServerError, Message: "Written to 0/1 sinks.", AllSinks: 1, FailedSinks: 1}, dispatch(`{"level":"error"}`))
+	sinkResult := lastResult.Sinks[0]
+	assert.ErrorContains(t, sinkResult.Err(), "cannot write record to the sink mirror: some error")
+	assert.Equal(t, &router.SinkResult{
+		SinkID:     mirrorKey.SinkID,
+		StatusCode: http.StatusInternalServerError,
+		ErrorName:  "stream.in.genericError",
+		Message:    "Some error.",
+	}, exportedSinkResult(sinkResult.Mirror))
+	assert.Equal(t, 2, sinkController.WriteCount(sink.SinkKey))
+
+	// Enable the dead-letter storage, the record which is not written to the mirror is stored there, the record doesn't fail
+	_, err := d.DefinitionRepository().Sink().Update(sink.SinkKey, now, test.ByUser(), "enable dead-letter", func(sink definition.Sink) (definition.Sink, error) {
+		sink.DeadLetter = true
+		return sink, nil
+	}).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, http.StatusOK, dispatch(`{"level":"error"}`).StatusCode)
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, http.StatusInternalServerError, lastResult.Sinks[0].Mirror.StatusCode)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		records, err := d.DeadLetterRepository().List(sink.SinkKey).Do(ctx).All()
+		assert.NoError(c, err)
+		if assert.Len(c, records, 1) {
+			assert.JSONEq(c, `{"level":"error"}`, string(records[0].Body))
+			assert.Contains(c, records[0].Error, "cannot write record to the sink mirror: some error")
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+	sinkController.PipelineWriteHook = nil
+
+	// Remove the mirror, the record is written only to the sink
+	_, err = d.DefinitionRepository().Sink().Update(sink.SinkKey, now, test.ByUser(), "remove mirror", func(sink definition.Sink) (definition.Sink, error) {
+		sink.Table.Mirror = nil
+		return sink, nil
+	}).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		sinkController.ResetWriteCounts()
+		dispatch(`{"level":"error"}`)
+		assert.Equal(c, 1, sinkController.WriteCount(sink.SinkKey))
+		assert.Equal(c, 0, sinkController.WriteCount(mirrorKey))
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// exportedSinkResult returns a copy of the result with exported fields only, so it can be compared.
+func exportedSinkResult(r *router.SinkResult) *router.SinkResult {
+	return &router.SinkResult{SinkID: r.SinkID, StatusCode: r.StatusCode, ErrorName: r.ErrorName, Message: r.Message}
+}
@@ -10,6 +10,7 @@ import (
 
 	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -22,6 +23,7 @@ type SourcesResult struct {
 	AllSinks        int             `json:"-"`
 	SuccessfulSinks int             `json:"-"`
 	FailedSinks     int             `json:"-"`
+	DroppedSinks    int             `json:"-"`
 	finalized       bool
 }
 
@@ -36,6 +38,7 @@ type SourceResult struct {
 	AllSinks        int               `json:"-"`
 	SuccessfulSinks int               `json:"-"`
 	FailedSinks     int               `json:"-"`
+	DroppedSinks    int               `json:"-"`
 	finalized       bool
 }
 
@@ -44,9 +47,43 @@ type SinkResult struct {
 	StatusCode int        `json:"statusCode"`
 	ErrorName  string     `json:"error,omitempty"`
 	Message    string     `json:"message"`
-	status     pipeline.RecordStatus
-	error      error
-	finalized  bool
+	// Mirror is the result of the sink mirror, see definition.TableMirror.
+	// A failed mirror fails the record, unless the record is stored to the dead-letter storage of the sink.
+	Mirror *SinkResult `json:"mirror,omitempty"`
+	status pipeline.RecordStatus
+	error  error
+	// bytes written to the mirror, the metric is reported separately for the mirror
+	bytes int
+	// duplicate is true, if the record has been skipped by the deduplication window of the sink
+	duplicate bool
+	finalized bool
+}
+
+// Failed returns results of the sinks, to which the record has not been written.
+func (r *SourceResult) Failed() (out []*SinkResult) {
+	for _, sink := range r.Sinks {
+		if sink.error != nil {
+			out = append(out, sink)
+		}
+	}
+	return out
+}
+
+// Processed returns true, if the record has been written to a persistent storage or service, see pipeline.RecordProcessed.
+// It is false, if the record has been only accepted by the sink, see pipeline.RecordAccepted, or the write failed.
+func (r *SinkResult) Processed() bool {
+	return r.error == nil && r.status == pipeline.RecordProcessed
+}
+
+// Err returns the write error, or nil, if the record has been written to the sink.
+func (r *SinkResult) Err() error {
+	return r.error
+}
+
+// Retryable returns false, if the write of the same record cannot succeed later, for example, if the record cannot be mapped by the sink.
+// Such a record has been already stored to the dead-letter storage of the sink, if enabled.
+func (r *SinkResult) Retryable() bool {
+	return r.error != nil && !errors.As(r.error, &column.RenderError{})
 }
 
 // Finalize result strings only when they are really needed - verbose mode or an error.
@@ -64,6 +101,8 @@ func (r *SourcesResult) Finalize() {
 
 	// Message
 	switch {
+	case r.AllSinks == 0 && r.DroppedSinks > 0:
+		r.Message = "Record dropped by filters of all sinks."
 	case r.AllSinks == 0:
 		r.Message = "No enabled sink found."
 	case r.SuccessfulSinks == r.AllSinks:
@@ -110,6 +149,8 @@ func (r *SourceResult) Finalize() {
 
 	// Message
 	switch {
+	case r.AllSinks == 0 && r.DroppedSinks > 0:
+		r.Message = "Record dropped by filters of all sinks."
 	case r.AllSinks == 0:
 		r.Message = "No enabled sink found."
 	case r.SuccessfulSinks == r.AllSinks:
@@ -161,6 +202,8 @@ func (r *SinkResult) Finalize() {
 		} else {
 			r.Message = errors.Format(r.error, errors.FormatAsSentences())
 		}
+	case r.duplicate:
+		r.Message = "duplicate"
 	case r.status == pipeline.RecordProcessed:
 		r.Message = "processed"
 	case r.status == pipeline.RecordAccepted:
@@ -168,6 +211,10 @@ func (r *SinkResult) Finalize() {
 	default:
 		panic(errors.Errorf(`unexpected record status code %v`, r.status))
 	}
+
+	if r.Mirror != nil {
+		r.Mirror.Finalize()
+	}
 }
 
 func resultStatusCode(status pipeline.RecordStatus, err error) int {
@@ -5,6 +5,7 @@ package router
 import (
 	"context"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,17 +17,27 @@ import (
 	"golang.org/x/exp/maps"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configpatch"
 	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/filter"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter"
+	deadLetterRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deadletter/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/deduplication"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
+	sampleRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	statsRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/ulid"
 )
 
 const (
@@ -40,6 +51,14 @@ type Router struct {
 	plugins     *plugin.Plugins
 	definitions *definitionRepo.Repository
 	collection  *collection
+	filters     *filter.Evaluator
+	dropped     *recordsCounter
+	deadLetters *deadLetters
+	samples     *samples
+
+	dedupConfig  deduplication.Config
+	deduplicator *deduplicator
+	deduplicated *recordsCounter
 
 	lock      sync.RWMutex
 	pipelines map[key.SinkKey]*pipelineRef
@@ -67,19 +86,31 @@ type dependencies interface {
 	Process() *servicectx.Process
 	Plugins() *plugin.Plugins
 	DefinitionRepository() *definitionRepo.Repository
+	StatisticsRepository() *statsRepo.Repository
+	DeadLetterRepository() *deadLetterRepo.Repository
+	SampleRepository() *sampleRepo.Repository
+	NewIDGenerator() ulid.Generator
 	Telemetry() telemetry.Telemetry
 }
 
-func New(d dependencies, sourceType string) (*Router, error) {
+func New(d dependencies, sourceType string, nodeID string, statsConfig statistics.SyncConfig, deadLetterConfig deadletter.Config, dedupConfig deduplication.Config, sampleConfig sample.Config) (*Router, error) {
+	logger := d.Logger().WithComponent("sink.router")
 	r := &Router{
-		sourceType:  sourceType,
-		clock:       d.Clock(),
-		logger:      d.Logger().WithComponent("sink.router"),
-		plugins:     d.Plugins(),
-		definitions: d.DefinitionRepository(),
-		collection:  newCollection(),
-		closed:      make(chan struct{}),
-		pipelines:   make(map[key.SinkKey]*pipelineRef),
+		sourceType:   sourceType,
+		clock:        d.Clock(),
+		logger:       logger,
+		plugins:      d.Plugins(),
+		definitions:  d.DefinitionRepository(),
+		collection:   newCollection(),
+		filters:      filter.NewEvaluator(),
+		dropped:      newDroppedRecords(logger, d.StatisticsRepository(), statsConfig, nodeID),
+		deadLetters:  newDeadLetters(deadLetterConfig, d.Clock(), logger, d.DeadLetterRepository(), d.NewIDGenerator()),
+		samples:      newSamples(sampleConfig, d.Clock(), logger, d.SampleRepository(), d.NewIDGenerator()),
+		dedupConfig:  dedupConfig,
+		deduplicator: newDeduplicator(d.Clock()),
+		deduplicated: newDeduplicatedRecords(logger, d.StatisticsRepository(), statsConfig, nodeID),
+		closed:       make(chan struct{}),
+		pipelines:    make(map[key.SinkKey]*pipelineRef),
 		metrics: &metrics{
 			sourceDuration: d.Telemetry().Meter().FloatHistogram("keboola.go.stream.source.record.duration", "Duration of source requests.", "ms"),
 			sourceBytes:    d.Telemetry().Meter().IntCounter("keboola.go.stream.source.in.bytes", "Source request length.", "B"),
@@ -104,6 +135,16 @@ func New(d dependencies, sourceType string) (*Router, error) {
 		// Wait for closing all pipelines
 		r.closeAllPipelines(ctx, "shutdown")
 
+		// Save final counts of dropped and deduplicated records, the error is already logged
+		_ = r.dropped.sync(ctx)
+		_ = r.deduplicated.sync(ctx)
+
+		// Wait for saving of queued dead-letter records
+		r.deadLetters.close()
+
+		// Wait for saving of queued samples
+		r.samples.close()
+
 		r.logger.Infof(ctx, "closed sink router")
 	})
 
@@ -122,16 +163,19 @@ func New(d dependencies, sourceType string) (*Router, error) {
 
 					switch event.Type {
 					case etcdop.CreateEvent, etcdop.UpdateEvent:
-						r.collection.addSink(sink)
-
-						// If a Sink entity is modified, it may be necessary to reopen the pipeline
-						if p := r.pipelineRefOrNil(sink.SinkKey); p != nil {
-							if sink.IsEnabled() && p.pipeline.ReopenOnSinkModification() {
-								p.close(ctx, "sink updated")
+						r.collection.addSink(sink, r.deduplicationConfig(ctx, sink))
+
+						// If a Sink entity is modified, it may be necessary to reopen the pipeline, also the pipeline of the mirror
+						for _, sinkKey := range []key.SinkKey{sink.SinkKey, sink.SinkKey.Mirror()} {
+							if p := r.pipelineRefOrNil(sinkKey); p != nil {
+								if sink.IsEnabled() && p.pipeline.ReopenOnSinkModification() {
+									p.close(ctx, "sink updated")
+								}
 							}
 						}
 					case etcdop.DeleteEvent:
 						r.collection.deleteSink(sink.SinkKey)
+						r.deduplicator.deleteSink(sink.SinkKey)
 					default:
 						panic(errors.Errorf(`unexpected event type "%v"`, event.Type))
 					}
@@ -163,6 +207,27 @@ func New(d dependencies, sourceType string) (*Router, error) {
 		}
 	}
 
+	// Periodically save counts of dropped and deduplicated records to the database.
+	// Sync can be disabled in tests, it causes problems when mocked clock is used.
+	if statsConfig.Enabled {
+		r.wg.Add(1)
+		ticker := r.clock.NewTicker(statsConfig.SyncInterval.Duration())
+		go func() {
+			defer r.wg.Done()
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.Chan():
+					// Note: errors are already logged
+					_ = r.dropped.sync(ctx)
+					_ = r.deduplicated.sync(ctx)
+				}
+			}
+		}()
+	}
+
 	return r, nil
 }
 
@@ -193,6 +258,7 @@ func (r *Router) DispatchToSources(sources []key.SourceKey, c recordctx.Context)
 			result.AllSinks += sourceResult.AllSinks
 			result.SuccessfulSinks += sourceResult.SuccessfulSinks
 			result.FailedSinks += sourceResult.FailedSinks
+			result.DroppedSinks += sourceResult.DroppedSinks
 		})
 	}
 
@@ -203,6 +269,20 @@ func (r *Router) DispatchToSources(sources []key.SourceKey, c recordctx.Context)
 }
 
 func (r *Router) DispatchToSource(sourceKey key.SourceKey, c recordctx.Context) *SourceResult {
+	// Capture a sample of the source, also if the source has no sink yet, see the sample package
+	r.samples.capture(context.Background(), sourceKey, c)
+
+	return r.dispatchToSource(sourceKey, nil, c)
+}
+
+// DispatchToSinks writes the record only to the listed sinks of the source, other sinks are skipped.
+// It is used to retry the record, which has not been written to some sinks, so the sample of the source is not captured again.
+func (r *Router) DispatchToSinks(sourceKey key.SourceKey, sinkIDs []key.SinkID, c recordctx.Context) *SourceResult {
+	return r.dispatchToSource(sourceKey, sinkIDs, c)
+}
+
+// dispatchToSource writes the record to enabled sinks of the source, the nil sinkIDs means all sinks.
+func (r *Router) dispatchToSource(sourceKey key.SourceKey, sinkIDs []key.SinkID, c recordctx.Context) *SourceResult {
 	startTime := r.clock.Now()
 
 	result := &SourceResult{
@@ -218,6 +298,15 @@ func (r *Router) DispatchToSource(sourceKey key.SourceKey, c recordctx.Context)
 		return result
 	}
 
+	// The raw record is copied only if a write fails, so buffers of the record
+	// are not released by sinks until the record is written to all sinks.
+	deadLetter := r.deadLetters.enabled(source)
+	if deadLetter {
+		var release func()
+		c, release = recordctx.WithDeferredRelease(c)
+		defer release()
+	}
+
 	// Write to sinks in parallel
 	signal := c.Signal()
 	var lock sync.Mutex
@@ -227,6 +316,10 @@ func (r *Router) DispatchToSource(sourceKey key.SourceKey, c recordctx.Context)
 			continue
 		}
 
+		if sinkIDs != nil && !slices.Contains(sinkIDs, sink.sinkKey.SinkID) {
+			continue
+		}
+
 		if !definition.SignalAccepted(sink.allowedSignals, signal) {
 			continue
 		}
@@ -235,12 +328,18 @@ func (r *Router) DispatchToSource(sourceKey key.SourceKey, c recordctx.Context)
 		wg.Go(func() {
 			defer r.wg.Done()
 
-			sinkResult := r.dispatchToSink(sink, c)
+			sinkResult := r.dispatchToSink(sink, c, deadLetter)
 
 			// Aggregate result
 			lock.Lock()
 			defer lock.Unlock()
 
+			// The record has been dropped by the sink filter
+			if sinkResult == nil {
+				result.DroppedSinks++
+				return
+			}
+
 			result.Sinks = append(result.Sinks, sinkResult)
 			if sinkResult.StatusCode > result.StatusCode {
 				result.StatusCode = sinkResult.StatusCode
@@ -317,22 +416,156 @@ func (r *Router) DispatchToSource(sourceKey key.SourceKey, c recordctx.Context)
 	return result
 }
 
+// deduplicationConfig returns the deduplication configuration, with modifications from the Sink.Config.
+func (r *Router) deduplicationConfig(ctx context.Context, sink definition.Sink) deduplication.Config {
+	cfg := r.dedupConfig
+	kvs := sink.Config.In("sink.deduplication")
+	if len(kvs) > 0 {
+		if err := configpatch.ApplyKVs(&cfg, &deduplication.ConfigPatch{}, kvs, configpatch.WithModifyProtected()); err != nil {
+			r.logger.Warnf(ctx, `invalid deduplication configuration of the sink "%s", default values are used: %s`, sink.SinkKey, err)
+			return r.dedupConfig
+		}
+	}
+	return cfg
+}
+
 func (r *Router) SourcesCount() int {
 	return r.collection.sourcesCount()
 }
 
-func (r *Router) dispatchToSink(sink *sinkData, c recordctx.Context) *SinkResult {
+// ReplayToSink writes a dead-letter record to the sink again, other sinks of the source are skipped, see the deadletter package.
+// If the write fails, the record is not stored to the dead-letter storage again, the error is returned instead.
+func (r *Router) ReplayToSink(sinkKey key.SinkKey, c recordctx.Context) error {
+	sink, found := r.collection.sink(sinkKey)
+	if !found {
+		return svcerrors.NewResourceNotFoundError("sink", sinkKey.SinkID.String(), "source")
+	}
+	if !sink.enabled {
+		return svcerrors.NewBadRequestError(errors.Errorf(`sink "%s" is disabled`, sinkKey.SinkID))
+	}
+
+	r.wg.Add(1)
+	defer r.wg.Done()
+
+	// The record may be dropped by the sink filter, then it is not written
+	if result := r.dispatchToSink(sink, c, false); result != nil {
+		return result.error
+	}
+	return nil
+}
+
+// StoreDeadLetter stores the record to the dead-letter storage of the sink, regardless of the error type, see the deadletter package.
+// It is used by a source, which cannot return the error to the client, and which has given up retrying of the record.
+// False is returned, if the dead-letter storage is not enabled for the sink, or the record cannot be queued.
+func (r *Router) StoreDeadLetter(sinkKey key.SinkKey, c recordctx.Context, err error) bool {
+	return r.StoreDeadLetterSnapshot(context.WithoutCancel(c.Ctx()), sinkKey, r.NewDeadLetterSnapshot(c), err)
+}
+
+// DropRecord counts the record, which cannot be written to the sink, as dropped.
+// It is used by a source, which cannot return the error to the client, for example, if the record cannot be mapped by the sink.
+// True is returned and nothing is counted, if the sink has the enabled dead-letter storage, then the record has been already stored there.
+func (r *Router) DropRecord(sinkKey key.SinkKey, c recordctx.Context) bool {
+	if sink, found := r.collection.sink(sinkKey); found && sink.deadLetter {
+		return true
+	}
+
+	r.dropped.add(sinkKey, c.Timestamp())
+	return false
+}
+
+// NewDeadLetterSnapshot copies the raw record, so it can be stored later, when the record context is no longer valid.
+// It is used by a sink pipeline, which delivers records asynchronously, see the StoreDeadLetterSnapshot method.
+func (r *Router) NewDeadLetterSnapshot(c recordctx.Context) deadletter.Snapshot {
+	return deadletter.NewSnapshot(c, r.deadLetters.config.MaxBodySize)
+}
+
+// StoreDeadLetterSnapshot stores the snapshot to the dead-letter storage of the sink, regardless of the error type.
+// False is returned, if the dead-letter storage is not enabled for the sink, or the record cannot be queued.
+func (r *Router) StoreDeadLetterSnapshot(ctx context.Context, sinkKey key.SinkKey, snapshot deadletter.Snapshot, err error) bool {
+	sink, found := r.collection.sink(sinkKey)
+	if !found || !sink.deadLetter {
+		return false
+	}
+
+	return r.deadLetters.enqueue(ctx, sinkKey, &snapshot, err)
+}
+
+// dispatchToSink writes the record to the sink pipeline.
+// The nil result is returned, if the record has been dropped by the sink filter.
+// A duplicate record, already written within the deduplication window of the sink, is skipped, see the deduplication package.
+// The record is stored to the dead-letter storage, if the record cannot be mapped by the sink, and the deadLetter flag is true.
+func (r *Router) dispatchToSink(sink *sinkData, c recordctx.Context, deadLetter bool) *SinkResult {
 	startTime := r.clock.Now()
 
-	writeResult, err := r.writeRecord(sink, c)
+	if accepted, err := r.filters.Accepts(sink.filter, c); err != nil {
+		err = svcerrors.NewUnprocessableContentError(errors.PrefixError(err, "cannot evaluate sink filter"))
+		return &SinkResult{
+			SinkID:     sink.sinkKey.SinkID,
+			StatusCode: resultStatusCode(pipeline.RecordError, err),
+			status:     pipeline.RecordError,
+			error:      err,
+		}
+	} else if !accepted {
+		r.dropped.add(sink.sinkKey, c.Timestamp())
+		return nil
+	}
+
+	var reservation *dedupReservation
+	if sink.deduplication.Enabled() {
+		// Values of primary key columns are rendered only once, for the deduplication and for the sink mapping
+		c = recordctx.WithSharedValues(c)
+
+		var original *pipeline.RecordStatus
+		var err error
+		if reservation, original, err = r.deduplicator.reserve(sink, c); err != nil {
+			return &SinkResult{
+				SinkID:     sink.sinkKey.SinkID,
+				StatusCode: resultStatusCode(pipeline.RecordError, err),
+				status:     pipeline.RecordError,
+				error:      err,
+			}
+		} else if original != nil {
+			// The duplicate gets the status of the original record
+			r.deduplicated.add(sink.sinkKey, c.Timestamp())
+			return &SinkResult{
+				SinkID:     sink.sinkKey.SinkID,
+				StatusCode: resultStatusCode(*original, nil),
+				status:     *original,
+				duplicate:  true,
+			}
+		}
+	}
+
+	finalizationCtx := context.WithoutCancel(c.Ctx())
+
+	writeResult, mirror, err := r.writeRecord(sink, c)
+	if err == nil && mirror != nil && mirror.error != nil {
+		// The record is not lost, if it is stored to the dead-letter storage of the sink.
+		// Otherwise, the record fails, so the source retries it.
+		if !deadLetter || !sink.deadLetter || !r.deadLetters.addMirrorError(finalizationCtx, sink.sinkKey, c, mirror.error) {
+			err = errors.PrefixError(mirror.error, "cannot write record to the sink mirror")
+			writeResult.Status = pipeline.RecordError
+		}
+	}
+	if reservation != nil {
+		// The key of a failed record is released, so a retry is not skipped
+		if err != nil {
+			reservation.finish(pipeline.RecordError)
+		} else {
+			reservation.finish(writeResult.Status)
+		}
+	}
 	result := &SinkResult{
 		SinkID:     sink.sinkKey.SinkID,
 		StatusCode: resultStatusCode(writeResult.Status, err),
+		Mirror:     mirror,
 		status:     writeResult.Status,
 		error:      err,
 	}
 
-	finalizationCtx := context.WithoutCancel(c.Ctx())
+	if err != nil && deadLetter && sink.deadLetter {
+		r.deadLetters.add(finalizationCtx, sink.sinkKey, c, err)
+	}
 
 	if result.StatusCode == http.StatusInternalServerError {
 		r.logger.Errorf(finalizationCtx, `write record error: %s`, err.Error())
@@ -347,15 +580,52 @@ func (r *Router) dispatchToSink(sink *sinkData, c recordctx.Context) *SinkResult
 	durationMs := float64(r.clock.Now().Sub(startTime)) / float64(time.Millisecond)
 	r.metrics.sinkDuration.Record(finalizationCtx, durationMs, metric.WithAttributes(attrs...))
 	r.metrics.sinkBytes.Add(finalizationCtx, int64(writeResult.Bytes), metric.WithAttributes(attrs...))
+	if mirror != nil {
+		mirrorAttrs := append(
+			sink.mirror.sinkKey.Telemetry(),
+			attribute.String("error_type", telemetry.ErrorType(mirror.error)),
+			attribute.String("sink_type", sink.mirror.sinkType.String()),
+		)
+		r.metrics.sinkBytes.Add(finalizationCtx, int64(mirror.bytes), metric.WithAttributes(mirrorAttrs...))
+	}
 
 	return result
 }
 
-func (r *Router) writeRecord(sink *sinkData, c recordctx.Context) (pipeline.WriteResult, error) {
+// writeRecord writes the record to the sink pipeline, and to the pipeline of the sink mirror, if any.
+// The sink filter is evaluated only once, and the mapping values are shared, if the mirror contains the same columns.
+// The write result of the sink is returned, the result of the mirror is reported separately, see SinkResult.Mirror.
+func (r *Router) writeRecord(sink *sinkData, c recordctx.Context) (pipeline.WriteResult, *SinkResult, error) {
 	if r.isClosed() {
-		return pipeline.WriteResult{Status: pipeline.RecordError}, ShutdownError{}
+		return pipeline.WriteResult{Status: pipeline.RecordError}, nil, ShutdownError{}
 	}
-	return r.pipelineRef(sink).writeRecord(c)
+
+	if sink.mirror == nil {
+		result, err := r.pipelineRef(sink).writeRecord(c)
+		return result, nil, err
+	}
+
+	c = recordctx.WithSharedValues(c)
+
+	// Write to the sink and to the mirror in parallel
+	var mirrorResult pipeline.WriteResult
+	var mirrorErr error
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		mirrorResult, mirrorErr = r.pipelineRef(sink.mirror).writeRecord(c)
+	})
+	result, err := r.pipelineRef(sink).writeRecord(c)
+	wg.Wait()
+
+	mirror := &SinkResult{
+		SinkID:     sink.mirror.sinkKey.SinkID,
+		StatusCode: resultStatusCode(mirrorResult.Status, mirrorErr),
+		status:     mirrorResult.Status,
+		error:      mirrorErr,
+		bytes:      mirrorResult.Bytes,
+	}
+
+	return result, mirror, err
 }
 
 // pipelineRef gets or creates sink pipeline.
@@ -0,0 +1,95 @@
+package router
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
+	sampleRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/ulid"
+)
+
+// samplesQueueSize limits samples waiting to be saved, one sample per source is captured in the interval, so the queue is small.
+const samplesQueueSize = 100
+
+// samples captures bodies of the last requests of sources, see the sample package.
+// Samples are saved in the background, so a request is not slowed down by the database.
+type samples struct {
+	config      sample.Config
+	clock       clockwork.Clock
+	logger      log.Logger
+	repository  *sampleRepo.Repository
+	idGenerator ulid.Generator
+
+	lock       sync.Mutex
+	closed     bool
+	capturedAt map[key.SourceKey]time.Time
+	queue      chan sample.Sample
+	done       chan struct{}
+}
+
+func newSamples(config sample.Config, clock clockwork.Clock, logger log.Logger, repository *sampleRepo.Repository, idGenerator ulid.Generator) *samples {
+	s := &samples{
+		config:      config,
+		clock:       clock,
+		logger:      logger,
+		repository:  repository,
+		idGenerator: idGenerator,
+		capturedAt:  make(map[key.SourceKey]time.Time),
+		queue:       make(chan sample.Sample, samplesQueueSize),
+		done:        make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+		ctx := context.Background()
+		for item := range s.queue {
+			if err := s.repository.Add(item, s.config.Size).Do(ctx).Err(); err != nil {
+				s.logger.Errorf(ctx, `cannot save sample "%s": %s`, item.SampleKey, err)
+			}
+		}
+	}()
+
+	return s
+}
+
+// capture enqueues the record body, if the last sample of the source is older than the interval.
+func (s *samples) capture(ctx context.Context, sourceKey key.SourceKey, c recordctx.Context) {
+	if s.config.Size == 0 {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.closed {
+		return
+	}
+
+	now := s.clock.Now()
+	if last, found := s.capturedAt[sourceKey]; found && now.Sub(last) < s.config.Interval {
+		return
+	}
+	s.capturedAt[sourceKey] = now
+
+	k := sample.SampleKey{SourceKey: sourceKey, SampleID: sample.SampleID(s.idGenerator.NewULID())}
+	select {
+	case s.queue <- sample.New(k, c, s.config.MaxBodySize):
+	default:
+		s.logger.Warnf(ctx, `samples queue is full, sample of the source "%s" is not stored`, sourceKey)
+	}
+}
+
+// close waits until all queued samples are saved.
+func (s *samples) close() {
+	s.lock.Lock()
+	s.closed = true
+	close(s.queue)
+	s.lock.Unlock()
+	<-s.done
+}
@@ -0,0 +1,69 @@
+package router_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestRouter_Samples(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	d, _ := dependencies.NewMockedSourceScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Source.Sample.Size = 2
+		cfg.Source.Sample.Interval = time.Hour
+	})
+
+	// Create branch and source, without sinks
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+
+	dispatch := func(body string) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		d.SinkRouter().DispatchToSource(sourceKey, recordctx.FromHTTP(now, req)).Finalize()
+	}
+
+	// The first request is captured, the second is skipped, the interval has not passed
+	dispatch(`{"foo":"bar"}`)
+	dispatch(`{"foo":"baz"}`)
+
+	// Samples are saved in the background
+	var samples []sample.Sample
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		var err error
+		samples, err = d.SampleRepository().List(sourceKey).Do(ctx).All()
+		assert.NoError(c, err)
+		assert.Len(c, samples, 1)
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "application/json", samples[0].ContentType)
+	body, err := samples[0].BodyMap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "bar", body.GetOrNil("foo"))
+
+	// Shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+}
@@ -126,6 +126,7 @@ func New(d dependencies, apiProvider apiProvider, config keboolasink.Config) (*B
 
 	b.setupOnFileOpen()
 	b.deleteCredentialsOnFileDelete()
+	b.resetJobOnFileReimport()
 	b.deleteTokenOnSinkDeactivation()
 	b.plugins.RegisterFileImporter(targetProvider, b.importFile)
 	b.plugins.RegisterSliceUploader(stagingFileProvider, b.uploadSlice)
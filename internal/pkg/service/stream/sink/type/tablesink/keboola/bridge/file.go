@@ -10,12 +10,16 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
+	serviceError "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/rollback"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
 	keboolasink "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/type/tablesink/keboola"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
@@ -32,8 +36,27 @@ func (b *Bridge) setupOnFileOpen() {
 			return nil
 		}
 
+		// Keboola Storage imports slices as headerless CSV files, other encoders are supported by file sinks
+		if err := validateEncoder(file); err != nil {
+			return err
+		}
+
 		tableKey := keboola.TableKey{BranchID: sink.BranchID, TableID: sink.Table.Keboola.TableID}
 
+		// Records are written to the partition table, if partitioning is enabled.
+		// Each record is routed by the partitioning column value, records of the next partition wait for the next file,
+		// see the storage router SinkPipeline. The file is rotated at the end of the partition, see the filerotation operator.
+		if partitioning := sink.Table.Partitioning; partitioning != nil {
+			partition := partitioning.Partition(now)
+			tableKey.TableID = partition.TableID(tableKey.TableID)
+			file.TargetStorage.Partition = &targetModel.Partition{
+				ID:     partition.ID,
+				Column: partitioning.Column,
+				Start:  utctime.From(partition.Start),
+				End:    utctime.From(partition.End),
+			}
+		}
+
 		// Create bucket if not exists, but only if the operation is called via API.
 		// We need a token with right permissions, to be able to create the bucket.
 		// If the operation is not called via API, but from a background operator, the bucket should already exist.
@@ -59,7 +82,7 @@ func (b *Bridge) setupOnFileOpen() {
 		}
 
 		// Create file resource
-		keboolaFile, err := b.createStagingFile(ctx, api, sink, file)
+		keboolaFile, err := b.createStagingFile(ctx, api, tableKey.TableID, sink, file)
 		if err != nil {
 			return err
 		}
@@ -69,23 +92,40 @@ func (b *Bridge) setupOnFileOpen() {
 		file.StagingStorage.Provider = stagingFileProvider // staging file is provided by the Keboola
 		file.TargetStorage.Provider = targetProvider       // destination is a Keboola table
 		file.StagingStorage.Expiration = keboolaFile.Expiration()
+		file.StagingStorage.Retained = b.config.StagingFileRetention > 0
+
+		// Keboola Storage cannot import ZSTD files, slices compressed by ZSTD on the disk are transcoded to GZIP on upload
+		if file.StagingStorage.Compression.Type == compression.TypeZSTD {
+			file.StagingStorage.Compression = compression.NewGZIPConfig()
+		}
 
 		return nil
 	})
 }
 
-func (b *Bridge) createStagingFile(ctx context.Context, api *keboola.AuthorizedAPI, sink definition.Sink, file *model.File) (keboolasink.File, error) {
+func validateEncoder(file *model.File) error {
+	if t := file.Encoding.Encoder.Type; t != encoder.TypeCSV {
+		return serviceError.NewBadRequestError(errors.Errorf(
+			`encoder type "%s" is not supported by the Keboola table sink, only "%s" is supported, use a file sink for other encoders`, t, encoder.TypeCSV,
+		))
+	}
+	return nil
+}
+
+func (b *Bridge) createStagingFile(ctx context.Context, api *keboola.AuthorizedAPI, tableID keboola.TableID, sink definition.Sink, file *model.File) (keboolasink.File, error) {
 	name := fmt.Sprintf(`%s_%s_%s`, file.SourceID, file.SinkID, file.OpenedAt().Time().Format(fileNameDateFormat))
 	attributes := file.Telemetry()
 	attributes = append(attributes, attribute.String("file.name", name))
 	ctx = ctxattr.ContextWith(ctx, attributes...)
 
-	// Create staging file
+	// Create staging file.
+	// A retained staging file is permanent, otherwise it expires in the Keboola Storage regardless of the imported file retention.
 	b.logger.Debug(ctx, `creating staging file`)
 	stagingFile, err := api.CreateFileResourceRequest(
 		file.BranchID,
 		name,
 		keboola.WithIsSliced(true),
+		keboola.WithIsPermanent(b.config.StagingFileRetention > 0),
 		keboola.WithTags(
 			fmt.Sprintf("stream.sourceID=%s", file.SourceID),
 			fmt.Sprintf("stream.sinkID=%s", file.SinkID),
@@ -106,7 +146,7 @@ func (b *Bridge) createStagingFile(ctx context.Context, api *keboola.AuthorizedA
 	keboolaFile := keboolasink.File{
 		FileKey:               &file.FileKey,
 		SinkKey:               file.SinkKey,
-		TableID:               sink.Table.Keboola.TableID,
+		TableID:               tableID,
 		Columns:               sink.Table.Mapping.Columns.Names(),
 		FileID:                &stagingFile.FileID,
 		FileName:              &stagingFile.Name,
@@ -132,13 +172,111 @@ func (b *Bridge) createStagingFile(ctx context.Context, api *keboola.AuthorizedA
 }
 
 // deleteCredentialsOnFileDelete deletes upload credentials from database, staging file is kept until its expiration.
+// A retained staging file is permanent, so it is deleted with the file, see keboolasink.Config.StagingFileRetention.
 func (b *Bridge) deleteCredentialsOnFileDelete() {
 	b.plugins.Collection().OnFileDelete(func(ctx context.Context, now time.Time, original, file *model.File) error {
-		if b.isKeboolaStagingFile(file) {
+		if !b.isKeboolaStagingFile(file) {
+			return nil
+		}
+
+		if !file.StagingStorage.Retained {
 			op.AtomicOpCtxFrom(ctx).Write(func(ctx context.Context) op.Op {
 				return b.schema.File().ForFile(file.FileKey).Delete(b.client)
 			})
+			return nil
+		}
+
+		// The retained staging file is deleted from the Storage API only after the transaction is committed,
+		// the write phase can be retried, and the Storage API call cannot be rolled back.
+		var keboolaFile *keboolasink.File
+		var existingToken *keboolasink.Token
+		op.AtomicOpCtxFrom(ctx).AddFrom(op.
+			Atomic(b.client, &op.NoResult{}).
+			Read(
+				func(ctx context.Context) op.Op {
+					return b.schema.File().ForFile(file.FileKey).GetOrNil(b.client).WithResultTo(&keboolaFile)
+				},
+				func(ctx context.Context) op.Op {
+					return b.schema.Token().ForSink(file.SinkKey).GetOrNil(b.client).WithResultTo(&existingToken)
+				},
+			).
+			Write(func(ctx context.Context) op.Op {
+				return b.schema.File().ForFile(file.FileKey).Delete(b.client)
+			}).
+			AddProcessor(func(ctx context.Context, r *op.Result[op.NoResult]) {
+				if r.Err() == nil {
+					b.deleteRetainedStagingFile(ctx, file, keboolaFile, existingToken)
+				}
+			}),
+		)
+		return nil
+	})
+}
+
+// deleteRetainedStagingFile deletes the permanent staging file from the Keboola Storage.
+// The error is not critical, the file record is deleted anyway, so the error is only logged.
+func (b *Bridge) deleteRetainedStagingFile(ctx context.Context, file *model.File, keboolaFile *keboolasink.File, existingToken *keboolasink.Token) {
+	if keboolaFile == nil {
+		return
+	}
+
+	fileKey := keboola.FileKey{BranchID: file.BranchID, FileID: keboolaFile.ID()}
+	ctx = ctxattr.ContextWith(ctx, attribute.String("stagingFile.ID", fileKey.FileID.String()))
+
+	if existingToken == nil {
+		b.logger.Warn(ctx, "cannot delete retained staging file, token of the sink has been already deleted")
+		return
+	}
+
+	token, err := existingToken.DecryptToken(ctx, b.tokenEncryptor, cloudencrypt.Metadata{"sink": file.SinkKey.String()})
+	if err != nil {
+		b.logger.Warnf(ctx, "cannot delete retained staging file, cannot decrypt token: %s", err)
+		return
+	}
+
+	api := b.publicAPI.NewAuthorizedAPI(token.Token, 1*time.Minute)
+	if err := api.DeleteFileRequest(fileKey).SendOrErr(ctx); err != nil {
+		b.logger.Warnf(ctx, "cannot delete retained staging file: %s", err)
+		return
+	}
+
+	b.logger.Info(ctx, "deleted retained staging file")
+}
+
+// resetJobOnFileReimport removes the storage job of the previous import, so a new job is created by the re-import.
+// If the target table is overridden, the table must be in the same bucket, the sink token is scoped to the bucket.
+func (b *Bridge) resetJobOnFileReimport() {
+	b.plugins.Collection().OnFileSave(func(ctx context.Context, now time.Time, original, file *model.File) error {
+		if original == nil || original.State != model.FileImported || file.State != model.FileReimporting || !b.isKeboolaStagingFile(file) {
+			return nil
 		}
+
+		var tableID *keboola.TableID
+		if file.Reimport.TableID != "" {
+			v, err := keboola.ParseTableID(file.Reimport.TableID)
+			if err != nil {
+				return serviceError.NewBadRequestError(err)
+			}
+			tableID = &v
+		}
+
+		var keboolaFile keboolasink.File
+		op.AtomicOpCtxFrom(ctx).
+			Read(func(ctx context.Context) op.Op {
+				return b.schema.File().ForFile(file.FileKey).GetOrErr(b.client).WithResultTo(&keboolaFile)
+			}).
+			Write(func(ctx context.Context) op.Op {
+				if tableID != nil {
+					if tableID.BucketID != keboolaFile.TableID.BucketID {
+						return op.ErrorOp(serviceError.NewBadRequestError(errors.Errorf(
+							`table "%s" must be in the bucket "%s" of the file`, tableID, keboolaFile.TableID.BucketID,
+						)))
+					}
+					keboolaFile.TableID = *tableID
+				}
+				keboolaFile.StorageJobID = nil
+				return b.schema.File().ForFile(file.FileKey).Put(b.client, keboolaFile)
+			})
 		return nil
 	})
 }
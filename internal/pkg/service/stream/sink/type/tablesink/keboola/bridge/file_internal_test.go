@@ -0,0 +1,27 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+)
+
+func TestValidateEncoder(t *testing.T) {
+	t.Parallel()
+
+	file := &model.File{}
+	file.Encoding.Encoder.Type = encoder.TypeCSV
+	require.NoError(t, validateEncoder(file))
+
+	for _, typ := range []encoder.Type{encoder.TypeParquet, encoder.TypeNDJSON} {
+		file.Encoding.Encoder.Type = typ
+		err := validateEncoder(file)
+		if assert.Error(t, err) {
+			assert.Equal(t, `encoder type "`+string(typ)+`" is not supported by the Keboola table sink, only "csv" is supported, use a file sink for other encoders`, err.Error())
+		}
+	}
+}
@@ -138,3 +138,83 @@ func TestBridge_ImportFile_EmptyFile(t *testing.T) {
 	d.Process().WaitForShutdown()
 	logger.AssertNoErrorMessage(t)
 }
+
+func TestBridge_DeleteFile_RetainedStagingFile(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	clk := clockwork.NewFakeClockAt(utctime.MustParse("2000-01-01T01:00:00.000Z").Time())
+	by := test.ByUser()
+
+	d, mock := dependencies.NewMockedCoordinatorScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Storage.MetadataCleanup.ImportedFileRetention = 24 * time.Hour
+	}, commonDeps.WithClock(clk))
+	logger := mock.DebugLogger()
+	client := mock.TestEtcdClient()
+	defRepo := d.DefinitionRepository()
+	storageRepo := d.StorageRepository()
+	volumeRepo := storageRepo.Volume()
+
+	apiCtx := rollback.ContextWith(ctx, rollback.New(d.Logger()))
+	apiCtx = context.WithValue(apiCtx, dependencies.KeboolaProjectAPICtxKey, mock.KeboolaProjectAPI())
+
+	// Register mocked responses
+	// -----------------------------------------------------------------------------------------------------------------
+	transport := mock.MockedHTTPTransport()
+	{
+		bridgeTest.MockTokenStorageAPICalls(t, transport)
+		bridgeTest.MockBucketStorageAPICalls(t, transport)
+		bridgeTest.MockTableStorageAPICalls(t, transport)
+		bridgeTest.MockFileStorageAPICalls(t, clk, transport)
+	}
+
+	// Register active volumes
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		session, err := concurrency.NewSession(client)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, session.Close()) }()
+		test.RegisterWriterVolumes(t, ctx, volumeRepo, session, 1)
+	}
+
+	// Create sink, the staging file is retained
+	// -----------------------------------------------------------------------------------------------------------------
+	projectID := keboola.ProjectID(123)
+	branchKey := key.BranchKey{ProjectID: projectID, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}
+	var file model.File
+	{
+		branch := test.NewBranch(branchKey)
+		require.NoError(t, defRepo.Branch().Create(&branch, clk.Now(), by).Do(apiCtx).Err())
+		source := test.NewSource(sourceKey)
+		require.NoError(t, defRepo.Source().Create(&source, clk.Now(), by, "Create source").Do(apiCtx).Err())
+		sink := test.NewKeboolaTableSink(sinkKey)
+		require.NoError(t, defRepo.Sink().Create(&sink, clk.Now(), by, "Create sink").Do(apiCtx).Err())
+
+		files, err := storageRepo.File().ListAll().Do(ctx).All()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		file = files[0]
+		assert.True(t, file.StagingStorage.Retained)
+	}
+
+	// Delete the file, the permanent staging file is deleted from the Storage API
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		logger.Truncate()
+		transport.ZeroCallCounters()
+		require.NoError(t, storageRepo.File().Delete(file.FileKey, clk.Now()).Do(ctx).Err())
+		expectedStorageAPICall := "DELETE https://connection.keboola.local/v2/storage/branch/456/files/1001"
+		assert.Equal(t, 1, transport.GetCallCountInfo()[expectedStorageAPICall])
+		logger.AssertJSONMessages(t, `{"level":"info","message":"deleted retained staging file","component":"keboola.bridge"}`)
+	}
+
+	// Shutdown
+	// -----------------------------------------------------------------------------------------------------------------
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+	logger.AssertNoErrorMessage(t)
+}
@@ -15,6 +15,11 @@ import (
 func (r *Repository) purgeJobsOnSinkDelete() {
 	r.plugins.Collection().OnSinkDelete(func(ctx context.Context, now time.Time, by definition.By, original, deleted *definition.Sink) error {
 		op.AtomicOpCtxFrom(ctx).AddFrom(r.purgeAllFrom(deleted.SinkKey))
+
+		// Purge also jobs of the sink mirror, if any
+		if original.MirrorSink() != nil || deleted.MirrorSink() != nil {
+			op.AtomicOpCtxFrom(ctx).AddFrom(r.purgeAllFrom(deleted.SinkKey.Mirror()))
+		}
 		return nil
 	})
 }
@@ -30,7 +30,7 @@ func (b *Bridge) uploadSlice(ctx context.Context, volume *diskreader.Volume, sli
 
 	start := b.clock.Now()
 
-	reader, err := volume.OpenReader(slice.SliceKey, slice.LocalStorage, slice.EncodingCompression, slice.StagingStorage.Compression)
+	reader, err := volume.OpenReader(slice.SliceKey, slice.LocalStorage, slice.EncoderType, slice.EncodingCompression, slice.StagingStorage.Compression)
 	if err != nil {
 		b.logger.Warnf(ctx, "unable to open reader: %v", err)
 		return err
@@ -12,6 +12,7 @@ import (
 	serviceError "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/rollback"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
@@ -25,7 +26,7 @@ func (b *Bridge) ensureTableExists(ctx context.Context, api *keboola.AuthorizedA
 	var apiErr *keboola.StorageError
 	if errors.As(err, &apiErr) && apiErr.ErrCode == "storage.tables.notFound" {
 		// Create table
-		tab, err = b.createTable(ctx, api, tableKey, columnsNames, primaryKey)
+		tab, err = b.createTable(ctx, api, tableKey, sink.Table.Mapping.Columns)
 	}
 
 	// Handle get/create error
@@ -60,24 +61,25 @@ func (b *Bridge) getTable(ctx context.Context, api *keboola.AuthorizedAPI, table
 	return api.GetTableRequest(tableKey).Send(ctx)
 }
 
-func (b *Bridge) createTable(ctx context.Context, api *keboola.AuthorizedAPI, tableKey keboola.TableKey, columnNames, primaryKey []string) (*keboola.Table, error) {
+func (b *Bridge) createTable(ctx context.Context, api *keboola.AuthorizedAPI, tableKey keboola.TableKey, columns column.Columns) (*keboola.Table, error) {
+	primaryKey := columns.PrimaryKey()
 	ctx = ctxattr.ContextWith(
 		ctx,
 		attribute.String("table.key", tableKey.String()),
-		attribute.StringSlice("table.columns", columnNames),
+		attribute.StringSlice("table.columns", columns.Names()),
 		attribute.StringSlice("table.pk", primaryKey),
 	)
 
 	rb := rollback.FromContext(ctx)
 
-	// Create table definition
+	// Create table definition, typed columns have the matching base type
 	tableDef := keboola.TableDefinition{PrimaryKeyNames: primaryKey}
-	for _, name := range columnNames {
+	for _, col := range columns {
 		tableDef.Columns = append(
 			tableDef.Columns,
 			keboola.Column{
-				Name:     name,
-				BaseType: new(keboola.TypeString),
+				Name:     col.ColumnName(),
+				BaseType: new(columnBaseType(col)),
 			},
 		)
 	}
@@ -100,6 +102,28 @@ func (b *Bridge) createTable(ctx context.Context, api *keboola.AuthorizedAPI, ta
 	return tab, nil
 }
 
+// columnBaseType maps the target data type of the column to the Keboola base type, see column.Cast.
+// Values of a "json" column and of columns without the data type are stored as strings.
+func columnBaseType(col column.Column) keboola.BaseType {
+	typed, ok := col.(column.Typed)
+	if !ok || typed.ColumnCast() == nil {
+		return keboola.TypeString
+	}
+
+	switch typed.ColumnCast().DataType {
+	case column.DataTypeInteger:
+		return keboola.TypeInt
+	case column.DataTypeNumber:
+		return keboola.TypeFloat
+	case column.DataTypeBoolean:
+		return keboola.TypeBoolean
+	case column.DataTypeTimestamp:
+		return keboola.TypeTimestamp
+	default:
+		return keboola.TypeString
+	}
+}
+
 func (b *Bridge) addTableMetadata(ctx context.Context, api *keboola.AuthorizedAPI, table *keboola.Table, sink definition.Sink) error {
 	foundSinkMetaKey := false
 	foundSourceMetaKey := false
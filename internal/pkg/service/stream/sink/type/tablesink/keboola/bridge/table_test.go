@@ -0,0 +1,22 @@
+package bridge
+
+import (
+	"testing"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+)
+
+func TestColumnBaseType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, keboola.TypeString, columnBaseType(column.UUID{Name: "id"}))
+	assert.Equal(t, keboola.TypeString, columnBaseType(column.Body{Name: "body"}))
+	assert.Equal(t, keboola.TypeString, columnBaseType(column.Body{Name: "body", Cast: &column.Cast{DataType: column.DataTypeJSON}}))
+	assert.Equal(t, keboola.TypeInt, columnBaseType(column.Path{Name: "count", Cast: &column.Cast{DataType: column.DataTypeInteger}}))
+	assert.Equal(t, keboola.TypeFloat, columnBaseType(column.Path{Name: "price", Cast: &column.Cast{DataType: column.DataTypeNumber}}))
+	assert.Equal(t, keboola.TypeBoolean, columnBaseType(column.Template{Name: "active", Cast: &column.Cast{DataType: column.DataTypeBoolean}}))
+	assert.Equal(t, keboola.TypeTimestamp, columnBaseType(column.Template{Name: "time", Cast: &column.Cast{DataType: column.DataTypeTimestamp}}))
+}
@@ -28,6 +28,11 @@ func (b *Bridge) deleteTokenOnSinkDeactivation() {
 			}
 
 			op.AtomicOpCtxFrom(ctx).AddFrom(b.deleteToken(api, sink.SinkKey))
+
+			// Delete token of the sink mirror, if any
+			if original.MirrorSink() != nil || sink.MirrorSink() != nil {
+				op.AtomicOpCtxFrom(ctx).AddFrom(b.deleteToken(api, sink.SinkKey.Mirror()))
+			}
 		}
 		return nil
 	})
@@ -9,6 +9,9 @@ type Config struct {
 	// EventSendTimeout is a timeout to perform slice upload event or file import event.
 	EventSendTimeout time.Duration `configKey:"eventSendTimeout" configUsage:"Timeout to perform upload send event of slice or import event of file"`
 	JobLimit         int           `configKey:"jobLimit" configUsage:"Number of import jobs running in keboola for single sink"`
+	// StagingFileRetention is set from the "storage.metadataCleanup.importedFileRetention" configuration.
+	// If it is set, staging files are permanent in the Keboola Storage, so they don't expire before the file is deleted by the cleanup.
+	StagingFileRetention time.Duration `configKey:"-"`
 }
 
 func NewConfig() Config {
@@ -0,0 +1,56 @@
+package webhooksink
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// BlockedAddressError is returned if the webhook URL, or a redirect, points to a private, loopback or link-local address.
+type BlockedAddressError struct {
+	Address string
+}
+
+func (e BlockedAddressError) Error() string {
+	return fmt.Sprintf("address %q is not allowed, it is a private, loopback or link-local address", e.Address)
+}
+
+// newHTTPClient creates the client for webhook requests.
+// The target address is checked when the connection is established, after the DNS resolution,
+// so the check cannot be bypassed by a DNS record pointing to an internal address, or by a redirect.
+func newHTTPClient(cfg Config) *http.Client {
+	dialer := &net.Dialer{}
+	if !cfg.AllowPrivateTargets {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			return checkAddress(address)
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would be checked instead of the target, so it is not used
+	transport.Proxy = nil
+
+	return &http.Client{Transport: transport}
+}
+
+func checkAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return errors.Errorf(`unexpected address "%s", expected an IP address`, address)
+	}
+
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return BlockedAddressError{Address: ip.String()}
+	}
+
+	return nil
+}
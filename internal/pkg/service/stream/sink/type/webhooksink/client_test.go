@@ -0,0 +1,37 @@
+package webhooksink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAddress(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		address string
+		allowed bool
+	}{
+		{address: "93.184.216.34:443", allowed: true},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443", allowed: true},
+		{address: "127.0.0.1:80"},
+		{address: "[::1]:80"},
+		{address: "10.1.2.3:80"},
+		{address: "172.16.0.1:80"},
+		{address: "192.168.1.1:80"},
+		{address: "[fd00::1]:80"},
+		{address: "169.254.169.254:80"},
+		{address: "[fe80::1]:80"},
+		{address: "0.0.0.0:80"},
+	}
+
+	for _, tc := range cases {
+		err := checkAddress(tc.address)
+		if tc.allowed {
+			assert.NoError(t, err, tc.address)
+		} else if assert.Error(t, err, tc.address) {
+			assert.ErrorAs(t, err, &BlockedAddressError{}, tc.address)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package webhooksink
+
+import (
+	"time"
+)
+
+type Config struct {
+	BatchSize           int           `configKey:"batchSize" configUsage:"Maximum number of records sent in one request." validate:"required,min=1,max=10000"`
+	FlushInterval       time.Duration `configKey:"flushInterval" configUsage:"Maximum time a record waits for other records of the batch." validate:"required,minDuration=1ms,maxDuration=1m"`
+	RequestTimeout      time.Duration `configKey:"requestTimeout" configUsage:"Timeout of one request to the webhook endpoint." validate:"required,minDuration=100ms,maxDuration=5m"`
+	MaxRetries          int           `configKey:"maxRetries" configUsage:"Maximum number of retries of a failed request, then records of the batch are stored to the dead-letter storage of the sink, if enabled." validate:"min=0,max=100"`
+	RetryInitialBackoff time.Duration `configKey:"retryInitialBackoff" configUsage:"Initial delay before a retry of a failed request." validate:"required,minDuration=1ms,maxDuration=1m"`
+	RetryMaxBackoff     time.Duration `configKey:"retryMaxBackoff" configUsage:"Maximum delay before a retry of a failed request." validate:"required,minDuration=1ms,maxDuration=5m,gtefield=RetryInitialBackoff"`
+	MaxPendingBatches   int           `configKey:"maxPendingBatches" configUsage:"Maximum number of batches collected or sent at once by one sink, a record over the limit is rejected." validate:"required,min=1,max=10000"`
+	AllowPrivateTargets bool          `configKey:"allowPrivateTargets" configUsage:"Allow requests to private, loopback and link-local addresses. It should be enabled only for a local development."`
+}
+
+func NewConfig() Config {
+	return Config{
+		BatchSize:           100,
+		FlushInterval:       100 * time.Millisecond,
+		RequestTimeout:      30 * time.Second,
+		MaxRetries:          3,
+		RetryInitialBackoff: 100 * time.Millisecond,
+		RetryMaxBackoff:     5 * time.Second,
+		MaxPendingBatches:   100,
+	}
+}
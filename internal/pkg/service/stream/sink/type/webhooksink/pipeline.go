@@ -0,0 +1,386 @@
+package webhooksink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/jonboulle/clockwork"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// maxResponseBodySize limits the part of the response body which is read, to reuse the connection.
+	maxResponseBodySize = 64 * 1024
+	// maxReasonLength limits the part of the response body which is included in the error message.
+	maxReasonLength = 256
+)
+
+// webhookPipeline implements pipeline.Pipeline for the definition.SinkTypeWebhook.
+//
+// Records are collected to batches, one batch per rendered headers.
+// A batch is sent, if it reaches the Config.BatchSize, or after the Config.FlushInterval.
+// The WriteRecord method waits until the batch is acknowledged by the webhook endpoint, then the pipeline.RecordProcessed status is returned.
+// So no record is lost on a crash of the node, the source has not confirmed it yet.
+// Records of a batch, which cannot be delivered, are stored to the dead-letter storage of the sink, if enabled.
+// The number of batches collected or sent at once is limited by the Config.MaxPendingBatches, a record over the limit is rejected.
+type webhookPipeline struct {
+	logger     log.Logger
+	clock      clockwork.Clock
+	config     Config
+	client     *http.Client
+	renderer   *column.Renderer
+	router     *sinkRouter.Router
+	sinkKey    key.SinkKey
+	webhook    definition.WebhookSink
+	deadLetter bool
+	keys       [][]byte
+	onClose    func(ctx context.Context, cause string)
+
+	// ctx is used for requests, they are not bound to a record context, the batch contains multiple records
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	// closing channel is closed by the Close method, retries are not waited for anymore
+	closing chan struct{}
+
+	lock    sync.Mutex
+	closed  bool
+	batches map[string]*batch
+	// pending is the number of batches collected or sent, it is limited by the Config.MaxPendingBatches
+	pending int
+	// wg waits for all batches, including batches in flight
+	wg sync.WaitGroup
+}
+
+type batch struct {
+	header  http.Header
+	records [][]byte
+	timer   clockwork.Timer
+	// taken is true, if the batch has been taken by a goroutine to be sent
+	taken bool
+	// done channel is closed, when the batch has been sent, or the send failed, see the err field
+	done chan struct{}
+	err  error
+}
+
+func (p *webhookPipeline) ReopenOnSinkModification() bool {
+	// The pipeline contains the webhook definition, so it must be reopened on a modification
+	return true
+}
+
+func (p *webhookPipeline) WriteRecord(c recordctx.Context) (pipeline.WriteResult, error) {
+	record, err := p.renderRecord(c)
+	if err != nil {
+		return pipeline.WriteResult{Status: pipeline.RecordError}, err
+	}
+
+	header, batchKey, err := p.renderHeader(c)
+	if err != nil {
+		return pipeline.WriteResult{Status: pipeline.RecordError}, err
+	}
+
+	b, full, err := p.addRecord(batchKey, header, record)
+	if err != nil {
+		return pipeline.WriteResult{Status: pipeline.RecordError}, err
+	}
+
+	// The full batch is sent immediately, otherwise after the flush interval
+	if full {
+		go p.flush(batchKey, b)
+	}
+
+	// Wait for the webhook endpoint
+	select {
+	case <-b.done:
+	case <-c.Ctx().Done():
+		// The record may still be delivered, the source may retry it
+		return pipeline.WriteResult{Status: pipeline.RecordError}, errors.PrefixError(context.Cause(c.Ctx()), "webhook request has not been finished")
+	}
+
+	if b.err == nil {
+		return pipeline.WriteResult{Status: pipeline.RecordProcessed, Bytes: len(record)}, nil
+	}
+
+	// The record context is still valid, so the record can be stored to the dead-letter storage, if enabled
+	if p.deadLetter && p.router.StoreDeadLetter(p.sinkKey, c, b.err) {
+		return pipeline.WriteResult{Status: pipeline.RecordProcessed}, nil
+	}
+
+	return pipeline.WriteResult{Status: pipeline.RecordError}, b.err
+}
+
+// Close sends pending batches, retries of failed requests are stopped.
+// Requests in flight are cancelled, if the ctx is done before they are finished.
+func (p *webhookPipeline) Close(ctx context.Context, cause string) {
+	p.lock.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.closing)
+	}
+	pending := make(map[string]*batch, len(p.batches))
+	for k, b := range p.batches {
+		pending[k] = b
+	}
+	p.lock.Unlock()
+
+	// Send pending batches without waiting for the flush interval
+	for batchKey, b := range pending {
+		go p.flush(batchKey, b)
+	}
+
+	// Wait for batches in flight
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		p.cancel(errors.Errorf("webhook sink pipeline closed: %s", cause))
+		<-done
+	}
+
+	p.onClose(ctx, cause)
+}
+
+// renderRecord maps the record to a JSON object, keys are column names.
+func (p *webhookPipeline) renderRecord(c recordctx.Context) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, col := range p.webhook.Mapping.Columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(p.keys[i])
+		buf.WriteByte(':')
+		if err := p.renderer.WriteJSONValue(&buf, col, c); err != nil {
+			return nil, svcerrors.NewUnprocessableContentError(errors.PrefixErrorf(err, `cannot render column "%s"`, col.ColumnName()))
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// renderHeader renders request headers, the batch key identifies records with the same headers.
+func (p *webhookPipeline) renderHeader(c recordctx.Context) (http.Header, string, error) {
+	header := make(http.Header, len(p.webhook.Headers))
+	var batchKey strings.Builder
+	for _, col := range p.webhook.Headers {
+		value, err := p.renderer.CSVValue(col, c)
+		if err != nil {
+			return nil, "", svcerrors.NewUnprocessableContentError(errors.PrefixErrorf(err, `cannot render header "%s"`, col.ColumnName()))
+		}
+
+		var str string
+		switch v := value.(type) {
+		case []byte:
+			str = string(v)
+		case string:
+			str = v
+		default:
+			return nil, "", errors.Errorf(`unexpected value type "%T"`, value)
+		}
+
+		header.Set(col.ColumnName(), str)
+		batchKey.WriteString(col.ColumnName())
+		batchKey.WriteByte(':')
+		batchKey.WriteString(str)
+		batchKey.WriteByte('\n')
+	}
+	return header, batchKey.String(), nil
+}
+
+// addRecord adds the record to the batch, the full flag is true, if the batch should be sent.
+func (p *webhookPipeline) addRecord(batchKey string, header http.Header, record []byte) (b *batch, full bool, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.closed {
+		return nil, false, svcerrors.NewServiceUnavailableError(errors.New("webhook sink pipeline is closed"))
+	}
+
+	b = p.batches[batchKey]
+	if b == nil {
+		if p.pending >= p.config.MaxPendingBatches {
+			return nil, false, svcerrors.NewServiceUnavailableError(errors.Errorf("too many pending webhook batches, the limit is %d", p.config.MaxPendingBatches))
+		}
+
+		b = &batch{header: header, done: make(chan struct{})}
+		b.timer = p.clock.AfterFunc(p.config.FlushInterval, func() {
+			p.flush(batchKey, b)
+		})
+		p.batches[batchKey] = b
+		p.pending++
+		p.wg.Add(1)
+	}
+
+	b.records = append(b.records, record)
+
+	full = len(b.records) >= p.config.BatchSize
+	if full {
+		// The next record starts a new batch, the full batch is sent by the flush method
+		delete(p.batches, batchKey)
+	}
+	return b, full, nil
+}
+
+// flush sends the batch, if it has not been already sent by another goroutine.
+// Writers of the batch records are unblocked, when the batch is sent or the send failed.
+func (p *webhookPipeline) flush(batchKey string, b *batch) {
+	p.lock.Lock()
+	if b.taken {
+		p.lock.Unlock()
+		return
+	}
+	b.taken = true
+	if p.batches[batchKey] == b {
+		delete(p.batches, batchKey)
+	}
+	b.timer.Stop()
+	p.lock.Unlock()
+
+	defer p.wg.Done()
+	if err := p.send(b); err != nil {
+		p.logger.Warnf(p.ctx, `webhook batch of %d records has not been delivered: %s`, len(b.records), err)
+		b.err = err
+	}
+	close(b.done)
+
+	p.lock.Lock()
+	p.pending--
+	p.lock.Unlock()
+}
+
+// send sends the batch with retries.
+// Retries are stopped, if the pipeline is closing.
+func (p *webhookPipeline) send(b *batch) error {
+	body := append(append([]byte{'['}, bytes.Join(b.records, []byte{','})...), ']')
+
+	retry := newRetryBackoff(p.config)
+	attempt := 0
+	for {
+		attempt++
+
+		retryable, err := p.sendRequest(b.header, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retryable || attempt > p.config.MaxRetries {
+			return errors.Errorf(`webhook request failed after %d attempts: %w`, attempt, err)
+		}
+
+		delay := retry.NextBackOff()
+		p.logger.Infof(p.ctx, `webhook request failed, attempt %d, next attempt after %s: %s`, attempt, delay, err)
+
+		select {
+		case <-p.closing:
+			return errors.Errorf(`webhook request failed after %d attempts, the pipeline is closing: %w`, attempt, err)
+		case <-p.ctx.Done():
+			return errors.Errorf(`webhook request failed after %d attempts: %w`, attempt, context.Cause(p.ctx))
+		case <-p.clock.After(delay):
+		}
+	}
+}
+
+// sendRequest sends one request, the retryable flag is true for network errors and 408, 429, 5xx responses.
+// A request to a blocked address is not retried, see the newHTTPClient function.
+func (p *webhookPipeline) sendRequest(header http.Header, body []byte) (retryable bool, err error) {
+	ctx, cancel := context.WithTimeout(p.ctx, p.config.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header = header.Clone()
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if errors.As(err, &BlockedAddressError{}) {
+		return false, err
+	} else if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = errors.Errorf(`unexpected status code "%d"`, resp.StatusCode)
+	if reason := strings.TrimSpace(string(respBody)); reason != "" {
+		if len(reason) > maxReasonLength {
+			reason = reason[:maxReasonLength] + "…"
+		}
+		err = errors.Errorf(`unexpected status code "%d": %s`, resp.StatusCode, reason)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode >= 500:
+		return true, err
+	default:
+		return false, err
+	}
+}
+
+func newPipeline(ctx context.Context, p *Provider, sinkKey key.SinkKey, webhook definition.WebhookSink, deadLetter bool, onClose func(ctx context.Context, cause string)) (*webhookPipeline, error) {
+	// Pre-encode object keys
+	keys := make([][]byte, len(webhook.Mapping.Columns))
+	for i, col := range webhook.Mapping.Columns {
+		k, err := json.Marshal(col.ColumnName())
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = k
+	}
+
+	ctx, cancel := context.WithCancelCause(ctxattr.ContextWith(context.WithoutCancel(ctx), sinkKey.Telemetry()...))
+	return &webhookPipeline{
+		logger:     p.logger.With(sinkKey.Telemetry()...),
+		clock:      p.clock,
+		config:     p.config,
+		client:     p.client,
+		renderer:   p.renderer,
+		router:     p.router,
+		sinkKey:    sinkKey,
+		webhook:    webhook,
+		deadLetter: deadLetter,
+		keys:       keys,
+		onClose:    onClose,
+		ctx:        ctx,
+		cancel:     cancel,
+		closing:    make(chan struct{}),
+		batches:    make(map[string]*batch),
+	}, nil
+}
+
+func newRetryBackoff(cfg Config) *backoff.ExponentialBackOff {
+	b := backoff.NewExponentialBackOff()
+	b.RandomizationFactor = 0.2
+	b.Multiplier = 2
+	b.InitialInterval = cfg.RetryInitialBackoff
+	b.MaxInterval = cfg.RetryMaxBackoff
+	b.Reset()
+	return b
+}
@@ -0,0 +1,77 @@
+// Package webhooksink provides the definition.SinkTypeWebhook sink, it forwards mapped records to an HTTP endpoint.
+//
+// The sink doesn't use the local storage, records are sent directly from the source node,
+// see the webhookPipeline for details about batching and retries.
+//
+// Requests to private, loopback and link-local addresses are rejected, see the newHTTPClient function.
+package webhooksink
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Provider opens pipelines of webhook sinks.
+// Records which cannot be delivered are stored to the dead-letter storage of the sink, see the deadletter package.
+type Provider struct {
+	logger      log.Logger
+	clock       clockwork.Clock
+	config      Config
+	client      *http.Client
+	renderer    *column.Renderer
+	definitions *definitionRepo.Repository
+	router      *sinkRouter.Router
+}
+
+type dependencies interface {
+	Logger() log.Logger
+	Clock() clockwork.Clock
+	Plugins() *plugin.Plugins
+	DefinitionRepository() *definitionRepo.Repository
+	SinkRouter() *sinkRouter.Router
+}
+
+func New(d dependencies, config Config) *Provider {
+	p := &Provider{
+		logger:      d.Logger().WithComponent("sink.webhook"),
+		clock:       d.Clock(),
+		config:      config,
+		client:      newHTTPClient(config),
+		renderer:    column.NewRenderer(),
+		definitions: d.DefinitionRepository(),
+		router:      d.SinkRouter(),
+	}
+
+	d.Plugins().RegisterSinkPipelineOpener(p.openPipeline)
+
+	return p
+}
+
+func (p *Provider) openPipeline(ctx context.Context, sinkKey key.SinkKey, sinkType definition.SinkType, onClose func(ctx context.Context, cause string)) (pipeline.Pipeline, error) {
+	if sinkType != definition.SinkTypeWebhook {
+		return nil, pipeline.NoOpenerFoundError{SinkType: sinkType}
+	}
+
+	sink, err := p.definitions.Sink().Get(sinkKey).Do(ctx).ResultOrErr()
+	if err != nil {
+		return nil, err
+	}
+
+	if sink.Webhook == nil {
+		return nil, errors.Errorf(`sink "%s" has no webhook configuration`, sinkKey)
+	}
+
+	return newPipeline(ctx, p, sinkKey, *sink.Webhook, sink.DeadLetter, onClose)
+}
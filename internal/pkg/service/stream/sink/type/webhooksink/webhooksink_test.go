@@ -0,0 +1,303 @@
+package webhooksink_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+)
+
+type receivedRequest struct {
+	Tenant      string
+	ContentType string
+	Body        string
+}
+
+func TestWebhookSink(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	// Webhook endpoint: the first request fails with a retryable error, the "bad" tenant is always rejected
+	var lock sync.Mutex
+	var received []receivedRequest
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		tenant := req.Header.Get("X-Tenant")
+		switch {
+		case tenant == "bad":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("unknown tenant"))
+		case failures > 0:
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			received = append(received, receivedRequest{Tenant: tenant, ContentType: req.Header.Get("Content-Type"), Body: string(body)})
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	d, _ := dependencies.NewMockedSourceScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Sink.Webhook.BatchSize = 2
+		cfg.Sink.Webhook.FlushInterval = 50 * time.Millisecond
+		cfg.Sink.Webhook.MaxRetries = 2
+		cfg.Sink.Webhook.RetryInitialBackoff = time.Millisecond
+		cfg.Sink.Webhook.RetryMaxBackoff = 5 * time.Millisecond
+		// The test server listens on the loopback
+		cfg.Sink.Webhook.AllowPrivateTargets = true
+	})
+
+	// Create branch, source and sink
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	sink := test.NewWebhookSink(sinkKey, server.URL)
+	sink.DeadLetter = true
+	sink.Webhook.Headers = column.Columns{
+		column.Template{Name: "X-Tenant", Template: column.TemplateConfig{Language: column.TemplateLanguageJsonnet, Content: `Body("tenant")`}, RawString: true},
+	}
+	sink.Webhook.Mapping = table.Mapping{
+		Columns: column.Columns{
+			column.Path{Name: "id", Path: "id"},
+			column.Body{Name: "body"},
+		},
+	}
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&sink, now, test.ByUser(), "create").Do(ctx).Err())
+
+	// Wait for the sink router, it stores dead-letter records
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 1, d.SinkRouter().SourcesCount())
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Open pipeline
+	closed := make(chan struct{})
+	p, err := d.Plugins().OpenSinkPipeline(ctx, sinkKey, definition.SinkTypeWebhook, func(ctx context.Context, cause string) {
+		close(closed)
+	})
+	require.NoError(t, err)
+	assert.True(t, p.ReopenOnSinkModification())
+
+	writeRecord := func(body string) (pipeline.WriteResult, error) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return p.WriteRecord(recordctx.FromHTTP(now, req))
+	}
+
+	// Records are written in parallel, each write waits for the webhook endpoint.
+	// Two records with the same headers are sent in one batch, the first attempt fails and it is retried.
+	// A single record is sent after the flush interval.
+	// A non-retryable error stores the record to the dead-letter storage, it is also a persistent storage.
+	bodies := []string{`{"tenant":"a","id":1}`, `{"tenant":"a","id":2}`, `{"tenant":"b","id":3}`, `{"tenant":"bad","id":4}`}
+	results := make([]pipeline.WriteResult, len(bodies))
+	errs := make([]error, len(bodies))
+	wg := &sync.WaitGroup{}
+	for i, body := range bodies {
+		wg.Go(func() {
+			results[i], errs[i] = writeRecord(body)
+		})
+	}
+	wg.Wait()
+	for i := range bodies {
+		require.NoError(t, errs[i])
+		assert.Equal(t, pipeline.RecordProcessed, results[i].Status)
+	}
+	assert.Equal(t, len(`{"id":3,"body":{"tenant":"b","id":3}}`), results[2].Bytes)
+
+	// Check the dead-letter storage
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		records, err := d.DeadLetterRepository().List(sinkKey).Do(ctx).All()
+		if assert.NoError(c, err) && assert.Len(c, records, 1) {
+			assert.JSONEq(c, `{"tenant":"bad","id":4}`, string(records[0].Body))
+			assert.Equal(c, `webhook request failed after 1 attempts: unexpected status code "400": unknown tenant`, records[0].Error)
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Check received requests, all writes have been acknowledged, so there is no need to wait
+	lock.Lock()
+	slices.SortFunc(received, func(a, b receivedRequest) int {
+		return strings.Compare(a.Tenant, b.Tenant)
+	})
+	if assert.Len(t, received, 2) {
+		assert.Equal(t, "a", received[0].Tenant)
+		assert.Equal(t, "application/json", received[0].ContentType)
+		assert.Len(t, strings.Split(received[0].Body, `},{"id"`), 2)
+		assert.Contains(t, received[0].Body, `{"id":1,"body":{"tenant":"a","id":1}}`)
+		assert.Contains(t, received[0].Body, `{"id":2,"body":{"tenant":"a","id":2}}`)
+		assert.Equal(t, "b", received[1].Tenant)
+		assert.Equal(t, `[{"id":3,"body":{"tenant":"b","id":3}}]`, received[1].Body)
+	}
+	lock.Unlock()
+
+	// Close the pipeline
+	p.Close(ctx, "test")
+	<-closed
+
+	// Writes to the closed pipeline fail
+	_, err = writeRecord(`{"tenant":"c","id":5}`)
+	if assert.Error(t, err) {
+		assert.Equal(t, "webhook sink pipeline is closed", err.Error())
+	}
+}
+
+func TestWebhookSink_PrivateTarget(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	// The endpoint listens on the loopback, so it must not be called
+	var called atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called.Store(true)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	d, _ := dependencies.NewMockedSourceScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Sink.Webhook.BatchSize = 1
+		cfg.Sink.Webhook.RetryInitialBackoff = time.Millisecond
+		cfg.Sink.Webhook.RetryMaxBackoff = 5 * time.Millisecond
+	})
+
+	// Create branch, source and sink
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	sink := test.NewWebhookSink(sinkKey, server.URL)
+	sink.DeadLetter = true
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&sink, now, test.ByUser(), "create").Do(ctx).Err())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 1, d.SinkRouter().SourcesCount())
+	}, 10*time.Second, 10*time.Millisecond)
+
+	p, err := d.Plugins().OpenSinkPipeline(ctx, sinkKey, definition.SinkTypeWebhook, func(ctx context.Context, cause string) {})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"foo":"bar"}`))
+	req.Header.Set("Content-Type", "application/json")
+	result, err := p.WriteRecord(recordctx.FromHTTP(now, req))
+	require.NoError(t, err)
+	assert.Equal(t, pipeline.RecordProcessed, result.Status)
+
+	// The request is blocked without a retry, the record is stored to the dead-letter storage
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		records, err := d.DeadLetterRepository().List(sinkKey).Do(ctx).All()
+		if assert.NoError(c, err) && assert.Len(c, records, 1) {
+			assert.Contains(c, records[0].Error, "webhook request failed after 1 attempts")
+			assert.Contains(c, records[0].Error, `address "127.0.0.1" is not allowed, it is a private, loopback or link-local address`)
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.False(t, called.Load())
+
+	p.Close(ctx, "test")
+}
+
+func TestWebhookSink_MaxPendingBatches(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	// The endpoint blocks the first request, until it is released
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		once.Do(func() {
+			close(requested)
+		})
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	d, _ := dependencies.NewMockedSourceScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Sink.Webhook.BatchSize = 1
+		cfg.Sink.Webhook.MaxPendingBatches = 1
+		cfg.Sink.Webhook.AllowPrivateTargets = true
+	})
+
+	// Create branch, source and sink
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(sourceKey)
+	sink := test.NewWebhookSink(sinkKey, server.URL)
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&sink, now, test.ByUser(), "create").Do(ctx).Err())
+
+	p, err := d.Plugins().OpenSinkPipeline(ctx, sinkKey, definition.SinkTypeWebhook, func(ctx context.Context, cause string) {})
+	require.NoError(t, err)
+
+	writeRecord := func() (pipeline.WriteResult, error) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"foo":"bar"}`))
+		req.Header.Set("Content-Type", "application/json")
+		return p.WriteRecord(recordctx.FromHTTP(now, req))
+	}
+
+	// The first write waits for the endpoint
+	type writeResult struct {
+		result pipeline.WriteResult
+		err    error
+	}
+	firstDone := make(chan writeResult, 1)
+	go func() {
+		result, err := writeRecord()
+		firstDone <- writeResult{result: result, err: err}
+	}()
+	<-requested
+
+	// The second write is rejected, the first batch is still pending
+	_, err = writeRecord()
+	if assert.Error(t, err) {
+		assert.Equal(t, "too many pending webhook batches, the limit is 1", err.Error())
+	}
+
+	// The first write is finished, when the endpoint responds
+	close(release)
+	first := <-firstDone
+	require.NoError(t, first.err)
+	assert.Equal(t, pipeline.RecordProcessed, first.result.Status)
+
+	p.Close(ctx, "test")
+}
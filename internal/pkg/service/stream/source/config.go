@@ -1,17 +1,28 @@
 package source
 
 import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/ratelimit"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/httpsource"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/kafkasource"
 )
 
 type Config struct {
-	HTTP httpsource.Config `configKey:"http"`
+	HTTP      httpsource.Config  `configKey:"http"`
+	Kafka     kafkasource.Config `configKey:"kafka"`
+	Sample    sample.Config      `configKey:"sample"`
+	RateLimit ratelimit.Config   `configKey:"rateLimit"`
 }
 
-type ConfigPatch struct{}
+type ConfigPatch struct {
+	RateLimit *ratelimit.ConfigPatch `json:"rateLimit,omitempty"`
+}
 
 func NewConfig() Config {
 	return Config{
-		HTTP: httpsource.NewConfig(),
+		HTTP:      httpsource.NewConfig(),
+		Kafka:     kafkasource.NewConfig(),
+		Sample:    sample.NewConfig(),
+		RateLimit: ratelimit.NewConfig(),
 	}
 }
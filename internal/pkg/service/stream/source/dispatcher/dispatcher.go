@@ -6,10 +6,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
 	etcd "go.etcd.io/etcd/client/v3"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/configpatch"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
@@ -18,6 +21,8 @@ import (
 	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
 	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/ratelimit"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/httpsource/signature"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -26,6 +31,12 @@ import (
 type Dispatcher struct {
 	logger     log.Logger
 	sinkRouter *sinkRouter.Router
+	// signatures verifies webhook signatures, and stores received signatures for the replay protection
+	signatures *signature.Verifier
+	// limiter enforces per-source and per-project rate limits, limits are shared by all source nodes
+	limiter *ratelimit.Limiter
+	// projectLimit is the per-project rate limit, the per-source limit may be modified by the Source.Config
+	projectLimit ratelimit.Limit
 	// sources field contains in-memory snapshot of all active HTTP sources. Only necessary data is saved.
 	sources *etcdop.MirrorTree[definition.Source, *sourceData]
 	// cancelMirror on shutdown
@@ -41,21 +52,36 @@ type sourceData struct {
 	sourceType definition.SourceType
 	enabled    bool
 	secret     string
+	signature  *definition.HTTPSourceSignature
+	rateLimit  ratelimit.Limit
 }
 
 type dependencies interface {
+	Clock() clockwork.Clock
 	Process() *servicectx.Process
+	DistributionNode() *distribution.Node
 	DefinitionRepository() *definitionRepo.Repository
 	SinkRouter() *sinkRouter.Router
 	Telemetry() telemetry.Telemetry
 	WatchTelemetryInterval() time.Duration
 }
 
-func New(d dependencies, logger log.Logger) (*Dispatcher, error) {
+func New(d dependencies, logger log.Logger, rateLimitCfg ratelimit.Config) (*Dispatcher, error) {
 	dp := &Dispatcher{
-		logger:     logger.WithComponent("dispatcher"),
-		sinkRouter: d.SinkRouter(),
-		closed:     make(chan struct{}),
+		logger:       logger.WithComponent("dispatcher"),
+		sinkRouter:   d.SinkRouter(),
+		signatures:   signature.NewVerifier(d.Clock()),
+		projectLimit: rateLimitCfg.Project,
+		closed:       make(chan struct{}),
+	}
+
+	// Rate limits are divided by the number of source nodes in the group
+	{
+		group, err := d.DistributionNode().Group("source.ratelimit")
+		if err != nil {
+			return nil, err
+		}
+		dp.limiter = ratelimit.NewLimiter(group.NodesCount)
 	}
 
 	// Start sources mirroring, only necessary data is saved
@@ -71,9 +97,11 @@ func New(d dependencies, logger log.Logger) (*Dispatcher, error) {
 			},
 			func(key string, source definition.Source, rawValue *op.KeyValue, oldValue **sourceData) *sourceData {
 				var secret string
+				var sig *definition.HTTPSourceSignature
 				switch source.Type {
 				case definition.SourceTypeHTTP:
 					secret = source.HTTP.Secret
+					sig = source.HTTP.Signature
 				case definition.SourceTypeOTLP:
 					secret = source.OTLP.Secret
 				}
@@ -82,6 +110,8 @@ func New(d dependencies, logger log.Logger) (*Dispatcher, error) {
 					sourceType: source.Type,
 					enabled:    source.IsEnabled(),
 					secret:     secret,
+					signature:  sig,
+					rateLimit:  dp.sourceRateLimit(ctx, rateLimitCfg, source),
 				}
 			},
 		).
@@ -114,6 +144,67 @@ func (d *Dispatcher) ValidateSource(projectID keboola.ProjectID, sourceID key.So
 	return err
 }
 
+// VerifySignature checks the webhook signature of the request, if it is configured in the matched source.
+// The HTTP handler calls it before the record context is created, a request with an invalid signature is not dispatched.
+// The returned release function must be called, if the request has not been successfully dispatched, see signature.Verifier.
+func (d *Dispatcher) VerifySignature(projectID keboola.ProjectID, sourceID key.SourceID, secret string, expectedType definition.SourceType, header signature.HeaderGetter, body []byte) (release func(), err error) {
+	d.wg.Add(1)
+	defer d.wg.Done()
+
+	if d.isClosed() {
+		return nil, ShutdownError{}
+	}
+
+	// The signature is checked for each matched source/branch, the configuration may differ between branches
+	var releases []func()
+	d.sources.WalkPrefix(sourceKeyPrefix(projectID, sourceID), func(key string, source *sourceData) (stop bool) {
+		if source.sourceType != expectedType || source.secret != secret || !source.enabled || source.signature == nil {
+			return false
+		}
+		var releaseSource func()
+		releaseSource, err = d.signatures.Verify(source.sourceKey.String(), *source.signature, header, body)
+		if err != nil {
+			return true
+		}
+		releases = append(releases, releaseSource)
+		return false
+	})
+
+	release = func() {
+		for _, fn := range releases {
+			fn()
+		}
+	}
+
+	// Signatures stored for other branches are released, the request is rejected
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// CheckRateLimit takes the records and bytes from rate limits of the project and all matched sources/branches.
+// The HTTP and OTLP handlers call it before dispatching, so a rejected request is not written to any sink.
+func (d *Dispatcher) CheckRateLimit(now time.Time, projectID keboola.ProjectID, sourceID key.SourceID, secret string, expectedType definition.SourceType, records, bytes int) error {
+	d.wg.Add(1)
+	defer d.wg.Done()
+
+	if d.isClosed() {
+		return ShutdownError{}
+	}
+
+	requests := []ratelimit.Request{{Key: ratelimit.Key{Scope: ratelimit.ScopeProject, ID: projectID.String()}, Limit: d.projectLimit}}
+	d.walkMatchedSources(projectID, sourceID, secret, expectedType, func(source *sourceData) {
+		if source.enabled {
+			requests = append(requests, ratelimit.Request{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: source.sourceKey.String()}, Limit: source.rateLimit})
+		}
+	})
+
+	return d.limiter.Allow(now, requests, records, bytes)
+}
+
 func (d *Dispatcher) Dispatch(projectID keboola.ProjectID, sourceID key.SourceID, secret string, expectedType definition.SourceType, c recordctx.Context) (*sinkRouter.SourcesResult, error) {
 	d.wg.Add(1)
 	defer d.wg.Done()
@@ -139,21 +230,12 @@ func (d *Dispatcher) lookupSources(projectID keboola.ProjectID, sourceID key.Sou
 	// Get all relevant sources from all branches
 	disabled := 0
 	var matchedSources []key.SourceKey
-	d.sources.WalkPrefix(sourceKeyPrefix(projectID, sourceID), func(key string, source *sourceData) (stop bool) {
-		// Secret is now immutable and should be the same in all branches.
-		// If in the future we would allow secret to be regenerated in the main/dev branch, it will still work correctly.
-		// The source type must also match — the secret is namespaced by transport.
-		if source.sourceType != expectedType {
-			return false
-		}
-		if source.secret == secret {
-			if source.enabled {
-				matchedSources = append(matchedSources, source.sourceKey)
-			} else {
-				disabled++
-			}
+	d.walkMatchedSources(projectID, sourceID, secret, expectedType, func(source *sourceData) {
+		if source.enabled {
+			matchedSources = append(matchedSources, source.sourceKey)
+		} else {
+			disabled++
 		}
-		return false
 	})
 
 	// At least one source/branch must be found
@@ -168,6 +250,30 @@ func (d *Dispatcher) lookupSources(projectID keboola.ProjectID, sourceID key.Sou
 	return matchedSources, nil
 }
 
+// walkMatchedSources calls the fn for all sources matching projectID/sourceID/secret AND the expected source type.
+func (d *Dispatcher) walkMatchedSources(projectID keboola.ProjectID, sourceID key.SourceID, secret string, expectedType definition.SourceType, fn func(source *sourceData)) {
+	d.sources.WalkPrefix(sourceKeyPrefix(projectID, sourceID), func(key string, source *sourceData) (stop bool) {
+		// Secret is now immutable and should be the same in all branches.
+		// If in the future we would allow secret to be regenerated in the main/dev branch, it will still work correctly.
+		// The source type must also match — the secret is namespaced by transport.
+		if source.sourceType == expectedType && source.secret == secret {
+			fn(source)
+		}
+		return false
+	})
+}
+
+// sourceRateLimit returns the per-source rate limit, with modifications from the Source.Config.
+func (d *Dispatcher) sourceRateLimit(ctx context.Context, cfg ratelimit.Config, source definition.Source) ratelimit.Limit {
+	kvs := source.Config.In("source.rateLimit")
+	if len(kvs) > 0 {
+		if err := configpatch.ApplyKVs(&cfg, &ratelimit.ConfigPatch{}, kvs, configpatch.WithModifyProtected()); err != nil {
+			d.logger.Warnf(ctx, `invalid rate limit configuration of the source "%s", default values are used: %s`, source.SourceKey, err)
+		}
+	}
+	return cfg.SourceLimit()
+}
+
 func (d *Dispatcher) Close(ctx context.Context) error {
 	// Block new writes
 	close(d.closed)
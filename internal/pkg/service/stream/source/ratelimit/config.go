@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"github.com/c2h5oh/datasize"
+)
+
+// Config configures token-bucket rate limits of HTTP and OTLP sources.
+// Per-source limits can be modified by the Source.Config patch, the per-project limit only by the service configuration.
+// Each limit is split evenly among source nodes, so a request larger than the per-node burst is rejected, see the Limiter.
+type Config struct {
+	RecordsPerSecond uint64            `json:"recordsPerSecond" configKey:"recordsPerSecond" configUsage:"Max records per second accepted by a source, 0 means unlimited. The limit is split evenly among source nodes." validate:"max=10000000"`
+	RecordsBurst     uint64            `json:"recordsBurst" configKey:"recordsBurst" configUsage:"Max records accepted by a source in a burst, 0 means the same as recordsPerSecond. The burst is split evenly among source nodes, a larger request is rejected." validate:"max=100000000"`
+	BytesPerSecond   datasize.ByteSize `json:"bytesPerSecond" configKey:"bytesPerSecond" configUsage:"Max bytes per second accepted by a source, 0 means unlimited. The limit is split evenly among source nodes." validate:"maxBytes=10GB"`
+	BytesBurst       datasize.ByteSize `json:"bytesBurst" configKey:"bytesBurst" configUsage:"Max bytes accepted by a source in a burst, 0 means the same as bytesPerSecond. The burst is split evenly among source nodes, a larger request is rejected." validate:"maxBytes=100GB"`
+	Project          Limit             `json:"-" configKey:"project"`
+}
+
+// ConfigPatch is same as the Config, but with optional/nullable fields.
+// It may be part of a Source definition to allow modification of the default configuration.
+type ConfigPatch struct {
+	RecordsPerSecond *uint64            `json:"recordsPerSecond,omitempty"`
+	RecordsBurst     *uint64            `json:"recordsBurst,omitempty"`
+	BytesPerSecond   *datasize.ByteSize `json:"bytesPerSecond,omitempty"`
+	BytesBurst       *datasize.ByteSize `json:"bytesBurst,omitempty"`
+}
+
+// Limit configures one token bucket, for example all sources of a project.
+type Limit struct {
+	RecordsPerSecond uint64            `json:"recordsPerSecond" configKey:"recordsPerSecond" configUsage:"Max records per second accepted by all sources of a project, 0 means unlimited. The limit is split evenly among source nodes." validate:"max=10000000"`
+	RecordsBurst     uint64            `json:"recordsBurst" configKey:"recordsBurst" configUsage:"Max records accepted by all sources of a project in a burst, 0 means the same as recordsPerSecond. The burst is split evenly among source nodes, a larger request is rejected." validate:"max=100000000"`
+	BytesPerSecond   datasize.ByteSize `json:"bytesPerSecond" configKey:"bytesPerSecond" configUsage:"Max bytes per second accepted by all sources of a project, 0 means unlimited. The limit is split evenly among source nodes." validate:"maxBytes=10GB"`
+	BytesBurst       datasize.ByteSize `json:"bytesBurst" configKey:"bytesBurst" configUsage:"Max bytes accepted by all sources of a project in a burst, 0 means the same as bytesPerSecond. The burst is split evenly among source nodes, a larger request is rejected." validate:"maxBytes=100GB"`
+}
+
+// NewConfig returns the default configuration, without limits.
+func NewConfig() Config {
+	return Config{}
+}
+
+// SourceLimit returns the per-source limit.
+func (c Config) SourceLimit() Limit {
+	return Limit{
+		RecordsPerSecond: c.RecordsPerSecond,
+		RecordsBurst:     c.RecordsBurst,
+		BytesPerSecond:   c.BytesPerSecond,
+		BytesBurst:       c.BytesBurst,
+	}
+}
+
+// IsEnabled returns true, if at least one of the limits is set.
+func (l Limit) IsEnabled() bool {
+	return l.RecordsPerSecond > 0 || l.BytesPerSecond > 0
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// LimitExceededError is returned if a rate limit is exceeded, the client should retry after the RetryAfter duration.
+type LimitExceededError struct {
+	Scope      string
+	RetryAfter time.Duration
+}
+
+func (e LimitExceededError) Error() string {
+	return fmt.Sprintf("rate limit of the %s exceeded, retry after %s", e.Scope, e.RetryAfter)
+}
+
+func (LimitExceededError) ErrorName() string {
+	return "rateLimitExceeded"
+}
+
+func (LimitExceededError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// RetryAfterHeader returns value of the Retry-After HTTP header, in whole seconds, at least 1.
+func (e LimitExceededError) RetryAfterHeader() string {
+	return strconv.Itoa(max(1, int(math.Ceil(e.RetryAfter.Seconds()))))
+}
+
+// RequestTooLargeError is returned if the request is larger than the per-node burst of a rate limit.
+// The request can never be accepted, so the client should split it instead of a retry.
+type RequestTooLargeError struct {
+	Scope string
+	Unit  string
+	Burst int
+}
+
+func (e RequestTooLargeError) Error() string {
+	return fmt.Sprintf("request is larger than the rate limit burst of the %s, the limit is %d %s per source node, split the request", e.Scope, e.Burst, e.Unit)
+}
+
+func (RequestTooLargeError) ErrorName() string {
+	return "rateLimitRequestTooLarge"
+}
+
+func (RequestTooLargeError) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
@@ -0,0 +1,178 @@
+// Package ratelimit provides token-bucket rate limits of HTTP and OTLP sources, see the Limiter.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	ScopeSource  = "source"
+	ScopeProject = "project"
+)
+
+// cleanupInterval is the minimal interval between checks of idle buckets, see Limiter.removeIdleBuckets.
+const cleanupInterval = time.Minute
+
+// Limiter limits records and bytes accepted per second by sources and projects.
+//
+// Limits are shared by all source nodes: each node enforces the limit divided by the number of nodes,
+// see the nodesCount function. It expects requests to be distributed evenly among nodes by the load balancer.
+// The per-node burst is at least one second of the per-node rate, so the configured rate can always be reached.
+//
+// Idle buckets are removed, so buckets of deleted sources and projects don't accumulate, see removeIdleBuckets.
+type Limiter struct {
+	nodesCount  func() int
+	lock        sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+// Key identifies a token bucket.
+type Key struct {
+	Scope string
+	ID    string
+}
+
+// Request is a rate-limited operation, records and bytes are taken from all buckets or from none.
+type Request struct {
+	Key   Key
+	Limit Limit
+}
+
+type bucket struct {
+	limit      Limit
+	nodesCount int
+	records    *rate.Limiter
+	bytes      *rate.Limiter
+}
+
+func NewLimiter(nodesCount func() int) *Limiter {
+	return &Limiter{nodesCount: nodesCount, buckets: make(map[string]*bucket)}
+}
+
+// Allow takes records and bytes from all buckets of the requests.
+// If any of the limits is exceeded, nothing is taken and the LimitExceededError is returned.
+// If the records or bytes are larger than the per-node burst, the RequestTooLargeError is returned, a retry cannot succeed.
+func (l *Limiter) Allow(now time.Time, requests []Request, records, bytes int) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	nodesCount := max(1, l.nodesCount())
+	l.removeIdleBuckets(now)
+
+	var reservations []*rate.Reservation
+	cancel := func() {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+
+	for _, req := range requests {
+		if !req.Limit.IsEnabled() {
+			continue
+		}
+
+		b := l.bucket(now, req.Key, req.Limit, nodesCount)
+		for _, item := range []struct {
+			limiter *rate.Limiter
+			n       int
+			unit    string
+		}{{b.records, records, "records"}, {b.bytes, bytes, "bytes"}} {
+			if item.limiter == nil {
+				continue
+			}
+
+			r := item.limiter.ReserveN(now, item.n)
+			if !r.OK() {
+				// The request is larger than the burst, it can never be accepted
+				cancel()
+				return RequestTooLargeError{Scope: req.Key.Scope, Unit: item.unit, Burst: item.limiter.Burst()}
+			}
+
+			reservations = append(reservations, r)
+			if delay := r.DelayFrom(now); delay > 0 {
+				cancel()
+				return LimitExceededError{Scope: req.Key.Scope, RetryAfter: delay}
+			}
+		}
+	}
+
+	return nil
+}
+
+// BucketsCount returns the number of token buckets in memory.
+func (l *Limiter) BucketsCount() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return len(l.buckets)
+}
+
+// removeIdleBuckets removes full buckets, a full bucket is same as a new one, so no state is lost.
+// The buckets are checked at most once per cleanupInterval.
+func (l *Limiter) removeIdleBuckets(now time.Time) {
+	if now.Sub(l.lastCleanup) < cleanupInterval {
+		return
+	}
+
+	l.lastCleanup = now
+	for k, b := range l.buckets {
+		if b.isFull(now) {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// bucket returns the bucket for the key, the bucket is updated, if the limit or the number of nodes has changed.
+func (l *Limiter) bucket(now time.Time, k Key, limit Limit, nodesCount int) *bucket {
+	mapKey := k.Scope + "/" + k.ID
+	b, ok := l.buckets[mapKey]
+	if ok && b.limit == limit && b.nodesCount == nodesCount {
+		return b
+	}
+
+	var records, bytes *rate.Limiter
+	if ok {
+		records, bytes = b.records, b.bytes
+	}
+
+	b = &bucket{
+		limit:      limit,
+		nodesCount: nodesCount,
+		records:    newLimiter(now, records, limit.RecordsPerSecond, limit.RecordsBurst, nodesCount),
+		bytes:      newLimiter(now, bytes, limit.BytesPerSecond.Bytes(), limit.BytesBurst.Bytes(), nodesCount),
+	}
+	l.buckets[mapKey] = b
+	return b
+}
+
+// isFull returns true, if no tokens are taken from the bucket.
+func (b *bucket) isFull(now time.Time) bool {
+	for _, limiter := range []*rate.Limiter{b.records, b.bytes} {
+		if limiter != nil && limiter.TokensAt(now) < float64(limiter.Burst()) {
+			return false
+		}
+	}
+	return true
+}
+
+// newLimiter creates a new limiter or updates the existing one, so the tokens already taken are preserved.
+func newLimiter(now time.Time, existing *rate.Limiter, perSecond, burst uint64, nodesCount int) *rate.Limiter {
+	if perSecond == 0 {
+		return nil
+	}
+
+	nodeRate := float64(perSecond) / float64(nodesCount)
+	nodeBurst := int(max(burst, perSecond) / uint64(nodesCount))
+	nodeBurst = max(nodeBurst, int(nodeRate), 1)
+
+	if existing == nil {
+		return rate.NewLimiter(rate.Limit(nodeRate), nodeBurst)
+	}
+
+	existing.SetLimitAt(now, rate.Limit(nodeRate))
+	existing.SetBurstAt(now, nodeBurst)
+	return existing
+}
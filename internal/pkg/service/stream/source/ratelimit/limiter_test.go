@@ -0,0 +1,149 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/ratelimit"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestLimiter_Records(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := ratelimit.NewLimiter(func() int { return 1 })
+	requests := []ratelimit.Request{
+		{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: "my-source"}, Limit: ratelimit.Limit{RecordsPerSecond: 10}},
+	}
+
+	// Burst is one second of the rate
+	require.NoError(t, limiter.Allow(now, requests, 6, 0))
+	require.NoError(t, limiter.Allow(now, requests, 4, 0))
+
+	// Bucket is empty
+	err := limiter.Allow(now, requests, 1, 0)
+	var limitErr ratelimit.LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, ratelimit.ScopeSource, limitErr.Scope)
+	assert.Equal(t, 100*time.Millisecond, limitErr.RetryAfter)
+	assert.Equal(t, "1", limitErr.RetryAfterHeader())
+	assert.Equal(t, 429, limitErr.StatusCode())
+
+	// Tokens are refilled
+	require.NoError(t, limiter.Allow(now.Add(500*time.Millisecond), requests, 5, 0))
+}
+
+func TestLimiter_Bytes(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := ratelimit.NewLimiter(func() int { return 1 })
+	requests := []ratelimit.Request{
+		{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: "my-source"}, Limit: ratelimit.Limit{BytesPerSecond: 1 * datasize.KB, BytesBurst: 2 * datasize.KB}},
+	}
+
+	require.NoError(t, limiter.Allow(now, requests, 1000, 2048))
+
+	err := limiter.Allow(now, requests, 1, 1024)
+	var limitErr ratelimit.LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, time.Second, limitErr.RetryAfter)
+
+	// Request larger than the burst can never be accepted, a retry doesn't help
+	err = limiter.Allow(now.Add(time.Hour), requests, 1, 4096)
+	var tooLargeErr ratelimit.RequestTooLargeError
+	require.True(t, errors.As(err, &tooLargeErr))
+	assert.Equal(t, ratelimit.ScopeSource, tooLargeErr.Scope)
+	assert.Equal(t, 2048, tooLargeErr.Burst)
+	assert.Equal(t, 413, tooLargeErr.StatusCode())
+	assert.Equal(t, "request is larger than the rate limit burst of the source, the limit is 2048 bytes per source node, split the request", tooLargeErr.Error())
+	assert.False(t, errors.As(err, &limitErr))
+}
+
+func TestLimiter_AllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := ratelimit.NewLimiter(func() int { return 1 })
+	project := ratelimit.Request{Key: ratelimit.Key{Scope: ratelimit.ScopeProject, ID: "123"}, Limit: ratelimit.Limit{RecordsPerSecond: 10}}
+	source1 := ratelimit.Request{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: "source-1"}, Limit: ratelimit.Limit{RecordsPerSecond: 5}}
+	source2 := ratelimit.Request{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: "source-2"}, Limit: ratelimit.Limit{}}
+
+	// Source limit is exceeded, no tokens are taken from the project bucket
+	require.NoError(t, limiter.Allow(now, []ratelimit.Request{project, source1}, 3, 0))
+	err := limiter.Allow(now, []ratelimit.Request{project, source1}, 3, 0)
+	var limitErr ratelimit.LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, ratelimit.ScopeSource, limitErr.Scope)
+
+	// Source without limit, the project limit is shared
+	require.NoError(t, limiter.Allow(now, []ratelimit.Request{project, source2}, 7, 0))
+	err = limiter.Allow(now, []ratelimit.Request{project, source1}, 1, 0)
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, ratelimit.ScopeProject, limitErr.Scope)
+}
+
+func TestLimiter_NodesCount(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	nodesCount := 4
+	limiter := ratelimit.NewLimiter(func() int { return nodesCount })
+	requests := []ratelimit.Request{
+		{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: "my-source"}, Limit: ratelimit.Limit{RecordsPerSecond: 100}},
+	}
+
+	// Each node accepts a quarter of the limit
+	require.NoError(t, limiter.Allow(now, requests, 25, 0))
+	require.Error(t, limiter.Allow(now, requests, 1, 0))
+
+	// A request larger than the per-node burst is rejected, although it is within the configured burst
+	var tooLargeErr ratelimit.RequestTooLargeError
+	require.True(t, errors.As(limiter.Allow(now, requests, 50, 0), &tooLargeErr))
+	assert.Equal(t, "records", tooLargeErr.Unit)
+	assert.Equal(t, 25, tooLargeErr.Burst)
+
+	// A node has left, the limit per node is increased, already taken tokens are kept
+	nodesCount = 2
+	require.Error(t, limiter.Allow(now, requests, 1, 0))
+	require.NoError(t, limiter.Allow(now.Add(500*time.Millisecond), requests, 25, 0))
+	require.Error(t, limiter.Allow(now.Add(500*time.Millisecond), requests, 1, 0))
+}
+
+func TestLimiter_RemoveIdleBuckets(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := ratelimit.NewLimiter(func() int { return 1 })
+	source1 := []ratelimit.Request{
+		{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: "source-1"}, Limit: ratelimit.Limit{RecordsPerSecond: 10}},
+	}
+	source2 := []ratelimit.Request{
+		{Key: ratelimit.Key{Scope: ratelimit.ScopeSource, ID: "source-2"}, Limit: ratelimit.Limit{RecordsPerSecond: 10, RecordsBurst: 6000}},
+	}
+
+	require.NoError(t, limiter.Allow(now, source1, 10, 0))
+	require.NoError(t, limiter.Allow(now, source2, 6000, 0))
+	assert.Equal(t, 2, limiter.BucketsCount())
+
+	// The bucket of the source 1 is full again, it is removed, the bucket of the source 2 is still refilling
+	now = now.Add(time.Minute)
+	require.NoError(t, limiter.Allow(now, nil, 0, 0))
+	assert.Equal(t, 1, limiter.BucketsCount())
+
+	// Tokens taken from the bucket of the source 2 are preserved
+	err := limiter.Allow(now, source2, 6000, 0)
+	var limitErr ratelimit.LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, 9*time.Minute, limitErr.RetryAfter)
+
+	// The bucket of the source 2 is removed, when it is full
+	now = now.Add(9 * time.Minute)
+	require.NoError(t, limiter.Allow(now, nil, 0, 0))
+	assert.Equal(t, 0, limiter.BucketsCount())
+}
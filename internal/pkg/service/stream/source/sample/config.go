@@ -0,0 +1,23 @@
+package sample
+
+import (
+	"time"
+
+	"github.com/c2h5oh/datasize"
+)
+
+// Config of request samples, the sampling is disabled by default.
+// An enabled sampling takes a lock for each request of a source, to check the Interval.
+type Config struct {
+	Size        int               `configKey:"size" configUsage:"Maximum number of request samples stored per source, the oldest samples are deleted. Zero disables the sampling." validate:"min=0,max=100"`
+	Interval    time.Duration     `configKey:"interval" configUsage:"Minimal interval between two samples of a source captured by a node." validate:"required,minDuration=100ms,maxDuration=1h"`
+	MaxBodySize datasize.ByteSize `configKey:"maxBodySize" configUsage:"Maximum size of a stored sample body, a longer body is truncated and the sample is ignored." validate:"required,minBytes=100B,maxBytes=1MB"`
+}
+
+func NewConfig() Config {
+	return Config{
+		Size:        0,
+		Interval:    10 * time.Second,
+		MaxBodySize: 64 * datasize.KB,
+	}
+}
@@ -0,0 +1,120 @@
+// Package repository provides database operations with the request samples of sources, see the sample package.
+package repository
+
+import (
+	"context"
+	"time"
+
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository/source"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample/repository/schema"
+)
+
+type Repository struct {
+	client  *etcd.Client
+	schema  schema.Sample
+	sources *source.Repository
+	plugins *plugin.Plugins
+}
+
+type dependencies interface {
+	EtcdClient() *etcd.Client
+	EtcdSerde() *serde.Serde
+	DefinitionRepository() *definitionRepo.Repository
+	Plugins() *plugin.Plugins
+}
+
+func New(d dependencies) *Repository {
+	r := &Repository{
+		client:  d.EtcdClient(),
+		schema:  schema.New(d.EtcdSerde()),
+		sources: d.DefinitionRepository().Source(),
+		plugins: d.Plugins(),
+	}
+
+	r.purgeOnSourceDelete()
+	return r
+}
+
+// Add saves the sample, the oldest samples of the source are deleted to keep at most maxSamples samples.
+func (r *Repository) Add(s sample.Sample, maxSamples int) *op.AtomicOp[sample.Sample] {
+	var existing []string
+	var added sample.Sample
+	return op.Atomic(r.client, &added).
+		// Source must exist
+		Read(func(ctx context.Context) op.Op {
+			return r.sources.ExistsOrErr(s.SourceKey)
+		}).
+		// Load keys of stored samples, from the oldest
+		Read(func(ctx context.Context) op.Op {
+			return r.sampleKeys(s.SourceKey).WithResultTo(&existing)
+		}).
+		Write(func(ctx context.Context) op.Op {
+			txn := op.Txn(r.client)
+
+			// Delete the oldest samples over the limit
+			if overLimit := len(existing) + 1 - maxSamples; overLimit > 0 {
+				for _, k := range existing[:overLimit] {
+					txn.Then(op.NewNoResultOp(
+						r.client,
+						func(_ context.Context) (etcd.Op, error) {
+							return etcd.OpDelete(k), nil
+						},
+						func(_ context.Context, _ *op.RawResponse) error {
+							return nil
+						},
+					))
+				}
+			}
+
+			// Save the sample
+			added = s
+			return txn.Then(r.schema.ByKey(s.SampleKey).Put(r.client, added))
+		})
+}
+
+// List samples by the parent key (SourceKey, BranchKey, ProjectID), from the oldest.
+func (r *Repository) List(parentKey any, opts ...iterator.Option) iterator.DefinitionT[sample.Sample] {
+	return r.schema.In(parentKey).GetAll(r.client, opts...)
+}
+
+// Purge removes all samples of the source.
+func (r *Repository) Purge(k key.SourceKey) op.CountOp {
+	return r.schema.InSource(k).DeleteAll(r.client)
+}
+
+func (r *Repository) purgeOnSourceDelete() {
+	r.plugins.Collection().OnSourceDelete(func(ctx context.Context, now time.Time, by definition.By, original, deleted *definition.Source) error {
+		op.AtomicOpCtxFrom(ctx).Write(func(ctx context.Context) op.Op {
+			return r.Purge(deleted.SourceKey)
+		})
+		return nil
+	})
+}
+
+// sampleKeys loads only keys of the source samples, bodies are not needed, from the oldest.
+func (r *Repository) sampleKeys(k key.SourceKey) op.WithResult[[]string] {
+	pfx := r.schema.InSource(k).Prefix()
+	return op.NewForType(
+		r.client,
+		func(_ context.Context) (etcd.Op, error) {
+			return etcd.OpGet(pfx, etcd.WithPrefix(), etcd.WithKeysOnly(), etcd.WithSort(etcd.SortByKey, etcd.SortAscend)), nil
+		},
+		func(_ context.Context, raw *op.RawResponse) ([]string, error) {
+			keys := make([]string, 0, len(raw.Get().Kvs))
+			for _, kv := range raw.Get().Kvs {
+				keys = append(keys, string(kv.Key))
+			}
+			return keys, nil
+		},
+	)
+}
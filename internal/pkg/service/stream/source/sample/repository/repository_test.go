@@ -0,0 +1,80 @@
+package repository_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	serviceErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+)
+
+func TestRepository(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	now := utctime.MustParse("2000-01-01T01:00:00.000Z").Time()
+	by := test.ByUser()
+
+	d, _ := dependencies.NewMockedServiceScope(t, ctx)
+	repo := d.SampleRepository()
+
+	// Fixtures
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	newSample := func(id sample.SampleID) sample.Sample {
+		return sample.Sample{
+			SampleKey:   sample.SampleKey{SourceKey: sourceKey, SampleID: id},
+			ReceivedAt:  utctime.From(now),
+			ContentType: "application/json",
+			Body:        []byte(`{"foo":"bar"}`),
+		}
+	}
+
+	// Add - parent not found
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		if err := repo.Add(newSample("001"), 2).Do(ctx).Err(); assert.Error(t, err) {
+			assert.Equal(t, `branch "456" not found in the project`, err.Error())
+			serviceErrors.AssertErrorStatusCode(t, http.StatusNotFound, err)
+		}
+	}
+
+	// Create source
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		branch := test.NewBranch(branchKey)
+		require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, by).Do(ctx).Err())
+		source := test.NewSource(sourceKey)
+		require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, by, "Create source").Do(ctx).Err())
+	}
+
+	// Add - the oldest samples over the limit are deleted
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		require.NoError(t, repo.Add(newSample("001"), 2).Do(ctx).Err())
+		require.NoError(t, repo.Add(newSample("002"), 2).Do(ctx).Err())
+		require.NoError(t, repo.Add(newSample("003"), 2).Do(ctx).Err())
+		samples, err := repo.List(sourceKey).Do(ctx).All()
+		require.NoError(t, err)
+		if assert.Len(t, samples, 2) {
+			assert.Equal(t, sample.SampleID("002"), samples[0].SampleID)
+			assert.Equal(t, sample.SampleID("003"), samples[1].SampleID)
+		}
+	}
+
+	// Purge on source delete
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		require.NoError(t, d.DefinitionRepository().Source().SoftDelete(sourceKey, now, by).Do(ctx).Err())
+		samples, err := repo.List(sourceKey).Do(ctx).All()
+		require.NoError(t, err)
+		assert.Empty(t, samples)
+	}
+}
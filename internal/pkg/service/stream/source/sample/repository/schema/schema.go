@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Sample is an etcd prefix that stores bodies of the last requests of a source.
+type Sample struct {
+	etcdop.PrefixT[sample.Sample]
+}
+
+func New(s *serde.Serde) Sample {
+	return Sample{PrefixT: etcdop.NewTypedPrefix[sample.Sample]("source/sample", s)}
+}
+
+func (s Sample) In(objectKey any) etcdop.PrefixT[sample.Sample] {
+	switch k := objectKey.(type) {
+	case keboola.ProjectID:
+		return s.Add(k.String())
+	case key.BranchKey:
+		return s.Add(k.String())
+	case key.SourceKey:
+		return s.Add(k.String())
+	default:
+		panic(errors.Errorf(`unexpected sample parent key type "%T"`, objectKey))
+	}
+}
+
+func (s Sample) InSource(k key.SourceKey) etcdop.PrefixT[sample.Sample] {
+	return s.Add(k.String())
+}
+
+func (s Sample) ByKey(k sample.SampleKey) etcdop.KeyT[sample.Sample] {
+	return s.Key(k.String())
+}
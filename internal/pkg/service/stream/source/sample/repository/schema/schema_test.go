@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/serde"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/sample"
+)
+
+func TestSampleSchema(t *testing.T) {
+	t.Parallel()
+	s := New(serde.NewJSON(serde.NoValidation))
+
+	sampleKey := sample.SampleKey{
+		SourceKey: key.SourceKey{
+			BranchKey: key.BranchKey{ProjectID: 123, BranchID: 456},
+			SourceID:  "my-source",
+		},
+		SampleID: "01HZ3FQ4Y6V1N9J8T7K5M2C0XR",
+	}
+
+	cases := []struct{ actual, expected string }{
+		{
+			s.Prefix(),
+			"source/sample/",
+		},
+		{
+			s.In(sampleKey.ProjectID).Prefix(),
+			"source/sample/123/",
+		},
+		{
+			s.In(sampleKey.BranchKey).Prefix(),
+			"source/sample/123/456/",
+		},
+		{
+			s.In(sampleKey.SourceKey).Prefix(),
+			"source/sample/123/456/my-source/",
+		},
+		{
+			s.InSource(sampleKey.SourceKey).Prefix(),
+			"source/sample/123/456/my-source/",
+		},
+		{
+			s.ByKey(sampleKey).Key(),
+			"source/sample/123/456/my-source/01HZ3FQ4Y6V1N9J8T7K5M2C0XR",
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, c.actual)
+	}
+}
@@ -0,0 +1,95 @@
+// Package sample stores bodies of the last requests received by a source.
+// Samples are used to propose a sink mapping, see the infer package.
+//
+// Samples are captured by the sink router, at most one sample per source and node in the Config.Interval,
+// so the database is not overloaded by a source with a high traffic.
+// The count of stored samples per source is limited, the oldest samples are deleted, see Config.
+package sample
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// SampleID is a time-sortable ULID, so samples of a source are listed from the oldest.
+type SampleID string
+
+type SampleKey struct {
+	key.SourceKey
+	SampleID SampleID `json:"sampleId" validate:"required,min=1,max=48"`
+}
+
+// Sample is a body of a request received by the source.
+type Sample struct {
+	SampleKey
+	ReceivedAt  utctime.UTCTime `json:"receivedAt" validate:"required"`
+	ContentType string          `json:"contentType,omitempty"`
+	Body        []byte          `json:"body,omitempty"`
+	// BodyTruncated is true, if the body exceeded the Config.MaxBodySize, such sample cannot be parsed.
+	BodyTruncated bool `json:"bodyTruncated,omitempty"`
+}
+
+func (v SampleID) String() string {
+	if v == "" {
+		panic(errors.New("SampleID cannot be empty"))
+	}
+	return string(v)
+}
+
+func (v SampleKey) String() string {
+	return v.SourceKey.String() + "/" + v.SampleID.String()
+}
+
+func (v SampleKey) Telemetry() []attribute.KeyValue {
+	t := v.SourceKey.Telemetry()
+	t = append(t, attribute.String("sample.id", v.SampleID.String()))
+	return t
+}
+
+// New copies the body of the record, the body is truncated to the maxBodySize.
+func New(k SampleKey, c recordctx.Context, maxBodySize datasize.ByteSize) Sample {
+	s := Sample{
+		SampleKey:  k,
+		ReceivedAt: utctime.From(c.Timestamp()),
+	}
+
+	if headers := c.HeadersMap(); headers != nil {
+		if v, ok := headers.GetOrNil("Content-Type").(string); ok {
+			s.ContentType = v
+		}
+	}
+
+	// The body buffer may be reused, so it is copied
+	if body, err := c.BodyBytes(); err == nil {
+		if uint64(len(body)) > maxBodySize.Bytes() {
+			body = body[:maxBodySize.Bytes()]
+			s.BodyTruncated = true
+		}
+		s.Body = bytes.Clone(body)
+	}
+
+	return s
+}
+
+// BodyMap parses the stored body, the same way as the body of the original record.
+func (s Sample) BodyMap(ctx context.Context) (*orderedmap.OrderedMap, error) {
+	if s.BodyTruncated {
+		return nil, errors.Errorf(`body of the sample "%s" is truncated`, s.SampleID)
+	}
+
+	headers := orderedmap.New()
+	if s.ContentType != "" {
+		headers.Set("Content-Type", s.ContentType)
+	}
+
+	return recordctx.FromStored(ctx, s.ReceivedAt.Time(), nil, headers, s.Body).BodyMap()
+}
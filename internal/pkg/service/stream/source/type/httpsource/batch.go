@@ -0,0 +1,262 @@
+package httpsource
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fastjson"
+
+	svcErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/dispatcher"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// NDJSONContentType - the batch contains one JSON record per line, empty lines are skipped.
+	NDJSONContentType = "application/x-ndjson"
+	// JSONArrayContentType - the batch is a JSON array, each item is one record.
+	JSONArrayContentType = "application/vnd.keboola.batch+json"
+)
+
+type batchFormat int
+
+const (
+	batchFormatNDJSON batchFormat = iota
+	batchFormatJSONArray
+)
+
+// BatchResult is the response of a batch request, it reports the result of each record.
+type BatchResult struct {
+	StatusCode int                  `json:"statusCode"`
+	ErrorName  string               `json:"error,omitempty"`
+	Message    string               `json:"message"`
+	Total      int                  `json:"total"`
+	Accepted   []int                `json:"accepted"`
+	Failed     []*BatchRecordResult `json:"failed,omitempty"`
+}
+
+// BatchRecordResult describes a failed record of the batch.
+// Index is the line index for NDJSON, and the item index for JSON array, both from 0.
+type BatchRecordResult struct {
+	Index      int                        `json:"index"`
+	StatusCode int                        `json:"statusCode"`
+	ErrorName  string                     `json:"error,omitempty"`
+	Message    string                     `json:"message"`
+	Sources    []*sinkRouter.SourceResult `json:"sources,omitempty"`
+}
+
+type batchItem struct {
+	index int
+	body  []byte
+}
+
+// batchFormatFromContentType returns false, if the request is not a batch request.
+func batchFormatFromContentType(contentType []byte) (batchFormat, bool) {
+	mediaType, _, _ := bytes.Cut(contentType, []byte(";"))
+	switch strings.ToLower(string(bytes.TrimSpace(mediaType))) {
+	case NDJSONContentType:
+		return batchFormatNDJSON, true
+	case JSONArrayContentType:
+		return batchFormatJSONArray, true
+	default:
+		return 0, false
+	}
+}
+
+// splitBatch splits the request body to records.
+// The whole request body is limited by the MaxRequestBodySize, so the limit is applied to the whole batch.
+func splitBatch(format batchFormat, body []byte) ([]batchItem, error) {
+	var items []batchItem
+	switch format {
+	case batchFormatNDJSON:
+		index := 0
+		for line := range bytes.Lines(body) {
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				items = append(items, batchItem{index: index, body: line})
+			}
+			index++
+		}
+	case batchFormatJSONArray:
+		var p fastjson.Parser
+		value, err := p.ParseBytes(body)
+		if err != nil {
+			return nil, svcErrors.NewBadRequestError(errors.PrefixError(err, "invalid JSON array"))
+		}
+		array, err := value.Array()
+		if err != nil {
+			return nil, svcErrors.NewBadRequestError(errors.New("invalid JSON array: the body must be a JSON array"))
+		}
+		for i, item := range array {
+			items = append(items, batchItem{index: i, body: item.MarshalTo(nil)})
+		}
+	default:
+		panic(errors.Errorf(`unexpected batch format "%v"`, format))
+	}
+
+	if len(items) == 0 {
+		return nil, svcErrors.NewBadRequestError(errors.New("the batch doesn't contain any record"))
+	}
+
+	return items, nil
+}
+
+// dispatchBatch dispatches records of the batch in parallel, and aggregates the results.
+// Writes of the records wait for the sync of the sink storage together, so the whole batch is synced at once, see writesync package.
+// The parallelism is limited by the Config.BatchParallelism.
+// The order of the records in the sink storage is not guaranteed, as for parallel requests, indexes in the result are sorted.
+//
+// The response status code is:
+//   - the highest success status code, if all records have been written,
+//   - 207 Multi-Status, if some records have been written and some have failed,
+//   - the highest error status code, if all records have failed.
+func dispatchBatch(
+	ctx context.Context,
+	dp *dispatcher.Dispatcher,
+	now time.Time,
+	reqCtx *fasthttp.RequestCtx,
+	format batchFormat,
+	parallelism int,
+	projectID keboola.ProjectID,
+	sourceID key.SourceID,
+	secret string,
+) (*BatchResult, error) {
+	// Authenticate the request before the body is parsed
+	if err := dp.ValidateSource(projectID, sourceID, secret, definition.SourceTypeHTTP); err != nil {
+		return nil, err
+	}
+
+	items, err := splitBatch(format, reqCtx.Request.Body())
+	if err != nil {
+		return nil, err
+	}
+
+	// The whole batch is accepted or rejected by rate limits
+	if err := dp.CheckRateLimit(now, projectID, sourceID, secret, definition.SourceTypeHTTP, len(items), len(reqCtx.Request.Body())); err != nil {
+		return nil, err
+	}
+
+	// All records share the request headers and the client IP
+	reqRecordCtx := recordctx.FromFastHTTP(ctx, now, reqCtx)
+	headers := reqRecordCtx.HeadersMap()
+	clientIP := reqRecordCtx.ClientIP()
+
+	// Dispatch records in parallel, the results are stored by the item position
+	failedItems := make([]*BatchRecordResult, len(items))
+	okStatusCodes := make([]int, len(items))
+	sem := make(chan struct{}, parallelism)
+	wg := &sync.WaitGroup{}
+	for i, item := range items {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			okStatusCodes[i], failedItems[i] = dispatchBatchItem(ctx, dp, now, clientIP, headers, item, projectID, sourceID, secret)
+		})
+	}
+	wg.Wait()
+
+	// Aggregate results
+	result := &BatchResult{Total: len(items), Accepted: make([]int, 0, len(items))}
+	okStatusCode := http.StatusOK
+	failedStatusCode := 0
+	for i, item := range items {
+		if failed := failedItems[i]; failed != nil {
+			result.Failed = append(result.Failed, failed)
+			failedStatusCode = max(failedStatusCode, failed.StatusCode)
+		} else {
+			result.Accepted = append(result.Accepted, item.index)
+			okStatusCode = max(okStatusCode, okStatusCodes[i])
+		}
+	}
+
+	switch {
+	case len(result.Failed) == 0:
+		result.StatusCode = okStatusCode
+		result.Message = "Successfully written " + strconv.Itoa(result.Total) + "/" + strconv.Itoa(result.Total) + " records."
+	case len(result.Accepted) == 0:
+		result.StatusCode = failedStatusCode
+		result.ErrorName = ErrorNamePrefix + "writeFailed"
+		result.Message = "Written 0/" + strconv.Itoa(result.Total) + " records."
+	default:
+		result.StatusCode = http.StatusMultiStatus
+		result.ErrorName = ErrorNamePrefix + "writeFailed"
+		result.Message = "Written " + strconv.Itoa(len(result.Accepted)) + "/" + strconv.Itoa(result.Total) + " records."
+	}
+
+	return result, nil
+}
+
+// dispatchBatchItem dispatches one record of the batch.
+// The success status code is returned, if the record has been written, otherwise the failed record result is returned.
+func dispatchBatchItem(
+	ctx context.Context,
+	dp *dispatcher.Dispatcher,
+	now time.Time,
+	clientIP net.IP,
+	headers *orderedmap.OrderedMap,
+	item batchItem,
+	projectID keboola.ProjectID,
+	sourceID key.SourceID,
+	secret string,
+) (int, *BatchRecordResult) {
+	if err := fastjson.ValidateBytes(item.body); err != nil {
+		return 0, &BatchRecordResult{
+			Index:      item.index,
+			StatusCode: http.StatusBadRequest,
+			ErrorName:  ErrorNamePrefix + "badRequest",
+			Message:    "Invalid JSON: " + err.Error() + ".",
+		}
+	}
+
+	sourcesResult, err := dp.Dispatch(projectID, sourceID, secret, definition.SourceTypeHTTP, recordctx.FromBatchItem(ctx, now, clientIP, headers, item.index, item.body))
+	if err != nil {
+		// For example, the node is shutting down
+		return 0, &BatchRecordResult{
+			Index:      item.index,
+			StatusCode: errorStatusCode(err),
+			ErrorName:  errorName(err),
+			Message:    errors.Format(err, errors.FormatAsSentences()),
+		}
+	}
+
+	if sourcesResult.FailedSinks > 0 {
+		sourcesResult.Finalize()
+		return 0, &BatchRecordResult{
+			Index:      item.index,
+			StatusCode: sourcesResult.StatusCode,
+			ErrorName:  sourcesResult.ErrorName,
+			Message:    sourcesResult.Message,
+			Sources:    sourcesResult.Sources,
+		}
+	}
+
+	return sourcesResult.StatusCode, nil
+}
+
+func errorStatusCode(err error) int {
+	var withStatus svcErrors.WithStatusCode
+	if errors.As(err, &withStatus) {
+		return withStatus.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
+
+func errorName(err error) string {
+	var withName svcErrors.WithName
+	if errors.As(err, &withName) {
+		return ErrorNamePrefix + withName.ErrorName()
+	}
+	return ErrorNamePrefix + "genericError"
+}
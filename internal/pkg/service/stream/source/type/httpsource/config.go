@@ -16,6 +16,7 @@ type Config struct {
 	ReadBufferSize     datasize.ByteSize `configKey:"readBufferSize" configUsage:"Read buffer size, all HTTP headers must fit in" validate:"required,minBytes=1kB,maxBytes=1MB"`
 	WriteBufferSize    datasize.ByteSize `configKey:"writeBufferSize" configUsage:"Write buffer size." validate:"required,minBytes=1kB,maxBytes=1MB"`
 	MaxRequestBodySize datasize.ByteSize `configKey:"maxRequestBodySize" configUsage:"Max size of the HTTP request body." validate:"required,minBytes=100B,maxBytes=4MB"`
+	BatchParallelism   int               `configKey:"batchParallelism" configUsage:"Maximum number of records of a batch request dispatched in parallel, parallel writes wait for the sync together." validate:"required,min=1,max=10000"`
 }
 
 func NewConfig() Config {
@@ -28,5 +29,6 @@ func NewConfig() Config {
 		ReadBufferSize:     16 * datasize.KB,
 		WriteBufferSize:    4 * datasize.KB,
 		MaxRequestBodySize: 1 * datasize.MB,
+		BatchParallelism:   1000,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	svcErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/httpserver"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/ratelimit"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
@@ -31,6 +32,7 @@ func newErrorHandler(cfg Config, logger log.Logger) func(c *fasthttp.RequestCtx,
 		c.Response.Header.Set("Server", ServerHeader)
 
 		var smallBufferErr *fasthttp.ErrSmallBuffer
+		var rateLimitErr ratelimit.LimitExceededError
 
 		switch {
 		// Headers too large - small reader buffer
@@ -43,6 +45,9 @@ func newErrorHandler(cfg Config, logger log.Logger) func(c *fasthttp.RequestCtx,
 			err = svcErrors.NewBodyTooLargeError(
 				errors.Wrapf(err, `request body size is over the maximum %q`, cfg.MaxRequestBodySize.String()),
 			)
+		// Rate limit exceeded, the client should retry later
+		case errors.As(err, &rateLimitErr):
+			c.Response.Header.Set("Retry-After", rateLimitErr.RetryAfterHeader())
 		// EOF error, for example: client closed connection
 		case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
 			err = svcErrors.NewIOError(
@@ -18,6 +18,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
 	svcErrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
@@ -26,6 +27,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
 	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/dispatcher"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/ratelimit"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/otlpsource"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
@@ -49,13 +51,14 @@ type dependencies interface {
 	Clock() clockwork.Clock
 	Logger() log.Logger
 	Process() *servicectx.Process
+	DistributionNode() *distribution.Node
 	DefinitionRepository() *definitionRepo.Repository
 	SinkRouter() *sinkRouter.Router
 	Telemetry() telemetry.Telemetry
 	WatchTelemetryInterval() time.Duration
 }
 
-func Start(ctx context.Context, d dependencies, cfg Config) error {
+func Start(ctx context.Context, d dependencies, cfg Config, rateLimitCfg ratelimit.Config) error {
 	logger := d.Logger().WithComponent("http-source")
 	logger.Info(ctx, "starting HTTP source node")
 	errorHandler := newErrorHandler(cfg, logger)
@@ -76,7 +79,7 @@ func Start(ctx context.Context, d dependencies, cfg Config) error {
 	})
 
 	// Create dispatcher
-	dp, err := dispatcher.New(d, logger)
+	dp, err := dispatcher.New(d, logger, rateLimitCfg)
 	if err != nil {
 		return err
 	}
@@ -102,12 +105,67 @@ func Start(ctx context.Context, d dependencies, cfg Config) error {
 		sourceID := key.SourceID(c.Param("sourceID"))
 		secret := c.Param("secret")
 
-		// Create record context
+		// Verify the webhook signature, if it is configured, before the request is dispatched
+		header := func(name string) string {
+			return string(c.Request.Header.Peek(name))
+		}
+		releaseSignature, err := dp.VerifySignature(keboola.ProjectID(projectIDInt), sourceID, secret, definition.SourceTypeHTTP, header, c.PostBody())
+		if err != nil {
+			errorHandler(c.RequestCtx, err)
+			return nil //nolint:nilerr
+		}
+
 		ctx := telemetry.ContextWithDisabledTracing(ctx) // disable spans in the hot path
-		recordCtx := recordctx.FromFastHTTP(ctx, d.Clock().Now(), c.RequestCtx)
+
+		// Batch request, the body contains multiple records
+		if format, ok := batchFormatFromContentType(c.Request.Header.ContentType()); ok {
+			result, err := dispatchBatch(ctx, dp, d.Clock().Now(), c.RequestCtx, format, cfg.BatchParallelism, keboola.ProjectID(projectIDInt), sourceID, secret)
+
+			// The signature is not kept, if a record has not been written, so the retry of the request is not rejected as a replay
+			if err != nil || len(result.Failed) > 0 {
+				releaseSignature()
+			}
+
+			if err != nil {
+				errorLoggingCtx := ctxattr.ContextWith(ctx,
+					attribute.String("project.id", strconv.Itoa(projectIDInt)),
+					attribute.String("source.id", string(sourceID)),
+				)
+				logger.Warn(errorLoggingCtx, errors.Wrapf(err, "batch dispatch failed").Error())
+				errorHandler(c.RequestCtx, err)
+				return nil //nolint:nilerr
+			}
+
+			// The batch response is always verbose, it contains indexes of accepted and failed records
+			c.Response.Header.SetCanonical(contentTypeHeader, applicationJSONContentType)
+			c.Response.SetStatusCode(result.StatusCode)
+			enc := json.NewEncoder(c)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(result); err != nil {
+				errorHandler(c.RequestCtx, err)
+			}
+			return nil
+		}
+
+		// Check rate limits before the request is dispatched
+		now := d.Clock().Now()
+		if err := dp.CheckRateLimit(now, keboola.ProjectID(projectIDInt), sourceID, secret, definition.SourceTypeHTTP, 1, len(c.PostBody())); err != nil {
+			releaseSignature()
+			errorHandler(c.RequestCtx, err)
+			return nil //nolint:nilerr
+		}
+
+		// Create record context
+		recordCtx := recordctx.FromFastHTTP(ctx, now, c.RequestCtx)
 
 		// Dispatch request to all sinks
 		result, err := dp.Dispatch(keboola.ProjectID(projectIDInt), sourceID, secret, definition.SourceTypeHTTP, recordCtx)
+
+		// The signature is not kept, if the record has not been written, so the retry of the request is not rejected as a replay
+		if err != nil || result.FailedSinks > 0 {
+			releaseSignature()
+		}
+
 		if err != nil {
 			// Create an enriched context.Context for logging this specific error event.
 			errorLoggingCtx := ctxattr.ContextWith(ctx,
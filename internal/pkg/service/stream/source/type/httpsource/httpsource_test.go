@@ -2,11 +2,15 @@ package httpsource_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,6 +22,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
 	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
@@ -66,10 +71,12 @@ type testState struct {
 	source1A        definition.Source
 	source1B        definition.Source
 	source2Disabled definition.Source
+	source3Signed   definition.Source
 	sink1A1         definition.Sink
 	sink1B1         definition.Sink
 	sink1A2Disabled definition.Sink
 	sink1B2Disabled definition.Sink
+	sink3A1         definition.Sink
 }
 
 //nolint:tparallel // we want to run the subtests - requests sequentially and check the logs
@@ -109,20 +116,32 @@ func TestHTTPSource(t *testing.T) {
 	ts.source1B.HTTP.Secret = ts.validSecret
 	ts.source2Disabled = test.NewHTTPSource(key.SourceKey{BranchKey: ts.branchAKey, SourceID: "my-source-2"})
 	ts.source2Disabled.HTTP.Secret = ts.validSecret
+	ts.source3Signed = test.NewHTTPSource(key.SourceKey{BranchKey: ts.branchAKey, SourceID: "my-source-3"})
+	ts.source3Signed.HTTP.Secret = ts.validSecret
+	ts.source3Signed.HTTP.Signature = &definition.HTTPSourceSignature{
+		Algorithm: definition.SignatureAlgorithmHMACSHA256,
+		Format:    definition.SignatureFormatHex,
+		Header:       "X-Hub-Signature-256",
+		Secret:       "my-webhook-secret",
+		ReplayWindow: duration.From(time.Hour),
+	}
 	ts.sink1A1 = dummy.NewSink(key.SinkKey{SourceKey: ts.source1A.SourceKey, SinkID: "my-sink-1"})
 	ts.sink1B1 = dummy.NewSink(key.SinkKey{SourceKey: ts.source1B.SourceKey, SinkID: "my-sink-1"})
 	ts.sink1A2Disabled = dummy.NewSink(key.SinkKey{SourceKey: ts.source1A.SourceKey, SinkID: "my-sink-2"})
 	ts.sink1B2Disabled = dummy.NewSink(key.SinkKey{SourceKey: ts.source1B.SourceKey, SinkID: "my-sink-2"})
+	ts.sink3A1 = dummy.NewSink(key.SinkKey{SourceKey: ts.source3Signed.SourceKey, SinkID: "my-sink-1"})
 	require.NoError(t, ts.d.DefinitionRepository().Branch().Create(&ts.branchA, ts.clk.Now(), test.ByUser()).Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Branch().Create(&ts.branchB, ts.clk.Now(), test.ByUser()).Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Source().Create(&ts.source1A, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Source().Create(&ts.source1B, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Source().Create(&ts.source2Disabled, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
+	require.NoError(t, ts.d.DefinitionRepository().Source().Create(&ts.source3Signed, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Source().Disable(ts.source2Disabled.SourceKey, ts.clk.Now(), test.ByUser(), "reason").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Sink().Create(&ts.sink1A1, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Sink().Create(&ts.sink1B1, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Sink().Create(&ts.sink1A2Disabled, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Sink().Create(&ts.sink1B2Disabled, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
+	require.NoError(t, ts.d.DefinitionRepository().Sink().Create(&ts.sink3A1, ts.clk.Now(), test.ByUser(), "create").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Sink().Disable(ts.sink1A2Disabled.SinkKey, ts.clk.Now(), test.ByUser(), "reason").Do(ts.ctx).Err())
 	require.NoError(t, ts.d.DefinitionRepository().Sink().Disable(ts.sink1B2Disabled.SinkKey, ts.clk.Now(), test.ByUser(), "reason").Do(ts.ctx).Err())
 
@@ -597,11 +616,303 @@ func testCases(t *testing.T, ts *testState) []TestCase {
       "message": "No enabled sink found."
     }
   ]
+}`,
+		},
+		{
+			Name:               "stream input - POST - signature - missing",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-3/" + ts.validSecret,
+			Body:               strings.NewReader("foo"),
+			ExpectedStatusCode: http.StatusUnauthorized,
+			ExpectedHeaders:    map[string]string{"Server": httpsource.ServerHeader},
+			ExpectedBody: `
+{
+  "statusCode": 401,
+  "error": "stream.in.invalidSignature",
+  "message": "Invalid webhook signature: header \"X-Hub-Signature-256\" is missing."
+}`,
+		},
+		{
+			Name:               "stream input - POST - signature - invalid",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-3/" + ts.validSecret,
+			Headers:            map[string]string{"X-Hub-Signature-256": "sha256=" + hmacSHA256("my-webhook-secret", "bar")},
+			Body:               strings.NewReader("foo"),
+			ExpectedStatusCode: http.StatusUnauthorized,
+			ExpectedHeaders:    map[string]string{"Server": httpsource.ServerHeader},
+			ExpectedBody: `
+{
+  "statusCode": 401,
+  "error": "stream.in.invalidSignature",
+  "message": "Invalid webhook signature: signature doesn't match, expected \"hmac-sha256\" signature of the request body."
+}`,
+		},
+		{
+			Name:               "stream input - POST - signature - ok",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-3/" + ts.validSecret,
+			Headers:            map[string]string{"X-Hub-Signature-256": "sha256=" + hmacSHA256("my-webhook-secret", "foo")},
+			Body:               strings.NewReader("foo"),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "text/plain",
+				"Server":       httpsource.ServerHeader,
+			},
+			ExpectedBody: "OK",
+		},
+		{
+			Name:               "stream input - POST - signature - replay",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-3/" + ts.validSecret,
+			Headers:            map[string]string{"X-Hub-Signature-256": "sha256=" + hmacSHA256("my-webhook-secret", "foo")},
+			Body:               strings.NewReader("foo"),
+			ExpectedStatusCode: http.StatusUnauthorized,
+			ExpectedHeaders:    map[string]string{"Server": httpsource.ServerHeader},
+			ExpectedBody: `
+{
+  "statusCode": 401,
+  "error": "stream.in.invalidSignature",
+  "message": "Invalid webhook signature: the request has already been received in the replay window \"1h0m0s\"."
+}`,
+		},
+		{
+			Name: "stream input - POST - signature - write error",
+			Prepare: func(t *testing.T) {
+				t.Helper()
+				c := ts.mock.TestDummySinkController()
+				c.PipelineWriteError = errors.New("some write error")
+			},
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-3/" + ts.validSecret,
+			Headers:            map[string]string{"X-Hub-Signature-256": "sha256=" + hmacSHA256("my-webhook-secret", "bar")},
+			Body:               strings.NewReader("bar"),
+			ExpectedStatusCode: http.StatusInternalServerError,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+				"Server":       httpsource.ServerHeader,
+			},
+			ExpectedLogs: []string{
+				`{"level":"error","message":"source record processing failed: 1/1 sinks failed. Failed sinks: sink my-sink-1: Some write error.","component":"sink.router"}`,
+			},
+			ExpectedBody: `
+{
+  "statusCode": 500,
+  "error": "stream.in.writeFailed",
+  "message": "Written to 0/1 sinks.",
+  "sources": [
+    {
+      "projectId": 123,
+      "sourceId": "my-source-3",
+      "branchId": 111,
+      "statusCode": 500,
+      "error": "stream.in.writeFailed",
+      "message": "Written to 0/1 sinks.",
+      "sinks": [
+        {
+          "sinkId": "my-sink-1",
+          "statusCode": 500,
+          "error": "stream.in.genericError",
+          "message": "Some write error."
+        }
+      ]
+    }
+  ]
+}`,
+		},
+		{
+			Name: "stream input - POST - signature - retry after write error",
+			Prepare: func(t *testing.T) {
+				t.Helper()
+				c := ts.mock.TestDummySinkController()
+				c.PipelineWriteError = nil
+			},
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-3/" + ts.validSecret,
+			Headers:            map[string]string{"X-Hub-Signature-256": "sha256=" + hmacSHA256("my-webhook-secret", "bar")},
+			Body:               strings.NewReader("bar"),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "text/plain",
+				"Server":       httpsource.ServerHeader,
+			},
+			ExpectedBody: "OK",
+		},
+		{
+			Name:               "stream input - POST - batch - NDJSON - ok",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-1/" + ts.validSecret,
+			Headers:            map[string]string{"Content-Type": httpsource.NDJSONContentType},
+			Body:               strings.NewReader("{\"a\":1}\n\n{\"a\":2}\n"),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+				"Server":       httpsource.ServerHeader,
+			},
+			ExpectedBody: `
+{
+  "statusCode": 200,
+  "message": "Successfully written 2/2 records.",
+  "total": 2,
+  "accepted": [
+    0,
+    2
+  ]
+}`,
+		},
+		{
+			Name:               "stream input - POST - batch - NDJSON - invalid line",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-1/" + ts.validSecret,
+			Headers:            map[string]string{"Content-Type": httpsource.NDJSONContentType + "; charset=utf-8"},
+			Body:               strings.NewReader("{\"a\":1}\nfoo\n"),
+			ExpectedStatusCode: http.StatusMultiStatus,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+				"Server":       httpsource.ServerHeader,
+			},
+			ExpectedBody: `
+{
+  "statusCode": 207,
+  "error": "stream.in.writeFailed",
+  "message": "Written 1/2 records.",
+  "total": 2,
+  "accepted": [
+    0
+  ],
+  "failed": [
+    {
+      "index": 1,
+      "statusCode": 400,
+      "error": "stream.in.badRequest",
+      "message": "Invalid JSON: %s"
+    }
+  ]
+}`,
+		},
+		{
+			Name:               "stream input - POST - batch - JSON array - ok",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-1/" + ts.validSecret,
+			Headers:            map[string]string{"Content-Type": httpsource.JSONArrayContentType},
+			Body:               strings.NewReader(`[{"a":1},{"a":2},{"a":3}]`),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+				"Server":       httpsource.ServerHeader,
+			},
+			ExpectedBody: `
+{
+  "statusCode": 200,
+  "message": "Successfully written 3/3 records.",
+  "total": 3,
+  "accepted": [
+    0,
+    1,
+    2
+  ]
+}`,
+		},
+		{
+			Name: "stream input - POST - batch - records are dispatched in parallel",
+			Prepare: func(t *testing.T) {
+				t.Helper()
+				// Each write waits until all writes of the batch are in flight, like a shared sync of the sink storage.
+				// 3 records are written to one enabled sink.
+				var inFlight atomic.Int32
+				allInFlight := make(chan struct{})
+				c := ts.mock.TestDummySinkController()
+				c.PipelineWriteHook = func(key.SinkKey) error {
+					if inFlight.Add(1) == 3 {
+						close(allInFlight)
+					}
+					select {
+					case <-allInFlight:
+						return nil
+					case <-time.After(5 * time.Second):
+						return errors.New("records are not dispatched in parallel")
+					}
+				}
+				t.Cleanup(func() {
+					c.PipelineWriteHook = nil
+				})
+			},
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-1/" + ts.validSecret,
+			Headers:            map[string]string{"Content-Type": httpsource.NDJSONContentType},
+			Body:               strings.NewReader("{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n"),
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": "application/json",
+				"Server":       httpsource.ServerHeader,
+			},
+			ExpectedBody: `
+{
+  "statusCode": 200,
+  "message": "Successfully written 3/3 records.",
+  "total": 3,
+  "accepted": [
+    0,
+    1,
+    2
+  ]
+}`,
+		},
+		{
+			Name:               "stream input - POST - batch - JSON array - not array",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-1/" + ts.validSecret,
+			Headers:            map[string]string{"Content-Type": httpsource.JSONArrayContentType},
+			Body:               strings.NewReader(`{"a":1}`),
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedHeaders:    map[string]string{"Server": httpsource.ServerHeader},
+			ExpectedLogs:       []string{`{"level":"warn","message":"batch dispatch failed","nodeId":"test-node","project.id":"123","source.id":"my-source-1","component":"http-source"}`},
+			ExpectedBody: `
+{
+  "statusCode": 400,
+  "error": "stream.in.badRequest",
+  "message": "Invalid JSON array: the body must be a JSON array."
+}`,
+		},
+		{
+			Name:               "stream input - POST - batch - empty",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-1/" + ts.validSecret,
+			Headers:            map[string]string{"Content-Type": httpsource.NDJSONContentType},
+			Body:               strings.NewReader("\n\n"),
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedHeaders:    map[string]string{"Server": httpsource.ServerHeader},
+			ExpectedLogs:       []string{`{"level":"warn","message":"batch dispatch failed","nodeId":"test-node","project.id":"123","source.id":"my-source-1","component":"http-source"}`},
+			ExpectedBody: `
+{
+  "statusCode": 400,
+  "error": "stream.in.badRequest",
+  "message": "The batch doesn't contain any record."
+}`,
+		},
+		{
+			Name:               "stream input - POST - batch - over maximum body size",
+			Method:             http.MethodPost,
+			Path:               "/stream/123/my-source-1/" + ts.validSecret,
+			Headers:            map[string]string{"Content-Type": httpsource.NDJSONContentType},
+			Body:               strings.NewReader(strings.Repeat("{\"a\":1}\n", ts.maxBodySize/8+1)),
+			ExpectedStatusCode: http.StatusRequestEntityTooLarge,
+			ExpectedHeaders:    map[string]string{"Server": httpsource.ServerHeader},
+			ExpectedBody: `
+{
+  "statusCode": 413,
+  "error": "stream.in.bodyTooLarge",
+  "message": "Request body size is over the maximum \"8000B\"."
 }`,
 		},
 	}
 }
 
+func hmacSHA256(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // assertLogsUnordered checks that each expected log message appears at least the required number of times
 // in the actual logs, regardless of order. This allows for non-deterministic log ordering in tests.
 func assertLogsUnordered(t assert.TestingT, logger log.DebugLogger, expectedLogs []string, minOccurrences int) {
@@ -0,0 +1,31 @@
+package signature
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type InvalidSignatureError struct {
+	reason string
+}
+
+func newInvalidSignatureError(format string, a ...any) InvalidSignatureError {
+	return InvalidSignatureError{reason: fmt.Sprintf(format, a...)}
+}
+
+// Reason describes why the signature is invalid.
+func (e InvalidSignatureError) Reason() string {
+	return e.reason
+}
+
+func (e InvalidSignatureError) Error() string {
+	return "invalid webhook signature: " + e.reason
+}
+
+func (e InvalidSignatureError) ErrorName() string {
+	return "invalidSignature"
+}
+
+func (e InvalidSignatureError) StatusCode() int {
+	return http.StatusUnauthorized
+}
@@ -0,0 +1,147 @@
+// Package signature verifies HMAC signatures of webhooks received by the HTTP source, see definition.HTTPSourceSignature.
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // SHA-1 HMAC is still used by some webhook providers
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+)
+
+const (
+	SlackTimestampHeader = "X-Slack-Request-Timestamp"
+)
+
+// HeaderGetter returns value of the request header, or an empty string, if the header is not set.
+type HeaderGetter func(name string) string
+
+// Verify checks the signature of the request body, the replay protection is not applied, see Verifier.
+// The returned error is always an InvalidSignatureError, it describes why the signature is invalid.
+func Verify(cfg definition.HTTPSourceSignature, now time.Time, header HeaderGetter, body []byte) error {
+	_, err := verify(cfg, now, header, body)
+	return err
+}
+
+// verify returns the matched signature, to be used for the replay protection.
+func verify(cfg definition.HTTPSourceSignature, now time.Time, header HeaderGetter, body []byte) (string, error) {
+	value := strings.TrimSpace(header(cfg.Header))
+	if value == "" {
+		return "", newInvalidSignatureError(`header "%s" is missing`, cfg.Header)
+	}
+
+	var signatures []string
+	var payload []byte
+	switch cfg.Format {
+	case definition.SignatureFormatHex:
+		// For example "sha256=<signature>", the prefix is optional
+		if _, after, found := strings.Cut(value, "="); found {
+			value = after
+		}
+		signatures = []string{value}
+		payload = body
+	case definition.SignatureFormatStripe:
+		// For example "t=<timestamp>,v1=<signature>,v1=<signature>", there may be multiple signatures during a secret rotation
+		var timestamp string
+		for part := range strings.SplitSeq(value, ",") {
+			k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch k {
+			case "t":
+				timestamp = v
+			case "v1":
+				signatures = append(signatures, v)
+			}
+		}
+		if err := checkTimestamp(cfg, now, timestamp, `header "`+cfg.Header+`"`); err != nil {
+			return "", err
+		}
+		if len(signatures) == 0 {
+			return "", newInvalidSignatureError(`header "%s" doesn't contain a "v1" signature`, cfg.Header)
+		}
+		payload = make([]byte, 0, len(timestamp)+1+len(body))
+		payload = append(payload, timestamp...)
+		payload = append(payload, '.')
+		payload = append(payload, body...)
+	case definition.SignatureFormatSlack:
+		// For example "v0=<signature>", the version is part of the signed payload
+		version, signature, found := strings.Cut(value, "=")
+		if !found {
+			return "", newInvalidSignatureError(`header "%s" doesn't have the "<version>=<signature>" format`, cfg.Header)
+		}
+		timestamp := strings.TrimSpace(header(SlackTimestampHeader))
+		if err := checkTimestamp(cfg, now, timestamp, `header "`+SlackTimestampHeader+`"`); err != nil {
+			return "", err
+		}
+		signatures = []string{signature}
+		payload = make([]byte, 0, len(version)+len(timestamp)+2+len(body))
+		payload = append(payload, version...)
+		payload = append(payload, ':')
+		payload = append(payload, timestamp...)
+		payload = append(payload, ':')
+		payload = append(payload, body...)
+	default:
+		return "", newInvalidSignatureError(`unexpected signature format "%s"`, cfg.Format)
+	}
+
+	newHash, err := hashFunc(cfg.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(newHash, []byte(cfg.Secret))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+
+	decoded := false
+	for _, signature := range signatures {
+		if actual, err := hex.DecodeString(signature); err == nil {
+			decoded = true
+			if hmac.Equal(actual, expected) {
+				return signature, nil
+			}
+		}
+	}
+
+	if !decoded {
+		return "", newInvalidSignatureError(`signature in the header "%s" is not a hex string`, cfg.Header)
+	}
+	return "", newInvalidSignatureError(`signature doesn't match, expected "%s" signature of the request body`, cfg.Algorithm)
+}
+
+func checkTimestamp(cfg definition.HTTPSourceSignature, now time.Time, value, source string) error {
+	if value == "" {
+		return newInvalidSignatureError(`timestamp is missing, expected in the %s`, source)
+	}
+
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return newInvalidSignatureError(`timestamp "%s" in the %s is not a Unix time`, value, source)
+	}
+
+	if tolerance := cfg.TimestampTolerance.Duration(); tolerance > 0 {
+		if diff := now.Sub(time.Unix(unix, 0)).Abs(); diff > tolerance {
+			return newInvalidSignatureError(`timestamp "%s" is outside the tolerance "%s"`, value, tolerance)
+		}
+	}
+
+	return nil
+}
+
+func hashFunc(algorithm definition.SignatureAlgorithm) (func() hash.Hash, error) {
+	switch algorithm {
+	case definition.SignatureAlgorithmHMACSHA1:
+		return sha1.New, nil
+	case definition.SignatureAlgorithmHMACSHA256:
+		return sha256.New, nil
+	case definition.SignatureAlgorithmHMACSHA512:
+		return sha512.New, nil
+	default:
+		return nil, newInvalidSignatureError(`unexpected signature algorithm "%s"`, algorithm)
+	}
+}
@@ -0,0 +1,231 @@
+package signature_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // tested algorithm
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/httpsource/signature"
+)
+
+const (
+	testSecret = "my-secret"
+	testBody   = `{"foo":"bar"}`
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	oldTS := strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)
+
+	github := definition.HTTPSourceSignature{
+		Algorithm: definition.SignatureAlgorithmHMACSHA256,
+		Format:    definition.SignatureFormatHex,
+		Header:    "X-Hub-Signature-256",
+		Secret:    testSecret,
+	}
+	stripe := definition.HTTPSourceSignature{
+		Algorithm:          definition.SignatureAlgorithmHMACSHA256,
+		Format:             definition.SignatureFormatStripe,
+		Header:             "Stripe-Signature",
+		Secret:             testSecret,
+		TimestampTolerance: duration.From(5 * time.Minute),
+	}
+	slack := definition.HTTPSourceSignature{
+		Algorithm:          definition.SignatureAlgorithmHMACSHA256,
+		Format:             definition.SignatureFormatSlack,
+		Header:             "X-Slack-Signature",
+		Secret:             testSecret,
+		TimestampTolerance: duration.From(5 * time.Minute),
+	}
+	sha1Hex := definition.HTTPSourceSignature{
+		Algorithm: definition.SignatureAlgorithmHMACSHA1,
+		Format:    definition.SignatureFormatHex,
+		Header:    "X-Signature",
+		Secret:    testSecret,
+	}
+
+	cases := []struct {
+		Name          string
+		Config        definition.HTTPSourceSignature
+		Headers       map[string]string
+		ExpectedError string
+	}{
+		{
+			Name:    "hex: ok",
+			Config:  github,
+			Headers: map[string]string{"X-Hub-Signature-256": "sha256=" + sign(sha256.New, testBody)},
+		},
+		{
+			Name:    "hex: ok, without prefix",
+			Config:  github,
+			Headers: map[string]string{"X-Hub-Signature-256": sign(sha256.New, testBody)},
+		},
+		{
+			Name:    "hex: ok, sha1",
+			Config:  sha1Hex,
+			Headers: map[string]string{"X-Signature": "sha1=" + sign(sha1.New, testBody)},
+		},
+		{
+			Name:          "hex: missing header",
+			Config:        github,
+			ExpectedError: `invalid webhook signature: header "X-Hub-Signature-256" is missing`,
+		},
+		{
+			Name:          "hex: not hex",
+			Config:        github,
+			Headers:       map[string]string{"X-Hub-Signature-256": "sha256=foo"},
+			ExpectedError: `invalid webhook signature: signature in the header "X-Hub-Signature-256" is not a hex string`,
+		},
+		{
+			Name:          "hex: different body",
+			Config:        github,
+			Headers:       map[string]string{"X-Hub-Signature-256": "sha256=" + sign(sha256.New, "foo")},
+			ExpectedError: `invalid webhook signature: signature doesn't match, expected "hmac-sha256" signature of the request body`,
+		},
+		{
+			Name:    "stripe: ok",
+			Config:  stripe,
+			Headers: map[string]string{"Stripe-Signature": "t=" + ts + ",v1=" + sign(sha256.New, "foo") + ",v1=" + sign(sha256.New, ts+"."+testBody)},
+		},
+		{
+			Name:          "stripe: missing timestamp",
+			Config:        stripe,
+			Headers:       map[string]string{"Stripe-Signature": "v1=" + sign(sha256.New, ts+"."+testBody)},
+			ExpectedError: `invalid webhook signature: timestamp is missing, expected in the header "Stripe-Signature"`,
+		},
+		{
+			Name:          "stripe: invalid timestamp",
+			Config:        stripe,
+			Headers:       map[string]string{"Stripe-Signature": "t=foo,v1=" + sign(sha256.New, ts+"."+testBody)},
+			ExpectedError: `invalid webhook signature: timestamp "foo" in the header "Stripe-Signature" is not a Unix time`,
+		},
+		{
+			Name:          "stripe: old timestamp",
+			Config:        stripe,
+			Headers:       map[string]string{"Stripe-Signature": "t=" + oldTS + ",v1=" + sign(sha256.New, oldTS+"."+testBody)},
+			ExpectedError: `invalid webhook signature: timestamp "` + oldTS + `" is outside the tolerance "5m0s"`,
+		},
+		{
+			Name:          "stripe: missing signature",
+			Config:        stripe,
+			Headers:       map[string]string{"Stripe-Signature": "t=" + ts},
+			ExpectedError: `invalid webhook signature: header "Stripe-Signature" doesn't contain a "v1" signature`,
+		},
+		{
+			Name:    "slack: ok",
+			Config:  slack,
+			Headers: map[string]string{"X-Slack-Signature": "v0=" + sign(sha256.New, "v0:"+ts+":"+testBody), "X-Slack-Request-Timestamp": ts},
+		},
+		{
+			Name:          "slack: missing timestamp",
+			Config:        slack,
+			Headers:       map[string]string{"X-Slack-Signature": "v0=" + sign(sha256.New, "v0:"+ts+":"+testBody)},
+			ExpectedError: `invalid webhook signature: timestamp is missing, expected in the header "X-Slack-Request-Timestamp"`,
+		},
+		{
+			Name:          "slack: invalid format",
+			Config:        slack,
+			Headers:       map[string]string{"X-Slack-Signature": sign(sha256.New, "v0:"+ts+":"+testBody), "X-Slack-Request-Timestamp": ts},
+			ExpectedError: `invalid webhook signature: header "X-Slack-Signature" doesn't have the "<version>=<signature>" format`,
+		},
+		{
+			Name:          "slack: different timestamp",
+			Config:        slack,
+			Headers:       map[string]string{"X-Slack-Signature": "v0=" + sign(sha256.New, "v0:"+oldTS+":"+testBody), "X-Slack-Request-Timestamp": ts},
+			ExpectedError: `invalid webhook signature: signature doesn't match, expected "hmac-sha256" signature of the request body`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+
+			header := make(http.Header)
+			for k, v := range tc.Headers {
+				header.Set(k, v)
+			}
+
+			err := signature.Verify(tc.Config, now, header.Get, []byte(testBody))
+			if tc.ExpectedError == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Equal(t, tc.ExpectedError, err.Error())
+				var sigErr signature.InvalidSignatureError
+				assert.ErrorAs(t, err, &sigErr)
+				assert.Equal(t, http.StatusUnauthorized, sigErr.StatusCode())
+			}
+		})
+	}
+}
+
+func TestVerifier_ReplayWindow(t *testing.T) {
+	t.Parallel()
+
+	clk := clockwork.NewFakeClockAt(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	verifier := signature.NewVerifier(clk)
+
+	cfg := definition.HTTPSourceSignature{
+		Algorithm:    definition.SignatureAlgorithmHMACSHA256,
+		Format:       definition.SignatureFormatHex,
+		Header:       "X-Signature",
+		Secret:       testSecret,
+		ReplayWindow: duration.From(time.Minute),
+	}
+
+	header := make(http.Header)
+	header.Set("X-Signature", sign(sha256.New, testBody))
+
+	// The first request is accepted
+	_, err := verifier.Verify("source1", cfg, header.Get, []byte(testBody))
+	require.NoError(t, err)
+
+	// The same signature is rejected in the replay window
+	_, err = verifier.Verify("source1", cfg, header.Get, []byte(testBody))
+	if assert.Error(t, err) {
+		assert.Equal(t, `invalid webhook signature: the request has already been received in the replay window "1m0s"`, err.Error())
+	}
+
+	// Another source is not affected
+	_, err = verifier.Verify("source2", cfg, header.Get, []byte(testBody))
+	require.NoError(t, err)
+
+	// The signature is accepted again after the window
+	clk.Advance(time.Minute)
+	_, err = verifier.Verify("source1", cfg, header.Get, []byte(testBody))
+	require.NoError(t, err)
+
+	// The released signature is accepted again, for example, the retry of a request rejected by a rate limit
+	release, err := verifier.Verify("source4", cfg, header.Get, []byte(testBody))
+	require.NoError(t, err)
+	release()
+	_, err = verifier.Verify("source4", cfg, header.Get, []byte(testBody))
+	require.NoError(t, err)
+
+	// Replay protection is disabled
+	cfg.ReplayWindow = 0
+	_, err = verifier.Verify("source3", cfg, header.Get, []byte(testBody))
+	require.NoError(t, err)
+	_, err = verifier.Verify("source3", cfg, header.Get, []byte(testBody))
+	require.NoError(t, err)
+}
+
+func sign(fn func() hash.Hash, payload string) string {
+	mac := hmac.New(fn, []byte(testSecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package signature
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+)
+
+const (
+	cleanupInterval = time.Minute
+)
+
+// Verifier verifies signatures, and rejects replayed requests, if the definition.HTTPSourceSignature.ReplayWindow is set.
+//
+// Received signatures are stored in the memory of the node, so the replay protection is applied per node,
+// a request replayed to another source node is not detected, and the stored signatures are lost on a restart.
+// In combination with the TimestampTolerance, the signature cannot be replayed after the tolerance.
+type Verifier struct {
+	clock       clockwork.Clock
+	lock        sync.Mutex
+	seen        map[string]time.Time
+	nextCleanup time.Time
+}
+
+func NewVerifier(clock clockwork.Clock) *Verifier {
+	return &Verifier{
+		clock: clock,
+		seen:  make(map[string]time.Time),
+	}
+}
+
+// Verify checks the signature of the request body.
+// The scope separates stored signatures of different sources.
+//
+// The signature is stored, so a concurrent replay is rejected too.
+// The returned release function removes the stored signature, it must be called, if the request has not been dispatched,
+// for example, if it has been rejected by a rate limit, so a retry of the request is not rejected as a replay.
+func (v *Verifier) Verify(scope string, cfg definition.HTTPSourceSignature, header HeaderGetter, body []byte) (release func(), err error) {
+	now := v.clock.Now()
+	signature, err := verify(cfg, now, header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	window := cfg.ReplayWindow.Duration()
+	if window <= 0 {
+		return func() {}, nil
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.cleanup(now)
+
+	k := scope + "/" + signature
+	if expiration, found := v.seen[k]; found && now.Before(expiration) {
+		return nil, newInvalidSignatureError(`the request has already been received in the replay window "%s"`, window)
+	}
+
+	expiration := now.Add(window)
+	v.seen[k] = expiration
+	return func() {
+		v.lock.Lock()
+		defer v.lock.Unlock()
+		// The signature may have expired and may have been stored again by another request
+		if v.seen[k] == expiration {
+			delete(v.seen, k)
+		}
+	}, nil
+}
+
+// cleanup removes expired signatures, it is called at most once per cleanupInterval.
+func (v *Verifier) cleanup(now time.Time) {
+	if now.Before(v.nextCleanup) {
+		return
+	}
+
+	for k, expiration := range v.seen {
+		if !now.Before(expiration) {
+			delete(v.seen, k)
+		}
+	}
+
+	v.nextCleanup = now.Add(cleanupInterval)
+}
@@ -0,0 +1,31 @@
+package kafkasource
+
+import (
+	"time"
+)
+
+type Config struct {
+	ClientID            string        `configKey:"clientId" configUsage:"Client ID sent to Kafka brokers." validate:"required"`
+	DialTimeout         time.Duration `configKey:"dialTimeout" configUsage:"Timeout of a connection to a Kafka broker." validate:"required,minDuration=1s,maxDuration=60s"`
+	ReconcileInterval   time.Duration `configKey:"reconcileInterval" configUsage:"How often topic partitions are listed and assigned to source nodes." validate:"required,minDuration=1s,maxDuration=30m"`
+	MaxPollRecords      int           `configKey:"maxPollRecords" configUsage:"Maximum number of records processed in one poll." validate:"required,min=1,max=100000"`
+	RetryInitialBackoff time.Duration `configKey:"retryInitialBackoff" configUsage:"Initial delay before a retry of a record which has not been written to a sink." validate:"required,minDuration=1ms,maxDuration=1m"`
+	RetryMaxBackoff     time.Duration `configKey:"retryMaxBackoff" configUsage:"Maximum delay before a retry of a record which has not been written to a sink." validate:"required,minDuration=1ms,maxDuration=30m,gtefield=RetryInitialBackoff"`
+	MaxRetries          int           `configKey:"maxRetries" configUsage:"Maximum number of retries of a record which has not been written to a sink. Then the record is stored to the dead-letter storage of the sink, if enabled, and skipped." validate:"min=0,max=1000"`
+	DispatchParallelism int           `configKey:"dispatchParallelism" configUsage:"Maximum number of records of one poll dispatched in parallel, parallel writes wait for the sync together." validate:"required,min=1,max=100000"`
+	AcceptedCommitDelay time.Duration `configKey:"acceptedCommitDelay" configUsage:"Delay before the offset commit of records accepted by a sink without waiting for the sync. It is longer than the maximum sync interval, so an accepted record is lost only if its sync fails." validate:"required,minDuration=31s,maxDuration=5m"`
+}
+
+func NewConfig() Config {
+	return Config{
+		ClientID:            "keboola-stream",
+		DialTimeout:         10 * time.Second,
+		ReconcileInterval:   30 * time.Second,
+		MaxPollRecords:      1000,
+		RetryInitialBackoff: 100 * time.Millisecond,
+		RetryMaxBackoff:     30 * time.Second,
+		MaxRetries:          20,
+		DispatchParallelism: 1000,
+		AcceptedCommitDelay: 35 * time.Second,
+	}
+}
@@ -0,0 +1,492 @@
+package kafkasource
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/keboola/go-utils/pkg/orderedmap"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	HeaderTopic     = "X-Kafka-Topic"
+	HeaderPartition = "X-Kafka-Partition"
+	HeaderOffset    = "X-Kafka-Offset"
+	HeaderKey       = "X-Kafka-Key"
+)
+
+// dispatchResult is the result of the dispatch method.
+type dispatchResult struct {
+	processed bool
+	ok        bool
+}
+
+// pendingCommit is an offset, which can be committed after the deadline.
+type pendingCommit struct {
+	offset   kadm.Offset
+	deadline time.Time
+}
+
+// consumer consumes assigned partitions of the source topic.
+// Partitions are assigned by the node.reconcile method, the assignment is applied by the poll loop, between polls.
+type consumer struct {
+	node      *node
+	logger    log.Logger
+	sourceKey key.SourceKey
+	kafka     definition.KafkaSource
+	client    *kgo.Client
+	admin     *kadm.Client
+
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+
+	lock sync.Mutex
+	// requested partitions, nil means no change
+	requested []int32
+	// lastRequested partitions, to skip an assignment without change
+	lastRequested []int32
+	// assigned partitions, owned by the poll loop
+	assigned map[int32]bool
+	// pending offset commits of assigned partitions, owned by the poll loop, see the processPartition method
+	pending map[int32][]pendingCommit
+	// interruptPoll cancels the current poll, so the new assignment can be applied
+	interruptPoll context.CancelFunc
+}
+
+func newConsumer(n *node, source *sourceData) (*consumer, error) {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(source.Kafka.Brokers...),
+		kgo.ClientID(n.config.ClientID),
+		kgo.DialTimeout(n.config.DialTimeout),
+		// Partitions are added and removed dynamically, direct consumer without a group is used
+		kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &consumer{
+		node:      n,
+		logger:    n.logger.With(source.SourceKey.Telemetry()...),
+		sourceKey: source.SourceKey,
+		kafka:     source.Kafka,
+		client:    client,
+		admin:     kadm.NewClient(client),
+		assigned:  make(map[int32]bool),
+		pending:   make(map[int32][]pendingCommit),
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	ctx = telemetry.ContextWithDisabledTracing(ctx) // disable spans in the hot path
+	c.cancel = cancel
+	c.wg.Go(func() {
+		c.pollLoop(ctx)
+	})
+
+	c.logger.Infof(ctx, `started Kafka consumer, topic "%s", group "%s"`, c.kafka.Topic, c.kafka.GroupID)
+	return c, nil
+}
+
+func (c *consumer) sameDefinition(v definition.KafkaSource) bool {
+	return slices.Equal(c.kafka.Brokers, v.Brokers) && c.kafka.Topic == v.Topic && c.kafka.GroupID == v.GroupID
+}
+
+func (c *consumer) listPartitions(ctx context.Context) ([]int32, error) {
+	topics, err := c.admin.ListTopics(ctx, c.kafka.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	topic, found := topics[c.kafka.Topic]
+	if !found {
+		return nil, errors.Errorf(`topic "%s" not found`, c.kafka.Topic)
+	}
+	if topic.Err != nil {
+		return nil, topic.Err
+	}
+
+	return topic.Partitions.Numbers(), nil
+}
+
+// assign requests a new set of partitions, it is applied by the poll loop.
+func (c *consumer) assign(partitions []int32) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if partitions == nil {
+		partitions = []int32{}
+	}
+	slices.Sort(partitions)
+	if c.lastRequested != nil && slices.Equal(c.lastRequested, partitions) {
+		return
+	}
+
+	c.requested = partitions
+	c.lastRequested = partitions
+	if c.interruptPoll != nil {
+		c.interruptPoll()
+	}
+}
+
+// stop waits for processing of in-flight records, and closes the client.
+func (c *consumer) stop(ctx context.Context) {
+	c.cancel(errors.New("Kafka consumer stopped"))
+	c.wg.Wait()
+	c.client.Close()
+	c.logger.Info(ctx, "stopped Kafka consumer")
+}
+
+func (c *consumer) pollLoop(ctx context.Context) {
+	// Commit due offsets of processed records on shutdown
+	defer c.commitOffsets(ctx)
+
+	for ctx.Err() == nil {
+		if err := c.applyAssignment(ctx); err != nil {
+			c.logger.Errorf(ctx, `cannot assign Kafka partitions: %s`, err)
+		}
+
+		// The poll can be interrupted by a new assignment, or by the deadline of a pending commit
+		c.lock.Lock()
+		pollCtx, interruptPoll := context.WithCancel(ctx)
+		c.interruptPoll = interruptPoll
+		if c.requested != nil {
+			interruptPoll()
+		}
+		c.lock.Unlock()
+		stopTimer := func() bool { return false }
+		if deadline, ok := c.nextCommitDeadline(); ok {
+			stopTimer = c.node.clock.AfterFunc(deadline.Sub(c.node.clock.Now()), interruptPoll).Stop
+		}
+
+		fetches := c.client.PollRecords(pollCtx, c.node.config.MaxPollRecords)
+		interruptPoll()
+		stopTimer()
+		if fetches.IsClientClosed() {
+			return
+		}
+
+		fetches.EachError(func(topic string, partition int32, err error) {
+			if !errors.Is(err, context.Canceled) {
+				c.logger.Errorf(ctx, `cannot fetch Kafka topic "%s", partition "%d": %s`, topic, partition, err)
+			}
+		})
+
+		c.processFetches(ctx, fetches)
+
+		c.commitOffsets(ctx)
+	}
+}
+
+// applyAssignment adds and removes consumed partitions, according to the last requested assignment.
+// New partitions are consumed from the committed offset, or from the start, if there is no committed offset.
+func (c *consumer) applyAssignment(ctx context.Context) error {
+	c.lock.Lock()
+	requested := c.requested
+	c.requested = nil
+	c.lock.Unlock()
+
+	if requested == nil {
+		return nil
+	}
+
+	// Remove partitions
+	var removed []int32
+	for partition := range c.assigned {
+		if !slices.Contains(requested, partition) {
+			removed = append(removed, partition)
+			delete(c.assigned, partition)
+			// The new owner continues from the last committed offset
+			delete(c.pending, partition)
+		}
+	}
+	if len(removed) > 0 {
+		c.client.RemoveConsumePartitions(map[string][]int32{c.kafka.Topic: removed})
+		c.logger.Infof(ctx, `removed Kafka partitions %v`, removed)
+	}
+
+	// Add partitions
+	var added []int32
+	for _, partition := range requested {
+		if !c.assigned[partition] {
+			added = append(added, partition)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	committed, err := c.admin.FetchOffsetsForTopics(ctx, c.kafka.GroupID, c.kafka.Topic)
+	if errors.Is(err, kerr.GroupIDNotFound) {
+		// There is no committed offset yet
+		committed, err = nil, nil
+	}
+	if err != nil {
+		// Try it again with the next reconciliation
+		c.lock.Lock()
+		c.lastRequested = nil
+		c.lock.Unlock()
+		return errors.PrefixErrorf(err, `cannot fetch committed offsets of the group "%s"`, c.kafka.GroupID)
+	}
+
+	offsets := make(map[int32]kgo.Offset, len(added))
+	for _, partition := range added {
+		if r, found := committed.Lookup(c.kafka.Topic, partition); found && r.Err == nil && r.At >= 0 {
+			offsets[partition] = kgo.NewOffset().At(r.At)
+		} else {
+			offsets[partition] = kgo.NewOffset().AtStart()
+		}
+		c.assigned[partition] = true
+	}
+	c.client.AddConsumePartitions(map[string]map[int32]kgo.Offset{c.kafka.Topic: offsets})
+	c.logger.Infof(ctx, `added Kafka partitions %v`, added)
+	return nil
+}
+
+// processFetches dispatches all records of the poll in parallel, see the dispatch method.
+// Parallel writes wait for the sync of the sink storage together, so the whole poll is synced at once, see writesync package.
+// The parallelism is limited by the Config.DispatchParallelism.
+// The order of the records of a partition in the sink storage is therefore not guaranteed, as for parallel HTTP requests.
+func (c *consumer) processFetches(ctx context.Context, fetches kgo.Fetches) {
+	var partitions []kgo.FetchTopicPartition
+	fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+		if len(p.Records) > 0 {
+			partitions = append(partitions, p)
+		}
+	})
+
+	// Results are stored by the record position
+	results := make([][]dispatchResult, len(partitions))
+	sem := make(chan struct{}, c.node.config.DispatchParallelism)
+	wg := &sync.WaitGroup{}
+	for i, p := range partitions {
+		results[i] = make([]dispatchResult, len(p.Records))
+		for j, record := range p.Records {
+			if ctx.Err() != nil {
+				break // the consumer is stopping
+			}
+			sem <- struct{}{}
+			wg.Go(func() {
+				defer func() { <-sem }()
+				results[i][j].processed, results[i][j].ok = c.dispatch(ctx, record)
+			})
+		}
+	}
+	wg.Wait()
+
+	for i, p := range partitions {
+		c.processPartition(p, results[i])
+	}
+}
+
+// processPartition plans the offset commit of dispatched records of the partition.
+// The offset is committed after the records have been processed by all sinks, or skipped, see the dispatch method.
+// Only the offset of the continuous sequence of dispatched records is committed, the rest is consumed again after a restart.
+//
+// A record only accepted by a sink, see pipeline.RecordAccepted, is kept in memory of the sink until the sync,
+// the sink router doesn't report the later sync, so the offset is committed after the Config.AcceptedCommitDelay,
+// consuming of the partition continues meanwhile. The delay is longer than the maximum sync interval of a sink,
+// so an accepted record can be lost only if its sync fails, then the record is lost regardless of the commit.
+// Offsets of the partition are committed in order, so the offset of later processed records waits for earlier accepted records.
+func (c *consumer) processPartition(p kgo.FetchTopicPartition, results []dispatchResult) {
+	var last *kgo.Record
+	accepted := false
+	for i, record := range p.Records {
+		if !results[i].ok {
+			break // the consumer is stopping
+		}
+		if !results[i].processed {
+			accepted = true
+		}
+		last = record
+	}
+
+	if last == nil {
+		return
+	}
+
+	// Commit the offset of the next record
+	deadline := c.node.clock.Now()
+	if accepted {
+		deadline = deadline.Add(c.node.config.AcceptedCommitDelay)
+	}
+	queue := c.pending[p.Partition]
+	if len(queue) > 0 && queue[len(queue)-1].deadline.After(deadline) {
+		deadline = queue[len(queue)-1].deadline
+	}
+	offset := kadm.Offset{Topic: last.Topic, Partition: last.Partition, At: last.Offset + 1, LeaderEpoch: last.LeaderEpoch}
+	c.pending[p.Partition] = append(queue, pendingCommit{offset: offset, deadline: deadline})
+}
+
+// nextCommitDeadline returns the nearest deadline of a pending commit.
+func (c *consumer) nextCommitDeadline() (next time.Time, found bool) {
+	for _, queue := range c.pending {
+		if len(queue) > 0 && (!found || queue[0].deadline.Before(next)) {
+			next, found = queue[0].deadline, true
+		}
+	}
+	return next, found
+}
+
+// commitOffsets commits the last due offset of each partition.
+// The context can be already cancelled, so the processed records are not consumed again after a restart.
+func (c *consumer) commitOffsets(ctx context.Context) {
+	now := c.node.clock.Now()
+	offsets := make(kadm.Offsets)
+	for partition, queue := range c.pending {
+		due := 0
+		for due < len(queue) && !queue[due].deadline.After(now) {
+			due++
+		}
+		if due == 0 {
+			continue
+		}
+
+		offsets.Add(queue[due-1].offset)
+		if due == len(queue) {
+			delete(c.pending, partition)
+		} else {
+			c.pending[partition] = queue[due:]
+		}
+	}
+
+	if len(offsets) == 0 {
+		return
+	}
+
+	commitCtx, cancel := context.WithTimeoutCause(context.WithoutCancel(ctx), c.node.config.DialTimeout, errors.New("Kafka offset commit timeout"))
+	defer cancel()
+	if err := c.admin.CommitAllOffsets(commitCtx, c.kafka.GroupID, offsets); err != nil {
+		c.logger.Errorf(ctx, `cannot commit Kafka offsets of the group "%s": %s`, c.kafka.GroupID, err)
+	}
+}
+
+// dispatch writes the record to all sinks of the source.
+// Only failed sinks are retried, up to the Config.MaxRetries, then the record is stored to the dead-letter storage of the sink, if enabled, and skipped.
+// A record which cannot be mapped by a sink is not retried, see router.SinkResult.Retryable, it is dropped, see the drop method.
+// The processed result is false, if a sink has only accepted the record, see pipeline.RecordAccepted.
+// The ok result is false, if the consumer is stopping.
+func (c *consumer) dispatch(ctx context.Context, record *kgo.Record) (processed bool, ok bool) {
+	b := backoff.NewExponentialBackOff()
+	b.RandomizationFactor = 0.2
+	b.Multiplier = 2
+	b.InitialInterval = c.node.config.RetryInitialBackoff
+	b.MaxInterval = c.node.config.RetryMaxBackoff
+	b.Reset()
+
+	processed = true
+	result := c.node.router.DispatchToSource(c.sourceKey, newRecordContext(ctx, c.node.clock.Now(), record))
+	for retry := 1; ; retry++ {
+		var failed []key.SinkID
+		for _, sink := range result.Sinks {
+			switch {
+			case sink.Err() == nil:
+				processed = processed && sink.Processed()
+			case !sink.Retryable():
+				c.drop(ctx, record, sink)
+			case retry > c.node.config.MaxRetries:
+				c.skip(ctx, record, sink)
+			default:
+				failed = append(failed, sink.SinkID)
+			}
+		}
+
+		if len(failed) == 0 {
+			return processed, true
+		}
+
+		delay := b.NextBackOff()
+		result.Finalize()
+		c.logger.Warnf(
+			ctx,
+			`Kafka record partition "%d", offset "%d" has not been written to sinks %v, retry %d/%d in %s: %s`,
+			record.Partition, record.Offset, failed, retry, c.node.config.MaxRetries, delay, result.Message,
+		)
+
+		select {
+		case <-ctx.Done():
+			return false, false
+		case <-c.node.clock.After(delay):
+		}
+
+		result = c.node.router.DispatchToSinks(c.sourceKey, failed, newRecordContext(ctx, c.node.clock.Now(), record))
+	}
+}
+
+// drop gives up the record, which cannot be mapped by the sink, a retry cannot succeed.
+// The record has been already stored to the dead-letter storage of the sink, if enabled, otherwise it is counted as dropped.
+func (c *consumer) drop(ctx context.Context, record *kgo.Record, sink *sinkRouter.SinkResult) {
+	sinkKey := key.SinkKey{SourceKey: c.sourceKey, SinkID: sink.SinkID}
+	if c.node.router.DropRecord(sinkKey, newRecordContext(ctx, c.node.clock.Now(), record)) {
+		c.logger.Warnf(
+			ctx,
+			`Kafka record partition "%d", offset "%d" cannot be written to the sink "%s", it has been stored to the dead-letter storage: %s`,
+			record.Partition, record.Offset, sink.SinkID, sink.Err(),
+		)
+		return
+	}
+
+	c.logger.Errorf(
+		ctx,
+		`Kafka record partition "%d", offset "%d" cannot be written to the sink "%s", it has been dropped: %s`,
+		record.Partition, record.Offset, sink.SinkID, sink.Err(),
+	)
+}
+
+// skip gives up retrying of the record for the sink, so the offset can be committed.
+// The record is stored to the dead-letter storage of the sink, if enabled.
+func (c *consumer) skip(ctx context.Context, record *kgo.Record, sink *sinkRouter.SinkResult) {
+	sinkKey := key.SinkKey{SourceKey: c.sourceKey, SinkID: sink.SinkID}
+	if c.node.router.StoreDeadLetter(sinkKey, newRecordContext(ctx, c.node.clock.Now(), record), sink.Err()) {
+		c.logger.Warnf(
+			ctx,
+			`Kafka record partition "%d", offset "%d" has not been written to the sink "%s" after %d retries, it has been stored to the dead-letter storage: %s`,
+			record.Partition, record.Offset, sink.SinkID, c.node.config.MaxRetries, sink.Err(),
+		)
+		return
+	}
+
+	c.logger.Errorf(
+		ctx,
+		`Kafka record partition "%d", offset "%d" has not been written to the sink "%s" after %d retries, it has been skipped: %s`,
+		record.Partition, record.Offset, sink.SinkID, c.node.config.MaxRetries, sink.Err(),
+	)
+}
+
+func newRecordContext(ctx context.Context, now time.Time, record *kgo.Record) recordctx.Context {
+	headers := orderedmap.New()
+	for _, h := range record.Headers {
+		headers.Set(http.CanonicalHeaderKey(h.Key), string(h.Value))
+	}
+	headers.Set(HeaderTopic, record.Topic)
+	headers.Set(HeaderPartition, strconv.FormatInt(int64(record.Partition), 10))
+	headers.Set(HeaderOffset, strconv.FormatInt(record.Offset, 10))
+	if record.Key != nil {
+		headers.Set(HeaderKey, string(record.Key))
+	}
+	headers.SortKeys(func(keys []string) {
+		sort.Strings(keys)
+	})
+
+	timestamp := record.Timestamp
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+
+	return recordctx.FromKafka(ctx, timestamp, headers, record.Value)
+}
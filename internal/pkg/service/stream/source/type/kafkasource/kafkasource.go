@@ -0,0 +1,284 @@
+// Package kafkasource consumes records from a topic of a Kafka-protocol compatible broker.
+//
+// Partitions of all Kafka sources are distributed between source nodes using the distribution.Assigner,
+// the distribution key consists of the source key and the partition number.
+// Each node consumes only owned partitions, the broker-side group membership is not used,
+// but consumed offsets are committed to the consumer group configured in the source definition.
+//
+// Records are dispatched through the sink router, an offset is committed only after
+// the record has been processed by all sinks, see pipeline.RecordProcessed.
+// A record only accepted by a sink, see pipeline.RecordAccepted, is committed after the Config.AcceptedCommitDelay,
+// which is longer than the maximum sync interval, so the in-memory buffer of the sink has been synced.
+// Consuming of the partition is not blocked meanwhile.
+// Records of one poll are dispatched in parallel, so they wait for the sync of the sink storage together.
+// A record which cannot be mapped by a sink is not retried, it is stored to the dead-letter storage of the sink, if enabled, or counted as dropped.
+// Only failed sinks are retried. After Config.MaxRetries the record is stored
+// to the dead-letter storage of the sink, if enabled, and skipped, so one broken sink cannot block the partition forever.
+package kafkasource
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	etcd "go.etcd.io/etcd/client/v3"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distribution"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	definitionRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/repository"
+	sinkRouter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/router"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	distributionGroup = "source.kafka"
+)
+
+type dependencies interface {
+	Clock() clockwork.Clock
+	Logger() log.Logger
+	Process() *servicectx.Process
+	DefinitionRepository() *definitionRepo.Repository
+	DistributionNode() *distribution.Node
+	SinkRouter() *sinkRouter.Router
+	Telemetry() telemetry.Telemetry
+	WatchTelemetryInterval() time.Duration
+}
+
+type node struct {
+	config       Config
+	clock        clockwork.Clock
+	logger       log.Logger
+	router       *sinkRouter.Router
+	distribution *distribution.GroupNode
+
+	// sources field contains in-memory snapshot of all Kafka sources. Only necessary data is saved.
+	sources *etcdop.MirrorMap[definition.Source, key.SourceKey, *sourceData]
+
+	// trigger channel requests a reconciliation of consumers
+	trigger chan struct{}
+
+	lock      sync.Mutex
+	consumers map[key.SourceKey]*consumer
+}
+
+type sourceData struct {
+	SourceKey key.SourceKey
+	Enabled   bool
+	Kafka     definition.KafkaSource
+}
+
+func Start(ctx context.Context, d dependencies, cfg Config) error {
+	var err error
+	n := &node{
+		config:    cfg,
+		clock:     d.Clock(),
+		logger:    d.Logger().WithComponent("kafka-source"),
+		router:    d.SinkRouter(),
+		trigger:   make(chan struct{}, 1),
+		consumers: make(map[key.SourceKey]*consumer),
+	}
+
+	n.logger.Info(ctx, "starting Kafka source node")
+
+	// Join the distribution group
+	n.distribution, err = d.DistributionNode().Group(distributionGroup)
+	if err != nil {
+		return err
+	}
+
+	// Graceful shutdown
+	wg := &sync.WaitGroup{}
+	bgCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	d.Process().OnShutdown(func(ctx context.Context) {
+		n.logger.Info(ctx, "shutting down Kafka source")
+
+		// Stop mirroring and reconciliation
+		cancel(errors.New("shutting down: Kafka source"))
+		wg.Wait()
+
+		// Stop all consumers, in-flight records are finished, and their offsets committed
+		n.stopAllConsumers(ctx)
+
+		n.logger.Info(ctx, "Kafka source shutdown done")
+	})
+
+	// Mirror Kafka sources
+	{
+		n.sources = etcdop.SetupMirrorMap[definition.Source, key.SourceKey, *sourceData](
+			d.DefinitionRepository().Source().GetAllAndWatch(bgCtx, etcd.WithPrevKV()),
+			func(_ string, source definition.Source) key.SourceKey {
+				return source.SourceKey
+			},
+			func(_ string, source definition.Source, rawValue *op.KeyValue, oldValue **sourceData) *sourceData {
+				return &sourceData{
+					SourceKey: source.SourceKey,
+					Enabled:   source.IsEnabled(),
+					Kafka:     *source.Kafka,
+				}
+			},
+		).
+			WithFilter(func(event etcdop.WatchEvent[definition.Source]) bool {
+				return event.Value.Type == definition.SourceTypeKafka && event.Value.Kafka != nil
+			}).
+			WithOnUpdate(func(_ etcdop.MirrorUpdate) {
+				n.triggerReconcile()
+			}).
+			BuildMirror()
+		if err = <-n.sources.StartMirroring(bgCtx, wg, n.logger, d.Telemetry(), d.WatchTelemetryInterval()); err != nil {
+			return err
+		}
+	}
+
+	// Reassign partitions on distribution change
+	{
+		wg.Add(1)
+		listener := n.distribution.OnChangeListener()
+
+		go func() {
+			defer wg.Done()
+			defer listener.Stop()
+
+			for {
+				select {
+				case <-bgCtx.Done():
+					return
+				case events := <-listener.C:
+					n.logger.Infof(bgCtx, "distribution changed: %s", events.Messages())
+					n.triggerReconcile()
+				}
+			}
+		}()
+	}
+
+	// Reconcile consumers on change and periodically, the count of topic partitions may change
+	{
+		wg.Add(1)
+		ticker := n.clock.NewTicker(n.config.ReconcileInterval)
+
+		go func() {
+			defer wg.Done()
+			defer ticker.Stop()
+
+			n.reconcile(bgCtx)
+			for {
+				select {
+				case <-bgCtx.Done():
+					return
+				case <-ticker.Chan():
+					n.reconcile(bgCtx)
+				case <-n.trigger:
+					n.reconcile(bgCtx)
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (n *node) triggerReconcile() {
+	select {
+	case n.trigger <- struct{}{}:
+	default:
+		// reconciliation is already requested
+	}
+}
+
+// reconcile starts/stops consumers, and updates partitions assigned to the consumers.
+// The lock protects only the consumers map, network calls are made without the lock.
+func (n *node) reconcile(ctx context.Context) {
+	assigner := n.distribution.CloneAssigner()
+	sources := n.sources.CloneMap()
+
+	type activeConsumer struct {
+		consumer *consumer
+		source   *sourceData
+	}
+
+	var stopped []*consumer
+	var active []activeConsumer
+	n.lock.Lock()
+
+	// Stop consumers of deleted, disabled and modified sources
+	for sourceKey, c := range n.consumers {
+		if source, found := sources[sourceKey]; !found || !source.Enabled || !c.sameDefinition(source.Kafka) {
+			stopped = append(stopped, c)
+			delete(n.consumers, sourceKey)
+		}
+	}
+
+	// Start consumers
+	for sourceKey, source := range sources {
+		if !source.Enabled {
+			continue
+		}
+
+		c, found := n.consumers[sourceKey]
+		if !found {
+			var err error
+			c, err = newConsumer(n, source)
+			if err != nil {
+				n.logger.Errorf(ctx, `cannot create Kafka consumer for the source "%s": %s`, sourceKey, err)
+				continue
+			}
+			n.consumers[sourceKey] = c
+		}
+
+		active = append(active, activeConsumer{consumer: c, source: source})
+	}
+
+	n.lock.Unlock()
+
+	for _, c := range stopped {
+		c.stop(ctx)
+	}
+
+	for _, item := range active {
+		c, sourceKey := item.consumer, item.source.SourceKey
+
+		// List partitions of the topic
+		partitions, err := c.listPartitions(ctx)
+		if err != nil {
+			n.logger.Errorf(ctx, `cannot list partitions of the Kafka topic "%s", source "%s": %s`, item.source.Kafka.Topic, sourceKey, err)
+			continue
+		}
+
+		// Assign owned partitions
+		var owned []int32
+		for _, partition := range partitions {
+			if ok, err := assigner.IsOwner(distributionKey(sourceKey, partition)); err != nil {
+				n.logger.Errorf(ctx, `cannot check the Kafka partition owner: %s`, err)
+			} else if ok {
+				owned = append(owned, partition)
+			}
+		}
+		c.assign(owned)
+	}
+}
+
+func (n *node) stopAllConsumers(ctx context.Context) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	wg := &sync.WaitGroup{}
+	for sourceKey, c := range n.consumers {
+		wg.Go(func() {
+			c.stop(ctx)
+		})
+		delete(n.consumers, sourceKey)
+	}
+	wg.Wait()
+}
+
+func distributionKey(sourceKey key.SourceKey, partition int32) string {
+	return sourceKey.String() + "/" + strconv.FormatInt(int64(partition), 10)
+}
@@ -0,0 +1,274 @@
+package kafkasource_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/source/type/kafkasource"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	testTopic      = "my-topic"
+	testPartitions = 3
+	testRecords    = 10
+)
+
+func TestKafkaSource(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 60*time.Second)
+	defer cancel()
+
+	// In-process Kafka-protocol compatible cluster
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(testPartitions, testTopic))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	d, mock := dependencies.NewMockedSourceScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Source.Kafka.ReconcileInterval = time.Second
+		cfg.Source.Kafka.RetryInitialBackoff = 10 * time.Millisecond
+		cfg.Source.Kafka.RetryMaxBackoff = 100 * time.Millisecond
+	})
+
+	// The first writes to the failing sink fail, the records must be retried, and the offsets must not be skipped.
+	// The dummy sink processes records, see pipeline.RecordProcessed, so offsets are committed without a delay.
+	var failures atomic.Int32
+	failures.Store(3)
+	sinkController := mock.TestDummySinkController()
+	sinkController.PipelineWriteRecordStatus = pipeline.RecordProcessed
+	okSinkKey, failingSinkKey := createSource(t, ctx, d, cluster)
+	sinkController.PipelineWriteHook = func(sinkKey key.SinkKey) error {
+		if sinkKey == failingSinkKey && failures.Add(-1) >= 0 {
+			return errors.New("some write error")
+		}
+		return nil
+	}
+
+	// Start the source
+	require.NoError(t, kafkasource.Start(ctx, d, mock.TestConfig().Source.Kafka))
+	produceRecords(t, ctx, cluster)
+
+	// All records have been written, only the failed writes have been retried
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, testRecords+3, sinkController.WriteCount(failingSinkKey))
+	}, 30*time.Second, 50*time.Millisecond)
+	assert.Equal(t, testRecords, sinkController.WriteCount(okSinkKey))
+
+	// Offsets of all records have been committed
+	assertCommittedOffsets(t, ctx, cluster)
+
+	// Shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+
+	mock.DebugLogger().AssertJSONMessages(t, `
+{"level":"info","message":"starting Kafka source node","component":"kafka-source"}
+{"level":"info","message":"started Kafka consumer, topic \"my-topic\", group \"my-group\"","component":"kafka-source"}
+{"level":"info","message":"added Kafka partitions [0 1 2]","component":"kafka-source"}
+{"level":"error","message":"write record error: some write error","component":"sink.router"}
+{"level":"error","message":"source record processing failed: 1/2 sinks failed. Failed sinks: sink failing-sink: Some write error.","component":"sink.router"}
+{"level":"warn","message":"Kafka record partition \"%d\", offset \"%d\" has not been written to sinks [failing-sink], retry 1/20 in %s: Written to 1/2 sinks.","component":"kafka-source"}
+{"level":"error","message":"write record error: some write error","component":"sink.router"}
+{"level":"error","message":"source record processing failed: 1/1 sinks failed. Failed sinks: sink failing-sink: Some write error.","component":"sink.router"}
+{"level":"warn","message":"Kafka record partition \"%d\", offset \"%d\" has not been written to sinks [failing-sink], retry 2/20 in %s: Written to 0/1 sinks.","component":"kafka-source"}
+{"level":"error","message":"write record error: some write error","component":"sink.router"}
+{"level":"error","message":"source record processing failed: 1/1 sinks failed. Failed sinks: sink failing-sink: Some write error.","component":"sink.router"}
+{"level":"warn","message":"Kafka record partition \"%d\", offset \"%d\" has not been written to sinks [failing-sink], retry 3/20 in %s: Written to 0/1 sinks.","component":"kafka-source"}
+{"level":"info","message":"stopped Kafka consumer","component":"kafka-source"}
+{"level":"info","message":"Kafka source shutdown done","component":"kafka-source"}
+`)
+}
+
+func TestKafkaSource_MaxRetries(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 60*time.Second)
+	defer cancel()
+
+	// In-process Kafka-protocol compatible cluster
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(testPartitions, testTopic))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	d, mock := dependencies.NewMockedSourceScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Source.Kafka.ReconcileInterval = time.Second
+		cfg.Source.Kafka.RetryInitialBackoff = time.Millisecond
+		cfg.Source.Kafka.RetryMaxBackoff = 5 * time.Millisecond
+		cfg.Source.Kafka.MaxRetries = 2
+	})
+
+	// Writes to the failing sink always fail, the records are stored to the dead-letter storage after the max retries.
+	// The dummy sink processes records, see pipeline.RecordProcessed, so offsets are committed without a delay.
+	sinkController := mock.TestDummySinkController()
+	sinkController.PipelineWriteRecordStatus = pipeline.RecordProcessed
+	okSinkKey, failingSinkKey := createSource(t, ctx, d, cluster)
+	sinkController.PipelineWriteHook = func(sinkKey key.SinkKey) error {
+		if sinkKey == failingSinkKey {
+			return errors.New("some write error")
+		}
+		return nil
+	}
+
+	// Start the source
+	require.NoError(t, kafkasource.Start(ctx, d, mock.TestConfig().Source.Kafka))
+	produceRecords(t, ctx, cluster)
+
+	// Each record has been written to the failing sink 1+2 times, the partitions are not blocked
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, testRecords*3, sinkController.WriteCount(failingSinkKey))
+	}, 30*time.Second, 50*time.Millisecond)
+	assert.Equal(t, testRecords, sinkController.WriteCount(okSinkKey))
+
+	// All records have been stored to the dead-letter storage of the failing sink
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		records, err := d.DeadLetterRepository().List(failingSinkKey).Do(ctx).All()
+		if assert.NoError(c, err) && assert.Len(c, records, testRecords) {
+			assert.JSONEq(c, `{"foo":"bar"}`, string(records[0].Body))
+			assert.Equal(c, testTopic, records[0].Headers[kafkasource.HeaderTopic])
+			assert.Contains(c, records[0].Error, "some write error")
+		}
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// Offsets of all records have been committed
+	assertCommittedOffsets(t, ctx, cluster)
+
+	// Shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+
+	// Retries of records from different partitions are interleaved, so only counts of the messages are checked
+	warnings := mock.DebugLogger().WarnMessages()
+	assert.Equal(t, testRecords, strings.Count(warnings, `has not been written to sinks [failing-sink], retry 1/2`))
+	assert.Equal(t, testRecords, strings.Count(warnings, `has not been written to sinks [failing-sink], retry 2/2`))
+	assert.Equal(t, testRecords, strings.Count(warnings, `has not been written to the sink \"failing-sink\" after 2 retries, it has been stored to the dead-letter storage: some write error`))
+}
+
+func TestKafkaSource_AcceptedRecords(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 60*time.Second)
+	defer cancel()
+
+	// In-process Kafka-protocol compatible cluster
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(testPartitions, testTopic))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	d, mock := dependencies.NewMockedSourceScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Source.Kafka.ReconcileInterval = time.Second
+		cfg.Source.Kafka.AcceptedCommitDelay = 5 * time.Minute
+	})
+
+	// The dummy sink only accepts records, see pipeline.RecordAccepted, records are not synced yet
+	sinkController := mock.TestDummySinkController()
+	okSinkKey, failingSinkKey := createSource(t, ctx, d, cluster)
+
+	// Start the source
+	require.NoError(t, kafkasource.Start(ctx, d, mock.TestConfig().Source.Kafka))
+	produceRecords(t, ctx, cluster)
+
+	// All records have been written
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, testRecords, sinkController.WriteCount(okSinkKey))
+		assert.Equal(c, testRecords, sinkController.WriteCount(failingSinkKey))
+	}, 30*time.Second, 50*time.Millisecond)
+
+	// Shutdown before the commit delay, the accepted records may not have been synced, so no offset has been committed
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+	assert.Equal(t, int64(0), committedOffsets(t, ctx, cluster))
+}
+
+// createSource creates a Kafka source with two sinks, the failing sink has the enabled dead-letter storage.
+func createSource(t *testing.T, ctx context.Context, d dependencies.SourceScope, cluster *kfake.Cluster) (okSinkKey, failingSinkKey key.SinkKey) {
+	t.Helper()
+
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 456}
+	branch := test.NewBranch(branchKey)
+	source := test.NewKafkaSource(key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}, cluster.ListenAddrs(), testTopic)
+	okSink := dummy.NewSink(key.SinkKey{SourceKey: source.SourceKey, SinkID: "ok-sink"})
+	failingSink := dummy.NewSink(key.SinkKey{SourceKey: source.SourceKey, SinkID: "failing-sink"})
+	failingSink.DeadLetter = true
+	now := d.Clock().Now()
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, now, test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&okSink, now, test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&failingSink, now, test.ByUser(), "create").Do(ctx).Err())
+
+	// Wait for the sink router
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.Equal(c, 1, d.SinkRouter().SourcesCount())
+	}, 10*time.Second, 10*time.Millisecond)
+
+	return okSink.SinkKey, failingSink.SinkKey
+}
+
+func produceRecords(t *testing.T, ctx context.Context, cluster *kfake.Cluster) {
+	t.Helper()
+
+	producer, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...), kgo.DefaultProduceTopic(testTopic))
+	require.NoError(t, err)
+	defer producer.Close()
+	for i := range testRecords {
+		record := &kgo.Record{Key: []byte("key" + strconv.Itoa(i)), Value: []byte(`{"foo":"bar"}`)}
+		require.NoError(t, producer.ProduceSync(ctx, record).FirstErr())
+	}
+}
+
+func assertCommittedOffsets(t *testing.T, ctx context.Context, cluster *kfake.Cluster) {
+	t.Helper()
+
+	client, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...))
+	require.NoError(t, err)
+	defer client.Close()
+
+	admin := kadm.NewClient(client)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		offsets, err := admin.FetchOffsetsForTopics(ctx, "my-group", testTopic)
+		if assert.NoError(c, err) {
+			assert.Equal(c, int64(testRecords), sumOffsets(offsets))
+		}
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func committedOffsets(t *testing.T, ctx context.Context, cluster *kfake.Cluster) int64 {
+	t.Helper()
+
+	client, err := kgo.NewClient(kgo.SeedBrokers(cluster.ListenAddrs()...))
+	require.NoError(t, err)
+	defer client.Close()
+
+	offsets, err := kadm.NewClient(client).FetchOffsetsForTopics(ctx, "my-group", testTopic)
+	if errors.Is(err, kerr.GroupIDNotFound) {
+		return 0
+	}
+	require.NoError(t, err)
+	return sumOffsets(offsets)
+}
+
+func sumOffsets(offsets kadm.OffsetResponses) (committed int64) {
+	offsets.Each(func(o kadm.OffsetResponse) {
+		if o.At > 0 {
+			committed += o.At
+		}
+	})
+	return committed
+}
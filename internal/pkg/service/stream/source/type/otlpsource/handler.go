@@ -133,12 +133,19 @@ func (h *Handler) handle(c *routing.Context, signal string, decode signalDecoder
 		return nil
 	}
 
+	// The whole batch is accepted or rejected by rate limits, 429 tells the OTLP client to retry later.
+	now := h.clock.Now()
+	if err := h.dispatcher.CheckRateLimit(now, projectID, sourceID, secret, definition.SourceTypeOTLP, len(records), len(body)); err != nil {
+		h.errorHandler(c.RequestCtx, err)
+		return nil //nolint:nilerr
+	}
+
 	ctx := telemetry.ContextWithDisabledTracing(h.ctx)
 	headers := headersToOrderedMap(c.RequestCtx)
 	result := DispatchRecords(
 		ctx,
 		h.dispatcher,
-		h.clock.Now(),
+		now,
 		c.RemoteIP(),
 		headers,
 		projectID,
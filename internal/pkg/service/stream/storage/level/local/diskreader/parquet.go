@@ -0,0 +1,174 @@
+package diskreader
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	compressionReader "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/reader"
+	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
+	parquetEncoder "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/parquet"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// mergeParquetFilesAndWrite merges row groups of all slice files, written by different source nodes, to one Parquet file.
+// The result is compressed with the local compression, so the rest of the reader chain is the same as for other file types.
+func mergeParquetFilesAndWrite(
+	ctx context.Context,
+	logger log.Logger,
+	localCompression compression.Config,
+	opener FileOpener,
+	filePaths []string,
+	writer *io.PipeWriter,
+) {
+	if err := mergeParquetFiles(ctx, logger, localCompression, opener, filePaths, writer); err != nil {
+		logger.Errorf(ctx, `cannot merge parquet files: %s`, err)
+		closeWithError(logger, ctx, writer, err)
+	}
+}
+
+func mergeParquetFiles(
+	ctx context.Context,
+	logger log.Logger,
+	localCompression compression.Config,
+	opener FileOpener,
+	filePaths []string,
+	writer io.Writer,
+) error {
+	// Compress the merged file back with the local compression
+	var out io.WriteCloser
+	if localCompression.Type == compression.TypeNone {
+		out = nopWriteCloser{Writer: writer}
+	} else {
+		w, err := compressionWriter.New(writer, localCompression)
+		if err != nil {
+			return errors.PrefixError(err, `cannot create compression writer`)
+		}
+		out = w
+	}
+
+	merger := parquetEncoder.NewMerger(out)
+	for _, filePath := range filePaths {
+		if err := mergeParquetFile(ctx, logger, localCompression, opener, filePath, merger); err != nil {
+			return err
+		}
+	}
+
+	if err := merger.Close(); err != nil {
+		return err
+	}
+
+	return out.Close()
+}
+
+// mergeParquetFile appends row groups of the file to the merged file.
+func mergeParquetFile(
+	ctx context.Context,
+	logger log.Logger,
+	localCompression compression.Config,
+	opener FileOpener,
+	filePath string,
+	merger *parquetEncoder.Merger,
+) (err error) {
+	reader, size, filePath, closeFn, err := openParquetReader(ctx, logger, localCompression, opener, filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := closeFn(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	// An empty file is skipped, the source node did not write anything
+	if err := merger.Append(reader, size); err != nil {
+		return errors.PrefixErrorf(err, `cannot merge parquet file "%s"`, filePath)
+	}
+
+	return nil
+}
+
+// openParquetReader opens the file for a random access, the Parquet footer is at the end of the file.
+// An uncompressed file is read directly.
+// A compressed file is decompressed to a temporary file, so the memory usage does not depend on the file size.
+func openParquetReader(
+	ctx context.Context,
+	logger log.Logger,
+	localCompression compression.Config,
+	opener FileOpener,
+	filePath string,
+) (reader io.ReaderAt, size int64, actualPath string, closeFn func() error, err error) {
+	file, filePath, err := openFile(ctx, logger, localCompression, opener, filePath)
+	if err != nil {
+		return nil, 0, "", nil, err
+	}
+
+	logger.With(attribute.String("file.path", filePath)).Debug(ctx, "opened file")
+
+	if readerAt, ok := file.(io.ReaderAt); ok && localCompression.Type == compression.TypeNone {
+		size, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			_ = file.Close()
+			return nil, 0, "", nil, errors.PrefixErrorf(err, `cannot seek to the end of file "%s"`, filePath)
+		}
+		return readerAt, size, filePath, file.Close, nil
+	}
+
+	tmp, size, err := decompressToTempFile(localCompression, file, filePath)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		if tmp != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+		return nil, 0, "", nil, err
+	}
+
+	closeFn = func() error {
+		closeErr := tmp.Close()
+		if err := os.Remove(tmp.Name()); err != nil {
+			return err
+		}
+		return closeErr
+	}
+
+	return tmp, size, filePath, closeFn, nil
+}
+
+func decompressToTempFile(localCompression compression.Config, file File, filePath string) (*os.File, int64, error) {
+	var r io.Reader = file
+	if localCompression.Type != compression.TypeNone {
+		decompressed, err := compressionReader.New(file, localCompression)
+		if err != nil {
+			return nil, 0, errors.PrefixErrorf(err, `cannot create compression reader for file "%s"`, filePath)
+		}
+		defer decompressed.Close()
+		r = decompressed
+	}
+
+	tmp, err := os.CreateTemp("", "stream-slice-*.parquet")
+	if err != nil {
+		return nil, 0, errors.PrefixError(err, `cannot create temporary file`)
+	}
+
+	size, err := io.Copy(tmp, r)
+	if err != nil {
+		return tmp, 0, errors.PrefixErrorf(err, `cannot decompress file "%s"`, filePath)
+	}
+
+	return tmp, size, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
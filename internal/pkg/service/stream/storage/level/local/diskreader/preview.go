@@ -0,0 +1,129 @@
+package diskreader
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskreader/readchain"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	compressionReader "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/reader"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// PreviewSlice reads the last records from the slice files, it is used to check the mapping output.
+//
+// Unlike the Volume.OpenReader method, it can read files of an active slice:
+//   - Hidden files of active writers are read, but never moved.
+//   - The data is always decompressed.
+//   - An incomplete record or compressed block at the end of a file, which is still being written, is ignored.
+//
+// Files from multiple source nodes are read one by one, so the records are ordered within a file only.
+func PreviewSlice(
+	ctx context.Context,
+	logger log.Logger,
+	opener FileOpener,
+	volumePath string,
+	slice localModel.Slice,
+	encoderType encoder.Type,
+	localCompression compression.Config,
+	limit int,
+) ([][]string, error) {
+	if encoderType != encoder.TypeCSV {
+		return nil, errors.Errorf(`preview is not supported for the "%s" encoder`, encoderType)
+	}
+
+	// Find visible and hidden files
+	var paths []string
+	for _, pattern := range []string{slice.FileGlob(volumePath), slice.FileGlobWithBackup(volumePath)} {
+		matched, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matched...)
+	}
+
+	// Sort files by the source node, regardless of the hidden file prefix
+	slices.SortFunc(paths, func(a, b string) int {
+		return strings.Compare(strings.TrimPrefix(filepath.Base(a), "."), strings.TrimPrefix(filepath.Base(b), "."))
+	})
+
+	// Keep the last records
+	var records [][]string
+	for _, filePath := range paths {
+		err := previewFile(ctx, logger, opener, filePath, localCompression, func(record []string) {
+			if len(records) == limit {
+				records = records[1:]
+			}
+			records = append(records, record)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+func previewFile(
+	ctx context.Context,
+	logger log.Logger,
+	opener FileOpener,
+	filePath string,
+	localCompression compression.Config,
+	onRecord func(record []string),
+) error {
+	file, err := opener.OpenFile(filePath)
+	if err != nil {
+		return errors.PrefixErrorf(err, `cannot open file "%s"`, filePath)
+	}
+
+	chain := readchain.New(logger, file)
+	defer func() {
+		// The file is only read, a close error of a truncated compressed stream is expected
+		if err := chain.Close(ctx); err != nil {
+			logger.Debugf(ctx, `cannot close preview of file "%s": %s`, filePath, err)
+		}
+	}()
+
+	// Decompress the file stream on-the-fly.
+	// The standard GZIP implementation returns the decompressed data before an error of a truncated block,
+	// the parallel implementation decompresses whole blocks in advance, so the data would be lost.
+	if localCompression.Type == compression.TypeGZIP {
+		gzipCfg := *localCompression.GZIP
+		gzipCfg.Implementation = compression.GZIPImplStandard
+		localCompression.GZIP = &gzipCfg
+	}
+	if localCompression.Type != compression.TypeNone {
+		_, err := chain.PrependReaderOrErr(func(r io.Reader) (io.Reader, error) {
+			return compressionReader.New(r, localCompression)
+		})
+		if errors.Is(err, io.EOF) {
+			// The file is empty, nothing has been written yet
+			return nil
+		} else if err != nil {
+			return errors.PrefixErrorf(err, `cannot create compression reader for file "%s"`, filePath)
+		}
+	}
+
+	// All records have the same number of fields, a truncated record at the end of the file is detected
+	csvReader := csv.NewReader(chain)
+	csvReader.FieldsPerRecord = 0
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			// The file is still being written, the rest of the file is skipped
+			logger.Debugf(ctx, `preview of file "%s" stopped: %s`, filePath, err)
+			return nil
+		}
+		onRecord(record)
+	}
+}
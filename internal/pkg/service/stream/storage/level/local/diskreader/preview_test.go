@@ -0,0 +1,62 @@
+package diskreader_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskreader"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
+)
+
+func TestPreviewSlice(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	volumePath := t.TempDir()
+	slice := localModel.Slice{Dir: "my-slice", FilenamePrefix: "slice", FilenameExtension: "csv.gz"}
+	require.NoError(t, os.MkdirAll(slice.DirName(volumePath), 0o750))
+
+	compress := func(data string) []byte {
+		var buf bytes.Buffer
+		w, err := compressionWriter.New(&buf, compression.NewGZIPConfig())
+		require.NoError(t, err)
+		_, err = w.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	// Closed file of the first source node
+	file1 := compress("\"1\",\"foo\"\n\"2\",\"bar\"\n\"3\",\"baz\"\n")
+	require.NoError(t, os.WriteFile(slice.FileName(volumePath, "source-node-1"), file1, 0o640))
+
+	// Hidden file of the second source node, the last compressed block is still being written
+	file2 := compress("\"4\",\"multi\nline\"\n\"5\",\"abc\"\n")
+	incomplete := compress("\"6\",\"def\"\n")
+	file2 = append(file2, incomplete[:len(incomplete)/2]...)
+	require.NoError(t, os.WriteFile(slice.FileNameWithBackup(volumePath, "source-node-2"), file2, 0o640))
+
+	// Empty file of the third source node, nothing has been written yet
+	require.NoError(t, os.WriteFile(slice.FileName(volumePath, "source-node-3"), nil, 0o640))
+
+	records, err := diskreader.PreviewSlice(ctx, log.NewNopLogger(), diskreader.DefaultFileOpener{}, volumePath, slice, encoder.TypeCSV, compression.NewGZIPConfig(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"3", "baz"}, {"4", "multi\nline"}, {"5", "abc"}}, records)
+
+	// Hidden file is not moved
+	assert.FileExists(t, slice.FileNameWithBackup(volumePath, "source-node-2"))
+
+	// Parquet is not supported
+	_, err = diskreader.PreviewSlice(ctx, log.NewNopLogger(), diskreader.DefaultFileOpener{}, volumePath, slice, encoder.TypeParquet, compression.NewGZIPConfig(), 3)
+	if assert.Error(t, err) {
+		assert.Equal(t, `preview is not supported for the "parquet" encoder`, err.Error())
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
 	compressionReader "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/reader"
 	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/events"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
@@ -57,6 +58,7 @@ func newReader(
 	sliceKey model.SliceKey,
 	opener FileOpener,
 	path string,
+	encoderType encoder.Type,
 	localCompression compression.Config,
 	targetCompression compression.Config,
 	readerEvents *events.Events[Reader],
@@ -84,8 +86,14 @@ func newReader(
 
 	reader, writer := io.Pipe()
 	go func() {
-		for _, filePath := range matched {
-			openFileAndWrite(ctx, r.logger, localCompression, opener, filePath, writer)
+		if encoderType == encoder.TypeParquet {
+			// Parquet files cannot be concatenated, row groups of all files must be merged to a new file.
+			// A single file may also contain multiple parts, each sync and each writer session writes a new part.
+			mergeParquetFilesAndWrite(ctx, r.logger, localCompression, opener, matched, writer)
+		} else {
+			for _, filePath := range matched {
+				openFileAndWrite(ctx, r.logger, localCompression, opener, filePath, writer)
+			}
 		}
 
 		writer.Close()
@@ -233,27 +241,48 @@ func openFileAndWrite(
 	filePath string,
 	writer *io.PipeWriter,
 ) {
-	file, err := opener.OpenFile(filePath)
+	file, filePath, err := openFile(ctx, logger, localCompression, opener, filePath)
+	if err != nil {
+		closeWithError(logger, ctx, writer, err)
+		return
+	}
 	defer func() {
-		if file != nil {
-			err = file.Close()
-			if err != nil {
-				writer.CloseWithError(err)
-			}
+		err = file.Close()
+		if err != nil {
+			writer.CloseWithError(err)
 		}
 	}()
+
+	logger = logger.With(attribute.String("file.path", filePath))
+	logger.Debug(ctx, "opened file")
+	_, err = io.Copy(writer, file)
 	if err != nil {
-		logger.Errorf(ctx, `cannot open file "%s": %s`, filePath, err)
+		logger.Errorf(ctx, `cannot copy to writer "%s": %s`, filePath, err)
 		closeWithError(logger, ctx, writer, err)
-		return
+	}
+}
+
+// openFile opens the file for reading, a hidden file is made visible at first, see processHiddenFile.
+// Returns the opened file and its actual path.
+func openFile(
+	ctx context.Context,
+	logger log.Logger,
+	localCompression compression.Config,
+	opener FileOpener,
+	filePath string,
+) (File, string, error) {
+	file, err := opener.OpenFile(filePath)
+	if err != nil {
+		logger.Errorf(ctx, `cannot open file "%s": %s`, filePath, err)
+		return nil, "", err
 	}
 
 	// Check if the file is hidden (has "." prefix)
 	if strings.HasPrefix(path.Base(filePath), ".") {
 		visiblePath, err := processHiddenFile(ctx, logger, localCompression, file, filePath)
 		if err != nil {
-			closeWithError(logger, ctx, writer, err)
-			return
+			_ = file.Close()
+			return nil, "", err
 		}
 
 		// Reopen file with new path
@@ -261,21 +290,14 @@ func openFileAndWrite(
 		newFile, err := opener.OpenFile(visiblePath)
 		if err != nil {
 			logger.Errorf(ctx, `cannot open file "%s": %s`, visiblePath, err)
-			closeWithError(logger, ctx, writer, err)
-			return
+			return nil, "", err
 		}
 
 		file = newFile
 		filePath = visiblePath
 	}
 
-	logger = logger.With(attribute.String("file.path", filePath))
-	logger.Debug(ctx, "opened file")
-	_, err = io.Copy(writer, file)
-	if err != nil {
-		logger.Errorf(ctx, `cannot copy to writer "%s": %s`, filePath, err)
-		closeWithError(logger, ctx, writer, err)
-	}
+	return file, filePath, nil
 }
 
 // processHiddenFile handles all the processing steps for a hidden file:
@@ -12,6 +12,7 @@ import (
 
 	"github.com/keboola/go-utils/pkg/wildcards"
 	"github.com/klauspost/compress/gzip"
+	"github.com/parquet-go/parquet-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
 	compressionReader "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/reader"
 	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
 	volumeModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
@@ -124,6 +126,129 @@ func TestVolume_NewReaderFor_MultipleFilesSingleVolume(t *testing.T) {
 	}, 5*time.Second, 10*time.Millisecond)
 }
 
+// TestVolume_NewReaderFor_MultipleParquetFiles tests that Parquet files from multiple source nodes are merged to one file.
+func TestVolume_NewReaderFor_MultipleParquetFiles(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Datetime string `parquet:"datetime"`
+		Body     string `parquet:"body"`
+	}
+
+	// Create Parquet file content
+	var fileContent bytes.Buffer
+	require.NoError(t, parquet.Write(&fileContent, []row{
+		{Datetime: "2000-01-01T01:00:00.000Z", Body: "foo"},
+		{Datetime: "2000-01-01T02:00:00.000Z", Body: "bar"},
+	}))
+
+	tc := newReaderTestCase(t)
+	tc.Slice.Encoding.Encoder.Type = encoder.TypeParquet
+	tc.Slice.LocalStorage.FilenameExtension = "parquet"
+	tc.Files = []string{"my-node1", "my-node2"}
+	tc.SliceData = fileContent.Bytes()
+
+	r, err := tc.NewReader(false)
+	require.NoError(t, err)
+
+	// Read all
+	var buf bytes.Buffer
+	_, err = r.WriteTo(&buf)
+	require.NoError(t, err)
+	require.NoError(t, r.Close(t.Context()))
+
+	// Rows from both files are in the merged file
+	rows, err := parquet.Read[row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, row{Datetime: "2000-01-01T02:00:00.000Z", Body: "bar"}, rows[3])
+}
+
+// TestVolume_NewReaderFor_MultipleParquetParts tests that Parquet parts appended to one file, for example by a restarted writer, are merged to one file.
+func TestVolume_NewReaderFor_MultipleParquetParts(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Datetime string `parquet:"datetime"`
+		Body     string `parquet:"body"`
+	}
+
+	// Create Parquet file content, the second part is appended after the footer of the first part
+	var fileContent bytes.Buffer
+	require.NoError(t, parquet.Write(&fileContent, []row{
+		{Datetime: "2000-01-01T01:00:00.000Z", Body: "foo"},
+	}))
+	require.NoError(t, parquet.Write(&fileContent, []row{
+		{Datetime: "2000-01-01T02:00:00.000Z", Body: "bar"},
+	}))
+
+	tc := newReaderTestCase(t)
+	tc.Slice.Encoding.Encoder.Type = encoder.TypeParquet
+	tc.Slice.LocalStorage.FilenameExtension = "parquet"
+	tc.Files = []string{"my-node"}
+	tc.SliceData = fileContent.Bytes()
+
+	r, err := tc.NewReader(false)
+	require.NoError(t, err)
+
+	// Read all
+	var buf bytes.Buffer
+	_, err = r.WriteTo(&buf)
+	require.NoError(t, err)
+	require.NoError(t, r.Close(t.Context()))
+
+	// Rows from both parts are in the merged file
+	rows, err := parquet.Read[row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, []row{
+		{Datetime: "2000-01-01T01:00:00.000Z", Body: "foo"},
+		{Datetime: "2000-01-01T02:00:00.000Z", Body: "bar"},
+	}, rows)
+}
+
+// TestVolume_NewReaderFor_MultipleParquetFiles_GZIP tests that compressed Parquet files are decompressed before the merge.
+func TestVolume_NewReaderFor_MultipleParquetFiles_GZIP(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		Datetime string `parquet:"datetime"`
+		Body     string `parquet:"body"`
+	}
+
+	// Create compressed Parquet file content
+	var fileContent bytes.Buffer
+	gzipWriter := gzip.NewWriter(&fileContent)
+	require.NoError(t, parquet.Write(gzipWriter, []row{
+		{Datetime: "2000-01-01T01:00:00.000Z", Body: "foo"},
+		{Datetime: "2000-01-01T02:00:00.000Z", Body: "bar"},
+	}))
+	require.NoError(t, gzipWriter.Close())
+
+	tc := newReaderTestCase(t)
+	tc.Slice.Encoding.Encoder.Type = encoder.TypeParquet
+	tc.Slice.Encoding.Compression = compression.NewGZIPConfig()
+	tc.Slice.StagingStorage.Compression = compression.NewNoneConfig()
+	tc.Slice.LocalStorage.FilenameExtension = "parquet.gz"
+	tc.WithBackup = true
+	tc.Files = []string{"my-node1", "my-node2"}
+	tc.SliceData = fileContent.Bytes()
+
+	r, err := tc.NewReader(false)
+	require.NoError(t, err)
+
+	// Read all
+	var buf bytes.Buffer
+	_, err = r.WriteTo(&buf)
+	require.NoError(t, err)
+	require.NoError(t, r.Close(t.Context()))
+
+	// Rows from both files are in the merged file
+	rows, err := parquet.Read[row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Len(t, rows, 4)
+	assert.Equal(t, row{Datetime: "2000-01-01T01:00:00.000Z", Body: "foo"}, rows[0])
+}
+
 // TestVolume_NewBackupReader_NoIssue tests that a new reader works with backup reader.
 func TestVolume_NewBackupReader_NoIssue(t *testing.T) {
 	t.Parallel()
@@ -225,7 +350,6 @@ func TestVolume_NewReaderFor_Compression(t *testing.T) {
 			Name:               "None_To_ZSTD",
 			LocalCompression:   compression.NewNoneConfig(),
 			StagingCompression: compression.NewZSTDConfig(),
-			DisableValidation:  true, // zstd is not currently considered valid
 		},
 		{
 			Name:               "GZIP_To_None",
@@ -237,14 +361,12 @@ func TestVolume_NewReaderFor_Compression(t *testing.T) {
 			Name:               "ZSTD_To_None",
 			LocalCompression:   compression.NewZSTDConfig(),
 			StagingCompression: compression.NewNoneConfig(),
-			DisableValidation:  true, // zstd is not currently considered valid
 			UseBackupReader:    true,
 		},
 		{
 			Name:               "ZSTD_To_GZIP",
 			LocalCompression:   compression.NewZSTDConfig(),
 			StagingCompression: compression.NewGZIPConfig(),
-			DisableValidation:  true, // zstd is not currently considered valid
 			UseBackupReader:    true,
 		},
 		{
@@ -500,7 +622,7 @@ func (tc *readerTestCase) NewReader(disableValidation bool) (diskreader.Reader,
 		}
 	}
 
-	r, err := tc.Volume.OpenReader(tc.Slice.SliceKey, tc.Slice.LocalStorage, tc.Slice.Encoding.Compression, tc.Slice.StagingStorage.Compression)
+	r, err := tc.Volume.OpenReader(tc.Slice.SliceKey, tc.Slice.LocalStorage, tc.Slice.Encoding.Encoder.Type, tc.Slice.Encoding.Compression, tc.Slice.StagingStorage.Compression)
 	if err != nil {
 		return nil, err
 	}
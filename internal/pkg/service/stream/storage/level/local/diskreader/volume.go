@@ -15,6 +15,7 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/events"
 	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
 	volume "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
@@ -129,7 +130,7 @@ func (v *Volume) Metadata() volume.Metadata {
 	}
 }
 
-func (v *Volume) OpenReader(sliceKey model.SliceKey, slice localModel.Slice, encodingCompression, stagingCompression compression.Config) (r Reader, err error) {
+func (v *Volume) OpenReader(sliceKey model.SliceKey, slice localModel.Slice, encoderType encoder.Type, encodingCompression, stagingCompression compression.Config) (r Reader, err error) {
 	// Check context
 	if err := v.ctx.Err(); err != nil {
 		return nil, errors.PrefixErrorf(err, `reader for slice "%s" cannot be created: volume is closed`, sliceKey.String())
@@ -182,6 +183,7 @@ func (v *Volume) OpenReader(sliceKey model.SliceKey, slice localModel.Slice, enc
 		sliceKey,
 		opener,
 		path,
+		encoderType,
 		encodingCompression,
 		stagingCompression,
 		v.readerEvents,
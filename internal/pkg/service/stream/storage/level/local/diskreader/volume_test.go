@@ -256,9 +256,9 @@ func TestVolume_Close_Errors(t *testing.T) {
 	vol, err := tc.OpenVolume()
 	require.NoError(t, err)
 	// Open two writers
-	_, err = vol.OpenReader(slice1.SliceKey, slice1.LocalStorage, slice1.Encoding.Compression, slice1.StagingStorage.Compression)
+	_, err = vol.OpenReader(slice1.SliceKey, slice1.LocalStorage, slice1.Encoding.Encoder.Type, slice1.Encoding.Compression, slice1.StagingStorage.Compression)
 	require.NoError(t, err)
-	_, err = vol.OpenReader(slice2.SliceKey, slice2.LocalStorage, slice2.Encoding.Compression, slice2.StagingStorage.Compression)
+	_, err = vol.OpenReader(slice2.SliceKey, slice2.LocalStorage, slice2.Encoding.Encoder.Type, slice2.Encoding.Compression, slice2.StagingStorage.Compression)
 	require.NoError(t, err)
 
 	// Close volume, expect close errors from the writers
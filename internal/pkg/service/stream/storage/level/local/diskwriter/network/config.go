@@ -7,10 +7,11 @@ import (
 )
 
 const (
-	TransportProtocolKCP                = TransportProtocol("kcp")
-	TransportProtocolTCP                = TransportProtocol("tcp")
-	RandomBalancerType     BalancerType = "rand"
-	RoundRobinBalancerType BalancerType = "roundRobin"
+	TransportProtocolKCP                 = TransportProtocol("kcp")
+	TransportProtocolTCP                 = TransportProtocol("tcp")
+	RandomBalancerType      BalancerType = "rand"
+	RoundRobinBalancerType  BalancerType = "roundRobin"
+	LeastLoadedBalancerType BalancerType = "leastLoaded"
 )
 
 type TransportProtocol string
@@ -30,7 +31,7 @@ type Config struct {
 	ShutdownTimeout        time.Duration     `configKey:"shutdownTimeout" configUsage:"How long the server waits for streams closing." validate:"required,minDuration=1s,max=600s"`
 	KCPInputBuffer         datasize.ByteSize `configKey:"kcpInputBuffer" configUsage:"Buffer size for transferring data between source and writer nodes (kcp)." validate:"required,minBytes=16kB,maxBytes=100MB"`
 	KCPResponseBuffer      datasize.ByteSize `configKey:"kcpResponseBuffer" configUsage:"Buffer size for transferring responses between writer and source node (kcp)." validate:"required,minBytes=16kB,maxBytes=100MB"`
-	PipelineBalancer       BalancerType      `configKey:"pipelineBalancer" configUsage:"Pipeline balancer type which balances the writing on particular nodes based on selected strategy" validate:"required,oneof=rand roundRobin"`
+	PipelineBalancer       BalancerType      `configKey:"pipelineBalancer" configUsage:"Pipeline balancer type which balances the writing on particular nodes based on selected strategy" validate:"required,oneof=rand roundRobin leastLoaded"`
 }
 
 func NewConfig() Config {
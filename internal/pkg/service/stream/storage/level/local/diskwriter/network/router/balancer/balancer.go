@@ -28,6 +28,9 @@ func NewBalancer(pipelineBalancer network.BalancerType) (Balancer, error) {
 	case network.RoundRobinBalancerType:
 		return NewRoundRobinBalancer(), nil
 
+	case network.LeastLoadedBalancerType:
+		return NewLeastLoadedBalancer(), nil
+
 	default:
 		return nil, errors.New("invalid balancer selected")
 	}
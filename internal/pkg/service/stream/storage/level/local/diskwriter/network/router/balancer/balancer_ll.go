@@ -0,0 +1,101 @@
+package balancer
+
+import (
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"go.uber.org/atomic"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// inFlightBytesUnit is an amount of pending bytes, which doubles the pipeline cost.
+	inFlightBytesUnit = 64 * datasize.KB
+	// minLatency prevents zero cost of a pipeline without measured latency.
+	minLatency = time.Millisecond
+	// errorRateWeight - the pipeline cost is multiplied by 11, if all recent writes failed.
+	errorRateWeight = 10
+)
+
+// Load of the SlicePipeline, it is used by the LeastLoadedBalancer.
+type Load struct {
+	// InFlightBytes is the amount of bytes waiting for the write to the disk writer node.
+	InFlightBytes datasize.ByteSize
+	// Latency is a moving average of the RPC write latency.
+	Latency time.Duration
+	// ErrorRate is a moving average of failed RPC writes, from 0.0 to 1.0.
+	ErrorRate float64
+}
+
+// LoadProvider is an optional interface of the SlicePipeline, see LeastLoadedBalancer.
+// A pipeline without the interface is considered idle.
+type LoadProvider interface {
+	Load() Load
+}
+
+// LeastLoadedBalancer selects the pipeline with the lowest cost, computed from the pipeline Load.
+// Slow or partially failing disk writer nodes get less traffic.
+// Pipelines with the same cost are rotated, as in the RoundRobinBalancer.
+// If the selected pipeline is not ready, a ready pipeline is searched from the next index.
+type LeastLoadedBalancer struct {
+	counter *atomic.Int64
+}
+
+func NewLeastLoadedBalancer() Balancer {
+	return &LeastLoadedBalancer{counter: atomic.NewInt64(-1)}
+}
+
+func (b LeastLoadedBalancer) WriteRecord(c recordctx.Context, pipelines []SlicePipeline) (pipeline.WriteResult, error) {
+	length := len(pipelines)
+
+	if length == 0 {
+		return pipeline.WriteResult{Status: pipeline.RecordError}, NoPipelineError{}
+	}
+
+	if length == 1 {
+		result, err := pipelines[0].WriteRecord(c)
+		if errors.As(err, &PipelineNotReadyError{}) {
+			return pipeline.WriteResult{Status: pipeline.RecordError}, NoPipelineReadyError{}
+		}
+		return result, err
+	}
+
+	// Find the least loaded pipeline, the first one wins on equal cost
+	start := int(b.counter.Add(1))
+	best := start % length
+	bestCost := pipelineCost(pipelines[best])
+	for i := 1; i < length; i++ {
+		index := (start + i) % length
+		if cost := pipelineCost(pipelines[index]); cost < bestCost {
+			best, bestCost = index, cost
+		}
+	}
+
+	for i := range length {
+		index := (best + i) % length
+		result, err := pipelines[index].WriteRecord(c)
+		if errors.As(err, &PipelineNotReadyError{}) {
+			// Pipeline is not ready, try next
+			continue
+		}
+		return result, err
+	}
+
+	return pipeline.WriteResult{Status: pipeline.RecordError}, NoPipelineReadyError{}
+}
+
+func pipelineCost(p SlicePipeline) float64 {
+	provider, ok := p.(LoadProvider)
+	if !ok {
+		return float64(minLatency)
+	}
+
+	load := provider.Load()
+	inFlight := 1 + float64(load.InFlightBytes)/float64(inFlightBytesUnit)
+	latency := float64(max(load.Latency, minLatency))
+	errorRate := 1 + errorRateWeight*min(max(load.ErrorRate, 0), 1)
+	return inFlight * latency * errorRate
+}
@@ -0,0 +1,92 @@
+package balancer_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/router/balancer"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestLeastLoadedBalancer(t *testing.T) {
+	t.Parallel()
+
+	// Fixtures
+	b := balancer.NewLeastLoadedBalancer()
+	c := recordctx.FromHTTP(time.Now(), &http.Request{})
+
+	// Pipelines
+	var logger strings.Builder
+	p1 := NewTestPipeline("pipeline1", test.NewSliceKeyOpenedAt("2000-01-01T01:00:00.000Z"), &logger)
+	p2 := NewTestPipeline("pipeline2", test.NewSliceKeyOpenedAt("2000-01-01T02:00:00.000Z"), &logger)
+	p3 := NewTestPipeline("pipeline3", test.NewSliceKeyOpenedAt("2000-01-01T03:00:00.000Z"), &logger)
+	pipelines := []balancer.SlicePipeline{p1, p2, p3}
+	expectWriteToPipeline := func(expectedPipeline *TestPipeline) {
+		result, err := b.WriteRecord(c, pipelines)
+		assert.Equal(t, pipeline.RecordProcessed, result.Status)
+		require.NoError(t, err)
+		assert.Equal(t, "write "+expectedPipeline.Name, strings.TrimSpace(logger.String()))
+		logger.Reset()
+	}
+	expectWriteError := func(expectedErr string) {
+		result, err := b.WriteRecord(c, pipelines)
+		assert.Equal(t, pipeline.RecordError, result.Status)
+		if assert.Error(t, err) {
+			assert.Equal(t, expectedErr, err.Error())
+		}
+		logger.Reset()
+	}
+
+	// No pipeline
+	result, err := b.WriteRecord(c, nil)
+	assert.Equal(t, pipeline.RecordError, result.Status)
+	if assert.Error(t, err) {
+		assert.Equal(t, "no pipeline", err.Error())
+	}
+
+	// No load - pipelines are rotated
+	expectWriteToPipeline(p1)
+	expectWriteToPipeline(p2)
+	expectWriteToPipeline(p3)
+
+	// High latency of p1, cost: p1=10ms, p2=1ms, p3=1ms
+	p1.CurrentLoad = balancer.Load{Latency: 10 * time.Millisecond}
+	// 3%3 == 0, p1 is skipped
+	expectWriteToPipeline(p2)
+	// 4%3 == 1
+	expectWriteToPipeline(p2)
+	// 5%3 == 2
+	expectWriteToPipeline(p3)
+
+	// Errors of p2, cost: p1=10ms, p2=6ms, p3=1ms
+	p2.CurrentLoad = balancer.Load{ErrorRate: 0.5}
+	expectWriteToPipeline(p3)
+	expectWriteToPipeline(p3)
+
+	// In-flight bytes of p3, cost: p1=10ms, p2=6ms, p3=17ms
+	p3.CurrentLoad = balancer.Load{InFlightBytes: datasize.MB}
+	expectWriteToPipeline(p2)
+	expectWriteToPipeline(p2)
+
+	// The least loaded pipeline is not ready, try next
+	p2.Ready = false
+	expectWriteToPipeline(p3)
+
+	p1.Ready = false
+	p3.Ready = false
+	expectWriteError("no pipeline is ready")
+
+	// Write error
+	p2.Ready = true
+	p2.WriteError = errors.New("some write error")
+	expectWriteError("some write error")
+}
@@ -12,12 +12,13 @@ import (
 )
 
 type TestPipeline struct {
-	logger     io.Writer
-	sliceKey   model.SliceKey
-	Name       string
-	Ready      bool
-	WriteError error
-	CloseError error
+	logger      io.Writer
+	sliceKey    model.SliceKey
+	Name        string
+	Ready       bool
+	WriteError  error
+	CloseError  error
+	CurrentLoad balancer.Load
 }
 
 func NewTestPipeline(name string, sliceKey model.SliceKey, logger io.Writer) *TestPipeline {
@@ -45,6 +46,10 @@ func (p *TestPipeline) WriteRecord(c recordctx.Context) (pipeline.WriteResult, e
 	return pipeline.WriteResult{Status: pipeline.RecordProcessed}, nil
 }
 
+func (p *TestPipeline) Load() balancer.Load {
+	return p.CurrentLoad
+}
+
 func (p *TestPipeline) Close(_ context.Context) error {
 	_, _ = fmt.Fprintf(p.logger, "close %s\n", p.Name)
 	return p.CloseError
@@ -0,0 +1,98 @@
+package pipeline
+
+import (
+	"net/http"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/router/balancer"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// partitionPipelines groups slice pipelines of a time-based partition, see targetModel.Partition.
+type partitionPipelines struct {
+	partition targetModel.Partition
+	column    column.Column
+	pipelines []balancer.SlicePipeline
+}
+
+// PartitionNotOpenedError is returned, if the record belongs to a partition after the partition of opened slices.
+// The file is rotated at the end of the partition, so the write can be retried later.
+type PartitionNotOpenedError struct {
+	partitionEnd utctime.UTCTime
+}
+
+func (e PartitionNotOpenedError) StatusCode() int {
+	return http.StatusServiceUnavailable
+}
+
+func (e PartitionNotOpenedError) ErrorName() string {
+	return "partitionNotOpened"
+}
+
+func (e PartitionNotOpenedError) Error() string {
+	return "record belongs to a partition after " + e.partitionEnd.String() + ", which has not been opened yet"
+}
+
+// groupByPartition groups pipelines by the partition of the slice, nil is returned, if the slices are not partitioned.
+func groupByPartition(all []*SlicePipeline) (out []*partitionPipelines) {
+	index := make(map[string]*partitionPipelines)
+	for _, item := range all {
+		partition := item.slice.Partition
+		if partition == nil {
+			continue
+		}
+
+		group, found := index[partition.ID]
+		if !found {
+			group = &partitionPipelines{partition: *partition}
+			for _, col := range item.slice.Mapping.Columns {
+				if col.ColumnName() == partition.Column {
+					group.column = col
+					break
+				}
+			}
+			index[partition.ID] = group
+			out = append(out, group)
+		}
+
+		group.pipelines = append(group.pipelines, item)
+	}
+	return out
+}
+
+// selectPartition returns pipelines of the partition to which the record belongs.
+// The record cannot be written, if the partition has already been closed, a retry doesn't help.
+func selectPartition(renderer *column.Renderer, c recordctx.Context, partitions []*partitionPipelines) ([]balancer.SlicePipeline, error) {
+	// All slices of the sink are from files of the same sink, so the column is the same
+	first := partitions[0]
+	if first.column == nil {
+		return nil, errors.Errorf(`partitioning column "%s" not found in the mapping`, first.partition.Column)
+	}
+
+	t, err := renderer.TimeValue(first.column, c)
+	if err != nil {
+		return nil, err
+	}
+
+	last := first
+	for _, group := range partitions {
+		if group.partition.Contains(t) {
+			return group.pipelines, nil
+		}
+		if group.partition.End.Time().After(last.partition.End.Time()) {
+			last = group
+		}
+	}
+
+	if !t.Before(last.partition.End.Time()) {
+		return nil, PartitionNotOpenedError{partitionEnd: last.partition.End}
+	}
+
+	return nil, column.NewRenderError(errors.Errorf(
+		`partitioning column "%s" value "%s" is before the opened partition "%s", the partition has already been closed`,
+		last.partition.Column, utctime.From(t).String(), last.partition.ID,
+	))
+}
@@ -0,0 +1,79 @@
+package pipeline
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/router/balancer"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestSelectPartition(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{Columns: column.Columns{
+		column.Datetime{Name: "datetime"},
+		column.Template{Name: "time", Template: column.TemplateConfig{Language: column.TemplateLanguageJsonnet, Content: "Body('time')"}},
+	}}
+
+	newPipeline := func(partitionID, start, end string) *SlicePipeline {
+		return &SlicePipeline{slice: &SliceData{
+			Mapping: mapping,
+			Partition: &targetModel.Partition{
+				ID:     partitionID,
+				Column: "time",
+				Start:  utctime.MustParse(start),
+				End:    utctime.MustParse(end),
+			},
+		}}
+	}
+
+	newRecord := func(recordTime string) recordctx.Context {
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		body := io.NopCloser(strings.NewReader(`{"time":"` + recordTime + `"}`))
+		return recordctx.FromHTTP(time.Now(), &http.Request{Header: header, Body: body})
+	}
+
+	// The old file has not been closed yet, the new file is already opened
+	slice1 := newPipeline("20000101", "2000-01-01T00:00:00.000Z", "2000-01-02T00:00:00.000Z")
+	slice2 := newPipeline("20000102", "2000-01-02T00:00:00.000Z", "2000-01-03T00:00:00.000Z")
+	slice3 := newPipeline("20000102", "2000-01-02T00:00:00.000Z", "2000-01-03T00:00:00.000Z")
+	partitions := groupByPartition([]*SlicePipeline{slice1, slice2, slice3})
+	require.Len(t, partitions, 2)
+
+	renderer := column.NewRenderer()
+
+	// Record is routed by the column value
+	pipelines, err := selectPartition(renderer, newRecord("2000-01-01T23:59:59.999Z"), partitions)
+	require.NoError(t, err)
+	assert.Equal(t, []balancer.SlicePipeline{slice1}, pipelines)
+	pipelines, err = selectPartition(renderer, newRecord("2000-01-02T00:00:00.000Z"), partitions)
+	require.NoError(t, err)
+	assert.Equal(t, []balancer.SlicePipeline{slice2, slice3}, pipelines)
+
+	// Next partition is not opened yet, the write can be retried
+	_, err = selectPartition(renderer, newRecord("2000-01-03T00:00:00.000Z"), partitions)
+	var notOpenedErr PartitionNotOpenedError
+	require.True(t, errors.As(err, &notOpenedErr))
+	assert.Equal(t, http.StatusServiceUnavailable, notOpenedErr.StatusCode())
+
+	// Previous partition is already closed, a retry doesn't help
+	_, err = selectPartition(renderer, newRecord("1999-12-31T23:59:59.999Z"), partitions)
+	require.ErrorAs(t, err, &column.RenderError{})
+	assert.Equal(t, `partitioning column "time" value "1999-12-31T23:59:59.999Z" is before the opened partition "20000102", the partition has already been closed`, err.Error())
+
+	// Invalid value
+	_, err = selectPartition(renderer, newRecord("foo"), partitions)
+	require.ErrorAs(t, err, &column.RenderError{})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/ctxattr"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
 	pipelinePkg "github.com/keboola/keboola-as-code/internal/pkg/service/stream/sink/pipeline"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/connection"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/router/balancer"
@@ -20,6 +21,7 @@ import (
 )
 
 // SinkPipeline receives records for the sink and routes them to the nested slice pipelines using the balancer.Balancer.
+// If the slices are partitioned, only slices of the partition of the record are used, see the selectPartition function.
 // The SinkPipeline exists in a source node.
 type SinkPipeline struct {
 	sinkKey     key.SinkKey
@@ -28,13 +30,15 @@ type SinkPipeline struct {
 	connections *connection.Manager
 	encoding    *encoding.Manager
 	balancer    balancer.Balancer
+	renderer    *column.Renderer
 	onClose     func(ctx context.Context, cause string)
 
 	updateLock sync.Mutex
 	collection *Collection[model.SliceKey, *SlicePipeline]
 
-	writeLock sync.RWMutex
-	pipelines []balancer.SlicePipeline
+	writeLock  sync.RWMutex
+	pipelines  []balancer.SlicePipeline
+	partitions []*partitionPipelines
 
 	closed chan struct{}
 }
@@ -47,6 +51,7 @@ func NewSinkPipeline(sinkKey key.SinkKey, logger log.Logger, telemetry telemetry
 		connections: connections,
 		encoding:    encoding,
 		balancer:    b,
+		renderer:    column.NewRenderer(),
 		onClose:     onClose,
 		collection:  NewCollection[model.SliceKey, *SlicePipeline](logger),
 		closed:      make(chan struct{}),
@@ -66,6 +71,8 @@ func NewSinkPipeline(sinkKey key.SinkKey, logger log.Logger, telemetry telemetry
 		for _, item := range all {
 			p.pipelines = append(p.pipelines, item)
 		}
+
+		p.partitions = groupByPartition(all)
 	})
 
 	// Close sink pipeline, if all slice pipelines are gone, for example all disk writer nodes are down
@@ -97,8 +104,17 @@ func (p *SinkPipeline) WriteRecord(c recordctx.Context) (pipelinePkg.WriteResult
 	p.writeLock.RLock()
 	pipelines := make([]balancer.SlicePipeline, len(p.pipelines))
 	copy(pipelines, p.pipelines)
+	partitions := p.partitions
 	p.writeLock.RUnlock()
 
+	// Route the record to slices of its partition, if the slices are partitioned
+	if len(partitions) > 0 {
+		var err error
+		if pipelines, err = selectPartition(p.renderer, c, partitions); err != nil {
+			return pipelinePkg.WriteResult{Status: pipelinePkg.RecordError}, err
+		}
+	}
+
 	return p.balancer.WriteRecord(c, pipelines)
 }
 
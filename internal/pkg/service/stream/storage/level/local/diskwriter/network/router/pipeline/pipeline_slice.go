@@ -18,6 +18,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
 	encodingCfg "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/config"
 	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
 	storage "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
@@ -49,6 +50,7 @@ type SliceData struct {
 	Encoding     encodingCfg.Config
 	Mapping      table.Mapping
 	LocalStorage localModel.Slice
+	Partition    *targetModel.Partition
 }
 
 func NewSlicePipeline(
@@ -133,6 +135,23 @@ func (p *SlicePipeline) WriteRecord(c recordctx.Context) (pipelinePkg.WriteResul
 	return pipelinePkg.WriteResult{Status: pipelinePkg.RecordAccepted, Bytes: n}, nil
 }
 
+// Load implements balancer.LoadProvider.
+func (p *SlicePipeline) Load() balancer.Load {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.pipeline == nil {
+		return balancer.Load{}
+	}
+
+	load := p.pipeline.NetworkLoad()
+	return balancer.Load{
+		InFlightBytes: load.PendingBytes,
+		Latency:       load.WriteLatency,
+		ErrorRate:     load.ErrorRate,
+	}
+}
+
 func (p *SlicePipeline) Close(ctx context.Context, cause string) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
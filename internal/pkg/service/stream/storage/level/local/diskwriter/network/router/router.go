@@ -125,6 +125,7 @@ func New(d dependencies, sourceNodeID, sourceType string, config network.Config)
 					Encoding:     slice.Encoding,
 					Mapping:      slice.Mapping,
 					LocalStorage: slice.LocalStorage,
+					Partition:    slice.Partition,
 				}
 			},
 		).
@@ -206,7 +207,7 @@ func (r *Router) onSlicesModification(ctx context.Context, modifiedSinks []key.S
 func (r *Router) assignSinkSlices(sinkKey key.SinkKey) (out []*pipeline.SliceData) {
 	// Get sink slices
 	sinkSlices := make(map[storage.SliceKey]*pipeline.SliceData)
-	r.slices.WalkPrefix(sinkKey.String(), func(key string, slice *pipeline.SliceData) (stop bool) {
+	r.slices.WalkPrefix(sinkKey.String()+"/", func(key string, slice *pipeline.SliceData) (stop bool) {
 		if slice.State == storage.SliceWriting {
 			sinkSlices[slice.SliceKey] = slice
 		}
@@ -1,6 +1,8 @@
 package rpc
 
 import (
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
 	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 )
@@ -10,3 +12,10 @@ type sliceData struct {
 	LocalStorage localModel.Slice `json:"localStorage"`
 	WithBackup   bool             `json:"withBackup"`
 }
+
+type previewData struct {
+	SliceKey     model.SliceKey     `json:"sliceKey"`
+	LocalStorage localModel.Slice   `json:"localStorage"`
+	EncoderType  encoder.Type       `json:"encoderType"`
+	Compression  compression.Config `json:"compression"`
+}
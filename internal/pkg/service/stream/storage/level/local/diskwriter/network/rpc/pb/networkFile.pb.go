@@ -464,6 +464,147 @@ func (*CloseResponse) Descriptor() ([]byte, []int) {
 	return file_networkFile_proto_rawDescGZIP(), []int{9}
 }
 
+type PreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SliceDataJson []byte                 `protobuf:"bytes,1,opt,name=slice_data_json,json=sliceDataJson,proto3" json:"slice_data_json,omitempty"`
+	// The "limit" is maximum number of the last records returned.
+	Limit         uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRequest) Reset() {
+	*x = PreviewRequest{}
+	mi := &file_networkFile_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRequest) ProtoMessage() {}
+
+func (x *PreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_networkFile_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRequest) Descriptor() ([]byte, []int) {
+	return file_networkFile_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PreviewRequest) GetSliceDataJson() []byte {
+	if x != nil {
+		return x.SliceDataJson
+	}
+	return nil
+}
+
+func (x *PreviewRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type PreviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*PreviewRecord       `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewResponse) Reset() {
+	*x = PreviewResponse{}
+	mi := &file_networkFile_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewResponse) ProtoMessage() {}
+
+func (x *PreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_networkFile_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewResponse.ProtoReflect.Descriptor instead.
+func (*PreviewResponse) Descriptor() ([]byte, []int) {
+	return file_networkFile_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PreviewResponse) GetRecords() []*PreviewRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type PreviewRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRecord) Reset() {
+	*x = PreviewRecord{}
+	mi := &file_networkFile_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRecord) ProtoMessage() {}
+
+func (x *PreviewRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_networkFile_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRecord.ProtoReflect.Descriptor instead.
+func (*PreviewRecord) Descriptor() ([]byte, []int) {
+	return file_networkFile_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PreviewRecord) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 type SliceKey struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
@@ -479,7 +620,7 @@ type SliceKey struct {
 
 func (x *SliceKey) Reset() {
 	*x = SliceKey{}
-	mi := &file_networkFile_proto_msgTypes[10]
+	mi := &file_networkFile_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -491,7 +632,7 @@ func (x *SliceKey) String() string {
 func (*SliceKey) ProtoMessage() {}
 
 func (x *SliceKey) ProtoReflect() protoreflect.Message {
-	mi := &file_networkFile_proto_msgTypes[10]
+	mi := &file_networkFile_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -504,7 +645,7 @@ func (x *SliceKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SliceKey.ProtoReflect.Descriptor instead.
 func (*SliceKey) Descriptor() ([]byte, []int) {
-	return file_networkFile_proto_rawDescGZIP(), []int{10}
+	return file_networkFile_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SliceKey) GetProjectId() int64 {
@@ -580,7 +721,14 @@ const file_networkFile_proto_rawDesc = "" +
 	"\fSyncResponse\"'\n" +
 	"\fCloseRequest\x12\x17\n" +
 	"\afile_id\x18\x01 \x01(\x04R\x06fileId\"\x0f\n" +
-	"\rCloseResponse\"\x85\x02\n" +
+	"\rCloseResponse\"N\n" +
+	"\x0ePreviewRequest\x12&\n" +
+	"\x0fslice_data_json\x18\x01 \x01(\fR\rsliceDataJson\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\">\n" +
+	"\x0fPreviewResponse\x12+\n" +
+	"\arecords\x18\x01 \x03(\v2\x11.pb.PreviewRecordR\arecords\"'\n" +
+	"\rPreviewRecord\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\x85\x02\n" +
 	"\bSliceKey\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x1b\n" +
@@ -589,13 +737,14 @@ const file_networkFile_proto_rawDesc = "" +
 	"\asink_id\x18\x04 \x01(\tR\x06sinkId\x123\n" +
 	"\afile_id\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06fileId\x12\x1b\n" +
 	"\tvolume_id\x18\x06 \x01(\tR\bvolumeId\x125\n" +
-	"\bslice_id\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\asliceId2\x95\x02\n" +
+	"\bslice_id\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\asliceId2\xcb\x02\n" +
 	"\vNetworkFile\x12+\n" +
 	"\x04Open\x12\x0f.pb.OpenRequest\x1a\x10.pb.OpenResponse\"\x00\x12L\n" +
 	"\x0fKeepAliveStream\x12\x1a.pb.KeepAliveStreamRequest\x1a\x1b.pb.KeepAliveStreamResponse0\x01\x12.\n" +
 	"\x05Write\x12\x10.pb.WriteRequest\x1a\x11.pb.WriteResponse\"\x00\x12+\n" +
 	"\x04Sync\x12\x0f.pb.SyncRequest\x1a\x10.pb.SyncResponse\"\x00\x12.\n" +
-	"\x05Close\x12\x10.pb.CloseRequest\x1a\x11.pb.CloseResponse\"\x00B\x05Z\x03pb/b\x06proto3"
+	"\x05Close\x12\x10.pb.CloseRequest\x1a\x11.pb.CloseResponse\"\x00\x124\n" +
+	"\aPreview\x12\x12.pb.PreviewRequest\x1a\x13.pb.PreviewResponse\"\x00B\x05Z\x03pb/b\x06proto3"
 
 var (
 	file_networkFile_proto_rawDescOnce sync.Once
@@ -609,7 +758,7 @@ func file_networkFile_proto_rawDescGZIP() []byte {
 	return file_networkFile_proto_rawDescData
 }
 
-var file_networkFile_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_networkFile_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_networkFile_proto_goTypes = []any{
 	(*OpenRequest)(nil),             // 0: pb.OpenRequest
 	(*OpenResponse)(nil),            // 1: pb.OpenResponse
@@ -621,27 +770,33 @@ var file_networkFile_proto_goTypes = []any{
 	(*SyncResponse)(nil),            // 7: pb.SyncResponse
 	(*CloseRequest)(nil),            // 8: pb.CloseRequest
 	(*CloseResponse)(nil),           // 9: pb.CloseResponse
-	(*SliceKey)(nil),                // 10: pb.SliceKey
-	(*timestamppb.Timestamp)(nil),   // 11: google.protobuf.Timestamp
+	(*PreviewRequest)(nil),          // 10: pb.PreviewRequest
+	(*PreviewResponse)(nil),         // 11: pb.PreviewResponse
+	(*PreviewRecord)(nil),           // 12: pb.PreviewRecord
+	(*SliceKey)(nil),                // 13: pb.SliceKey
+	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
 }
 var file_networkFile_proto_depIdxs = []int32{
-	11, // 0: pb.SliceKey.file_id:type_name -> google.protobuf.Timestamp
-	11, // 1: pb.SliceKey.slice_id:type_name -> google.protobuf.Timestamp
-	0,  // 2: pb.NetworkFile.Open:input_type -> pb.OpenRequest
-	2,  // 3: pb.NetworkFile.KeepAliveStream:input_type -> pb.KeepAliveStreamRequest
-	4,  // 4: pb.NetworkFile.Write:input_type -> pb.WriteRequest
-	6,  // 5: pb.NetworkFile.Sync:input_type -> pb.SyncRequest
-	8,  // 6: pb.NetworkFile.Close:input_type -> pb.CloseRequest
-	1,  // 7: pb.NetworkFile.Open:output_type -> pb.OpenResponse
-	3,  // 8: pb.NetworkFile.KeepAliveStream:output_type -> pb.KeepAliveStreamResponse
-	5,  // 9: pb.NetworkFile.Write:output_type -> pb.WriteResponse
-	7,  // 10: pb.NetworkFile.Sync:output_type -> pb.SyncResponse
-	9,  // 11: pb.NetworkFile.Close:output_type -> pb.CloseResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	12, // 0: pb.PreviewResponse.records:type_name -> pb.PreviewRecord
+	14, // 1: pb.SliceKey.file_id:type_name -> google.protobuf.Timestamp
+	14, // 2: pb.SliceKey.slice_id:type_name -> google.protobuf.Timestamp
+	0,  // 3: pb.NetworkFile.Open:input_type -> pb.OpenRequest
+	2,  // 4: pb.NetworkFile.KeepAliveStream:input_type -> pb.KeepAliveStreamRequest
+	4,  // 5: pb.NetworkFile.Write:input_type -> pb.WriteRequest
+	6,  // 6: pb.NetworkFile.Sync:input_type -> pb.SyncRequest
+	8,  // 7: pb.NetworkFile.Close:input_type -> pb.CloseRequest
+	10, // 8: pb.NetworkFile.Preview:input_type -> pb.PreviewRequest
+	1,  // 9: pb.NetworkFile.Open:output_type -> pb.OpenResponse
+	3,  // 10: pb.NetworkFile.KeepAliveStream:output_type -> pb.KeepAliveStreamResponse
+	5,  // 11: pb.NetworkFile.Write:output_type -> pb.WriteResponse
+	7,  // 12: pb.NetworkFile.Sync:output_type -> pb.SyncResponse
+	9,  // 13: pb.NetworkFile.Close:output_type -> pb.CloseResponse
+	11, // 14: pb.NetworkFile.Preview:output_type -> pb.PreviewResponse
+	9,  // [9:15] is the sub-list for method output_type
+	3,  // [3:9] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_networkFile_proto_init() }
@@ -655,7 +810,7 @@ func file_networkFile_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_networkFile_proto_rawDesc), len(file_networkFile_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
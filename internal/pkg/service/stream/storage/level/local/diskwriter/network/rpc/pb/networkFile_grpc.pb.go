@@ -24,6 +24,7 @@ const (
 	NetworkFile_Write_FullMethodName           = "/pb.NetworkFile/Write"
 	NetworkFile_Sync_FullMethodName            = "/pb.NetworkFile/Sync"
 	NetworkFile_Close_FullMethodName           = "/pb.NetworkFile/Close"
+	NetworkFile_Preview_FullMethodName         = "/pb.NetworkFile/Preview"
 )
 
 // NetworkFileClient is the client API for NetworkFile service.
@@ -35,6 +36,7 @@ type NetworkFileClient interface {
 	Write(ctx context.Context, in *WriteRequest, opts ...grpc.CallOption) (*WriteResponse, error)
 	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
 	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
+	Preview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error)
 }
 
 type networkFileClient struct {
@@ -104,6 +106,16 @@ func (c *networkFileClient) Close(ctx context.Context, in *CloseRequest, opts ..
 	return out, nil
 }
 
+func (c *networkFileClient) Preview(ctx context.Context, in *PreviewRequest, opts ...grpc.CallOption) (*PreviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewResponse)
+	err := c.cc.Invoke(ctx, NetworkFile_Preview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkFileServer is the server API for NetworkFile service.
 // All implementations must embed UnimplementedNetworkFileServer
 // for forward compatibility.
@@ -113,6 +125,7 @@ type NetworkFileServer interface {
 	Write(context.Context, *WriteRequest) (*WriteResponse, error)
 	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
 	Close(context.Context, *CloseRequest) (*CloseResponse, error)
+	Preview(context.Context, *PreviewRequest) (*PreviewResponse, error)
 	mustEmbedUnimplementedNetworkFileServer()
 }
 
@@ -138,6 +151,9 @@ func (UnimplementedNetworkFileServer) Sync(context.Context, *SyncRequest) (*Sync
 func (UnimplementedNetworkFileServer) Close(context.Context, *CloseRequest) (*CloseResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Close not implemented")
 }
+func (UnimplementedNetworkFileServer) Preview(context.Context, *PreviewRequest) (*PreviewResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Preview not implemented")
+}
 func (UnimplementedNetworkFileServer) mustEmbedUnimplementedNetworkFileServer() {}
 func (UnimplementedNetworkFileServer) testEmbeddedByValue()                     {}
 
@@ -242,6 +258,24 @@ func _NetworkFile_Close_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkFile_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkFileServer).Preview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NetworkFile_Preview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkFileServer).Preview(ctx, req.(*PreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NetworkFile_ServiceDesc is the grpc.ServiceDesc for NetworkFile service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +299,10 @@ var NetworkFile_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Close",
 			Handler:    _NetworkFile_Close_Handler,
 		},
+		{
+			MethodName: "Preview",
+			Handler:    _NetworkFile_Preview_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/rpc/pb"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/transport"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
+	volume "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// SlicePreview is a slice to be previewed by the PreviewSlices function.
+type SlicePreview struct {
+	Volume       volume.Metadata
+	SliceKey     model.SliceKey
+	LocalStorage localModel.Slice
+	EncoderType  encoder.Type
+	Compression  compression.Config
+}
+
+// PreviewSlices reads the last records of the slices from the disk writer node, see diskreader.PreviewSlice.
+// All slices must be stored on volumes of the same node, one connection to the node is used for all slices.
+// The connection is short-lived, so the API node doesn't need connections to all disk writer nodes.
+// Records of each slice are returned in the order of the slices.
+func PreviewSlices(
+	ctx context.Context,
+	logger log.Logger,
+	cfg network.Config,
+	nodeID string,
+	slices []SlicePreview,
+	limit int,
+) ([][][]string, error) {
+	logger = logger.WithComponent("rpc")
+
+	if len(slices) == 0 {
+		return nil, nil
+	}
+
+	node := slices[0].Volume
+	for _, slice := range slices {
+		if slice.Volume.NodeID != node.NodeID {
+			return nil, errors.Errorf(`slice "%s" is not stored on the node "%s"`, slice.SliceKey.String(), node.NodeID)
+		}
+	}
+
+	// Connect to the disk writer node
+	tr, err := transport.NewProtocol(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client := transport.NewClient(logger, cfg, nodeID, tr)
+	defer func() {
+		if err := client.Close(); err != nil {
+			logger.Error(ctx, err.Error())
+		}
+	}()
+	conn, err := client.OpenConnectionOrErr(ctx, node.NodeID, node.NodeAddress.String())
+	if err != nil {
+		return nil, err
+	}
+
+	// Create gRPC client, requests of all slices share the connection
+	dialer := func(_ context.Context, _ string) (net.Conn, error) {
+		stream, err := conn.OpenStream()
+		if err != nil {
+			return nil, errors.PrefixErrorf(err, `cannot open stream to the node "%s"`, node.NodeID)
+		}
+		return stream, nil
+	}
+	clientConn, err := grpc.NewClient(
+		"127.0.0.1",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = clientConn.Close()
+	}()
+
+	rpcClient := pb.NewNetworkFileClient(clientConn)
+	out := make([][][]string, 0, len(slices))
+	for _, slice := range slices {
+		dataJSON, err := json.Encode(previewData{SliceKey: slice.SliceKey, LocalStorage: slice.LocalStorage, EncoderType: slice.EncoderType, Compression: slice.Compression}, false)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := rpcClient.Preview(ctx, &pb.PreviewRequest{SliceDataJson: dataJSON, Limit: uint32(limit)})
+		if err != nil {
+			return nil, errors.PrefixErrorf(err, `network file client: rpc preview error, slice "%s"`, slice.SliceKey.String())
+		}
+
+		records := make([][]string, 0, len(resp.Records))
+		for _, record := range resp.Records {
+			records = append(records, record.Values)
+		}
+		out = append(out, records)
+	}
+
+	return out, nil
+}
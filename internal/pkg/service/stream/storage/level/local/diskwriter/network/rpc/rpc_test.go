@@ -1,6 +1,7 @@
 package rpc_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/rpc"
+	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
 	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
 	volume "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
@@ -95,6 +97,84 @@ func TestNetworkFile(t *testing.T) {
 	assert.Equal(t, "foo\nbar\n", string(content))
 }
 
+func TestPreviewSlices(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	etcdCfg := etcdhelper.TmpNamespace(t)
+
+	// Create volume directory, with volume ID file
+	volumeID := volume.ID("my-volume")
+	volumesPath := t.TempDir()
+	volumePath := filepath.Join(volumesPath, "hdd", "001")
+	require.NoError(t, os.MkdirAll(volumePath, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(volumePath, volume.IDFile), []byte(volumeID), 0o600))
+
+	// Start disk writer node
+	writerNode := startDiskWriterNode(t, ctx, etcdCfg, "disk-writer", volumesPath)
+
+	// Create resources in an API node
+	apiScp, apiMock := dependencies.NewMockedAPIScope(t, ctx, commonDeps.WithEtcdConfig(etcdCfg))
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 111}
+	branch := test.NewBranch(branchKey)
+	source := test.NewHTTPSource(key.SourceKey{BranchKey: branchKey, SourceID: "my-source"})
+	source.HTTP.Secret = strings.Repeat("1", 48)
+	sink := dummy.NewSinkWithLocalStorage(key.SinkKey{SourceKey: source.SourceKey, SinkID: "my-sink"})
+	require.NoError(t, apiScp.DefinitionRepository().Branch().Create(&branch, apiScp.Clock().Now(), test.ByUser()).Do(ctx).Err())
+	require.NoError(t, apiScp.DefinitionRepository().Source().Create(&source, apiScp.Clock().Now(), test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, apiScp.DefinitionRepository().Sink().Create(&sink, apiScp.Clock().Now(), test.ByUser(), "create").Do(ctx).Err())
+
+	// Load created slice and the writer volume
+	slices, err := apiScp.StorageRepository().Slice().ListIn(sink.SinkKey).Do(ctx).All()
+	require.NoError(t, err)
+	require.Len(t, slices, 1)
+	slice := slices[0]
+	volumes, err := apiScp.StorageRepository().Volume().ListWriterVolumes().Do(ctx).All()
+	require.NoError(t, err)
+	require.Len(t, volumes, 1)
+
+	// Write compressed CSV records from a source node
+	var buf bytes.Buffer
+	w, err := compressionWriter.New(&buf, slice.Encoding.Compression)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("\"foo\",\"1\"\n\"bar\",\"2\"\n\"baz\",\"3\"\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	file, sourceNode := openNetworkFile(t, ctx, etcdCfg, "source", slice.SliceKey, slice.LocalStorage)
+	_, err = file.Write(ctx, true, buf.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, file.Sync(ctx))
+
+	// Preview the last records of the open slice, via the RPC
+	records, err := rpc.PreviewSlices(
+		ctx,
+		apiScp.Logger(),
+		apiMock.TestConfig().Storage.Level.Local.Writer.Network,
+		"api",
+		[]rpc.SlicePreview{{
+			Volume:       volumes[0],
+			SliceKey:     slice.SliceKey,
+			LocalStorage: slice.LocalStorage,
+			EncoderType:  slice.Encoding.Encoder.Type,
+			Compression:  slice.Encoding.Compression,
+		}},
+		2,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, [][][]string{{{"bar", "2"}, {"baz", "3"}}}, records)
+
+	// Close
+	require.NoError(t, file.Close(ctx))
+
+	// Shutdown nodes, the writer first
+	writerNode.Process().Shutdown(ctx, errors.New("bye bye"))
+	writerNode.Process().WaitForShutdown()
+	sourceNode.Process().Shutdown(ctx, errors.New("bye bye"))
+	sourceNode.Process().WaitForShutdown()
+}
+
 func startDiskWriterNode(t *testing.T, ctx context.Context, etcdCfg etcdclient.Config, nodeID string, volumesPath string) dependencies.Mocked {
 	t.Helper()
 
@@ -15,6 +15,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
 	local "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskreader"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/rpc/pb"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskwriter/network/transport"
@@ -208,6 +209,32 @@ func (s *NetworkFileServer) Close(ctx context.Context, req *pb.CloseRequest) (*p
 	return nil, w.Close(ctx)
 }
 
+func (s *NetworkFileServer) Preview(ctx context.Context, req *pb.PreviewRequest) (*pb.PreviewResponse, error) {
+	var data previewData
+	if err := json.Decode(req.SliceDataJson, &data); err != nil {
+		return nil, err
+	}
+
+	// Get volume
+	vol, err := s.volumes.Collection().Volume(data.SliceKey.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the last records, files of active writers are not modified
+	records, err := diskreader.PreviewSlice(ctx, s.logger, diskreader.DefaultFileOpener{}, vol.Path(), data.LocalStorage, data.EncoderType, data.Compression, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.PreviewResponse{Records: make([]*pb.PreviewRecord, 0, len(records))}
+	for _, record := range records {
+		resp.Records = append(resp.Records, &pb.PreviewRecord{Values: record})
+	}
+
+	return resp, nil
+}
+
 func (s *NetworkFileServer) writer(id uint64) (diskwriter.Writer, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/c2h5oh/datasize"
 
@@ -30,6 +31,8 @@ type Writer struct {
 	activeChunk *Chunk
 	// completedChunks from the oldest, to the newest.
 	completedChunks []*Chunk
+	// pendingBytes is size of the completedChunks, it is read without the lock, see PendingBytes method.
+	pendingBytes atomic.Int64
 	// chunksPool reuses buffers from freed chunks.
 	chunksPool *sync.Pool
 }
@@ -167,6 +170,12 @@ func (w *Writer) CompletedChunks() int {
 	return len(w.completedChunks)
 }
 
+// PendingBytes returns size of all completed chunks, which are waiting for processing.
+// The method doesn't lock the writer, it is called by the load balancer for each record.
+func (w *Writer) PendingBytes() datasize.ByteSize {
+	return datasize.ByteSize(max(w.pendingBytes.Load(), 0))
+}
+
 // ProcessCompletedChunks iterates over completed chunks.
 // The method can be used, for example, to send/upload chunks to the next stage.
 // If the callback is successful, the chunk is removed from the list and the internal buffer is reused.
@@ -195,6 +204,7 @@ func (w *Writer) ProcessCompletedChunks(fn func(chunk *Chunk) error) error {
 			return err
 		}
 		processedIndex++
+		w.pendingBytes.Add(-int64(chunk.Len()))
 		w.freeChunks(chunk)
 	}
 
@@ -214,6 +224,7 @@ func (w *Writer) swapChunks(all ...*Chunk) {
 		if l := w.activeChunk.buffer.Len(); l > 0 {
 			w.maxChunkRealSize = min(w.maxChunkRealSize, l)
 			w.completedChunks = append(w.completedChunks, w.activeChunk)
+			w.pendingBytes.Add(int64(l))
 			w.logger.Debugf(context.Background(), "chunk completed, aligned = %t, size = %q", w.activeChunk.Aligned(), datasize.ByteSize(l).String())
 
 			// Unblock WaitForChunkCh method
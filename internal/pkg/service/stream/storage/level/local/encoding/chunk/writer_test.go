@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/c2h5oh/datasize"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -55,6 +56,7 @@ func TestWriter_Ok(t *testing.T) {
 	assert.Equal(t, 5, n)
 	require.NoError(t, err)
 	assert.Equal(t, 1, w.CompletedChunks())
+	assert.Equal(t, datasize.ByteSize(10), w.PendingBytes())
 	expectedChunks = append(expectedChunks, "aligned = false, data = 1234567890")
 
 	// Write over the maximum
@@ -73,6 +75,7 @@ func TestWriter_Ok(t *testing.T) {
 	// Not empty flush
 	require.NoError(t, w.Flush())
 	assert.Equal(t, 3, w.CompletedChunks())
+	assert.Equal(t, datasize.ByteSize(22), w.PendingBytes())
 	expectedChunks = append(expectedChunks, "aligned = true, data = kl")
 
 	// Write long message, which requires more than 2 chunks
@@ -109,6 +112,7 @@ func TestWriter_Ok(t *testing.T) {
 	}))
 	assert.Equal(t, expectedChunks, actualChunks)
 	assert.Equal(t, 0, w.CompletedChunks())
+	assert.Equal(t, datasize.ByteSize(0), w.PendingBytes())
 }
 
 func TestWriter_WaitForChunk(t *testing.T) {
@@ -306,3 +310,26 @@ func TestWriter_ProcessCompletedChunks(t *testing.T) {
 		assert.Fail(t, "the channel shouldn't be blocked")
 	}
 }
+
+func TestWriter_PendingBytes_ProcessingError(t *testing.T) {
+	t.Parallel()
+
+	w := chunk.NewWriter(log.NewNopLogger(), 10)
+	_, err := w.Write([]byte("1234567890abcdefghij"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, datasize.ByteSize(20), w.PendingBytes())
+
+	// Only processed chunks are subtracted
+	var processed int
+	err = w.ProcessCompletedChunks(func(chunk *chunk.Chunk) error {
+		if processed > 0 {
+			return errors.New("some error")
+		}
+		processed++
+		return nil
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, w.CompletedChunks())
+	assert.Equal(t, datasize.ByteSize(10), w.PendingBytes())
+}
@@ -25,7 +25,7 @@ func TestConfig_Validation(t *testing.T) {
 		},
 		{
 			Name:          "invalid type",
-			ExpectedError: `"type" must be one of [none gzip]`,
+			ExpectedError: `"type" must be one of [none gzip zstd]`,
 			Config: Config{
 				Type: "foo",
 			},
@@ -68,6 +68,17 @@ func TestConfig_Validation(t *testing.T) {
 			Name:   "gzip: default ok",
 			Config: NewGZIPConfig(),
 		},
+		{
+			Name:   "zstd: default ok",
+			Config: NewZSTDConfig(),
+		},
+		{
+			Name:          "zstd: missing config",
+			ExpectedError: `"ZSTD" is a required field`,
+			Config: Config{
+				Type: TypeZSTD,
+			},
+		},
 		{
 			Name:          "gzip: level under min",
 			ExpectedError: `"gzip.level" must be 1 or greater`,
@@ -1,16 +1,36 @@
 package encoder
 
-import "github.com/c2h5oh/datasize"
+import (
+	"github.com/c2h5oh/datasize"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
 
 const (
-	TypeCSV = Type("csv")
+	TypeCSV     = Type("csv")
+	TypeParquet = Type("parquet")
+	TypeNDJSON  = Type("ndjson")
 )
 
 type Type string
 
+// FileExtension returns extension of the encoded file, without the compression suffix.
+func (t Type) FileExtension() (string, error) {
+	switch t {
+	case TypeCSV:
+		return "csv", nil
+	case TypeParquet:
+		return "parquet", nil
+	case TypeNDJSON:
+		return "ndjson", nil
+	default:
+		return "", errors.Errorf(`unexpected encoder type "%s"`, t)
+	}
+}
+
 // Config configures the local writer.
 type Config struct {
-	Type         Type              `json:"type" configKey:"type" configUsage:"Encoder type." validate:"required,oneof=csv"`
+	Type         Type              `json:"type" configKey:"type" configUsage:"Encoder type." validate:"required,oneof=csv parquet ndjson"`
 	Concurrency  int               `json:"concurrency" configKey:"concurrency" configUsage:"Concurrency of the format writer for the specified file type. 0 = auto = num of CPU cores" validate:"min=0,max=256"`
 	RowSizeLimit datasize.ByteSize `json:"rowSizeLimit" configKey:"rowSizeLimit" configUsage:"Set's the limit of single row to be encoded. Limit should be bigger than accepted request on source otherwise received message will never be encoded" validate:"minBytes=1kB,maxBytes=2MB"`
 	// OverrideEncoderFactory overrides encoder factory.
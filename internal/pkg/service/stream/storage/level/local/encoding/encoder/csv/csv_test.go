@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/c2h5oh/datasize"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -87,18 +88,16 @@ func TestCSVWriter(t *testing.T) {
 				return r
 			},
 		},
-		// ZSTD compression is not fully supported, we cannot test it, config/entities validation fails.
-		//{
-		//	Name:   "zstd",
-		//	Config: compression.NewZSTDConfig(),
-		//	FileDecoder: func(t *testing.T, r io.Reader) io.Reader {
-		//		t.Helper()
-		//		r, err := zstd.NewReader(r)
-		//		require.NoError(t, err)
-		//		return r
-		//	},
-		//	DisableValidation: true,
-		// },
+		{
+			Name:   "zstd",
+			Config: compression.NewZSTDConfig(),
+			FileDecoder: func(t *testing.T, r io.Reader) io.Reader {
+				t.Helper()
+				r, err := zstd.NewReader(r)
+				require.NoError(t, err)
+				return r
+			},
+		},
 	}
 
 	syncModes := []writesync.Mode{
@@ -5,6 +5,8 @@ import (
 	"io"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/csv"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/ndjson"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/parquet"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync/notify"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -19,6 +21,10 @@ func (DefaultFactory) NewEncoder(cfg Config, mapping any, out io.Writer, notifie
 	switch cfg.Type {
 	case TypeCSV:
 		return csv.NewEncoder(cfg.Concurrency, cfg.RowSizeLimit, mapping, out, notifier)
+	case TypeParquet:
+		return parquet.NewEncoder(cfg.RowSizeLimit, mapping, out, notifier)
+	case TypeNDJSON:
+		return ndjson.NewEncoder(cfg.RowSizeLimit, mapping, out, notifier)
 	default:
 		return nil, errors.Errorf(`unexpected encoder type "%s"`, cfg.Type)
 	}
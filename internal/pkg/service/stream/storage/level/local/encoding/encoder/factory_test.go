@@ -59,6 +59,62 @@ func TestDefaultFactory_FileTypeCSV(t *testing.T) {
 	assert.NotNil(t, w)
 }
 
+// TestDefaultFactory_FileTypeParquet tests that parquet.Encoder is created for the encoder.TypeParquet.
+// Test for parquet.Encoder itself are in the "parquet" package.
+func TestDefaultFactory_FileTypeParquet(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	d, _ := dependencies.NewMockedSourceScope(t, ctx)
+
+	slice := test.NewSlice()
+	slice.Encoding.Encoder.Type = encoder.TypeParquet
+
+	w, err := d.EncodingManager().OpenPipeline(
+		ctx,
+		slice.SliceKey,
+		d.Telemetry(),
+		d.ConnectionManager(),
+		slice.Mapping,
+		slice.Encoding,
+		slice.LocalStorage,
+		slice.Encoding.Compression.Type != compression.TypeNone,
+		func(ctx context.Context, cause string) {},
+		discardOutput{},
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, w)
+}
+
+// TestDefaultFactory_FileTypeNDJSON tests that ndjson.Encoder is created for the encoder.TypeNDJSON.
+// Test for ndjson.Encoder itself are in the "ndjson" package.
+func TestDefaultFactory_FileTypeNDJSON(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	d, _ := dependencies.NewMockedSourceScope(t, ctx)
+
+	slice := test.NewSlice()
+	slice.Encoding.Encoder.Type = encoder.TypeNDJSON
+
+	w, err := d.EncodingManager().OpenPipeline(
+		ctx,
+		slice.SliceKey,
+		d.Telemetry(),
+		d.ConnectionManager(),
+		slice.Mapping,
+		slice.Encoding,
+		slice.LocalStorage,
+		slice.Encoding.Compression.Type != compression.TypeNone,
+		func(ctx context.Context, cause string) {},
+		discardOutput{},
+	)
+	require.NoError(t, err)
+	assert.NotNil(t, w)
+}
+
 // TestDefaultFactory_FileTypeInvalid test handling of an invalid file type.
 func TestDefaultFactory_FileTypeInvalid(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,120 @@
+// Package ndjson provides the newline-delimited JSON implementation of the encoder.Encoder.
+//
+// Each record is encoded as one JSON object on a single line, keys are column names.
+// Values of the Body, Headers, Path and Template columns are kept as structured JSON, if they contain a valid JSON.
+package ndjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/c2h5oh/datasize"
+
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/result"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync/notify"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type Encoder struct {
+	columns      column.Columns
+	keys         [][]byte
+	rowSizeLimit datasize.ByteSize
+	out          io.Writer
+	buffersPool  *sync.Pool
+	notifier     func(ctx context.Context) *notify.Notifier
+}
+
+var columnRenderer = column.NewRenderer() //nolint:gochecknoglobals // contains Jsonnet VMs sync.Pool
+
+// NewEncoder creates NDJSON encoder and implements encoder.Encoder.
+// The order of the lines is not preserved, there are several source nodes with a load balancer in front of them.
+// In case of encoder accepts too big row, it returns error.
+func NewEncoder(
+	rowSizeLimit datasize.ByteSize,
+	mapping any,
+	out io.Writer,
+	notifier func(ctx context.Context) *notify.Notifier,
+) (*Encoder, error) {
+	tableMapping, ok := mapping.(table.Mapping)
+	if !ok {
+		return nil, errors.Errorf("ndjson encoder supports only table mapping, given %v", mapping)
+	}
+
+	// Pre-encode object keys
+	keys := make([][]byte, len(tableMapping.Columns))
+	for i, col := range tableMapping.Columns {
+		key, err := json.Marshal(col.ColumnName())
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+
+	return &Encoder{
+		columns:      tableMapping.Columns,
+		keys:         keys,
+		rowSizeLimit: rowSizeLimit,
+		out:          out,
+		buffersPool: &sync.Pool{
+			New: func() any {
+				return &bytes.Buffer{}
+			},
+		},
+		notifier: notifier,
+	}, nil
+}
+
+func (w *Encoder) WriteRecord(record recordctx.Context) (result.WriteRecordResult, error) {
+	// Reduce memory allocations
+	buf := w.buffersPool.Get().(*bytes.Buffer)
+	defer w.buffersPool.Put(buf)
+	buf.Reset()
+
+	// Map the record to a JSON object
+	buf.WriteByte('{')
+	for i, col := range w.columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(w.keys[i])
+		buf.WriteByte(':')
+
+		if err := columnRenderer.WriteJSONValue(buf, col, record); err != nil {
+			return result.WriteRecordResult{}, errors.PrefixErrorf(err, "cannot convert column %q to JSON value", col)
+		}
+
+		if limit := int(w.rowSizeLimit.Bytes()); buf.Len() > limit {
+			return result.WriteRecordResult{}, svcerrors.NewPayloadTooLargeError(errors.Errorf(`too big NDJSON row, column: "%s", row limit: %s`, col.ColumnName(), w.rowSizeLimit.HumanReadable()))
+		}
+	}
+	buf.WriteString("}\n")
+
+	// Write the whole line at once
+	n, err := w.out.Write(buf.Bytes())
+	if err != nil {
+		return result.WriteRecordResult{}, err
+	}
+
+	// Get notifier after successful written record
+	writeRecordResult := result.NewNotifierWriteRecordResult(n, w.notifier(record.Ctx()))
+
+	// Buffers can be released
+	record.ReleaseBuffers()
+
+	return writeRecordResult, nil
+}
+
+func (w *Encoder) Flush() error {
+	return nil
+}
+
+func (w *Encoder) Close() error {
+	return nil
+}
@@ -0,0 +1,111 @@
+package ndjson_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/ndjson"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync/notify"
+)
+
+func TestNDJSONEncoder(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Datetime{Name: "datetime"},
+			column.Body{Name: "body"},
+			column.Headers{Name: "headers"},
+			column.Path{Name: "key", Path: "key"},
+			column.Path{Name: "keyRaw", Path: "key", RawString: true},
+			column.Template{Name: "template", Template: column.TemplateConfig{Language: column.TemplateLanguageJsonnet, Content: `{"sum": Body("a") + 1}`}},
+		},
+	}
+
+	var out bytes.Buffer
+	enc, err := ndjson.NewEncoder(1*datasize.MB, mapping, &out, newNotifier)
+	require.NoError(t, err)
+
+	r, err := enc.WriteRecord(newRecord("2000-01-01T01:00:00.000Z", "{\n  \"a\": 1,\n  \"key\": \"<value>\"\n}"))
+	require.NoError(t, err)
+	assert.NotNil(t, r.Notifier)
+	assert.Equal(t, out.Len(), r.N)
+
+	require.NoError(t, enc.Flush())
+	require.NoError(t, enc.Close())
+
+	assert.Equal(t,
+		`{"datetime":"2000-01-01T01:00:00.000Z","body":{"a":1,"key":"<value>"},"headers":{"Content-Type":"application/json"},"key":"<value>","keyRaw":"<value>","template":{"sum":2}}`+"\n",
+		out.String(),
+	)
+}
+
+func TestNDJSONEncoder_NotJSONBody(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Body{Name: "body"},
+		},
+	}
+
+	var out bytes.Buffer
+	enc, err := ndjson.NewEncoder(1*datasize.MB, mapping, &out, newNotifier)
+	require.NoError(t, err)
+
+	record := recordctx.FromHTTP(
+		utctime.MustParse("2000-01-01T01:00:00.000Z").Time(),
+		&http.Request{Body: io.NopCloser(strings.NewReader("foo\nbar"))},
+	)
+	_, err = enc.WriteRecord(record)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"body":"foo\nbar"}`+"\n", out.String())
+}
+
+func TestNDJSONEncoderAboveLimit(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Datetime{Name: "datetime"},
+			column.Body{Name: "body"},
+		},
+	}
+
+	enc, err := ndjson.NewEncoder(60*datasize.B, mapping, io.Discard, newNotifier)
+	require.NoError(t, err)
+
+	_, err = enc.WriteRecord(newRecord("2000-01-01T03:00:00.000Z", `"foo"`))
+	require.NoError(t, err)
+
+	_, err = enc.WriteRecord(newRecord("2000-01-01T03:00:00.000Z", `"foobartoomuch"`))
+	if assert.Error(t, err) {
+		assert.Equal(t, `too big NDJSON row, column: "body", row limit: 60 B`, err.Error())
+	}
+}
+
+func newRecord(timestamp, body string) recordctx.Context {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	return recordctx.FromHTTP(
+		utctime.MustParse(timestamp).Time(),
+		&http.Request{Header: header, Body: io.NopCloser(strings.NewReader(body))},
+	)
+}
+
+func newNotifier(_ context.Context) *notify.Notifier {
+	return notify.New()
+}
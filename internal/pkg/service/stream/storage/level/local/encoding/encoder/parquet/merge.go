@@ -0,0 +1,169 @@
+package parquet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/encoding/thrift"
+	"github.com/parquet-go/parquet-go/format"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// magic is at the start and at the end of each Parquet part.
+var magic = []byte("PAR1") //nolint:gochecknoglobals // constant
+
+// Merger merges row groups of multiple Parquet files to one Parquet file.
+// Each file has its own footer, so files cannot be simply concatenated.
+// The merged file is created with the schema of the first non-empty file.
+type Merger struct {
+	out    io.Writer
+	writer *parquet.Writer
+}
+
+func NewMerger(out io.Writer) *Merger {
+	return &Merger{out: out}
+}
+
+// Append appends all row groups of all complete parts of the file, pages are read on demand.
+// An empty file is skipped, it is a file to which nothing has been written yet.
+// An incomplete part is skipped, it contains only records which have not been synced, see SplitParts.
+func (m *Merger) Append(r io.ReaderAt, size int64) error {
+	if size == 0 {
+		return nil
+	}
+
+	parts, err := SplitParts(r, size)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		file, err := parquet.OpenFile(part, part.Size())
+		if err != nil {
+			return errors.PrefixError(err, `cannot open parquet file`)
+		}
+
+		if m.writer == nil {
+			m.writer = parquet.NewWriter(m.out, file.Schema())
+		}
+
+		for _, rowGroup := range file.RowGroups() {
+			if _, err := m.writer.WriteRowGroup(rowGroup); err != nil {
+				return errors.PrefixError(err, `cannot write row group`)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close writes the footer of the merged file.
+// Nothing is written, if all files were empty.
+func (m *Merger) Close() error {
+	if m.writer == nil {
+		return nil
+	}
+	return m.writer.Close()
+}
+
+// SplitParts returns complete Parquet parts of the slice file.
+//
+// The encoder writes a complete part, with its own footer, on each sync,
+// and a restarted writer appends new parts to the existing file.
+// Bytes which don't belong to a complete part are skipped,
+// they are the unsynced tail of a part, which was interrupted, for example, by a crash of the writer.
+//
+// The end of a part is recognized by the magic bytes after the footer.
+// The start of the part is computed from the footer, it is the end of the last column chunk minus its offset.
+func SplitParts(r io.ReaderAt, size int64) (parts []*io.SectionReader, err error) {
+	var partEnd int64 // end of the last complete part
+	var window [4]byte
+	var pos int64
+
+	buf := bufio.NewReader(io.NewSectionReader(r, 0, size))
+	for {
+		b, err := buf.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, errors.PrefixError(err, `cannot read parquet file`)
+		}
+
+		pos++
+		copy(window[:], window[1:])
+		window[3] = b
+		if pos < int64(len(magic)) || !bytes.Equal(window[:], magic) {
+			continue
+		}
+
+		if start, ok := partStart(r, partEnd, pos); ok {
+			parts = append(parts, io.NewSectionReader(r, start, pos-start))
+			partEnd = pos
+		}
+	}
+
+	return parts, nil
+}
+
+// partStart checks whether the magic bytes ending at the end position close a part starting at or after the minStart.
+func partStart(r io.ReaderAt, minStart, end int64) (int64, bool) {
+	// Part layout: magic | column chunks and indexes | footer | footer length | magic
+	footerLenPos := end - int64(len(magic)) - 4
+	if footerLenPos < minStart+int64(len(magic)) {
+		return 0, false
+	}
+
+	var footerLenBytes [4]byte
+	if _, err := r.ReadAt(footerLenBytes[:], footerLenPos); err != nil {
+		return 0, false
+	}
+
+	footerLen := int64(binary.LittleEndian.Uint32(footerLenBytes[:]))
+	footerPos := footerLenPos - footerLen
+	if footerLen == 0 || footerPos < minStart+int64(len(magic)) {
+		return 0, false
+	}
+
+	footer := make([]byte, footerLen)
+	if _, err := r.ReadAt(footer, footerPos); err != nil {
+		return 0, false
+	}
+
+	var metadata format.FileMetaData
+	if err := thrift.Unmarshal(new(thrift.CompactProtocol), footer, &metadata); err != nil {
+		return 0, false
+	}
+
+	// Offsets in the footer are relative to the start of the part, the footer follows the last column chunk or index
+	dataEnd := int64(len(magic))
+	for _, rowGroup := range metadata.RowGroups {
+		for _, col := range rowGroup.Columns {
+			chunkStart := col.MetaData.DataPageOffset
+			if col.MetaData.DictionaryPageOffset > 0 && col.MetaData.DictionaryPageOffset < chunkStart {
+				chunkStart = col.MetaData.DictionaryPageOffset
+			}
+			dataEnd = max(
+				dataEnd,
+				chunkStart+col.MetaData.TotalCompressedSize,
+				col.ColumnIndexOffset+int64(col.ColumnIndexLength),
+				col.OffsetIndexOffset+int64(col.OffsetIndexLength),
+			)
+		}
+	}
+
+	start := footerPos - dataEnd
+	if start < minStart {
+		return 0, false
+	}
+
+	var startMagic [4]byte
+	if _, err := r.ReadAt(startMagic[:], start); err != nil || !bytes.Equal(startMagic[:], magic) {
+		return 0, false
+	}
+
+	return start, true
+}
@@ -0,0 +1,266 @@
+// Package parquet provides the Parquet implementation of the encoder.Encoder.
+//
+// Records are buffered in memory and written as a new Parquet part on each Flush,
+// so each sync of the pipeline produces one complete part, with its own footer, in the file.
+// Synced records are therefore readable even if the writer is not closed properly.
+// A slice file may contain multiple parts, for example after a restart of the writer, see SplitParts.
+package parquet
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/parquet-go/parquet-go"
+
+	svcerrors "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/result"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync/notify"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const schemaName = "record"
+
+type Encoder struct {
+	columns      column.Columns
+	leafIndexes  []int
+	rowSizeLimit int
+	notifier     func(ctx context.Context) *notify.Notifier
+	out          io.Writer
+	schema       *parquet.Schema
+
+	lock sync.Mutex
+	// writer of the current part, it is created on the first row of the part
+	writer *parquet.Writer
+	rowBuf []parquet.Row
+}
+
+var columnRenderer = column.NewRenderer() //nolint:gochecknoglobals // contains Jsonnet VMs sync.Pool
+
+// NewEncoder creates Parquet writer and implements encoder.Encoder.
+// Column types are mapped by the NewSchema function.
+// In case of encoder accepts too big row, it returns error.
+func NewEncoder(
+	rowSizeLimit datasize.ByteSize,
+	mapping any,
+	out io.Writer,
+	notifier func(ctx context.Context) *notify.Notifier,
+) (*Encoder, error) {
+	tableMapping, ok := mapping.(table.Mapping)
+	if !ok {
+		return nil, errors.Errorf("parquet encoder supports only table mapping, given %v", mapping)
+	}
+
+	schema, err := NewSchema(tableMapping.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fields of a parquet group are sorted by name, map the mapping column index to the leaf column index
+	leafIndexes := make([]int, len(tableMapping.Columns))
+	for i, col := range tableMapping.Columns {
+		leaf, found := schema.Lookup(col.ColumnName())
+		if !found {
+			return nil, errors.Errorf(`column "%s" not found in the parquet schema`, col.ColumnName())
+		}
+		leafIndexes[i] = leaf.ColumnIndex
+	}
+
+	return &Encoder{
+		columns:      tableMapping.Columns,
+		leafIndexes:  leafIndexes,
+		rowSizeLimit: int(rowSizeLimit.Bytes()),
+		notifier:     notifier,
+		out:          out,
+		schema:       schema,
+		rowBuf:       make([]parquet.Row, 1),
+	}, nil
+}
+
+// NewSchema creates the Parquet schema for the columns:
+//   - A column with the column.Cast is stored as an optional column of the matching type,
+//     the "number" data type is stored as a double, the "json" data type as a string with the JSON logical type.
+//   - The column.Datetime is stored as a timestamp in milliseconds.
+//   - Other columns are stored as strings.
+func NewSchema(columns column.Columns) (*parquet.Schema, error) {
+	group := make(parquet.Group, len(columns))
+	for _, col := range columns {
+		if _, found := group[col.ColumnName()]; found {
+			return nil, errors.Errorf(`duplicate column "%s"`, col.ColumnName())
+		}
+		node, err := columnNode(col)
+		if err != nil {
+			return nil, err
+		}
+		group[col.ColumnName()] = node
+	}
+	return parquet.NewSchema(schemaName, group), nil
+}
+
+func columnNode(col column.Column) (parquet.Node, error) {
+	if _, ok := col.(column.Datetime); ok {
+		return parquet.Timestamp(parquet.Millisecond), nil
+	}
+
+	dataType := columnDataType(col)
+	switch dataType {
+	case "":
+		return parquet.String(), nil
+	case column.DataTypeInteger:
+		return parquet.Optional(parquet.Int(64)), nil
+	case column.DataTypeNumber:
+		return parquet.Optional(parquet.Leaf(parquet.DoubleType)), nil
+	case column.DataTypeBoolean:
+		return parquet.Optional(parquet.Leaf(parquet.BooleanType)), nil
+	case column.DataTypeTimestamp:
+		return parquet.Optional(parquet.Timestamp(parquet.Millisecond)), nil
+	case column.DataTypeJSON:
+		return parquet.Optional(parquet.JSON()), nil
+	default:
+		return nil, errors.Errorf(`unexpected data type "%s" of the column "%s"`, dataType, col.ColumnName())
+	}
+}
+
+func columnDataType(col column.Column) column.DataType {
+	if typed, ok := col.(column.Typed); ok && typed.ColumnCast() != nil {
+		return typed.ColumnCast().DataType
+	}
+	return ""
+}
+
+func (w *Encoder) WriteRecord(record recordctx.Context) (result.WriteRecordResult, error) {
+	// Map the record to tabular data
+	size := 0
+	row := make(parquet.Row, len(w.columns))
+	for i, col := range w.columns {
+		value, dataType, null, err := columnRenderer.TypedValue(col, record)
+		if err != nil {
+			return result.WriteRecordResult{}, errors.PrefixErrorf(err, "cannot convert column %q to Parquet value", col)
+		}
+
+		var b []byte
+		switch v := value.(type) {
+		case []byte:
+			// The body buffer is released after the write, so a copy is needed
+			b = append([]byte(nil), v...)
+		case string:
+			b = []byte(v)
+		default:
+			return result.WriteRecordResult{}, errors.Errorf(`cannot convert value of the column "%s" to the string: unexpected type "%T"`, col.ColumnName(), value)
+		}
+
+		size += len(b)
+		if size > w.rowSizeLimit {
+			limit := datasize.ByteSize(w.rowSizeLimit)
+			return result.WriteRecordResult{}, svcerrors.NewPayloadTooLargeError(errors.Errorf(`too big Parquet row, column: "%s", row limit: %s`, col.ColumnName(), limit.HumanReadable()))
+		}
+
+		// Row values must be ordered by the leaf column index
+		v, err := parquetValue(col, dataType, null, b)
+		if err != nil {
+			return result.WriteRecordResult{}, errors.PrefixErrorf(err, "cannot convert column %q to Parquet value", col)
+		}
+		row[w.leafIndexes[i]] = v.Level(0, v.DefinitionLevel(), w.leafIndexes[i])
+	}
+
+	// Write the row to the in-memory buffer of the current part
+	w.lock.Lock()
+	if w.writer == nil {
+		w.writer = parquet.NewWriter(w.out, w.schema)
+	}
+	w.rowBuf[0] = row
+	_, err := w.writer.WriteRows(w.rowBuf)
+	w.lock.Unlock()
+	if err != nil {
+		return result.WriteRecordResult{}, err
+	}
+
+	// Get notifier after successful written record
+	writeRecordResult := result.NewNotifierWriteRecordResult(size, w.notifier(record.Ctx()))
+
+	// Buffers can be released, values have been copied
+	record.ReleaseBuffers()
+
+	return writeRecordResult, nil
+}
+
+// parquetValue converts the rendered value to the Parquet value of the column type, see NewSchema.
+// Values of typed columns are already normalized by the column.Renderer, so they are always parseable.
+// The definition level of a value of an optional column is 1, the definition level of the null is 0.
+func parquetValue(col column.Column, dataType column.DataType, null bool, b []byte) (parquet.Value, error) {
+	if _, ok := col.(column.Datetime); ok {
+		t, err := time.Parse(column.TimeFormat, string(b))
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		return parquet.Int64Value(t.UnixMilli()), nil
+	}
+
+	if dataType == "" {
+		return parquet.ByteArrayValue(b), nil
+	}
+
+	if null {
+		return parquet.NullValue(), nil
+	}
+
+	var v parquet.Value
+	switch dataType {
+	case column.DataTypeInteger:
+		i, err := strconv.ParseInt(string(b), 10, 64)
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		v = parquet.Int64Value(i)
+	case column.DataTypeNumber:
+		f, err := strconv.ParseFloat(string(b), 64)
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		v = parquet.DoubleValue(f)
+	case column.DataTypeBoolean:
+		v = parquet.BooleanValue(string(b) == "true")
+	case column.DataTypeTimestamp:
+		t, err := time.Parse(column.TimeFormat, string(b))
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		v = parquet.Int64Value(t.UnixMilli())
+	case column.DataTypeJSON:
+		v = parquet.ByteArrayValue(b)
+	default:
+		return parquet.Value{}, errors.Errorf(`unexpected data type "%s"`, dataType)
+	}
+
+	return v.Level(0, 1, 0), nil
+}
+
+// Flush writes buffered rows as a new part, including the footer.
+// Nothing is written, if there is no buffered row.
+func (w *Encoder) Flush() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.closePart()
+}
+
+// Close writes remaining rows as a new part, including the footer.
+func (w *Encoder) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.closePart()
+}
+
+func (w *Encoder) closePart() error {
+	if w.writer == nil {
+		return nil
+	}
+	writer := w.writer
+	w.writer = nil
+	return writer.Close()
+}
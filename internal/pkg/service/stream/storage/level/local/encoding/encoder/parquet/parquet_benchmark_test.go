@@ -0,0 +1,188 @@
+package parquet_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/benchmark"
+)
+
+const (
+	benchmarkRecordBodyLength = 1 * datasize.KB
+	benchmarkUniqueRecords    = 1000
+)
+
+// BenchmarkParquetWrite benchmarks different configuration options of the parquet.Encoder.
+//
+// Run
+//
+//	go test -p 1 -benchmem ./internal/pkg/service/stream/storage/level/local/encoding/encoder/parquet/ -bench=. -benchtime=500000x -count 1 | tee benchmark.txt
+//
+// Optionally format results
+//
+//	benchstat benchmark.txt
+func BenchmarkParquetWrite(b *testing.B) {
+	cases := []struct {
+		Name      string
+		Configure func(wb *benchmark.WriterBenchmark)
+	}{
+		{
+			Name: "compression=None,sync=ToCache,wait=false",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Sync.Mode = writesync.ModeCache
+				wb.Sync.Wait = false
+			},
+		},
+		{
+			Name: "compression=None,sync=ToCache,wait=true",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Sync.Mode = writesync.ModeCache
+				wb.Sync.Wait = true
+			},
+		},
+		{
+			Name: "compression=None,sync=ToDisk,wait=false",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = false
+			},
+		},
+		{
+			Name: "compression=None,sync=ToDisk,wait=true",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = true
+			},
+		},
+		{
+			Name: "compression=GZIP_Standard_BestSpeed,sync=ToDisk,wait=true",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Compression = compression.NewGZIPConfig()
+				wb.Compression.GZIP.Implementation = compression.GZIPImplStandard
+				wb.Compression.GZIP.Level = gzip.BestSpeed
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = true
+			},
+		},
+		{
+			Name: "compression=GZIP_Standard_BestSpeed,sync=ToDisk,wait=false",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Compression = compression.NewGZIPConfig()
+				wb.Compression.GZIP.Implementation = compression.GZIPImplStandard
+				wb.Compression.GZIP.Level = gzip.BestSpeed
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = false
+			},
+		},
+		{
+			Name: "compression=GZIP_Fast_BestSpeed,sync=ToDisk,wait=true",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Compression = compression.NewGZIPConfig()
+				wb.Compression.GZIP.Implementation = compression.GZIPImplFast
+				wb.Compression.GZIP.Level = gzip.BestSpeed
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = true
+			},
+		},
+		{
+			Name: "compression=GZIP_Fast_BestSpeed,sync=ToDisk,wait=false",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Compression = compression.NewGZIPConfig()
+				wb.Compression.GZIP.Implementation = compression.GZIPImplFast
+				wb.Compression.GZIP.Level = gzip.BestSpeed
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = false
+			},
+		},
+		{
+			Name: "compression=GZIP_Parallel_BestSpeed,sync=ToDisk,wait=true",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Compression = compression.NewGZIPConfig()
+				wb.Compression.GZIP.Implementation = compression.GZIPImplParallel
+				wb.Compression.GZIP.Level = gzip.BestSpeed
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = true
+			},
+		},
+		{
+			Name: "compression=GZIP_Parallel_BestSpeed,sync=ToDisk,wait=false",
+			Configure: func(wb *benchmark.WriterBenchmark) {
+				wb.Compression = compression.NewGZIPConfig()
+				wb.Compression.GZIP.Implementation = compression.GZIPImplParallel
+				wb.Compression.GZIP.Level = gzip.BestSpeed
+				wb.Sync.Mode = writesync.ModeDisk
+				wb.Sync.Wait = false
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		b.Run(tc.Name, func(b *testing.B) {
+			newBenchmark(tc.Configure).Run(b)
+		})
+	}
+}
+
+func newBenchmark(configure func(wb *benchmark.WriterBenchmark)) *benchmark.WriterBenchmark {
+	columns := column.Columns{
+		column.UUID{Name: "uuid"},
+		column.Datetime{Name: "datetime"},
+		column.Body{Name: "body"},
+	}
+
+	wb := &benchmark.WriterBenchmark{
+		Parallelism: 10000,
+		Columns:     columns,
+		Allocate:    100 * datasize.MB,
+		Sync:        writesync.NewConfig(),
+		Compression: compression.NewNoneConfig(),
+		Encoder:     encoder.TypeParquet,
+		DataChFactory: func(ctx context.Context, n int, g *benchmark.RandomStringGenerator) <-chan recordctx.Context {
+			ch := make(chan recordctx.Context, 1000)
+			bodyLength := int(benchmarkRecordBodyLength.Bytes())
+
+			// Pre-generate unique records
+			records := make([]recordctx.Context, benchmarkUniqueRecords)
+			now := utctime.MustParse("2000-01-01T01:00:00.000Z")
+			for i := range benchmarkUniqueRecords {
+				now = now.Add(time.Hour)
+				records[i] = recordctx.FromHTTP(
+					now.Time(),
+					&http.Request{Body: io.NopCloser(strings.NewReader(g.RandomString(bodyLength)))},
+				)
+			}
+
+			// Send the pre-generated records to the channel over and over
+			go func() {
+				defer close(ch)
+				for i := range n {
+					if ctx.Err() != nil {
+						break
+					}
+					ch <- records[i%benchmarkUniqueRecords]
+				}
+			}()
+
+			return ch
+		},
+	}
+
+	if configure != nil {
+		configure(wb)
+	}
+
+	return wb
+}
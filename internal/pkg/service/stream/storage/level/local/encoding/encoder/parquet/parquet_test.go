@@ -0,0 +1,247 @@
+package parquet_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/parquet"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync/notify"
+)
+
+func TestParquetEncoder(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Datetime{Name: "datetime"},
+			column.Body{Name: "body"},
+		},
+	}
+
+	var out bytes.Buffer
+	enc, err := parquet.NewEncoder(1*datasize.MB, mapping, &out, newNotifier)
+	require.NoError(t, err)
+
+	// Each flush creates a new complete part
+	writeRecord(t, enc, "2000-01-01T01:00:00.000Z", "abc")
+	writeRecord(t, enc, "2000-01-01T02:00:00.000Z", `"def"`)
+	require.NoError(t, enc.Flush())
+
+	// The flushed part is readable before Close
+	rows, err := parquetgo.Read[testRow](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	// Flush without a new record writes nothing
+	size := out.Len()
+	require.NoError(t, enc.Flush())
+	assert.Equal(t, size, out.Len())
+
+	writeRecord(t, enc, "2000-01-01T03:00:00.000Z", "foo")
+	require.NoError(t, enc.Close())
+
+	// The file contains two parts
+	parts, err := parquet.SplitParts(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	assert.Len(t, parts, 2)
+
+	// Merge parts to one file
+	assert.Equal(t, []testRow{
+		{Datetime: utctime.MustParse("2000-01-01T01:00:00.000Z").Time(), Body: "abc"},
+		{Datetime: utctime.MustParse("2000-01-01T02:00:00.000Z").Time(), Body: `"def"`},
+		{Datetime: utctime.MustParse("2000-01-01T03:00:00.000Z").Time(), Body: "foo"},
+	}, mergeRows(t, out.Bytes()))
+}
+
+// TestParquetEncoderRestart tests that a restarted writer appends new parts to the same file
+// and the incomplete part, interrupted by a crash, is skipped.
+func TestParquetEncoderRestart(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Datetime{Name: "datetime"},
+			column.Body{Name: "body"},
+		},
+	}
+
+	// The first writer session syncs one record
+	var out bytes.Buffer
+	enc1, err := parquet.NewEncoder(1*datasize.MB, mapping, &out, newNotifier)
+	require.NoError(t, err)
+	writeRecord(t, enc1, "2000-01-01T01:00:00.000Z", "abc")
+	require.NoError(t, enc1.Flush())
+
+	// The second part is interrupted by a crash, it is not synced
+	synced := out.Len()
+	writeRecord(t, enc1, "2000-01-01T02:00:00.000Z", "lost")
+	require.NoError(t, enc1.Flush())
+	out.Truncate(synced + (out.Len()-synced)/2)
+
+	// The writer is restarted, a new encoder appends to the same file
+	enc2, err := parquet.NewEncoder(1*datasize.MB, mapping, &out, newNotifier)
+	require.NoError(t, err)
+	writeRecord(t, enc2, "2000-01-01T03:00:00.000Z", "def")
+	require.NoError(t, enc2.Flush())
+	writeRecord(t, enc2, "2000-01-01T04:00:00.000Z", "foo")
+	require.NoError(t, enc2.Close())
+
+	assert.Equal(t, []testRow{
+		{Datetime: utctime.MustParse("2000-01-01T01:00:00.000Z").Time(), Body: "abc"},
+		{Datetime: utctime.MustParse("2000-01-01T03:00:00.000Z").Time(), Body: "def"},
+		{Datetime: utctime.MustParse("2000-01-01T04:00:00.000Z").Time(), Body: "foo"},
+	}, mergeRows(t, out.Bytes()))
+}
+
+func TestParquetEncoderTypedColumns(t *testing.T) {
+	t.Parallel()
+
+	cast := func(dataType column.DataType) *column.Cast {
+		return &column.Cast{DataType: dataType}
+	}
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Path{Name: "int", Path: "int", Cast: cast(column.DataTypeInteger)},
+			column.Path{Name: "num", Path: "num", Cast: cast(column.DataTypeNumber)},
+			column.Path{Name: "bool", Path: "bool", Cast: cast(column.DataTypeBoolean)},
+			column.Path{Name: "time", Path: "time", Cast: cast(column.DataTypeTimestamp)},
+			column.Path{Name: "obj", Path: "obj", Cast: cast(column.DataTypeJSON)},
+			column.Path{Name: "str", Path: "str", RawString: true},
+		},
+	}
+
+	// Check schema
+	schema, err := parquet.NewSchema(mapping.Columns)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(`
+message record {
+	optional boolean bool;
+	optional int64 int (INT(64,true));
+	optional double num;
+	optional binary obj (JSON);
+	required binary str (STRING);
+	optional int64 time (TIMESTAMP(isAdjustedToUTC=true,unit=MILLIS));
+}
+`), strings.TrimSpace(schema.String()))
+
+	var out bytes.Buffer
+	enc, err := parquet.NewEncoder(1*datasize.MB, mapping, &out, newNotifier)
+	require.NoError(t, err)
+
+	_, err = enc.WriteRecord(newJSONRecord(`{"int":"12","num":1.5,"bool":"1","time":"2024-01-02T03:04:05Z","obj":{"a":1},"str":"foo"}`))
+	require.NoError(t, err)
+	_, err = enc.WriteRecord(newJSONRecord(`{"int":null,"num":null,"bool":null,"time":null,"obj":null,"str":"bar"}`))
+	require.NoError(t, err)
+	require.NoError(t, enc.Close())
+
+	type row struct {
+		Int  *int64     `parquet:"int,optional"`
+		Num  *float64   `parquet:"num,optional"`
+		Bool *bool      `parquet:"bool,optional"`
+		Time *time.Time `parquet:"time,optional,timestamp(millisecond)"`
+		Obj  *string    `parquet:"obj,optional,json"`
+		Str  string     `parquet:"str"`
+	}
+	rows, err := parquetgo.Read[row](bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, row{
+		Int:  new(int64(12)),
+		Num:  new(1.5),
+		Bool: new(true),
+		Time: new(utctime.MustParse("2024-01-02T03:04:05.000Z").Time()),
+		Obj:  new(`{"a":1}`),
+		Str:  "foo",
+	}, rows[0])
+	assert.Equal(t, row{Str: "bar"}, rows[1])
+}
+
+func TestParquetEncoderAboveLimit(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Datetime{Name: "datetime"},
+			column.Body{Name: "body"},
+		},
+	}
+
+	enc, err := parquet.NewEncoder(30*datasize.B, mapping, io.Discard, newNotifier)
+	require.NoError(t, err)
+
+	writeRecord(t, enc, "2000-01-01T03:00:00.000Z", "foobar")
+
+	_, err = enc.WriteRecord(newRecord("2000-01-01T03:00:00.000Z", "foobartoomuch"))
+	if assert.Error(t, err) {
+		assert.Equal(t, `too big Parquet row, column: "body", row limit: 30 B`, err.Error())
+	}
+}
+
+func TestParquetEncoderDuplicateColumn(t *testing.T) {
+	t.Parallel()
+
+	mapping := table.Mapping{
+		Columns: column.Columns{
+			column.Datetime{Name: "foo"},
+			column.Body{Name: "foo"},
+		},
+	}
+
+	_, err := parquet.NewEncoder(1*datasize.MB, mapping, io.Discard, newNotifier)
+	if assert.Error(t, err) {
+		assert.Equal(t, `duplicate column "foo"`, err.Error())
+	}
+}
+
+type testRow struct {
+	Datetime time.Time `parquet:"datetime,timestamp(millisecond)"`
+	Body     string    `parquet:"body"`
+}
+
+func mergeRows(t *testing.T, file []byte) []testRow {
+	t.Helper()
+	var merged bytes.Buffer
+	merger := parquet.NewMerger(&merged)
+	require.NoError(t, merger.Append(bytes.NewReader(file), int64(len(file))))
+	require.NoError(t, merger.Close())
+	rows, err := parquetgo.Read[testRow](bytes.NewReader(merged.Bytes()), int64(merged.Len()))
+	require.NoError(t, err)
+	return rows
+}
+
+func writeRecord(t *testing.T, enc *parquet.Encoder, timestamp, body string) {
+	t.Helper()
+	r, err := enc.WriteRecord(newRecord(timestamp, body))
+	require.NoError(t, err)
+	assert.NotNil(t, r.Notifier)
+}
+
+func newRecord(timestamp, body string) recordctx.Context {
+	return recordctx.FromHTTP(
+		utctime.MustParse(timestamp).Time(),
+		&http.Request{Body: io.NopCloser(strings.NewReader(body))},
+	)
+}
+
+func newJSONRecord(body string) recordctx.Context {
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	return recordctx.FromHTTP(time.Now(), &http.Request{Header: header, Body: io.NopCloser(strings.NewReader(body))})
+}
+
+func newNotifier(_ context.Context) *notify.Notifier {
+	return notify.New()
+}
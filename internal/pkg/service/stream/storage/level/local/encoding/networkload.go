@@ -0,0 +1,75 @@
+package encoding
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+)
+
+// networkLoadSmoothing is a weight of the last observation in the moving averages.
+const networkLoadSmoothing = 0.2
+
+// errorRateHalfLife is the time after which the error rate is halved, if there is no chunk write.
+// Without the decay, a pipeline with a few failed writes would be avoided by the balancer forever,
+// because no new records are routed to it, so no new successful writes can lower the error rate.
+const errorRateHalfLife = 30 * time.Second
+
+// NetworkLoad describes the current load of the pipeline network output.
+// It is used for load balancing between disk writer nodes.
+type NetworkLoad struct {
+	// PendingBytes contains size of completed chunks, which have not been written to the network output yet.
+	PendingBytes datasize.ByteSize
+	// WriteLatency is an exponential moving average of the chunk write duration.
+	WriteLatency time.Duration
+	// ErrorRate is an exponential moving average of failed chunk writes, from 0.0 to 1.0.
+	// The value decays over time, see errorRateHalfLife.
+	ErrorRate float64
+}
+
+// networkLoadMeter measures latency and errors of the chunk writes.
+type networkLoadMeter struct {
+	lock      sync.RWMutex
+	latency   time.Duration
+	errorRate float64
+	// errorRateAt is the time of the last errorRate update, it is used for the decay
+	errorRateAt time.Time
+}
+
+func (m *networkLoadMeter) Observe(now time.Time, latency time.Duration, err error) {
+	var failed float64
+	if err != nil {
+		failed = 1
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Latency of a failed write is not representative
+	if err == nil {
+		if m.latency == 0 {
+			m.latency = latency
+		} else {
+			m.latency += time.Duration(networkLoadSmoothing * float64(latency-m.latency))
+		}
+	}
+
+	errorRate := m.decayedErrorRate(now)
+	m.errorRate = errorRate + networkLoadSmoothing*(failed-errorRate)
+	m.errorRateAt = now
+}
+
+func (m *networkLoadMeter) Values(now time.Time) (latency time.Duration, errorRate float64) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.latency, m.decayedErrorRate(now)
+}
+
+func (m *networkLoadMeter) decayedErrorRate(now time.Time) float64 {
+	elapsed := now.Sub(m.errorRateAt)
+	if m.errorRate == 0 || elapsed <= 0 {
+		return m.errorRate
+	}
+	return m.errorRate * math.Pow(0.5, float64(elapsed)/float64(errorRateHalfLife))
+}
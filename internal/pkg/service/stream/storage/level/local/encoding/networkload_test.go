@@ -0,0 +1,42 @@
+package encoding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+func TestNetworkLoadMeter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	m := &networkLoadMeter{}
+
+	// Latency is a moving average of successful writes
+	m.Observe(now, 100*time.Millisecond, nil)
+	m.Observe(now, 200*time.Millisecond, nil)
+	latency, errorRate := m.Values(now)
+	assert.Equal(t, 120*time.Millisecond, latency)
+	assert.InDelta(t, 0.0, errorRate, 0.0001)
+
+	// Latency of a failed write is ignored
+	m.Observe(now, time.Hour, errors.New("some error"))
+	latency, errorRate = m.Values(now)
+	assert.Equal(t, 120*time.Millisecond, latency)
+	assert.InDelta(t, 0.2, errorRate, 0.0001)
+
+	// The error rate decays without writes
+	latency, errorRate = m.Values(now.Add(errorRateHalfLife))
+	assert.Equal(t, 120*time.Millisecond, latency)
+	assert.InDelta(t, 0.1, errorRate, 0.0001)
+	_, errorRate = m.Values(now.Add(10 * errorRateHalfLife))
+	assert.Less(t, errorRate, 0.001)
+
+	// The next observation starts from the decayed value
+	m.Observe(now.Add(errorRateHalfLife), 100*time.Millisecond, errors.New("some error"))
+	_, errorRate = m.Values(now.Add(errorRateHalfLife))
+	assert.InDelta(t, 0.28, errorRate, 0.0001)
+}
@@ -47,6 +47,8 @@ type Pipeline interface {
 	IsReady() bool
 	// NetworkOutput returns the network output of the pipeline.
 	NetworkOutput() rpc.NetworkOutput
+	// NetworkLoad is used for load balancing, to prefer less loaded disk writer nodes.
+	NetworkLoad() NetworkLoad
 	// WriteRecord blocks until the record is written and synced to the local storage, if the wait is enabled.
 	WriteRecord(record recordctx.Context) (int, error)
 	// Events provides listening to the writer lifecycle.
@@ -74,6 +76,7 @@ type StatisticsProvider interface {
 // For conversion between record values and bytes, the encoder.Encoder is used.
 type pipeline struct {
 	logger    log.Logger
+	clock     clockwork.Clock
 	sliceKey  model.SliceKey
 	events    *events.Events[Pipeline]
 	flushLock sync.RWMutex
@@ -86,6 +89,7 @@ type pipeline struct {
 	telemetry    telemetry.Telemetry
 	localStorage localModel.Slice
 	network      rpc.NetworkOutput
+	networkLoad  networkLoadMeter
 	withBackup   bool
 	closeFunc    func(ctx context.Context, cause string)
 
@@ -124,6 +128,7 @@ func newPipeline(
 ) (out Pipeline, err error) {
 	p := &pipeline{
 		logger:       logger.WithComponent("encoding.pipeline"),
+		clock:        clk,
 		telemetry:    telemetry,
 		connections:  connections,
 		sliceKey:     sliceKey,
@@ -310,6 +315,15 @@ func (p *pipeline) NetworkOutput() rpc.NetworkOutput {
 	return p.network
 }
 
+func (p *pipeline) NetworkLoad() NetworkLoad {
+	latency, errorRate := p.networkLoad.Values(p.clock.Now())
+	return NetworkLoad{
+		PendingBytes: p.chunks.PendingBytes(),
+		WriteLatency: latency,
+		ErrorRate:    errorRate,
+	}
+}
+
 func (p *pipeline) WriteRecord(record recordctx.Context) (int, error) {
 	timestamp := record.Timestamp()
 
@@ -483,7 +497,10 @@ func (p *pipeline) processChunks(ctx context.Context, clk clockwork.Clock, encod
 				return err
 			}
 			l := datasize.ByteSize(length)
-			if _, err := p.network.Write(ctx, chunk.Aligned(), chunk.Bytes()); err != nil {
+			startTime := clk.Now()
+			_, err = p.network.Write(ctx, chunk.Aligned(), chunk.Bytes())
+			p.networkLoad.Observe(clk.Now(), clk.Since(startTime), err)
+			if err != nil {
 				if strings.HasSuffix(err.Error(), os.ErrClosed.Error()) {
 					// Open remote RPC file
 					p.network, err = rpc.OpenNetworkFile(
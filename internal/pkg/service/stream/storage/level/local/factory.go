@@ -3,6 +3,7 @@ package local
 import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/config"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
 )
 
@@ -13,9 +14,15 @@ func NewFile(path string, c config.Config) model.File {
 	}
 }
 
-func NewSlice(path string, compressionCfg compression.Config) (model.Slice, error) {
+func NewSlice(path string, encoderType encoder.Type, compressionCfg compression.Config) (model.Slice, error) {
+	// Filename extension according to the encoder type
+	extension, err := encoderType.FileExtension()
+	if err != nil {
+		return model.Slice{}, err
+	}
+
 	// Filename extension according to the compression type
-	extension, err := compression.Filename("csv", compressionCfg.Type)
+	extension, err = compression.Filename(extension, compressionCfg.Type)
 	if err != nil {
 		return model.Slice{}, err
 	}
@@ -6,12 +6,15 @@ import (
 	"github.com/c2h5oh/datasize"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
 )
 
 // Config configures for the staging storage.
 type Config struct {
-	Operator OperatorConfig `configKey:"operator"`
-	Upload   UploadConfig   `configKey:"upload"`
+	Operator    OperatorConfig   `configKey:"operator"`
+	Upload      UploadConfig     `configKey:"upload"`
+	Compression compression.Type `configKey:"compression" configUsage:"Compression type of slices in the staging storage, empty means the same as in the local storage. Slices are transcoded on upload, if the types differ." validate:"omitempty,oneof=none gzip zstd"`
+	Provider    ProviderConfig   `configKey:"provider"`
 }
 
 // ConfigPatch is same as the Config, but with optional/nullable fields.
@@ -37,6 +40,7 @@ func NewConfig() Config {
 				Interval: duration.From(30 * time.Second),
 			},
 		},
+		Provider: NewProviderConfig(),
 	}
 }
 
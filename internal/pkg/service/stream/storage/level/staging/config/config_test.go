@@ -21,6 +21,10 @@ func TestConfig_Validation(t *testing.T) {
 		Interval: duration.From(30*time.Minute + 1),
 	}
 
+	invalidProviderCfg := config.NewConfig()
+	invalidProviderCfg.Provider.S3.Enabled = true
+	invalidProviderCfg.Provider.Local.Enabled = true
+
 	// Test cases
 	cases := testvalidation.TestCases[config.Config]{
 		{
@@ -35,6 +39,7 @@ func TestConfig_Validation(t *testing.T) {
 - "upload.trigger.count" is a required field
 - "upload.trigger.size" is a required field
 - "upload.trigger.interval" is a required field
+- "provider.expiration" is a required field
 `,
 			Value: config.Config{},
 		},
@@ -47,6 +52,15 @@ func TestConfig_Validation(t *testing.T) {
 `,
 			Value: overMaximumCfg,
 		},
+		{
+			Name: "enabled providers without settings",
+			ExpectedError: `
+- "provider.s3.region" is a required field
+- "provider.s3.bucket" is a required field
+- "provider.local.path" is a required field
+`,
+			Value: invalidProviderCfg,
+		},
 		{
 			Name:  "default",
 			Value: config.NewConfig(),
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+)
+
+const (
+	ProviderTypeS3    ProviderType = "s3"
+	ProviderTypeLocal ProviderType = "local"
+)
+
+// ProviderType is a type of the staging provider independent of Keboola file resources.
+type ProviderType string
+
+// ProviderConfig configures staging providers of file sinks, each sink selects one of the enabled providers.
+// Files of a Keboola table sink are always staged in the Keboola Storage.
+type ProviderConfig struct {
+	Expiration duration.Duration   `configKey:"expiration" configUsage:"How long it is possible to write to a staging file of the provider." validate:"required,minDuration=1m,maxDuration=168h"`
+	S3         S3ProviderConfig    `configKey:"s3"`
+	Local      LocalProviderConfig `configKey:"local"`
+}
+
+// S3ProviderConfig configures an S3-compatible bucket, for example AWS S3 or MinIO.
+type S3ProviderConfig struct {
+	Enabled         bool   `configKey:"enabled" configUsage:"Enable the \"s3\" staging provider for file sinks."`
+	Endpoint        string `configKey:"endpoint" configUsage:"Endpoint of an S3-compatible API, for example MinIO. Empty means AWS S3."`
+	Region          string `configKey:"region" configUsage:"Region of the bucket." validate:"required_if=Enabled true"`
+	Bucket          string `configKey:"bucket" configUsage:"Name of the bucket." validate:"required_if=Enabled true"`
+	Prefix          string `configKey:"prefix" configUsage:"Prefix of all objects in the bucket."`
+	AccessKeyID     string `configKey:"accessKeyId" configUsage:"Access key ID. Empty means the default AWS credentials chain."`
+	SecretAccessKey string `configKey:"secretAccessKey" configUsage:"Secret access key." sensitive:"true"`
+	ForcePathStyle  bool   `configKey:"forcePathStyle" configUsage:"Use path-style addressing, it is required by MinIO."`
+}
+
+// LocalProviderConfig configures a directory in the local filesystem, it is intended for offline testing.
+type LocalProviderConfig struct {
+	Enabled bool   `configKey:"enabled" configUsage:"Enable the \"local\" staging provider for file sinks."`
+	Path    string `configKey:"path" configUsage:"Absolute path to the directory with staging files." validate:"required_if=Enabled true"`
+}
+
+func NewProviderConfig() ProviderConfig {
+	return ProviderConfig{
+		Expiration: duration.From(24 * time.Hour),
+	}
+}
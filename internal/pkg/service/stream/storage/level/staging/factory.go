@@ -10,11 +10,26 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
-func NewFile(encodingCfg encoding.Config, slicesUploadCfg config.UploadConfig, openedAt time.Time) stagingModel.File {
-	// Note: Compression in the staging storage is same as in the local storage, but it can be modified in the future.
+func NewFile(encodingCfg encoding.Config, stagingCfg config.Config, openedAt time.Time) stagingModel.File {
 	return stagingModel.File{
-		Compression: encodingCfg.Compression,
-		Upload:      slicesUploadCfg,
+		Compression: NewCompression(encodingCfg.Compression, stagingCfg.Compression),
+		Upload:      stagingCfg.Upload,
+	}
+}
+
+// NewCompression returns compression of the staging file.
+// The local compression is kept, if the staging type is empty or the same, then slices are uploaded without transcoding.
+// Otherwise, slices are transcoded on upload to the default configuration of the staging type.
+func NewCompression(localCompression compression.Config, stagingType compression.Type) compression.Config {
+	switch {
+	case stagingType == "" || stagingType == localCompression.Type:
+		return localCompression.Simplify()
+	case stagingType == compression.TypeGZIP:
+		return compression.NewGZIPConfig()
+	case stagingType == compression.TypeZSTD:
+		return compression.NewZSTDConfig()
+	default:
+		return compression.NewNoneConfig()
 	}
 }
 
@@ -25,6 +40,8 @@ func NewSlice(path string, f stagingModel.File) (stagingModel.Slice, error) {
 		// nop
 	case compression.TypeGZIP:
 		path += ".gz"
+	case compression.TypeZSTD:
+		path += ".zst"
 	default:
 		return stagingModel.Slice{}, errors.Errorf(`compression type "%s" is not supported by the staging storage`, f.Compression.Type)
 	}
@@ -0,0 +1,57 @@
+package staging_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	encoding "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+)
+
+func TestNewCompression(t *testing.T) {
+	t.Parallel()
+
+	local := compression.NewZSTDConfig()
+
+	// Same as local
+	assert.Equal(t, local.Simplify(), staging.NewCompression(local, ""))
+	assert.Equal(t, local.Simplify(), staging.NewCompression(local, compression.TypeZSTD))
+
+	// Transcoding
+	assert.Equal(t, compression.NewGZIPConfig(), staging.NewCompression(local, compression.TypeGZIP))
+	assert.Equal(t, compression.NewNoneConfig(), staging.NewCompression(local, compression.TypeNone))
+	assert.Equal(t, compression.NewZSTDConfig(), staging.NewCompression(compression.NewGZIPConfig(), compression.TypeZSTD))
+}
+
+func TestNewSlice_Extension(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		compression compression.Type
+		path        string
+	}{
+		{compression: compression.TypeNone, path: "slice"},
+		{compression: compression.TypeGZIP, path: "slice.gz"},
+		{compression: compression.TypeZSTD, path: "slice.zst"},
+	}
+
+	for _, tc := range cases {
+		encodingCfg := encoding.NewConfig()
+		encodingCfg.Compression = compression.NewZSTDConfig()
+		stagingCfg := config.NewConfig()
+		stagingCfg.Compression = tc.compression
+
+		file := staging.NewFile(encodingCfg, stagingCfg, time.Now())
+		assert.Equal(t, tc.compression, file.Compression.Type)
+
+		slice, err := staging.NewSlice("slice", file)
+		require.NoError(t, err)
+		assert.Equal(t, tc.path, slice.Path)
+		assert.Equal(t, file.Compression, slice.Compression)
+	}
+}
@@ -15,6 +15,8 @@ type File struct {
 	Compression compression.Config `json:"compression" validate:"required"`
 	// Expiration determines how long it is possible to write to the staging file, e.g. due to expiring credentials.
 	Expiration utctime.UTCTime `json:"expiration" validate:"required"`
+	// Retained is set, if the staging file is kept after the import, until the file is deleted by the cleanup, so it can be re-imported.
+	Retained bool `json:"retained,omitempty"`
 	// Upload - slices upload configuration.
 	Upload config.UploadConfig `json:"upload"`
 }
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/fileblob"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// ManifestFilename is a name of the file manifest, it is stored next to slices of the file.
+const ManifestFilename = "manifest.json"
+
+// Bucket stores slices of each file under the "<prefix>/<fileKey>/" prefix.
+// The manifest lists URLs of all uploaded slices of the file, it has the same format as the Keboola sliced file manifest,
+// extended by the encoder and compression of slices, so a consumer knows how to decode them.
+type Bucket struct {
+	bucket  *blob.Bucket
+	baseURL string
+}
+
+type Manifest struct {
+	Encoder     encoder.Type     `json:"encoder,omitempty"`
+	Compression compression.Type `json:"compression,omitempty"`
+	Entries     []ManifestEntry  `json:"entries"`
+}
+
+type ManifestEntry struct {
+	URL string `json:"url"`
+}
+
+// OpenLocalBucket opens a bucket backed by a directory in the local filesystem.
+func OpenLocalBucket(cfg config.LocalProviderConfig) (*Bucket, error) {
+	if cfg.Path == "" {
+		return nil, errors.New(`path of the local staging provider is not set`)
+	}
+
+	dir, err := filepath.Abs(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := fileblob.OpenBucket(dir, &fileblob.Options{CreateDir: true, NoTempDir: true, Metadata: fileblob.MetadataDontWrite})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bucket{bucket: bucket, baseURL: (&url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}).String()}, nil
+}
+
+func (b *Bucket) Close() error {
+	return b.bucket.Close()
+}
+
+// NewSliceWriter returns a writer of the slice object, the object is visible after a successful close.
+func (b *Bucket) NewSliceWriter(ctx context.Context, fileKey model.FileKey, slicePath string) (io.WriteCloser, error) {
+	return b.bucket.NewWriter(ctx, b.key(fileKey, slicePath), nil)
+}
+
+// NewSliceReader returns a reader of the slice object.
+func (b *Bucket) NewSliceReader(ctx context.Context, fileKey model.FileKey, slicePath string) (io.ReadCloser, error) {
+	return b.bucket.NewReader(ctx, b.key(fileKey, slicePath), nil)
+}
+
+// ListSlices returns sorted paths of all slices of the file uploaded so far.
+func (b *Bucket) ListSlices(ctx context.Context, fileKey model.FileKey) ([]string, error) {
+	var paths []string
+
+	prefix := b.key(fileKey, "")
+	iter := b.bucket.List(&blob.ListOptions{Prefix: prefix})
+	for {
+		obj, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		path := strings.TrimPrefix(obj.Key, prefix)
+		if obj.IsDir || path == ManifestFilename {
+			continue
+		}
+
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// UpdateManifest writes the manifest with all slices of the file uploaded so far.
+// The operation is not atomic, the caller must prevent concurrent updates of the same file.
+func (b *Bucket) UpdateManifest(ctx context.Context, fileKey model.FileKey, encoderType encoder.Type, compressionType compression.Type) (Manifest, error) {
+	paths, err := b.ListSlices(ctx, fileKey)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{Encoder: encoderType, Compression: compressionType, Entries: make([]ManifestEntry, 0, len(paths))}
+	for _, path := range paths {
+		manifest.Entries = append(manifest.Entries, ManifestEntry{URL: b.url(b.key(fileKey, path))})
+	}
+
+	content, err := json.Encode(manifest, true)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	if err := b.bucket.WriteAll(ctx, b.key(fileKey, ManifestFilename), content, &blob.WriterOptions{ContentType: "application/json"}); err != nil {
+		return Manifest{}, err
+	}
+
+	return manifest, nil
+}
+
+// ReadManifest reads the manifest of the file.
+func (b *Bucket) ReadManifest(ctx context.Context, fileKey model.FileKey) (Manifest, error) {
+	content, err := b.bucket.ReadAll(ctx, b.key(fileKey, ManifestFilename))
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var manifest Manifest
+	if err := json.Decode(content, &manifest); err != nil {
+		return Manifest{}, err
+	}
+
+	return manifest, nil
+}
+
+func (b *Bucket) key(fileKey model.FileKey, path string) string {
+	return fileKey.String() + "/" + path
+}
+
+func (b *Bucket) url(key string) string {
+	return b.baseURL + "/" + key
+}
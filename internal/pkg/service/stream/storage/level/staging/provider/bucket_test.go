@@ -0,0 +1,143 @@
+package provider_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/provider"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+)
+
+func TestLocalBucket(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "staging")
+	fileKey := test.NewFileKey()
+
+	bucket, err := provider.OpenLocalBucket(config.LocalProviderConfig{Path: dir})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, bucket.Close()) }()
+
+	// Upload slices
+	for _, path := range []string{"slice-2.csv.gz", "slice-1.csv.gz"} {
+		writer, err := bucket.NewSliceWriter(ctx, fileKey, path)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(path))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+	}
+
+	// Slices are stored in the directory of the file
+	content, err := os.ReadFile(filepath.Join(dir, fileKey.String(), "slice-1.csv.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, "slice-1.csv.gz", string(content))
+
+	// Manifest lists all slices, but not itself
+	manifest, err := bucket.UpdateManifest(ctx, fileKey, encoder.TypeCSV, compression.TypeGZIP)
+	require.NoError(t, err)
+	manifest, err = bucket.UpdateManifest(ctx, fileKey, encoder.TypeCSV, compression.TypeGZIP)
+	require.NoError(t, err)
+	expected := provider.Manifest{Encoder: encoder.TypeCSV, Compression: compression.TypeGZIP, Entries: []provider.ManifestEntry{
+		{URL: "file://" + filepath.ToSlash(dir) + "/" + fileKey.String() + "/slice-1.csv.gz"},
+		{URL: "file://" + filepath.ToSlash(dir) + "/" + fileKey.String() + "/slice-2.csv.gz"},
+	}}
+	assert.Equal(t, expected, manifest)
+
+	stored, err := bucket.ReadManifest(ctx, fileKey)
+	require.NoError(t, err)
+	assert.Equal(t, expected, stored)
+}
+
+// TestS3Bucket uses an in-process S3-compatible server, as a local stand-in for MinIO.
+func TestS3Bucket(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fileKey := test.NewFileKey()
+
+	backend := s3mem.New()
+	require.NoError(t, backend.CreateBucket("staging"))
+	server := httptest.NewServer(gofakes3.New(backend).Server())
+	defer server.Close()
+
+	bucket, err := provider.OpenS3Bucket(ctx, config.S3ProviderConfig{
+		Endpoint:        server.URL,
+		Region:          "us-east-1",
+		Bucket:          "staging",
+		Prefix:          "/my-prefix/",
+		AccessKeyID:     "key",
+		SecretAccessKey: "secret",
+		ForcePathStyle:  true,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, bucket.Close()) }()
+
+	// Upload slices
+	for _, path := range []string{"slice-2.csv.gz", "slice-1.csv.gz"} {
+		writer, err := bucket.NewSliceWriter(ctx, fileKey, path)
+		require.NoError(t, err)
+		_, err = writer.Write([]byte(path))
+		require.NoError(t, err)
+		require.NoError(t, writer.Close())
+	}
+
+	// Slices are stored under the prefix and the file key
+	object, err := backend.GetObject("staging", "my-prefix/"+fileKey.String()+"/slice-1.csv.gz", nil)
+	require.NoError(t, err)
+	content, err := io.ReadAll(object.Contents)
+	require.NoError(t, err)
+	require.NoError(t, object.Contents.Close())
+	assert.Equal(t, "slice-1.csv.gz", string(content))
+
+	// Slices can be read back
+	reader, err := bucket.NewSliceReader(ctx, fileKey, "slice-2.csv.gz")
+	require.NoError(t, err)
+	content, err = io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.Equal(t, "slice-2.csv.gz", string(content))
+
+	// Manifest lists all slices, but not itself
+	manifest, err := bucket.UpdateManifest(ctx, fileKey, encoder.TypeCSV, compression.TypeGZIP)
+	require.NoError(t, err)
+	manifest, err = bucket.UpdateManifest(ctx, fileKey, encoder.TypeCSV, compression.TypeGZIP)
+	require.NoError(t, err)
+	expected := provider.Manifest{Encoder: encoder.TypeCSV, Compression: compression.TypeGZIP, Entries: []provider.ManifestEntry{
+		{URL: "s3://staging/my-prefix/" + fileKey.String() + "/slice-1.csv.gz"},
+		{URL: "s3://staging/my-prefix/" + fileKey.String() + "/slice-2.csv.gz"},
+	}}
+	assert.Equal(t, expected, manifest)
+
+	stored, err := bucket.ReadManifest(ctx, fileKey)
+	require.NoError(t, err)
+	assert.Equal(t, expected, stored)
+}
+
+func TestLocalBucket_MissingPath(t *testing.T) {
+	t.Parallel()
+
+	_, err := provider.OpenLocalBucket(config.LocalProviderConfig{})
+	require.Error(t, err)
+	assert.Equal(t, "path of the local staging provider is not set", err.Error())
+}
+
+func TestS3Bucket_MissingBucket(t *testing.T) {
+	t.Parallel()
+
+	_, err := provider.OpenS3Bucket(context.Background(), config.S3ProviderConfig{Region: "us-east-1"})
+	require.Error(t, err)
+	assert.Equal(t, "bucket of the S3 staging provider is not set", err.Error())
+}
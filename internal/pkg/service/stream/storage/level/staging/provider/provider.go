@@ -0,0 +1,220 @@
+// Package provider implements staging providers of file sinks, independent of Keboola file resources.
+//
+// Each file sink selects one of the enabled providers, see definition.FileSink:
+//   - ProviderS3 uploads slices to an S3-compatible bucket, for example AWS S3 or MinIO.
+//   - ProviderLocal uploads slices to a directory in the local filesystem, so the pipeline can be tested fully offline.
+//
+// Files of a Keboola table sink are always staged in the Keboola Storage, see the bridge package.
+//
+// Files staged by a provider are kept in the bucket, see TargetProvider,
+// or merged to the local filesystem, if the localfs target provider is configured.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/distlock"
+	serviceError "github.com/keboola/keboola-as-code/internal/pkg/service/common/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/servicectx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskreader"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+	stagingModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/model"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	// ProviderS3 marks staging files stored in an S3-compatible bucket.
+	ProviderS3 = stagingModel.FileProvider(config.ProviderTypeS3)
+	// ProviderLocal marks staging files stored in the local filesystem.
+	ProviderLocal = stagingModel.FileProvider(config.ProviderTypeLocal)
+	// TargetProvider marks files which are only kept in the staging bucket.
+	// Slices and the manifest are already in the bucket, so the import only finishes the file.
+	TargetProvider = targetModel.Provider("staging")
+)
+
+// Providers contains all enabled staging providers.
+type Providers struct {
+	logger    log.Logger
+	config    config.ProviderConfig
+	plugins   *plugin.Plugins
+	providers map[stagingModel.FileProvider]*Provider
+}
+
+// Provider uploads slices to a bucket.
+type Provider struct {
+	logger log.Logger
+	locks  *distlock.Provider
+	name   stagingModel.FileProvider
+	bucket *Bucket
+}
+
+type dependencies interface {
+	Logger() log.Logger
+	Process() *servicectx.Process
+	Plugins() *plugin.Plugins
+	DistributedLockProvider() *distlock.Provider
+}
+
+// New opens buckets of all enabled providers and registers the providers to plugins.
+func New(ctx context.Context, d dependencies, cfg config.ProviderConfig) (*Providers, error) {
+	p := &Providers{
+		logger:    d.Logger().WithComponent("staging.provider"),
+		config:    cfg,
+		plugins:   d.Plugins(),
+		providers: make(map[stagingModel.FileProvider]*Provider),
+	}
+
+	if cfg.S3.Enabled {
+		bucket, err := OpenS3Bucket(ctx, cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		p.add(d, ProviderS3, bucket)
+	}
+
+	if cfg.Local.Enabled {
+		bucket, err := OpenLocalBucket(cfg.Local)
+		if err != nil {
+			return nil, err
+		}
+		p.add(d, ProviderLocal, bucket)
+	}
+
+	p.setupOnFileOpen()
+	p.plugins.RegisterFileImporter(TargetProvider, p.importFile)
+	return p, nil
+}
+
+// Get returns the enabled provider.
+func (p *Providers) Get(name stagingModel.FileProvider) (*Provider, bool) {
+	provider, ok := p.providers[name]
+	return provider, ok
+}
+
+// Len returns count of the enabled providers.
+func (p *Providers) Len() int {
+	return len(p.providers)
+}
+
+// Name returns the provider name stored in the stagingModel.File.Provider.
+func (p *Provider) Name() stagingModel.FileProvider {
+	return p.name
+}
+
+// Bucket returns the bucket of the provider.
+func (p *Provider) Bucket() *Bucket {
+	return p.bucket
+}
+
+func (p *Providers) add(d dependencies, name stagingModel.FileProvider, bucket *Bucket) {
+	provider := &Provider{
+		logger: p.logger.With(attribute.String("staging.provider", string(name))),
+		locks:  d.DistributedLockProvider(),
+		name:   name,
+		bucket: bucket,
+	}
+
+	d.Process().OnShutdown(func(ctx context.Context) {
+		if err := bucket.Close(); err != nil {
+			provider.logger.Errorf(ctx, "cannot close staging bucket: %s", err)
+		}
+	})
+
+	p.providers[name] = provider
+	p.plugins.RegisterSliceUploader(name, provider.uploadSlice)
+}
+
+// setupOnFileOpen sets the provider selected by the file sink.
+// The file is only kept in the bucket, if no other target provider is set later, see the localfs package.
+func (p *Providers) setupOnFileOpen() {
+	p.plugins.Collection().OnFileOpen(func(ctx context.Context, now time.Time, sink definition.Sink, file *model.File) error {
+		if sink.Type != definition.SinkTypeFile {
+			return nil
+		}
+
+		provider, ok := p.Get(stagingModel.FileProvider(sink.File.Provider))
+		if !ok {
+			return serviceError.NewBadRequestError(errors.Errorf(`staging provider "%s" is not enabled`, sink.File.Provider))
+		}
+
+		file.Mapping = sink.File.Mapping
+		file.StagingStorage.Provider = provider.name
+		file.StagingStorage.Expiration = utctime.From(now.Add(p.config.Expiration.Duration()))
+		file.TargetStorage.Provider = TargetProvider
+		return nil
+	})
+}
+
+// importFile finishes a file kept in the staging bucket, the manifest has been already updated by the last slice upload.
+func (p *Providers) importFile(ctx context.Context, file plugin.File, _ statistics.Value) error {
+	if file.IsEmpty {
+		p.logger.Info(ctx, "empty file, skipped import")
+		return nil
+	}
+
+	p.logger.Info(ctx, "file is kept in the staging bucket")
+	return nil
+}
+
+func (p *Provider) uploadSlice(ctx context.Context, volume *diskreader.Volume, slice plugin.Slice, _ statistics.Value) error {
+	// Skip upload if the slice is empty.
+	// The state is anyway switched to the SliceUploaded by the operator.
+	if slice.LocalStorage.IsEmpty {
+		p.logger.Info(ctx, "empty slice, skipped upload")
+		return nil
+	}
+
+	reader, err := volume.OpenReader(slice.SliceKey, slice.LocalStorage, slice.EncoderType, slice.EncodingCompression, slice.StagingStorage.Compression)
+	if err != nil {
+		p.logger.Warnf(ctx, "unable to open reader: %v", err)
+		return err
+	}
+
+	// Error when closing the reader is not a fatal error
+	defer func() {
+		if err := reader.Close(ctx); err != nil {
+			p.logger.Warnf(ctx, "unable to close reader: %v", err)
+		}
+	}()
+
+	// Upload slice
+	writer, err := p.bucket.NewSliceWriter(ctx, slice.FileKey, slice.StagingStorage.Path)
+	if err != nil {
+		return err
+	}
+	if _, err := reader.WriteTo(writer); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	// Update file manifest atomically, slices of the file are uploaded from multiple nodes
+	manifestLock := p.locks.NewMutex(fmt.Sprintf("upload.staging.%s.manifest.%s", p.name, slice.FileKey))
+	if err := manifestLock.Lock(ctx); err != nil {
+		p.logger.Errorf(ctx, "cannot acquire manifest lock %q: %s", manifestLock.Key(), err)
+		return err
+	}
+	defer func() {
+		unlockCtx, unlockCancel := context.WithTimeoutCause(context.WithoutCancel(ctx), 10*time.Second, errors.New("manifest unlock timeout"))
+		defer unlockCancel()
+		if err := manifestLock.Unlock(unlockCtx); err != nil {
+			p.logger.Warnf(ctx, "cannot unlock manifest lock %q: %s", manifestLock.Key(), err)
+		}
+	}()
+
+	_, err = p.bucket.UpdateManifest(ctx, slice.FileKey, slice.EncoderType, slice.StagingStorage.Compression.Type)
+	return err
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/s3blob"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// OpenS3Bucket opens an S3-compatible bucket, for example AWS S3 or MinIO.
+func OpenS3Bucket(ctx context.Context, cfg config.S3ProviderConfig) (*Bucket, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New(`bucket of the S3 staging provider is not set`)
+	}
+	if cfg.Region == "" {
+		return nil, errors.New(`region of the S3 staging provider is not set`)
+	}
+
+	awsCfg, err := awsConfig.LoadDefaultConfig(ctx, awsConfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	// Static credentials take precedence over the default credentials chain
+	if cfg.AccessKeyID != "" {
+		awsCfg.Credentials = credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, "")
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.ForcePathStyle
+	})
+
+	bucket, err := s3blob.OpenBucket(ctx, client, cfg.Bucket, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := (&url.URL{Scheme: "s3", Host: cfg.Bucket}).String()
+	if prefix := strings.Trim(cfg.Prefix, "/"); prefix != "" {
+		bucket = blob.PrefixedBucket(bucket, prefix+"/")
+		baseURL += "/" + prefix
+	}
+
+	return &Bucket{bucket: bucket, baseURL: baseURL}, nil
+}
@@ -12,6 +12,7 @@ import (
 type Config struct {
 	Operator OperatorConfig `configKey:"operator"`
 	Import   ImportConfig   `configKey:"import"`
+	Provider ProviderConfig `configKey:"provider"`
 }
 
 // ConfigPatch is same as the Config, but with optional/nullable fields.
@@ -23,6 +23,9 @@ func TestConfig_Validation(t *testing.T) {
 		Expiration:  duration.From(24 * time.Hour),
 	}
 
+	invalidProviderCfg := config.NewConfig()
+	invalidProviderCfg.Provider.Type = "foo"
+
 	// Test cases
 	cases := testvalidation.TestCases[config.Config]{
 		{
@@ -54,6 +57,11 @@ func TestConfig_Validation(t *testing.T) {
 `,
 			Value: overMaximumCfg,
 		},
+		{
+			Name:          "invalid provider type",
+			ExpectedError: `"provider.type" must be one of [localfs]`,
+			Value:         invalidProviderCfg,
+		},
 		{
 			Name:  "default",
 			Value: config.NewConfig(),
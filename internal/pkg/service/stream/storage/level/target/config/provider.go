@@ -0,0 +1,20 @@
+package config
+
+const (
+	ProviderTypeLocalFS ProviderType = "localfs"
+)
+
+// ProviderType is a type of the target provider independent of Keboola tables.
+type ProviderType string
+
+// ProviderConfig configures the target provider of files of file sinks.
+// Files are read from the staging provider, so it must be enabled too.
+type ProviderConfig struct {
+	Type    ProviderType          `configKey:"type" configUsage:"Target provider of files of file sinks: empty to keep files in the staging bucket or \"localfs\". It requires an enabled staging provider." validate:"omitempty,oneof=localfs"`
+	LocalFS LocalFSProviderConfig `configKey:"localfs"`
+}
+
+// LocalFSProviderConfig configures a directory in the local filesystem, it is intended for offline testing.
+type LocalFSProviderConfig struct {
+	Path string `configKey:"path" configUsage:"Absolute path to the directory with imported files."`
+}
@@ -0,0 +1,188 @@
+package localfs
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	compressionReader "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/reader"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	parquetEncoder "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/parquet"
+	stagingProvider "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/provider"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+const (
+	ManifestFilename = "manifest.json"
+	dataFilename     = "data"
+	dirPermission    = 0o750
+	filePermission   = 0o640
+)
+
+// Manifest describes an imported file.
+type Manifest struct {
+	FileKey          model.FileKey     `json:"fileKey"`
+	DataFile         string            `json:"dataFile"`
+	Columns          []string          `json:"columns"`
+	Slices           []string          `json:"slices"`
+	RecordsCount     uint64            `json:"recordsCount"`
+	UncompressedSize datasize.ByteSize `json:"uncompressedSize"`
+	FirstRecordAt    utctime.UTCTime   `json:"firstRecordAt"`
+	LastRecordAt     utctime.UTCTime   `json:"lastRecordAt"`
+}
+
+// writeFile merges all staged slices of the file into one decompressed data file and writes the manifest.
+// Both files are written to a temporary file first and then renamed, so a retried import overwrites the previous attempt.
+func writeFile(ctx context.Context, bucket *stagingProvider.Bucket, path string, file model.File, stats statistics.Value) (string, Manifest, error) {
+	slices, err := bucket.ListSlices(ctx, file.FileKey)
+	if err != nil {
+		return "", Manifest{}, err
+	}
+
+	dir := filepath.Join(path, filepath.FromSlash(file.FileKey.String()))
+	if err := os.MkdirAll(dir, dirPermission); err != nil {
+		return "", Manifest{}, err
+	}
+
+	manifest := Manifest{
+		FileKey:          file.FileKey,
+		DataFile:         dataFilename + "." + string(file.Encoding.Encoder.Type),
+		Columns:          make([]string, 0, len(file.Mapping.Columns)),
+		Slices:           slices,
+		RecordsCount:     stats.RecordsCount,
+		UncompressedSize: stats.UncompressedSize,
+		FirstRecordAt:    stats.FirstRecordAt,
+		LastRecordAt:     stats.LastRecordAt,
+	}
+	for _, c := range file.Mapping.Columns {
+		manifest.Columns = append(manifest.Columns, c.ColumnName())
+	}
+
+	// Merge slices
+	err = writeAtomic(filepath.Join(dir, manifest.DataFile), func(w io.Writer) error {
+		// Each Parquet slice is a standalone file with its own footer, slices cannot be simply concatenated
+		if file.Encoding.Encoder.Type == encoder.TypeParquet {
+			return mergeParquetSlices(ctx, w, bucket, file, slices)
+		}
+
+		for _, slicePath := range slices {
+			if err := copySlice(ctx, w, bucket, file, slicePath); err != nil {
+				return errors.Errorf(`cannot copy slice "%s": %w`, slicePath, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", Manifest{}, err
+	}
+
+	// Write manifest
+	content, err := json.Encode(manifest, true)
+	if err != nil {
+		return "", Manifest{}, err
+	}
+	err = writeAtomic(filepath.Join(dir, ManifestFilename), func(w io.Writer) error {
+		_, err := w.Write(content)
+		return err
+	})
+	if err != nil {
+		return "", Manifest{}, err
+	}
+
+	return dir, manifest, nil
+}
+
+func copySlice(ctx context.Context, w io.Writer, bucket *stagingProvider.Bucket, file model.File, slicePath string) (err error) {
+	reader, err := bucket.NewSliceReader(ctx, file.FileKey, slicePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	decompressed, err := compressionReader.New(reader, file.StagingStorage.Compression)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := decompressed.Close(); err == nil && closeErr != nil {
+			err = closeErr
+		}
+	}()
+
+	_, err = io.Copy(w, decompressed)
+	return err
+}
+
+func mergeParquetSlices(ctx context.Context, w io.Writer, bucket *stagingProvider.Bucket, file model.File, slices []string) error {
+	merger := parquetEncoder.NewMerger(w)
+	for _, slicePath := range slices {
+		if err := appendParquetSlice(ctx, merger, bucket, file, slicePath); err != nil {
+			return errors.Errorf(`cannot merge slice "%s": %w`, slicePath, err)
+		}
+	}
+	return merger.Close()
+}
+
+// appendParquetSlice decompresses the slice to a temporary file, Parquet footer is at the end of the file, so a random access is needed.
+func appendParquetSlice(ctx context.Context, merger *parquetEncoder.Merger, bucket *stagingProvider.Bucket, file model.File, slicePath string) (err error) {
+	tmp, err := os.CreateTemp("", "stream-slice-*.parquet")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmp.Close()
+		if removeErr := os.Remove(tmp.Name()); err == nil && removeErr != nil {
+			err = removeErr
+		}
+	}()
+
+	if err := copySlice(ctx, tmp, bucket, file, slicePath); err != nil {
+		return err
+	}
+
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	return merger.Append(tmp, size)
+}
+
+func writeAtomic(path string, fn func(w io.Writer) error) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if err := fn(tmp); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), filePermission); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
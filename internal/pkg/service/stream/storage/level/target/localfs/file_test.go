@@ -0,0 +1,160 @@
+package localfs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	parquetEncoder "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder/parquet"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync/notify"
+	stagingConfig "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+	stagingProvider "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/provider"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+)
+
+func TestWriteFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	targetPath := t.TempDir()
+
+	bucket, err := stagingProvider.OpenLocalBucket(stagingConfig.LocalProviderConfig{Path: t.TempDir()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, bucket.Close()) }()
+
+	file := test.NewFile()
+	file.StagingStorage.Compression = compression.NewGZIPConfig()
+
+	// Upload compressed slices
+	uploadSlice(t, bucket, file, "slice-1.csv.gz", "foo1\nbar1\n")
+	uploadSlice(t, bucket, file, "slice-2.csv.gz", "foo2\n")
+
+	stats := statistics.Value{
+		SlicesCount:      2,
+		FirstRecordAt:    utctime.MustParse("2000-01-01T01:00:00.000Z"),
+		LastRecordAt:     utctime.MustParse("2000-01-01T02:00:00.000Z"),
+		RecordsCount:     3,
+		UncompressedSize: 15,
+	}
+
+	// Import twice, the second import overwrites the first one
+	_, _, err = writeFile(ctx, bucket, targetPath, *file, stats)
+	require.NoError(t, err)
+	dir, manifest, err := writeFile(ctx, bucket, targetPath, *file, stats)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(targetPath, filepath.FromSlash(file.FileKey.String())), dir)
+
+	// Data file contains decompressed slices
+	data, err := os.ReadFile(filepath.Join(dir, "data.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo1\nbar1\nfoo2\n", string(data))
+
+	// Manifest
+	expected := Manifest{
+		FileKey:          file.FileKey,
+		DataFile:         "data.csv",
+		Columns:          []string{"body"},
+		Slices:           []string{"slice-1.csv.gz", "slice-2.csv.gz"},
+		RecordsCount:     3,
+		UncompressedSize: 15,
+		FirstRecordAt:    stats.FirstRecordAt,
+		LastRecordAt:     stats.LastRecordAt,
+	}
+	assert.Equal(t, expected, manifest)
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFilename))
+	require.NoError(t, err)
+	var stored Manifest
+	require.NoError(t, json.Decode(content, &stored))
+	assert.Equal(t, expected, stored)
+
+	// No temporary file remains
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestWriteFile_Parquet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	bucket, err := stagingProvider.OpenLocalBucket(stagingConfig.LocalProviderConfig{Path: t.TempDir()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, bucket.Close()) }()
+
+	file := test.NewFile()
+	file.Encoding.Encoder.Type = encoder.TypeParquet
+	file.StagingStorage.Compression = compression.NewGZIPConfig()
+	file.Mapping = table.Mapping{Columns: column.Columns{column.Body{Name: "body"}}}
+
+	// Each slice is a standalone Parquet file, the second slice is empty
+	uploadSlice(t, bucket, file, "slice-1.parquet.gz", encodeParquet(t, file.Mapping, "foo1", "bar1"))
+	uploadSlice(t, bucket, file, "slice-2.parquet.gz", "")
+	uploadSlice(t, bucket, file, "slice-3.parquet.gz", encodeParquet(t, file.Mapping, "foo2"))
+
+	dir, manifest, err := writeFile(ctx, bucket, t.TempDir(), *file, statistics.Value{RecordsCount: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "data.parquet", manifest.DataFile)
+
+	// Row groups of all slices are merged to one file
+	type row struct {
+		Body string `parquet:"body"`
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "data.parquet"))
+	require.NoError(t, err)
+	rows, err := parquetgo.Read[row](bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, []row{{Body: "foo1"}, {Body: "bar1"}, {Body: "foo2"}}, rows)
+}
+
+func encodeParquet(t *testing.T, mapping table.Mapping, bodies ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	enc, err := parquetEncoder.NewEncoder(datasize.MB, mapping, &out, func(context.Context) *notify.Notifier { return notify.New() })
+	require.NoError(t, err)
+	for _, body := range bodies {
+		_, err := enc.WriteRecord(recordctx.FromHTTP(time.Now(), &http.Request{Body: io.NopCloser(strings.NewReader(body))}))
+		require.NoError(t, err)
+	}
+	require.NoError(t, enc.Close())
+	return out.String()
+}
+
+func uploadSlice(t *testing.T, bucket *stagingProvider.Bucket, file *model.File, path, content string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := compressionWriter.New(&buf, file.StagingStorage.Compression)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	writer, err := bucket.NewSliceWriter(context.Background(), file.FileKey, path)
+	require.NoError(t, err)
+	_, err = writer.Write(buf.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+}
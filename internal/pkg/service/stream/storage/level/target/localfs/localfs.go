@@ -0,0 +1,118 @@
+// Package localfs implements the target provider, which merges staged slices of a file
+// into one decompressed file in a directory of the local filesystem.
+//
+// The provider imports files of file sinks, staged by a staging provider, see definition.FileSink.
+// Together with the local staging provider, the whole source → writer → reader → coordinator flow
+// can be run in integration tests and on dev machines without the Storage API.
+//
+// Each imported file is stored in the "<path>/<fileKey>/" directory:
+//   - "data.<encoder type>" contains data of all slices, for example "data.csv".
+//     Parquet slices are merged by row groups to one Parquet file, other slices are concatenated.
+//   - "manifest.json" describes the file, see Manifest.
+package localfs
+
+import (
+	"context"
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
+	stagingProvider "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/provider"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/config"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	storageRepo "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// Provider marks files imported to the local filesystem.
+const Provider = targetModel.Provider(config.ProviderTypeLocalFS)
+
+type Importer struct {
+	logger            log.Logger
+	plugins           *plugin.Plugins
+	storageRepository *storageRepo.Repository
+	staging           *stagingProvider.Providers
+	path              string
+}
+
+type dependencies interface {
+	Logger() log.Logger
+	Plugins() *plugin.Plugins
+	StorageRepository() *storageRepo.Repository
+	StagingProviders() *stagingProvider.Providers
+}
+
+// New registers the importer to plugins.
+// If the provider type is not configured, nil is returned.
+func New(d dependencies, cfg config.ProviderConfig) (*Importer, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case config.ProviderTypeLocalFS:
+		// continue
+	default:
+		return nil, errors.Errorf(`unexpected target provider type "%s"`, cfg.Type)
+	}
+
+	if cfg.LocalFS.Path == "" {
+		return nil, errors.New(`path of the localfs target provider is not set`)
+	}
+
+	// Slices are read from the staging provider
+	if d.StagingProviders().Len() == 0 {
+		return nil, errors.New(`the localfs target provider requires an enabled staging provider`)
+	}
+
+	i := &Importer{
+		logger:            d.Logger().WithComponent("target.localfs"),
+		plugins:           d.Plugins(),
+		storageRepository: d.StorageRepository(),
+		staging:           d.StagingProviders(),
+		path:              cfg.LocalFS.Path,
+	}
+
+	i.setupOnFileOpen()
+	i.plugins.RegisterFileImporter(Provider, i.importFile)
+	return i, nil
+}
+
+// setupOnFileOpen sets the provider to each file which would be otherwise only kept in the staging bucket.
+// The hook must be registered after the staging provider hook.
+func (i *Importer) setupOnFileOpen() {
+	i.plugins.Collection().OnFileOpen(func(ctx context.Context, now time.Time, sink definition.Sink, file *model.File) error {
+		if file.TargetStorage.Provider == stagingProvider.TargetProvider {
+			file.TargetStorage.Provider = Provider
+		}
+		return nil
+	})
+}
+
+func (i *Importer) importFile(ctx context.Context, file plugin.File, stats statistics.Value) error {
+	// Skip import if the file is empty.
+	// The state is anyway switched to the FileImported by the operator.
+	if file.IsEmpty {
+		i.logger.Info(ctx, "empty file, skipped import")
+		return nil
+	}
+
+	f, err := i.storageRepository.File().Get(file.FileKey).Do(ctx).ResultOrErr()
+	if err != nil {
+		return err
+	}
+
+	staging, ok := i.staging.Get(f.StagingStorage.Provider)
+	if !ok {
+		return model.NewNonRetryableError(errors.Errorf(`staging provider "%s" is not enabled`, f.StagingStorage.Provider))
+	}
+
+	dir, manifest, err := writeFile(ctx, staging.Bucket(), i.path, f, stats)
+	if err != nil {
+		return err
+	}
+
+	i.logger.Infof(ctx, `imported file to "%s", slices count %d`, dir, len(manifest.Slices))
+	return nil
+}
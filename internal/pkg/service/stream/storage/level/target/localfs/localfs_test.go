@@ -0,0 +1,189 @@
+package localfs_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/rollback"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/recordctx"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
+	stagingConfig "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+	targetConfig "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/localfs"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/testnode"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/testhelper"
+)
+
+type row struct {
+	Datetime time.Time `parquet:"datetime,timestamp(millisecond)"`
+	Body     string    `parquet:"body"`
+}
+
+// TestLocalFS_EndToEnd runs the whole pipeline offline: source → writer → reader → coordinator → file on disk.
+// Slices are staged by the local staging provider, so neither the Storage API nor S3 is used.
+func TestLocalFS_EndToEnd(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		encoder  encoder.Type
+		validate func(t *testing.T, data []byte)
+	}{
+		{
+			encoder: encoder.TypeCSV,
+			validate: func(t *testing.T, data []byte) {
+				t.Helper()
+				assert.Equal(t, "\"2000-01-01T00:00:00.000Z\",\"foo\"\n\"2000-01-01T01:00:00.000Z\",\"bar\"\n\"2000-01-01T02:00:00.000Z\",\"baz\"\n", string(data))
+			},
+		},
+		{
+			encoder: encoder.TypeNDJSON,
+			validate: func(t *testing.T, data []byte) {
+				t.Helper()
+				assert.Equal(t, `{"datetime":"2000-01-01T00:00:00.000Z","body":"foo"}`+"\n"+
+					`{"datetime":"2000-01-01T01:00:00.000Z","body":"bar"}`+"\n"+
+					`{"datetime":"2000-01-01T02:00:00.000Z","body":"baz"}`+"\n", string(data))
+			},
+		},
+		{
+			encoder: encoder.TypeParquet,
+			validate: func(t *testing.T, data []byte) {
+				t.Helper()
+				rows, err := parquetgo.Read[row](bytes.NewReader(data), int64(len(data)))
+				require.NoError(t, err)
+				assert.Equal(t, []row{
+					{Datetime: utctime.MustParse("2000-01-01T00:00:00.000Z").Time(), Body: "foo"},
+					{Datetime: utctime.MustParse("2000-01-01T01:00:00.000Z").Time(), Body: "bar"},
+					{Datetime: utctime.MustParse("2000-01-01T02:00:00.000Z").Time(), Body: "baz"},
+				}, rows)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.encoder), func(t *testing.T) {
+			t.Parallel()
+			testEndToEnd(t, tc.encoder, tc.validate)
+		})
+	}
+}
+
+func testEndToEnd(t *testing.T, encoderType encoder.Type, validate func(t *testing.T, data []byte)) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeoutCause(t.Context(), 60*time.Second, errors.New("test timeout"))
+	defer cancel()
+
+	logger := log.NewDebugLogger()
+	logger.ConnectTo(testhelper.VerboseStdout())
+	etcdCfg := etcdhelper.TmpNamespace(t)
+
+	stagingPath := t.TempDir()
+	targetPath := t.TempDir()
+	modifyConfig := func(cfg *config.Config) {
+		cfg.Storage.Level.Local.Writer.WatchDrainFile = false
+		cfg.Storage.Level.Local.Encoding.Encoder.Type = encoderType
+		cfg.Storage.Level.Staging.Operator.SliceRotationCheckInterval = duration.From(100 * time.Millisecond)
+		cfg.Storage.Level.Staging.Operator.SliceUploadCheckInterval = duration.From(500 * time.Millisecond)
+		cfg.Storage.Level.Staging.Provider.Local = stagingConfig.LocalProviderConfig{Enabled: true, Path: stagingPath}
+		cfg.Storage.Level.Target.Operator.FileRotationCheckInterval = duration.From(100 * time.Millisecond)
+		cfg.Storage.Level.Target.Operator.FileImportCheckInterval = duration.From(500 * time.Millisecond)
+		cfg.Storage.Level.Target.Provider = targetConfig.ProviderConfig{
+			Type:    targetConfig.ProviderTypeLocalFS,
+			LocalFS: targetConfig.LocalFSProviderConfig{Path: targetPath},
+		}
+	}
+
+	// Start nodes, the source node is the last one, so the connection log message is not truncated
+	apiNode, apiNodeMock := testnode.StartAPINode(t, ctx, logger, etcdCfg, modifyConfig)
+	coordinatorNode, _ := testnode.StartCoordinatorNode(t, ctx, logger, etcdCfg, modifyConfig)
+	writerNode, writerNodeMock := testnode.StartDiskWriterNode(t, ctx, logger, etcdCfg, 1, modifyConfig)
+	readerNode, _ := testnode.StartDiskReaderNode(t, ctx, logger, etcdCfg, writerNodeMock.TestConfig().Storage.VolumesPath, modifyConfig)
+	sourceNode, _ := testnode.StartSourceNode(t, ctx, logger, etcdCfg, modifyConfig)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		logger.AssertJSONMessages(c, `{"level":"info","message":"disk writer client connected from \"%s\" to \"disk-writer\" - \"%s\"","component":"storage.router.connections.client.transport"}`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Create a file sink
+	apiCtx := context.WithValue(ctx, dependencies.KeboolaProjectAPICtxKey, apiNodeMock.KeboolaProjectAPI())
+	apiCtx = rollback.ContextWith(apiCtx, rollback.New(apiNode.Logger()))
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 111}
+	branch := test.NewBranch(branchKey)
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	source := test.NewHTTPSource(sourceKey)
+	sink := test.NewFileSink(key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}, definition.StagingProviderLocal)
+	require.NoError(t, apiNode.DefinitionRepository().Branch().Create(&branch, apiNode.Clock().Now(), test.ByUser()).Do(apiCtx).Err())
+	require.NoError(t, apiNode.DefinitionRepository().Source().Create(&source, apiNode.Clock().Now(), test.ByUser(), "create").Do(apiCtx).Err())
+	require.NoError(t, apiNode.DefinitionRepository().Sink().Create(&sink, apiNode.Clock().Now(), test.ByUser(), "create").Do(apiCtx).Err())
+	files, err := apiNode.StorageRepository().File().ListIn(sink.SinkKey).Do(ctx).All()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	file := files[0]
+	assert.Equal(t, localfs.Provider, file.TargetStorage.Provider)
+
+	// Wait for pipeline initialization
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		logger.AssertJSONMessages(c, `{"level":"debug","message":"watch stream mirror synced to revision %s","component":"sink.router"}`)
+		logger.AssertJSONMessages(c, `{"level":"debug","message":"watch stream mirror synced to revision %s","component":"storage.router"}`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Write records through the source node
+	for i, body := range []string{"foo", "bar", "baz"} {
+		timestamp := utctime.MustParse("2000-01-01T00:00:00.000Z").Time().Add(time.Duration(i) * time.Hour)
+		result := sourceNode.SinkRouter().DispatchToSource(sourceKey, recordctx.FromHTTP(timestamp, &http.Request{Body: io.NopCloser(strings.NewReader(body))}))
+		require.Empty(t, result.ErrorName, result.Message)
+	}
+
+	// Rotate the file, the coordinator closes it, the reader uploads slices and the coordinator imports the file
+	require.NoError(t, apiNode.StorageRepository().File().Rotate(sink.SinkKey, apiNode.Clock().Now()).Do(apiCtx).Err())
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		imported, err := apiNode.StorageRepository().File().Get(file.FileKey).Do(ctx).ResultOrErr()
+		require.NoError(c, err)
+		assert.Equal(c, model.FileImported, imported.State)
+	}, 30*time.Second, 100*time.Millisecond)
+
+	// The imported file contains all records
+	dir := filepath.Join(targetPath, filepath.FromSlash(file.FileKey.String()))
+	data, err := os.ReadFile(filepath.Join(dir, "data."+string(encoderType)))
+	require.NoError(t, err)
+	validate(t, data)
+
+	content, err := os.ReadFile(filepath.Join(dir, localfs.ManifestFilename))
+	require.NoError(t, err)
+	var manifest localfs.Manifest
+	require.NoError(t, json.Decode(content, &manifest))
+	assert.Equal(t, []string{"datetime", "body"}, manifest.Columns)
+	assert.Equal(t, uint64(3), manifest.RecordsCount)
+	assert.Len(t, manifest.Slices, 1)
+
+	// Shutdown nodes
+	for _, d := range []dependencies.ServiceScope{apiNode, sourceNode, coordinatorNode, readerNode, writerNode} {
+		d.Process().Shutdown(ctx, errors.New("bye bye"))
+		d.Process().WaitForShutdown()
+	}
+
+	// No error should be logged
+	logger.AssertNoErrorMessage(t)
+}
@@ -1,12 +1,35 @@
 package model
 
-import "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/config"
+import (
+	"time"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/config"
+)
 
 type Target struct {
 	// Provider of the target data destination.
 	Provider Provider `json:"provider" validate:"required"`
 	// Import - file import configuration.
 	Import config.ImportConfig `json:"import"`
+	// Partition is set if the file contains records of one time-based partition of the target.
+	Partition *Partition `json:"partition,omitempty"`
 }
 
 type Provider string
+
+// Partition of the target, for example a daily partition table.
+// Each record is routed by the time value of the Column, records of the partition are written to slices of the file.
+// The file is rotated at the partition End, so records of the next partition are written to a new file.
+type Partition struct {
+	ID string `json:"id" validate:"required"`
+	// Column is name of the mapping column, which value determines the partition of a record.
+	Column string          `json:"column" validate:"required"`
+	Start  utctime.UTCTime `json:"start" validate:"required"`
+	End    utctime.UTCTime `json:"end" validate:"required"`
+}
+
+// Contains returns true, if the time t belongs to the partition.
+func (p Partition) Contains(t time.Time) bool {
+	return !t.Before(p.Start.Time()) && t.Before(p.End.Time())
+}
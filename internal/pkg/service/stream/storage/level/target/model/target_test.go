@@ -3,6 +3,7 @@ package model
 import (
 	"testing"
 
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
 	target "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/config"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/testvalidation"
 )
@@ -30,6 +31,33 @@ func TestFile_Validation(t *testing.T) {
 				Import:   target.NewConfig().Import,
 			},
 		},
+		{
+			Name: "empty partition",
+			ExpectedError: `
+- "partition.id" is a required field
+- "partition.column" is a required field
+- "partition.start" is a required field
+- "partition.end" is a required field
+`,
+			Value: Target{
+				Provider:  "foo",
+				Import:    target.NewConfig().Import,
+				Partition: &Partition{},
+			},
+		},
+		{
+			Name: "ok with partition",
+			Value: Target{
+				Provider: "foo",
+				Import:   target.NewConfig().Import,
+				Partition: &Partition{
+					ID:     "20240101",
+					Column: "datetime",
+					Start:  utctime.MustParse("2024-01-01T00:00:00.000Z"),
+					End:    utctime.MustParse("2024-01-02T00:00:00.000Z"),
+				},
+			},
+		},
 	}
 
 	cases.Run(t)
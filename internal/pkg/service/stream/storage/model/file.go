@@ -21,10 +21,12 @@ type File struct {
 	FileKey
 	Retryable
 	Deleted     bool             `json:"-"` // internal field to mark the entity for deletion, there is no soft delete
-	State       FileState        `json:"state" validate:"required,oneof=writing closing importing imported"`
-	ClosingAt   *utctime.UTCTime `json:"closingAt,omitempty" validate:"excluded_if=State writing,required_if=State closing,required_if=State importing,required_if=State imported"`
-	ImportingAt *utctime.UTCTime `json:"importingAt,omitempty" validate:"excluded_if=State writing,excluded_if=State closing,required_if=State importing,required_if=State imported"`
-	ImportedAt  *utctime.UTCTime `json:"importedAt,omitempty" validate:"excluded_if=State writing,excluded_if=State closing,excluded_if=State importing,required_if=State imported"`
+	State       FileState        `json:"state" validate:"required,oneof=writing closing importing imported reimporting"`
+	ClosingAt   *utctime.UTCTime `json:"closingAt,omitempty" validate:"excluded_if=State writing,required_if=State closing,required_if=State importing,required_if=State imported,required_if=State reimporting"`
+	ImportingAt *utctime.UTCTime `json:"importingAt,omitempty" validate:"excluded_if=State writing,excluded_if=State closing,required_if=State importing,required_if=State imported,required_if=State reimporting"`
+	ImportedAt  *utctime.UTCTime `json:"importedAt,omitempty" validate:"excluded_if=State writing,excluded_if=State closing,excluded_if=State importing,required_if=State imported,required_if=State reimporting"`
+	// Reimport is set, if the imported file is being imported again, see FileReimporting.
+	Reimport *FileReimport `json:"reimport,omitempty" validate:"required_if=State reimporting,excluded_unless=State reimporting"`
 	// Mapping defines how an incoming record is mapping to the result format, for example to a tabular data.
 	Mapping table.Mapping `json:"mapping"` // in the future, here can be an interface - multiple mapping ways
 	// Encoding defines how is the result format encoded, for example a tabular data to the CSV file.
@@ -37,6 +39,13 @@ type File struct {
 	TargetStorage targetModel.Target `json:"target"`
 }
 
+// FileReimport contains details of the requested re-import of an imported File.
+type FileReimport struct {
+	RequestedAt utctime.UTCTime `json:"requestedAt" validate:"required"`
+	// TableID optionally overrides the target table, the value is interpreted by the target provider.
+	TableID string `json:"tableId,omitempty"`
+}
+
 type FileKey struct {
 	key.SinkKey
 	FileID
@@ -69,6 +78,8 @@ func (v FileKey) Telemetry() []attribute.KeyValue {
 
 func (f File) LastStateChange() utctime.UTCTime {
 	switch {
+	case f.Reimport != nil:
+		return f.Reimport.RequestedAt
 	case f.ImportedAt != nil:
 		return *f.ImportedAt
 	case f.ImportingAt != nil:
@@ -257,6 +257,50 @@ func TestFile_Validation(t *testing.T) {
 				TargetStorage:  targetStorage,
 			},
 		},
+		{
+			Name: "file state reimporting",
+			Value: File{
+				FileKey:     testFileKey(),
+				State:       FileReimporting,
+				ClosingAt:   new(utctime.MustParse("2006-01-02T15:04:05.000Z")),
+				ImportingAt: new(utctime.MustParse("2006-01-02T16:04:05.000Z")),
+				ImportedAt:  new(utctime.MustParse("2006-01-02T17:04:05.000Z")),
+				Reimport:    &FileReimport{RequestedAt: utctime.MustParse("2006-01-02T18:04:05.000Z")},
+				Mapping: table.Mapping{
+					Columns: column.Columns{
+						column.UUID{Name: "uuid"},
+						column.Headers{Name: "headers"},
+						column.Body{Name: "body"},
+					},
+				},
+				Encoding:       encoding.NewConfig(),
+				LocalStorage:   localStorage,
+				StagingStorage: stagingStorage,
+				TargetStorage:  targetStorage,
+			},
+		},
+		{
+			Name:          "file state reimporting without reimport",
+			ExpectedError: `"reimport" is a required field`,
+			Value: File{
+				FileKey:     testFileKey(),
+				State:       FileReimporting,
+				ClosingAt:   new(utctime.MustParse("2006-01-02T15:04:05.000Z")),
+				ImportingAt: new(utctime.MustParse("2006-01-02T16:04:05.000Z")),
+				ImportedAt:  new(utctime.MustParse("2006-01-02T17:04:05.000Z")),
+				Mapping: table.Mapping{
+					Columns: column.Columns{
+						column.UUID{Name: "uuid"},
+						column.Headers{Name: "headers"},
+						column.Body{Name: "body"},
+					},
+				},
+				Encoding:       encoding.NewConfig(),
+				LocalStorage:   localStorage,
+				StagingStorage: stagingStorage,
+				TargetStorage:  targetStorage,
+			},
+		},
 	}
 
 	// Run test cases
@@ -26,10 +26,16 @@ const FileImporting FileState = "importing"
 // The File has been successfully imported to the target table.
 const FileImported FileState = "imported"
 
+// FileReimporting
+// The imported File is being imported again from the staging storage, for example after the target table has been truncated.
+// The re-import is requested by the API, the staged file must still be retained.
+const FileReimporting FileState = "reimporting"
+
 // FileState is an enum type for file states.
 //
 // Only following transitions are allowed:
 // FileWriting -> FileClosing -> FileImporting -> FileImported
+// FileImported -> FileReimporting -> FileImported
 //
 // Example File and Slice transitions.
 //
@@ -64,6 +70,11 @@ func (f File) WithState(at time.Time, to FileState) (File, error) {
 		f.ImportingAt = &atUTC
 	case from == FileImporting && to == FileImported:
 		f.ImportedAt = &atUTC
+	case from == FileImported && to == FileReimporting:
+		f.Reimport = &FileReimport{RequestedAt: atUTC}
+	case from == FileReimporting && to == FileImported:
+		// ImportedAt is kept, so the retention of the staged file is not extended by the re-import
+		f.Reimport = nil
 	default:
 		return File{}, serviceError.NewBadRequestError(errors.Errorf(`unexpected file "%s" state transition from "%s" to "%s"`, f.FileKey, from, to))
 	}
@@ -78,7 +89,7 @@ func (f FileState) Level() Level {
 	switch f {
 	case FileWriting, FileClosing:
 		return LevelLocal
-	case FileImporting:
+	case FileImporting, FileReimporting:
 		return LevelStaging
 	case FileImported:
 		return LevelTarget
@@ -59,6 +59,33 @@ func TestFile_StateTransition(t *testing.T) {
 		ImportedAt:  new(utctime.MustParse("2000-01-01T03:00:00.000Z")),
 	}, file)
 
+	// FileReimporting
+	clk.Advance(time.Hour)
+	file, err = file.WithState(clk.Now(), FileReimporting)
+	require.NoError(t, err)
+	assert.Equal(t, File{
+		FileKey:     fileKey,
+		State:       FileReimporting,
+		ClosingAt:   new(utctime.MustParse("2000-01-01T01:00:00.000Z")),
+		ImportingAt: new(utctime.MustParse("2000-01-01T02:00:00.000Z")),
+		ImportedAt:  new(utctime.MustParse("2000-01-01T03:00:00.000Z")),
+		Reimport:    &FileReimport{RequestedAt: utctime.MustParse("2000-01-01T04:00:00.000Z")},
+	}, file)
+	assert.Equal(t, LevelStaging, file.State.Level())
+	assert.Equal(t, utctime.MustParse("2000-01-01T04:00:00.000Z"), file.LastStateChange())
+
+	// FileImported again, ImportedAt is kept
+	clk.Advance(time.Hour)
+	file, err = file.WithState(clk.Now(), FileImported)
+	require.NoError(t, err)
+	assert.Equal(t, File{
+		FileKey:     fileKey,
+		State:       FileImported,
+		ClosingAt:   new(utctime.MustParse("2000-01-01T01:00:00.000Z")),
+		ImportingAt: new(utctime.MustParse("2000-01-01T02:00:00.000Z")),
+		ImportedAt:  new(utctime.MustParse("2000-01-01T03:00:00.000Z")),
+	}, file)
+
 	// Invalid
 	if _, err = file.WithState(clk.Now(), FileImporting); assert.Error(t, err) {
 		wildcards.Assert(t, `unexpected file "%s" state transition from "imported" to "importing"`, err.Error())
@@ -3,17 +3,13 @@ package model
 import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
-	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
 func SliceFilename(ft encoder.Type, ct compression.Type) (string, error) {
-	filename := sliceFilename
-	switch ft {
-	case encoder.TypeCSV:
-		filename += ".csv"
-	default:
-		return "", errors.Errorf(`unexpected encoder type "%s"`, ft)
+	extension, err := ft.FileExtension()
+	if err != nil {
+		return "", err
 	}
 
-	return compression.Filename(filename, ct)
+	return compression.Filename(sliceFilename+"."+extension, ct)
 }
@@ -21,6 +21,10 @@ func TestSliceFilename(t *testing.T) {
 		{"slice.csv", encoder.TypeCSV, compression.TypeNone},
 		{"slice.csv.gz", encoder.TypeCSV, compression.TypeGZIP},
 		{"slice.csv.zstd", encoder.TypeCSV, compression.TypeZSTD},
+		{"slice.parquet", encoder.TypeParquet, compression.TypeNone},
+		{"slice.parquet.gz", encoder.TypeParquet, compression.TypeGZIP},
+		{"slice.ndjson", encoder.TypeNDJSON, compression.TypeNone},
+		{"slice.ndjson.gz", encoder.TypeNDJSON, compression.TypeGZIP},
 		{"", "invalid", compression.TypeNone},
 		{"", encoder.TypeCSV, "invalid"},
 	}
@@ -14,6 +14,11 @@ func (r *Repository) closeFileOnSinkDeactivation() {
 	r.plugins.Collection().OnSinkDeactivation(func(ctx context.Context, now time.Time, by definition.By, original, sink *definition.Sink) error {
 		if r.plugins.IsSinkWithLocalStorage(sink.Type) {
 			op.AtomicOpCtxFrom(ctx).AddFrom(r.closeFilesInSink(sink.SinkKey, now))
+
+			// Close also files of the sink mirror, if any
+			if original.MirrorSink() != nil || sink.MirrorSink() != nil {
+				op.AtomicOpCtxFrom(ctx).AddFrom(r.closeFilesInSink(sink.SinkKey.Mirror(), now))
+			}
 		}
 		return nil
 	})
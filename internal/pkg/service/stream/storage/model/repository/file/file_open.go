@@ -20,7 +20,13 @@ import (
 func (r *Repository) openFileOnSinkActivation() {
 	r.plugins.Collection().OnSinkActivation(func(ctx context.Context, now time.Time, by definition.By, original, sink *definition.Sink) error {
 		if r.plugins.IsSinkWithLocalStorage(sink.Type) {
-			op.AtomicOpCtxFrom(ctx).AddFrom(r.openFileForSink(sink.SinkKey, now, plugin.SourceFromContext(ctx, sink.SourceKey), sink))
+			source := plugin.SourceFromContext(ctx, sink.SourceKey)
+			op.AtomicOpCtxFrom(ctx).AddFrom(r.openFileForSink(sink.SinkKey, now, source, sink))
+
+			// Open a file also for the sink mirror, if any
+			if mirror := sink.MirrorSink(); mirror != nil {
+				op.AtomicOpCtxFrom(ctx).AddFrom(r.openFileForSink(mirror.SinkKey, now, source, mirror))
+			}
 		}
 		return nil
 	})
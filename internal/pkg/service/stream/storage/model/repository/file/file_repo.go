@@ -155,11 +155,24 @@ func (r *Repository) loadSourceIfNil(atomicOp *op.AtomicOpCore, k key.SourceKey,
 
 // loadSinkIfNil - if the sink pointer is nil, a new value is allocated and later loaded,
 // it will be available after the atomic operation Read phase.
+// A sink mirror is derived from the owner sink, see definition.Sink.MirrorSink.
 func (r *Repository) loadSinkIfNil(atomicOp *op.AtomicOpCore, k key.SinkKey, sink *definition.Sink) *definition.Sink {
 	if sink == nil {
 		sink = &definition.Sink{}
 		atomicOp.Read(func(ctx context.Context) op.Op {
-			return r.definition.Sink().Get(k).WithResultTo(sink)
+			if !k.IsMirror() {
+				return r.definition.Sink().Get(k).WithResultTo(sink)
+			}
+			return r.definition.Sink().Get(k.Owner()).
+				WithResultValidator(func(owner definition.Sink) error {
+					if owner.MirrorSink() == nil {
+						return serviceError.NewResourceNotFoundError("sink mirror", k.SinkID.String(), "source")
+					}
+					return nil
+				}).
+				WithOnResult(func(owner definition.Sink) {
+					*sink = *owner.MirrorSink()
+				})
 		})
 	}
 	return sink
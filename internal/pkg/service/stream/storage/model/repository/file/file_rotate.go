@@ -27,7 +27,15 @@ func (r *Repository) rotateFileOnSinkModification() {
 		}
 
 		// Rotate file, close active file, if any, and open a new file
-		op.AtomicOpCtxFrom(ctx).AddFrom(r.rotate(now, sink.SinkKey, plugin.SourceFromContext(ctx, sink.SourceKey), sink))
+		source := plugin.SourceFromContext(ctx, sink.SourceKey)
+		op.AtomicOpCtxFrom(ctx).AddFrom(r.rotate(now, sink.SinkKey, source, sink))
+
+		// Rotate file of the sink mirror, or close the file, if the mirror has been removed
+		if mirror := sink.MirrorSink(); mirror != nil {
+			op.AtomicOpCtxFrom(ctx).AddFrom(r.rotate(now, mirror.SinkKey, source, mirror))
+		} else if old.MirrorSink() != nil {
+			op.AtomicOpCtxFrom(ctx).AddFrom(r.closeFilesInSink(sink.SinkKey.Mirror(), now))
+		}
 
 		return nil
 	})
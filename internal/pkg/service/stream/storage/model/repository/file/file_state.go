@@ -20,6 +20,12 @@ func withIsEmpty(isEmpty bool) switchStateOption {
 	}
 }
 
+func withReimportTableID(tableID string) switchStateOption {
+	return func(file *model.File) {
+		file.Reimport.TableID = tableID
+	}
+}
+
 func (r *Repository) SwitchToImporting(k model.FileKey, now time.Time, isEmpty bool) *op.AtomicOp[model.File] {
 	return r.stateTransition(k, now, model.FileClosing, model.FileImporting, withIsEmpty(isEmpty))
 }
@@ -28,6 +34,17 @@ func (r *Repository) SwitchToImported(k model.FileKey, now time.Time) *op.Atomic
 	return r.stateTransition(k, now, model.FileImporting, model.FileImported)
 }
 
+// SwitchToReimporting moves the imported file back to the staging level, so it is imported again by the file import operator.
+// The optional tableID overrides the target table.
+func (r *Repository) SwitchToReimporting(k model.FileKey, now time.Time, tableID string) *op.AtomicOp[model.File] {
+	return r.stateTransition(k, now, model.FileImported, model.FileReimporting, withReimportTableID(tableID))
+}
+
+// SwitchToReimported switches the re-imported file back to the imported state.
+func (r *Repository) SwitchToReimported(k model.FileKey, now time.Time) *op.AtomicOp[model.File] {
+	return r.stateTransition(k, now, model.FileReimporting, model.FileImported)
+}
+
 // stateTransition switch state of the file, state of the file slices is also atomically switched using plugin, if needed.
 func (r *Repository) stateTransition(k model.FileKey, now time.Time, from, to model.FileState, opts ...switchStateOption) *op.AtomicOp[model.File] {
 	var old, updated model.File
@@ -7,6 +7,7 @@ import (
 
 	"github.com/jonboulle/clockwork"
 	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/etcd/client/v3/concurrency"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model/repository/file"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/etcdhelper"
@@ -122,3 +124,106 @@ func TestFileRepository_StateTransition(t *testing.T) {
 	// -----------------------------------------------------------------------------------------------------------------
 	etcdhelper.AssertKVsFromFile(t, client, `fixtures/file_state_snapshot_001.txt`, etcdhelper.WithIgnoredKeyPattern("^definition/|storage/file/all/|storage/slice/|storage/secret/|storage/volume/|storage/stats/"))
 }
+
+func TestFileRepository_Reimport(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	clk := clockwork.NewFakeClockAt(utctime.MustParse("2000-01-01T01:00:00.000Z").Time())
+	by := test.ByUser()
+
+	// Fixtures
+	projectID := keboola.ProjectID(123)
+	branchKey := key.BranchKey{ProjectID: projectID, BranchID: 456}
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	sinkKey := key.SinkKey{SourceKey: sourceKey, SinkID: "my-sink"}
+
+	// Get services
+	d, mocked := dependencies.NewMockedStorageScope(t, ctx, commonDeps.WithClock(clk))
+	client := mocked.TestEtcdClient()
+	defRepo := d.DefinitionRepository()
+	storageRepo := d.StorageRepository()
+	fileRepo := storageRepo.File()
+	sliceRepo := storageRepo.Slice()
+	volumeRepo := storageRepo.Volume()
+
+	// Register active volumes
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		session, err := concurrency.NewSession(client)
+		require.NoError(t, err)
+		defer func() { require.NoError(t, session.Close()) }()
+		test.RegisterWriterVolumes(t, ctx, volumeRepo, session, 1)
+	}
+
+	// Create parent branch, source, sink, file, slice
+	// -----------------------------------------------------------------------------------------------------------------
+	var fileKey model.FileKey
+	{
+		branch := test.NewBranch(branchKey)
+		require.NoError(t, defRepo.Branch().Create(&branch, clk.Now(), by).Do(ctx).Err())
+		source := test.NewSource(sourceKey)
+		require.NoError(t, defRepo.Source().Create(&source, clk.Now(), by, "Create source").Do(ctx).Err())
+		sink := dummy.NewSinkWithLocalStorage(sinkKey)
+		require.NoError(t, defRepo.Sink().Create(&sink, clk.Now(), by, "Create sink").Do(ctx).Err())
+		fileKey = model.FileKey{SinkKey: sinkKey, FileID: model.FileID{OpenedAt: utctime.From(clk.Now())}}
+	}
+
+	// Import the file
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		clk.Advance(time.Hour)
+		require.NoError(t, fileRepo.Rotate(sinkKey, clk.Now()).Do(ctx).Err())
+		require.NoError(t, sliceRepo.ListIn(fileKey).ForEach(func(s model.Slice, header *iterator.Header) error {
+			if err := sliceRepo.SwitchToUploading(s.SliceKey, clk.Now(), false).Do(ctx).Err(); err != nil {
+				return err
+			}
+			return sliceRepo.SwitchToUploaded(s.SliceKey, clk.Now()).Do(ctx).Err()
+		}).Do(ctx).Err())
+		require.NoError(t, fileRepo.SwitchToImporting(fileKey, clk.Now(), false).Do(ctx).Err())
+		require.NoError(t, fileRepo.SwitchToImported(fileKey, clk.Now()).Do(ctx).Err())
+	}
+
+	// The file can be re-imported only from the imported state
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		err := fileRepo.SwitchToReimported(fileKey, clk.Now()).Do(ctx).Err()
+		require.ErrorIs(t, err, file.ErrInvalidStateTransition)
+	}
+
+	// Switch file to the Reimporting state, the file is moved to the staging level, slices are kept imported
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		clk.Advance(time.Hour)
+		f, err := fileRepo.SwitchToReimporting(fileKey, clk.Now(), "in.c-bucket.other").Do(ctx).ResultOrErr()
+		require.NoError(t, err)
+		assert.Equal(t, model.FileReimporting, f.State)
+		assert.Equal(t, &model.FileReimport{RequestedAt: utctime.From(clk.Now()), TableID: "in.c-bucket.other"}, f.Reimport)
+
+		files, err := fileRepo.ListInLevel(sinkKey, model.LevelStaging).Do(ctx).All()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, fileKey, files[0].FileKey)
+
+		slices, err := sliceRepo.ListIn(fileKey).Do(ctx).All()
+		require.NoError(t, err)
+		for _, s := range slices {
+			assert.Equal(t, model.SliceImported, s.State)
+		}
+	}
+
+	// Switch file back to the Imported state
+	// -----------------------------------------------------------------------------------------------------------------
+	{
+		clk.Advance(time.Hour)
+		f, err := fileRepo.SwitchToReimported(fileKey, clk.Now()).Do(ctx).ResultOrErr()
+		require.NoError(t, err)
+		assert.Equal(t, model.FileImported, f.State)
+		assert.Nil(t, f.Reimport)
+
+		files, err := fileRepo.ListInLevel(sinkKey, model.LevelTarget).Do(ctx).All()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, fileKey, files[0].FileKey)
+	}
+}
@@ -34,7 +34,8 @@ func (r *Repository) newSlice(now time.Time, file model.File, volumeID volume.ID
 	s.State = model.SliceWriting
 	s.Mapping = file.Mapping
 	s.Encoding = file.Encoding
-	if s.LocalStorage, err = local.NewSlice(localDir, file.Encoding.Compression); err != nil {
+	s.Partition = file.TargetStorage.Partition
+	if s.LocalStorage, err = local.NewSlice(localDir, file.Encoding.Encoder.Type, file.Encoding.Compression); err != nil {
 		return model.Slice{}, err
 	}
 	if s.StagingStorage, err = staging.NewSlice(stagingPath, file.StagingStorage); err != nil {
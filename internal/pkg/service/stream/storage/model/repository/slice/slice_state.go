@@ -37,7 +37,8 @@ func (r *Repository) SwitchToUploaded(k model.SliceKey, now time.Time) *op.Atomi
 
 func (r *Repository) updateSlicesOnFileImport() {
 	r.plugins.Collection().OnFileSave(func(ctx context.Context, now time.Time, original, file *model.File) error {
-		if original != nil && original.State != file.State && file.State == model.FileImported {
+		// Slices of a re-imported file are already imported
+		if original != nil && original.State == model.FileImporting && file.State == model.FileImported {
 			op.AtomicOpCtxFrom(ctx).AddFrom(r.switchSlicesToImported(*file, now))
 		}
 		return nil
@@ -163,7 +164,7 @@ func validateFileAndSliceState(fileState model.FileState, sliceState model.Slice
 		if sliceState == model.SliceUploaded {
 			return nil
 		}
-	case model.FileImported:
+	case model.FileImported, model.FileReimporting:
 		// Slice must be marked as imported
 		if sliceState == model.SliceImported {
 			return nil
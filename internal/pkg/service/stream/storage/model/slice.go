@@ -9,6 +9,7 @@ import (
 	localModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
 	stagingModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/model"
+	targetModel "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/target/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
 
@@ -29,6 +30,8 @@ type Slice struct {
 	Mapping table.Mapping `json:"mapping"` // in the future, here can be an interface - multiple mapping ways
 	// Encoding defines how is the result format encoded, for example a tabular data to the CSV file.
 	Encoding encoding.Config `json:"encoding"`
+	// Partition is copied from the file, only records of the partition are written to the slice, see targetModel.Partition.
+	Partition *targetModel.Partition `json:"partition,omitempty"`
 	// LocalStorage contains details of how the slice is stored in the local storage.
 	LocalStorage localModel.Slice `json:"local"`
 	// StagingStorage contains details of how the slice is uploaded to the staging storage.
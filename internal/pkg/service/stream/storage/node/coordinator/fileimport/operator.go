@@ -138,6 +138,7 @@ func Start(d dependencies, config targetConfig.OperatorConfig) error {
 						FileKey:  file.FileKey,
 						IsEmpty:  file.StagingStorage.IsEmpty,
 						Provider: file.TargetStorage.Provider,
+						Reimport: file.Reimport,
 					},
 					State: file.State,
 					Retry: file.Retryable,
@@ -268,13 +269,13 @@ func (o *operator) checkFile(ctx context.Context, file *fileData) {
 	}
 
 	// Skip file import if sink is deleted or disabled
-	sink, ok := o.sinks.Get(file.SinkKey)
+	sink, ok := o.sinks.Get(file.SinkKey.Owner())
 	if !ok || !sink.Enabled {
 		return
 	}
 
 	switch file.State {
-	case model.FileImporting:
+	case model.FileImporting, model.FileReimporting:
 		o.importFile(ctx, file)
 	default:
 		// nop
@@ -367,9 +368,17 @@ func (o *operator) doImportFile(ctx context.Context, lock *etcdop.Mutex, file *f
 		return statistics.Value{}, errors.PrefixError(err, "cannot get file statistics")
 	}
 
+	// Slices of a re-imported file have been already imported, so the statistics are in the target level
+	value := stats.Staging
+	switchToImported := o.storage.File().SwitchToImported
+	if file.Reimport != nil {
+		value = stats.Target
+		switchToImported = o.storage.File().SwitchToReimported
+	}
+
 	// Import the file using the specific provider
 	// Empty file import can be skipped in the import implementation.
-	err = o.plugins.ImportFile(ctx, file.File, stats.Staging)
+	err = o.plugins.ImportFile(ctx, file.File, value)
 	if err != nil {
 		// Record metric for failed file imports
 		attrs := append(
@@ -379,7 +388,7 @@ func (o *operator) doImportFile(ctx context.Context, lock *etcdop.Mutex, file *f
 
 		o.metrics.FileImportFailed.Record(ctx, int64(file.Retry.RetryAttempt), metric.WithAttributes(attrs...))
 
-		return stats.Staging, errors.PrefixError(err, "file import failed")
+		return value, errors.PrefixError(err, "file import failed")
 	}
 
 	// New context for database operation, we may be running out of time
@@ -387,7 +396,7 @@ func (o *operator) doImportFile(ctx context.Context, lock *etcdop.Mutex, file *f
 	defer dbCancel()
 
 	// Switch file to the imported state
-	result := o.storage.File().SwitchToImported(file.FileKey, o.clock.Now()).RequireLock(lock).Do(dbCtx)
+	result := switchToImported(file.FileKey, o.clock.Now()).RequireLock(lock).Do(dbCtx)
 	err = result.Err()
 	// Check specifically for invalid state transition errors
 	// This happens only when ETCD is restarted and the mirror is constructed again from scratch.
@@ -396,13 +405,13 @@ func (o *operator) doImportFile(ctx context.Context, lock *etcdop.Mutex, file *f
 		o.logger.Warnf(dbCtx, "skipping file transition to imported state: %s", err)
 		// Mark as processed to avoid retry for known state transition errors
 		file.Processed = true
-		return stats.Staging, nil
+		return value, nil
 	}
 
 	if err != nil {
 		o.logger.Warnf(ctx, `Not switching file "%s" to imported state used %d operations`, file.String(), result.MaxOps())
-		return stats.Staging, errors.PrefixError(err, "cannot switch file to the imported state")
+		return value, errors.PrefixError(err, "cannot switch file to the imported state")
 	}
 
-	return stats.Staging, nil
+	return value, nil
 }
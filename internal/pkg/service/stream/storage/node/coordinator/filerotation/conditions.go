@@ -22,6 +22,7 @@ const (
 	sizeThreshold
 	timeThreshold
 	maxSlices
+	partitionEnd
 )
 
 func newConditionResult(result int, message string) fileRotationConditionResult {
@@ -55,6 +56,8 @@ func (c fileRotationConditionResult) String() string {
 		return "time"
 	case maxSlices:
 		return "maxSlices"
+	case partitionEnd:
+		return "partitionEnd"
 	default:
 		return "unknown"
 	}
@@ -100,3 +103,12 @@ func shouldImport(cfg targetConfig.ImportConfig, now, openedAt, expiration time.
 
 	return newConditionResult(noConditionMet, "no condition met")
 }
+
+// shouldRotatePartition checks the end of the file partition, see the targetModel.Partition.
+// Records of the next partition must be written to a new file, so the condition is not limited by the min interval.
+func shouldRotatePartition(now, end time.Time) fileRotationConditionResult {
+	if now.Before(end) {
+		return newConditionResult(noConditionMet, "partition has not ended")
+	}
+	return newConditionResult(partitionEnd, fmt.Sprintf("partition ended at: %s", end.Format(utctime.TimeFormat)))
+}
@@ -111,3 +111,20 @@ func TestShouldImport(t *testing.T) {
 	assert.True(t, result.ShouldImport())
 	assert.Equal(t, "time threshold met, opened at: 2000-01-01T00:40:00.000Z, passed: 20m0s threshold: 5m0s", result.Cause())
 }
+
+func TestShouldRotatePartition(t *testing.T) {
+	t.Parallel()
+
+	now := utctime.MustParse("2000-01-01T23:59:59.000Z").Time()
+	end := utctime.MustParse("2000-01-02T00:00:00.000Z").Time()
+
+	result := shouldRotatePartition(now, end)
+	assert.Equal(t, noConditionMet, result.result)
+	assert.False(t, result.ShouldImport())
+
+	result = shouldRotatePartition(end, end)
+	assert.Equal(t, partitionEnd, result.result)
+	assert.True(t, result.ShouldImport())
+	assert.Equal(t, "partitionEnd", result.String())
+	assert.Equal(t, "partition ended at: 2000-01-02T00:00:00.000Z", result.Cause())
+}
@@ -68,6 +68,7 @@ type fileData struct {
 	Expiration   utctime.UTCTime
 	Provider     targetModel.Provider
 	ImportConfig targetConfig.ImportConfig
+	PartitionEnd *utctime.UTCTime
 	Retry        model.Retryable
 	ModRevision  int64
 	Attrs        []attribute.KeyValue
@@ -153,6 +154,10 @@ func Start(d dependencies, config targetConfig.OperatorConfig) error {
 					Attrs:        file.Telemetry(),
 				}
 
+				if partition := file.TargetStorage.Partition; partition != nil {
+					out.PartitionEnd = &partition.End
+				}
+
 				// Keep the same lock, to prevent parallel processing of the same file.
 				// No modification from another code is expected, but just to be sure.
 				if oldValue != nil {
@@ -324,7 +329,7 @@ func (o *operator) checkFile(ctx context.Context, file *fileData) {
 	// Skip check if sink is deleted or disabled:
 	//  rotateFile: When the sink is deactivated, the file state is atomically switched to the FileClosing state.
 	//  closeFile: The state of the slices will not change, there is no reason to wait for slices upload.
-	sink, ok := o.sinks.Get(file.FileKey.SinkKey)
+	sink, ok := o.sinks.Get(file.FileKey.SinkKey.Owner())
 	if !ok || !sink.Enabled {
 		return
 	}
@@ -359,6 +364,9 @@ func (o *operator) rotateFile(ctx context.Context, file *fileData) {
 
 	// Check conditions
 	result := shouldImport(file.ImportConfig, o.clock.Now(), file.FileKey.OpenedAt().Time(), file.Expiration.Time(), stats.Total)
+	if !result.ShouldImport() && file.PartitionEnd != nil {
+		result = shouldRotatePartition(o.clock.Now(), file.PartitionEnd.Time())
+	}
 	if !result.ShouldImport() {
 		o.logger.Debugf(ctx, "skipping file rotation: %s", result.Cause())
 		return
@@ -377,6 +385,10 @@ func (o *operator) rotateFile(ctx context.Context, file *fileData) {
 			// Allowing the file to get even more slices could lead to "too many operations in txn request" error
 			// To prevent this we log an error to know about this case but let file rotation continue
 			o.logger.Error(ctx, "file rotation: sink is throttled but file has too many slices")
+		case partitionEnd:
+			// The sink is throttled but the file partition has ended
+			// Records of the next partition cannot be written to the file, the rotation must continue
+			o.logger.Warn(ctx, "file rotation: sink is throttled but file partition has ended")
 		default:
 			o.logger.Warn(ctx, "skipping file rotation: sink is throttled")
 			return
@@ -9,6 +9,7 @@ type Config struct {
 	ArchivedFileRetentionPerSink int           `configKey:"archivedFileRetentionPerSink" configMap:"archivedFileRetentionPerSink" configUsage:"Retention period of a file per sink." validate:"required,min=0,max=100"`
 	ActiveFileExpiration         time.Duration `configKey:"activeFileExpiration"  configUsage:"Expiration interval of a file that has not yet been imported." validate:"required,minDuration=1h,maxDuration=720h,gtefield=ArchivedFileExpiration"` // maxDuration=30 days
 	ArchivedFileExpiration       time.Duration `configKey:"archivedFileExpiration"  configUsage:"Expiration interval of a file that has already been imported." validate:"required,minDuration=15m,maxDuration=720h"`                              // maxDuration=30 days
+	ImportedFileRetention        time.Duration `configKey:"importedFileRetention"  configUsage:"How long is an imported file and its staging file kept for a re-import, 0 = disabled." validate:"maxDuration=720h"`
 	Interval                     time.Duration `configKey:"interval"  configUsage:"Cleanup interval of a file." validate:"required,minDuration=30s,maxDuration=24h"`
 }
 
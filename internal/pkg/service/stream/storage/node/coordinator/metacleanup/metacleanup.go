@@ -194,38 +194,45 @@ func (n *Node) cleanMetadata(ctx context.Context) (err error) {
 				return nil
 			}
 
-			// Process files for this sink key
-			counter := 0
-			return n.storageRepository.File().ListIn(sinkKey, iterator.WithSort(etcd.SortDescend)).ForEach(
-				func(file model.File, _ *iterator.Header) error {
-					// Get current position and increment counter for next file
-					fileCount := counter
-					counter++
-
-					grp.Go(func() error {
-						err, deleted := n.cleanFile(ctx, file, fileCount)
-						if deleted {
-							fileCounter.Inc()
-						} else {
-							retainCounter.Inc()
-						}
-
-						if err != nil {
-							// Record metric for failed file cleanups
-							attrs := append(
-								file.FileKey.SinkKey.Telemetry(),
-								attribute.String("operation", "filecleanup"),
-							)
-							n.metrics.FileCleanupFailed.Record(ctx, 1, metric.WithAttributes(attrs...))
-						}
-
-						if err != nil && int(errCount.Inc()) > n.config.ErrorTolerance {
-							return err
-						}
+			// Process files for this sink key, and files of the sink mirror.
+			// The mirror may have been already removed from the sink, but its files may still exist.
+			for _, filesKey := range []key.SinkKey{sinkKey, sinkKey.Mirror()} {
+				counter := 0
+				err := n.storageRepository.File().ListIn(filesKey, iterator.WithSort(etcd.SortDescend)).ForEach(
+					func(file model.File, _ *iterator.Header) error {
+						// Get current position and increment counter for next file
+						fileCount := counter
+						counter++
+
+						grp.Go(func() error {
+							err, deleted := n.cleanFile(ctx, file, fileCount)
+							if deleted {
+								fileCounter.Inc()
+							} else {
+								retainCounter.Inc()
+							}
+
+							if err != nil {
+								// Record metric for failed file cleanups
+								attrs := append(
+									file.FileKey.SinkKey.Telemetry(),
+									attribute.String("operation", "filecleanup"),
+								)
+								n.metrics.FileCleanupFailed.Record(ctx, 1, metric.WithAttributes(attrs...))
+							}
+
+							if err != nil && int(errCount.Inc()) > n.config.ErrorTolerance {
+								return err
+							}
+							return nil
+						})
 						return nil
-					})
-					return nil
-				}).Do(ctx).Err()
+					}).Do(ctx).Err()
+				if err != nil {
+					return err
+				}
+			}
+			return nil
 		})
 		return false
 	})
@@ -284,6 +291,10 @@ func (n *Node) cleanFile(ctx context.Context, file model.File, fileCount int) (e
 func (n *Node) isFileExpired(file model.File, age time.Duration, fileCount int) bool {
 	// Imported files are completed, so they expire sooner
 	if file.State == model.FileImported {
+		// Imported files are retained for a possible re-import, see the ReimportSinkFiles API task
+		if age < n.config.ImportedFileRetention {
+			return false
+		}
 		return age >= n.config.ArchivedFileExpiration && fileCount > n.config.ArchivedFileRetentionPerSink
 	}
 
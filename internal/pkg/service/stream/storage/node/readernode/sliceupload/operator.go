@@ -143,6 +143,7 @@ func Start(d dependencies, config stagingConfig.OperatorConfig) error {
 						SliceKey:            slice.SliceKey,
 						LocalStorage:        slice.LocalStorage,
 						StagingStorage:      slice.StagingStorage,
+						EncoderType:         slice.Encoding.Encoder.Type,
 						EncodingCompression: slice.Encoding.Compression,
 					},
 					State: slice.State,
@@ -247,7 +248,7 @@ func (o *operator) checkSlice(ctx context.Context, slice *sliceData) {
 	}
 
 	// Skip slice upload if sink is deleted or disabled
-	sink, ok := o.sinks.Get(slice.SinkKey)
+	sink, ok := o.sinks.Get(slice.SinkKey.Owner())
 	if !ok || !sink.Enabled {
 		return
 	}
@@ -1,7 +1,9 @@
 package sliceupload_test
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -16,11 +18,17 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/duration"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/plugin"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/diskreader"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	compressionReader "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/reader"
+	compressionWriter "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression/writer"
 	volume "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/volume/model"
+	stagingConfig "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/config"
+	stagingProvider "github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/staging/provider"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/readernode/sliceupload"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
@@ -296,3 +304,123 @@ func TestSliceUploadDisabledSink(t *testing.T) {
 	// No error is logged
 	logger.AssertNoErrorMessage(t)
 }
+
+// TestSliceUpload_StagingProvider uploads a slice of a file sink to the local staging provider.
+func TestSliceUpload_StagingProvider(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	slicesCheckInterval := time.Second
+
+	volumesPath := t.TempDir()
+	volumePath1 := filepath.Join(volumesPath, "hdd", "001")
+	require.NoError(t, os.MkdirAll(volumePath1, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(volumePath1, volume.IDFile), []byte("vol-1"), 0o600))
+	stagingPath := t.TempDir()
+
+	// Create dependencies
+	clk := clockwork.NewFakeClockAt(utctime.MustParse("2000-01-01T00:00:00.000Z").Time())
+
+	d, mock := dependencies.NewMockedStorageReaderScopeWithConfig(t, ctx, func(cfg *config.Config) {
+		cfg.Storage.VolumesPath = volumesPath
+		cfg.Storage.Level.Staging.Operator.SliceUploadCheckInterval = duration.From(slicesCheckInterval)
+		cfg.Storage.Level.Staging.Provider.Local = stagingConfig.LocalProviderConfig{Enabled: true, Path: stagingPath}
+	}, commonDeps.WithClock(clk))
+
+	// Start slice upload reader node
+	require.NoError(t, sliceupload.Start(d, mock.TestConfig().Storage.Level.Staging.Operator))
+
+	client := mock.TestEtcdClient()
+	// Register some volumes
+	session, err := concurrency.NewSession(client)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, session.Close()) }()
+	test.RegisterCustomWriterVolumes(t, ctx, d.StorageRepository().Volume(), session, []volume.Metadata{
+		{
+			ID:   "vol-1",
+			Spec: volume.Spec{NodeID: "node", NodeAddress: "localhost:1234", Type: "hdd", Label: "1", Path: "hdd/1"},
+		},
+	})
+
+	logger := mock.DebugLogger()
+
+	// Fixtures
+	branchKey := key.BranchKey{ProjectID: 123, BranchID: 111}
+	branch := test.NewBranch(branchKey)
+	sourceKey := key.SourceKey{BranchKey: branchKey, SourceID: "my-source"}
+	source := test.NewHTTPSource(sourceKey)
+	sink := test.NewFileSink(key.SinkKey{SourceKey: source.SourceKey, SinkID: "my-file-sink"}, definition.StagingProviderLocal)
+	sink.Config = testconfig.LocalVolumeConfig(1, []string{"hdd"})
+	require.NoError(t, d.DefinitionRepository().Branch().Create(&branch, clk.Now(), test.ByUser()).Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Source().Create(&source, clk.Now(), test.ByUser(), "create").Do(ctx).Err())
+	require.NoError(t, d.DefinitionRepository().Sink().Create(&sink, clk.Now(), test.ByUser(), "create").Do(ctx).Err())
+
+	// The file uses the staging provider selected by the sink
+	files, err := d.StorageRepository().File().ListIn(sink.SinkKey).Do(ctx).All()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, stagingProvider.ProviderLocal, files[0].StagingStorage.Provider)
+	assert.Equal(t, stagingProvider.TargetProvider, files[0].TargetStorage.Provider)
+	slices, err := d.StorageRepository().Slice().ListIn(sink.SinkKey).Do(ctx).All()
+	require.NoError(t, err)
+	require.Len(t, slices, 1)
+	slice := slices[0]
+
+	// Write the slice file, as a source node would
+	var content bytes.Buffer
+	w, err := compressionWriter.New(&content, slice.Encoding.Compression)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("\"2000-01-01T00:00:00.000Z\",\"foo\"\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	filePath := slice.LocalStorage.FileName(volumePath1, "source-node")
+	if slice.Encoding.Compression.Type != compression.TypeNone {
+		filePath = slice.LocalStorage.FileNameWithBackup(volumePath1, "source-node")
+	}
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o700))
+	require.NoError(t, os.WriteFile(filePath, content.Bytes(), 0o600))
+
+	clk.Advance(1 * time.Second)
+	require.NoError(t, d.StorageRepository().File().Rotate(sink.SinkKey, clk.Now()).Do(ctx).Err())
+	logger.Truncate()
+	require.NoError(t, d.StorageRepository().Slice().SwitchToUploading(slice.SliceKey, clk.Now(), false).Do(ctx).Err())
+	require.NoError(t, d.StatisticsRepository().Put(ctx, "node", []statistics.PerSlice{{SliceKey: slice.SliceKey, RecordsCount: 1}}))
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		logger.AssertJSONMessages(c, `{"level":"debug","message":"watch stream mirror synced to revision %d","component":"storage.node.operator.slice.upload"}`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Triggers slice upload
+	clk.Advance(slicesCheckInterval)
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		logger.AssertJSONMessages(c, `{"level":"info","message":"uploaded slice","component":"storage.node.operator.slice.upload"}`)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The slice and the manifest are in the bucket
+	provider, ok := d.StagingProviders().Get(stagingProvider.ProviderLocal)
+	require.True(t, ok)
+	reader, err := provider.Bucket().NewSliceReader(ctx, slice.FileKey, slice.StagingStorage.Path)
+	require.NoError(t, err)
+	decompressed, err := compressionReader.New(reader, slice.StagingStorage.Compression)
+	require.NoError(t, err)
+	uploaded, err := io.ReadAll(decompressed)
+	require.NoError(t, err)
+	require.NoError(t, decompressed.Close())
+	require.NoError(t, reader.Close())
+	assert.Equal(t, "\"2000-01-01T00:00:00.000Z\",\"foo\"\n", string(uploaded))
+
+	manifest, err := provider.Bucket().ReadManifest(ctx, slice.FileKey)
+	require.NoError(t, err)
+	assert.Equal(t, slice.Encoding.Encoder.Type, manifest.Encoder)
+	assert.Equal(t, slice.StagingStorage.Compression.Type, manifest.Compression)
+	require.Len(t, manifest.Entries, 1)
+	assert.Contains(t, manifest.Entries[0].URL, slice.StagingStorage.Path)
+
+	// Shutdown
+	d.Process().Shutdown(ctx, errors.New("bye bye"))
+	d.Process().WaitForShutdown()
+
+	// No error is logged
+	logger.AssertNoErrorMessage(t)
+}
@@ -182,6 +182,10 @@ func (w *testWriter) NetworkOutput() rpc.NetworkOutput {
 	return nil
 }
 
+func (w *testWriter) NetworkLoad() encoding.NetworkLoad {
+	return encoding.NetworkLoad{}
+}
+
 func (w *testWriter) SliceKey() model.SliceKey {
 	return w.SliceKeyValue
 }
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+)
+
+// PutDeduplicated creates or updates statistics of duplicate records skipped by the sink deduplication window, per the source node.
+func (r *Repository) PutDeduplicated(ctx context.Context, nodeID string, stats []statistics.PerSink) (err error) {
+	ctx, span := r.telemetry.Tracer().Start(ctx, "keboola.go.stream.storage.statistics.Repository.PutDeduplicated")
+	defer span.End(&err)
+
+	span.SetAttributes(attribute.Int("put.records_count", len(stats)))
+
+	return r.putPerSourceNode(ctx, r.schema.Deduplicated(), nodeID, stats)
+}
+
+// LastDeduplicatedNodeValue returns the last saved statistics of duplicate records for the sink and source node,
+// so the source node can continue where it left off, after a restart.
+func (r *Repository) LastDeduplicatedNodeValue(k key.SinkKey, nodeID string) op.WithResult[statistics.Value] {
+	return r.schema.Deduplicated().InSinkSourceNode(k, nodeID).GetOrEmpty(r.client)
+}
+
+// SinkDeduplicatedStats sums statistics of duplicate records skipped by the sink from all source nodes.
+func (r *Repository) SinkDeduplicatedStats(k key.SinkKey) *op.TxnOp[statistics.Value] {
+	return r.sumPerSourceNode(r.schema.Deduplicated(), k)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+)
+
+// PutDropped creates or updates statistics of records dropped by sink filters, per the source node.
+func (r *Repository) PutDropped(ctx context.Context, nodeID string, stats []statistics.PerSink) (err error) {
+	ctx, span := r.telemetry.Tracer().Start(ctx, "keboola.go.stream.storage.statistics.Repository.PutDropped")
+	defer span.End(&err)
+
+	span.SetAttributes(attribute.Int("put.records_count", len(stats)))
+
+	return r.putPerSourceNode(ctx, r.schema.Dropped(), nodeID, stats)
+}
+
+// LastDroppedNodeValue returns the last saved statistics of dropped records for the sink and source node,
+// so the source node can continue where it left off, after a restart.
+func (r *Repository) LastDroppedNodeValue(k key.SinkKey, nodeID string) op.WithResult[statistics.Value] {
+	return r.schema.Dropped().InSinkSourceNode(k, nodeID).GetOrEmpty(r.client)
+}
+
+// SinkDroppedStats sums statistics of records dropped by the sink filter from all source nodes.
+func (r *Repository) SinkDroppedStats(k key.SinkKey) *op.TxnOp[statistics.Value] {
+	return r.sumPerSourceNode(r.schema.Dropped(), k)
+}
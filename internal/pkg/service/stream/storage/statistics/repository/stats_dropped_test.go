@@ -0,0 +1,123 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
+)
+
+func TestRepository_Dropped(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	d, _ := dependencies.NewMockedStorageScope(t, ctx)
+	repo := d.StatisticsRepository()
+	sinkKey := test.NewSinkKey()
+
+	// Empty
+	value, err := repo.SinkDroppedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, statistics.Value{}, value)
+
+	// Two nodes
+	require.NoError(t, repo.PutDropped(ctx, "test-node-1", []statistics.PerSink{
+		{
+			SinkKey:       sinkKey,
+			FirstRecordAt: utctime.MustParse("2000-01-20T00:00:00.000Z"),
+			LastRecordAt:  utctime.MustParse("2000-01-21T00:00:00.000Z"),
+			RecordsCount:  2,
+		},
+	}))
+	require.NoError(t, repo.PutDropped(ctx, "test-node-2", []statistics.PerSink{
+		{
+			SinkKey:       sinkKey,
+			FirstRecordAt: utctime.MustParse("2000-01-19T00:00:00.000Z"),
+			LastRecordAt:  utctime.MustParse("2000-01-20T00:00:00.000Z"),
+			RecordsCount:  3,
+		},
+	}))
+	value, err = repo.SinkDroppedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, statistics.Value{
+		FirstRecordAt: utctime.MustParse("2000-01-19T00:00:00.000Z"),
+		LastRecordAt:  utctime.MustParse("2000-01-21T00:00:00.000Z"),
+		RecordsCount:  5,
+	}, value)
+
+	// Last node value
+	value, err = repo.LastDroppedNodeValue(sinkKey, "test-node-1").Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), value.RecordsCount)
+
+	// Reset
+	require.NoError(t, repo.ResetSinkStats(sinkKey).Do(ctx).Err())
+	value, err = repo.SinkDroppedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), value.RecordsCount)
+
+	// New records after the reset
+	require.NoError(t, repo.PutDropped(ctx, "test-node-1", []statistics.PerSink{
+		{
+			SinkKey:       sinkKey,
+			FirstRecordAt: utctime.MustParse("2000-01-20T00:00:00.000Z"),
+			LastRecordAt:  utctime.MustParse("2000-01-22T00:00:00.000Z"),
+			RecordsCount:  4,
+		},
+	}))
+	value, err = repo.SinkDroppedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), value.RecordsCount)
+}
+
+func TestRepository_Deduplicated(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	d, _ := dependencies.NewMockedStorageScope(t, ctx)
+	repo := d.StatisticsRepository()
+	sinkKey := test.NewSinkKey()
+
+	// Empty
+	value, err := repo.SinkDeduplicatedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, statistics.Value{}, value)
+
+	// Deduplicated and dropped records are stored separately
+	require.NoError(t, repo.PutDeduplicated(ctx, "test-node-1", []statistics.PerSink{
+		{
+			SinkKey:       sinkKey,
+			FirstRecordAt: utctime.MustParse("2000-01-20T00:00:00.000Z"),
+			LastRecordAt:  utctime.MustParse("2000-01-21T00:00:00.000Z"),
+			RecordsCount:  2,
+		},
+	}))
+	value, err = repo.SinkDeduplicatedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), value.RecordsCount)
+	value, err = repo.SinkDroppedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), value.RecordsCount)
+
+	// Last node value
+	value, err = repo.LastDeduplicatedNodeValue(sinkKey, "test-node-1").Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), value.RecordsCount)
+
+	// Reset
+	require.NoError(t, repo.ResetSinkStats(sinkKey).Do(ctx).Err())
+	value, err = repo.SinkDeduplicatedStats(sinkKey).Do(ctx).ResultOrErr()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), value.RecordsCount)
+}
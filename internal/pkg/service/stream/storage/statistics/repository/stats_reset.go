@@ -38,6 +38,7 @@ func (r *Repository) ResetAllSinksStats(ctx context.Context, sinkKeys []key.Sink
 
 // ResetSinkStats sums all statistics for data in target level and saves the sum in _reset key.
 // Local and staging levels are unaffected because those data will be moved to target later.
+// Statistics of records dropped by the sink filter and of duplicate records are also reset.
 func (r *Repository) ResetSinkStats(sinkKey key.SinkKey) *op.AtomicOp[op.NoResult] {
 	// SkipPrefixKeysCheck: the read sums all statistics under the sink prefix, which may contain
 	// many per-slice keys. Without this, one IF condition is generated per key, exploding the
@@ -70,6 +71,28 @@ func (r *Repository) ResetSinkStats(sinkKey key.SinkKey) *op.AtomicOp[op.NoResul
 		return resetKey.Put(r.client, objectSum)
 	})
 
+	// Statistics of records dropped by the sink filter, and of duplicate records, are reset in the same way
+	for _, pfx := range []schemaInObject{r.schema.Dropped().InSink(sinkKey), r.schema.Deduplicated().InSink(sinkKey)} {
+		var sum statistics.Value
+		ops.Read(func(context.Context) op.Op {
+			sum = statistics.Value{}
+			return pfx.GetAll(r.client).ForEach(func(item statistics.Value, _ *iterator.Header) error {
+				if item.ResetAt == nil {
+					sum = sum.With(item)
+				}
+				return nil
+			})
+		})
+		ops.Write(func(context.Context) op.Op {
+			if sum.RecordsCount == 0 {
+				// Nop
+				return nil
+			}
+			sum.ResetAt = new(utctime.From(r.clock.Now()))
+			return pfx.Reset().Put(r.client, sum)
+		})
+	}
+
 	return ops
 }
 
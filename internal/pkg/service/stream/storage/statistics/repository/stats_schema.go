@@ -43,6 +43,18 @@ func (s schema) InLevel(l model.Level) schemaInLevel {
 	}
 }
 
+// Dropped returns prefix of statistics of records dropped by a sink filter.
+// The statistics are not bound to a storage level, they are stored per sink and source node.
+func (s schema) Dropped() schemaInLevel {
+	return schemaInLevel{PrefixT: s.Add("dropped")}
+}
+
+// Deduplicated returns prefix of statistics of duplicate records skipped by the deduplication window of a sink.
+// The statistics are not bound to a storage level, they are stored per sink and source node.
+func (s schema) Deduplicated() schemaInLevel {
+	return schemaInLevel{PrefixT: s.Add("deduplicated")}
+}
+
 // InParentOf returns prefix of the parent object, it is used as schemaInLevel.InParentOf(...).Sum().
 func (v schemaInLevel) InParentOf(k fmt.Stringer) schemaInObject {
 	switch k := k.(type) {
@@ -98,6 +110,10 @@ func (v schemaInLevel) InSliceSourceNode(k model.SliceKey, nodeID string) etcdop
 	return v.inObject(k).Key(nodeID)
 }
 
+func (v schemaInLevel) InSinkSourceNode(k key.SinkKey, nodeID string) etcdop.KeyT[statistics.Value] {
+	return v.inObject(k).Key(nodeID)
+}
+
 func (v schemaInLevel) inObject(objectKey fmt.Stringer) schemaInObject {
 	return schemaInObject{PrefixT: v.Add(objectKey.String())}
 }
@@ -34,6 +34,18 @@ func TestSchema(t *testing.T) {
 			s.InLevel(model.LevelTarget).Prefix(),
 			"storage/stats/target/",
 		},
+		{
+			s.Dropped().Prefix(),
+			"storage/stats/dropped/",
+		},
+		{
+			s.Dropped().InSinkSourceNode(sliceKey.SinkKey, "my-node").Key(),
+			"storage/stats/dropped/123/456/my-source/my-sink/my-node",
+		},
+		{
+			s.Dropped().InSink(sliceKey.SinkKey).Reset().Key(),
+			"storage/stats/dropped/123/456/my-source/my-sink/~reset",
+		},
 		{
 			s.InLevel(model.LevelLocal).InObject(sliceKey.ProjectID).Prefix(),
 			"storage/stats/local/123/",
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/iterator"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdop/op"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/statistics"
+)
+
+// Statistics of records which are not written to the sink, for example dropped or deduplicated records,
+// are not bound to a storage level, they are collected by source nodes and stored per sink and source node.
+
+func (r *Repository) putPerSourceNode(ctx context.Context, pfx schemaInLevel, nodeID string, stats []statistics.PerSink) error {
+	return op.RunWriteTxnsInBatches(ctx, r.client, stats, putMaxStatsPerTxn, func(txn *op.TxnOp[op.NoResult], v statistics.PerSink) {
+		value := statistics.Value{
+			FirstRecordAt: v.FirstRecordAt,
+			LastRecordAt:  v.LastRecordAt,
+			RecordsCount:  v.RecordsCount,
+		}
+		txn.Then(pfx.InSinkSourceNode(v.SinkKey, nodeID).Put(r.client, value))
+	})
+}
+
+func (r *Repository) sumPerSourceNode(pfx schemaInLevel, k key.SinkKey) *op.TxnOp[statistics.Value] {
+	var result, reset statistics.Value
+	return op.TxnWithResult(r.client, &result).
+		Then(pfx.InSink(k).GetAll(r.client).ForEach(func(v statistics.Value, _ *iterator.Header) error {
+			if v.ResetAt == nil {
+				result = result.With(v)
+			} else {
+				reset = v
+			}
+			return nil
+		})).
+		OnSucceeded(func(_ *op.TxnResult[statistics.Value]) {
+			// The reset is subtracted at the end, to prevent underflow
+			if reset.ResetAt != nil {
+				result = result.With(reset)
+			}
+		})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/c2h5oh/datasize"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/utctime"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/definition/key"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/model"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 )
@@ -53,6 +54,15 @@ type PerSlice struct {
 	CompressedSize   datasize.ByteSize
 }
 
+// PerSink contains statistics of records dropped by the filter of a sink, or skipped as duplicates, collected by a source node.
+type PerSink struct {
+	SinkKey key.SinkKey
+
+	FirstRecordAt utctime.UTCTime
+	LastRecordAt  utctime.UTCTime
+	RecordsCount  uint64
+}
+
 // Aggregated contains aggregated statistics for an object, such as file or export.
 type Aggregated struct {
 	// Local field contains summarized statistics for slices in storage.SliceWriting, storage.SliceClosing, storage.SliceUploading.
@@ -25,6 +25,7 @@ import (
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/mapping/table/column"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/compression"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/encoder"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/level/local/encoding/writesync"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/test/dummy"
@@ -42,6 +43,8 @@ type WriterBenchmark struct {
 	Allocate    datasize.ByteSize
 	Sync        writesync.Config
 	Compression compression.Config
+	// Encoder type, the default CSV encoder is used, if it is not set.
+	Encoder encoder.Type
 
 	// DataChFactory must return the channel with table rows, the channel must be closed after the n reads.
 	DataChFactory func(ctx context.Context, n int, g *RandomStringGenerator) <-chan recordctx.Context
@@ -106,6 +109,9 @@ func (wb *WriterBenchmark) Run(b *testing.B) {
 	vol, err := wb.writerNode.Volumes().Collection().Volume("my-volume-001")
 	require.NoError(b, err)
 	filePath := slice.LocalStorage.FileName(vol.Path(), wb.sourceNodeMock.TestConfig().NodeID)
+	if wb.Compression.Type != compression.TypeNone {
+		filePath = slice.LocalStorage.FileNameWithBackup(vol.Path(), wb.sourceNodeMock.TestConfig().NodeID)
+	}
 
 	// Wait for initialization
 	assert.EventuallyWithT(b, func(c *assert.CollectT) {
@@ -248,4 +254,7 @@ func (wb *WriterBenchmark) updateServiceConfig(cfg *config.Config) {
 	cfg.Storage.Level.Local.Writer.WatchDrainFile = false
 	cfg.Storage.Level.Local.Encoding.Sync = wb.Sync
 	cfg.Storage.Level.Local.Encoding.Compression = wb.Compression
+	if wb.Encoder != "" {
+		cfg.Storage.Level.Local.Encoding.Encoder.Type = wb.Encoder
+	}
 }
@@ -42,3 +42,39 @@ func NewKeboolaTableSink(k key.SinkKey) definition.Sink {
 		},
 	}
 }
+
+func NewWebhookSink(k key.SinkKey, url string) definition.Sink {
+	return definition.Sink{
+		SinkKey:     k,
+		Type:        definition.SinkTypeWebhook,
+		Name:        "My Sink",
+		Description: "My Description",
+		Webhook: &definition.WebhookSink{
+			URL: url,
+			Mapping: table.Mapping{
+				Columns: column.Columns{
+					column.Datetime{Name: "datetime"},
+					column.Body{Name: "body"},
+				},
+			},
+		},
+	}
+}
+
+func NewFileSink(k key.SinkKey, provider definition.StagingProvider) definition.Sink {
+	return definition.Sink{
+		SinkKey:     k,
+		Type:        definition.SinkTypeFile,
+		Name:        "My Sink",
+		Description: "My Description",
+		File: &definition.FileSink{
+			Provider: provider,
+			Mapping: table.Mapping{
+				Columns: column.Columns{
+					column.Datetime{Name: "datetime"},
+					column.Body{Name: "body"},
+				},
+			},
+		},
+	}
+}
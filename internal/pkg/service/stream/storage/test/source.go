@@ -37,3 +37,13 @@ func NewOTLPSource(k key.SourceKey) definition.Source {
 		OTLP:        &definition.OTLPSource{Secret: "012345678901234567890123456789012345678912345678"},
 	}
 }
+
+func NewKafkaSource(k key.SourceKey, brokers []string, topic string) definition.Source {
+	return definition.Source{
+		SourceKey:   k,
+		Type:        definition.SourceTypeKafka,
+		Name:        "My Kafka Source",
+		Description: "My Description",
+		Kafka:       &definition.KafkaSource{Brokers: brokers, Topic: topic, GroupID: "my-group"},
+	}
+}
@@ -4,26 +4,33 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
 	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/coordinator"
 )
 
 func StartCoordinatorNode(tb testing.TB, ctx context.Context, logger log.DebugLogger, etcdCfg etcdclient.Config, modifyConfig func(cfg *config.Config), opts ...commonDeps.MockedOption) (dependencies.CoordinatorScope, dependencies.Mocked) {
 	tb.Helper()
 
 	opts = append(opts, commonDeps.WithDebugLogger(logger), commonDeps.WithEtcdConfig(etcdCfg))
-	return dependencies.NewMockedCoordinatorScopeWithConfig(
+	d, mock := dependencies.NewMockedCoordinatorScopeWithConfig(
 		tb,
 		ctx,
 		func(cfg *config.Config) {
 			if modifyConfig != nil {
 				modifyConfig(cfg)
 			}
-			cfg.NodeID = "api"
+			cfg.NodeID = "coordinator"
 		},
 		opts...,
 	)
+
+	require.NoError(tb, coordinator.Start(ctx, d, mock.TestConfig()))
+
+	return d, mock
 }
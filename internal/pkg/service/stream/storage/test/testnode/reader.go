@@ -0,0 +1,38 @@
+package testnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	commonDeps "github.com/keboola/keboola-as-code/internal/pkg/service/common/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/common/etcdclient"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/config"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/dependencies"
+	"github.com/keboola/keboola-as-code/internal/pkg/service/stream/storage/node/readernode"
+)
+
+// StartDiskReaderNode starts a reader node on volumes of a disk writer node, see StartDiskWriterNode.
+func StartDiskReaderNode(tb testing.TB, ctx context.Context, logger log.DebugLogger, etcdCfg etcdclient.Config, volumesPath string, modifyConfig func(cfg *config.Config), opts ...commonDeps.MockedOption) (dependencies.StorageReaderScope, dependencies.Mocked) {
+	tb.Helper()
+
+	opts = append(opts, commonDeps.WithDebugLogger(logger), commonDeps.WithEtcdConfig(etcdCfg))
+	d, mock := dependencies.NewMockedStorageReaderScopeWithConfig(
+		tb,
+		ctx,
+		func(cfg *config.Config) {
+			if modifyConfig != nil {
+				modifyConfig(cfg)
+			}
+			cfg.NodeID = "disk-reader"
+			cfg.Storage.VolumesPath = volumesPath
+		},
+		opts...,
+	)
+
+	require.NoError(tb, readernode.Start(ctx, d, mock.TestConfig()))
+
+	return d, mock
+}
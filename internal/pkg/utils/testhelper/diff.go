@@ -108,17 +108,17 @@ func compareDirectories(ctx context.Context, expectedFs filesystem.Fs, expectedD
 			return nil
 		}
 
-		// Ignore hidden files, except .env*, .gitignore
-		if IsIgnoredFile(path, info) {
-			return nil
-		}
-
 		// Get relative path
 		relPath, err := filesystem.Rel(actualDir, path)
 		if err != nil {
 			return err
 		}
 
+		// Ignore hidden files, except .env*, .gitignore and state files present in the expected dir
+		if IsIgnoredFile(path, info) && !(IsStateFile(path) && expectedFs.IsFile(ctx, filesystem.Join(expectedDir, relPath))) {
+			return nil
+		}
+
 		// Create node
 		hashMap[normalizeString(relPath)] = &fileNodeState{
 			relPath: normalizeString(relPath),
@@ -143,8 +143,8 @@ func compareDirectories(ctx context.Context, expectedFs filesystem.Fs, expectedD
 			return nil
 		}
 
-		// Ignore hidden files, except .env*, .gitignore
-		if IsIgnoredFile(path, info) {
+		// Ignore hidden files, except .env*, .gitignore and state files
+		if IsIgnoredFile(path, info) && !IsStateFile(path) {
 			return nil
 		}
 
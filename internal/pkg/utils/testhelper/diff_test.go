@@ -172,6 +172,24 @@ func TestAssertDirectoryIgnoreHiddenFiles(t *testing.T) {
 	assert.Empty(t, test.buf.String())
 }
 
+func TestAssertDirectoryStateFiles(t *testing.T) {
+	t.Parallel()
+	expectedFs := aferofs.NewMemoryFs()
+	actualFs := aferofs.NewMemoryFs()
+
+	// State file in expected and actual, it is compared
+	stateFilePath := filesystem.Join(filesystem.MetadataDir, ".state.json")
+	require.NoError(t, expectedFs.WriteFile(t.Context(), filesystem.NewRawFile(stateFilePath, "foo\n")))
+	require.NoError(t, actualFs.WriteFile(t.Context(), filesystem.NewRawFile(stateFilePath, "bar\n")))
+
+	// State file only in actual, it is ignored
+	require.NoError(t, actualFs.WriteFile(t.Context(), filesystem.NewRawFile(filesystem.Join(filesystem.MetadataDir, ".other.json"), "bar\n")))
+
+	test := newMockedT()
+	AssertDirectoryContentsSame(test, expectedFs, `/`, actualFs, `/`)
+	assert.Contains(t, test.buf.String(), "different content of the file \".keboola/.state.json\"")
+}
+
 func TestAssertDirectorySame(t *testing.T) {
 	t.Parallel()
 	expectedFs := aferofs.NewMemoryFs()
@@ -87,8 +87,8 @@ func ReplaceEnvsDirWithSeparator(ctx context.Context, fs filesystem.Fs, root str
 			return err
 		}
 
-		// Ignore hidden files, except .env*, .gitignore
-		if IsIgnoredFile(p, info) {
+		// Ignore hidden files, except .env*, .gitignore and state files, they contain object IDs
+		if IsIgnoredFile(p, info) && !IsStateFile(p) {
 			return nil
 		}
 
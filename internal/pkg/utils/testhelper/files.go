@@ -18,6 +18,12 @@ func IsIgnoredFile(path string, d filesystem.FileInfo) bool {
 		base != ".gitignore" && base != ".kbcignore"
 }
 
+// IsStateFile returns true for a hidden state file in the metadata dir, for example ".keboola/.sync-base.json".
+// State files are compared only if they are present in the expected directory.
+func IsStateFile(path string) bool {
+	return filepath.Base(filepath.Dir(path)) == filesystem.MetadataDir && strings.HasPrefix(filepath.Base(path), ".")
+}
+
 func IsIgnoredDir(path string, d filesystem.FileInfo) bool {
 	base := filepath.Base(path)
 	return d.IsDir() && strings.HasPrefix(base, ".")
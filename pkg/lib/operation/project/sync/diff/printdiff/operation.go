@@ -2,8 +2,10 @@ package printdiff
 
 import (
 	"context"
+	"io"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
@@ -13,10 +15,13 @@ import (
 type Options struct {
 	PrintDetails      bool
 	LogUntrackedPaths bool
+	// OutputFormat is diff.OutputFormatText or diff.OutputFormatJSON, empty means text.
+	OutputFormat string
 }
 
 type dependencies interface {
 	Logger() log.Logger
+	Stdout() io.Writer
 	Telemetry() telemetry.Telemetry
 }
 
@@ -37,6 +42,18 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 		projectState.LogUntrackedPaths(ctx, logger)
 	}
 
+	// Print machine-readable diff, the output is not mixed with info messages
+	if o.OutputFormat == diff.OutputFormatJSON {
+		bytes, err := json.Encode(results.ToJSON(o.PrintDetails), true)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := d.Stdout().Write(bytes); err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
 	if results.Equal {
 		logger.Info(ctx, "No difference.")
 	} else {
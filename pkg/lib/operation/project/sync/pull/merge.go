@@ -0,0 +1,145 @@
+package pull
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/keboola/go-utils/pkg/orderedmap"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/merge"
+	"github.com/keboola/keboola-as-code/internal/pkg/naming"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/diffop"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/syncbase"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+// readLocalChanges reads local files of the changed objects, before they are overwritten by the remote state.
+func readLocalChanges(ctx context.Context, fs filesystem.Fs, plan *diffop.Plan) (map[string]syncbase.Files, error) {
+	out := make(map[string]syncbase.Files)
+	for _, result := range plan.Results(diffop.ActionSaveLocal) {
+		if result.State != diff.ResultNotEqual {
+			continue
+		}
+		files, err := syncbase.ReadFiles(ctx, fs, result.Path())
+		if err != nil {
+			return nil, err
+		}
+		out[result.Key().String()] = files
+	}
+	return out, nil
+}
+
+// mergeLocalChanges merges the local changes with the pulled remote state, using the last synced state as the base.
+// The base is updated to the pulled remote state, objects which are not pulled keep the old base.
+// The base is saved, with the conflicts, even if an error occurs.
+func mergeLocalChanges(ctx context.Context, fs filesystem.Fs, logger log.Logger, results *diff.Results, plan *diffop.Plan, base *syncbase.File, localChanges map[string]syncbase.Files) error {
+	// Keep only conflicts which have not been resolved yet
+	base.Conflicts = base.UnresolvedConflicts(ctx, fs)
+
+	pulled := make(map[string]bool)
+	for _, result := range plan.Results(diffop.ActionSaveLocal) {
+		pulled[result.Key().String()] = true
+	}
+	for _, result := range plan.Results(diffop.ActionDeleteLocal) {
+		base.Delete(result.Key())
+	}
+
+	errs := errors.NewMultiError()
+	for _, result := range results.Results {
+		key := result.Key()
+		if result.State != diff.ResultEqual && !pulled[key.String()] {
+			continue
+		}
+
+		remoteFiles, err := syncbase.ReadFiles(ctx, fs, result.Path())
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		// Merge local changes against the old base
+		if localFiles, found := localChanges[key.String()]; found {
+			if baseFiles, found := base.Get(key); found {
+				// Files are merged in a stable order, so the log is deterministic
+				for _, relPath := range slices.Sorted(maps.Keys(localFiles)) {
+					localContent := localFiles[relPath]
+					remoteContent, found := remoteFiles[relPath]
+					if !found {
+						continue
+					}
+					baseContent, found := baseFiles[relPath]
+					if !found || localContent == baseContent || localContent == remoteContent {
+						continue
+					}
+					path := filesystem.Join(result.Path(), relPath)
+					if err := mergeFile(ctx, fs, logger, base, path, baseContent, localContent, remoteContent); err != nil {
+						errs.Append(err)
+					}
+				}
+			}
+		}
+
+		base.Set(key, remoteFiles)
+	}
+
+	// Conflicts already written must be listed by the "status" command, even if other files cannot be merged
+	if err := base.Save(ctx, fs); err != nil {
+		errs.Append(err)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func mergeFile(ctx context.Context, fs filesystem.Fs, logger log.Logger, base *syncbase.File, path, baseContent, localContent, remoteContent string) error {
+	if filesystem.Base(path) == naming.ConfigFile {
+		return mergeConfigFile(ctx, fs, logger, base, path, baseContent, localContent, remoteContent)
+	}
+
+	merged, conflict := merge.Text(baseContent, localContent, remoteContent)
+	if err := fs.WriteFile(ctx, filesystem.NewRawFile(path, merged)); err != nil {
+		return err
+	}
+
+	if conflict {
+		base.AddConflict(path)
+		logger.Warnf(ctx, `Conflict in "%s", resolve the conflict markers.`, path)
+	} else {
+		logger.Infof(ctx, `Merged local changes in "%s".`, path)
+	}
+	return nil
+}
+
+// mergeConfigFile merges JSON keys, the remote value wins in a conflict and the local file is saved to the ".conflict" file.
+func mergeConfigFile(ctx context.Context, fs filesystem.Fs, logger log.Logger, base *syncbase.File, path, baseContent, localContent, remoteContent string) error {
+	baseMap, localMap, remoteMap := orderedmap.New(), orderedmap.New(), orderedmap.New()
+	var conflicts []string
+	if json.DecodeString(baseContent, baseMap) != nil || json.DecodeString(localContent, localMap) != nil || json.DecodeString(remoteContent, remoteMap) != nil {
+		// Invalid JSON cannot be merged, keep the remote version
+		conflicts = []string{"."}
+	} else {
+		merged, mergeConflicts := merge.JSON(baseMap, localMap, remoteMap)
+		if err := fs.WriteFile(ctx, filesystem.NewJSONFile(path, merged)); err != nil {
+			return err
+		}
+		conflicts = mergeConflicts
+	}
+
+	if len(conflicts) == 0 {
+		logger.Infof(ctx, `Merged local changes in "%s".`, path)
+		return nil
+	}
+
+	conflictPath := path + syncbase.ConflictSuffix
+	if err := fs.WriteFile(ctx, filesystem.NewRawFile(conflictPath, localContent)); err != nil {
+		return err
+	}
+
+	base.AddConflict(conflictPath)
+	logger.Warnf(ctx, `Conflict in "%s", the remote version has been kept and the local version saved to "%s".`, path, conflictPath)
+	return nil
+}
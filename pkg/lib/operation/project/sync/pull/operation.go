@@ -8,10 +8,12 @@ import (
 
 	"github.com/keboola/keboola-as-code/internal/pkg/diff"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/diffop"
 	"github.com/keboola/keboola-as-code/internal/pkg/plan/pull"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/project/cachefile"
 	"github.com/keboola/keboola-as-code/internal/pkg/project/ignore"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/syncbase"
 	"github.com/keboola/keboola-as-code/internal/pkg/state"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
@@ -26,6 +28,12 @@ type Options struct {
 	DryRun                 bool
 	LogUntrackedPaths      bool
 	CleanupRenameConflicts bool
+	// OutputFormat of the dry run plan, diff.OutputFormatText or diff.OutputFormatJSON, empty means text.
+	OutputFormat string
+	// Details includes the changed values in the JSON plan, secret values are masked.
+	Details bool
+	// Selection restricts the operation to the selected objects, nil means all objects.
+	Selection *diffop.Selection
 }
 
 type dependencies interface {
@@ -82,7 +90,7 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 	}
 
 	// Get plan
-	plan, err := pull.NewPlan(results)
+	plan, err := pull.NewPlan(results, o.Selection)
 	if err != nil {
 		return err
 	}
@@ -93,6 +101,11 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 		return err
 	}
 
+	// Print machine-readable plan of the dry run, with the changed values if details are enabled
+	if o.DryRun && o.OutputFormat == diff.OutputFormatJSON {
+		return plan.WriteJSON(d.Stdout(), o.Details)
+	}
+
 	// Log plan
 	plan.Log(d.Stdout())
 
@@ -110,11 +123,26 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 		return nil
 	}
 
+	// Read local changes, they are merged with the pulled remote state
+	syncBase, err := syncbase.Load(ctx, projectState.Fs())
+	if err != nil {
+		return err
+	}
+	localChanges, err := readLocalChanges(ctx, projectState.Fs(), plan)
+	if err != nil {
+		return err
+	}
+
 	// Invoke
 	if err := plan.Invoke(logger, projectState.Ctx(), projectState.LocalManager(), projectState.RemoteManager(), ``); err != nil { // nolint: contextcheck
 		return err
 	}
 
+	// Three-way merge of the local changes
+	if err := mergeLocalChanges(ctx, projectState.Fs(), logger, results, plan, syncBase, localChanges); err != nil {
+		return err
+	}
+
 	// Save manifest
 	if _, err := saveManifest.Run(ctx, projectState.ProjectManifest(), projectState.Fs(), d); err != nil {
 		return err
@@ -3,14 +3,20 @@ package push
 import (
 	"context"
 	"io"
+	"strings"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/diffop"
 	"github.com/keboola/keboola-as-code/internal/pkg/plan/push"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
 	"github.com/keboola/keboola-as-code/internal/pkg/project/ignore"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/pushsnapshot"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/syncbase"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
 	"github.com/keboola/keboola-as-code/pkg/lib/operation/project/local/encrypt"
@@ -25,9 +31,18 @@ type Options struct {
 	AllowRemoteDelete bool
 	LogUntrackedPaths bool
 	ChangeDescription string
+	// OutputFormat of the dry run plan, diff.OutputFormatText or diff.OutputFormatJSON, empty means text.
+	OutputFormat string
+	// Details includes the changed values in the JSON plan, secret values are masked.
+	Details bool
+	// CreateSnapshot of the remote objects changed by the push, the push can be rolled back then.
+	CreateSnapshot bool
+	// Selection restricts the operation to the selected objects, nil means all objects.
+	Selection *diffop.Selection
 }
 
 type dependencies interface {
+	Clock() clockwork.Clock
 	KeboolaProjectAPI() *keboola.AuthorizedAPI
 	Logger() log.Logger
 	ProjectID() keboola.ProjectID
@@ -41,9 +56,12 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 
 	logger := d.Logger()
 
-	// Encrypt before push - ALWAYS (--encrypt flag kept for backwards compatibility)
-	if err := encrypt.Run(ctx, projectState, encrypt.Options{DryRun: o.DryRun, LogEmpty: false}, d); err != nil {
-		return err
+	// Encrypt before push - ALWAYS (--encrypt flag kept for backwards compatibility).
+	// The dry run of the encryption only prints the plan, it is skipped if the output must be machine-readable.
+	if !o.DryRun || o.OutputFormat != diff.OutputFormatJSON {
+		if err := encrypt.Run(ctx, projectState, encrypt.Options{DryRun: o.DryRun, LogEmpty: false}, d); err != nil {
+			return err
+		}
 	}
 
 	// Change description - optional arg
@@ -94,7 +112,7 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 	}
 
 	// Get plan
-	plan, err := push.NewPlan(results)
+	plan, err := push.NewPlan(results, o.Selection)
 	if err != nil {
 		return err
 	}
@@ -104,6 +122,11 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 		plan.AllowRemoteDelete()
 	}
 
+	// Print machine-readable plan of the dry run, with the changed values if details are enabled
+	if o.DryRun && o.OutputFormat == diff.OutputFormatJSON {
+		return plan.WriteJSON(d.Stdout(), o.Details)
+	}
+
 	// Log plan
 	plan.Log(d.Stdout())
 
@@ -114,12 +137,58 @@ func Run(ctx context.Context, projectState *project.State, o Options, d dependen
 			return nil
 		}
 
+		// Conflicts from the pull must be resolved first
+		syncBase, err := syncbase.Load(ctx, projectState.Fs())
+		if err != nil {
+			return err
+		}
+		if conflicts := syncBase.UnresolvedConflicts(ctx, projectState.Fs()); len(conflicts) > 0 {
+			return errors.Errorf(`found unresolved conflicts in "%s", resolve them first, see "kbc status"`, strings.Join(conflicts, `", "`))
+		}
+
+		// Snapshot of the remote objects, for the rollback
+		var snapshot *pushsnapshot.Snapshot
+		if o.CreateSnapshot {
+			if snapshot, err = createSnapshot(ctx, projectState.Fs(), plan, o, d); err != nil {
+				return err
+			}
+		}
+
 		// Invoke
 		if err := plan.Invoke(logger, ctx, projectState.LocalManager(), projectState.RemoteManager(), o.ChangeDescription); err != nil {
 			return err
 		}
 
+		// Pushed objects are synced, update the base of the three-way merge in the pull
+		if err := updateSyncBase(ctx, projectState.Fs(), plan, o.AllowRemoteDelete, syncBase); err != nil {
+			return err
+		}
+
+		if snapshot != nil {
+			// The push is done, a failure only disables the detection of remote changes in the rollback
+			if err := completeSnapshot(ctx, projectState.Fs(), snapshot, d); err != nil {
+				logger.Warnf(ctx, `Cannot save remote versions of the pushed objects: %s`, err)
+			}
+			logger.Infof(ctx, `Push "%s" can be rolled back by "kbc sync rollback %s".`, snapshot.PushID, snapshot.PushID)
+		}
+
 		logger.Info(ctx, "Push done.")
 	}
 	return nil
 }
+
+func updateSyncBase(ctx context.Context, fs filesystem.Fs, plan *diffop.Plan, allowRemoteDelete bool, syncBase *syncbase.File) error {
+	for _, result := range plan.Results(diffop.ActionSaveRemote) {
+		files, err := syncbase.ReadFiles(ctx, fs, result.Path())
+		if err != nil {
+			return err
+		}
+		syncBase.Set(result.Key(), files)
+	}
+	if allowRemoteDelete {
+		for _, result := range plan.Results(diffop.ActionDeleteRemote) {
+			syncBase.Delete(result.Key())
+		}
+	}
+	return syncBase.Save(ctx, fs)
+}
@@ -0,0 +1,96 @@
+package push
+
+import (
+	"context"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+	"github.com/keboola/keboola-sdk-go/v2/pkg/request"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/model"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/diffop"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/pushsnapshot"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/ulid"
+)
+
+// createSnapshot saves remote versions of the configs and rows changed by the push, so the push can be rolled back.
+func createSnapshot(ctx context.Context, fs filesystem.Fs, plan *diffop.Plan, o Options, d dependencies) (*pushsnapshot.Snapshot, error) {
+	snapshot := &pushsnapshot.Snapshot{
+		PushID:            ulid.NewDefaultGenerator().NewULID(),
+		Created:           d.Clock().Now().UTC(),
+		ChangeDescription: o.ChangeDescription,
+		Objects:           make([]*pushsnapshot.Object, 0),
+	}
+
+	results := plan.Results(diffop.ActionSaveRemote)
+	if o.AllowRemoteDelete {
+		results = append(results, plan.Results(diffop.ActionDeleteRemote)...)
+	}
+
+	// Load remote versions in the Storage API form
+	api := d.KeboolaProjectAPI()
+	wg := request.NewWaitGroup(ctx)
+	for _, result := range results {
+		var object *pushsnapshot.Object
+		switch k := result.Key().(type) {
+		case model.ConfigKey:
+			object = &pushsnapshot.Object{Path: result.Path(), ConfigKey: keboola.ConfigKey{BranchID: k.BranchID, ComponentID: k.ComponentID, ID: k.ID}}
+			if result.HasRemoteState() {
+				wg.Send(api.GetConfigRequest(object.ConfigKey).WithOnSuccess(func(_ context.Context, config *keboola.Config) error {
+					object.Config = config
+					return nil
+				}))
+			}
+		case model.ConfigRowKey:
+			object = &pushsnapshot.Object{Path: result.Path(), ConfigKey: keboola.ConfigKey{BranchID: k.BranchID, ComponentID: k.ComponentID, ID: k.ConfigID}, RowID: k.ID}
+			if result.HasRemoteState() {
+				wg.Send(api.GetConfigRowRequest(object.RowKey()).WithOnSuccess(func(_ context.Context, row *keboola.ConfigRow) error {
+					object.Row = row
+					return nil
+				}))
+			}
+		default:
+			// Branches are not restored
+			continue
+		}
+		snapshot.Objects = append(snapshot.Objects, object)
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Save snapshot
+	file, err := pushsnapshot.Load(ctx, fs)
+	if err != nil {
+		return nil, err
+	}
+	file.Add(snapshot)
+	if err := file.Save(ctx, fs); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// completeSnapshot saves remote versions of the objects after the push, so the rollback detects changes made after the push.
+func completeSnapshot(ctx context.Context, fs filesystem.Fs, snapshot *pushsnapshot.Snapshot, d dependencies) error {
+	pushed, err := pushsnapshot.LoadRemote(ctx, d.KeboolaProjectAPI(), snapshot.Objects)
+	if err != nil {
+		return err
+	}
+	for i, object := range snapshot.Objects {
+		if pushed[i] != nil {
+			object.PushedVersion = pushed[i].Version()
+		}
+	}
+
+	// Update the saved snapshot
+	file, err := pushsnapshot.Load(ctx, fs)
+	if err != nil {
+		return err
+	}
+	if saved, found := file.Get(snapshot.PushID); found {
+		saved.Objects = snapshot.Objects
+	}
+	return file.Save(ctx, fs)
+}
@@ -0,0 +1,105 @@
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/keboola/keboola-sdk-go/v2/pkg/keboola"
+
+	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
+	"github.com/keboola/keboola-as-code/internal/pkg/log"
+	"github.com/keboola/keboola-as-code/internal/pkg/plan/rollback"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/pushsnapshot"
+	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
+	"github.com/keboola/keboola-as-code/internal/pkg/utils/errors"
+)
+
+type Options struct {
+	PushID            string
+	DryRun            bool
+	ChangeDescription string
+}
+
+type dependencies interface {
+	KeboolaProjectAPI() *keboola.AuthorizedAPI
+	Logger() log.Logger
+	StorageAPIToken() keboola.Token
+	Telemetry() telemetry.Telemetry
+	Stdout() io.Writer
+}
+
+func Run(ctx context.Context, fs filesystem.Fs, o Options, d dependencies) (err error) {
+	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.project.sync.rollback")
+	defer span.End(&err)
+
+	logger := d.Logger()
+
+	// Load snapshot
+	file, err := pushsnapshot.Load(ctx, fs)
+	if err != nil {
+		return err
+	}
+	snapshot, found := file.Get(o.PushID)
+	if !found {
+		return errors.Errorf(`snapshot of the push "%s" not found, run "kbc sync rollback" to list available snapshots`, o.PushID)
+	}
+
+	// Load the current remote state of the objects
+	current, err := pushsnapshot.LoadRemote(ctx, d.KeboolaProjectAPI(), snapshot.Objects)
+	if err != nil {
+		return err
+	}
+
+	// Get plan
+	plan := rollback.NewPlan(snapshot, current)
+
+	// Log plan
+	plan.Log(d.Stdout())
+
+	// Warn about remote changes made after the push
+	for _, path := range plan.ChangedAfterPush() {
+		logger.Warnf(ctx, `"%s" has been changed in the remote after the push, the changes will be overwritten by the rollback.`, path)
+	}
+
+	if plan.Empty() {
+		return nil
+	}
+
+	// Dry run?
+	if o.DryRun {
+		logger.Info(ctx, "Dry run, nothing changed.")
+		return nil
+	}
+
+	// Invoke
+	changeDescription := o.ChangeDescription
+	if changeDescription == "" {
+		changeDescription = fmt.Sprintf(`Rollback of the push "%s" from #KeboolaCLI`, snapshot.PushID)
+	}
+	if err := plan.Invoke(ctx, d.KeboolaProjectAPI(), d.StorageAPIToken().Token, changeDescription); err != nil {
+		return err
+	}
+
+	logger.Info(ctx, `Rollback done. Run "kbc sync pull" to update the local directory.`)
+	return nil
+}
+
+// List available snapshots, the latest snapshot is the last one.
+func List(ctx context.Context, fs filesystem.Fs, w io.Writer) error {
+	file, err := pushsnapshot.Load(ctx, fs)
+	if err != nil {
+		return err
+	}
+
+	if len(file.Snapshots) == 0 {
+		fmt.Fprintln(w, "No push snapshot found.")
+		return nil
+	}
+
+	fmt.Fprintln(w, "Push snapshots:")
+	for _, snapshot := range file.Snapshots {
+		fmt.Fprintf(w, "  %s  %s  objects: %d  %s\n", snapshot.PushID, snapshot.Created.Format("2006-01-02 15:04:05"), len(snapshot.Objects), snapshot.ChangeDescription)
+	}
+	return nil
+}
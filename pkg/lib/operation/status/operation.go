@@ -2,16 +2,35 @@ package status
 
 import (
 	"context"
+	"io"
 
 	"github.com/keboola/keboola-as-code/internal/pkg/dbt"
+	"github.com/keboola/keboola-as-code/internal/pkg/diff"
+	"github.com/keboola/keboola-as-code/internal/pkg/encoding/json"
 	"github.com/keboola/keboola-as-code/internal/pkg/filesystem"
 	"github.com/keboola/keboola-as-code/internal/pkg/log"
 	"github.com/keboola/keboola-as-code/internal/pkg/project"
+	"github.com/keboola/keboola-as-code/internal/pkg/project/syncbase"
 	"github.com/keboola/keboola-as-code/internal/pkg/telemetry"
 	"github.com/keboola/keboola-as-code/internal/pkg/template"
 	"github.com/keboola/keboola-as-code/internal/pkg/template/repository"
 )
 
+type Options struct {
+	// OutputFormat is diff.OutputFormatText or diff.OutputFormatJSON, empty means text.
+	OutputFormat string
+}
+
+// Status is a machine-readable form of the status, Type is "project", "template", "repository", "dbtProject" or "none".
+type Status struct {
+	Type         string `json:"type"`
+	Directory    string `json:"directory,omitempty"`
+	WorkingDir   string `json:"workingDir,omitempty"`
+	ManifestPath string `json:"manifestPath,omitempty"`
+	// Conflicts contains files with unresolved conflicts from the pull, only for a project.
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
 type dependencies interface {
 	Fs() filesystem.Fs
 	LocalProject(ctx context.Context, ignoreErrors bool) (*project.Project, bool, error)
@@ -19,23 +38,41 @@ type dependencies interface {
 	LocalTemplateRepository(ctx context.Context) (*repository.Repository, bool, error)
 	LocalDbtProject(ctx context.Context) (*dbt.Project, bool, error)
 	Logger() log.Logger
+	Stdout() io.Writer
 	Telemetry() telemetry.Telemetry
 }
 
-func Run(ctx context.Context, d dependencies) (err error) {
+func Run(ctx context.Context, o Options, d dependencies) (err error) {
 	ctx, span := d.Telemetry().Tracer().Start(ctx, "keboola.go.operation.status")
 	defer span.End(&err)
 
 	logger := d.Logger()
+	jsonOutput := o.OutputFormat == diff.OutputFormatJSON
 
 	if prj, found, err := d.LocalProject(ctx, false); found {
 		if err != nil {
 			return err
 		}
 
+		syncBase, err := syncbase.Load(ctx, prj.Fs())
+		if err != nil {
+			return err
+		}
+		conflicts := syncBase.UnresolvedConflicts(ctx, prj.Fs())
+
+		if jsonOutput {
+			return writeJSON(d, Status{Type: "project", Directory: prj.Fs().BasePath(), WorkingDir: prj.Fs().WorkingDir(), ManifestPath: prj.Manifest().Path(), Conflicts: conflicts})
+		}
+
 		logger.Infof(ctx, "Project directory:  %s", prj.Fs().BasePath())
 		logger.Infof(ctx, "Working directory:  %s", prj.Fs().WorkingDir())
 		logger.Infof(ctx, "Manifest path:      %s", prj.Manifest().Path())
+		if len(conflicts) > 0 {
+			logger.Warn(ctx, "Unresolved conflicts:")
+			for _, path := range conflicts {
+				logger.Warnf(ctx, "  %s", path)
+			}
+		}
 		return nil
 	}
 
@@ -44,6 +81,10 @@ func Run(ctx context.Context, d dependencies) (err error) {
 			return err
 		}
 
+		if jsonOutput {
+			return writeJSON(d, Status{Type: "template", Directory: tmpl.Fs().BasePath(), WorkingDir: tmpl.Fs().WorkingDir(), ManifestPath: tmpl.ManifestPath()})
+		}
+
 		logger.Infof(ctx, "Template directory:  %s", tmpl.Fs().BasePath())
 		logger.Infof(ctx, "Working directory:   %s", tmpl.Fs().WorkingDir())
 		logger.Infof(ctx, "Manifest path:       %s", tmpl.ManifestPath())
@@ -55,6 +96,10 @@ func Run(ctx context.Context, d dependencies) (err error) {
 			return err
 		}
 
+		if jsonOutput {
+			return writeJSON(d, Status{Type: "repository", Directory: repo.Fs().BasePath(), WorkingDir: repo.Fs().WorkingDir(), ManifestPath: repo.Manifest().Path()})
+		}
+
 		logger.Infof(ctx, "Repository directory:  %s", repo.Fs().BasePath())
 		logger.Infof(ctx, "Working directory:     %s", repo.Fs().WorkingDir())
 		logger.Infof(ctx, "Manifest path:         %s", repo.Manifest().Path())
@@ -66,11 +111,27 @@ func Run(ctx context.Context, d dependencies) (err error) {
 			return err
 		}
 
+		if jsonOutput {
+			return writeJSON(d, Status{Type: "dbtProject", Directory: prj.Fs().BasePath(), WorkingDir: prj.Fs().WorkingDir()})
+		}
+
 		logger.Infof(ctx, "Dbt project directory:  %s", prj.Fs().BasePath())
 		logger.Infof(ctx, "Working directory:      %s", prj.Fs().WorkingDir())
 		return nil
 	}
 
 	logger.Warnf(ctx, `Directory "%s" is not a project or template repository.`, d.Fs().BasePath())
+	if jsonOutput {
+		return writeJSON(d, Status{Type: "none", Directory: d.Fs().BasePath()})
+	}
 	return nil
 }
+
+func writeJSON(d dependencies, status Status) error {
+	bytes, err := json.Encode(status, true)
+	if err != nil {
+		return err
+	}
+	_, err = d.Stdout().Write(bytes)
+	return err
+}
@@ -55,7 +55,7 @@ func Run(ctx context.Context, tmpl *template.Template, o Options, d dependencies
 	}
 
 	// Get plan
-	plan, err := pull.NewPlan(results)
+	plan, err := pull.NewPlan(results, nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,13 @@
+# Sum of natural numbers up to num
+
+num = 16
+
+if num < 0:
+    print("Enter a positive number")
+else:
+    sum = 0
+    # use while loop to iterate until zero
+    while (num > 0):
+        sum += num
+        num -= 1
+    print("The total is", sum)
@@ -0,0 +1,3 @@
+# This program prints Hello, world!
+
+print('Hello, world!')
@@ -0,0 +1 @@
+# {{:codes/my-code-1}}
@@ -0,0 +1,3 @@
+num1 = 1.5
+num2 = 6.3
+sum = num1 + num2
@@ -264,8 +264,8 @@ func (ts *testState) startNodes(t *testing.T, ctx context.Context, modifyConfig
 	// Start nodes
 	ts.logSection(t, "starting nodes")
 	require.NoError(t, api.Start(ctx, ts.apiScp, ts.apiMock.TestConfig()))
-	require.NoError(t, httpsource.Start(ctx, ts.sourceScp1, ts.sourceMock1.TestConfig().Source.HTTP))
-	require.NoError(t, httpsource.Start(ctx, ts.sourceScp2, ts.sourceMock2.TestConfig().Source.HTTP))
+	require.NoError(t, httpsource.Start(ctx, ts.sourceScp1, ts.sourceMock1.TestConfig().Source.HTTP, ts.sourceMock1.TestConfig().Source.RateLimit))
+	require.NoError(t, httpsource.Start(ctx, ts.sourceScp2, ts.sourceMock2.TestConfig().Source.HTTP, ts.sourceMock2.TestConfig().Source.RateLimit))
 	require.NoError(t, writernode.Start(ctx, ts.writerScp1, ts.writerMock1.TestConfig()))
 	require.NoError(t, writernode.Start(ctx, ts.writerScp2, ts.writerMock2.TestConfig()))
 	require.NoError(t, readernode.Start(ctx, ts.readerScp1, ts.readerMock1.TestConfig()))